@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/openfoundry/oms/internal/config"
+	"github.com/openfoundry/oms/internal/infrastructure/database"
+	"github.com/openfoundry/oms/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up        Apply all pending migrations
+  down N    Roll back the last N applied migrations
+  status    List migrations and whether they are applied
+
+Migrations are read from DB_MIGRATION_DIR.`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// Initialize logger
+	logger, err := logger.NewLogger()
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	// Load configuration
+	cfg, err := config.LoadDatabaseConfig()
+	if err != nil {
+		logger.Fatal("Failed to load configuration", zap.Error(err))
+	}
+
+	// Initialize database
+	db, err := database.NewPostgresDB(*cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize database", zap.Error(err))
+	}
+	defer db.Close()
+
+	migrator := database.NewMigrator(db, cfg.MigrationDirectory, logger)
+	if err := run(context.Background(), migrator, os.Args[1:]); err != nil {
+		logger.Error("Migration command failed", zap.Error(err))
+		db.Close()
+		os.Exit(1)
+	}
+}
+
+// run executes the migration command named by args
+func run(ctx context.Context, migrator *database.Migrator, args []string) error {
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		fmt.Printf("Applied %d migration(s)\n", len(applied))
+		if err != nil {
+			return err
+		}
+		return printVersion(ctx, migrator)
+
+	case "down":
+		if len(args) < 2 {
+			return fmt.Errorf("down requires the number of migrations to roll back\n%s", usage)
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid number of migrations: %s", args[1])
+		}
+		rolledBack, err := migrator.Down(ctx, n)
+		fmt.Printf("Rolled back %d migration(s)\n", len(rolledBack))
+		if err != nil {
+			return err
+		}
+		return printVersion(ctx, migrator)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%06d  %-8s %s\n", status.Version, state, status.Name)
+		}
+		return printVersion(ctx, migrator)
+
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
+
+// printVersion reports the applied schema version
+func printVersion(ctx context.Context, migrator *database.Migrator) error {
+	version, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		fmt.Printf("Schema version: %d (dirty)\n", version)
+		return nil
+	}
+	fmt.Printf("Schema version: %d\n", version)
+	return nil
+}
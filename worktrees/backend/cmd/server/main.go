@@ -2,20 +2,36 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/openfoundry/oms/internal/config"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	domainrepo "github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
 	"github.com/openfoundry/oms/internal/infrastructure/database"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/openfoundry/oms/internal/infrastructure/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/tracing"
 	"github.com/openfoundry/oms/internal/interfaces/rest"
 	"github.com/openfoundry/oms/internal/pkg/logger"
+	"github.com/openfoundry/oms/internal/pkg/validator"
+	"go.uber.org/zap"
 )
 
+// shutdownTimeout bounds the whole shutdown sequence
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	// Initialize logger
 	logger, err := logger.NewLogger()
@@ -29,18 +45,75 @@ func main() {
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		logger.Fatal("Failed to load configuration", "error", err)
+		logger.Fatal("Failed to load configuration", zap.Error(err))
+	}
+	entity.MaxProperties = cfg.Server.MaxProperties
+	entity.UniquePropertyDisplayNames = cfg.Server.UniquePropertyDisplayNames
+	validator.NameCasePolicy = cfg.Server.NameCasePolicy
+	entity.MaxPatternLength = cfg.Security.RegexMaxPatternLength
+	entity.PatternMatchTimeout = cfg.Security.RegexMatchTimeout
+
+	// Sign pagination cursors, with the JWT secret unless a key of their own is set
+	cursorSigningKey := cfg.Security.CursorSigningKey
+	if cursorSigningKey == "" {
+		cursorSigningKey = cfg.Security.JWTSecret
+	}
+	cursors := domainrepo.NewCursorCodec([]byte(cursorSigningKey), cfg.Security.CursorTTL, cfg.Security.CursorAcceptUnsigned)
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.NewTracerProvider(context.Background(), cfg.Metrics, "oms")
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
 	}
 
 	// Initialize database
 	db, err := database.NewPostgresDB(cfg.Database)
 	if err != nil {
-		logger.Fatal("Failed to initialize database", "error", err)
+		logger.Fatal("Failed to initialize database", zap.Error(err))
 	}
 	defer db.Close()
 
+	// Route object and link type reads to the read replica, if configured
+	var replica *sql.DB
+	if cfg.Database.ReplicaDSN != "" {
+		replica, err = database.NewPostgresReplicaDB(cfg.Database)
+		if err != nil {
+			logger.Fatal("Failed to initialize read replica", zap.Error(err))
+		}
+		defer replica.Close()
+	}
+	dbs := database.NewReadWriteSplitter(db, replica, cfg.Database.ReadYourWritesWindow)
+
+	// Initialize metrics
+	m := metrics.NewMetrics(metrics.NewDefaultRegistry())
+
+	// Initialize cache
+	redisCache, err := cache.NewRedisCache(fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		cfg.Redis.Password, cfg.Redis.DB, cfg.Redis.TTL, m, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize cache", zap.Error(err))
+	}
+	defer redisCache.Close()
+
+	// Initialize messaging
+	// Events are stored before publishing so they can be replayed
+	eventStore := repository.NewPostgresEventStore(db)
+	publisher := messaging.NewStoringPublisher(
+		messaging.NewKafkaPublisher(cfg.Kafka.Brokers, cfg.Kafka.Topic, logger), eventStore, logger)
+	consumer := messaging.NewKafkaConsumer(cfg.Kafka.Brokers, cfg.Kafka.Topic, cfg.Kafka.GroupID,
+		messaging.NewConsumerConfig(cfg.Kafka), m, logger)
+	broker := messaging.NewSubscriptionBroker(messaging.DefaultSubscriptionBuffer, logger)
+	broker.Attach(consumer)
+
+	// Webhooks read on a consumer group of their own so they see every event too
+	webhookConsumer := messaging.NewKafkaConsumer(cfg.Kafka.Brokers, cfg.Kafka.Topic, cfg.Webhook.GroupID,
+		messaging.NewConsumerConfig(cfg.Kafka), m, logger)
+	webhooks := messaging.NewWebhookDispatcher(repository.NewPostgresWebhookSubscriptionRepository(db),
+		messaging.NewWebhookConfig(cfg.Webhook), m, logger)
+	webhooks.Attach(webhookConsumer)
+
 	// Initialize router
-	router := rest.NewRouter(cfg, db, logger)
+	router := rest.NewRouter(cfg, db, redisCache, m, logger)
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -51,11 +124,69 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Start background workers; cancelling workerCtx asks them to stop
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		if err := consumer.Start(workerCtx); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error("Kafka consumer stopped", zap.Error(err))
+		}
+	}()
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		if err := webhookConsumer.Start(workerCtx); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error("Webhook consumer stopped", zap.Error(err))
+		}
+	}()
+
+	// Purge definitions soft-deleted longer ago than the retention period
+	if cfg.Database.SoftDeleteRetention > 0 {
+		retention := service.NewRetentionService(
+			repository.NewPostgresObjectTypeRepository(dbs, cursors, cfg.Database.StatementTimeout, logger),
+			repository.NewPostgresLinkTypeRepository(dbs, cursors, cfg.Database.StatementTimeout),
+			publisher,
+			service.RetentionConfig{
+				Retention: cfg.Database.SoftDeleteRetention,
+				Interval:  cfg.Database.PurgeInterval,
+				DryRun:    cfg.Database.PurgeDryRun,
+			},
+			logger)
+
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			_ = retention.Run(workerCtx)
+		}()
+	}
+
+	// Warm the cache so the first requests after a cold start do not all miss
+	if cfg.Redis.WarmOnStart {
+		warmer := service.NewCacheWarmer(
+			repository.NewPostgresObjectTypeRepository(dbs, cursors, cfg.Database.StatementTimeout, logger),
+			repository.NewPostgresLinkTypeRepository(dbs, cursors, cfg.Database.StatementTimeout),
+			cursors, redisCache, service.NewCacheTTLs(cfg.Redis),
+			service.CacheWarmConfig{Concurrency: cfg.Redis.WarmConcurrency, Rate: cfg.Redis.WarmRate},
+			logger)
+
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			// Failures are logged by the warmer; the cache fills on demand instead
+			_, _ = warmer.Warm(workerCtx)
+		}()
+	}
+
 	// Start server in a goroutine
 	go func() {
-		logger.Info("Server starting", "port", cfg.Server.Port)
+		logger.Info("Server starting", zap.Int("port", cfg.Server.Port))
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Fatal("Failed to start server", "error", err)
+			logger.Fatal("Failed to start server", zap.Error(err))
 		}
 	}()
 
@@ -67,12 +198,52 @@ func main() {
 	logger.Info("Shutting down server...")
 
 	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
+	// Stop accepting requests first so no new events are produced
 	if err := srv.Shutdown(ctx); err != nil {
-		logger.Fatal("Server forced to shutdown", "error", err)
+		logger.Error("Server forced to shutdown", zap.Error(err))
+	}
+
+	// Let workers finish the message they are handling
+	stopWorkers()
+	if err := waitGroup(ctx, &workers); err != nil {
+		logger.Warn("Background workers did not stop in time", zap.Error(err))
+	}
+
+	if err := consumer.Close(); err != nil {
+		logger.Error("Failed to close Kafka consumer", zap.Error(err))
+	}
+	if err := webhookConsumer.Close(); err != nil {
+		logger.Error("Failed to close webhook consumer", zap.Error(err))
+	}
+
+	// Flush pending events
+	if err := publisher.Close(); err != nil {
+		logger.Error("Failed to close Kafka publisher", zap.Error(err))
+	}
+
+	// Flush pending spans
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Failed to shut down tracing", zap.Error(err))
 	}
 
 	logger.Info("Server exited")
-}
\ No newline at end of file
+}
+
+// waitGroup waits for wg to finish, giving up when ctx is done
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
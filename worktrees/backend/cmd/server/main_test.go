@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWaitGroupReturnsOnlyAfterWorkersExit(t *testing.T) {
+	// Arrange
+	var workers sync.WaitGroup
+	exited := false
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		time.Sleep(20 * time.Millisecond)
+		exited = true
+	}()
+
+	// Act
+	err := waitGroup(context.Background(), &workers)
+
+	// Assert
+	if err != nil || !exited {
+		t.Errorf("waitGroup = %v with worker exited = %v, want nil after the worker exits", err, exited)
+	}
+}
+
+func TestWaitGroupGivesUpAtShutdownTimeout(t *testing.T) {
+	// Arrange
+	var workers sync.WaitGroup
+	workers.Add(1)
+	defer workers.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := waitGroup(ctx, &workers)
+
+	// Assert
+	if err != context.DeadlineExceeded {
+		t.Errorf("waitGroup = %v, want %v for a worker that never exits", err, context.DeadlineExceeded)
+	}
+}
@@ -3,9 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/openfoundry/oms/internal/pkg/validator"
 )
 
 type Config struct {
@@ -13,6 +15,7 @@ type Config struct {
 	Database DatabaseConfig
 	Redis    RedisConfig
 	Kafka    KafkaConfig
+	Webhook  WebhookConfig
 	Security SecurityConfig
 	Metrics  MetricsConfig
 }
@@ -23,6 +26,22 @@ type ServerConfig struct {
 	GRPCPort    int           `envconfig:"GRPC_PORT" default:"9090"`
 	MetricsPort int           `envconfig:"METRICS_PORT" default:"9091"`
 	Timeout     time.Duration `envconfig:"SERVER_TIMEOUT" default:"30s"`
+	// MaxProperties limits the properties of one object or link type; 0 disables the limit
+	MaxProperties int `envconfig:"MAX_PROPERTIES" default:"500"`
+	// UniquePropertyDisplayNames rejects object and link types whose
+	// properties share a display name
+	UniquePropertyDisplayNames bool `envconfig:"UNIQUE_PROPERTY_DISPLAY_NAMES" default:"false"`
+	// NameCasePolicy is one of validator.NameCasePolicies and constrains the
+	// case of object type and property names
+	NameCasePolicy string `envconfig:"NAME_CASE_POLICY" default:"any"`
+	// IdempotencyTTL is how long responses to requests with an
+	// Idempotency-Key header are kept for replay
+	IdempotencyTTL time.Duration `envconfig:"IDEMPOTENCY_TTL" default:"24h"`
+	// Compression gzips or deflates API responses of at least
+	// CompressionMinSize bytes for clients that accept it
+	CompressionEnabled bool `envconfig:"COMPRESSION_ENABLED" default:"true"`
+	CompressionMinSize int  `envconfig:"COMPRESSION_MIN_SIZE" default:"1024"`
+	CompressionLevel   int  `envconfig:"COMPRESSION_LEVEL" default:"-1"`
 }
 
 type DatabaseConfig struct {
@@ -37,6 +56,25 @@ type DatabaseConfig struct {
 	ConnMaxLifetime    time.Duration `envconfig:"DB_CONN_MAX_LIFETIME" default:"5m"`
 	ConnMaxIdleTime    time.Duration `envconfig:"DB_CONN_MAX_IDLE_TIME" default:"30m"`
 	MigrationDirectory string        `envconfig:"DB_MIGRATION_DIR" default:"./migrations"`
+	// SlowQueryThreshold is the repository operation latency at which a
+	// warning is logged; 0 disables slow query logging
+	SlowQueryThreshold time.Duration `envconfig:"DB_SLOW_QUERY_THRESHOLD" default:"500ms"`
+	// StatementTimeout is how long PostgreSQL lets a list, count or search
+	// query run before cancelling it; 0 disables the timeout
+	StatementTimeout time.Duration `envconfig:"DB_STATEMENT_TIMEOUT" default:"10s"`
+	// ReplicaDSN is the connection string of a read replica serving object
+	// and link type reads; empty sends every query to the primary
+	ReplicaDSN string `envconfig:"DB_REPLICA_DSN"`
+	// ReadYourWritesWindow keeps a user's reads on the primary for this long
+	// after each of their writes; 0 always reads from the replica
+	ReadYourWritesWindow time.Duration `envconfig:"DB_READ_YOUR_WRITES_WINDOW" default:"0s"`
+	// SoftDeleteRetention is how long soft-deleted object and link types are
+	// kept before a background job purges them; 0 keeps them forever
+	SoftDeleteRetention time.Duration `envconfig:"DB_SOFT_DELETE_RETENTION" default:"0s"`
+	// PurgeInterval is the time between purge runs
+	PurgeInterval time.Duration `envconfig:"DB_PURGE_INTERVAL" default:"1h"`
+	// PurgeDryRun logs and announces what would be purged without deleting it
+	PurgeDryRun bool `envconfig:"DB_PURGE_DRY_RUN" default:"false"`
 }
 
 type RedisConfig struct {
@@ -45,12 +83,38 @@ type RedisConfig struct {
 	Password string        `envconfig:"REDIS_PASSWORD"`
 	DB       int           `envconfig:"REDIS_DB" default:"0"`
 	TTL      time.Duration `envconfig:"REDIS_TTL" default:"5m"`
+	// Per-category TTLs; the entity TTLs fall back to TTL when unset
+	ObjectTypeTTL   time.Duration `envconfig:"REDIS_OBJECT_TYPE_TTL"`
+	LinkTypeTTL     time.Duration `envconfig:"REDIS_LINK_TYPE_TTL"`
+	SearchTTL       time.Duration `envconfig:"REDIS_SEARCH_TTL" default:"2m"`
+	LinkTypeListTTL time.Duration `envconfig:"REDIS_LINK_TYPE_LIST_TTL" default:"5m"`
+	// Cache warming loads every object and link type at startup when
+	// WarmOnStart is set; WarmRate caps entities per second, 0 disables the cap
+	WarmOnStart     bool    `envconfig:"CACHE_WARM_ON_START" default:"false"`
+	WarmConcurrency int     `envconfig:"CACHE_WARM_CONCURRENCY" default:"4"`
+	WarmRate        float64 `envconfig:"CACHE_WARM_RATE" default:"200"`
 }
 
 type KafkaConfig struct {
 	Brokers []string `envconfig:"KAFKA_BROKERS" default:"localhost:9092"`
 	Topic   string   `envconfig:"KAFKA_TOPIC" default:"oms-events"`
 	GroupID string   `envconfig:"KAFKA_GROUP_ID" default:"oms-service"`
+
+	MaxRetries      int           `envconfig:"KAFKA_MAX_RETRIES" default:"3"`
+	RetryBackoff    time.Duration `envconfig:"KAFKA_RETRY_BACKOFF" default:"500ms"`
+	MaxRetryBackoff time.Duration `envconfig:"KAFKA_MAX_RETRY_BACKOFF" default:"30s"`
+	DeadLetterTopic string        `envconfig:"KAFKA_DEAD_LETTER_TOPIC" default:"oms-events.dlq"`
+}
+
+// WebhookConfig controls delivery of change events to webhook subscriptions
+type WebhookConfig struct {
+	// GroupID is the Kafka consumer group the dispatcher reads change events with;
+	// it must differ from KAFKA_GROUP_ID so both consumers see every event
+	GroupID         string        `envconfig:"WEBHOOK_GROUP_ID" default:"oms-webhooks"`
+	Timeout         time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+	MaxRetries      int           `envconfig:"WEBHOOK_MAX_RETRIES" default:"3"`
+	RetryBackoff    time.Duration `envconfig:"WEBHOOK_RETRY_BACKOFF" default:"1s"`
+	MaxRetryBackoff time.Duration `envconfig:"WEBHOOK_MAX_RETRY_BACKOFF" default:"30s"`
 }
 
 type SecurityConfig struct {
@@ -58,11 +122,37 @@ type SecurityConfig struct {
 	APIKeyHeader   string `envconfig:"API_KEY_HEADER" default:"X-API-Key"`
 	AllowedOrigins string `envconfig:"ALLOWED_ORIGINS" default:"*"`
 	TLSEnabled     bool   `envconfig:"TLS_ENABLED" default:"false"`
+	// CORS methods and headers are comma-separated; credentials are never
+	// allowed for origins admitted by "*"
+	CORSAllowedMethods   string `envconfig:"CORS_ALLOWED_METHODS" default:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`
+	CORSAllowedHeaders   string `envconfig:"CORS_ALLOWED_HEADERS" default:"Content-Type,Authorization,X-API-Key,If-Match,X-Correlation-ID"`
+	CORSAllowCredentials bool   `envconfig:"CORS_ALLOW_CREDENTIALS" default:"true"`
+	// RateLimitRPS is the sustained requests per second allowed per client; 0 disables rate limiting
+	RateLimitRPS   float64 `envconfig:"RATE_LIMIT_RPS" default:"20"`
+	RateLimitBurst int     `envconfig:"RATE_LIMIT_BURST" default:"40"`
+	// JWTIssuer and JWTAudience are checked against the iss and aud claims of
+	// bearer tokens when set
+	JWTIssuer   string `envconfig:"JWT_ISSUER"`
+	JWTAudience string `envconfig:"JWT_AUDIENCE"`
+	// JWTJWKSURL switches bearer token verification from HS256 with JWTSecret
+	// to RS256 with the keys published at this URL
+	JWTJWKSURL             string        `envconfig:"JWT_JWKS_URL"`
+	JWTJWKSRefreshInterval time.Duration `envconfig:"JWT_JWKS_REFRESH_INTERVAL" default:"1h"`
+	// CursorSigningKey signs pagination cursors; JWTSecret is used when
+	// empty. CursorAcceptUnsigned keeps accepting cursors issued before
+	// signing was rolled out.
+	CursorSigningKey     string        `envconfig:"CURSOR_SIGNING_KEY"`
+	CursorTTL            time.Duration `envconfig:"CURSOR_TTL" default:"24h"`
+	CursorAcceptUnsigned bool          `envconfig:"CURSOR_ACCEPT_UNSIGNED" default:"true"`
+	// RegexMaxPatternLength bounds pattern validator regexes (0 disables the
+	// limit) and RegexMatchTimeout bounds matching a value against one
+	RegexMaxPatternLength int           `envconfig:"REGEX_MAX_PATTERN_LENGTH" default:"1000"`
+	RegexMatchTimeout     time.Duration `envconfig:"REGEX_MATCH_TIMEOUT" default:"100ms"`
 }
 
 type MetricsConfig struct {
 	Path          string `envconfig:"METRICS_PATH" default:"/metrics"`
-	TraceEndpoint string `envconfig:"TRACE_ENDPOINT" default:"http://jaeger:14268/api/traces"`
+	TraceEndpoint string `envconfig:"TRACE_ENDPOINT" default:"http://jaeger:4318/v1/traces"`
 	Enabled       bool   `envconfig:"METRICS_ENABLED" default:"true"`
 }
 
@@ -83,6 +173,22 @@ func LoadConfig() (*Config, error) {
 	return &cfg, nil
 }
 
+// LoadDatabaseConfig loads only the database settings, for tools such as the
+// migration command that do not need the rest of the service configuration
+func LoadDatabaseConfig() (*DatabaseConfig, error) {
+	var cfg DatabaseConfig
+
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, fmt.Errorf("failed to load database config: %w", err)
+	}
+
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("invalid configuration: database host is required")
+	}
+
+	return &cfg, nil
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	if c.Server.Port <= 0 || c.Server.Port > 65535 {
@@ -101,6 +207,54 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT secret is required")
 	}
 
+	if c.Server.MaxProperties < 0 {
+		return fmt.Errorf("max properties must not be negative: %d", c.Server.MaxProperties)
+	}
+
+	if c.Security.RegexMaxPatternLength < 0 {
+		return fmt.Errorf("regex max pattern length must not be negative: %d", c.Security.RegexMaxPatternLength)
+	}
+
+	if c.Server.CompressionLevel < -1 || c.Server.CompressionLevel > 9 {
+		return fmt.Errorf("compression level must be between -1 and 9: %d", c.Server.CompressionLevel)
+	}
+
+	if !slices.Contains(validator.NameCasePolicies, c.Server.NameCasePolicy) {
+		return fmt.Errorf("name case policy must be one of %v: %q", validator.NameCasePolicies, c.Server.NameCasePolicy)
+	}
+
+	if c.Security.RateLimitRPS > 0 && c.Security.RateLimitBurst < 1 {
+		return fmt.Errorf("rate limit burst must be at least 1: %d", c.Security.RateLimitBurst)
+	}
+
+	if c.Database.ReadYourWritesWindow < 0 {
+		return fmt.Errorf("read your writes window must not be negative: %v", c.Database.ReadYourWritesWindow)
+	}
+
+	if c.Database.SoftDeleteRetention < 0 {
+		return fmt.Errorf("soft delete retention must not be negative: %v", c.Database.SoftDeleteRetention)
+	}
+
+	if c.Database.SoftDeleteRetention > 0 && c.Database.PurgeInterval <= 0 {
+		return fmt.Errorf("purge interval must be positive: %v", c.Database.PurgeInterval)
+	}
+
+	if c.Redis.WarmConcurrency < 1 {
+		return fmt.Errorf("cache warm concurrency must be at least 1: %d", c.Redis.WarmConcurrency)
+	}
+
+	if c.Redis.WarmRate < 0 {
+		return fmt.Errorf("cache warm rate must not be negative: %v", c.Redis.WarmRate)
+	}
+
+	if c.Webhook.GroupID == "" || c.Webhook.GroupID == c.Kafka.GroupID {
+		return fmt.Errorf("webhook group ID must be set and differ from the Kafka group ID: %q", c.Webhook.GroupID)
+	}
+
+	if c.Webhook.MaxRetries < 0 {
+		return fmt.Errorf("webhook max retries must not be negative: %d", c.Webhook.MaxRetries)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey authenticates a service principal acting in TenantID. Only the
+// SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	KeyHash     string     `json:"-"`
+	Principal   string     `json:"principal"`
+	TenantID    string     `json:"tenantId"`
+	Roles       []string   `json:"roles"`
+	Permissions []string   `json:"permissions"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+}
+
+// IsRevoked reports whether the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil && !k.RevokedAt.After(time.Now())
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 hash under which a key is stored
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
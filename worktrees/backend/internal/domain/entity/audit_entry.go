@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited entity types
+const (
+	AuditEntityObjectType = "object_type"
+	AuditEntityLinkType   = "link_type"
+)
+
+// Audited actions
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	// AuditActionRestore brings back a soft-deleted entity
+	AuditActionRestore = "restore"
+	// AuditActionPurge removes a soft-deleted entity permanently
+	AuditActionPurge = "purge"
+)
+
+// AuditEntry records one mutation of an entity. OldValue is nil for creates
+// and NewValue is nil for deletes.
+type AuditEntry struct {
+	ID            uuid.UUID   `json:"id"`
+	TenantID      string      `json:"tenantId"`
+	EntityType    string      `json:"entityType"`
+	EntityID      uuid.UUID   `json:"entityId"`
+	Action        string      `json:"action"`
+	Actor         string      `json:"actor"`
+	OldValue      interface{} `json:"oldValue,omitempty"`
+	NewValue      interface{} `json:"newValue,omitempty"`
+	CorrelationID string      `json:"correlationId,omitempty"`
+	CreatedAt     time.Time   `json:"createdAt"`
+}
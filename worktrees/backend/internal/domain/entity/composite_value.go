@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateGeoPoint checks that value is a {lat, lng} object with coordinates in range
+func validateGeoPoint(value interface{}) error {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("geopoint must be an object with lat and lng")
+	}
+
+	lat, ok := toFloat64(obj["lat"])
+	if !ok {
+		return fmt.Errorf("geopoint lat must be a number")
+	}
+	lng, ok := toFloat64(obj["lng"])
+	if !ok {
+		return fmt.Errorf("geopoint lng must be a number")
+	}
+
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("geopoint lat %v is out of range [-90, 90]", lat)
+	}
+	if lng < -180 || lng > 180 {
+		return fmt.Errorf("geopoint lng %v is out of range [-180, 180]", lng)
+	}
+
+	for key := range obj {
+		if key != "lat" && key != "lng" {
+			return fmt.Errorf("geopoint has unexpected field %s", key)
+		}
+	}
+
+	return nil
+}
+
+// validateCurrency checks that value is an {amount, currencyCode} object with
+// an ISO 4217 currency code
+func validateCurrency(value interface{}) error {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("currency must be an object with amount and currencyCode")
+	}
+
+	if _, ok := toFloat64(obj["amount"]); !ok {
+		return fmt.Errorf("currency amount must be a number")
+	}
+
+	code, ok := obj["currencyCode"].(string)
+	if !ok {
+		return fmt.Errorf("currency currencyCode must be a string")
+	}
+	if !isValidCurrencyCode(code) {
+		return fmt.Errorf("invalid ISO 4217 currency code: %s", code)
+	}
+
+	for key := range obj {
+		if key != "amount" && key != "currencyCode" {
+			return fmt.Errorf("currency has unexpected field %s", key)
+		}
+	}
+
+	return nil
+}
+
+// isValidCurrencyCode reports whether code is an active ISO 4217 currency code
+func isValidCurrencyCode(code string) bool {
+	if len(code) != 3 || strings.ToUpper(code) != code {
+		return false
+	}
+	return iso4217Codes[code]
+}
+
+// iso4217Codes lists the active ISO 4217 alphabetic currency codes
+var iso4217Codes = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true,
+	"ARS": true, "AUD": true, "AWG": true, "AZN": true, "BAM": true, "BBD": true,
+	"BDT": true, "BGN": true, "BHD": true, "BIF": true, "BMD": true, "BND": true,
+	"BOB": true, "BOV": true, "BRL": true, "BSD": true, "BTN": true, "BWP": true,
+	"BYN": true, "BZD": true, "CAD": true, "CDF": true, "CHE": true, "CHF": true,
+	"CHW": true, "CLF": true, "CLP": true, "CNY": true, "COP": true, "COU": true,
+	"CRC": true, "CUP": true, "CVE": true, "CZK": true, "DJF": true, "DKK": true,
+	"DOP": true, "DZD": true, "EGP": true, "ERN": true, "ETB": true, "EUR": true,
+	"FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true, "GIP": true,
+	"GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true, "HNL": true,
+	"HTG": true, "HUF": true, "IDR": true, "ILS": true, "INR": true, "IQD": true,
+	"IRR": true, "ISK": true, "JMD": true, "JOD": true, "JPY": true, "KES": true,
+	"KGS": true, "KHR": true, "KMF": true, "KPW": true, "KRW": true, "KWD": true,
+	"KYD": true, "KZT": true, "LAK": true, "LBP": true, "LKR": true, "LRD": true,
+	"LSL": true, "LYD": true, "MAD": true, "MDL": true, "MGA": true, "MKD": true,
+	"MMK": true, "MNT": true, "MOP": true, "MRU": true, "MUR": true, "MVR": true,
+	"MWK": true, "MXN": true, "MXV": true, "MYR": true, "MZN": true, "NAD": true,
+	"NGN": true, "NIO": true, "NOK": true, "NPR": true, "NZD": true, "OMR": true,
+	"PAB": true, "PEN": true, "PGK": true, "PHP": true, "PKR": true, "PLN": true,
+	"PYG": true, "QAR": true, "RON": true, "RSD": true, "RUB": true, "RWF": true,
+	"SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true, "SGD": true,
+	"SHP": true, "SLE": true, "SOS": true, "SRD": true, "SSP": true, "STN": true,
+	"SVC": true, "SYP": true, "SZL": true, "THB": true, "TJS": true, "TMT": true,
+	"TND": true, "TOP": true, "TRY": true, "TTD": true, "TWD": true, "TZS": true,
+	"UAH": true, "UGX": true, "USD": true, "USN": true, "UYI": true, "UYU": true,
+	"UYW": true, "UZS": true, "VED": true, "VES": true, "VND": true, "VUV": true,
+	"WST": true, "XAF": true, "XCD": true, "XOF": true, "XPF": true, "YER": true,
+	"ZAR": true, "ZMW": true, "ZWG": true,
+}
@@ -0,0 +1,135 @@
+package entity
+
+import "testing"
+
+func geoPoint(lat, lng float64) map[string]interface{} {
+	return map[string]interface{}{"lat": lat, "lng": lng}
+}
+
+func currency(amount float64, code string) map[string]interface{} {
+	return map[string]interface{}{"amount": amount, "currencyCode": code}
+}
+
+func TestGeoPointAcceptsBoundaryCoordinates(t *testing.T) {
+	// Arrange
+	points := []map[string]interface{}{
+		geoPoint(90, 180), geoPoint(-90, -180), geoPoint(90, -180), geoPoint(-90, 180),
+	}
+
+	for _, point := range points {
+		// Act
+		err := validateGeoPoint(point)
+
+		// Assert
+		if err != nil {
+			t.Errorf("validateGeoPoint(%v) = %v, want nil", point, err)
+		}
+	}
+}
+
+func TestGeoPointRejectsLatitudeOutOfRange(t *testing.T) {
+	// Arrange
+	points := []map[string]interface{}{geoPoint(90.000001, 0), geoPoint(-90.000001, 0)}
+
+	for _, point := range points {
+		// Act
+		err := validateGeoPoint(point)
+
+		// Assert
+		if err == nil {
+			t.Errorf("validateGeoPoint(%v) = nil, want an error", point)
+		}
+	}
+}
+
+func TestGeoPointRejectsLongitudeOutOfRange(t *testing.T) {
+	// Arrange
+	points := []map[string]interface{}{geoPoint(0, 180.000001), geoPoint(0, -180.000001)}
+
+	for _, point := range points {
+		// Act
+		err := validateGeoPoint(point)
+
+		// Assert
+		if err == nil {
+			t.Errorf("validateGeoPoint(%v) = nil, want an error", point)
+		}
+	}
+}
+
+func TestGeoPointRejectsMissingCoordinate(t *testing.T) {
+	// Arrange
+	point := map[string]interface{}{"lat": 10.0}
+
+	// Act
+	err := validateGeoPoint(point)
+
+	// Assert
+	if err == nil {
+		t.Error("validateGeoPoint without lng = nil, want an error")
+	}
+}
+
+func TestCurrencyAcceptsISO4217Code(t *testing.T) {
+	// Arrange
+	value := currency(12.5, "EUR")
+
+	// Act
+	err := validateCurrency(value)
+
+	// Assert
+	if err != nil {
+		t.Errorf("validateCurrency(%v) = %v, want nil", value, err)
+	}
+}
+
+func TestCurrencyRejectsInvalidCodes(t *testing.T) {
+	// Arrange
+	codes := []string{"usd", "XYZ", "US", "USDD", ""}
+
+	for _, code := range codes {
+		// Act
+		err := validateCurrency(currency(1, code))
+
+		// Assert
+		if err == nil {
+			t.Errorf("validateCurrency with code %q = nil, want an error", code)
+		}
+	}
+}
+
+func TestPropertyValidateRejectsMalformedGeoPointDefault(t *testing.T) {
+	// Arrange
+	property := &Property{
+		Name:         "location",
+		DisplayName:  "Location",
+		DataType:     DataTypeGeoPoint,
+		DefaultValue: geoPoint(91, 0),
+	}
+
+	// Act
+	err := property.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted a default latitude of 91")
+	}
+}
+
+func TestPropertyValidateRejectsInvalidCurrencyDefault(t *testing.T) {
+	// Arrange
+	property := &Property{
+		Name:         "price",
+		DisplayName:  "Price",
+		DataType:     DataTypeCurrency,
+		DefaultValue: currency(10, "ABC"),
+	}
+
+	// Act
+	err := property.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted the currency code ABC")
+	}
+}
@@ -3,6 +3,9 @@ package entity
 import (
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
 // Domain errors
@@ -11,20 +14,46 @@ var (
 	ErrObjectTypeNotFound   = errors.New("object type not found")
 	ErrObjectTypeNameExists = errors.New("object type name already exists")
 	ErrInvalidObjectType    = errors.New("invalid object type")
+	ErrVersionNotFound      = errors.New("object type version not found")
+	ErrObjectTypeInUse      = errors.New("object type is referenced by link types")
+	ErrObjectTypeProtected  = errors.New("object type is protected from deletion by link types")
+	ErrInheritanceCycle     = errors.New("object type inheritance cycle detected")
+	ErrInheritanceConflict  = errors.New("inherited property data type conflict")
+	ErrInvalidInheritance   = errors.New("invalid object type inheritance")
+	ErrObjectTypeNotDeleted = errors.New("object type must be soft deleted before it can be purged")
+	ErrParentDeleted        = errors.New("parent object type is deleted")
+	ErrBatchFailed          = errors.New("batch operation failed")
 	
 	// Property errors
 	ErrPropertyNotFound          = errors.New("property not found")
 	ErrInvalidPropertyNameFormat = errors.New("property name must start with lowercase letter and contain only alphanumeric and underscore")
+	ErrInvalidPropertyOrder      = errors.New("property order must list every property exactly once")
+	ErrReferencedTypeMissing     = errors.New("referenced object type does not exist or is deleted")
+	ErrTooManyProperties         = errors.New("too many properties")
+	ErrUnsafePattern             = errors.New("regex pattern is too long or complex")
+	ErrPatternTimeout            = errors.New("regex pattern match timed out")
 	
 	// Link Type errors
 	ErrLinkTypeNotFound   = errors.New("link type not found")
 	ErrLinkTypeNameExists = errors.New("link type name already exists")
 	ErrCircularReference  = errors.New("circular reference detected")
+	ErrInvalidTraversalDirection = errors.New("traversal direction must be outgoing, incoming or both")
+	ErrConflictingLinkConstraints = errors.New("link type cannot both cascade and prevent deletes")
+	
+	// API key errors
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	
+	// Webhook errors
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrInvalidWebhookURL           = errors.New("webhook url must be an absolute http or https url")
 	
 	// General validation errors
 	ErrInvalidName       = errors.New("name is required")
 	ErrInvalidNameFormat = errors.New("name must start with letter and contain only alphanumeric and underscore")
 	ErrRequiredFieldMissing = errors.New("required field is missing")
+	ErrInvalidMetadata      = errors.New("invalid metadata")
+	// ErrValidationFailed wraps the error of a definition failing validation
+	ErrValidationFailed = errors.New("validation failed")
 )
 
 // ErrRequiredField returns an error for a missing required field
@@ -32,13 +61,60 @@ func ErrRequiredField(fieldName string) error {
 	return fmt.Errorf("%w: %s", ErrRequiredFieldMissing, fieldName)
 }
 
+// ErrObjectTypeInUseBy returns an error listing the link types blocking a deletion
+func ErrObjectTypeInUseBy(linkTypeNames []string) error {
+	return fmt.Errorf("%w: %s", ErrObjectTypeInUse, strings.Join(linkTypeNames, ", "))
+}
+
+// ErrObjectTypeProtectedBy returns an error listing the link types preventing a deletion
+func ErrObjectTypeProtectedBy(linkTypeNames []string) error {
+	return fmt.Errorf("%w: %s", ErrObjectTypeProtected, strings.Join(linkTypeNames, ", "))
+}
+
+// ErrCircularReferencePath returns an error describing the cycle a link type would close
+func ErrCircularReferencePath(path []uuid.UUID) error {
+	ids := make([]string, len(path))
+	for i, id := range path {
+		ids[i] = id.String()
+	}
+	return fmt.Errorf("%w: %s", ErrCircularReference, strings.Join(ids, " -> "))
+}
+
+// ErrInheritedPropertyConflict returns an error for a property overriding an inherited one with a different data type
+func ErrInheritedPropertyConflict(propertyName string, inherited, overriding DataType) error {
+	return fmt.Errorf("%w: %s is %s in parent but %s in child", ErrInheritanceConflict, propertyName, inherited, overriding)
+}
+
+// ErrReferencedTypeMissingFor returns an error naming the property whose
+// referenced object type is missing
+func ErrReferencedTypeMissingFor(propertyName string, objectTypeID uuid.UUID) error {
+	return fmt.Errorf("%w: property %s references %s", ErrReferencedTypeMissing, propertyName, objectTypeID)
+}
+
+// ErrTooManyPropertiesFor returns an error reporting a property count over limit
+func ErrTooManyPropertiesFor(count, limit int) error {
+	return fmt.Errorf("%w: %d properties, limit is %d", ErrTooManyProperties, count, limit)
+}
+
 // ErrDuplicateProperty returns an error for duplicate property
 func ErrDuplicateProperty(propertyName string) error {
 	return fmt.Errorf("duplicate property name: %s", propertyName)
 }
 
-// ErrPropertyNotFoundWithName returns an error for property not found
-func ErrPropertyNotFound(propertyName string) error {
+// ErrDuplicatePropertyDisplayName returns an error for two properties sharing
+// a display name
+func ErrDuplicatePropertyDisplayName(displayName, first, second string) error {
+	return fmt.Errorf("duplicate property display name %q: used by %s and %s", displayName, first, second)
+}
+
+// ErrUniqueNonScalar returns an error for a unique property whose values are
+// arrays or objects
+func ErrUniqueNonScalar(propertyName string, dataType DataType) error {
+	return fmt.Errorf("unique only applies to scalar properties: %s is %s", propertyName, dataType)
+}
+
+// ErrPropertyNotFoundFor returns ErrPropertyNotFound naming the property
+func ErrPropertyNotFoundFor(propertyName string) error {
 	return fmt.Errorf("%w: %s", ErrPropertyNotFound, propertyName)
 }
 
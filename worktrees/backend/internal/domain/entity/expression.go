@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Expression validators compare two properties of the same record. The
+// validator value has the form "<property> <operator> <property>", for
+// example "endDate >= startDate". Supported operators are:
+//
+//	==  !=  >  >=  <  <=
+//
+// Operands are compared according to the left property's data type: NUMBER
+// numerically, DATE and DATETIME chronologically (RFC 3339 or YYYY-MM-DD),
+// STRING lexicographically. BOOLEAN only supports == and !=. A comparison
+// is skipped when either value is missing; use Required to enforce presence.
+
+// comparison is a parsed expression validator
+type comparison struct {
+	Left     string
+	Operator string
+	Right    string
+}
+
+// parseComparison parses an expression validator value
+func parseComparison(expr string) (*comparison, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("expression %q must have the form \"<property> <operator> <property>\"", expr)
+	}
+
+	c := &comparison{Left: fields[0], Operator: fields[1], Right: fields[2]}
+
+	switch c.Operator {
+	case "==", "!=", ">", ">=", "<", "<=":
+	default:
+		return nil, fmt.Errorf("unsupported operator %q in expression %q", c.Operator, expr)
+	}
+
+	for _, name := range []string{c.Left, c.Right} {
+		if !isValidPropertyName(name) {
+			return nil, fmt.Errorf("invalid property name %q in expression %q", name, expr)
+		}
+	}
+
+	return c, nil
+}
+
+// evaluate checks the comparison against a record
+func (c *comparison) evaluate(values map[string]interface{}, dataType DataType) error {
+	left, right := values[c.Left], values[c.Right]
+	if left == nil || right == nil {
+		return nil
+	}
+
+	if dataType == DataTypeBoolean && c.Operator != "==" && c.Operator != "!=" {
+		return fmt.Errorf("operator %s is not supported for boolean properties %s and %s", c.Operator, c.Left, c.Right)
+	}
+
+	cmp, err := compareValues(left, right, dataType)
+	if err != nil {
+		return fmt.Errorf("cannot compare %s and %s: %w", c.Left, c.Right, err)
+	}
+
+	var ok bool
+	switch c.Operator {
+	case "==":
+		ok = cmp == 0
+	case "!=":
+		ok = cmp != 0
+	case ">":
+		ok = cmp > 0
+	case ">=":
+		ok = cmp >= 0
+	case "<":
+		ok = cmp < 0
+	case "<=":
+		ok = cmp <= 0
+	}
+
+	if !ok {
+		return fmt.Errorf("%s (%v) must be %s %s (%v)", c.Left, left, c.Operator, c.Right, right)
+	}
+
+	return nil
+}
+
+// compareValues returns -1, 0 or 1 comparing a and b as the given data type
+func compareValues(a, b interface{}, dataType DataType) (int, error) {
+	switch dataType {
+	case DataTypeNumber:
+		x, ok := toFloat64(a)
+		if !ok {
+			return 0, fmt.Errorf("value %v is not a number", a)
+		}
+		y, ok := toFloat64(b)
+		if !ok {
+			return 0, fmt.Errorf("value %v is not a number", b)
+		}
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return 1, nil
+		}
+		return 0, nil
+
+	case DataTypeDate, DataTypeDateTime:
+		x, err := parseTimeValue(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := parseTimeValue(b)
+		if err != nil {
+			return 0, err
+		}
+		return x.Compare(y), nil
+
+	case DataTypeString:
+		x, ok := a.(string)
+		if !ok {
+			return 0, fmt.Errorf("value %v is not a string", a)
+		}
+		y, ok := b.(string)
+		if !ok {
+			return 0, fmt.Errorf("value %v is not a string", b)
+		}
+		return strings.Compare(x, y), nil
+
+	case DataTypeBoolean:
+		x, ok := a.(bool)
+		if !ok {
+			return 0, fmt.Errorf("value %v is not a boolean", a)
+		}
+		y, ok := b.(bool)
+		if !ok {
+			return 0, fmt.Errorf("value %v is not a boolean", b)
+		}
+		if x == y {
+			return 0, nil
+		}
+		return 1, nil
+	}
+
+	return 0, fmt.Errorf("comparison is not supported for %s properties", dataType)
+}
+
+// toFloat64 converts a numeric value to float64
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// parseTimeValue parses a date or datetime value
+func parseTimeValue(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("value %v is not a valid date", value)
+}
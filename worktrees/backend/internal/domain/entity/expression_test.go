@@ -0,0 +1,197 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// rangeObjectType returns an object type with start and end properties of
+// dataType, where end carries the expression validator expr
+func rangeObjectType(dataType DataType, expr string) *ObjectType {
+	return &ObjectType{
+		ID:          uuid.New(),
+		Name:        "Booking",
+		DisplayName: "Booking",
+		Properties: []Property{
+			{Name: "start", DisplayName: "Start", DataType: dataType},
+			{Name: "end", DisplayName: "End", DataType: dataType, Validators: []Validator{
+				{Type: ValidatorExpression, Value: expr},
+			}},
+		},
+	}
+}
+
+func TestExpressionAcceptsLaterDate(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeDate, "end >= start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"start": "2024-01-01", "end": "2024-01-31"})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateInstance = %v, want nil", err)
+	}
+}
+
+func TestExpressionAcceptsEqualDateWithGreaterOrEqual(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeDate, "end >= start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"start": "2024-01-01", "end": "2024-01-01"})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateInstance = %v, want nil", err)
+	}
+}
+
+func TestExpressionRejectsEarlierDate(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeDate, "end >= start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"start": "2024-01-31", "end": "2024-01-01"})
+
+	// Assert
+	if err == nil {
+		t.Error("ValidateInstance accepted an end date before the start date")
+	}
+}
+
+func TestExpressionErrorNamesBothFields(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeDate, "end >= start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"start": "2024-01-31", "end": "2024-01-01"})
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "end") || !strings.Contains(err.Error(), "start") {
+		t.Errorf("err = %v, want it to name end and start", err)
+	}
+}
+
+func TestExpressionComparesDateTimesChronologically(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeDateTime, "end > start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{
+		"start": "2024-01-01T10:00:00+02:00",
+		"end":   "2024-01-01T09:30:00Z",
+	})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateInstance = %v, want nil for 09:30Z after 08:00Z", err)
+	}
+}
+
+func TestExpressionAcceptsLargerNumber(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeNumber, "end > start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"start": 9.5, "end": 10})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateInstance = %v, want nil", err)
+	}
+}
+
+func TestExpressionRejectsEqualNumberWithGreaterThan(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeNumber, "end > start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"start": 10.0, "end": 10})
+
+	// Assert
+	if err == nil {
+		t.Error("ValidateInstance accepted 10 > 10")
+	}
+}
+
+func TestExpressionComparesNumbersNumerically(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeNumber, "start < end")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"start": 9.0, "end": 10.0})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateInstance = %v, want nil for 9 < 10", err)
+	}
+}
+
+func TestExpressionSkipsMissingValue(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeNumber, "end > start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"end": 1.0})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateInstance = %v, want nil when start is missing", err)
+	}
+}
+
+func TestExpressionErrorsReportsEveryFailure(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeNumber, "end > start")
+	objectType.Properties[0].Validators = []Validator{{Type: ValidatorExpression, Value: "start != end"}}
+
+	// Act
+	failures := objectType.ExpressionErrors(map[string]interface{}{"start": 5.0, "end": 5.0})
+
+	// Assert
+	if len(failures) != 2 {
+		t.Errorf("failures = %v, want 2", failures)
+	}
+}
+
+func TestValidateRejectsUnsupportedExpressionOperator(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeNumber, "end => start")
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted the operator =>")
+	}
+}
+
+func TestValidateRejectsExpressionReferencingUnknownProperty(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeNumber, "end > begin")
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted an expression referencing an unknown property")
+	}
+}
+
+func TestExpressionRejectsOrderingBooleans(t *testing.T) {
+	// Arrange
+	objectType := rangeObjectType(DataTypeBoolean, "end > start")
+
+	// Act
+	err := objectType.ValidateInstance(map[string]interface{}{"start": false, "end": true})
+
+	// Assert
+	if err == nil {
+		t.Error("ValidateInstance accepted > between booleans")
+	}
+}
@@ -0,0 +1,97 @@
+package entity
+
+import "testing"
+
+// formatCases lists valid and invalid values for each of ValidatorFormats
+var formatCases = map[string]struct {
+	valid   []string
+	invalid []string
+}{
+	FormatEmail: {
+		valid:   []string{"alice@example.com", "a.b+tag@sub.example.org"},
+		invalid: []string{"alice", "alice@", "@example.com", "alice@@example.com"},
+	},
+	FormatURL: {
+		valid:   []string{"https://example.com", "http://example.com/path?q=1"},
+		invalid: []string{"example.com", "not a url", "ftp//missing-colon"},
+	},
+	FormatUUID: {
+		valid:   []string{"123e4567-e89b-12d3-a456-426614174000"},
+		invalid: []string{"123e4567", "123e4567-e89b-12d3-a456-42661417400g", ""},
+	},
+	FormatDate: {
+		valid:   []string{"2024-02-29", "1999-12-31"},
+		invalid: []string{"2023-02-29", "2024-13-01", "2024-01-01T00:00:00Z", "01/02/2024"},
+	},
+}
+
+func formatValidator(format string) Validator {
+	return Validator{Type: ValidatorFormat, Value: format}
+}
+
+func TestFormatValidatorAcceptsValidValues(t *testing.T) {
+	for format, cases := range formatCases {
+		for _, value := range cases.valid {
+			// Arrange
+			validator := formatValidator(format)
+
+			// Act
+			err := applyValidator(validator, value, DataTypeString)
+
+			// Assert
+			if err != nil {
+				t.Errorf("%s format rejected %q: %v", format, value, err)
+			}
+		}
+	}
+}
+
+func TestFormatValidatorRejectsInvalidValues(t *testing.T) {
+	for format, cases := range formatCases {
+		for _, value := range cases.invalid {
+			// Arrange
+			validator := formatValidator(format)
+
+			// Act
+			err := applyValidator(validator, value, DataTypeString)
+
+			// Assert
+			if err == nil {
+				t.Errorf("%s format accepted %q", format, value)
+			}
+		}
+	}
+}
+
+func TestFormatValidatorCoversEveryFormat(t *testing.T) {
+	// Arrange
+	formats := ValidatorFormats
+
+	for _, format := range formats {
+		// Act
+		_, ok := formatCases[format]
+
+		// Assert
+		if !ok {
+			t.Errorf("no test cases for the %s format", format)
+		}
+	}
+}
+
+func TestPropertyValidateRejectsUnknownFormat(t *testing.T) {
+	// Arrange
+	property := &Property{
+		Name:        "phone",
+		DisplayName: "Phone",
+		DataType:    DataTypeString,
+		Validators:  []Validator{formatValidator("phone")},
+	}
+
+	// Act
+	err := property.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted the unknown format phone")
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/pkg/validator"
 )
 
 // LinkType represents a relationship between two object types
 type LinkType struct {
 	ID                 uuid.UUID              `json:"id"`
+	TenantID           string                 `json:"tenantId"`
 	Name               string                 `json:"name"`
 	DisplayName        string                 `json:"displayName"`
 	SourceObjectTypeID uuid.UUID              `json:"sourceObjectTypeId"`
@@ -17,12 +19,28 @@ type LinkType struct {
 	Description        *string                `json:"description,omitempty"`
 	Properties         []Property             `json:"properties,omitempty"`
 	Metadata           map[string]interface{} `json:"metadata"`
+	Constraints        LinkConstraints        `json:"constraints"`
 	Version            int                    `json:"version"`
 	IsDeleted          bool                   `json:"-"`
 	CreatedAt          time.Time              `json:"createdAt"`
 	CreatedBy          string                 `json:"createdBy"`
 	UpdatedAt          time.Time              `json:"updatedAt"`
 	UpdatedBy          string                 `json:"updatedBy"`
+
+	// PairID names the companion of a link type created with an inverse;
+	// IsInverse marks the generated, target-to-source side of the pair
+	PairID    *uuid.UUID `json:"pairId,omitempty"`
+	IsInverse bool       `json:"isInverse,omitempty"`
+}
+
+// LinkConstraints controls what happens to a link type when one of its
+// endpoint object types is deleted. With neither flag set, deletion is refused
+// unless forced.
+type LinkConstraints struct {
+	// CascadeDelete soft deletes the link type along with the object type
+	CascadeDelete bool `json:"cascadeDelete"`
+	// PreventDelete refuses deletion of the object type, even when forced
+	PreventDelete bool `json:"preventDelete"`
 }
 
 // Cardinality represents the cardinality of a relationship
@@ -70,7 +88,18 @@ func (lt *LinkType) Validate() error {
 		return ErrInvalidCardinality(string(lt.Cardinality))
 	}
 
+	if err := ValidateMetadata("link type", lt.Metadata); err != nil {
+		return err
+	}
+
+	if lt.Constraints.CascadeDelete && lt.Constraints.PreventDelete {
+		return ErrConflictingLinkConstraints
+	}
+
 	// Validate properties if any
+	if err := validatePropertyCount(len(lt.Properties)); err != nil {
+		return err
+	}
 	propertyNames := make(map[string]bool)
 	for _, prop := range lt.Properties {
 		if propertyNames[prop.Name] {
@@ -81,6 +110,14 @@ func (lt *LinkType) Validate() error {
 		if err := prop.Validate(); err != nil {
 			return err
 		}
+
+		// Link properties are subject to the same reserved names as object type properties
+		if err := validator.ValidatePropertyName(prop.Name); err != nil {
+			return err
+		}
+	}
+	if err := validatePropertyDisplayNames(lt.Properties); err != nil {
+		return err
 	}
 
 	return nil
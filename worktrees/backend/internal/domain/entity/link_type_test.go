@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// linkTypeWith returns a valid link type with one property
+func linkTypeWith(property Property) *LinkType {
+	return &LinkType{
+		ID:                 uuid.New(),
+		Name:               "customerOrders",
+		DisplayName:        "Customer orders",
+		SourceObjectTypeID: uuid.New(),
+		TargetObjectTypeID: uuid.New(),
+		Cardinality:        CardinalityOneToMany,
+		Properties:         []Property{property},
+	}
+}
+
+func TestLinkTypeValidateRejectsPropertyNamedVersion(t *testing.T) {
+	// Arrange
+	linkType := linkTypeWith(Property{Name: "version", DisplayName: "Version", DataType: DataTypeNumber})
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("err = %v, want version rejected as reserved", err)
+	}
+}
+
+func TestLinkTypeValidateRejectsPropertyNamedCreatedAt(t *testing.T) {
+	// Arrange
+	linkType := linkTypeWith(Property{Name: "createdAt", DisplayName: "Created at", DataType: DataTypeDateTime})
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("err = %v, want createdAt rejected as reserved", err)
+	}
+}
+
+func TestLinkTypeValidateAcceptsOrdinaryPropertyName(t *testing.T) {
+	// Arrange
+	linkType := linkTypeWith(Property{Name: "since", DisplayName: "Since", DataType: DataTypeDate})
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
+
+func TestLinkTypeValidateRejectsConflictingConstraints(t *testing.T) {
+	// Arrange
+	linkType := linkTypeWith(Property{Name: "since", DisplayName: "Since", DataType: DataTypeString})
+	linkType.Constraints = LinkConstraints{CascadeDelete: true, PreventDelete: true}
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if err != ErrConflictingLinkConstraints {
+		t.Errorf("Validate = %v, want ErrConflictingLinkConstraints", err)
+	}
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Metadata limits applied to object types, link types and properties
+const (
+	// MaxMetadataSize is the largest serialized metadata accepted, in bytes
+	MaxMetadataSize = 64 << 10
+	// MaxMetadataDepth is the deepest nesting accepted; a flat map is depth 1
+	MaxMetadataDepth = 10
+)
+
+// ValidateMetadata checks metadata against MaxMetadataDepth and
+// MaxMetadataSize. owner names the metadata in the error, e.g.
+// "property email".
+func ValidateMetadata(owner string, metadata map[string]interface{}) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	if metadataDepth(metadata, 1) > MaxMetadataDepth {
+		return fmt.Errorf("%w: %s metadata nests deeper than %d levels", ErrInvalidMetadata, owner, MaxMetadataDepth)
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("%w: %s metadata is not serializable: %v", ErrInvalidMetadata, owner, err)
+	}
+	if len(data) > MaxMetadataSize {
+		return fmt.Errorf("%w: %s metadata is %d bytes, limit is %d", ErrInvalidMetadata, owner, len(data), MaxMetadataSize)
+	}
+
+	return nil
+}
+
+// metadataDepth returns the nesting depth of value, where depth is the level
+// value sits at. It stops descending once MaxMetadataDepth is exceeded.
+func metadataDepth(value interface{}, depth int) int {
+	if depth > MaxMetadataDepth {
+		return depth
+	}
+
+	deepest := depth
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			if d := childDepth(child, depth); d > deepest {
+				deepest = d
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if d := childDepth(child, depth); d > deepest {
+				deepest = d
+			}
+		}
+	}
+	return deepest
+}
+
+// childDepth returns the depth of a nested collection, or depth for scalars
+func childDepth(child interface{}, depth int) int {
+	switch child.(type) {
+	case map[string]interface{}, []interface{}:
+		return metadataDepth(child, depth+1)
+	}
+	return depth
+}
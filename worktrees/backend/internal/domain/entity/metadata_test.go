@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// nestedMetadata returns metadata nested depth levels deep
+func nestedMetadata(depth int) map[string]interface{} {
+	metadata := map[string]interface{}{"leaf": true}
+	for i := 1; i < depth; i++ {
+		metadata = map[string]interface{}{"child": metadata}
+	}
+	return metadata
+}
+
+// oversizedMetadata returns flat metadata just over MaxMetadataSize once
+// serialized
+func oversizedMetadata() map[string]interface{} {
+	return map[string]interface{}{"blob": strings.Repeat("x", MaxMetadataSize)}
+}
+
+func TestValidateMetadataAcceptsMaximumDepth(t *testing.T) {
+	// Act
+	err := ValidateMetadata("object type", nestedMetadata(MaxMetadataDepth))
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateMetadata = %v, want nil at %d levels", err, MaxMetadataDepth)
+	}
+}
+
+func TestValidateMetadataRejectsDeepNesting(t *testing.T) {
+	// Act
+	err := ValidateMetadata("object type", nestedMetadata(MaxMetadataDepth+1))
+
+	// Assert
+	if !errors.Is(err, ErrInvalidMetadata) {
+		t.Errorf("ValidateMetadata = %v, want ErrInvalidMetadata", err)
+	}
+}
+
+func TestValidateMetadataCountsNestingInsideArrays(t *testing.T) {
+	// Arrange
+	metadata := map[string]interface{}{"items": []interface{}{nestedMetadata(MaxMetadataDepth)}}
+
+	// Act
+	err := ValidateMetadata("object type", metadata)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidMetadata) {
+		t.Errorf("ValidateMetadata = %v, want ErrInvalidMetadata for nesting through an array", err)
+	}
+}
+
+func TestValidateMetadataRejectsOversizedPayload(t *testing.T) {
+	// Act
+	err := ValidateMetadata("object type", oversizedMetadata())
+
+	// Assert
+	if !errors.Is(err, ErrInvalidMetadata) {
+		t.Errorf("ValidateMetadata = %v, want ErrInvalidMetadata", err)
+	}
+}
+
+func TestValidateMetadataNamesOwner(t *testing.T) {
+	// Act
+	err := ValidateMetadata("property email", oversizedMetadata())
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "property email") {
+		t.Errorf("ValidateMetadata = %v, want an error naming property email", err)
+	}
+}
+
+func TestObjectTypeValidateRejectsOversizedMetadata(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{Name: "email", DisplayName: "Email", DataType: DataTypeString})
+	objectType.Metadata = oversizedMetadata()
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if !errors.Is(err, ErrInvalidMetadata) {
+		t.Errorf("Validate = %v, want ErrInvalidMetadata", err)
+	}
+}
+
+func TestObjectTypeValidateRejectsDeepPropertyMetadata(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{
+		Name: "email", DisplayName: "Email", DataType: DataTypeString, Metadata: nestedMetadata(MaxMetadataDepth + 1),
+	})
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if !errors.Is(err, ErrInvalidMetadata) {
+		t.Errorf("Validate = %v, want ErrInvalidMetadata for property metadata", err)
+	}
+}
+
+func TestLinkTypeValidateRejectsDeepMetadata(t *testing.T) {
+	// Arrange
+	linkType := linkTypeWith(Property{Name: "since", DisplayName: "Since", DataType: DataTypeString})
+	linkType.Metadata = nestedMetadata(MaxMetadataDepth + 1)
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if !errors.Is(err, ErrInvalidMetadata) {
+		t.Errorf("Validate = %v, want ErrInvalidMetadata", err)
+	}
+}
@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,6 +12,7 @@ import (
 // ObjectType represents a business object definition
 type ObjectType struct {
 	ID           uuid.UUID              `json:"id"`
+	TenantID     string                 `json:"tenantId"`
 	Name         string                 `json:"name"`
 	DisplayName  string                 `json:"displayName"`
 	Description  *string                `json:"description,omitempty"`
@@ -17,12 +21,16 @@ type ObjectType struct {
 	Properties   []Property             `json:"properties"`
 	BaseDatasets []DatasetReference     `json:"baseDatasets,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata"`
+	ParentID     *uuid.UUID             `json:"parentId,omitempty"`
 	Version      int                    `json:"version"`
 	IsDeleted    bool                   `json:"-"`
 	CreatedAt    time.Time              `json:"createdAt"`
 	CreatedBy    string                 `json:"createdBy"`
 	UpdatedAt    time.Time              `json:"updatedAt"`
 	UpdatedBy    string                 `json:"updatedBy"`
+
+	// ResolvedProperties holds own and inherited properties when requested; never persisted
+	ResolvedProperties []Property `json:"resolvedProperties,omitempty"`
 }
 
 // DatasetReference represents a reference to a base dataset
@@ -31,6 +39,49 @@ type DatasetReference struct {
 	Name       string `json:"name"`
 }
 
+// DefaultMaxProperties is the default limit on the properties of one object
+// or link type
+const DefaultMaxProperties = 500
+
+// MaxProperties limits the properties of one object or link type, keeping
+// definitions and their cache entries bounded. It is set from configuration
+// at startup; 0 disables the limit.
+var MaxProperties = DefaultMaxProperties
+
+// validatePropertyCount checks count against MaxProperties
+func validatePropertyCount(count int) error {
+	if MaxProperties > 0 && count > MaxProperties {
+		return ErrTooManyPropertiesFor(count, MaxProperties)
+	}
+	return nil
+}
+
+// UniquePropertyDisplayNames makes Validate reject object and link types
+// whose properties share a display name, ignoring case and surrounding
+// space. It is set from configuration at startup.
+var UniquePropertyDisplayNames = false
+
+// validatePropertyDisplayNames checks that no two properties share a display
+// name when UniquePropertyDisplayNames is set
+func validatePropertyDisplayNames(props []Property) error {
+	if !UniquePropertyDisplayNames {
+		return nil
+	}
+
+	seen := make(map[string]string, len(props))
+	for _, prop := range props {
+		key := strings.ToLower(strings.TrimSpace(prop.DisplayName))
+		if key == "" {
+			continue
+		}
+		if other, ok := seen[key]; ok {
+			return ErrDuplicatePropertyDisplayName(prop.DisplayName, other, prop.Name)
+		}
+		seen[key] = prop.Name
+	}
+	return nil
+}
+
 // Validate validates the object type
 func (ot *ObjectType) Validate() error {
 	if ot.Name == "" {
@@ -45,7 +96,18 @@ func (ot *ObjectType) Validate() error {
 		return ErrRequiredField("displayName")
 	}
 
+	if ot.ParentID != nil && *ot.ParentID == ot.ID {
+		return ErrInheritanceCycle
+	}
+
+	if err := ValidateMetadata("object type", ot.Metadata); err != nil {
+		return err
+	}
+
 	// Validate properties
+	if err := validatePropertyCount(len(ot.Properties)); err != nil {
+		return err
+	}
 	propertyNames := make(map[string]bool)
 	for _, prop := range ot.Properties {
 		if propertyNames[prop.Name] {
@@ -56,11 +118,238 @@ func (ot *ObjectType) Validate() error {
 		if err := prop.Validate(); err != nil {
 			return err
 		}
+		if prop.Unique && !prop.DataType.IsScalar() {
+			return ErrUniqueNonScalar(prop.Name, prop.DataType)
+		}
+	}
+	if err := validatePropertyDisplayNames(ot.Properties); err != nil {
+		return err
+	}
+
+	// Inherited properties are only known once the parent chain is loaded;
+	// ValidateInheritance checks expression references in that case
+	if ot.ParentID == nil {
+		if err := checkExpressionReferences(ot.Properties); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateInstance validates a record against the object type, including
+// expression validators that compare properties of the same record.
+// ResolvedProperties is used when set so inherited properties are checked too.
+func (ot *ObjectType) ValidateInstance(values map[string]interface{}) error {
+	properties := ot.Properties
+	if ot.ResolvedProperties != nil {
+		properties = ot.ResolvedProperties
+	}
+
+	for _, prop := range properties {
+		if err := prop.ValidateValue(values[prop.Name]); err != nil {
+			return err
+		}
+	}
+
+	if failures := ot.ExpressionErrors(values); len(failures) > 0 {
+		return fmt.Errorf("validation failed for %s: %w", failures[0].Property, failures[0].Err)
 	}
 
 	return nil
 }
 
+// ApplyDefaults returns a copy of values with the default value of every
+// property whose name is absent from values. A key present with a nil value
+// is an explicit null and is kept as is, never replaced by the default.
+// Defaults that do not match the property's data type are skipped, and
+// array and object defaults are copied so callers may modify the result.
+// ResolvedProperties is used when set. values itself is not modified.
+func (ot *ObjectType) ApplyDefaults(values map[string]interface{}) map[string]interface{} {
+	properties := ot.Properties
+	if ot.ResolvedProperties != nil {
+		properties = ot.ResolvedProperties
+	}
+
+	result := make(map[string]interface{}, len(values)+len(properties))
+	for name, value := range values {
+		result[name] = value
+	}
+
+	for i := range properties {
+		prop := &properties[i]
+		if _, present := result[prop.Name]; present || prop.DefaultValue == nil {
+			continue
+		}
+		if err := prop.validateDefaultValue(); err != nil {
+			continue
+		}
+		result[prop.Name] = copyValue(prop.DefaultValue)
+	}
+
+	return result
+}
+
+// PropertyError is a validation failure attributed to one property
+type PropertyError struct {
+	Property string
+	Err      error
+}
+
+// ExpressionErrors evaluates the expression validators of every property
+// against values and returns all failures in property order.
+// ResolvedProperties is used when set.
+func (ot *ObjectType) ExpressionErrors(values map[string]interface{}) []PropertyError {
+	properties := ot.Properties
+	if ot.ResolvedProperties != nil {
+		properties = ot.ResolvedProperties
+	}
+
+	dataTypes := make(map[string]DataType, len(properties))
+	for _, prop := range properties {
+		dataTypes[prop.Name] = prop.DataType
+	}
+
+	var failures []PropertyError
+	for _, prop := range properties {
+		for _, v := range prop.Validators {
+			if v.Type != ValidatorExpression {
+				continue
+			}
+			expr, _ := v.Value.(string)
+			c, err := parseComparison(expr)
+			if err == nil {
+				err = c.evaluate(values, dataTypes[c.Left])
+			}
+			if err != nil {
+				failures = append(failures, PropertyError{Property: prop.Name, Err: err})
+			}
+		}
+	}
+
+	return failures
+}
+
+// checkExpressionReferences ensures expression validators only reference known properties
+func checkExpressionReferences(properties []Property) error {
+	known := make(map[string]bool, len(properties))
+	for _, prop := range properties {
+		known[prop.Name] = true
+	}
+
+	for _, prop := range properties {
+		for _, v := range prop.Validators {
+			if v.Type != ValidatorExpression {
+				continue
+			}
+			expr, _ := v.Value.(string)
+			c, err := parseComparison(expr)
+			if err != nil {
+				return err
+			}
+			for _, name := range []string{c.Left, c.Right} {
+				if !known[name] {
+					return fmt.Errorf("expression validator on %s references unknown property %s", prop.Name, name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateInheritance checks the object type against its ancestor chain,
+// ordered from the immediate parent up to the root
+func (ot *ObjectType) ValidateInheritance(ancestors []*ObjectType) error {
+	dataTypes := make(map[string]DataType)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if ancestors[i].ID == ot.ID {
+			return ErrInheritanceCycle
+		}
+		if err := checkInheritedDataTypes(dataTypes, ancestors[i].Properties); err != nil {
+			return err
+		}
+	}
+	if err := checkInheritedDataTypes(dataTypes, ot.Properties); err != nil {
+		return err
+	}
+	return checkExpressionReferences(ot.ResolveProperties(ancestors))
+}
+
+// ResolveProperties merges inherited properties with the object type's own.
+// Ancestors are ordered from the immediate parent up to the root; a property
+// defined closer to the object type overrides one with the same name.
+func (ot *ObjectType) ResolveProperties(ancestors []*ObjectType) []Property {
+	var resolved []Property
+	index := make(map[string]int)
+
+	overlay := func(props []Property) {
+		for _, prop := range props {
+			if i, ok := index[prop.Name]; ok {
+				resolved[i] = prop
+				continue
+			}
+			index[prop.Name] = len(resolved)
+			resolved = append(resolved, prop)
+		}
+	}
+
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		overlay(ancestors[i].Properties)
+	}
+	overlay(ot.Properties)
+
+	return resolved
+}
+
+func checkInheritedDataTypes(dataTypes map[string]DataType, props []Property) error {
+	for _, prop := range props {
+		if inherited, ok := dataTypes[prop.Name]; ok && inherited != prop.DataType {
+			return ErrInheritedPropertyConflict(prop.Name, inherited, prop.DataType)
+		}
+		dataTypes[prop.Name] = prop.DataType
+	}
+	return nil
+}
+
+// Copy returns a deep copy of the object type that shares no maps, slices or
+// pointers with it
+func (ot *ObjectType) Copy() *ObjectType {
+	copied := *ot
+	if ot.Description != nil {
+		description := *ot.Description
+		copied.Description = &description
+	}
+	if ot.Category != nil {
+		category := *ot.Category
+		copied.Category = &category
+	}
+	if ot.ParentID != nil {
+		parentID := *ot.ParentID
+		copied.ParentID = &parentID
+	}
+	if ot.Tags != nil {
+		copied.Tags = append([]string(nil), ot.Tags...)
+	}
+	if ot.Properties != nil {
+		copied.Properties = make([]Property, len(ot.Properties))
+		for i, prop := range ot.Properties {
+			copied.Properties[i] = prop.Copy()
+		}
+	}
+	if ot.BaseDatasets != nil {
+		copied.BaseDatasets = append([]DatasetReference(nil), ot.BaseDatasets...)
+	}
+	if ot.ResolvedProperties != nil {
+		copied.ResolvedProperties = make([]Property, len(ot.ResolvedProperties))
+		for i, prop := range ot.ResolvedProperties {
+			copied.ResolvedProperties[i] = prop.Copy()
+		}
+	}
+	copied.Metadata = copyMetadata(ot.Metadata)
+	return &copied
+}
+
 // IncrementVersion increments the version number
 func (ot *ObjectType) IncrementVersion() {
 	ot.Version++
@@ -100,7 +389,7 @@ func (ot *ObjectType) RemoveProperty(propertyName string) error {
 			return nil
 		}
 	}
-	return ErrPropertyNotFound(propertyName)
+	return ErrPropertyNotFoundFor(propertyName)
 }
 
 // UpdateProperty updates an existing property
@@ -117,7 +406,42 @@ func (ot *ObjectType) UpdateProperty(propertyName string, updatedProp Property)
 			return nil
 		}
 	}
-	return ErrPropertyNotFound(propertyName)
+	return ErrPropertyNotFoundFor(propertyName)
+}
+
+// SortProperties orders properties by their Order field, keeping the stored
+// order between properties with equal Order
+func (ot *ObjectType) SortProperties() {
+	sort.SliceStable(ot.Properties, func(i, j int) bool {
+		return ot.Properties[i].Order < ot.Properties[j].Order
+	})
+}
+
+// ReorderProperties assigns each property the position of its name in names,
+// which must list every property exactly once
+func (ot *ObjectType) ReorderProperties(names []string) error {
+	if len(names) != len(ot.Properties) {
+		return ErrInvalidPropertyOrder
+	}
+
+	positions := make(map[string]int, len(names))
+	for i, name := range names {
+		if _, ok := positions[name]; ok {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidPropertyOrder, name)
+		}
+		positions[name] = i
+	}
+
+	for i := range ot.Properties {
+		position, ok := positions[ot.Properties[i].Name]
+		if !ok {
+			return fmt.Errorf("%w: %s is missing", ErrInvalidPropertyOrder, ot.Properties[i].Name)
+		}
+		ot.Properties[i].Order = position
+	}
+
+	ot.SortProperties()
+	return nil
 }
 
 // GetProperty returns a property by name
@@ -127,7 +451,7 @@ func (ot *ObjectType) GetProperty(propertyName string) (*Property, error) {
 			return &prop, nil
 		}
 	}
-	return nil, ErrPropertyNotFound(propertyName)
+	return nil, ErrPropertyNotFoundFor(propertyName)
 }
 
 // HasTag checks if the object type has a specific tag
@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyDefaultsFillsEachDataType(t *testing.T) {
+	cases := []struct {
+		dataType     DataType
+		defaultValue interface{}
+	}{
+		{DataTypeString, "active"},
+		{DataTypeNumber, float64(10)},
+		{DataTypeBoolean, true},
+		{DataTypeDate, "2024-01-01"},
+		{DataTypeDateTime, "2024-01-01T00:00:00Z"},
+		{DataTypeArray, []interface{}{"a", "b"}},
+		{DataTypeObject, map[string]interface{}{"street": "Main St"}},
+		{DataTypeReference, "5b0f4c1e-8b7a-4c1e-9d2f-3a6b8c9d0e1f"},
+		{DataTypeGeoPoint, map[string]interface{}{"lat": 52.5, "lng": 13.4}},
+		{DataTypeCurrency, map[string]interface{}{"amount": float64(0), "currencyCode": "EUR"}},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		objectType := objectTypeWith(Property{Name: "field", DisplayName: "Field", DataType: tc.dataType, DefaultValue: tc.defaultValue})
+
+		// Act
+		values := objectType.ApplyDefaults(nil)
+
+		// Assert
+		if !reflect.DeepEqual(values["field"], tc.defaultValue) {
+			t.Errorf("%s: field = %#v, want default %#v", tc.dataType, values["field"], tc.defaultValue)
+		}
+	}
+}
+
+func TestApplyDefaultsKeepsProvidedValue(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{Name: "status", DisplayName: "Status", DataType: DataTypeString, DefaultValue: "active"})
+
+	// Act
+	values := objectType.ApplyDefaults(map[string]interface{}{"status": "closed"})
+
+	// Assert
+	if values["status"] != "closed" {
+		t.Errorf("status = %v, want the provided value", values["status"])
+	}
+}
+
+func TestApplyDefaultsKeepsExplicitNull(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{Name: "status", DisplayName: "Status", DataType: DataTypeString, DefaultValue: "active"})
+
+	// Act
+	values := objectType.ApplyDefaults(map[string]interface{}{"status": nil})
+
+	// Assert
+	if value, present := values["status"]; !present || value != nil {
+		t.Errorf("status = %v (present %v), want an explicit null kept", value, present)
+	}
+}
+
+func TestApplyDefaultsSkipsMismatchedDefault(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{Name: "count", DisplayName: "Count", DataType: DataTypeNumber, DefaultValue: "ten"})
+
+	// Act
+	values := objectType.ApplyDefaults(nil)
+
+	// Assert
+	if _, present := values["count"]; present {
+		t.Errorf("count = %v, want a default of the wrong type skipped", values["count"])
+	}
+}
+
+func TestApplyDefaultsCopiesCollectionDefaults(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{
+		Name: "labels", DisplayName: "Labels", DataType: DataTypeArray, DefaultValue: []interface{}{"new"},
+	})
+
+	// Act
+	values := objectType.ApplyDefaults(nil)
+	values["labels"].([]interface{})[0] = "changed"
+
+	// Assert
+	if objectType.Properties[0].DefaultValue.([]interface{})[0] != "new" {
+		t.Error("changing an applied default changed the property definition")
+	}
+}
+
+func TestApplyDefaultsDoesNotModifyInput(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{Name: "status", DisplayName: "Status", DataType: DataTypeString, DefaultValue: "active"})
+	input := map[string]interface{}{}
+
+	// Act
+	objectType.ApplyDefaults(input)
+
+	// Assert
+	if len(input) != 0 {
+		t.Errorf("input = %v, want it left empty", input)
+	}
+}
+
+func TestApplyDefaultsUsesResolvedProperties(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{Name: "email", DisplayName: "Email", DataType: DataTypeString})
+	objectType.ResolvedProperties = append(objectType.Properties,
+		Property{Name: "country", DisplayName: "Country", DataType: DataTypeString, DefaultValue: "DE"})
+
+	// Act
+	values := objectType.ApplyDefaults(nil)
+
+	// Assert
+	if values["country"] != "DE" {
+		t.Errorf("country = %v, want the inherited default", values["country"])
+	}
+}
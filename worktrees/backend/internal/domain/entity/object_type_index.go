@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxIndexNameLength is the PostgreSQL identifier length limit
+const maxIndexNameLength = 63
+
+// ObjectTypeIndex is an index requested on instance storage for a property
+type ObjectTypeIndex struct {
+	ObjectTypeID uuid.UUID `json:"objectTypeId"`
+	PropertyName string    `json:"propertyName"`
+	Name         string    `json:"name"`
+	Unique       bool      `json:"unique"`
+	CreatedAt    time.Time `json:"createdAt"`
+	CreatedBy    string    `json:"createdBy"`
+}
+
+// DesiredIndexes returns the indexes implied by the Indexed and Unique flags
+// of the object type's properties, including inherited ones once resolved
+func (ot *ObjectType) DesiredIndexes() []ObjectTypeIndex {
+	properties := ot.ResolvedProperties
+	if properties == nil {
+		properties = ot.Properties
+	}
+
+	var indexes []ObjectTypeIndex
+	for _, prop := range properties {
+		if !prop.Indexed && !prop.Unique {
+			continue
+		}
+		indexes = append(indexes, ObjectTypeIndex{
+			ObjectTypeID: ot.ID,
+			PropertyName: prop.Name,
+			Name:         IndexName(ot.ID, prop.Name, prop.Unique),
+			Unique:       prop.Unique,
+		})
+	}
+
+	return indexes
+}
+
+// UniqueProperties returns the properties whose values must be unique across
+// instances, including inherited ones once resolved
+func (ot *ObjectType) UniqueProperties() []Property {
+	properties := ot.ResolvedProperties
+	if properties == nil {
+		properties = ot.Properties
+	}
+
+	unique := []Property{}
+	for _, prop := range properties {
+		if prop.Unique {
+			unique = append(unique, prop)
+		}
+	}
+	return unique
+}
+
+// IndexName derives a stable storage index name for a property
+func IndexName(objectTypeID uuid.UUID, propertyName string, unique bool) string {
+	prefix := "idx_"
+	if unique {
+		prefix = "uidx_"
+	}
+
+	name := prefix + strings.ReplaceAll(objectTypeID.String(), "-", "")[:12] + "_" + propertyName
+	if len(name) > maxIndexNameLength {
+		name = name[:maxIndexNameLength]
+	}
+	return name
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// objectTypeWith returns a valid object type with one property
+func objectTypeWith(property Property) *ObjectType {
+	return &ObjectType{
+		ID:          uuid.New(),
+		Name:        "Customer",
+		DisplayName: "Customer",
+		Properties:  []Property{property},
+	}
+}
+
+func TestValidateRejectsUniqueArrayProperty(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{
+		Name: "emails", DisplayName: "Emails", DataType: DataTypeArray, ElementType: DataTypeString, Unique: true,
+	})
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted a unique ARRAY property")
+	}
+}
+
+func TestValidateRejectsUniqueObjectProperty(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{
+		Name: "address", DisplayName: "Address", DataType: DataTypeObject, Unique: true,
+	})
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted a unique OBJECT property")
+	}
+}
+
+func TestValidateAcceptsUniqueScalarProperty(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{
+		Name: "email", DisplayName: "Email", DataType: DataTypeString, Unique: true,
+	})
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil for a unique STRING property", err)
+	}
+}
+
+func TestUniquePropertiesListsOnlyUniqueProperties(t *testing.T) {
+	// Arrange
+	objectType := objectTypeWith(Property{Name: "email", DisplayName: "Email", DataType: DataTypeString, Unique: true})
+	objectType.Properties = append(objectType.Properties,
+		Property{Name: "nickname", DisplayName: "Nickname", DataType: DataTypeString})
+
+	// Act
+	unique := objectType.UniqueProperties()
+
+	// Assert
+	if len(unique) != 1 || unique[0].Name != "email" {
+		t.Errorf("UniqueProperties = %v, want only email", unique)
+	}
+}
+
+func TestSortPropertiesOrdersByOrder(t *testing.T) {
+	// Arrange
+	objectType := &ObjectType{Properties: []Property{{Name: "b", Order: 2}, {Name: "a", Order: 1}}}
+
+	// Act
+	objectType.SortProperties()
+
+	// Assert
+	if objectType.Properties[0].Name != "a" || objectType.Properties[1].Name != "b" {
+		t.Errorf("properties = %v, want a before b", objectType.Properties)
+	}
+}
+
+func TestSortPropertiesKeepsStoredOrderForEqualOrder(t *testing.T) {
+	// Arrange
+	objectType := &ObjectType{Properties: []Property{{Name: "z"}, {Name: "y"}, {Name: "x"}}}
+
+	// Act
+	objectType.SortProperties()
+
+	// Assert
+	if objectType.Properties[0].Name != "z" || objectType.Properties[2].Name != "x" {
+		t.Errorf("properties = %v, want stored order z y x", objectType.Properties)
+	}
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"time"
+)
+
+// Default pattern validator limits
+const (
+	DefaultMaxPatternLength    = 1000
+	DefaultPatternMatchTimeout = 100 * time.Millisecond
+)
+
+// Pattern validator limits, set from configuration at startup. Go's regexp
+// runs in linear time, so patterns cannot backtrack catastrophically, but
+// long patterns and nested repetition still compile into large programs that
+// are slow to run against long values.
+var (
+	// MaxPatternLength bounds the length of a pattern validator's regex;
+	// 0 disables the limit
+	MaxPatternLength = DefaultMaxPatternLength
+	// PatternMatchTimeout bounds matching one value against a pattern;
+	// 0 disables the timeout
+	PatternMatchTimeout = DefaultPatternMatchTimeout
+)
+
+// maxPatternInstructions bounds the compiled size of a pattern. Repetition
+// such as (abcdef){1000} is short but expands to one instruction per
+// repeated element.
+const maxPatternInstructions = 5000
+
+// compilePattern compiles a pattern validator's regex, rejecting patterns
+// over MaxPatternLength or maxPatternInstructions
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if MaxPatternLength > 0 && len(pattern) > MaxPatternLength {
+		return nil, fmt.Errorf("%w: %d characters exceeds the limit of %d", ErrUnsafePattern, len(pattern), MaxPatternLength)
+	}
+
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern: %w", err)
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern: %w", err)
+	}
+	if len(prog.Inst) > maxPatternInstructions {
+		return nil, fmt.Errorf("%w: compiles to %d instructions, over the limit of %d", ErrUnsafePattern, len(prog.Inst), maxPatternInstructions)
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern: %w", err)
+	}
+	return compiled, nil
+}
+
+// matchPattern reports whether value matches pattern, giving up with
+// ErrPatternTimeout after PatternMatchTimeout. A match that times out keeps
+// running in the background until it completes, which linear-time matching
+// guarantees.
+func matchPattern(pattern, value string) (bool, error) {
+	compiled, err := compilePattern(pattern)
+	if err != nil {
+		return false, err
+	}
+	if PatternMatchTimeout <= 0 {
+		return compiled.MatchString(value), nil
+	}
+
+	result := make(chan bool, 1)
+	go func() {
+		result <- compiled.MatchString(value)
+	}()
+
+	timer := time.NewTimer(PatternMatchTimeout)
+	defer timer.Stop()
+	select {
+	case matched := <-result:
+		return matched, nil
+	case <-timer.C:
+		return false, fmt.Errorf("%w after %s", ErrPatternTimeout, PatternMatchTimeout)
+	}
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompilePatternRejectsLargeRepetition(t *testing.T) {
+	// Arrange
+	pattern := `(abcdef){1000}`
+
+	// Act
+	_, err := compilePattern(pattern)
+
+	// Assert
+	if !errors.Is(err, ErrUnsafePattern) {
+		t.Errorf("err = %v, want %v", err, ErrUnsafePattern)
+	}
+}
+
+func TestCompilePatternRejectsLongPattern(t *testing.T) {
+	// Arrange
+	pattern := strings.Repeat("a", MaxPatternLength+1)
+
+	// Act
+	_, err := compilePattern(pattern)
+
+	// Assert
+	if !errors.Is(err, ErrUnsafePattern) {
+		t.Errorf("err = %v, want %v", err, ErrUnsafePattern)
+	}
+}
+
+func TestMatchPatternBoundsCatastrophicBacktrackingPattern(t *testing.T) {
+	// Arrange
+	pattern := `^(a+)+$`
+	value := strings.Repeat("a", 100000) + "!"
+
+	// Act
+	start := time.Now()
+	matched, err := matchPattern(pattern, value)
+	elapsed := time.Since(start)
+
+	// Assert
+	if matched || elapsed > time.Second {
+		t.Errorf("matchPattern = %v, %v after %s; want no match within a second", matched, err, elapsed)
+	}
+}
+
+func TestMatchPatternTimesOut(t *testing.T) {
+	// Arrange
+	previous := PatternMatchTimeout
+	t.Cleanup(func() { PatternMatchTimeout = previous })
+	PatternMatchTimeout = time.Nanosecond
+	value := strings.Repeat("ab", 1<<20)
+
+	// Act
+	_, err := matchPattern(`(a|b)*c`, value)
+
+	// Assert
+	if !errors.Is(err, ErrPatternTimeout) {
+		t.Errorf("err = %v, want %v", err, ErrPatternTimeout)
+	}
+}
+
+func TestPatternValidatorRejectsUnsafePattern(t *testing.T) {
+	// Arrange
+	property := &Property{
+		Name:        "code",
+		DisplayName: "Code",
+		DataType:    DataTypeString,
+		Validators:  []Validator{{Type: ValidatorPattern, Value: `(abcdef){1000}`}},
+	}
+
+	// Act
+	err := property.Validate()
+
+	// Assert
+	if !errors.Is(err, ErrUnsafePattern) {
+		t.Errorf("err = %v, want %v", err, ErrUnsafePattern)
+	}
+}
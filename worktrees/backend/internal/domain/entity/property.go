@@ -3,8 +3,12 @@ package entity
 import (
 	"fmt"
 	"regexp"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/pkg/validator"
 )
 
 // Property represents a property of an object type
@@ -20,6 +24,70 @@ type Property struct {
 	Description  *string                `json:"description,omitempty"`
 	Validators   []Validator            `json:"validators,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata"`
+	Order        int                    `json:"order"`
+
+	// ElementType types the elements of an ARRAY property; untyped arrays
+	// accept any elements
+	ElementType DataType `json:"elementType,omitempty"`
+	// ItemValidators apply to each element of a typed ARRAY property
+	ItemValidators []Validator `json:"itemValidators,omitempty"`
+	// AllowNestedArrays permits an ElementType of ARRAY
+	AllowNestedArrays bool `json:"allowNestedArrays,omitempty"`
+
+	// ReferencedObjectTypeID names the object type a REFERENCE property points to
+	ReferencedObjectTypeID *uuid.UUID `json:"referencedObjectTypeId,omitempty"`
+
+	// Group names the section schema editors show the property in, e.g.
+	// "Contact"; it has no effect on validation
+	Group string `json:"group,omitempty"`
+
+	// Deprecated marks a property kept for existing data that new data should
+	// not use; DeprecationMessage tells users what to use instead
+	Deprecated         bool    `json:"deprecated,omitempty"`
+	DeprecationMessage *string `json:"deprecationMessage,omitempty"`
+}
+
+// WithoutDeprecated returns the properties that are not deprecated, leaving
+// properties untouched
+func WithoutDeprecated(properties []Property) []Property {
+	active := make([]Property, 0, len(properties))
+	for _, prop := range properties {
+		if !prop.Deprecated {
+			active = append(active, prop)
+		}
+	}
+	return active
+}
+
+// PropertyGroup is a section of properties sharing a Group. Ungrouped
+// properties form the group with an empty name.
+type PropertyGroup struct {
+	Name       string     `json:"name"`
+	Properties []Property `json:"properties"`
+}
+
+// GroupProperties splits properties by Group. Groups are ordered by their
+// first property in Order, and properties keep their Order within a group,
+// falling back to the given order between properties with equal Order.
+func GroupProperties(properties []Property) []PropertyGroup {
+	sorted := make([]Property, len(properties))
+	copy(sorted, properties)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Order < sorted[j].Order
+	})
+
+	groups := []PropertyGroup{}
+	index := make(map[string]int)
+	for _, prop := range sorted {
+		i, ok := index[prop.Group]
+		if !ok {
+			i = len(groups)
+			index[prop.Group] = i
+			groups = append(groups, PropertyGroup{Name: prop.Group})
+		}
+		groups[i].Properties = append(groups[i].Properties, prop)
+	}
+	return groups
 }
 
 // DataType represents the data type of a property
@@ -34,6 +102,10 @@ const (
 	DataTypeArray     DataType = "ARRAY"
 	DataTypeObject    DataType = "OBJECT"
 	DataTypeReference DataType = "REFERENCE"
+	// DataTypeGeoPoint values are {"lat": number, "lng": number} objects
+	DataTypeGeoPoint DataType = "GEOPOINT"
+	// DataTypeCurrency values are {"amount": number, "currencyCode": "ISO 4217"} objects
+	DataTypeCurrency DataType = "CURRENCY"
 )
 
 // IsValid checks if the data type is valid
@@ -41,7 +113,20 @@ func (dt DataType) IsValid() bool {
 	switch dt {
 	case DataTypeString, DataTypeNumber, DataTypeBoolean,
 		DataTypeDate, DataTypeDateTime, DataTypeArray,
-		DataTypeObject, DataTypeReference:
+		DataTypeObject, DataTypeReference,
+		DataTypeGeoPoint, DataTypeCurrency:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsScalar reports whether values of the data type are single values rather
+// than arrays or objects
+func (dt DataType) IsScalar() bool {
+	switch dt {
+	case DataTypeString, DataTypeNumber, DataTypeBoolean,
+		DataTypeDate, DataTypeDateTime, DataTypeReference:
 		return true
 	default:
 		return false
@@ -65,19 +150,56 @@ const (
 	ValidatorMax       ValidatorType = "max"
 	ValidatorEnum      ValidatorType = "enum"
 	ValidatorFormat    ValidatorType = "format"
+	// ValidatorExpression compares properties of the same record, e.g. "endDate >= startDate"
+	ValidatorExpression ValidatorType = "expression"
 )
 
+// Formats a ValidatorFormat validator can require of a string value
+const (
+	FormatEmail = "email"
+	FormatURL   = "url"
+	FormatUUID  = "uuid"
+	FormatDate  = "date" // RFC 3339 full date, e.g. 2006-01-02
+)
+
+// ValidatorFormats lists the formats a ValidatorFormat validator accepts
+var ValidatorFormats = []string{FormatEmail, FormatURL, FormatUUID, FormatDate}
+
 // IsValid checks if the validator type is valid
 func (vt ValidatorType) IsValid() bool {
 	switch vt {
 	case ValidatorMinLength, ValidatorMaxLength, ValidatorPattern,
-		ValidatorMin, ValidatorMax, ValidatorEnum, ValidatorFormat:
+		ValidatorMin, ValidatorMax, ValidatorEnum, ValidatorFormat,
+		ValidatorExpression:
 		return true
 	default:
 		return false
 	}
 }
 
+// Copy returns a deep copy of the property that shares no maps, slices or
+// pointers with it
+func (p Property) Copy() Property {
+	copied := p
+	copied.DefaultValue = copyValue(p.DefaultValue)
+	copied.Validators = copyValidators(p.Validators)
+	copied.Metadata = copyMetadata(p.Metadata)
+	copied.ItemValidators = copyValidators(p.ItemValidators)
+	if p.Description != nil {
+		description := *p.Description
+		copied.Description = &description
+	}
+	if p.ReferencedObjectTypeID != nil {
+		id := *p.ReferencedObjectTypeID
+		copied.ReferencedObjectTypeID = &id
+	}
+	if p.DeprecationMessage != nil {
+		message := *p.DeprecationMessage
+		copied.DeprecationMessage = &message
+	}
+	return copied
+}
+
 // Validate validates the property definition
 func (p *Property) Validate() error {
 	if p.Name == "" {
@@ -88,6 +210,10 @@ func (p *Property) Validate() error {
 		return ErrInvalidPropertyNameFormat
 	}
 
+	if err := validator.ValidateNameCase("property name", p.Name); err != nil {
+		return err
+	}
+
 	if p.DisplayName == "" {
 		return ErrRequiredField("displayName")
 	}
@@ -96,6 +222,10 @@ func (p *Property) Validate() error {
 		return ErrInvalidDataType(string(p.DataType))
 	}
 
+	if err := ValidateMetadata("property "+p.Name, p.Metadata); err != nil {
+		return err
+	}
+
 	// Validate validators
 	for _, v := range p.Validators {
 		if err := p.validateValidator(v); err != nil {
@@ -103,6 +233,18 @@ func (p *Property) Validate() error {
 		}
 	}
 
+	if err := p.validateElementType(); err != nil {
+		return err
+	}
+
+	if p.ReferencedObjectTypeID != nil && p.DataType != DataTypeReference {
+		return fmt.Errorf("referenced object type only applies to reference type")
+	}
+
+	if p.DeprecationMessage != nil && !p.Deprecated {
+		return fmt.Errorf("deprecation message only applies to deprecated properties")
+	}
+
 	// Validate default value if provided
 	if p.DefaultValue != nil {
 		if err := p.validateDefaultValue(); err != nil {
@@ -145,8 +287,17 @@ func (p *Property) validateValidator(v Validator) error {
 		if !ok {
 			return fmt.Errorf("invalid pattern value")
 		}
-		if _, err := regexp.Compile(pattern); err != nil {
-			return fmt.Errorf("invalid regex pattern: %w", err)
+		if _, err := compilePattern(pattern); err != nil {
+			return err
+		}
+
+	case ValidatorFormat:
+		if p.DataType != DataTypeString {
+			return fmt.Errorf("format validator only applies to string type")
+		}
+		format, ok := v.Value.(string)
+		if !ok || !slices.Contains(ValidatorFormats, format) {
+			return fmt.Errorf("format validator value must be one of %s", strings.Join(ValidatorFormats, ", "))
 		}
 
 	case ValidatorEnum:
@@ -154,6 +305,15 @@ func (p *Property) validateValidator(v Validator) error {
 		if _, ok := v.Value.([]interface{}); !ok {
 			return fmt.Errorf("enum validator value must be an array")
 		}
+
+	case ValidatorExpression:
+		expr, ok := v.Value.(string)
+		if !ok {
+			return fmt.Errorf("expression validator value must be a string")
+		}
+		if _, err := parseComparison(expr); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -189,11 +349,24 @@ func (p *Property) validateDefaultValue() error {
 		if _, ok := p.DefaultValue.([]interface{}); !ok {
 			return fmt.Errorf("default value must be an array for array type")
 		}
+		if err := p.validateElements(p.DefaultValue); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
 
 	case DataTypeObject:
 		if _, ok := p.DefaultValue.(map[string]interface{}); !ok {
 			return fmt.Errorf("default value must be an object for object type")
 		}
+
+	case DataTypeGeoPoint:
+		if err := validateGeoPoint(p.DefaultValue); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+
+	case DataTypeCurrency:
+		if err := validateCurrency(p.DefaultValue); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
 	}
 
 	return nil
@@ -223,9 +396,75 @@ func (p *Property) ValidateValue(value interface{}) error {
 		}
 	}
 
+	return p.validateElements(value)
+}
+
+// validateElementType checks ElementType and ItemValidators against the
+// property's data type
+func (p *Property) validateElementType() error {
+	if p.DataType != DataTypeArray {
+		if p.ElementType != "" || len(p.ItemValidators) > 0 || p.AllowNestedArrays {
+			return fmt.Errorf("element type settings only apply to array type")
+		}
+		return nil
+	}
+
+	if p.ElementType == "" {
+		if len(p.ItemValidators) > 0 {
+			return fmt.Errorf("item validators require an element type")
+		}
+		return nil
+	}
+
+	if !p.ElementType.IsValid() {
+		return ErrInvalidDataType(string(p.ElementType))
+	}
+	if p.ElementType == DataTypeArray && !p.AllowNestedArrays {
+		return fmt.Errorf("nested arrays are not allowed for property %s", p.Name)
+	}
+
+	element := p.elementProperty(0)
+	for _, v := range p.ItemValidators {
+		if v.Type == ValidatorExpression {
+			return fmt.Errorf("expression validators cannot apply to array elements")
+		}
+		if err := element.validateValidator(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateElements validates each element of a typed array value against
+// ElementType and ItemValidators. Null elements are rejected.
+func (p *Property) validateElements(value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok || p.ElementType == "" {
+		return nil
+	}
+
+	for i, item := range items {
+		element := p.elementProperty(i)
+		if err := element.ValidateValue(item); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// elementProperty describes the element at index i of an array property, so
+// errors name it as property[i]
+func (p *Property) elementProperty(i int) *Property {
+	return &Property{
+		Name:       fmt.Sprintf("%s[%d]", p.Name, i),
+		DataType:   p.ElementType,
+		Required:   true,
+		Validators: p.ItemValidators,
+	}
+}
+
 // validateValueType validates that the value matches the expected type
 func (p *Property) validateValueType(value interface{}) error {
 	switch p.DataType {
@@ -265,6 +504,16 @@ func (p *Property) validateValueType(value interface{}) error {
 		default:
 			return fmt.Errorf("value must be a string or object for reference property %s", p.Name)
 		}
+
+	case DataTypeGeoPoint:
+		if err := validateGeoPoint(value); err != nil {
+			return fmt.Errorf("invalid value for property %s: %w", p.Name, err)
+		}
+
+	case DataTypeCurrency:
+		if err := validateCurrency(value); err != nil {
+			return fmt.Errorf("invalid value for property %s: %w", p.Name, err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+// shortStrings returns an array of strings whose elements are at most five
+// characters long
+func shortStrings() *Property {
+	return &Property{
+		Name: "tags", DisplayName: "Tags", DataType: DataTypeArray, ElementType: DataTypeString,
+		ItemValidators: []Validator{{Type: ValidatorMaxLength, Value: float64(5)}},
+	}
+}
+
+func TestArrayOfShortStringsIsValidDefinition(t *testing.T) {
+	// Act
+	err := shortStrings().Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
+
+func TestArrayElementsWithinMaxLengthAreAccepted(t *testing.T) {
+	// Act
+	err := shortStrings().ValidateValue([]interface{}{"a", "hello"})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateValue = %v, want nil", err)
+	}
+}
+
+func TestArrayElementOverMaxLengthIsRejected(t *testing.T) {
+	// Act
+	err := shortStrings().ValidateValue([]interface{}{"a", "too long"})
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "tags[1]") {
+		t.Errorf("ValidateValue = %v, want an error naming tags[1]", err)
+	}
+}
+
+func TestArrayElementOfWrongTypeIsRejected(t *testing.T) {
+	// Act
+	err := shortStrings().ValidateValue([]interface{}{"a", float64(1)})
+
+	// Assert
+	if err == nil {
+		t.Error("ValidateValue accepted a number in an array of strings")
+	}
+}
+
+func TestNullArrayElementIsRejected(t *testing.T) {
+	// Act
+	err := shortStrings().ValidateValue([]interface{}{nil})
+
+	// Assert
+	if err == nil {
+		t.Error("ValidateValue accepted a null element")
+	}
+}
+
+func TestUntypedArrayAcceptsMixedElements(t *testing.T) {
+	// Arrange
+	property := &Property{Name: "anything", DisplayName: "Anything", DataType: DataTypeArray}
+
+	// Act
+	err := property.ValidateValue([]interface{}{"a", float64(1), true})
+
+	// Assert
+	if err != nil {
+		t.Errorf("ValidateValue = %v, want untyped arrays unchanged", err)
+	}
+}
+
+func TestNestedArrayElementTypeNeedsOptIn(t *testing.T) {
+	// Arrange
+	property := &Property{Name: "matrix", DisplayName: "Matrix", DataType: DataTypeArray, ElementType: DataTypeArray}
+
+	// Act
+	err := property.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted nested arrays without AllowNestedArrays")
+	}
+}
+
+func TestNestedArrayElementTypeAllowedWithOptIn(t *testing.T) {
+	// Arrange
+	property := &Property{
+		Name: "matrix", DisplayName: "Matrix", DataType: DataTypeArray, ElementType: DataTypeArray, AllowNestedArrays: true,
+	}
+
+	// Act
+	err := property.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nested arrays allowed", err)
+	}
+}
+
+func TestItemValidatorsNeedElementType(t *testing.T) {
+	// Arrange
+	property := shortStrings()
+	property.ElementType = ""
+
+	// Act
+	err := property.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted item validators without an element type")
+	}
+}
+
+func TestElementTypeOnlyAppliesToArrays(t *testing.T) {
+	// Arrange
+	property := &Property{Name: "email", DisplayName: "Email", DataType: DataTypeString, ElementType: DataTypeString}
+
+	// Act
+	err := property.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate accepted an element type on a string property")
+	}
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// manyProperties returns count distinct string properties
+func manyProperties(count int) []Property {
+	properties := make([]Property, count)
+	for i := range properties {
+		properties[i] = Property{Name: fmt.Sprintf("field%d", i), DisplayName: fmt.Sprintf("Field %d", i), DataType: DataTypeString}
+	}
+	return properties
+}
+
+// withMaxProperties sets MaxProperties for the rest of the test
+func withMaxProperties(t *testing.T, limit int) {
+	t.Helper()
+	previous := MaxProperties
+	MaxProperties = limit
+	t.Cleanup(func() { MaxProperties = previous })
+}
+
+func TestObjectTypeAtPropertyLimitIsValid(t *testing.T) {
+	// Arrange
+	objectType := &ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Properties: manyProperties(DefaultMaxProperties)}
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil at %d properties", err, DefaultMaxProperties)
+	}
+}
+
+func TestObjectTypeOverPropertyLimitIsRejected(t *testing.T) {
+	// Arrange
+	objectType := &ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Properties: manyProperties(DefaultMaxProperties + 1)}
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if !errors.Is(err, ErrTooManyProperties) {
+		t.Errorf("Validate = %v, want ErrTooManyProperties at %d properties", err, DefaultMaxProperties+1)
+	}
+}
+
+func TestLinkTypeAtPropertyLimitIsValid(t *testing.T) {
+	// Arrange
+	linkType := linkTypeWith(Property{})
+	linkType.Properties = manyProperties(DefaultMaxProperties)
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil at %d properties", err, DefaultMaxProperties)
+	}
+}
+
+func TestLinkTypeOverPropertyLimitIsRejected(t *testing.T) {
+	// Arrange
+	linkType := linkTypeWith(Property{})
+	linkType.Properties = manyProperties(DefaultMaxProperties + 1)
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if !errors.Is(err, ErrTooManyProperties) {
+		t.Errorf("Validate = %v, want ErrTooManyProperties at %d properties", err, DefaultMaxProperties+1)
+	}
+}
+
+func TestConfiguredPropertyLimitIsEnforced(t *testing.T) {
+	// Arrange
+	withMaxProperties(t, 3)
+	objectType := &ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Properties: manyProperties(4)}
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if !errors.Is(err, ErrTooManyProperties) {
+		t.Errorf("Validate = %v, want ErrTooManyProperties over a limit of 3", err)
+	}
+}
+
+func TestZeroPropertyLimitDisablesCheck(t *testing.T) {
+	// Arrange
+	withMaxProperties(t, 0)
+	objectType := &ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Properties: manyProperties(DefaultMaxProperties + 1)}
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want no limit when MaxProperties is 0", err)
+	}
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// deprecatedProperty returns a deprecated property named name with message
+func deprecatedProperty(name, message string) Property {
+	return Property{Name: name, DisplayName: name, DataType: DataTypeString, Deprecated: true, DeprecationMessage: &message}
+}
+
+func TestWithoutDeprecatedKeepsActivePropertiesInOrder(t *testing.T) {
+	// Arrange
+	properties := []Property{
+		groupedProperty("name", "", 1),
+		deprecatedProperty("fax", "Use email"),
+		groupedProperty("email", "", 3),
+	}
+
+	// Act
+	active := WithoutDeprecated(properties)
+
+	// Assert
+	var names []string
+	for _, prop := range active {
+		names = append(names, prop.Name)
+	}
+	if !slices.Equal(names, []string{"name", "email"}) {
+		t.Errorf("properties = %v, want [name email]", names)
+	}
+	if len(properties) != 3 || properties[1].Name != "fax" {
+		t.Errorf("input = %v, want it left unchanged", properties)
+	}
+}
+
+func TestValidateRejectsDeprecationMessageOnActiveProperty(t *testing.T) {
+	// Arrange
+	prop := deprecatedProperty("fax", "Use email")
+	prop.Deprecated = false
+
+	// Act
+	err := prop.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Validate = nil, want the message rejected")
+	}
+}
+
+func TestValidateAcceptsDeprecatedProperty(t *testing.T) {
+	// Arrange
+	prop := deprecatedProperty("fax", "Use email")
+
+	// Act
+	err := prop.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
+
+func TestCopyDoesNotShareDeprecationMessage(t *testing.T) {
+	// Arrange
+	prop := deprecatedProperty("fax", "Use email")
+
+	// Act
+	copied := prop.Copy()
+	*copied.DeprecationMessage = "Use phone"
+
+	// Assert
+	if *prop.DeprecationMessage != "Use email" {
+		t.Errorf("original message = %q, want it unchanged by the copy", *prop.DeprecationMessage)
+	}
+}
+
+func TestDeprecationOmittedFromJSONOfActiveProperty(t *testing.T) {
+	// Arrange
+	prop := groupedProperty("email", "", 1)
+
+	// Act
+	data, err := json.Marshal(prop)
+
+	// Assert
+	var fields map[string]interface{}
+	_ = json.Unmarshal(data, &fields)
+	if _, ok := fields["deprecated"]; err != nil || ok {
+		t.Errorf("JSON = %s, want no deprecated field", data)
+	}
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// withUniqueDisplayNames sets UniquePropertyDisplayNames for the rest of the
+// test
+func withUniqueDisplayNames(t *testing.T, unique bool) {
+	t.Helper()
+	previous := UniquePropertyDisplayNames
+	UniquePropertyDisplayNames = unique
+	t.Cleanup(func() { UniquePropertyDisplayNames = previous })
+}
+
+// sharedDisplayNames returns two distinctly named properties both shown as
+// Name, differing only in case and surrounding space
+func sharedDisplayNames() []Property {
+	return []Property{
+		{Name: "first_name", DisplayName: "Name", DataType: DataTypeString},
+		{Name: "full_name", DisplayName: " name ", DataType: DataTypeString},
+	}
+}
+
+func TestObjectTypeWithSharedDisplayNamesIsValidByDefault(t *testing.T) {
+	// Arrange
+	withUniqueDisplayNames(t, false)
+	objectType := &ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Properties: sharedDisplayNames()}
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil outside strict mode", err)
+	}
+}
+
+func TestStrictObjectTypeRejectsSharedDisplayNames(t *testing.T) {
+	// Arrange
+	withUniqueDisplayNames(t, true)
+	objectType := &ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Properties: sharedDisplayNames()}
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err == nil {
+		t.Fatal("Validate = nil, want the shared display name rejected")
+	}
+	for _, part := range []string{"display name", "first_name", "full_name"} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("error %q does not mention %s", err, part)
+		}
+	}
+}
+
+func TestStrictObjectTypeAcceptsDistinctDisplayNames(t *testing.T) {
+	// Arrange
+	withUniqueDisplayNames(t, true)
+	objectType := &ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Properties: manyProperties(3)}
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil for distinct display names", err)
+	}
+}
+
+func TestStrictLinkTypeRejectsSharedDisplayNames(t *testing.T) {
+	// Arrange
+	withUniqueDisplayNames(t, true)
+	linkType := linkTypeWith(sharedDisplayNames()[0])
+	linkType.Properties = append(linkType.Properties, sharedDisplayNames()[1])
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "full_name") {
+		t.Errorf("Validate = %v, want the shared display name rejected", err)
+	}
+}
+
+func TestLinkTypeWithSharedDisplayNamesIsValidByDefault(t *testing.T) {
+	// Arrange
+	withUniqueDisplayNames(t, false)
+	linkType := linkTypeWith(sharedDisplayNames()[0])
+	linkType.Properties = append(linkType.Properties, sharedDisplayNames()[1])
+
+	// Act
+	err := linkType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil outside strict mode", err)
+	}
+}
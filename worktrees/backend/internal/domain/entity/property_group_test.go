@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// groupedProperty returns a property named name in group at order
+func groupedProperty(name, group string, order int) Property {
+	return Property{Name: name, DisplayName: name, DataType: DataTypeString, Group: group, Order: order}
+}
+
+// layout returns the group names of groups and the property names of each
+func layout(groups []PropertyGroup) ([]string, [][]string) {
+	names := make([]string, len(groups))
+	members := make([][]string, len(groups))
+	for i, group := range groups {
+		names[i] = group.Name
+		for _, prop := range group.Properties {
+			members[i] = append(members[i], prop.Name)
+		}
+	}
+	return names, members
+}
+
+func TestGroupPropertiesOrdersGroupsByFirstProperty(t *testing.T) {
+	// Arrange
+	properties := []Property{
+		groupedProperty("iban", "Billing", 3),
+		groupedProperty("email", "Contact", 1),
+		groupedProperty("phone", "Contact", 2),
+		groupedProperty("vat", "Billing", 4),
+	}
+
+	// Act
+	names, members := layout(GroupProperties(properties))
+
+	// Assert
+	if !slices.Equal(names, []string{"Contact", "Billing"}) {
+		t.Errorf("groups = %v, want Contact then Billing", names)
+	}
+	if !slices.Equal(members[0], []string{"email", "phone"}) || !slices.Equal(members[1], []string{"iban", "vat"}) {
+		t.Errorf("members = %v, want [email phone] [iban vat]", members)
+	}
+}
+
+func TestGroupPropertiesKeepsGivenOrderForEqualOrder(t *testing.T) {
+	// Arrange
+	properties := []Property{
+		groupedProperty("street", "Address", 0),
+		groupedProperty("city", "Address", 0),
+		groupedProperty("zip", "Address", 0),
+	}
+
+	// Act
+	for i := 0; i < 10; i++ {
+		_, members := layout(GroupProperties(properties))
+
+		// Assert
+		if !slices.Equal(members[0], []string{"street", "city", "zip"}) {
+			t.Fatalf("members = %v, want the given order", members[0])
+		}
+	}
+}
+
+func TestGroupPropertiesCollectsUngroupedUnderEmptyName(t *testing.T) {
+	// Arrange
+	properties := []Property{
+		groupedProperty("notes", "", 2),
+		groupedProperty("email", "Contact", 1),
+		groupedProperty("id", "", 0),
+	}
+
+	// Act
+	names, members := layout(GroupProperties(properties))
+
+	// Assert
+	if !slices.Equal(names, []string{"", "Contact"}) || !slices.Equal(members[0], []string{"id", "notes"}) {
+		t.Errorf("groups = %v %v, want the ungrouped [id notes] first", names, members)
+	}
+}
+
+func TestGroupPropertiesLeavesInputOrder(t *testing.T) {
+	// Arrange
+	properties := []Property{groupedProperty("b", "", 2), groupedProperty("a", "", 1)}
+
+	// Act
+	GroupProperties(properties)
+
+	// Assert
+	if properties[0].Name != "b" {
+		t.Errorf("properties reordered to %v", properties)
+	}
+}
+
+func TestGroupPropertiesWithoutPropertiesIsEmpty(t *testing.T) {
+	// Act
+	groups := GroupProperties(nil)
+
+	// Assert
+	if groups == nil || len(groups) != 0 {
+		t.Errorf("groups = %#v, want an empty list", groups)
+	}
+}
+
+func TestPropertyGroupSurvivesJSONRoundTrip(t *testing.T) {
+	// Arrange
+	data, _ := json.Marshal(groupedProperty("email", "Contact", 1))
+	var decoded Property
+
+	// Act
+	err := json.Unmarshal(data, &decoded)
+
+	// Assert
+	if err != nil || decoded.Group != "Contact" {
+		t.Errorf("group = %q (err %v), want Contact", decoded.Group, err)
+	}
+}
+
+func TestValidateAcceptsGroupedProperties(t *testing.T) {
+	// Arrange
+	objectType := &ObjectType{Name: "Customer", DisplayName: "Customer", Properties: []Property{
+		groupedProperty("email", "Contact", 1),
+		groupedProperty("iban", "Billing", 2),
+	}}
+
+	// Act
+	err := objectType.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/openfoundry/oms/internal/pkg/validator"
+)
+
+// withNameCasePolicy sets validator.NameCasePolicy for the rest of the test
+func withNameCasePolicy(t *testing.T, policy string) {
+	t.Helper()
+	previous := validator.NameCasePolicy
+	validator.NameCasePolicy = policy
+	t.Cleanup(func() { validator.NameCasePolicy = previous })
+}
+
+func TestPropertyValidateEnforcesNameCasePolicy(t *testing.T) {
+	cases := []struct {
+		policy string
+		name   string
+		valid  bool
+	}{
+		{validator.NameCaseAny, "first_name", true},
+		{validator.NameCaseAny, "firstName", true},
+		{validator.NameCaseCamel, "firstName", true},
+		{validator.NameCaseCamel, "first_name", false},
+		{validator.NameCaseSnake, "first_name", true},
+		{validator.NameCaseSnake, "firstName", false},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		withNameCasePolicy(t, tc.policy)
+		property := Property{Name: tc.name, DisplayName: "Name", DataType: DataTypeString}
+
+		// Act
+		err := property.Validate()
+
+		// Assert
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: Validate(%q) = %v, want valid %t", tc.policy, tc.name, err, tc.valid)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "regexp"
+
+// DefaultTenantID is the tenant of requests that do not name one, and of
+// every definition created before tenants were introduced
+const DefaultTenantID = "default"
+
+// tenantIDPattern restricts tenant IDs to characters that are safe in cache
+// keys and key patterns
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// IsValidTenantID reports whether id is a valid tenant ID: up to 64 lowercase
+// letters, digits, underscores and hyphens, starting with a letter or digit
+func IsValidTenantID(id string) bool {
+	return tenantIDPattern.MatchString(id)
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsValidTenantID(t *testing.T) {
+	cases := []struct {
+		id   string
+		want bool
+	}{
+		{"acme", true},
+		{DefaultTenantID, true},
+		{"acme-eu_2", true},
+		{"9lives", true},
+		{strings.Repeat("a", 64), true},
+		{strings.Repeat("a", 65), false},
+		{"", false},
+		{"Acme", false},
+		{"_acme", false},
+		{"acme corp", false},
+		{"acme:*", false},
+		{"acme*", false},
+	}
+
+	for _, tc := range cases {
+		// Act
+		got := IsValidTenantID(tc.id)
+
+		// Assert
+		if got != tc.want {
+			t.Errorf("IsValidTenantID(%q) = %v, want %v", tc.id, got, tc.want)
+		}
+	}
+}
@@ -3,6 +3,10 @@ package entity
 import (
 	"fmt"
 	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/pkg/validator"
 )
 
 // isValidName checks if the name is valid for object types and link types
@@ -51,9 +55,9 @@ func applyValidator(validator Validator, value interface{}, dataType DataType) e
 		if !ok {
 			return fmt.Errorf("invalid pattern value")
 		}
-		matched, err := regexp.MatchString(pattern, str)
+		matched, err := matchPattern(pattern, str)
 		if err != nil {
-			return fmt.Errorf("invalid regex pattern: %w", err)
+			return err
 		}
 		if !matched {
 			return fmt.Errorf("value does not match pattern %s", pattern)
@@ -118,7 +122,83 @@ func applyValidator(validator Validator, value interface{}, dataType DataType) e
 		if !found {
 			return fmt.Errorf("value is not in enum")
 		}
+
+	case ValidatorFormat:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("value is not a string")
+		}
+		format, ok := validator.Value.(string)
+		if !ok {
+			return fmt.Errorf("invalid format value")
+		}
+		if err := checkFormat(format, str); err != nil {
+			return err
+		}
+
+	case ValidatorExpression:
+		// Needs the whole record; evaluated by ObjectType.ValidateInstance
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// checkFormat checks a string value against one of ValidatorFormats
+func checkFormat(format, value string) error {
+	switch format {
+	case FormatEmail:
+		return validator.ValidateEmail(value)
+	case FormatURL:
+		return validator.ValidateURL(value)
+	case FormatUUID:
+		if _, err := uuid.Parse(value); err != nil {
+			return fmt.Errorf("invalid UUID format")
+		}
+	case FormatDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return fmt.Errorf("invalid date format, expected YYYY-MM-DD")
+		}
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	return nil
+}
+
+// copyValue deep copies the JSON collections in value
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	}
+	return value
+}
+
+// copyMetadata deep copies a metadata map, keeping nil as nil
+func copyMetadata(metadata map[string]interface{}) map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+	return copyValue(metadata).(map[string]interface{})
+}
+
+// copyValidators deep copies validators and their values
+func copyValidators(validators []Validator) []Validator {
+	if validators == nil {
+		return nil
+	}
+	copied := make([]Validator, len(validators))
+	for i, v := range validators {
+		copied[i] = Validator{Type: v.Type, Value: copyValue(v.Value)}
+	}
+	return copied
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription asks for change events to be POSTed to an HTTP
+// endpoint. Deliveries are signed with Secret, which is never returned by
+// the API.
+type WebhookSubscription struct {
+	ID         uuid.UUID `json:"id"`
+	TenantID   string    `json:"tenantId"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"`
+	EventTypes []string  `json:"eventTypes"` // Empty matches every event type
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+	CreatedBy  string    `json:"createdBy"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	UpdatedBy  string    `json:"updatedBy"`
+}
+
+// Validate checks the subscription has an absolute http(s) URL and a secret
+func (s *WebhookSubscription) Validate() error {
+	if s.URL == "" {
+		return ErrRequiredField("url")
+	}
+
+	target, err := url.Parse(s.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: %s", ErrInvalidWebhookURL, s.URL)
+	}
+
+	if s.Secret == "" {
+		return ErrRequiredField("secret")
+	}
+
+	for _, eventType := range s.EventTypes {
+		if eventType == "" {
+			return ErrRequiredField("eventTypes")
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether events of eventType are delivered to the subscription
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,17 @@ package event
 
 import (
 	"context"
+	"fmt"
 	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/openfoundry/oms/internal/domain/entity"
 )
 
 // Event represents a domain event
 type Event struct {
+	// ID is a ULID. IDs sort lexicographically by creation time, and IDs
+	// created in the same millisecond by one process sort in creation order.
 	ID            string      `json:"id"`
 	EventType     string      `json:"eventType"`
 	AggregateID   string      `json:"aggregateId"`
@@ -16,6 +22,18 @@ type Event struct {
 	UserID        string      `json:"userId"`
 	Data          interface{} `json:"data"`
 	Metadata      map[string]string `json:"metadata,omitempty"`
+	// TenantID is the tenant the aggregate belongs to. Stream and webhook
+	// subscribers only receive events of their own tenant.
+	TenantID string `json:"tenantId,omitempty"`
+}
+
+// Tenant returns the tenant of the event, entity.DefaultTenantID for events
+// published before events carried one
+func (e Event) Tenant() string {
+	if e.TenantID == "" {
+		return entity.DefaultTenantID
+	}
+	return e.TenantID
 }
 
 // EventPublisher defines the interface for publishing events
@@ -24,9 +42,40 @@ type EventPublisher interface {
 	PublishBatch(ctx context.Context, events []Event) error
 }
 
-// EventStore defines the interface for storing events
+// EventStore defines the interface for storing events. Reads only return
+// events of the tenant on ctx.
 type EventStore interface {
 	Save(ctx context.Context, event Event) error
 	GetByAggregateID(ctx context.Context, aggregateID string) ([]Event, error)
 	GetByEventType(ctx context.Context, eventType string, limit int) ([]Event, error)
-}
\ No newline at end of file
+}
+
+// NewID generates an event ID. IDs are ULIDs, so they sort lexicographically
+// in generation order, including within a millisecond.
+func NewID() string {
+	return ulid.Make().String()
+}
+
+// EventHandler handles a single event
+type EventHandler func(ctx context.Context, event Event) error
+
+// ReplayEvents re-dispatches the stored events of an aggregate to handler in
+// publish order, so consumers can rebuild state derived from them. It stops
+// at the first event handler fails on.
+func ReplayEvents(ctx context.Context, store EventStore, aggregateID string, handler EventHandler) error {
+	events, err := store.GetByAggregateID(ctx, aggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to load events of %s: %w", aggregateID, err)
+	}
+
+	for _, evt := range events {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := handler(ctx, evt); err != nil {
+			return fmt.Errorf("failed to replay event %s: %w", evt.ID, err)
+		}
+	}
+
+	return nil
+}
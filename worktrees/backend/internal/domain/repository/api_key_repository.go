@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// APIKeyRepository defines the interface for API key lookup
+type APIKeyRepository interface {
+	// GetByHash returns the key stored under the hash, or
+	// entity.ErrAPIKeyNotFound
+	GetByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+}
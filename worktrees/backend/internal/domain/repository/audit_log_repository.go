@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// AuditFilter narrows an audit log query. Zero fields match everything.
+type AuditFilter struct {
+	EntityType string
+	EntityID   *uuid.UUID
+	Actor      string
+	Limit      int
+}
+
+// AuditLogRepository reads the audit log. Entries are written by the
+// repositories that perform the audited change, in the same transaction.
+type AuditLogRepository interface {
+	// List returns matching entries of the tenant on ctx, newest first
+	List(ctx context.Context, filter AuditFilter) ([]*entity.AuditEntry, error)
+}
+
+// auditEntryKey is the context key for the pending audit entry
+type auditEntryKey struct{}
+
+// WithAuditEntry returns a context carrying the audit entry that the next
+// mutation made under it records alongside its change. Entities the
+// mutation changes beyond the one the entry describes get entries of their
+// own under the same actor and correlation ID. An entry without an
+// EntityType only names the actor, for mutations such as batches that
+// record every entity they change themselves.
+func WithAuditEntry(ctx context.Context, entry *entity.AuditEntry) context.Context {
+	return context.WithValue(ctx, auditEntryKey{}, entry)
+}
+
+// AuditEntryFromContext returns the audit entry on ctx, or nil if none
+func AuditEntryFromContext(ctx context.Context) *entity.AuditEntry {
+	entry, _ := ctx.Value(auditEntryKey{}).(*entity.AuditEntry)
+	return entry
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func testCursor() PageCursor {
+	return PageCursor{SortBy: SortByName, Value: "a:b", ID: uuid.New()}
+}
+
+func TestSignedCursorRoundTrips(t *testing.T) {
+	// Arrange
+	codec := NewCursorCodec([]byte("key-1"), time.Hour, false)
+	cursor := testCursor()
+
+	// Act
+	decoded, err := codec.Decode(codec.Encode(cursor))
+
+	// Assert
+	if err != nil || *decoded != cursor {
+		t.Errorf("Decode = %+v, %v; want %+v", decoded, err, cursor)
+	}
+}
+
+func TestTamperedCursorIsRejected(t *testing.T) {
+	// Arrange
+	codec := NewCursorCodec([]byte("key-1"), time.Hour, false)
+	parts := strings.Split(codec.Encode(testCursor()), ".")
+	forged := base64.StdEncoding.EncodeToString([]byte("name:z:" + uuid.NewString()))
+	tampered := forged + "." + parts[1] + "." + parts[2]
+
+	// Act
+	_, err := codec.Decode(tampered)
+
+	// Assert
+	if !errors.Is(err, ErrCursorSignatureInvalid) {
+		t.Errorf("err = %v, want %v", err, ErrCursorSignatureInvalid)
+	}
+}
+
+func TestCursorSignedWithAnotherKeyIsRejected(t *testing.T) {
+	// Arrange
+	encoded := NewCursorCodec([]byte("old-key"), time.Hour, false).Encode(testCursor())
+
+	// Act
+	_, err := NewCursorCodec([]byte("new-key"), time.Hour, false).Decode(encoded)
+
+	// Assert
+	if !errors.Is(err, ErrCursorSignatureInvalid) {
+		t.Errorf("err = %v, want %v", err, ErrCursorSignatureInvalid)
+	}
+}
+
+func TestExpiredCursorIsRejected(t *testing.T) {
+	// Arrange
+	codec := NewCursorCodec([]byte("key-1"), time.Minute, false)
+	data := strings.Split(codec.Encode(testCursor()), ".")[0]
+	signed := data + "." + strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	expired := signed + "." + codec.sign(signed)
+
+	// Act
+	_, err := codec.Decode(expired)
+
+	// Assert
+	if !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("err = %v, want %v", err, ErrCursorExpired)
+	}
+}
+
+func TestUnsignedCursorIsRejected(t *testing.T) {
+	// Arrange
+	unsigned := (&CursorCodec{}).Encode(testCursor())
+
+	// Act
+	_, err := NewCursorCodec([]byte("key-1"), time.Hour, false).Decode(unsigned)
+
+	// Assert
+	if !errors.Is(err, ErrCursorUnsigned) {
+		t.Errorf("err = %v, want %v", err, ErrCursorUnsigned)
+	}
+}
+
+func TestUnsignedCursorIsAcceptedDuringRollout(t *testing.T) {
+	// Arrange
+	cursor := testCursor()
+	unsigned := (&CursorCodec{}).Encode(cursor)
+
+	// Act
+	decoded, err := NewCursorCodec([]byte("key-1"), time.Hour, true).Decode(unsigned)
+
+	// Assert
+	if err != nil || *decoded != cursor {
+		t.Errorf("Decode = %+v, %v; want %+v", decoded, err, cursor)
+	}
+}
+
+func TestCodecsWithDifferentSettingsDoNotInterfere(t *testing.T) {
+	// Arrange
+	signing := NewCursorCodec([]byte("key-1"), time.Hour, false)
+	unsigned := &CursorCodec{}
+	cursor := testCursor()
+
+	// Act
+	signed := signing.Encode(cursor)
+	plain := unsigned.Encode(cursor)
+
+	// Assert
+	if strings.Count(signed, ".") != 2 || strings.Contains(plain, ".") {
+		t.Errorf("signed = %q, plain = %q, want only the first signed", signed, plain)
+	}
+	if decoded, err := unsigned.Decode(signed); err != nil || *decoded != cursor {
+		t.Errorf("unsigned Decode = %+v, %v; want %+v", decoded, err, cursor)
+	}
+}
@@ -1,6 +1,10 @@
 package repository
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // Common repository errors
 var (
@@ -18,4 +22,24 @@ var (
 	
 	// ErrOptimisticLock indicates that the item was modified by another process
 	ErrOptimisticLock = errors.New("optimistic lock failure")
-)
\ No newline at end of file
+
+	// ErrQueryTimeout indicates that the database cancelled a query that ran
+	// past the statement timeout
+	ErrQueryTimeout = fmt.Errorf("query timed out: %w", context.DeadlineExceeded)
+)
+
+// BatchItemError reports which item of a batch operation failed
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("batch item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
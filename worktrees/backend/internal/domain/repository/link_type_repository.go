@@ -2,23 +2,38 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/openfoundry/oms/internal/domain/entity"
 )
 
-// LinkTypeRepository defines the interface for link type persistence
+// LinkTypeRepository defines the interface for link type persistence.
+// Methods see and change only the link types of the tenant on ctx, except
+// ListPurgeable, which spans all tenants. Under a context from
+// WithAuditEntry, mutating methods record an audit entry for every link type
+// they change, in the same transaction.
 type LinkTypeRepository interface {
 	// Basic CRUD operations
 	Create(ctx context.Context, linkType *entity.LinkType) error
+	// CreatePair creates a link type and its inverse atomically
+	CreatePair(ctx context.Context, linkType, inverse *entity.LinkType) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.LinkType, error)
 	GetByName(ctx context.Context, name string) (*entity.LinkType, error)
+	// Update saves linkType, including its PairID and IsInverse
 	Update(ctx context.Context, linkType *entity.LinkType) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Purge permanently removes a soft-deleted link type and its version
+	// history; live link types are refused with ErrLinkTypeNotFound
+	Purge(ctx context.Context, id uuid.UUID) error
+	// ListPurgeable returns up to limit link types soft-deleted before
+	// deletedBefore, oldest deletions first, whatever their tenant
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.LinkType, error)
 
 	// Query operations
 	List(ctx context.Context, filter LinkTypeFilter) ([]*entity.LinkType, error)
 	Count(ctx context.Context, filter LinkTypeFilter) (int64, error)
+	Search(ctx context.Context, query string, limit int) ([]*entity.LinkType, error)
 
 	// Relationship queries
 	GetBySourceObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error)
@@ -26,7 +41,12 @@ type LinkTypeRepository interface {
 	GetByObjectTypes(ctx context.Context, sourceID, targetID uuid.UUID) ([]*entity.LinkType, error)
 
 	// Validation
-	CheckCircularReference(ctx context.Context, sourceID, targetID uuid.UUID) (bool, error)
+
+	// CheckCircularReference reports whether adding a link from sourceID to
+	// targetID would close a cycle through existing link types, ignoring
+	// generated inverses. It returns the cycle as a path of object type IDs
+	// starting and ending at sourceID, or nil when the link is acyclic.
+	CheckCircularReference(ctx context.Context, sourceID, targetID uuid.UUID) ([]uuid.UUID, error)
 }
 
 // LinkTypeFilter represents filtering options for link types
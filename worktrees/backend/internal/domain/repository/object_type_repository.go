@@ -8,43 +8,158 @@ import (
 	"github.com/openfoundry/oms/internal/domain/entity"
 )
 
-// ObjectTypeRepository defines the interface for object type persistence
+// ObjectTypeRepository defines the interface for object type persistence.
+// Methods see and change only the object types of the tenant on ctx, except
+// ListPurgeable and ListTenants, which span all tenants. Under a context from
+// WithAuditEntry, mutating methods record an audit entry for every object
+// and link type they change, in the same transaction.
 type ObjectTypeRepository interface {
 	// Basic CRUD operations
 	Create(ctx context.Context, objectType *entity.ObjectType) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error)
 	GetByName(ctx context.Context, name string) (*entity.ObjectType, error)
-	Update(ctx context.Context, objectType *entity.ObjectType) error
+	// GetByNameIncludingDeleted returns the live object type named name, if
+	// any, followed by soft-deleted ones that held the name
+	GetByNameIncludingDeleted(ctx context.Context, name string) ([]*entity.ObjectType, error)
+	// Update fails with ErrOptimisticLock unless the stored version is
+	// objectType.Version - 1. changeDescription is recorded on the new
+	// version and may be empty.
+	Update(ctx context.Context, objectType *entity.ObjectType, changeDescription string) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteCascade(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	Purge(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	ListDeleted(ctx context.Context, limit int) ([]*entity.ObjectType, error)
+	// ListPurgeable returns up to limit object types soft-deleted before
+	// deletedBefore that Purge can remove: none has child object types, live
+	// link types or link types that prevent deletes. Oldest deletions come
+	// first, whatever their tenant.
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.ObjectType, error)
+	// ListTenants returns the tenants that have live object types, sorted
+	ListTenants(ctx context.Context) ([]string, error)
 
 	// Query operations
 	List(ctx context.Context, filter ObjectTypeFilter) ([]*entity.ObjectType, error)
 	Count(ctx context.Context, filter ObjectTypeFilter) (int64, error)
-	Search(ctx context.Context, query string, limit int) ([]*entity.ObjectType, error)
+	// Search returns one page of matches, best ranked first
+	Search(ctx context.Context, query string, limit int, opts SearchOptions) (*SearchPage, error)
+	SuggestNames(ctx context.Context, prefix string, limit int) ([]NameSuggestion, error)
+	// ListChildren returns live object types whose parent is id
+	ListChildren(ctx context.Context, id uuid.UUID) ([]*entity.ObjectType, error)
+	// ListReferencing returns live object types with a REFERENCE property
+	// pointing at id
+	ListReferencing(ctx context.Context, id uuid.UUID) ([]*entity.ObjectType, error)
+	// ListCategories and ListTags count live object types per category and tag
+	ListCategories(ctx context.Context) ([]CategoryCount, error)
+	ListTags(ctx context.Context) ([]TagCount, error)
 
 	// Version management
 	GetVersion(ctx context.Context, id uuid.UUID, version int) (*entity.ObjectType, error)
-	ListVersions(ctx context.Context, id uuid.UUID) ([]*ObjectTypeVersion, error)
+	// GetVersionAt returns the latest version recorded at or before at, or
+	// the earliest version when all are newer
+	GetVersionAt(ctx context.Context, id uuid.UUID, at time.Time) (*entity.ObjectType, error)
+	// ListVersions returns one page of versions, newest first
+	ListVersions(ctx context.Context, id uuid.UUID, filter VersionFilter) ([]*ObjectTypeVersion, error)
 	CompareVersions(ctx context.Context, id uuid.UUID, v1, v2 int) (*VersionDiff, error)
+	// CompareVersionRange returns the diff of each consecutive pair of
+	// versions from fromVersion to toVersion, oldest first
+	CompareVersionRange(ctx context.Context, id uuid.UUID, fromVersion, toVersion int) ([]*VersionDiff, error)
 
 	// Batch operations
 	BatchCreate(ctx context.Context, objectTypes []*entity.ObjectType) error
-	BatchUpdate(ctx context.Context, objectTypes []*entity.ObjectType) error
+	// BatchUpdate saves object types in one transaction, recording a version
+	// of each with changeDescription. Like Update, it fails with
+	// ErrOptimisticLock when an item's stored version is not Version-1.
+	BatchUpdate(ctx context.Context, objectTypes []*entity.ObjectType, changeDescription string) error
+	// BatchDelete soft deletes object types and their link types, returning
+	// the deleted link type IDs per object type
+	BatchDelete(ctx context.Context, ids []uuid.UUID) ([][]uuid.UUID, error)
+
+	// Index management
+	ListIndexes(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.ObjectTypeIndex, error)
+	SyncIndexes(ctx context.Context, objectTypeID uuid.UUID, added []entity.ObjectTypeIndex, removed []string) error
 }
 
 // ObjectTypeFilter represents filtering options for object types
 type ObjectTypeFilter struct {
-	Category      *string
-	Tags          []string
-	IsDeleted     *bool
-	CreatedAfter  *time.Time
-	CreatedBefore *time.Time
-	UpdatedAfter  *time.Time
-	UpdatedBefore *time.Time
+	Category        *string
+	Tags            []string
+	MetadataFilters map[string]string // Metadata values that must all match, by key
+	IsDeleted       *bool             // Only deleted when true; live when nil or false
+	IncludeDeleted  bool              // Both live and deleted, overriding IsDeleted
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	UpdatedAfter    *time.Time
+	UpdatedBefore   *time.Time
+	PageSize        int
+	PageCursor      string // Cursor-based pagination (items after the cursor)
+	Before          string // Backward pagination (items before the cursor)
+	SortBy          string // One of ObjectTypeSortFields, defaults to created_at
+	SortOrder       string // "asc" or "desc", defaults to desc
+}
+
+// Search modes
+const (
+	SearchModePlain  = "plain"  // All words, in any order
+	SearchModePhrase = "phrase" // Words adjacent and in order
+	SearchModePrefix = "prefix" // Every word as a prefix, for type-ahead
+)
+
+// SearchModes lists the supported search modes
+var SearchModes = []string{SearchModePlain, SearchModePhrase, SearchModePrefix}
+
+// SearchOptions narrows a full-text object type search
+type SearchOptions struct {
+	Mode         string // One of SearchModes, defaults to plain
+	Category     *string
+	Tags         []string // Matches object types carrying any of the tags
+	CreatedAfter *time.Time
+	Cursor       string // Returns matches after this SearchPage.NextCursor
+}
+
+// SearchPage is one page of full-text search results
+type SearchPage struct {
+	Results    []*entity.ObjectType `json:"results"`
+	NextCursor string               `json:"nextCursor,omitempty"` // Empty on the last page
+}
+
+// NameSuggestion is a lightweight object type match for autocomplete
+type NameSuggestion struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+// List page sizes. Repositories apply DefaultListPageSize when no page size is
+// given and never return more than MaxListPageSize rows from one List call.
+const (
+	DefaultListPageSize = 20
+	MaxListPageSize     = 200
+)
+
+// Version history page sizes
+const (
+	DefaultVersionPageSize = 20
+	MaxVersionPageSize     = 100
+)
+
+// VersionFilter selects a page of version history
+type VersionFilter struct {
 	PageSize      int
-	PageCursor    string // Cursor-based pagination
-	SortBy        string
-	SortOrder     string // "asc" or "desc"
+	BeforeVersion int // Only versions below this one; 0 starts from the newest
+}
+
+// CategoryCount is the number of object types in a category
+type CategoryCount struct {
+	Category string `json:"category"`
+	Count    int64  `json:"count"`
+}
+
+// TagCount is the number of object types carrying a tag
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
 }
 
 // ObjectTypeVersion represents a historical version of an object type
@@ -58,6 +173,10 @@ type ObjectTypeVersion struct {
 	CreatedBy        string              `json:"createdBy"`
 }
 
+// MaxVersionRange bounds the number of diffs one CompareVersionRange call
+// returns
+const MaxVersionRange = 100
+
 // VersionDiff represents the difference between two versions
 type VersionDiff struct {
 	ObjectTypeID uuid.UUID      `json:"objectTypeId"`
@@ -85,6 +204,7 @@ const (
 
 // PageCursor represents pagination cursor information
 type PageCursor struct {
-	Timestamp time.Time
-	ID        uuid.UUID
+	SortBy string    // Sort field the cursor was issued for
+	Value  string    // Sort key of the last item seen
+	ID     uuid.UUID // Tie-breaker for equal sort keys
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// tenantKey is the context key for the tenant a request acts in
+type tenantKey struct{}
+
+// WithTenant returns a context whose object and link type reads and writes
+// are confined to tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant on ctx, or entity.DefaultTenantID if none
+func TenantFromContext(ctx context.Context) string {
+	if tenant, _ := ctx.Value(tenantKey{}).(string); tenant != "" {
+		return tenant
+	}
+	return entity.DefaultTenantID
+}
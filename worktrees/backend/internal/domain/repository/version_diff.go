@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// CompareObjectTypes lists the field changes between two snapshots of an
+// object type. Properties are compared attribute by attribute, see
+// CompareProperties.
+func CompareObjectTypes(ot1, ot2 *entity.ObjectType) []FieldChange {
+	changes := []FieldChange{}
+
+	changes = appendIfChanged(changes, "name", ot1.Name, ot2.Name)
+	changes = appendIfChanged(changes, "displayName", ot1.DisplayName, ot2.DisplayName)
+	changes = appendIfChanged(changes, "description", ot1.Description, ot2.Description)
+	changes = appendIfChanged(changes, "category", ot1.Category, ot2.Category)
+	changes = appendIfChanged(changes, "tags", ot1.Tags, ot2.Tags)
+	changes = appendIfChanged(changes, "metadata", ot1.Metadata, ot2.Metadata)
+	changes = appendIfChanged(changes, "parentId", ot1.ParentID, ot2.ParentID)
+
+	return append(changes, CompareProperties(ot1.Properties, ot2.Properties)...)
+}
+
+// CompareProperties lists the properties added or removed between props1 and
+// props2, matched by name, and one change per altered attribute of the
+// properties present in both, with a path such as properties.email.validators.
+// Changes follow the order of props1 then props2.
+func CompareProperties(props1, props2 []entity.Property) []FieldChange {
+	var changes []FieldChange
+
+	// Create maps for easier comparison
+	props1Map := make(map[string]entity.Property)
+	props2Map := make(map[string]entity.Property)
+
+	for _, p := range props1 {
+		props1Map[p.Name] = p
+	}
+	for _, p := range props2 {
+		props2Map[p.Name] = p
+	}
+
+	// Check for removed and modified properties
+	for _, p1 := range props1 {
+		field := fmt.Sprintf("properties.%s", p1.Name)
+		p2, exists := props2Map[p1.Name]
+		if !exists {
+			changes = append(changes, FieldChange{
+				Field:    field,
+				OldValue: p1,
+				NewValue: nil,
+				Type:     ChangeTypeRemoved,
+			})
+			continue
+		}
+
+		changes = appendIfChanged(changes, field+".displayName", p1.DisplayName, p2.DisplayName)
+		changes = appendIfChanged(changes, field+".dataType", p1.DataType, p2.DataType)
+		changes = appendIfChanged(changes, field+".required", p1.Required, p2.Required)
+		changes = appendIfChanged(changes, field+".unique", p1.Unique, p2.Unique)
+		changes = appendIfChanged(changes, field+".indexed", p1.Indexed, p2.Indexed)
+		changes = appendIfChanged(changes, field+".defaultValue", p1.DefaultValue, p2.DefaultValue)
+		changes = appendIfChanged(changes, field+".description", p1.Description, p2.Description)
+		changes = appendIfChanged(changes, field+".validators", p1.Validators, p2.Validators)
+		changes = appendIfChanged(changes, field+".elementType", p1.ElementType, p2.ElementType)
+		changes = appendIfChanged(changes, field+".itemValidators", p1.ItemValidators, p2.ItemValidators)
+		changes = appendIfChanged(changes, field+".allowNestedArrays", p1.AllowNestedArrays, p2.AllowNestedArrays)
+		changes = appendIfChanged(changes, field+".referencedObjectTypeId", p1.ReferencedObjectTypeID, p2.ReferencedObjectTypeID)
+		changes = appendIfChanged(changes, field+".metadata", p1.Metadata, p2.Metadata)
+		changes = appendIfChanged(changes, field+".order", p1.Order, p2.Order)
+		changes = appendIfChanged(changes, field+".group", p1.Group, p2.Group)
+		changes = appendIfChanged(changes, field+".deprecated", p1.Deprecated, p2.Deprecated)
+		changes = appendIfChanged(changes, field+".deprecationMessage", p1.DeprecationMessage, p2.DeprecationMessage)
+	}
+
+	// Check for added properties
+	for _, p2 := range props2 {
+		if _, exists := props1Map[p2.Name]; !exists {
+			changes = append(changes, FieldChange{
+				Field:    fmt.Sprintf("properties.%s", p2.Name),
+				OldValue: nil,
+				NewValue: p2,
+				Type:     ChangeTypeAdded,
+			})
+		}
+	}
+
+	return changes
+}
+
+// appendIfChanged appends a modification of field when oldValue and newValue
+// differ
+func appendIfChanged(changes []FieldChange, field string, oldValue, newValue interface{}) []FieldChange {
+	if valuesEqual(oldValue, newValue) {
+		return changes
+	}
+	return append(changes, FieldChange{
+		Field:    field,
+		OldValue: oldValue,
+		NewValue: newValue,
+		Type:     ChangeTypeModified,
+	})
+}
+
+// valuesEqual compares values by their JSON form, so a snapshot read back from
+// JSON equals the value it was written from. Nil and empty collections are
+// equal, as are a nil pointer and a missing field.
+func valuesEqual(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return bytes.Equal(normalizeEmpty(aJSON), normalizeEmpty(bJSON))
+}
+
+// normalizeEmpty maps empty JSON collections to null
+func normalizeEmpty(data []byte) []byte {
+	switch string(data) {
+	case "[]", "{}":
+		return []byte("null")
+	}
+	return data
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// emailProperty returns the property each diff case starts from
+func emailProperty() entity.Property {
+	return entity.Property{
+		Name:        "email",
+		DisplayName: "Email",
+		DataType:    entity.DataTypeString,
+		Order:       1,
+		Validators:  []entity.Validator{{Type: entity.ValidatorMaxLength, Value: 255}},
+	}
+}
+
+// stringPtr returns a pointer to s
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestComparePropertiesReportsEachChangedAttribute(t *testing.T) {
+	cases := []struct {
+		field  string
+		change func(p *entity.Property)
+	}{
+		{"properties.email.displayName", func(p *entity.Property) { p.DisplayName = "E-mail" }},
+		{"properties.email.dataType", func(p *entity.Property) { p.DataType = entity.DataTypeNumber }},
+		{"properties.email.required", func(p *entity.Property) { p.Required = true }},
+		{"properties.email.unique", func(p *entity.Property) { p.Unique = true }},
+		{"properties.email.indexed", func(p *entity.Property) { p.Indexed = true }},
+		{"properties.email.defaultValue", func(p *entity.Property) { p.DefaultValue = "nobody@example.com" }},
+		{"properties.email.description", func(p *entity.Property) { p.Description = stringPtr("Primary contact address") }},
+		{"properties.email.validators", func(p *entity.Property) {
+			p.Validators = []entity.Validator{{Type: entity.ValidatorMaxLength, Value: 320}}
+		}},
+		{"properties.email.metadata", func(p *entity.Property) { p.Metadata = map[string]interface{}{"pii": true} }},
+		{"properties.email.order", func(p *entity.Property) { p.Order = 2 }},
+		{"properties.email.deprecated", func(p *entity.Property) { p.Deprecated = true }},
+		{"properties.email.deprecationMessage", func(p *entity.Property) { p.DeprecationMessage = stringPtr("Use contactEmail") }},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		changed := emailProperty()
+		tc.change(&changed)
+
+		// Act
+		changes := CompareProperties([]entity.Property{emailProperty()}, []entity.Property{changed})
+
+		// Assert
+		if len(changes) != 1 || changes[0].Field != tc.field || changes[0].Type != ChangeTypeModified {
+			t.Errorf("%s: changes = %+v, want one modification of %s", tc.field, changes, tc.field)
+		}
+	}
+}
+
+func TestCompareObjectTypesReportsEachChangedField(t *testing.T) {
+	cases := []struct {
+		field  string
+		change func(ot *entity.ObjectType)
+	}{
+		{"displayName", func(ot *entity.ObjectType) { ot.DisplayName = "Client" }},
+		{"description", func(ot *entity.ObjectType) { ot.Description = stringPtr("Someone who buys from us") }},
+		{"category", func(ot *entity.ObjectType) { ot.Category = stringPtr("sales") }},
+		{"tags", func(ot *entity.ObjectType) { ot.Tags = []string{"crm", "core"} }},
+		{"metadata", func(ot *entity.ObjectType) { ot.Metadata = map[string]interface{}{"owner": "sales"} }},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		original := &entity.ObjectType{Name: "Customer", DisplayName: "Customer", Tags: []string{"crm"}}
+		changed := &entity.ObjectType{Name: "Customer", DisplayName: "Customer", Tags: []string{"crm"}}
+		tc.change(changed)
+
+		// Act
+		changes := CompareObjectTypes(original, changed)
+
+		// Assert
+		if len(changes) != 1 || changes[0].Field != tc.field || changes[0].Type != ChangeTypeModified {
+			t.Errorf("%s: changes = %+v, want one modification of %s", tc.field, changes, tc.field)
+		}
+	}
+}
+
+func TestComparePropertiesReportsAddedAndRemoved(t *testing.T) {
+	// Arrange
+	phone := entity.Property{Name: "phone", DisplayName: "Phone", DataType: entity.DataTypeString}
+
+	// Act
+	changes := CompareProperties([]entity.Property{emailProperty()}, []entity.Property{phone})
+
+	// Assert
+	if len(changes) != 2 ||
+		changes[0].Field != "properties.email" || changes[0].Type != ChangeTypeRemoved ||
+		changes[1].Field != "properties.phone" || changes[1].Type != ChangeTypeAdded {
+		t.Errorf("changes = %+v, want email removed then phone added", changes)
+	}
+}
+
+func TestComparePropertiesIgnoresJSONRoundTrip(t *testing.T) {
+	// Arrange
+	data, err := json.Marshal(emailProperty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snapshot entity.Property
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	changes := CompareProperties([]entity.Property{snapshot}, []entity.Property{emailProperty()})
+
+	// Assert
+	if len(changes) != 0 {
+		t.Errorf("changes = %+v, want none for a property read back from JSON", changes)
+	}
+}
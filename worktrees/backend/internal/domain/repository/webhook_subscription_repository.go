@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription
+// persistence. Subscriptions belong to the tenant on ctx when created, and
+// every other method only sees subscriptions of the tenant on ctx.
+type WebhookSubscriptionRepository interface {
+	// Create creates a new subscription
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) error
+
+	// GetByID returns a subscription, or entity.ErrWebhookSubscriptionNotFound
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+
+	// List returns every subscription, oldest first
+	List(ctx context.Context) ([]*entity.WebhookSubscription, error)
+
+	// ListActive returns the subscriptions events of the tenant on ctx are delivered to
+	ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error)
+
+	// Update updates an existing subscription
+	Update(ctx context.Context, subscription *entity.WebhookSubscription) error
+
+	// Delete deletes a subscription
+	Delete(ctx context.Context, id uuid.UUID) error
+}
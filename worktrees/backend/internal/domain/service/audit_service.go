@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"go.uber.org/zap"
+)
+
+// AuditService reads the audit log of object and link type mutations
+type AuditService struct {
+	repo   repository.AuditLogRepository
+	logger *zap.Logger
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(repo repository.AuditLogRepository, logger *zap.Logger) *AuditService {
+	return &AuditService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// List returns the audit entries matching filter, newest first
+func (s *AuditService) List(ctx context.Context, filter repository.AuditFilter) ([]*entity.AuditEntry, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// withAuditEntry returns a context asking the repository to record the
+// change in the audit log, in the same transaction as the change itself.
+// before is nil for creates and after is nil for deletes. The returned
+// context should only be passed to the one repository call it describes.
+func withAuditEntry(ctx context.Context, entityType string, entityID uuid.UUID, action, actor string, before, after interface{}) context.Context {
+	return repository.WithAuditEntry(ctx, &entity.AuditEntry{
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Action:        action,
+		Actor:         actor,
+		OldValue:      before,
+		NewValue:      after,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+	})
+}
+
+// withAuditActor returns a context asking a repository call that derives
+// its changes itself, such as a batch, to record one audit entry per entity
+// it changes as made by actor, in the same transaction as the changes
+func withAuditActor(ctx context.Context, actor string) context.Context {
+	return repository.WithAuditEntry(ctx, &entity.AuditEntry{
+		Actor:         actor,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+	})
+}
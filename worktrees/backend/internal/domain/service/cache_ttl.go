@@ -0,0 +1,55 @@
+package service
+
+import (
+	"time"
+
+	"github.com/openfoundry/oms/internal/config"
+)
+
+// CacheTTLs sets how long each category of cache entry lives. Writes made
+// through the services clear affected entries regardless of their TTL.
+type CacheTTLs struct {
+	// ObjectType applies to object types cached by ID and by name
+	ObjectType time.Duration
+	// LinkType applies to link types cached by ID
+	LinkType time.Duration
+	// Search applies to object type and link type search results
+	Search time.Duration
+	// LinkTypeList bounds how long cached link type lists may lag behind
+	// writes that bypass the services
+	LinkTypeList time.Duration
+}
+
+// DefaultCacheTTLs returns the TTLs used for categories left unconfigured
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		ObjectType:   5 * time.Minute,
+		LinkType:     5 * time.Minute,
+		Search:       2 * time.Minute,
+		LinkTypeList: 5 * time.Minute,
+	}
+}
+
+// NewCacheTTLs builds cache TTLs from the Redis configuration. The global
+// TTL applies to object types and link types by ID unless their own TTL is
+// set; zero values keep the defaults.
+func NewCacheTTLs(cfg config.RedisConfig) CacheTTLs {
+	ttls := DefaultCacheTTLs()
+	if cfg.TTL > 0 {
+		ttls.ObjectType = cfg.TTL
+		ttls.LinkType = cfg.TTL
+	}
+	if cfg.ObjectTypeTTL > 0 {
+		ttls.ObjectType = cfg.ObjectTypeTTL
+	}
+	if cfg.LinkTypeTTL > 0 {
+		ttls.LinkType = cfg.LinkTypeTTL
+	}
+	if cfg.SearchTTL > 0 {
+		ttls.Search = cfg.SearchTTL
+	}
+	if cfg.LinkTypeListTTL > 0 {
+		ttls.LinkTypeList = cfg.LinkTypeListTTL
+	}
+	return ttls
+}
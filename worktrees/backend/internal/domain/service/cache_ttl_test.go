@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/config"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// customTTLs has a distinct TTL for every category
+var customTTLs = CacheTTLs{
+	ObjectType:   11 * time.Second,
+	LinkType:     22 * time.Second,
+	Search:       33 * time.Second,
+	LinkTypeList: 44 * time.Second,
+}
+
+// ttlOf returns the TTL of the first write to a key starting with prefix
+func ttlOf(t *testing.T, c *fakeCache, prefix string) time.Duration {
+	t.Helper()
+	for _, set := range c.sets {
+		if strings.HasPrefix(set.key, prefix) {
+			return set.ttl
+		}
+	}
+	t.Fatalf("no cache write under %s in %v", prefix, c.sets)
+	return 0
+}
+
+// newTTLObjectTypeService returns an object type service using customTTLs
+// over objectTypes, and its cache
+func newTTLObjectTypeService(objectTypes ...*entity.ObjectType) (*ObjectTypeService, *fakeCache) {
+	c := newFakeCache()
+	svc := NewObjectTypeService(newFakeObjectTypeRepo(objectTypes...), nil, c, customTTLs, &fakePublisher{},
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	return svc, c
+}
+
+// newTTLLinkTypeService returns a link type service using customTTLs over
+// linkTypes, and its cache
+func newTTLLinkTypeService(linkTypes ...*entity.LinkType) (*LinkTypeService, *fakeCache) {
+	c := newFakeCache()
+	svc := NewLinkTypeService(newFakeLinkTypeRepo(linkTypes...), newFakeObjectTypeRepo(), c, customTTLs, &fakePublisher{},
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	return svc, c
+}
+
+func TestGetObjectTypeCachesWithConfiguredTTL(t *testing.T) {
+	// Arrange
+	objectType := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer"}
+	svc, c := newTTLObjectTypeService(objectType)
+
+	// Act
+	_, _ = svc.GetByID(context.Background(), objectType.ID)
+
+	// Assert
+	if ttl := ttlOf(t, c, "object_type:"+objectType.ID.String()); ttl != customTTLs.ObjectType {
+		t.Errorf("TTL = %v, want %v", ttl, customTTLs.ObjectType)
+	}
+}
+
+func TestSearchCachesWithConfiguredTTL(t *testing.T) {
+	// Arrange
+	svc, c := newTTLObjectTypeService()
+
+	// Act
+	_, _ = svc.Search(context.Background(), "customer", 10, repository.SearchOptions{})
+
+	// Assert
+	if ttl := ttlOf(t, c, "object_types:search:"); ttl != customTTLs.Search {
+		t.Errorf("TTL = %v, want %v", ttl, customTTLs.Search)
+	}
+}
+
+func TestGetLinkTypeCachesWithConfiguredTTL(t *testing.T) {
+	// Arrange
+	linkType := &entity.LinkType{ID: uuid.New(), Name: "customerAccounts"}
+	svc, c := newTTLLinkTypeService(linkType)
+
+	// Act
+	_, _ = svc.GetByID(context.Background(), linkType.ID)
+
+	// Assert
+	if ttl := ttlOf(t, c, "link_type:"+linkType.ID.String()); ttl != customTTLs.LinkType {
+		t.Errorf("TTL = %v, want %v", ttl, customTTLs.LinkType)
+	}
+}
+
+func TestLinkTypeListCachesWithConfiguredTTL(t *testing.T) {
+	// Arrange
+	svc, c := newTTLLinkTypeService()
+
+	// Act
+	_, _ = svc.GetBySourceObjectType(context.Background(), uuid.New())
+
+	// Assert
+	if ttl := ttlOf(t, c, "link_types:source:"); ttl != customTTLs.LinkTypeList {
+		t.Errorf("TTL = %v, want %v", ttl, customTTLs.LinkTypeList)
+	}
+}
+
+func TestNewCacheTTLsFallsBackToGlobalTTL(t *testing.T) {
+	// Act
+	ttls := NewCacheTTLs(config.RedisConfig{TTL: time.Minute, LinkTypeTTL: time.Hour})
+
+	// Assert
+	if ttls.ObjectType != time.Minute || ttls.LinkType != time.Hour {
+		t.Errorf("ttls = %+v, want object types at the global 1m and link types at their own 1h", ttls)
+	}
+}
+
+func TestNewCacheTTLsKeepsDefaultsWhenUnset(t *testing.T) {
+	// Act
+	ttls := NewCacheTTLs(config.RedisConfig{})
+
+	// Assert
+	if ttls != DefaultCacheTTLs() {
+		t.Errorf("ttls = %+v, want the defaults %+v", ttls, DefaultCacheTTLs())
+	}
+}
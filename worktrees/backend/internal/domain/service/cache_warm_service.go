@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
+	"go.uber.org/zap"
+)
+
+// ErrCacheWarmInProgress indicates a cache warming run was requested while
+// another one is still going
+var ErrCacheWarmInProgress = errors.New("cache warming is already in progress")
+
+// CacheWarmConfig bounds the load a cache warming run puts on PostgreSQL and Redis
+type CacheWarmConfig struct {
+	// Concurrency is the number of workers writing cache entries
+	Concurrency int
+	// Rate caps the entities cached per second across all workers; 0 disables the cap
+	Rate float64
+}
+
+// CacheWarmStatus reports the progress of the current or last cache warming run
+type CacheWarmStatus struct {
+	Running     bool       `json:"running"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+	ObjectTypes int        `json:"objectTypes"`
+	LinkTypes   int        `json:"linkTypes"`
+	Failed      int        `json:"failed"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// CacheWarmer loads every live object type and link type of every tenant into
+// the cache, so the first requests after a cold start or cache flush do not
+// all miss
+type CacheWarmer struct {
+	objectTypeRepo repository.ObjectTypeRepository
+	linkTypeRepo   repository.LinkTypeRepository
+	// cursors encodes the page cursors the repositories are listed with
+	cursors   *repository.CursorCodec
+	cache     cache.CacheService
+	cacheTTLs CacheTTLs
+	config    CacheWarmConfig
+	logger    *zap.Logger
+
+	mu     sync.Mutex
+	status CacheWarmStatus
+}
+
+// NewCacheWarmer creates a new cache warmer
+func NewCacheWarmer(
+	objectTypeRepo repository.ObjectTypeRepository,
+	linkTypeRepo repository.LinkTypeRepository,
+	cursors *repository.CursorCodec,
+	cache cache.CacheService,
+	cacheTTLs CacheTTLs,
+	config CacheWarmConfig,
+	logger *zap.Logger,
+) *CacheWarmer {
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	return &CacheWarmer{
+		objectTypeRepo: objectTypeRepo,
+		linkTypeRepo:   linkTypeRepo,
+		cursors:        cursors,
+		cache:          cache,
+		cacheTTLs:      cacheTTLs,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// Status returns the progress of the current run, or the summary of the last one
+func (w *CacheWarmer) Status() CacheWarmStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+// Warm runs a cache warming pass and returns its summary. It fails with
+// ErrCacheWarmInProgress if another pass is running.
+func (w *CacheWarmer) Warm(ctx context.Context) (CacheWarmStatus, error) {
+	if err := w.begin(); err != nil {
+		return CacheWarmStatus{}, err
+	}
+	err := w.run(ctx)
+	return w.Status(), err
+}
+
+// Start begins a cache warming pass in the background and returns once it
+// has started. The pass stops early when ctx is cancelled.
+func (w *CacheWarmer) Start(ctx context.Context) error {
+	if err := w.begin(); err != nil {
+		return err
+	}
+	go func() {
+		_ = w.run(ctx)
+	}()
+	return nil
+}
+
+// begin marks a run as started, resetting the counters of the previous one
+func (w *CacheWarmer) begin() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.status.Running {
+		return ErrCacheWarmInProgress
+	}
+	now := time.Now()
+	w.status = CacheWarmStatus{Running: true, StartedAt: &now}
+	return nil
+}
+
+// run pages through the object types then the link types of each tenant,
+// handing each to a pool of workers that write it to the cache. Listing
+// errors abort the run; cache write errors are counted and skipped.
+func (w *CacheWarmer) run(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "CacheWarmer.Warm")
+	defer span.End()
+
+	w.logger.Info("Cache warming started",
+		zap.Int("concurrency", w.config.Concurrency),
+		zap.Float64("rate", w.config.Rate))
+
+	defer func() {
+		status := w.finish(err)
+		if err != nil {
+			recordSpanError(span, err)
+			w.logger.Error("Cache warming failed",
+				zap.Int("object_types", status.ObjectTypes),
+				zap.Int("link_types", status.LinkTypes),
+				zap.Int("failed", status.Failed),
+				zap.Error(err))
+			return
+		}
+		w.logger.Info("Cache warming finished",
+			zap.Int("object_types", status.ObjectTypes),
+			zap.Int("link_types", status.LinkTypes),
+			zap.Int("failed", status.Failed),
+			zap.Duration("duration", status.FinishedAt.Sub(*status.StartedAt)))
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var limit <-chan time.Time
+	if w.config.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / w.config.Rate))
+		defer ticker.Stop()
+		limit = ticker.C
+	}
+
+	jobs := make(chan func(context.Context), w.config.Concurrency)
+	var workers sync.WaitGroup
+	for i := 0; i < w.config.Concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for job := range jobs {
+				if limit != nil {
+					select {
+					case <-limit:
+					case <-ctx.Done():
+						continue
+					}
+				}
+				job(ctx)
+			}
+		}()
+	}
+
+	err = w.enqueueTenants(ctx, jobs)
+	if err != nil {
+		cancel()
+	}
+	close(jobs)
+	workers.Wait()
+
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+// finish records the end of a run and returns its summary
+func (w *CacheWarmer) finish(err error) CacheWarmStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	w.status.Running = false
+	w.status.FinishedAt = &now
+	if err != nil {
+		w.status.Error = err.Error()
+	}
+	return w.status
+}
+
+// record counts one cached entity, or one failure when err is set
+func (w *CacheWarmer) record(counter *int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err != nil {
+		w.status.Failed++
+		return
+	}
+	*counter++
+}
+
+// enqueueTenants queues the object types then the link types of each tenant
+func (w *CacheWarmer) enqueueTenants(ctx context.Context, jobs chan<- func(context.Context)) error {
+	tenants, err := w.objectTypeRepo.ListTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	for _, tenant := range tenants {
+		tenantCtx := repository.WithTenant(ctx, tenant)
+		if err := w.enqueueObjectTypes(tenantCtx, jobs); err != nil {
+			return err
+		}
+		if err := w.enqueueLinkTypes(tenantCtx, jobs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueObjectTypes queues every live object type of the tenant on ctx for
+// caching by ID and by name
+func (w *CacheWarmer) enqueueObjectTypes(ctx context.Context, jobs chan<- func(context.Context)) error {
+	filter := repository.ObjectTypeFilter{
+		PageSize: repository.MaxListPageSize,
+		SortBy:   repository.SortByCreatedAt,
+	}
+	for {
+		page, err := w.objectTypeRepo.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list object types: %w", err)
+		}
+		sortProperties(page...)
+
+		for _, objectType := range page {
+			objectType := objectType
+			job := func(ctx context.Context) {
+				ctx = repository.WithTenant(ctx, objectType.TenantID)
+				err := w.cache.Set(ctx, fmt.Sprintf("object_type:%s", objectType.ID.String()), objectType, w.cacheTTLs.ObjectType)
+				if err == nil {
+					err = w.cache.Set(ctx, fmt.Sprintf("object_type:name:%s", objectType.Name), objectType, w.cacheTTLs.ObjectType)
+				}
+				if err != nil {
+					w.logger.Warn("Failed to cache object type",
+						zap.String("id", objectType.ID.String()),
+						zap.Error(err))
+				}
+				w.record(&w.status.ObjectTypes, err)
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		w.logProgress()
+		if len(page) < filter.PageSize {
+			return nil
+		}
+		filter.PageCursor = w.cursors.Encode(repository.NewObjectTypeCursor(page[len(page)-1], filter.SortBy))
+	}
+}
+
+// enqueueLinkTypes queues every live link type of the tenant on ctx for
+// caching by ID
+func (w *CacheWarmer) enqueueLinkTypes(ctx context.Context, jobs chan<- func(context.Context)) error {
+	filter := repository.LinkTypeFilter{
+		PageSize: repository.MaxListPageSize,
+		SortBy:   repository.SortByCreatedAt,
+	}
+	for {
+		page, err := w.linkTypeRepo.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list link types: %w", err)
+		}
+
+		for _, linkType := range page {
+			linkType := linkType
+			job := func(ctx context.Context) {
+				ctx = repository.WithTenant(ctx, linkType.TenantID)
+				err := w.cache.Set(ctx, fmt.Sprintf("link_type:%s", linkType.ID.String()), linkType, w.cacheTTLs.LinkType)
+				if err != nil {
+					w.logger.Warn("Failed to cache link type",
+						zap.String("id", linkType.ID.String()),
+						zap.Error(err))
+				}
+				w.record(&w.status.LinkTypes, err)
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		w.logProgress()
+		if len(page) < filter.PageSize {
+			return nil
+		}
+		filter.PageCursor = w.cursors.Encode(repository.NewLinkTypeCursor(page[len(page)-1]))
+	}
+}
+
+// logProgress logs the counts reached so far
+func (w *CacheWarmer) logProgress() {
+	status := w.Status()
+	w.logger.Info("Cache warming progress",
+		zap.Int("object_types", status.ObjectTypes),
+		zap.Int("link_types", status.LinkTypes),
+		zap.Int("failed", status.Failed))
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// tenantObjectTypeRepo pages through object types per tenant, like the
+// Postgres repository scoped to the tenant on ctx
+type tenantObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	byTenant map[string][]*entity.ObjectType
+	// listed, when set, blocks ListTenants until it is closed
+	listed chan struct{}
+	err    error
+}
+
+func (r *tenantObjectTypeRepo) ListTenants(ctx context.Context) ([]string, error) {
+	if r.listed != nil {
+		<-r.listed
+	}
+	var tenants []string
+	for tenant := range r.byTenant {
+		tenants = append(tenants, tenant)
+	}
+	slices.Sort(tenants)
+	return tenants, nil
+}
+
+func (r *tenantObjectTypeRepo) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return pageAfter(r.byTenant[repository.TenantFromContext(ctx)], filter.PageCursor, filter.PageSize,
+		func(ot *entity.ObjectType) uuid.UUID { return ot.ID }), nil
+}
+
+// tenantLinkTypeRepo pages through link types per tenant
+type tenantLinkTypeRepo struct {
+	repository.LinkTypeRepository
+	byTenant map[string][]*entity.LinkType
+}
+
+func (r *tenantLinkTypeRepo) List(ctx context.Context, filter repository.LinkTypeFilter) ([]*entity.LinkType, error) {
+	return pageAfter(r.byTenant[repository.TenantFromContext(ctx)], filter.PageCursor, filter.PageSize,
+		func(lt *entity.LinkType) uuid.UUID { return lt.ID }), nil
+}
+
+// pageAfter returns up to pageSize items following the one the cursor names
+func pageAfter[T any](items []T, cursor string, pageSize int, id func(T) uuid.UUID) []T {
+	start := 0
+	if cursor != "" {
+		decoded, _ := testCursors.Decode(cursor)
+		start = slices.IndexFunc(items, func(item T) bool { return id(item) == decoded.ID }) + 1
+	}
+	return items[start:min(start+pageSize, len(items))]
+}
+
+// tenantCache records the tenant on ctx of every write into a fakeCache,
+// keyed tenant/key, as the Redis cache namespaces keys
+type tenantCache struct {
+	*fakeCache
+	// fail, when set, fails writes of keys it returns true for
+	fail func(key string) bool
+}
+
+func (c *tenantCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if c.fail != nil && c.fail(key) {
+		return errors.New("redis unavailable")
+	}
+	return c.fakeCache.Set(ctx, repository.TenantFromContext(ctx)+"/"+key, value, ttl)
+}
+
+// warmFixture returns repositories holding more object types for acme than
+// fit on one page, plus a few for globex, and link types for both
+func warmFixture() (*tenantObjectTypeRepo, *tenantLinkTypeRepo) {
+	objectTypes := &tenantObjectTypeRepo{byTenant: map[string][]*entity.ObjectType{}}
+	linkTypes := &tenantLinkTypeRepo{byTenant: map[string][]*entity.LinkType{}}
+	counts := map[string]int{"acme": 2*repository.MaxListPageSize + 5, "globex": 3}
+	for tenant, count := range counts {
+		for i := 0; i < count; i++ {
+			objectTypes.byTenant[tenant] = append(objectTypes.byTenant[tenant], &entity.ObjectType{
+				ID: uuid.New(), Name: fmt.Sprintf("Type%d", i), DisplayName: "Type", TenantID: tenant, Version: 1,
+			})
+		}
+		for i := 0; i < 2; i++ {
+			linkTypes.byTenant[tenant] = append(linkTypes.byTenant[tenant], &entity.LinkType{
+				ID: uuid.New(), Name: fmt.Sprintf("link%d", i), TenantID: tenant,
+			})
+		}
+	}
+	return objectTypes, linkTypes
+}
+
+// newTestCacheWarmer builds a CacheWarmer with four workers and no rate cap
+func newTestCacheWarmer(objectTypes repository.ObjectTypeRepository, linkTypes repository.LinkTypeRepository, c *tenantCache) *CacheWarmer {
+	return NewCacheWarmer(objectTypes, linkTypes, testCursors, c, DefaultCacheTTLs(), CacheWarmConfig{Concurrency: 4}, zap.NewNop())
+}
+
+func TestWarmCachesEveryObjectAndLinkType(t *testing.T) {
+	// Arrange
+	objectTypes, linkTypes := warmFixture()
+	c := &tenantCache{fakeCache: newFakeCache()}
+	warmer := newTestCacheWarmer(objectTypes, linkTypes, c)
+
+	// Act
+	status, err := warmer.Warm(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Warm: %v", err)
+	}
+	for tenant, tenantTypes := range objectTypes.byTenant {
+		for _, ot := range tenantTypes {
+			var cached entity.ObjectType
+			if err := c.Get(context.Background(), tenant+"/object_type:"+ot.ID.String(), &cached); err != nil || cached.Name != ot.Name {
+				t.Fatalf("object type %s of %s cached as %+v, %v", ot.Name, tenant, cached, err)
+			}
+			if !c.has(tenant + "/object_type:name:" + ot.Name) {
+				t.Fatalf("object type %s of %s not cached by name", ot.Name, tenant)
+			}
+		}
+	}
+	for tenant, tenantLinks := range linkTypes.byTenant {
+		for _, lt := range tenantLinks {
+			if !c.has(tenant + "/link_type:" + lt.ID.String()) {
+				t.Errorf("link type %s of %s not cached", lt.Name, tenant)
+			}
+		}
+	}
+	if status.ObjectTypes != 2*repository.MaxListPageSize+8 || status.LinkTypes != 4 || status.Failed != 0 {
+		t.Errorf("status = %+v, want %d object types, 4 link types, no failures", status, 2*repository.MaxListPageSize+8)
+	}
+	if status.Running || status.FinishedAt == nil {
+		t.Errorf("status = %+v, want a finished run", status)
+	}
+}
+
+func TestWarmCachesWithTheConfiguredTTL(t *testing.T) {
+	// Arrange
+	objectTypes, linkTypes := warmFixture()
+	c := &tenantCache{fakeCache: newFakeCache()}
+	ttls := DefaultCacheTTLs()
+	ttls.ObjectType, ttls.LinkType = 7*time.Minute, 3*time.Minute
+	warmer := NewCacheWarmer(objectTypes, linkTypes, testCursors, c, ttls, CacheWarmConfig{Concurrency: 2}, zap.NewNop())
+
+	// Act
+	_, _ = warmer.Warm(context.Background())
+
+	// Assert
+	for _, set := range c.sets {
+		want := ttls.ObjectType
+		if strings.Contains(set.key, "/link_type:") {
+			want = ttls.LinkType
+		}
+		if set.ttl != want {
+			t.Fatalf("%s cached for %v, want %v", set.key, set.ttl, want)
+		}
+	}
+}
+
+func TestWarmCountsAndSkipsCacheFailures(t *testing.T) {
+	// Arrange
+	objectTypes, linkTypes := warmFixture()
+	failing := objectTypes.byTenant["globex"][0]
+	c := &tenantCache{fakeCache: newFakeCache(), fail: func(key string) bool {
+		return key == "object_type:"+failing.ID.String()
+	}}
+	warmer := newTestCacheWarmer(objectTypes, linkTypes, c)
+
+	// Act
+	status, err := warmer.Warm(context.Background())
+
+	// Assert
+	if err != nil || status.Failed != 1 || status.ObjectTypes != 2*repository.MaxListPageSize+7 || status.LinkTypes != 4 {
+		t.Errorf("Warm = %+v, %v, want one failure and everything else cached", status, err)
+	}
+}
+
+func TestWarmStopsWhenListingFails(t *testing.T) {
+	// Arrange
+	objectTypes, linkTypes := warmFixture()
+	objectTypes.err = errors.New("connection refused")
+	warmer := newTestCacheWarmer(objectTypes, linkTypes, &tenantCache{fakeCache: newFakeCache()})
+
+	// Act
+	status, err := warmer.Warm(context.Background())
+
+	// Assert
+	if err == nil || status.Error == "" || status.Running {
+		t.Errorf("Warm = %+v, %v, want the listing error reported", status, err)
+	}
+}
+
+func TestWarmWhileRunningIsRejected(t *testing.T) {
+	// Arrange
+	objectTypes, linkTypes := warmFixture()
+	objectTypes.listed = make(chan struct{})
+	warmer := newTestCacheWarmer(objectTypes, linkTypes, &tenantCache{fakeCache: newFakeCache()})
+	if err := warmer.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	// Act
+	_, err := warmer.Warm(context.Background())
+
+	// Assert
+	if !errors.Is(err, ErrCacheWarmInProgress) || !warmer.Status().Running {
+		t.Errorf("Warm during a run = %v, want ErrCacheWarmInProgress", err)
+	}
+	close(objectTypes.listed)
+	waitForWarm(t, warmer)
+}
+
+func TestWarmRateLimitSpacesWrites(t *testing.T) {
+	// Arrange
+	objectTypes := &tenantObjectTypeRepo{byTenant: map[string][]*entity.ObjectType{"acme": {
+		{ID: uuid.New(), Name: "A", TenantID: "acme"},
+		{ID: uuid.New(), Name: "B", TenantID: "acme"},
+		{ID: uuid.New(), Name: "C", TenantID: "acme"},
+	}}}
+	linkTypes := &tenantLinkTypeRepo{byTenant: map[string][]*entity.LinkType{}}
+	warmer := NewCacheWarmer(objectTypes, linkTypes, testCursors, &tenantCache{fakeCache: newFakeCache()}, DefaultCacheTTLs(),
+		CacheWarmConfig{Concurrency: 3, Rate: 50}, zap.NewNop())
+	started := time.Now()
+
+	// Act
+	status, err := warmer.Warm(context.Background())
+
+	// Assert
+	if elapsed := time.Since(started); err != nil || status.ObjectTypes != 3 || elapsed < 50*time.Millisecond {
+		t.Errorf("Warm = %+v, %v in %v, want 3 object types at no more than 50 per second", status, err, elapsed)
+	}
+}
+
+// waitForWarm waits for the background run of warmer to finish
+func waitForWarm(t *testing.T, warmer *CacheWarmer) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for warmer.Status().Running {
+		if time.Now().After(deadline) {
+			t.Fatal("cache warming did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
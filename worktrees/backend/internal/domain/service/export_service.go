@@ -0,0 +1,650 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// BundleFormatVersion is the version of the bundle layout written by ExportBundle
+const BundleFormatVersion = 1
+
+var (
+	// ErrInvalidBundle indicates a bundle that cannot be parsed or has dangling references
+	ErrInvalidBundle = fmt.Errorf("%w: invalid bundle", repository.ErrInvalidInput)
+	// ErrImportConflict indicates a bundle entry whose name already exists
+	// while OnConflict is ConflictFail
+	ErrImportConflict = errors.New("bundle conflicts with existing definitions")
+)
+
+// Bundle is a portable snapshot of an ontology. Object types and link types
+// refer to each other by name so a bundle can be imported into an
+// environment where IDs differ.
+type Bundle struct {
+	FormatVersion int                `json:"formatVersion"`
+	ExportedAt    time.Time          `json:"exportedAt"`
+	ObjectTypes   []BundleObjectType `json:"objectTypes"`
+	LinkTypes     []BundleLinkType   `json:"linkTypes"`
+}
+
+// BundleObjectType is the definition of an object type within a bundle
+type BundleObjectType struct {
+	Name        string                 `json:"name"`
+	DisplayName string                 `json:"displayName"`
+	Description *string                `json:"description,omitempty"`
+	Category    *string                `json:"category,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Properties  []PropertyInput        `json:"properties"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Parent      *string                `json:"parent,omitempty"`
+}
+
+// BundleLinkType is the definition of a link type within a bundle
+type BundleLinkType struct {
+	Name        string                 `json:"name"`
+	DisplayName string                 `json:"displayName"`
+	Source      string                 `json:"source"`
+	Target      string                 `json:"target"`
+	Cardinality entity.Cardinality     `json:"cardinality"`
+	Description *string                `json:"description,omitempty"`
+	Properties  []PropertyInput        `json:"properties,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Constraints entity.LinkConstraints `json:"constraints"`
+}
+
+// ConflictStrategy decides what happens to bundle entries whose name already exists
+type ConflictStrategy string
+
+const (
+	ConflictFail      ConflictStrategy = "fail"
+	ConflictSkip      ConflictStrategy = "skip"
+	ConflictOverwrite ConflictStrategy = "overwrite"
+)
+
+// IsValid checks if the conflict strategy is valid
+func (c ConflictStrategy) IsValid() bool {
+	switch c {
+	case ConflictFail, ConflictSkip, ConflictOverwrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// ImportOptions controls ImportBundle
+type ImportOptions struct {
+	// DryRun validates the bundle and reports the plan without writing anything
+	DryRun bool
+	// OnConflict defaults to ConflictFail
+	OnConflict ConflictStrategy
+}
+
+// Import actions
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionSkip   = "skip"
+)
+
+// ImportItem reports what happens to one bundle entry
+type ImportItem struct {
+	Kind   string     `json:"kind"` // "object_type" or "link_type"
+	Name   string     `json:"name"`
+	Action string     `json:"action"`
+	ID     *uuid.UUID `json:"id,omitempty"`
+}
+
+// ImportResult reports the outcome, or for a dry run the plan, of an import
+type ImportResult struct {
+	DryRun  bool         `json:"dryRun"`
+	Items   []ImportItem `json:"items"`
+	Created int          `json:"created"`
+	Updated int          `json:"updated"`
+	Skipped int          `json:"skipped"`
+}
+
+// ImportError reports a write that failed partway through an import. The
+// entries in Result were applied before it and are not rolled back.
+type ImportError struct {
+	Err    error
+	Result *ImportResult
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("import stopped after %d applied entries: %v", len(e.Result.Items), e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// ExportService moves whole ontologies between environments
+type ExportService struct {
+	objectTypes *ObjectTypeService
+	linkTypes   *LinkTypeService
+	// cursors encodes the page cursors object types are listed with
+	cursors *repository.CursorCodec
+	logger  *zap.Logger
+}
+
+// NewExportService creates a new export service
+func NewExportService(objectTypes *ObjectTypeService, linkTypes *LinkTypeService, cursors *repository.CursorCodec, logger *zap.Logger) *ExportService {
+	return &ExportService{
+		objectTypes: objectTypes,
+		linkTypes:   linkTypes,
+		cursors:     cursors,
+		logger:      logger,
+	}
+}
+
+// listAllObjectTypes pages through every non-deleted object type, since a
+// single List call returns at most repository.MaxListPageSize of them
+func (s *ExportService) listAllObjectTypes(ctx context.Context) ([]*entity.ObjectType, error) {
+	var all []*entity.ObjectType
+	filter := repository.ObjectTypeFilter{
+		PageSize: repository.MaxListPageSize,
+		SortBy:   repository.SortByCreatedAt,
+	}
+	for {
+		page, err := s.objectTypes.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < filter.PageSize {
+			return all, nil
+		}
+		filter.PageCursor = s.cursors.Encode(repository.NewObjectTypeCursor(page[len(page)-1], filter.SortBy))
+	}
+}
+
+// ExportBundle serializes every non-deleted object type and link type into a
+// versioned JSON bundle
+func (s *ExportService) ExportBundle(ctx context.Context) ([]byte, error) {
+	objectTypes, err := s.listAllObjectTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list object types: %w", err)
+	}
+	linkTypes, err := s.linkTypes.List(ctx, repository.LinkTypeFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list link types: %w", err)
+	}
+
+	names := make(map[uuid.UUID]string, len(objectTypes))
+	for _, ot := range objectTypes {
+		names[ot.ID] = ot.Name
+	}
+
+	bundle := Bundle{
+		FormatVersion: BundleFormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		ObjectTypes:   make([]BundleObjectType, 0, len(objectTypes)),
+		LinkTypes:     make([]BundleLinkType, 0, len(linkTypes)),
+	}
+
+	for _, ot := range objectTypes {
+		entry := BundleObjectType{
+			Name:        ot.Name,
+			DisplayName: ot.DisplayName,
+			Description: ot.Description,
+			Category:    ot.Category,
+			Tags:        ot.Tags,
+			Properties:  propertyInputs(ot.Properties),
+			Metadata:    ot.Metadata,
+		}
+		if ot.ParentID != nil {
+			parent, ok := names[*ot.ParentID]
+			if !ok {
+				return nil, fmt.Errorf("object type %s has unknown parent %s", ot.Name, ot.ParentID)
+			}
+			entry.Parent = &parent
+		}
+		bundle.ObjectTypes = append(bundle.ObjectTypes, entry)
+	}
+
+	for _, lt := range linkTypes {
+		source, sourceOK := names[lt.SourceObjectTypeID]
+		target, targetOK := names[lt.TargetObjectTypeID]
+		if !sourceOK || !targetOK {
+			return nil, fmt.Errorf("link type %s references an unknown object type", lt.Name)
+		}
+		bundle.LinkTypes = append(bundle.LinkTypes, BundleLinkType{
+			Name:        lt.Name,
+			DisplayName: lt.DisplayName,
+			Source:      source,
+			Target:      target,
+			Cardinality: lt.Cardinality,
+			Description: lt.Description,
+			Properties:  propertyInputs(lt.Properties),
+			Metadata:    lt.Metadata,
+			Constraints: lt.Constraints,
+		})
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	s.logger.Info("Exported bundle",
+		zap.Int("object_types", len(bundle.ObjectTypes)),
+		zap.Int("link_types", len(bundle.LinkTypes)))
+	return data, nil
+}
+
+// importPlan is the validated set of changes an import will make
+type importPlan struct {
+	objectTypes []plannedObjectType // parents before children
+	linkTypes   []plannedLinkType
+}
+
+type plannedObjectType struct {
+	entry    BundleObjectType
+	action   string
+	existing *entity.ObjectType
+}
+
+type plannedLinkType struct {
+	entry    BundleLinkType
+	action   string
+	existing *entity.LinkType
+}
+
+// ImportBundle loads a bundle produced by ExportBundle. References are
+// validated and conflicts resolved before anything is written; object types
+// are then created parents first, followed by link types. The import is not
+// transactional, since each entry goes through the object and link type
+// services: if a write fails, entries applied before it are kept and an
+// *ImportError listing them is returned along with the partial result.
+func (s *ExportService) ImportBundle(ctx context.Context, data []byte, userID string, opts ImportOptions) (*ImportResult, error) {
+	if opts.OnConflict == "" {
+		opts.OnConflict = ConflictFail
+	}
+	if !opts.OnConflict.IsValid() {
+		return nil, fmt.Errorf("%w: unknown conflict strategy %q", repository.ErrInvalidInput, opts.OnConflict)
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	if bundle.FormatVersion != BundleFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidBundle, bundle.FormatVersion)
+	}
+
+	plan, err := s.planImport(ctx, &bundle, opts.OnConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{DryRun: opts.DryRun, Items: []ImportItem{}}
+	if opts.DryRun {
+		for _, p := range plan.objectTypes {
+			result.record(ImportItem{Kind: "object_type", Name: p.entry.Name, Action: p.action})
+		}
+		for _, p := range plan.linkTypes {
+			result.record(ImportItem{Kind: "link_type", Name: p.entry.Name, Action: p.action})
+		}
+		return result, nil
+	}
+
+	s.logger.Info("Importing bundle",
+		zap.Int("object_types", len(plan.objectTypes)),
+		zap.Int("link_types", len(plan.linkTypes)),
+		zap.String("on_conflict", string(opts.OnConflict)),
+		zap.String("user", userID))
+
+	// Object type IDs by name, for resolving parents and link endpoints
+	ids := make(map[string]uuid.UUID)
+
+	for _, p := range plan.objectTypes {
+		item := ImportItem{Kind: "object_type", Name: p.entry.Name, Action: p.action}
+		var parentID *uuid.UUID
+		if p.entry.Parent != nil {
+			id, err := s.resolveObjectTypeID(ctx, ids, *p.entry.Parent)
+			if err != nil {
+				return result, result.failed(err)
+			}
+			parentID = &id
+		}
+
+		switch p.action {
+		case ImportActionCreate:
+			ot, err := s.objectTypes.CreateObjectType(ctx, CreateObjectTypeInput{
+				Name:        p.entry.Name,
+				DisplayName: p.entry.DisplayName,
+				Description: p.entry.Description,
+				Category:    p.entry.Category,
+				Tags:        p.entry.Tags,
+				Properties:  p.entry.Properties,
+				Metadata:    p.entry.Metadata,
+				ParentID:    parentID,
+			}, userID)
+			if err != nil {
+				return result, result.failed(fmt.Errorf("object type %s: %w", p.entry.Name, err))
+			}
+			item.ID = &ot.ID
+		case ImportActionUpdate:
+			ot, err := s.objectTypes.UpdateObjectType(ctx, p.existing.ID, UpdateObjectTypeInput{
+				DisplayName: &p.entry.DisplayName,
+				Description: p.entry.Description,
+				Category:    p.entry.Category,
+				Tags:        p.entry.Tags,
+				Properties:  p.entry.Properties,
+				Metadata:    p.entry.Metadata,
+				ParentID:    parentID,
+			}, userID)
+			if err != nil {
+				return result, result.failed(fmt.Errorf("object type %s: %w", p.entry.Name, err))
+			}
+			item.ID = &ot.ID
+		default:
+			item.ID = &p.existing.ID
+		}
+
+		ids[p.entry.Name] = *item.ID
+		result.record(item)
+	}
+
+	for _, p := range plan.linkTypes {
+		item := ImportItem{Kind: "link_type", Name: p.entry.Name, Action: p.action}
+
+		switch p.action {
+		case ImportActionCreate:
+			sourceID, err := s.resolveObjectTypeID(ctx, ids, p.entry.Source)
+			if err != nil {
+				return result, result.failed(err)
+			}
+			targetID, err := s.resolveObjectTypeID(ctx, ids, p.entry.Target)
+			if err != nil {
+				return result, result.failed(err)
+			}
+			lt, err := s.linkTypes.CreateLinkType(ctx, CreateLinkTypeInput{
+				Name:               p.entry.Name,
+				DisplayName:        p.entry.DisplayName,
+				SourceObjectTypeID: sourceID,
+				TargetObjectTypeID: targetID,
+				Cardinality:        p.entry.Cardinality,
+				Description:        p.entry.Description,
+				Properties:         p.entry.Properties,
+				Metadata:           p.entry.Metadata,
+				Constraints:        p.entry.Constraints,
+			}, userID)
+			if err != nil {
+				return result, result.failed(fmt.Errorf("link type %s: %w", p.entry.Name, err))
+			}
+			item.ID = &lt.ID
+		case ImportActionUpdate:
+			lt, err := s.linkTypes.UpdateLinkType(ctx, p.existing.ID, UpdateLinkTypeInput{
+				DisplayName: &p.entry.DisplayName,
+				Cardinality: &p.entry.Cardinality,
+				Description: p.entry.Description,
+				Properties:  p.entry.Properties,
+				Metadata:    p.entry.Metadata,
+				Constraints: &p.entry.Constraints,
+				// Overwriting was requested explicitly, so the bundle's
+				// cardinality applies even when it is tighter
+				Force: true,
+			}, userID)
+			if err != nil {
+				return result, result.failed(fmt.Errorf("link type %s: %w", p.entry.Name, err))
+			}
+			item.ID = &lt.ID
+		default:
+			item.ID = &p.existing.ID
+		}
+
+		result.record(item)
+	}
+
+	s.logger.Info("Bundle imported",
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("skipped", result.Skipped))
+	return result, nil
+}
+
+// planImport validates every entry and reference in the bundle and decides
+// the action for each. Nothing is written.
+func (s *ExportService) planImport(ctx context.Context, bundle *Bundle, onConflict ConflictStrategy) (*importPlan, error) {
+	entries := make(map[string]BundleObjectType, len(bundle.ObjectTypes))
+	for _, entry := range bundle.ObjectTypes {
+		if _, ok := entries[entry.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate object type %s", ErrInvalidBundle, entry.Name)
+		}
+		entries[entry.Name] = entry
+	}
+
+	// existing holds object types already stored under a bundle or referenced name
+	existing := make(map[string]*entity.ObjectType)
+	lookup := func(name string) (*entity.ObjectType, error) {
+		if ot, ok := existing[name]; ok {
+			return ot, nil
+		}
+		ot, err := s.objectTypes.GetByName(ctx, name)
+		if err != nil && err != entity.ErrObjectTypeNotFound {
+			return nil, fmt.Errorf("failed to look up object type %s: %w", name, err)
+		}
+		existing[name] = ot
+		return ot, nil
+	}
+	// known reports whether a referenced object type will exist after the import
+	known := func(name string) (bool, error) {
+		if _, ok := entries[name]; ok {
+			return true, nil
+		}
+		ot, err := lookup(name)
+		return ot != nil, err
+	}
+
+	plan := &importPlan{}
+	conflicts := []string{}
+
+	// Order object types so that parents are written before their children
+	state := make(map[string]int) // 1 = visiting, 2 = done
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("%w: inheritance cycle through %s", ErrInvalidBundle, name)
+		case 2:
+			return nil
+		}
+		state[name] = 1
+
+		entry := entries[name]
+		if entry.Parent != nil {
+			ok, err := known(*entry.Parent)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("%w: object type %s has unknown parent %s", ErrInvalidBundle, name, *entry.Parent)
+			}
+			if _, inBundle := entries[*entry.Parent]; inBundle {
+				if err := visit(*entry.Parent); err != nil {
+					return err
+				}
+			}
+		}
+
+		candidate := &entity.ObjectType{
+			Name:        entry.Name,
+			DisplayName: entry.DisplayName,
+			Properties:  buildProperties(entry.Properties),
+		}
+		if err := candidate.Validate(); err != nil {
+			return fmt.Errorf("%w: object type %s: %v", ErrInvalidBundle, name, err)
+		}
+
+		current, err := lookup(name)
+		if err != nil {
+			return err
+		}
+		planned := plannedObjectType{entry: entry, action: ImportActionCreate, existing: current}
+		if current != nil {
+			switch onConflict {
+			case ConflictSkip:
+				planned.action = ImportActionSkip
+			case ConflictOverwrite:
+				planned.action = ImportActionUpdate
+			default:
+				conflicts = append(conflicts, "object type "+name)
+			}
+		}
+		plan.objectTypes = append(plan.objectTypes, planned)
+
+		state[name] = 2
+		return nil
+	}
+	for _, entry := range bundle.ObjectTypes {
+		if err := visit(entry.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	linkNames := make(map[string]bool, len(bundle.LinkTypes))
+	for _, entry := range bundle.LinkTypes {
+		if linkNames[entry.Name] {
+			return nil, fmt.Errorf("%w: duplicate link type %s", ErrInvalidBundle, entry.Name)
+		}
+		linkNames[entry.Name] = true
+
+		for _, end := range []string{entry.Source, entry.Target} {
+			ok, err := known(end)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("%w: link type %s references unknown object type %s", ErrInvalidBundle, entry.Name, end)
+			}
+		}
+
+		candidate := &entity.LinkType{
+			Name:               entry.Name,
+			DisplayName:        entry.DisplayName,
+			SourceObjectTypeID: uuid.New(),
+			TargetObjectTypeID: uuid.New(),
+			Cardinality:        entry.Cardinality,
+			Properties:         buildProperties(entry.Properties),
+		}
+		if err := candidate.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: link type %s: %v", ErrInvalidBundle, entry.Name, err)
+		}
+
+		current, err := s.linkTypes.GetByName(ctx, entry.Name)
+		if err != nil && err != entity.ErrLinkTypeNotFound {
+			return nil, fmt.Errorf("failed to look up link type %s: %w", entry.Name, err)
+		}
+		planned := plannedLinkType{entry: entry, action: ImportActionCreate, existing: current}
+		if current != nil {
+			switch onConflict {
+			case ConflictSkip:
+				planned.action = ImportActionSkip
+			case ConflictOverwrite:
+				// Endpoints are immutable, so an overwrite must keep them
+				if err := s.checkLinkEndpoints(ctx, current, entry, lookup); err != nil {
+					return nil, err
+				}
+				planned.action = ImportActionUpdate
+			default:
+				conflicts = append(conflicts, "link type "+entry.Name)
+			}
+		}
+		plan.linkTypes = append(plan.linkTypes, planned)
+	}
+
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrImportConflict, conflicts)
+	}
+
+	return plan, nil
+}
+
+// checkLinkEndpoints rejects overwriting a link type with different endpoints
+func (s *ExportService) checkLinkEndpoints(ctx context.Context, current *entity.LinkType, entry BundleLinkType, lookup func(string) (*entity.ObjectType, error)) error {
+	source, err := lookup(entry.Source)
+	if err != nil {
+		return err
+	}
+	target, err := lookup(entry.Target)
+	if err != nil {
+		return err
+	}
+	if source == nil || target == nil || source.ID != current.SourceObjectTypeID || target.ID != current.TargetObjectTypeID {
+		return fmt.Errorf("%w: link type %s cannot change its source or target", ErrInvalidBundle, entry.Name)
+	}
+	return nil
+}
+
+// resolveObjectTypeID returns the ID of an object type imported earlier or
+// already stored under name
+func (s *ExportService) resolveObjectTypeID(ctx context.Context, ids map[string]uuid.UUID, name string) (uuid.UUID, error) {
+	if id, ok := ids[name]; ok {
+		return id, nil
+	}
+	ot, err := s.objectTypes.GetByName(ctx, name)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("object type %s: %w", name, err)
+	}
+	ids[name] = ot.ID
+	return ot.ID, nil
+}
+
+// failed wraps the error of a failed write in an ImportError reporting the
+// entries applied so far
+func (r *ImportResult) failed(err error) error {
+	return &ImportError{Err: err, Result: r}
+}
+
+// record appends an item and updates the counters
+func (r *ImportResult) record(item ImportItem) {
+	r.Items = append(r.Items, item)
+	switch item.Action {
+	case ImportActionCreate:
+		r.Created++
+	case ImportActionUpdate:
+		r.Updated++
+	case ImportActionSkip:
+		r.Skipped++
+	}
+}
+
+// propertyInputs converts property entities back into inputs, dropping IDs
+func propertyInputs(properties []entity.Property) []PropertyInput {
+	inputs := make([]PropertyInput, len(properties))
+	for i, prop := range properties {
+		inputs[i] = PropertyInput{
+			Name:         prop.Name,
+			DisplayName:  prop.DisplayName,
+			DataType:     prop.DataType,
+			Required:     prop.Required,
+			Unique:       prop.Unique,
+			Indexed:      prop.Indexed,
+			DefaultValue: prop.DefaultValue,
+			Description:  prop.Description,
+			Validators:   prop.Validators,
+			Metadata:     prop.Metadata,
+			Order:        prop.Order,
+
+			ElementType:       prop.ElementType,
+			ItemValidators:    prop.ItemValidators,
+			AllowNestedArrays: prop.AllowNestedArrays,
+
+			ReferencedObjectTypeID: prop.ReferencedObjectTypeID,
+
+			Group: prop.Group,
+
+			Deprecated:         prop.Deprecated,
+			DeprecationMessage: prop.DeprecationMessage,
+		}
+	}
+	return inputs
+}
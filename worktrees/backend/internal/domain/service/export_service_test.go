@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// importTarget is an environment a bundle is imported into, holding a stored
+// Customer
+type importTarget struct {
+	svc         *ExportService
+	objectTypes *fakeObjectTypeRepo
+	linkTypes   *fakeLinkTypeRepo
+	customer    *entity.ObjectType
+}
+
+func newImportTarget() *importTarget {
+	customer := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Old customer", Version: 1}
+	objectTypes := newFakeObjectTypeRepo(customer)
+	objectTypes.links = newFakeLinkTypeRepo()
+	objectTypeService := newTestObjectTypeService(objectTypes)
+	objectTypeService.linkTypeRepo = objectTypes.links
+	linkTypeService := newTestLinkTypeService(objectTypes.links, objectTypes, &fakePublisher{})
+	return &importTarget{
+		svc:         NewExportService(objectTypeService, linkTypeService, testCursors, zap.NewNop()),
+		objectTypes: objectTypes,
+		linkTypes:   objectTypes.links,
+		customer:    customer,
+	}
+}
+
+// run imports bundle with opts
+func (i *importTarget) run(t *testing.T, bundle Bundle, opts ImportOptions) (*ImportResult, error) {
+	t.Helper()
+	bundle.FormatVersion = BundleFormatVersion
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("marshal bundle: %v", err)
+	}
+	return i.svc.ImportBundle(context.Background(), data, "alice", opts)
+}
+
+// unchanged reports whether the target still holds only the stored Customer
+func (i *importTarget) unchanged() bool {
+	stored := i.objectTypes.objectTypes[i.customer.ID]
+	return len(i.objectTypes.objectTypes) == 1 && stored.Version == 1 && len(i.linkTypes.linkTypes) == 0
+}
+
+// customerAccountsBundle holds Customer and Account linked by
+// customerAccounts; Customer is already stored in an importTarget
+func customerAccountsBundle() Bundle {
+	return Bundle{
+		ObjectTypes: []BundleObjectType{
+			{Name: "Customer", DisplayName: "Customer"},
+			{Name: "Account", DisplayName: "Account"},
+		},
+		LinkTypes: []BundleLinkType{{
+			Name: "customerAccounts", DisplayName: "Customer accounts",
+			Source: "Customer", Target: "Account", Cardinality: entity.CardinalityOneToMany,
+		}},
+	}
+}
+
+// actions returns the action of each imported item by name
+func actions(result *ImportResult) map[string]string {
+	byName := map[string]string{}
+	for _, item := range result.Items {
+		byName[item.Name] = item.Action
+	}
+	return byName
+}
+
+func TestImportWithConflictFailWritesNothing(t *testing.T) {
+	// Arrange
+	target := newImportTarget()
+
+	// Act
+	_, err := target.run(t, customerAccountsBundle(), ImportOptions{OnConflict: ConflictFail})
+
+	// Assert
+	if !errors.Is(err, ErrImportConflict) {
+		t.Errorf("ImportBundle = %v, want ErrImportConflict", err)
+	}
+	if !target.unchanged() {
+		t.Error("a conflicting import wrote definitions")
+	}
+}
+
+func TestImportWithConflictSkipKeepsTheStoredObjectType(t *testing.T) {
+	// Arrange
+	target := newImportTarget()
+
+	// Act
+	result, err := target.run(t, customerAccountsBundle(), ImportOptions{OnConflict: ConflictSkip})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ImportBundle: %v", err)
+	}
+	want := map[string]string{"Customer": ImportActionSkip, "Account": ImportActionCreate, "customerAccounts": ImportActionCreate}
+	if got := actions(result); len(got) != len(want) || got["Customer"] != want["Customer"] ||
+		got["Account"] != want["Account"] || got["customerAccounts"] != want["customerAccounts"] {
+		t.Errorf("actions = %v, want %v", got, want)
+	}
+	if stored := target.objectTypes.objectTypes[target.customer.ID]; stored.DisplayName != "Old customer" || stored.Version != 1 {
+		t.Errorf("stored Customer = %q v%d, want it untouched", stored.DisplayName, stored.Version)
+	}
+	linkType, err := target.linkTypes.GetByName(context.Background(), "customerAccounts")
+	if err != nil || linkType.SourceObjectTypeID != target.customer.ID {
+		t.Errorf("link type = %+v, %v, want it to start at the stored Customer", linkType, err)
+	}
+}
+
+func TestImportWithConflictOverwriteUpdatesTheStoredObjectType(t *testing.T) {
+	// Arrange
+	target := newImportTarget()
+
+	// Act
+	result, err := target.run(t, customerAccountsBundle(), ImportOptions{OnConflict: ConflictOverwrite})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ImportBundle: %v", err)
+	}
+	if result.Created != 2 || result.Updated != 1 || actions(result)["Customer"] != ImportActionUpdate {
+		t.Errorf("result = %+v, want Customer updated and the rest created", result)
+	}
+	if stored := target.objectTypes.objectTypes[target.customer.ID]; stored.DisplayName != "Customer" || stored.Version != 2 {
+		t.Errorf("stored Customer = %q v%d, want the bundle's definition as v2", stored.DisplayName, stored.Version)
+	}
+}
+
+func TestImportDryRunWritesNothing(t *testing.T) {
+	// Arrange
+	target := newImportTarget()
+
+	// Act
+	result, err := target.run(t, customerAccountsBundle(), ImportOptions{DryRun: true, OnConflict: ConflictOverwrite})
+
+	// Assert
+	if err != nil || !result.DryRun || result.Created != 2 || result.Updated != 1 {
+		t.Errorf("ImportBundle = %+v, %v, want the plan of the overwrite", result, err)
+	}
+	if !target.unchanged() {
+		t.Error("a dry run wrote definitions")
+	}
+}
+
+func TestImportWithDanglingLinkEndpointIsRejected(t *testing.T) {
+	// Arrange
+	target := newImportTarget()
+	bundle := customerAccountsBundle()
+	bundle.LinkTypes[0].Target = "Missing"
+
+	// Act
+	_, err := target.run(t, bundle, ImportOptions{OnConflict: ConflictOverwrite})
+
+	// Assert
+	if !errors.Is(err, ErrInvalidBundle) {
+		t.Errorf("ImportBundle = %v, want ErrInvalidBundle", err)
+	}
+	if !target.unchanged() {
+		t.Error("an import with a dangling link endpoint wrote definitions")
+	}
+}
+
+func TestImportFailingPartwayReportsTheAppliedEntries(t *testing.T) {
+	// Arrange
+	target := newImportTarget()
+	missing := uuid.New()
+	bundle := Bundle{ObjectTypes: []BundleObjectType{
+		{Name: "Account", DisplayName: "Account"},
+		{Name: "Invoice", DisplayName: "Invoice", Properties: []PropertyInput{{
+			Name: "order", DisplayName: "Order", DataType: entity.DataTypeReference, ReferencedObjectTypeID: &missing,
+		}}},
+	}}
+
+	// Act
+	result, err := target.run(t, bundle, ImportOptions{})
+
+	// Assert
+	var importErr *ImportError
+	if !errors.As(err, &importErr) || !errors.Is(err, entity.ErrValidationFailed) {
+		t.Fatalf("ImportBundle = %v, want an ImportError for the invalid reference", err)
+	}
+	if importErr.Result != result || len(result.Items) != 1 || result.Items[0].Name != "Account" || result.Items[0].ID == nil {
+		t.Errorf("applied = %+v, want only Account", importErr.Result.Items)
+	}
+	if _, stored := target.objectTypes.objectTypes[*result.Items[0].ID]; !stored {
+		t.Error("the applied Account was not kept")
+	}
+}
@@ -0,0 +1,741 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// errCacheMiss is returned by fakeCache for keys it does not hold
+var errCacheMiss = errors.New("cache miss")
+
+// testCursors signs the page cursors services hand to the fake repositories
+var testCursors = repository.NewCursorCodec([]byte("test-key"), time.Hour, false)
+
+// cacheSet is a write made to a fakeCache
+type cacheSet struct {
+	key string
+	ttl time.Duration
+}
+
+// fakeCache is an in-memory CacheService storing values as JSON, like the
+// Redis cache, and recording every write. TTLs are recorded, not enforced.
+type fakeCache struct {
+	cache.CacheService
+	mu     sync.Mutex
+	values map[string][]byte
+	sets   []cacheSet
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{values: map[string][]byte{}}
+}
+
+func (c *fakeCache) Get(ctx context.Context, key string, dest interface{}) error {
+	c.mu.Lock()
+	value, ok := c.values[key]
+	c.mu.Unlock()
+	if !ok {
+		return errCacheMiss
+	}
+	return json.Unmarshal(value, dest)
+}
+
+func (c *fakeCache) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = c.values[key]
+	}
+	return values, nil
+}
+
+func (c *fakeCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = data
+	c.sets = append(c.sets, cacheSet{key: key, ttl: ttl})
+	return nil
+}
+
+func (c *fakeCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	if exists, _ := c.Exists(ctx, key); exists {
+		return false, nil
+	}
+	return true, c.Set(ctx, key, value, ttl)
+}
+
+func (c *fakeCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.values, key)
+	return nil
+}
+
+func (c *fakeCache) InvalidatePattern(ctx context.Context, pattern string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.values {
+		if matched, _ := path.Match(pattern, key); matched {
+			delete(c.values, key)
+		}
+	}
+	return nil
+}
+
+func (c *fakeCache) Exists(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.values[key]
+	return ok, nil
+}
+
+// has reports whether the cache holds key
+func (c *fakeCache) has(key string) bool {
+	exists, _ := c.Exists(context.Background(), key)
+	return exists
+}
+
+// fakeObjectTypeRepo keeps object types and their indexes in memory and
+// records the arguments of the repository calls a test exercises;
+// unimplemented methods panic through the nil embedded interface
+type fakeObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	mu          sync.Mutex
+	objectTypes map[uuid.UUID]*entity.ObjectType
+	indexes     map[uuid.UUID][]*entity.ObjectTypeIndex
+	// getByIDsCalls counts GetByIDs round trips
+	getByIDsCalls atomic.Int32
+	searchLimit   int
+	purgeAudit    *entity.AuditEntry
+	// updateAudit is the audit entry carried by the last Update
+	updateAudit *entity.AuditEntry
+	// versions records the version each update writes, oldest first
+	versions []*repository.ObjectTypeVersion
+	// batchUpdates counts BatchUpdate calls
+	batchUpdates int
+	// batchCreates and batchDeletes count BatchCreate and BatchDelete calls
+	batchCreates int
+	batchDeletes int
+	// links receives the link type deletes of DeleteCascade
+	links *fakeLinkTypeRepo
+}
+
+func newFakeObjectTypeRepo(objectTypes ...*entity.ObjectType) *fakeObjectTypeRepo {
+	r := &fakeObjectTypeRepo{
+		objectTypes: map[uuid.UUID]*entity.ObjectType{},
+		indexes:     map[uuid.UUID][]*entity.ObjectTypeIndex{},
+	}
+	for _, ot := range objectTypes {
+		r.objectTypes[ot.ID] = ot
+	}
+	return r
+}
+
+func (r *fakeObjectTypeRepo) Create(ctx context.Context, objectType *entity.ObjectType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.byName(objectType.Name) != nil {
+		return entity.ErrObjectTypeNameExists
+	}
+	r.objectTypes[objectType.ID] = objectType.Copy()
+	return nil
+}
+
+func (r *fakeObjectTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ot, ok := r.objectTypes[id]
+	if !ok || ot.IsDeleted {
+		return nil, entity.ErrObjectTypeNotFound
+	}
+	return ot.Copy(), nil
+}
+
+func (r *fakeObjectTypeRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error) {
+	r.getByIDsCalls.Add(1)
+	var found []*entity.ObjectType
+	for _, id := range ids {
+		if ot, err := r.GetByID(ctx, id); err == nil {
+			found = append(found, ot)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeObjectTypeRepo) GetByName(ctx context.Context, name string) (*entity.ObjectType, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ot := r.byName(name); ot != nil {
+		return ot.Copy(), nil
+	}
+	return nil, entity.ErrObjectTypeNotFound
+}
+
+// GetByNameIncludingDeleted returns the live object type named name followed
+// by deleted ones, most recently updated first, like the Postgres repository
+func (r *fakeObjectTypeRepo) GetByNameIncludingDeleted(ctx context.Context, name string) ([]*entity.ObjectType, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var live, deleted []*entity.ObjectType
+	for _, ot := range r.objectTypes {
+		switch {
+		case ot.Name != name:
+		case ot.IsDeleted:
+			deleted = append(deleted, ot.Copy())
+		default:
+			live = append(live, ot.Copy())
+		}
+	}
+	slices.SortFunc(deleted, func(a, b *entity.ObjectType) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	return append(live, deleted...), nil
+}
+
+// byName returns the live object type named name; r.mu must be held
+func (r *fakeObjectTypeRepo) byName(name string) *entity.ObjectType {
+	for _, ot := range r.objectTypes {
+		if ot.Name == name && !ot.IsDeleted {
+			return ot
+		}
+	}
+	return nil
+}
+
+// Update saves objectType only if the stored version is the one before
+// objectType.Version, like the Postgres repository's WHERE version = $n
+func (r *fakeObjectTypeRepo) Update(ctx context.Context, objectType *entity.ObjectType, changeDescription string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.objectTypes[objectType.ID]
+	if !ok || stored.IsDeleted {
+		return entity.ErrObjectTypeNotFound
+	}
+	if stored.Version != objectType.Version-1 {
+		return repository.ErrOptimisticLock
+	}
+	r.objectTypes[objectType.ID] = objectType.Copy()
+	r.versions = append(r.versions, &repository.ObjectTypeVersion{
+		ID:                uuid.New(),
+		ObjectTypeID:      objectType.ID,
+		Version:           objectType.Version,
+		Snapshot:          *objectType.Copy(),
+		ChangeDescription: changeDescription,
+		CreatedBy:         objectType.UpdatedBy,
+	})
+	r.updateAudit = repository.AuditEntryFromContext(ctx)
+	return nil
+}
+
+// BatchUpdate saves every object type or, when any of them is missing or
+// its stored version has moved on, none of them, like the Postgres transaction
+func (r *fakeObjectTypeRepo) BatchUpdate(ctx context.Context, objectTypes []*entity.ObjectType, changeDescription string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batchUpdates++
+	for i, objectType := range objectTypes {
+		stored, ok := r.objectTypes[objectType.ID]
+		if !ok || stored.IsDeleted {
+			return &repository.BatchItemError{Index: i, Err: entity.ErrObjectTypeNotFound}
+		}
+		if stored.Version != objectType.Version-1 {
+			return &repository.BatchItemError{Index: i, Err: repository.ErrOptimisticLock}
+		}
+	}
+	for _, objectType := range objectTypes {
+		r.objectTypes[objectType.ID] = objectType.Copy()
+		r.versions = append(r.versions, &repository.ObjectTypeVersion{
+			ID:                uuid.New(),
+			ObjectTypeID:      objectType.ID,
+			Version:           objectType.Version,
+			Snapshot:          *objectType.Copy(),
+			ChangeDescription: changeDescription,
+			CreatedBy:         objectType.UpdatedBy,
+		})
+	}
+	return nil
+}
+
+func (r *fakeObjectTypeRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ot, ok := r.objectTypes[id]
+	if !ok || ot.IsDeleted {
+		return entity.ErrObjectTypeNotFound
+	}
+	ot.IsDeleted = true
+	return nil
+}
+
+// DeleteCascade deletes the object type and every live link type of links
+// that starts or ends at it, like the Postgres repository's transaction
+func (r *fakeObjectTypeRepo) DeleteCascade(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	if err := r.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	var deleted []uuid.UUID
+	for _, lt := range r.links.linkTypes {
+		if !lt.IsDeleted && (lt.SourceObjectTypeID == id || lt.TargetObjectTypeID == id) {
+			lt.IsDeleted = true
+			deleted = append(deleted, lt.ID)
+		}
+	}
+	return deleted, nil
+}
+
+// BatchCreate stores every object type or, when any name is taken, none of
+// them, like the Postgres transaction
+func (r *fakeObjectTypeRepo) BatchCreate(ctx context.Context, objectTypes []*entity.ObjectType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batchCreates++
+	for i, objectType := range objectTypes {
+		if r.byName(objectType.Name) != nil {
+			return &repository.BatchItemError{Index: i, Err: entity.ErrObjectTypeNameExists}
+		}
+	}
+	for _, objectType := range objectTypes {
+		r.objectTypes[objectType.ID] = objectType.Copy()
+	}
+	return nil
+}
+
+// BatchDelete deletes every object type, cascading to links, or, when any of
+// them is missing, none of them, like the Postgres transaction
+func (r *fakeObjectTypeRepo) BatchDelete(ctx context.Context, ids []uuid.UUID) ([][]uuid.UUID, error) {
+	r.mu.Lock()
+	r.batchDeletes++
+	for i, id := range ids {
+		if ot, ok := r.objectTypes[id]; !ok || ot.IsDeleted {
+			r.mu.Unlock()
+			return nil, &repository.BatchItemError{Index: i, Err: entity.ErrObjectTypeNotFound}
+		}
+	}
+	r.mu.Unlock()
+
+	linkTypeIDs := make([][]uuid.UUID, len(ids))
+	for i, id := range ids {
+		deleted, err := r.DeleteCascade(ctx, id)
+		if err != nil {
+			return nil, &repository.BatchItemError{Index: i, Err: err}
+		}
+		linkTypeIDs[i] = deleted
+	}
+	return linkTypeIDs, nil
+}
+
+// ListVersions pages the recorded versions newest first, like the Postgres
+// repository's ORDER BY version DESC
+func (r *fakeObjectTypeRepo) ListVersions(ctx context.Context, id uuid.UUID, filter repository.VersionFilter) ([]*repository.ObjectTypeVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var versions []*repository.ObjectTypeVersion
+	for i := len(r.versions) - 1; i >= 0 && len(versions) < filter.PageSize; i-- {
+		v := r.versions[i]
+		if v.ObjectTypeID == id && (filter.BeforeVersion == 0 || v.Version < filter.BeforeVersion) {
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+// GetVersion returns the snapshot of a recorded version
+func (r *fakeObjectTypeRepo) GetVersion(ctx context.Context, id uuid.UUID, version int) (*entity.ObjectType, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range r.versions {
+		if v.ObjectTypeID == id && v.Version == version {
+			return v.Snapshot.Copy(), nil
+		}
+	}
+	return nil, entity.ErrVersionNotFound
+}
+
+// GetVersionAt returns the latest version recorded at or before at, or the
+// earliest version when all are newer, like the Postgres repository
+func (r *fakeObjectTypeRepo) GetVersionAt(ctx context.Context, id uuid.UUID, at time.Time) (*entity.ObjectType, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var before, after *repository.ObjectTypeVersion
+	for _, v := range r.versions {
+		switch {
+		case v.ObjectTypeID != id:
+		case !v.CreatedAt.After(at):
+			if before == nil || v.Version > before.Version {
+				before = v
+			}
+		case after == nil || v.Version < after.Version:
+			after = v
+		}
+	}
+	if before == nil {
+		before = after
+	}
+	if before == nil {
+		return nil, entity.ErrVersionNotFound
+	}
+	return before.Snapshot.Copy(), nil
+}
+
+// Restore undeletes an object type unless a live one has taken its name
+func (r *fakeObjectTypeRepo) Restore(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ot, ok := r.objectTypes[id]
+	if !ok {
+		return entity.ErrObjectTypeNotFound
+	}
+	if !ot.IsDeleted {
+		return entity.ErrObjectTypeNotDeleted
+	}
+	if r.byName(ot.Name) != nil {
+		return entity.ErrObjectTypeNameExists
+	}
+	ot.IsDeleted = false
+	return nil
+}
+
+func (r *fakeObjectTypeRepo) ListIndexes(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.ObjectTypeIndex, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.indexes[objectTypeID], nil
+}
+
+func (r *fakeObjectTypeRepo) SyncIndexes(ctx context.Context, objectTypeID uuid.UUID, added []entity.ObjectTypeIndex, removed []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var kept []*entity.ObjectTypeIndex
+	for _, idx := range r.indexes[objectTypeID] {
+		if !slices.Contains(removed, idx.PropertyName) {
+			kept = append(kept, idx)
+		}
+	}
+	for i := range added {
+		kept = append(kept, &added[i])
+	}
+	r.indexes[objectTypeID] = kept
+	return nil
+}
+
+// Purge removes a soft-deleted object type and the deleted link types of
+// links referencing it, refusing a live one like the Postgres repository
+func (r *fakeObjectTypeRepo) Purge(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.purgeAudit = repository.AuditEntryFromContext(ctx)
+	ot, ok := r.objectTypes[id]
+	if !ok {
+		return entity.ErrObjectTypeNotFound
+	}
+	if !ot.IsDeleted {
+		return entity.ErrObjectTypeNotDeleted
+	}
+	delete(r.objectTypes, id)
+	if r.links != nil {
+		maps.DeleteFunc(r.links.linkTypes, func(_ uuid.UUID, lt *entity.LinkType) bool {
+			return lt.IsDeleted && (lt.SourceObjectTypeID == id || lt.TargetObjectTypeID == id)
+		})
+	}
+	return nil
+}
+
+// ListDeleted returns up to limit soft-deleted object types, most recently
+// deleted first
+func (r *fakeObjectTypeRepo) ListDeleted(ctx context.Context, limit int) ([]*entity.ObjectType, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted []*entity.ObjectType
+	for _, ot := range r.objectTypes {
+		if ot.IsDeleted {
+			deleted = append(deleted, ot.Copy())
+		}
+	}
+	slices.SortFunc(deleted, func(a, b *entity.ObjectType) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	return deleted[:min(limit, len(deleted))], nil
+}
+
+func (r *fakeObjectTypeRepo) Search(ctx context.Context, query string, limit int, opts repository.SearchOptions) (*repository.SearchPage, error) {
+	r.searchLimit = limit
+	return &repository.SearchPage{}, nil
+}
+
+// SuggestNames matches names by lowercased prefix, like the Postgres
+// repository's lower(name) LIKE query
+func (r *fakeObjectTypeRepo) SuggestNames(ctx context.Context, prefix string, limit int) ([]repository.NameSuggestion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	suggestions := []repository.NameSuggestion{}
+	for _, ot := range r.objectTypes {
+		if !ot.IsDeleted && strings.HasPrefix(strings.ToLower(ot.Name), strings.ToLower(prefix)) {
+			suggestions = append(suggestions, repository.NameSuggestion{ID: ot.ID, Name: ot.Name, DisplayName: ot.DisplayName})
+		}
+	}
+	return suggestions[:min(limit, len(suggestions))], nil
+}
+
+// ListCategories counts live object types per category, like the Postgres
+// repository's GROUP BY category
+func (r *fakeObjectTypeRepo) ListCategories(ctx context.Context) ([]repository.CategoryCount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := map[string]int64{}
+	for _, ot := range r.objectTypes {
+		if !ot.IsDeleted && ot.Category != nil {
+			counts[*ot.Category]++
+		}
+	}
+	categories := []repository.CategoryCount{}
+	for category, count := range counts {
+		categories = append(categories, repository.CategoryCount{Category: category, Count: count})
+	}
+	slices.SortFunc(categories, func(a, b repository.CategoryCount) int {
+		if a.Count != b.Count {
+			return int(b.Count - a.Count)
+		}
+		return strings.Compare(a.Category, b.Category)
+	})
+	return categories, nil
+}
+
+// ListTags counts live object types per tag, like the Postgres repository's
+// GROUP BY over unnest(tags)
+func (r *fakeObjectTypeRepo) ListTags(ctx context.Context) ([]repository.TagCount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := map[string]int64{}
+	for _, ot := range r.objectTypes {
+		if ot.IsDeleted {
+			continue
+		}
+		for _, tag := range ot.Tags {
+			counts[tag]++
+		}
+	}
+	tags := []repository.TagCount{}
+	for tag, count := range counts {
+		tags = append(tags, repository.TagCount{Tag: tag, Count: count})
+	}
+	slices.SortFunc(tags, func(a, b repository.TagCount) int {
+		if a.Count != b.Count {
+			return int(b.Count - a.Count)
+		}
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	return tags, nil
+}
+
+// ListChildren returns the live object types whose parent is id, by name
+func (r *fakeObjectTypeRepo) ListChildren(ctx context.Context, id uuid.UUID) ([]*entity.ObjectType, error) {
+	return r.liveMatching(func(ot *entity.ObjectType) bool {
+		return ot.ParentID != nil && *ot.ParentID == id
+	}), nil
+}
+
+// ListReferencing returns the live object types with a property naming id as
+// its referenced object type, like the Postgres repository's JSON
+// containment match, by name
+func (r *fakeObjectTypeRepo) ListReferencing(ctx context.Context, id uuid.UUID) ([]*entity.ObjectType, error) {
+	return r.liveMatching(func(ot *entity.ObjectType) bool {
+		return slices.ContainsFunc(ot.Properties, func(p entity.Property) bool {
+			return p.ReferencedObjectTypeID != nil && *p.ReferencedObjectTypeID == id
+		})
+	}), nil
+}
+
+// liveMatching returns copies of the live object types matching match,
+// ordered by name
+func (r *fakeObjectTypeRepo) liveMatching(match func(*entity.ObjectType) bool) []*entity.ObjectType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matched []*entity.ObjectType
+	for _, ot := range r.objectTypes {
+		if !ot.IsDeleted && match(ot) {
+			matched = append(matched, ot.Copy())
+		}
+	}
+	slices.SortFunc(matched, func(a, b *entity.ObjectType) int { return strings.Compare(a.Name, b.Name) })
+	return matched
+}
+
+// newTestObjectTypeService builds an ObjectTypeService around repo with
+// in-memory collaborators
+func newTestObjectTypeService(repo repository.ObjectTypeRepository) *ObjectTypeService {
+	return NewObjectTypeService(repo, nil, newFakeCache(), DefaultCacheTTLs(), &fakePublisher{},
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+}
+
+// fakePublisher records published events
+type fakePublisher struct {
+	mu     sync.Mutex
+	events []messaging.Event
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, event messaging.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *fakePublisher) PublishBatch(ctx context.Context, events []messaging.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func (p *fakePublisher) Close() error {
+	return nil
+}
+
+// fakeLinkTypeRepo keeps link types in memory and records the audit entry
+// each update was made under
+type fakeLinkTypeRepo struct {
+	repository.LinkTypeRepository
+	linkTypes    map[uuid.UUID]*entity.LinkType
+	updateAudits []*entity.AuditEntry
+	// searchLimits records the limit of each Search
+	searchLimits []int
+}
+
+func newFakeLinkTypeRepo(linkTypes ...*entity.LinkType) *fakeLinkTypeRepo {
+	r := &fakeLinkTypeRepo{linkTypes: map[uuid.UUID]*entity.LinkType{}}
+	for _, lt := range linkTypes {
+		r.linkTypes[lt.ID] = lt
+	}
+	return r
+}
+
+func (r *fakeLinkTypeRepo) Create(ctx context.Context, linkType *entity.LinkType) error {
+	stored := *linkType
+	r.linkTypes[linkType.ID] = &stored
+	return nil
+}
+
+func (r *fakeLinkTypeRepo) CreatePair(ctx context.Context, linkType, inverse *entity.LinkType) error {
+	_ = r.Create(ctx, linkType)
+	return r.Create(ctx, inverse)
+}
+
+func (r *fakeLinkTypeRepo) GetByName(ctx context.Context, name string) (*entity.LinkType, error) {
+	for _, lt := range r.linkTypes {
+		if lt.Name == name && !lt.IsDeleted {
+			stored := *lt
+			return &stored, nil
+		}
+	}
+	return nil, entity.ErrLinkTypeNotFound
+}
+
+// CheckCircularReference searches breadth first, like the recursive query of
+// the Postgres repository, for the shortest path of live, non-inverse link
+// types from targetID back to sourceID
+func (r *fakeLinkTypeRepo) CheckCircularReference(ctx context.Context, sourceID, targetID uuid.UUID) ([]uuid.UUID, error) {
+	paths := [][]uuid.UUID{{targetID}}
+	for len(paths) > 0 {
+		path := paths[0]
+		paths = paths[1:]
+		last := path[len(path)-1]
+		if last == sourceID {
+			return append([]uuid.UUID{sourceID}, path...), nil
+		}
+		for _, lt := range r.linkTypes {
+			if lt.IsDeleted || lt.IsInverse || lt.SourceObjectTypeID != last || slices.Contains(path, lt.TargetObjectTypeID) {
+				continue
+			}
+			paths = append(paths, append(slices.Clone(path), lt.TargetObjectTypeID))
+		}
+	}
+	return nil, nil
+}
+
+// Search returns up to limit live link types whose name contains query
+func (r *fakeLinkTypeRepo) Search(ctx context.Context, query string, limit int) ([]*entity.LinkType, error) {
+	r.searchLimits = append(r.searchLimits, limit)
+	var found []*entity.LinkType
+	for _, lt := range r.linkTypes {
+		if !lt.IsDeleted && strings.Contains(lt.Name, query) && len(found) < limit {
+			stored := *lt
+			found = append(found, &stored)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeLinkTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.LinkType, error) {
+	lt, ok := r.linkTypes[id]
+	if !ok || lt.IsDeleted {
+		return nil, entity.ErrLinkTypeNotFound
+	}
+	stored := *lt
+	return &stored, nil
+}
+
+func (r *fakeLinkTypeRepo) GetBySourceObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error) {
+	var linkTypes []*entity.LinkType
+	for _, lt := range r.linkTypes {
+		if lt.SourceObjectTypeID == objectTypeID && !lt.IsDeleted {
+			stored := *lt
+			linkTypes = append(linkTypes, &stored)
+		}
+	}
+	return linkTypes, nil
+}
+
+func (r *fakeLinkTypeRepo) GetByTargetObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error) {
+	var linkTypes []*entity.LinkType
+	for _, lt := range r.linkTypes {
+		if lt.TargetObjectTypeID == objectTypeID && !lt.IsDeleted {
+			stored := *lt
+			linkTypes = append(linkTypes, &stored)
+		}
+	}
+	return linkTypes, nil
+}
+
+func (r *fakeLinkTypeRepo) Update(ctx context.Context, linkType *entity.LinkType) error {
+	if _, ok := r.linkTypes[linkType.ID]; !ok {
+		return entity.ErrLinkTypeNotFound
+	}
+	stored := *linkType
+	r.linkTypes[linkType.ID] = &stored
+	r.updateAudits = append(r.updateAudits, repository.AuditEntryFromContext(ctx))
+	return nil
+}
+
+func (r *fakeLinkTypeRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	lt, ok := r.linkTypes[id]
+	if !ok || lt.IsDeleted {
+		return entity.ErrLinkTypeNotFound
+	}
+	lt.IsDeleted = true
+	return nil
+}
+
+// newTestLinkTypeService builds a LinkTypeService around repo and
+// objectTypes with in-memory collaborators
+func newTestLinkTypeService(repo repository.LinkTypeRepository, objectTypes repository.ObjectTypeRepository, publisher messaging.EventPublisher) *LinkTypeService {
+	return NewLinkTypeService(repo, objectTypes, newFakeCache(), DefaultCacheTTLs(), publisher,
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// cachedLinkChain returns the service of a three node link chain whose
+// outgoing list of the first node and incoming list of the last are cached
+func cachedLinkChain(t *testing.T) (*LinkTypeService, []uuid.UUID) {
+	t.Helper()
+	svc, ids := linkChain(t, 3)
+	if _, err := svc.GetBySourceObjectType(context.Background(), ids[0]); err != nil {
+		t.Fatalf("GetBySourceObjectType: %v", err)
+	}
+	if _, err := svc.GetByTargetObjectType(context.Background(), ids[2]); err != nil {
+		t.Fatalf("GetByTargetObjectType: %v", err)
+	}
+	return svc, ids
+}
+
+func TestGetBySourceObjectTypeServesCachedList(t *testing.T) {
+	// Arrange
+	svc, ids := cachedLinkChain(t)
+	repo := svc.repo.(*fakeLinkTypeRepo)
+	bypass := &entity.LinkType{ID: uuid.New(), Name: "bypass", SourceObjectTypeID: ids[0], TargetObjectTypeID: ids[2]}
+	repo.linkTypes[bypass.ID] = bypass
+
+	// Act
+	linkTypes, _ := svc.GetBySourceObjectType(context.Background(), ids[0])
+
+	// Assert
+	if len(linkTypes) != 1 {
+		t.Errorf("outgoing link types = %d, want the cached 1", len(linkTypes))
+	}
+}
+
+func TestCreateLinkTypeInvalidatesSourceOutgoingList(t *testing.T) {
+	// Arrange
+	svc, ids := cachedLinkChain(t)
+	if _, err := svc.CreateLinkType(context.Background(), linkInput("shortcut", ids[0], ids[2]), "alice"); err != nil {
+		t.Fatalf("CreateLinkType: %v", err)
+	}
+
+	// Act
+	linkTypes, _ := svc.GetBySourceObjectType(context.Background(), ids[0])
+
+	// Assert
+	if len(linkTypes) != 2 {
+		t.Errorf("outgoing link types = %d, want 2 after creating shortcut", len(linkTypes))
+	}
+}
+
+func TestCreateLinkTypeInvalidatesTargetIncomingList(t *testing.T) {
+	// Arrange
+	svc, ids := cachedLinkChain(t)
+	if _, err := svc.CreateLinkType(context.Background(), linkInput("shortcut", ids[0], ids[2]), "alice"); err != nil {
+		t.Fatalf("CreateLinkType: %v", err)
+	}
+
+	// Act
+	linkTypes, _ := svc.GetByTargetObjectType(context.Background(), ids[2])
+
+	// Assert
+	if len(linkTypes) != 2 {
+		t.Errorf("incoming link types = %d, want 2 after creating shortcut", len(linkTypes))
+	}
+}
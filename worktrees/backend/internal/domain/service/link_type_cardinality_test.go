@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// updateCardinality stores a link type with cardinality from and updates it
+// to to, returning the stored cardinality afterwards and the update error
+func updateCardinality(from, to entity.Cardinality, force bool) (entity.Cardinality, error) {
+	linkType := &entity.LinkType{
+		ID: uuid.New(), Name: "customerOrders", DisplayName: "Customer orders",
+		SourceObjectTypeID: uuid.New(), TargetObjectTypeID: uuid.New(),
+		Cardinality: from, Version: 1,
+	}
+	repo := newFakeLinkTypeRepo(linkType)
+	svc := newTestLinkTypeService(repo, nil, &fakePublisher{})
+
+	_, err := svc.UpdateLinkType(context.Background(), linkType.ID, UpdateLinkTypeInput{Cardinality: &to, Force: force}, "alice")
+	return repo.linkTypes[linkType.ID].Cardinality, err
+}
+
+func TestUpdateLinkTypeCardinalityTransitions(t *testing.T) {
+	cases := []struct {
+		from, to entity.Cardinality
+		allowed  bool
+	}{
+		{entity.CardinalityOneToOne, entity.CardinalityOneToOne, true},
+		{entity.CardinalityOneToOne, entity.CardinalityOneToMany, true},
+		{entity.CardinalityOneToOne, entity.CardinalityManyToMany, true},
+		{entity.CardinalityOneToMany, entity.CardinalityOneToOne, false},
+		{entity.CardinalityOneToMany, entity.CardinalityOneToMany, true},
+		{entity.CardinalityOneToMany, entity.CardinalityManyToMany, true},
+		{entity.CardinalityManyToMany, entity.CardinalityOneToOne, false},
+		{entity.CardinalityManyToMany, entity.CardinalityOneToMany, false},
+		{entity.CardinalityManyToMany, entity.CardinalityManyToMany, true},
+	}
+	for _, tc := range cases {
+		// Act
+		stored, err := updateCardinality(tc.from, tc.to, false)
+
+		// Assert
+		if got := CardinalityTransitionAllowed(tc.from, tc.to); got != tc.allowed {
+			t.Errorf("CardinalityTransitionAllowed(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.allowed)
+		}
+		if tc.allowed && (err != nil || stored != tc.to) {
+			t.Errorf("%s -> %s: update = %v leaving %s, want it applied", tc.from, tc.to, err, stored)
+		}
+		if !tc.allowed && (!errors.Is(err, ErrIncompatibleCardinalityChange) || stored != tc.from) {
+			t.Errorf("%s -> %s: update = %v leaving %s, want ErrIncompatibleCardinalityChange", tc.from, tc.to, err, stored)
+		}
+	}
+}
+
+func TestUpdateLinkTypeForcesTighteningCardinality(t *testing.T) {
+	cases := []struct{ from, to entity.Cardinality }{
+		{entity.CardinalityOneToMany, entity.CardinalityOneToOne},
+		{entity.CardinalityManyToMany, entity.CardinalityOneToOne},
+		{entity.CardinalityManyToMany, entity.CardinalityOneToMany},
+	}
+	for _, tc := range cases {
+		// Act
+		stored, err := updateCardinality(tc.from, tc.to, true)
+
+		// Assert
+		if err != nil || stored != tc.to {
+			t.Errorf("forced %s -> %s: update = %v leaving %s, want it applied", tc.from, tc.to, err, stored)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// Traversal directions for TraverseLinks
+const (
+	TraversalOutgoing = "outgoing"
+	TraversalIncoming = "incoming"
+	TraversalBoth     = "both"
+)
+
+const (
+	// DefaultTraversalDepth is used when no depth is requested
+	DefaultTraversalDepth = 2
+	// MaxTraversalDepth caps the depth of a traversal to bound its cost
+	MaxTraversalDepth = 5
+)
+
+// GraphNode is an object type reached during a traversal
+type GraphNode struct {
+	ObjectTypeID uuid.UUID `json:"objectTypeId"`
+	Name         string    `json:"name,omitempty"`
+	DisplayName  string    `json:"displayName,omitempty"`
+	Depth        int       `json:"depth"`
+}
+
+// GraphEdge is a link type crossed during a traversal
+type GraphEdge struct {
+	LinkTypeID         uuid.UUID          `json:"linkTypeId"`
+	Name               string             `json:"name"`
+	SourceObjectTypeID uuid.UUID          `json:"sourceObjectTypeId"`
+	TargetObjectTypeID uuid.UUID          `json:"targetObjectTypeId"`
+	Cardinality        entity.Cardinality `json:"cardinality"`
+	Depth              int                `json:"depth"`
+}
+
+// GraphResult is the subgraph reachable from a starting object type
+type GraphResult struct {
+	RootID    uuid.UUID   `json:"rootId"`
+	Direction string      `json:"direction"`
+	MaxDepth  int         `json:"maxDepth"`
+	Nodes     []GraphNode `json:"nodes"`
+	Edges     []GraphEdge `json:"edges"`
+}
+
+// TraverseLinks walks the link graph breadth-first from startID and returns
+// every object type reachable within maxDepth hops along with the link types
+// crossed. Each node is reported once, at the depth it was first reached.
+// maxDepth defaults to DefaultTraversalDepth and is capped at MaxTraversalDepth.
+func (s *LinkTypeService) TraverseLinks(ctx context.Context, startID uuid.UUID, maxDepth int, direction string) (*GraphResult, error) {
+	switch direction {
+	case "":
+		direction = TraversalOutgoing
+	case TraversalOutgoing, TraversalIncoming, TraversalBoth:
+	default:
+		return nil, entity.ErrInvalidTraversalDirection
+	}
+
+	if maxDepth <= 0 {
+		maxDepth = DefaultTraversalDepth
+	}
+	if maxDepth > MaxTraversalDepth {
+		maxDepth = MaxTraversalDepth
+	}
+
+	// Ensure the starting object type exists
+	if _, err := s.objectTypeRepo.GetByID(ctx, startID); err != nil {
+		return nil, err
+	}
+
+	result := &GraphResult{
+		RootID:    startID,
+		Direction: direction,
+		MaxDepth:  maxDepth,
+		Nodes:     []GraphNode{},
+		Edges:     []GraphEdge{},
+	}
+
+	depths := map[uuid.UUID]int{startID: 0}
+	order := []uuid.UUID{startID}
+	seenEdges := make(map[uuid.UUID]bool)
+	frontier := []uuid.UUID{startID}
+
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		var next []uuid.UUID
+
+		for _, nodeID := range frontier {
+			links, err := s.adjacentLinkTypes(ctx, nodeID, direction)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, link := range links {
+				if !seenEdges[link.ID] {
+					seenEdges[link.ID] = true
+					result.Edges = append(result.Edges, GraphEdge{
+						LinkTypeID:         link.ID,
+						Name:               link.Name,
+						SourceObjectTypeID: link.SourceObjectTypeID,
+						TargetObjectTypeID: link.TargetObjectTypeID,
+						Cardinality:        link.Cardinality,
+						Depth:              depth,
+					})
+				}
+
+				neighbour := link.TargetObjectTypeID
+				if neighbour == nodeID {
+					neighbour = link.SourceObjectTypeID
+				}
+				if _, visited := depths[neighbour]; visited {
+					continue
+				}
+
+				depths[neighbour] = depth
+				order = append(order, neighbour)
+				next = append(next, neighbour)
+			}
+		}
+
+		frontier = next
+	}
+
+	// Resolve node names in one query
+	objectTypes, err := s.objectTypeRepo.GetByIDs(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*entity.ObjectType, len(objectTypes))
+	for _, ot := range objectTypes {
+		byID[ot.ID] = ot
+	}
+
+	for _, id := range order {
+		node := GraphNode{ObjectTypeID: id, Depth: depths[id]}
+		if ot, ok := byID[id]; ok {
+			node.Name = ot.Name
+			node.DisplayName = ot.DisplayName
+		}
+		result.Nodes = append(result.Nodes, node)
+	}
+
+	return result, nil
+}
+
+// adjacentLinkTypes returns the link types touching an object type in the given direction
+func (s *LinkTypeService) adjacentLinkTypes(ctx context.Context, objectTypeID uuid.UUID, direction string) ([]*entity.LinkType, error) {
+	var links []*entity.LinkType
+
+	if direction == TraversalOutgoing || direction == TraversalBoth {
+		outgoing, err := s.repo.GetBySourceObjectType(ctx, objectTypeID)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, outgoing...)
+	}
+
+	if direction == TraversalIncoming || direction == TraversalBoth {
+		incoming, err := s.repo.GetByTargetObjectType(ctx, objectTypeID)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, incoming...)
+	}
+
+	return links, nil
+}
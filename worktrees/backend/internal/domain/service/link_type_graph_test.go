@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// linkGraph is a set of object types joined by link types named after their
+// ends, e.g. AB from A to B
+type linkGraph struct {
+	objectTypes map[string]*entity.ObjectType
+	linkTypes   []*entity.LinkType
+}
+
+func newLinkGraph() *linkGraph {
+	return &linkGraph{objectTypes: map[string]*entity.ObjectType{}}
+}
+
+// node returns the object type named name, adding it if needed
+func (g *linkGraph) node(name string) *entity.ObjectType {
+	if g.objectTypes[name] == nil {
+		g.objectTypes[name] = &entity.ObjectType{ID: uuid.New(), Name: name, DisplayName: name, Version: 1}
+	}
+	return g.objectTypes[name]
+}
+
+// link adds a link type from source to target
+func (g *linkGraph) link(source, target string) *linkGraph {
+	g.linkTypes = append(g.linkTypes, &entity.LinkType{
+		ID: uuid.New(), Name: source + target,
+		SourceObjectTypeID: g.node(source).ID, TargetObjectTypeID: g.node(target).ID,
+		Cardinality: entity.CardinalityOneToMany,
+	})
+	return g
+}
+
+// traverse runs TraverseLinks from the object type named start
+func (g *linkGraph) traverse(start string, maxDepth int, direction string) (*GraphResult, error) {
+	objectTypes := make([]*entity.ObjectType, 0, len(g.objectTypes))
+	for _, ot := range g.objectTypes {
+		objectTypes = append(objectTypes, ot)
+	}
+	svc := newTestLinkTypeService(newFakeLinkTypeRepo(g.linkTypes...), newFakeObjectTypeRepo(objectTypes...), &fakePublisher{})
+	return svc.TraverseLinks(context.Background(), g.node(start).ID, maxDepth, direction)
+}
+
+// nodeDepths returns the depth of each node of result by name, failing on
+// a node reported twice
+func nodeDepths(t *testing.T, result *GraphResult) map[string]int {
+	t.Helper()
+	depths := map[string]int{}
+	for _, node := range result.Nodes {
+		if _, ok := depths[node.Name]; ok {
+			t.Errorf("node %s reported more than once", node.Name)
+		}
+		depths[node.Name] = node.Depth
+	}
+	return depths
+}
+
+// edgeDepths returns the depth of each edge of result by name
+func edgeDepths(result *GraphResult) map[string]int {
+	depths := map[string]int{}
+	for _, edge := range result.Edges {
+		depths[edge.Name] = edge.Depth
+	}
+	return depths
+}
+
+// cycleGraph is the cycle A -> B -> C -> A with D -> A hanging off it
+func cycleGraph() *linkGraph {
+	return newLinkGraph().link("A", "B").link("B", "C").link("C", "A").link("D", "A")
+}
+
+func TestTraverseLinksFollowsTheDirection(t *testing.T) {
+	cases := []struct {
+		direction string
+		maxDepth  int
+		nodes     map[string]int
+		edges     map[string]int
+	}{
+		{TraversalOutgoing, 5,
+			map[string]int{"A": 0, "B": 1, "C": 2},
+			map[string]int{"AB": 1, "BC": 2, "CA": 3}},
+		{TraversalIncoming, 5,
+			map[string]int{"A": 0, "C": 1, "D": 1, "B": 2},
+			map[string]int{"CA": 1, "DA": 1, "BC": 2, "AB": 3}},
+		{TraversalBoth, 1,
+			map[string]int{"A": 0, "B": 1, "C": 1, "D": 1},
+			map[string]int{"AB": 1, "CA": 1, "DA": 1}},
+	}
+
+	for _, tc := range cases {
+		// Act
+		result, err := cycleGraph().traverse("A", tc.maxDepth, tc.direction)
+
+		// Assert
+		if err != nil {
+			t.Errorf("%s: TraverseLinks: %v", tc.direction, err)
+			continue
+		}
+		if nodes := nodeDepths(t, result); !maps.Equal(nodes, tc.nodes) {
+			t.Errorf("%s: nodes = %v, want %v", tc.direction, nodes, tc.nodes)
+		}
+		if edges := edgeDepths(result); !maps.Equal(edges, tc.edges) {
+			t.Errorf("%s: edges = %v, want %v", tc.direction, edges, tc.edges)
+		}
+		if result.Direction != tc.direction || result.Nodes[0].Name != "A" {
+			t.Errorf("%s: result = %+v, want it rooted at A", tc.direction, result)
+		}
+	}
+}
+
+func TestTraverseLinksClampsTheDepth(t *testing.T) {
+	// Arrange
+	chain := newLinkGraph()
+	for i := 0; i < MaxTraversalDepth+2; i++ {
+		chain.link(fmt.Sprint(i), fmt.Sprint(i+1))
+	}
+	cases := []struct {
+		requested int
+		want      int
+	}{
+		{0, DefaultTraversalDepth},
+		{3, 3},
+		{100, MaxTraversalDepth},
+	}
+
+	for _, tc := range cases {
+		// Act
+		result, err := chain.traverse("0", tc.requested, TraversalOutgoing)
+
+		// Assert
+		if err != nil {
+			t.Errorf("depth %d: TraverseLinks: %v", tc.requested, err)
+			continue
+		}
+		deepest := 0
+		for _, node := range result.Nodes {
+			deepest = max(deepest, node.Depth)
+		}
+		if result.MaxDepth != tc.want || deepest != tc.want || len(result.Nodes) != tc.want+1 {
+			t.Errorf("depth %d: max depth %d reaching %d nodes down to depth %d, want depth %d",
+				tc.requested, result.MaxDepth, len(result.Nodes), deepest, tc.want)
+		}
+	}
+}
+
+func TestTraverseLinksRejectsUnknownDirection(t *testing.T) {
+	// Act
+	_, err := cycleGraph().traverse("A", 2, "sideways")
+
+	// Assert
+	if !errors.Is(err, entity.ErrInvalidTraversalDirection) {
+		t.Errorf("TraverseLinks = %v, want ErrInvalidTraversalDirection", err)
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// createWithInverse creates a Customer -> Account link type with cardinality
+// and its inverse, returning the link type, the stored inverse and the repository
+func createWithInverse(t *testing.T, cardinality entity.Cardinality) (*entity.LinkType, *entity.LinkType, *fakeLinkTypeRepo) {
+	t.Helper()
+	objectTypes, ids := seedObjectTypes(2)
+	repo := newFakeLinkTypeRepo()
+	svc := newTestLinkTypeService(repo, objectTypes, &fakePublisher{})
+	input := linkInput("customerAccounts", ids[0], ids[1])
+	input.Cardinality = cardinality
+	input.CreateInverse = true
+	input.InverseDisplayName = "Account holders"
+
+	linkType, err := svc.CreateLinkType(context.Background(), input, "alice")
+	if err != nil {
+		t.Fatalf("CreateLinkType(%s): %v", cardinality, err)
+	}
+	if linkType.PairID == nil || repo.linkTypes[*linkType.PairID] == nil {
+		t.Fatalf("CreateLinkType(%s) stored no inverse", cardinality)
+	}
+	return linkType, repo.linkTypes[*linkType.PairID], repo
+}
+
+func TestCreateInverseUsesInverseCardinality(t *testing.T) {
+	cases := []struct{ cardinality, inverse entity.Cardinality }{
+		{entity.CardinalityOneToOne, entity.CardinalityOneToOne},
+		{entity.CardinalityOneToMany, entity.CardinalityManyToMany},
+		{entity.CardinalityManyToMany, entity.CardinalityManyToMany},
+	}
+	for _, tc := range cases {
+		// Act
+		_, inverse, _ := createWithInverse(t, tc.cardinality)
+
+		// Assert
+		if inverse.Cardinality != tc.inverse {
+			t.Errorf("inverse of %s = %s, want %s", tc.cardinality, inverse.Cardinality, tc.inverse)
+		}
+	}
+}
+
+func TestCreateInverseRunsFromTargetToSource(t *testing.T) {
+	// Act
+	linkType, inverse, _ := createWithInverse(t, entity.CardinalityOneToMany)
+
+	// Assert
+	if inverse.SourceObjectTypeID != linkType.TargetObjectTypeID || inverse.TargetObjectTypeID != linkType.SourceObjectTypeID {
+		t.Errorf("inverse runs %s -> %s, want %s -> %s", inverse.SourceObjectTypeID, inverse.TargetObjectTypeID,
+			linkType.TargetObjectTypeID, linkType.SourceObjectTypeID)
+	}
+	if inverse.Name != "customerAccounts_inverse" || inverse.DisplayName != "Account holders" || !inverse.IsInverse {
+		t.Errorf("inverse = %q %q inverse=%v, want customerAccounts_inverse displayed as Account holders",
+			inverse.Name, inverse.DisplayName, inverse.IsInverse)
+	}
+}
+
+func TestCreateInversePairsBothSides(t *testing.T) {
+	// Act
+	linkType, inverse, repo := createWithInverse(t, entity.CardinalityOneToMany)
+
+	// Assert
+	stored := repo.linkTypes[linkType.ID]
+	if stored.PairID == nil || *stored.PairID != inverse.ID || inverse.PairID == nil || *inverse.PairID != linkType.ID {
+		t.Errorf("pair IDs = %v and %v, want each side naming the other", stored.PairID, inverse.PairID)
+	}
+}
+
+func TestCreateWithoutInverseCreatesOneLinkType(t *testing.T) {
+	// Arrange
+	objectTypes, ids := seedObjectTypes(2)
+	repo := newFakeLinkTypeRepo()
+	svc := newTestLinkTypeService(repo, objectTypes, &fakePublisher{})
+
+	// Act
+	linkType, _ := svc.CreateLinkType(context.Background(), linkInput("customerAccounts", ids[0], ids[1]), "alice")
+
+	// Assert
+	if len(repo.linkTypes) != 1 || linkType.PairID != nil {
+		t.Errorf("stored %d link types with pair %v, want one unpaired", len(repo.linkTypes), linkType.PairID)
+	}
+}
+
+func TestCreateInverseRejectsTakenInverseName(t *testing.T) {
+	// Arrange
+	objectTypes, ids := seedObjectTypes(2)
+	taken := &entity.LinkType{ID: uuid.New(), Name: "customerAccounts_inverse"}
+	svc := newTestLinkTypeService(newFakeLinkTypeRepo(taken), objectTypes, &fakePublisher{})
+	input := linkInput("customerAccounts", ids[0], ids[1])
+	input.CreateInverse = true
+
+	// Act
+	_, err := svc.CreateLinkType(context.Background(), input, "alice")
+
+	// Assert
+	if err != entity.ErrLinkTypeNameExists {
+		t.Errorf("CreateLinkType = %v, want ErrLinkTypeNameExists", err)
+	}
+}
+
+func TestDeleteLinkTypeWithDeletePairDeletesInverse(t *testing.T) {
+	// Arrange
+	forward, inverse := newLinkTypePair()
+	repo := newFakeLinkTypeRepo(forward, inverse)
+	svc := newTestLinkTypeService(repo, nil, &fakePublisher{})
+
+	// Act
+	err := svc.DeleteLinkType(context.Background(), forward.ID, "alice", true)
+
+	// Assert
+	if err != nil || !repo.linkTypes[forward.ID].IsDeleted || !repo.linkTypes[inverse.ID].IsDeleted {
+		t.Errorf("DeleteLinkType = %v, want both sides deleted", err)
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// searchableLinkTypes returns live link types named names and a deleted one
+// named deletedPlaces
+func searchableLinkTypes(names ...string) []*entity.LinkType {
+	linkTypes := []*entity.LinkType{{ID: uuid.New(), Name: "deletedPlaces", IsDeleted: true}}
+	for _, name := range names {
+		linkTypes = append(linkTypes, &entity.LinkType{ID: uuid.New(), Name: name})
+	}
+	return linkTypes
+}
+
+func TestSearchLinkTypesCachesTheResultOfAMiss(t *testing.T) {
+	// Arrange
+	repo := newFakeLinkTypeRepo(searchableLinkTypes("customerPlaces", "customerOrders")...)
+	svc := newTestLinkTypeService(repo, newFakeObjectTypeRepo(), &fakePublisher{})
+	cache := svc.cache.(*fakeCache)
+	key := fmt.Sprintf("link_types:search:Places:%d", DefaultSearchLimit)
+
+	// Act
+	results, err := svc.SearchLinkTypes(context.Background(), "Places", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SearchLinkTypes: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "customerPlaces" {
+		t.Errorf("results = %+v, want customerPlaces alone", results)
+	}
+	if len(repo.searchLimits) != 1 || !cache.has(key) {
+		t.Errorf("repository searched %d times, cached = %v, want one search cached under %s",
+			len(repo.searchLimits), cache.has(key), key)
+	}
+}
+
+func TestSearchLinkTypesServesAHitFromTheCache(t *testing.T) {
+	// Arrange
+	repo := newFakeLinkTypeRepo(searchableLinkTypes("customerPlaces")...)
+	svc := newTestLinkTypeService(repo, newFakeObjectTypeRepo(), &fakePublisher{})
+	if _, err := svc.SearchLinkTypes(context.Background(), "Places", 10); err != nil {
+		t.Fatalf("warm the cache: %v", err)
+	}
+	added := &entity.LinkType{ID: uuid.New(), Name: "supplierPlaces"}
+	repo.linkTypes[added.ID] = added
+
+	// Act
+	results, err := svc.SearchLinkTypes(context.Background(), "Places", 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SearchLinkTypes: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "customerPlaces" {
+		t.Errorf("results = %+v, want the cached customerPlaces alone", results)
+	}
+	if len(repo.searchLimits) != 1 {
+		t.Errorf("repository searched %d times, want the hit served from the cache", len(repo.searchLimits))
+	}
+}
+
+func TestSearchLinkTypesClampsTheLimit(t *testing.T) {
+	// Arrange
+	repo := newFakeLinkTypeRepo()
+	svc := newTestLinkTypeService(repo, newFakeObjectTypeRepo(), &fakePublisher{})
+	requested := []int{-1, 0, 5, MaxSearchLimit, MaxSearchLimit + 1, 1000}
+
+	// Act
+	for i, limit := range requested {
+		if _, err := svc.SearchLinkTypes(context.Background(), fmt.Sprintf("Places%d", i), limit); err != nil {
+			t.Fatalf("limit %d: SearchLinkTypes: %v", limit, err)
+		}
+	}
+
+	// Assert
+	want := []int{DefaultSearchLimit, DefaultSearchLimit, 5, MaxSearchLimit, MaxSearchLimit, MaxSearchLimit}
+	if !slices.Equal(repo.searchLimits, want) {
+		t.Errorf("limits passed to the repository = %v for %v, want %v", repo.searchLimits, requested, want)
+	}
+}
@@ -0,0 +1,590 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"go.uber.org/zap"
+)
+
+// LinkTypeService handles business logic for link types
+type LinkTypeService struct {
+	repo           repository.LinkTypeRepository
+	objectTypeRepo repository.ObjectTypeRepository
+	cache          cache.CacheService
+	cacheTTLs      CacheTTLs
+	publisher      messaging.EventPublisher
+	metrics        *metrics.Metrics
+	logger         *zap.Logger
+}
+
+// NewLinkTypeService creates a new link type service
+func NewLinkTypeService(
+	repo repository.LinkTypeRepository,
+	objectTypeRepo repository.ObjectTypeRepository,
+	cache cache.CacheService,
+	cacheTTLs CacheTTLs,
+	publisher messaging.EventPublisher,
+	metrics *metrics.Metrics,
+	logger *zap.Logger,
+) *LinkTypeService {
+	return &LinkTypeService{
+		repo:           repo,
+		objectTypeRepo: objectTypeRepo,
+		cache:          cache,
+		cacheTTLs:      cacheTTLs,
+		publisher:      publisher,
+		metrics:        metrics,
+		logger:         logger,
+	}
+}
+
+// CreateLinkTypeInput represents input for creating a link type
+type CreateLinkTypeInput struct {
+	Name               string                 `json:"name"`
+	DisplayName        string                 `json:"displayName"`
+	SourceObjectTypeID uuid.UUID              `json:"sourceObjectTypeId"`
+	TargetObjectTypeID uuid.UUID              `json:"targetObjectTypeId"`
+	Cardinality        entity.Cardinality     `json:"cardinality"`
+	Description        *string                `json:"description"`
+	Properties         []PropertyInput        `json:"properties"`
+	Metadata           map[string]interface{} `json:"metadata"`
+	Constraints        entity.LinkConstraints `json:"constraints"`
+
+	// CreateInverse also creates the target-to-source companion of the link
+	// type, named InverseName (default "<name>_inverse") and displayed as
+	// InverseDisplayName (default DisplayName)
+	CreateInverse      bool   `json:"createInverse"`
+	InverseName        string `json:"inverseName,omitempty"`
+	InverseDisplayName string `json:"inverseDisplayName,omitempty"`
+}
+
+// CreateLinkType creates a new link type, and its inverse when
+// input.CreateInverse is set. The pair is created atomically and each side
+// names the other in PairID.
+func (s *LinkTypeService) CreateLinkType(ctx context.Context, input CreateLinkTypeInput, userID string) (*entity.LinkType, error) {
+	s.logger.Info("Creating link type", zap.String("name", input.Name), zap.String("user", userID))
+
+	// Check if name already exists
+	existing, _ := s.repo.GetByName(ctx, input.Name)
+	if existing != nil {
+		return nil, entity.ErrLinkTypeNameExists
+	}
+
+	// Both ends of the link must exist
+	if _, err := s.objectTypeRepo.GetByID(ctx, input.SourceObjectTypeID); err != nil {
+		return nil, fmt.Errorf("source object type: %w", err)
+	}
+	if _, err := s.objectTypeRepo.GetByID(ctx, input.TargetObjectTypeID); err != nil {
+		return nil, fmt.Errorf("target object type: %w", err)
+	}
+
+	// Reject links that would close a cycle through existing link types
+	cycle, err := s.repo.CheckCircularReference(ctx, input.SourceObjectTypeID, input.TargetObjectTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check circular reference: %w", err)
+	}
+	if len(cycle) > 0 {
+		return nil, entity.ErrCircularReferencePath(cycle)
+	}
+
+	// Create link type entity
+	linkType := &entity.LinkType{
+		ID:                 uuid.New(),
+		Name:               input.Name,
+		DisplayName:        input.DisplayName,
+		SourceObjectTypeID: input.SourceObjectTypeID,
+		TargetObjectTypeID: input.TargetObjectTypeID,
+		Cardinality:        input.Cardinality,
+		Description:        input.Description,
+		Properties:         buildProperties(input.Properties),
+		Metadata:           input.Metadata,
+		Constraints:        input.Constraints,
+		Version:            1,
+		IsDeleted:          false,
+		CreatedAt:          time.Now(),
+		CreatedBy:          userID,
+		UpdatedAt:          time.Now(),
+		UpdatedBy:          userID,
+	}
+
+	// Validate link type
+	if err := linkType.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrValidationFailed, err)
+	}
+
+	created := []*entity.LinkType{linkType}
+	if input.CreateInverse {
+		inverse, err := s.buildInverse(ctx, linkType, input)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, inverse)
+	}
+
+	// Save to repository
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityLinkType, linkType.ID, entity.AuditActionCreate, userID, nil, linkType)
+	if len(created) == 2 {
+		err = s.repo.CreatePair(auditCtx, linkType, created[1])
+	} else {
+		err = s.repo.Create(auditCtx, linkType)
+	}
+	if err != nil {
+		s.logger.Error("Failed to create link type", zap.Error(err))
+		return nil, fmt.Errorf("failed to create link type: %w", err)
+	}
+
+	for _, lt := range created {
+		// Invalidate cache
+		s.invalidateCache(ctx, lt)
+
+		// Publish event
+		event := messaging.Event{
+			ID:            uuid.New().String(),
+			Type:          messaging.EventLinkTypeCreated,
+			EntityID:      lt.ID.String(),
+			Actor:         userID,
+			Timestamp:     time.Now(),
+			Data:          lt,
+			CorrelationID: messaging.CorrelationIDFromContext(ctx),
+			TenantID:      repository.TenantFromContext(ctx),
+		}
+
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			// Log error but don't fail the operation
+			s.logger.Error("Failed to publish event", zap.Error(err))
+		}
+
+		s.metrics.LinkTypeCreated.Inc()
+		s.logger.Info("Link type created successfully", zap.String("id", lt.ID.String()))
+	}
+	return linkType, nil
+}
+
+// buildInverse builds the target-to-source companion of linkType with the
+// inverse cardinality, and pairs the two
+func (s *LinkTypeService) buildInverse(ctx context.Context, linkType *entity.LinkType, input CreateLinkTypeInput) (*entity.LinkType, error) {
+	name := input.InverseName
+	if name == "" {
+		name = linkType.Name + "_inverse"
+	}
+	if name == linkType.Name {
+		return nil, entity.ErrLinkTypeNameExists
+	}
+	if existing, _ := s.repo.GetByName(ctx, name); existing != nil {
+		return nil, entity.ErrLinkTypeNameExists
+	}
+
+	displayName := input.InverseDisplayName
+	if displayName == "" {
+		displayName = linkType.DisplayName
+	}
+
+	inverse := &entity.LinkType{
+		ID:                 uuid.New(),
+		Name:               name,
+		DisplayName:        displayName,
+		SourceObjectTypeID: linkType.TargetObjectTypeID,
+		TargetObjectTypeID: linkType.SourceObjectTypeID,
+		Cardinality:        linkType.GetInverseCardinality(),
+		Description:        linkType.Description,
+		Properties:         buildProperties(input.Properties),
+		Metadata:           linkType.Metadata,
+		Constraints:        linkType.Constraints,
+		Version:            1,
+		IsDeleted:          false,
+		CreatedAt:          linkType.CreatedAt,
+		CreatedBy:          linkType.CreatedBy,
+		UpdatedAt:          linkType.UpdatedAt,
+		UpdatedBy:          linkType.UpdatedBy,
+		PairID:             &linkType.ID,
+		IsInverse:          true,
+	}
+
+	if err := inverse.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: inverse: %w", entity.ErrValidationFailed, err)
+	}
+
+	linkType.PairID = &inverse.ID
+	return inverse, nil
+}
+
+// GetByID retrieves a link type by ID
+func (s *LinkTypeService) GetByID(ctx context.Context, id uuid.UUID) (*entity.LinkType, error) {
+	// Try cache first
+	cacheKey := fmt.Sprintf("link_type:%s", id.String())
+	var cached *entity.LinkType
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && cached != nil {
+		return cached, nil
+	}
+
+	// Get from repository
+	linkType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, normalizeLinkTypeNotFound(err)
+	}
+
+	// Cache the result
+	_ = s.cache.Set(ctx, cacheKey, linkType, s.cacheTTLs.LinkType)
+
+	return linkType, nil
+}
+
+// GetByName retrieves a link type by name
+func (s *LinkTypeService) GetByName(ctx context.Context, name string) (*entity.LinkType, error) {
+	linkType, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, normalizeLinkTypeNotFound(err)
+	}
+	return linkType, nil
+}
+
+// UpdateLinkTypeInput represents input for updating a link type
+type UpdateLinkTypeInput struct {
+	DisplayName *string                 `json:"displayName,omitempty"`
+	Cardinality *entity.Cardinality     `json:"cardinality,omitempty"`
+	Description *string                 `json:"description,omitempty"`
+	Properties  []PropertyInput         `json:"properties,omitempty"`
+	Metadata    map[string]interface{}  `json:"metadata,omitempty"`
+	Constraints *entity.LinkConstraints `json:"constraints,omitempty"`
+
+	// Force allows cardinality changes that tighten the relationship, see
+	// CardinalityTransitionAllowed
+	Force bool `json:"force,omitempty"`
+}
+
+// ErrIncompatibleCardinalityChange is returned when an update tightens the
+// cardinality of a link type without Force
+var ErrIncompatibleCardinalityChange = errors.New("cardinality change may be violated by existing links")
+
+// cardinalityRank orders cardinalities from tightest to loosest
+var cardinalityRank = map[entity.Cardinality]int{
+	entity.CardinalityOneToOne:   0,
+	entity.CardinalityOneToMany:  1,
+	entity.CardinalityManyToMany: 2,
+}
+
+// CardinalityTransitionAllowed reports whether a link type can change from
+// one cardinality to another without Force. Loosening is always allowed,
+// since existing links satisfy the looser constraint; tightening is not:
+//
+//	from \ to     ONE_TO_ONE  ONE_TO_MANY  MANY_TO_MANY
+//	ONE_TO_ONE    yes         yes          yes
+//	ONE_TO_MANY   no          yes          yes
+//	MANY_TO_MANY  no          no           yes
+func CardinalityTransitionAllowed(from, to entity.Cardinality) bool {
+	return cardinalityRank[to] >= cardinalityRank[from]
+}
+
+// UpdateLinkType updates an existing link type. Tightening the cardinality
+// fails with ErrIncompatibleCardinalityChange unless input.Force is set.
+func (s *LinkTypeService) UpdateLinkType(ctx context.Context, id uuid.UUID, input UpdateLinkTypeInput, userID string) (*entity.LinkType, error) {
+	s.logger.Info("Updating link type", zap.String("id", id.String()), zap.String("user", userID))
+
+	// Get existing link type
+	linkType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, normalizeLinkTypeNotFound(err)
+	}
+	before := *linkType
+
+	// Apply updates
+	if input.DisplayName != nil {
+		linkType.DisplayName = *input.DisplayName
+	}
+	if input.Cardinality != nil && *input.Cardinality != linkType.Cardinality {
+		if input.Cardinality.IsValid() && !CardinalityTransitionAllowed(linkType.Cardinality, *input.Cardinality) {
+			if !input.Force {
+				return nil, fmt.Errorf("%w: %s to %s", ErrIncompatibleCardinalityChange, linkType.Cardinality, *input.Cardinality)
+			}
+			s.logger.Warn("Forcing cardinality change that existing links may violate",
+				zap.String("id", id.String()),
+				zap.String("from", string(linkType.Cardinality)),
+				zap.String("to", string(*input.Cardinality)))
+		}
+		linkType.Cardinality = *input.Cardinality
+	}
+	if input.Description != nil {
+		linkType.Description = input.Description
+	}
+	if input.Properties != nil {
+		linkType.Properties = buildProperties(input.Properties)
+	}
+	if input.Metadata != nil {
+		linkType.Metadata = input.Metadata
+	}
+	if input.Constraints != nil {
+		linkType.Constraints = *input.Constraints
+	}
+
+	// Update metadata
+	linkType.IncrementVersion()
+	linkType.SetUpdatedBy(userID)
+
+	// Validate
+	if err := linkType.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrValidationFailed, err)
+	}
+
+	// Save to repository
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityLinkType, id, entity.AuditActionUpdate, userID, &before, linkType)
+	if err := s.repo.Update(auditCtx, linkType); err != nil {
+		if err = normalizeLinkTypeNotFound(err); err == entity.ErrLinkTypeNotFound {
+			return nil, err
+		}
+		s.logger.Error("Failed to update link type", zap.Error(err))
+		return nil, fmt.Errorf("failed to update link type: %w", err)
+	}
+
+	// Invalidate cache
+	s.invalidateCache(ctx, linkType)
+
+	// Publish event
+	event := messaging.Event{
+		ID:            uuid.New().String(),
+		Type:          messaging.EventLinkTypeUpdated,
+		EntityID:      linkType.ID.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          linkType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	s.metrics.LinkTypeUpdated.Inc()
+	s.logger.Info("Link type updated successfully", zap.String("id", linkType.ID.String()))
+	return linkType, nil
+}
+
+// DeleteLinkType soft deletes a link type. If it is one side of an inverse
+// pair, the other side is deleted as well when deletePair is set, and
+// otherwise kept as a standalone link type.
+func (s *LinkTypeService) DeleteLinkType(ctx context.Context, id uuid.UUID, userID string, deletePair bool) error {
+	s.logger.Info("Deleting link type", zap.String("id", id.String()), zap.String("user", userID))
+
+	// Check if link type exists
+	linkType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return normalizeLinkTypeNotFound(err)
+	}
+
+	if err := s.deleteLinkType(ctx, linkType, userID); err != nil {
+		return err
+	}
+
+	if linkType.PairID == nil {
+		return nil
+	}
+
+	// Delete the paired link type too, or unpair it so it stands alone
+	pair, err := s.repo.GetByID(ctx, *linkType.PairID)
+	if err != nil {
+		if normalizeLinkTypeNotFound(err) == entity.ErrLinkTypeNotFound {
+			return nil
+		}
+		s.logger.Error("Failed to load paired link type", zap.Error(err))
+		return fmt.Errorf("failed to load paired link type: %w", err)
+	}
+
+	if deletePair {
+		return s.deleteLinkType(ctx, pair, userID)
+	}
+	return s.unpairLinkType(ctx, pair, userID)
+}
+
+// unpairLinkType saves linkType as a new, standalone version and publishes
+// its update
+func (s *LinkTypeService) unpairLinkType(ctx context.Context, linkType *entity.LinkType, userID string) error {
+	before := *linkType
+	linkType.PairID = nil
+	linkType.IsInverse = false
+	linkType.IncrementVersion()
+	linkType.SetUpdatedBy(userID)
+
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityLinkType, linkType.ID, entity.AuditActionUpdate, userID, &before, linkType)
+	if err := s.repo.Update(auditCtx, linkType); err != nil {
+		if err = normalizeLinkTypeNotFound(err); err == entity.ErrLinkTypeNotFound {
+			return nil
+		}
+		s.logger.Error("Failed to unpair link type", zap.Error(err))
+		return fmt.Errorf("failed to unpair link type: %w", err)
+	}
+
+	s.invalidateCache(ctx, linkType)
+
+	event := messaging.Event{
+		ID:            uuid.New().String(),
+		Type:          messaging.EventLinkTypeUpdated,
+		EntityID:      linkType.ID.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          linkType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	s.metrics.LinkTypeUpdated.Inc()
+	return nil
+}
+
+// deleteLinkType soft deletes a single link type and publishes its event
+func (s *LinkTypeService) deleteLinkType(ctx context.Context, linkType *entity.LinkType, userID string) error {
+	id := linkType.ID
+
+	// Soft delete
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityLinkType, id, entity.AuditActionDelete, userID, linkType, nil)
+	if err := s.repo.Delete(auditCtx, id); err != nil {
+		if err = normalizeLinkTypeNotFound(err); err == entity.ErrLinkTypeNotFound {
+			return err
+		}
+		s.logger.Error("Failed to delete link type", zap.Error(err))
+		return fmt.Errorf("failed to delete link type: %w", err)
+	}
+
+	// Invalidate cache
+	s.invalidateCache(ctx, linkType)
+
+	// Publish event
+	event := messaging.Event{
+		ID:        uuid.New().String(),
+		Type:      messaging.EventLinkTypeDeleted,
+		EntityID:  id.String(),
+		Actor:     userID,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"linkTypeId": id.String(),
+			"name":       linkType.Name,
+		},
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	s.metrics.LinkTypeDeleted.Inc()
+	s.logger.Info("Link type deleted successfully", zap.String("id", id.String()))
+	return nil
+}
+
+// List retrieves a list of link types based on filter
+func (s *LinkTypeService) List(ctx context.Context, filter repository.LinkTypeFilter) ([]*entity.LinkType, error) {
+	return s.cachedList(ctx, linkTypeListCacheKey(filter), func() ([]*entity.LinkType, error) {
+		return s.repo.List(ctx, filter)
+	})
+}
+
+// Count counts link types matching the filter
+func (s *LinkTypeService) Count(ctx context.Context, filter repository.LinkTypeFilter) (int64, error) {
+	return s.repo.Count(ctx, filter)
+}
+
+// GetBySourceObjectType retrieves link types originating from an object type
+func (s *LinkTypeService) GetBySourceObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error) {
+	return s.cachedList(ctx, fmt.Sprintf("link_types:source:%s", objectTypeID.String()), func() ([]*entity.LinkType, error) {
+		return s.repo.GetBySourceObjectType(ctx, objectTypeID)
+	})
+}
+
+// GetByTargetObjectType retrieves link types pointing at an object type
+func (s *LinkTypeService) GetByTargetObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error) {
+	return s.cachedList(ctx, fmt.Sprintf("link_types:target:%s", objectTypeID.String()), func() ([]*entity.LinkType, error) {
+		return s.repo.GetByTargetObjectType(ctx, objectTypeID)
+	})
+}
+
+// cachedList returns the list cached under cacheKey, loading and caching it on a miss
+func (s *LinkTypeService) cachedList(ctx context.Context, cacheKey string, load func() ([]*entity.LinkType, error)) ([]*entity.LinkType, error) {
+	// Try cache first
+	var cached []*entity.LinkType
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && cached != nil {
+		return cached, nil
+	}
+
+	linkTypes, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache empty results as [] rather than null so they count as hits
+	if linkTypes == nil {
+		linkTypes = []*entity.LinkType{}
+	}
+	_ = s.cache.Set(ctx, cacheKey, linkTypes, s.cacheTTLs.LinkTypeList)
+
+	return linkTypes, nil
+}
+
+// linkTypeListCacheKey derives a cache key from every field of a list filter
+func linkTypeListCacheKey(filter repository.LinkTypeFilter) string {
+	data, _ := json.Marshal(filter)
+	sum := sha256.Sum256(data)
+	return "link_types:list:" + hex.EncodeToString(sum[:])
+}
+
+// SearchLinkTypes searches for link types. A non-positive limit falls back to
+// DefaultSearchLimit and larger limits are capped at MaxSearchLimit.
+func (s *LinkTypeService) SearchLinkTypes(ctx context.Context, query string, limit int) ([]*entity.LinkType, error) {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	} else if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	// Try cache first
+	cacheKey := fmt.Sprintf("link_types:search:%s:%d", query, limit)
+	var cached []*entity.LinkType
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && cached != nil {
+		return cached, nil
+	}
+
+	// Search in repository
+	results, err := s.repo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the results
+	_ = s.cache.Set(ctx, cacheKey, results, s.cacheTTLs.Search)
+
+	return results, nil
+}
+
+// invalidateCache invalidates cache entries for a link type, including the
+// outgoing and incoming lists of the object types it connects
+func (s *LinkTypeService) invalidateCache(ctx context.Context, linkType *entity.LinkType) {
+	_ = s.cache.Delete(ctx, fmt.Sprintf("link_type:%s", linkType.ID.String()))
+	_ = s.cache.Delete(ctx, fmt.Sprintf("link_types:source:%s", linkType.SourceObjectTypeID.String()))
+	_ = s.cache.Delete(ctx, fmt.Sprintf("link_types:target:%s", linkType.TargetObjectTypeID.String()))
+	_ = s.cache.InvalidatePattern(ctx, "link_types:list:*")
+	_ = s.cache.InvalidatePattern(ctx, "link_types:search:*")
+}
+
+// normalizeLinkTypeNotFound maps any not-found error from the repository onto
+// entity.ErrLinkTypeNotFound so handlers can answer 404 with a plain comparison
+func normalizeLinkTypeNotFound(err error) error {
+	if errors.Is(err, entity.ErrLinkTypeNotFound) || errors.Is(err, repository.ErrNotFound) {
+		return entity.ErrLinkTypeNotFound
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// newLinkTypePair returns a link type and its inverse, paired to each other
+func newLinkTypePair() (*entity.LinkType, *entity.LinkType) {
+	forward := &entity.LinkType{ID: uuid.New(), Name: "owns", Version: 1}
+	inverse := &entity.LinkType{ID: uuid.New(), Name: "owned_by", Version: 3, IsInverse: true}
+	forward.PairID = &inverse.ID
+	inverse.PairID = &forward.ID
+	return forward, inverse
+}
+
+func TestDeleteLinkTypeUnpairsSurvivorAsNewVersion(t *testing.T) {
+	// Arrange
+	forward, inverse := newLinkTypePair()
+	repo := newFakeLinkTypeRepo(forward, inverse)
+	svc := newTestLinkTypeService(repo, nil, &fakePublisher{})
+
+	// Act
+	_ = svc.DeleteLinkType(context.Background(), forward.ID, "alice", false)
+
+	// Assert
+	survivor := repo.linkTypes[inverse.ID]
+	if survivor.PairID != nil || survivor.IsInverse || survivor.Version != 4 {
+		t.Errorf("survivor = pair %v, inverse %v, version %d; want unpaired version 4",
+			survivor.PairID, survivor.IsInverse, survivor.Version)
+	}
+}
+
+func TestDeleteLinkTypeAuditsUnpairing(t *testing.T) {
+	// Arrange
+	forward, inverse := newLinkTypePair()
+	repo := newFakeLinkTypeRepo(forward, inverse)
+	svc := newTestLinkTypeService(repo, nil, &fakePublisher{})
+
+	// Act
+	_ = svc.DeleteLinkType(context.Background(), forward.ID, "alice", false)
+
+	// Assert
+	if len(repo.updateAudits) != 1 || repo.updateAudits[0] == nil ||
+		repo.updateAudits[0].EntityID != inverse.ID || repo.updateAudits[0].Action != entity.AuditActionUpdate {
+		t.Errorf("update audits = %+v; want one update entry for %s", repo.updateAudits, inverse.ID)
+	}
+}
+
+func TestDeleteLinkTypePublishesUnpairingUpdate(t *testing.T) {
+	// Arrange
+	forward, inverse := newLinkTypePair()
+	publisher := &fakePublisher{}
+	svc := newTestLinkTypeService(newFakeLinkTypeRepo(forward, inverse), nil, publisher)
+
+	// Act
+	_ = svc.DeleteLinkType(context.Background(), forward.ID, "alice", false)
+
+	// Assert
+	var updated bool
+	for _, event := range publisher.events {
+		if event.Type == messaging.EventLinkTypeUpdated && event.EntityID == inverse.ID.String() {
+			updated = true
+		}
+	}
+	if !updated {
+		t.Errorf("no %s event for the unpaired link type among %d events", messaging.EventLinkTypeUpdated, len(publisher.events))
+	}
+}
+
+// linkChain returns a link type service over n object types linked in a
+// chain types[0] -> types[1] -> ... -> types[n-1]
+func linkChain(t *testing.T, n int) (*LinkTypeService, []uuid.UUID) {
+	t.Helper()
+	objectTypes, ids := seedObjectTypes(n)
+	svc := newTestLinkTypeService(newFakeLinkTypeRepo(), objectTypes, &fakePublisher{})
+	for i := 0; i+1 < n; i++ {
+		if _, err := svc.CreateLinkType(context.Background(), linkInput(fmt.Sprintf("link_%d", i), ids[i], ids[i+1]), "alice"); err != nil {
+			t.Fatalf("create chain link %d: %v", i, err)
+		}
+	}
+	return svc, ids
+}
+
+func linkInput(name string, source, target uuid.UUID) CreateLinkTypeInput {
+	return CreateLinkTypeInput{
+		Name:               name,
+		DisplayName:        name,
+		SourceObjectTypeID: source,
+		TargetObjectTypeID: target,
+		Cardinality:        entity.CardinalityOneToMany,
+	}
+}
+
+func TestCreateLinkTypeRejectsThreeNodeCycle(t *testing.T) {
+	// Arrange
+	svc, ids := linkChain(t, 3)
+
+	// Act
+	_, err := svc.CreateLinkType(context.Background(), linkInput("closing", ids[2], ids[0]), "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrCircularReference) {
+		t.Errorf("err = %v, want %v", err, entity.ErrCircularReference)
+	}
+}
+
+func TestCreateLinkTypeRejectsFourNodeCycle(t *testing.T) {
+	// Arrange
+	svc, ids := linkChain(t, 4)
+
+	// Act
+	_, err := svc.CreateLinkType(context.Background(), linkInput("closing", ids[3], ids[0]), "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrCircularReference) {
+		t.Errorf("err = %v, want %v", err, entity.ErrCircularReference)
+	}
+}
+
+func TestCreateLinkTypeCycleErrorNamesThePath(t *testing.T) {
+	// Arrange
+	svc, ids := linkChain(t, 4)
+	want := fmt.Sprintf("%s -> %s -> %s -> %s -> %s", ids[3], ids[0], ids[1], ids[2], ids[3])
+
+	// Act
+	_, err := svc.CreateLinkType(context.Background(), linkInput("closing", ids[3], ids[0]), "alice")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("err = %v, want it to contain the path %s", err, want)
+	}
+}
+
+func TestCreateLinkTypeAllowsLinkThatClosesNoCycle(t *testing.T) {
+	// Arrange
+	svc, ids := linkChain(t, 4)
+
+	// Act
+	_, err := svc.CreateLinkType(context.Background(), linkInput("shortcut", ids[0], ids[3]), "alice")
+
+	// Assert
+	if err != nil {
+		t.Errorf("err = %v, want nil for a link parallel to the chain", err)
+	}
+}
+
+func TestCreateLinkTypeRejectsSelfLink(t *testing.T) {
+	// Arrange
+	svc, ids := linkChain(t, 1)
+
+	// Act
+	_, err := svc.CreateLinkType(context.Background(), linkInput("self", ids[0], ids[0]), "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrCircularReference) {
+		t.Errorf("err = %v, want %v", err, entity.ErrCircularReference)
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+func TestPurgeObjectTypeAuditsAsUser(t *testing.T) {
+	// Arrange
+	deleted := &entity.ObjectType{ID: uuid.New(), Name: "Customer", IsDeleted: true}
+	repo := newFakeObjectTypeRepo(deleted)
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_ = svc.PurgeObjectType(context.Background(), deleted.ID, "alice")
+
+	// Assert
+	if repo.purgeAudit == nil || repo.purgeAudit.Actor != "alice" {
+		t.Errorf("purge audit entry = %+v, want one naming alice", repo.purgeAudit)
+	}
+}
+
+func TestUpdateObjectTypeAuditsChangeAsUser(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	ctx := messaging.WithCorrelationID(context.Background(), "req-42")
+	displayName := "Client"
+
+	// Act
+	_, err := svc.UpdateObjectType(ctx, objectType.ID, UpdateObjectTypeInput{DisplayName: &displayName}, "bob")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("UpdateObjectType: %v", err)
+	}
+	entry := repo.updateAudit
+	if entry == nil {
+		t.Fatal("Update was called without an audit entry")
+	}
+	if entry.Actor != "bob" || entry.Action != entity.AuditActionUpdate ||
+		entry.EntityType != entity.AuditEntityObjectType || entry.EntityID != objectType.ID {
+		t.Errorf("audit entry = %+v, want an object type update of %s by bob", entry, objectType.ID)
+	}
+	if entry.CorrelationID != "req-42" {
+		t.Errorf("correlation ID = %q, want req-42", entry.CorrelationID)
+	}
+	before, _ := entry.OldValue.(*entity.ObjectType)
+	after, _ := entry.NewValue.(*entity.ObjectType)
+	if before == nil || after == nil || before.DisplayName != "Customer" || after.DisplayName != "Client" {
+		t.Errorf("snapshots = %+v -> %+v, want Customer renamed Client", entry.OldValue, entry.NewValue)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// batchInputs returns create inputs for object types named names
+func batchInputs(names ...string) []CreateObjectTypeInput {
+	inputs := make([]CreateObjectTypeInput, len(names))
+	for i, name := range names {
+		inputs[i] = CreateObjectTypeInput{Name: name, DisplayName: name}
+	}
+	return inputs
+}
+
+// assertNothingCreated fails t unless the batch failed at index alone,
+// leaving repo empty and publishing nothing
+func assertNothingCreated(t *testing.T, name string, results []BatchResult, err error, index int, repo *fakeObjectTypeRepo, publisher *fakePublisher) {
+	t.Helper()
+	if !errors.Is(err, entity.ErrBatchFailed) {
+		t.Errorf("%s: BatchCreateObjectTypes = %v, want ErrBatchFailed", name, err)
+	}
+	for i, result := range results {
+		if result.Index != i || (result.Error != "") != (i == index) || result.ID != nil {
+			t.Errorf("%s: results[%d] = %+v, want an error only at index %d and no IDs", name, i, result, index)
+		}
+	}
+	if len(repo.objectTypes) != 0 || repo.batchCreates != 0 || len(publisher.events) != 0 {
+		t.Errorf("%s: stored %d object types in %d batches with %d events, want none",
+			name, len(repo.objectTypes), repo.batchCreates, len(publisher.events))
+	}
+}
+
+func TestBatchCreateWithInvalidItemCreatesNothing(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	publisher := &fakePublisher{}
+	svc := newTestObjectTypeService(repo)
+	svc.publisher = publisher
+	inputs := batchInputs("Customer", "Account", "Invoice")
+	inputs[2].DisplayName = ""
+
+	// Act
+	results, err := svc.BatchCreateObjectTypes(context.Background(), inputs, "alice")
+
+	// Assert
+	assertNothingCreated(t, "invalid item", results, err, 2, repo, publisher)
+}
+
+func TestBatchCreateWithDuplicateNameCreatesNothing(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	publisher := &fakePublisher{}
+	svc := newTestObjectTypeService(repo)
+	svc.publisher = publisher
+
+	// Act
+	results, err := svc.BatchCreateObjectTypes(context.Background(), batchInputs("Customer", "Account", "Customer"), "alice")
+
+	// Assert
+	assertNothingCreated(t, "duplicate name", results, err, 2, repo, publisher)
+	if len(results) == 3 && !strings.Contains(results[2].Error, "duplicates item 0") {
+		t.Errorf("results[2].Error = %q, want it to name item 0", results[2].Error)
+	}
+}
+
+func TestBatchCreateCreatesEveryItem(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	publisher := &fakePublisher{}
+	svc := newTestObjectTypeService(repo)
+	svc.publisher = publisher
+
+	// Act
+	results, err := svc.BatchCreateObjectTypes(context.Background(), batchInputs("Customer", "Account"), "alice")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("BatchCreateObjectTypes: %v", err)
+	}
+	for i, result := range results {
+		if result.ID == nil || repo.objectTypes[*result.ID] == nil || result.Error != "" {
+			t.Errorf("results[%d] = %+v, want the ID of a stored object type", i, result)
+		}
+	}
+	if repo.batchCreates != 1 || len(publisher.events) != 2 {
+		t.Errorf("batches = %d, events = %d, want one batch and one event per item", repo.batchCreates, len(publisher.events))
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// batchDelete is a batch of Invoice, Account and Order where Account is
+// still the target of a customerAccounts link type from Customer
+type batchDelete struct {
+	svc       *ObjectTypeService
+	repo      *fakeObjectTypeRepo
+	publisher *fakePublisher
+	ids       []uuid.UUID
+	linkType  *entity.LinkType
+}
+
+// blockedIndex is the position of Account in a batchDelete
+const blockedIndex = 1
+
+func newBatchDelete() *batchDelete {
+	customer := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1}
+	invoice := &entity.ObjectType{ID: uuid.New(), Name: "Invoice", DisplayName: "Invoice", Version: 1}
+	account := &entity.ObjectType{ID: uuid.New(), Name: "Account", DisplayName: "Account", Version: 1}
+	order := &entity.ObjectType{ID: uuid.New(), Name: "Order", DisplayName: "Order", Version: 1}
+	linkType := &entity.LinkType{
+		ID: uuid.New(), Name: "customerAccounts", DisplayName: "Customer accounts",
+		SourceObjectTypeID: customer.ID, TargetObjectTypeID: account.ID,
+		Cardinality: entity.CardinalityOneToMany,
+	}
+
+	repo := newFakeObjectTypeRepo(customer, invoice, account, order)
+	repo.links = newFakeLinkTypeRepo(linkType)
+	publisher := &fakePublisher{}
+	svc := NewObjectTypeService(repo, repo.links, newFakeCache(), DefaultCacheTTLs(), publisher,
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	return &batchDelete{
+		svc: svc, repo: repo, publisher: publisher,
+		ids:      []uuid.UUID{invoice.ID, account.ID, order.ID},
+		linkType: linkType,
+	}
+}
+
+// run batch deletes the Invoice, Account and Order object types
+func (b *batchDelete) run(continueOnError bool) ([]BatchResult, error) {
+	return b.svc.BatchDeleteObjectTypes(context.Background(), b.ids, "alice", continueOnError)
+}
+
+// deleted reports whether the object type at index of the batch is deleted
+func (b *batchDelete) deleted(index int) bool {
+	return b.repo.objectTypes[b.ids[index]].IsDeleted
+}
+
+func TestBatchDeleteWithBlockedItemDeletesNothing(t *testing.T) {
+	// Arrange
+	b := newBatchDelete()
+
+	// Act
+	results, err := b.run(false)
+
+	// Assert
+	if !errors.Is(err, entity.ErrBatchFailed) {
+		t.Fatalf("BatchDeleteObjectTypes = %v, want ErrBatchFailed", err)
+	}
+	for i, result := range results {
+		blocked := i == blockedIndex
+		if result.Index != i || (result.Error != "") != blocked {
+			t.Errorf("results[%d] = %+v, want an error only at index %d", i, result, blockedIndex)
+		}
+		if b.deleted(i) {
+			t.Errorf("item %d was deleted, want nothing deleted", i)
+		}
+	}
+	if !strings.Contains(results[blockedIndex].Error, b.linkType.Name) {
+		t.Errorf("blocked error = %q, want it to name %s", results[blockedIndex].Error, b.linkType.Name)
+	}
+	if b.repo.batchDeletes != 0 || len(b.publisher.events) != 0 {
+		t.Errorf("batch deletes = %d, events = %d, want none", b.repo.batchDeletes, len(b.publisher.events))
+	}
+}
+
+func TestBatchDeleteContinuingOnErrorDeletesTheOthers(t *testing.T) {
+	// Arrange
+	b := newBatchDelete()
+
+	// Act
+	results, err := b.run(true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("BatchDeleteObjectTypes: %v", err)
+	}
+	for i, result := range results {
+		blocked := i == blockedIndex
+		if (result.Error != "") != blocked || b.deleted(i) == blocked {
+			t.Errorf("item %d: error %q, deleted %v, want only item %d kept with an error",
+				i, result.Error, b.deleted(i), blockedIndex)
+		}
+	}
+	if b.linkType.IsDeleted {
+		t.Error("the blocking link type was deleted")
+	}
+	if len(b.publisher.events) != len(b.ids)-1 {
+		t.Errorf("events = %d, want one per deleted object type", len(b.publisher.events))
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// missRecordingRepo records the IDs each GetByIDs call asks the database for
+type missRecordingRepo struct {
+	*fakeObjectTypeRepo
+	requested [][]uuid.UUID
+}
+
+func (r *missRecordingRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error) {
+	r.requested = append(r.requested, ids)
+	return r.fakeObjectTypeRepo.GetByIDs(ctx, ids)
+}
+
+// batchGetFixture seeds four object types, warms the cache with the first two
+// and returns the service, its repository and the seeded IDs
+func batchGetFixture(t *testing.T) (*ObjectTypeService, *missRecordingRepo, []uuid.UUID) {
+	t.Helper()
+	seeded, ids := seedObjectTypes(4)
+	repo := &missRecordingRepo{fakeObjectTypeRepo: seeded}
+	svc := newTestObjectTypeService(repo)
+	for _, id := range ids[:2] {
+		if _, err := svc.GetByID(context.Background(), id); err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+	}
+	return svc, repo, ids
+}
+
+// idsOf returns the IDs of objectTypes in order
+func idsOf(objectTypes []*entity.ObjectType) []uuid.UUID {
+	ids := make([]uuid.UUID, len(objectTypes))
+	for i, objectType := range objectTypes {
+		ids[i] = objectType.ID
+	}
+	return ids
+}
+
+func TestGetByIDsFetchesOnlyCacheMisses(t *testing.T) {
+	// Arrange
+	svc, repo, ids := batchGetFixture(t)
+
+	// Act
+	objectTypes, err := svc.GetByIDs(context.Background(), []uuid.UUID{ids[2], ids[0], ids[3], ids[1]})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetByIDs: %v", err)
+	}
+	if len(repo.requested) != 1 || !slices.Equal(repo.requested[0], []uuid.UUID{ids[2], ids[3]}) {
+		t.Errorf("database asked for %v, want only the misses %v", repo.requested, ids[2:])
+	}
+	if got := idsOf(objectTypes); !slices.Equal(got, []uuid.UUID{ids[2], ids[0], ids[3], ids[1]}) {
+		t.Errorf("ids = %v, want the request order", got)
+	}
+}
+
+func TestGetByIDsAllCachedSkipsTheDatabase(t *testing.T) {
+	// Arrange
+	svc, repo, ids := batchGetFixture(t)
+
+	// Act
+	objectTypes, _ := svc.GetByIDs(context.Background(), ids[:2])
+
+	// Assert
+	if len(repo.requested) != 0 || len(objectTypes) != 2 {
+		t.Errorf("database asked for %v with %d results, want 2 cached results", repo.requested, len(objectTypes))
+	}
+}
+
+func TestGetByIDsCachesFetchedMisses(t *testing.T) {
+	// Arrange
+	svc, repo, ids := batchGetFixture(t)
+	_, _ = svc.GetByIDs(context.Background(), ids)
+
+	// Act
+	objectTypes, _ := svc.GetByIDs(context.Background(), ids)
+
+	// Assert
+	if len(repo.requested) != 1 || len(objectTypes) != 4 {
+		t.Errorf("database asked %d times with %d results, want one fetch and 4 results", len(repo.requested), len(objectTypes))
+	}
+}
+
+func TestGetByIDsOmitsMissingAndRepeatedIDs(t *testing.T) {
+	// Arrange
+	svc, repo, ids := batchGetFixture(t)
+	missing := uuid.New()
+
+	// Act
+	objectTypes, _ := svc.GetByIDs(context.Background(), []uuid.UUID{ids[0], missing, ids[2], ids[0], ids[2]})
+
+	// Assert
+	if got := idsOf(objectTypes); !slices.Equal(got, []uuid.UUID{ids[0], ids[2]}) {
+		t.Errorf("ids = %v, want %v", got, []uuid.UUID{ids[0], ids[2]})
+	}
+	if len(repo.requested) != 1 || !slices.Equal(repo.requested[0], []uuid.UUID{missing, ids[2]}) {
+		t.Errorf("database asked for %v, want each miss once", repo.requested)
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// taggedObjectTypes returns a repository of four object types at version 1,
+// the first two of which are already tagged crm, and their IDs
+func taggedObjectTypes() (*fakeObjectTypeRepo, []uuid.UUID) {
+	repo, ids := seedObjectTypes(4)
+	for i, id := range ids {
+		ot := repo.objectTypes[id]
+		ot.Version = 1
+		if i < 2 {
+			ot.Tags = []string{"crm"}
+		}
+	}
+	return repo, ids
+}
+
+// versionsOf returns the stored version of each object type in ids
+func versionsOf(repo *fakeObjectTypeRepo, ids []uuid.UUID) []int {
+	versions := make([]int, len(ids))
+	for i, id := range ids {
+		versions[i] = repo.objectTypes[id].Version
+	}
+	return versions
+}
+
+func TestBulkAddTagOnlyVersionsTypesWithoutTheTag(t *testing.T) {
+	// Arrange
+	repo, ids := taggedObjectTypes()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	err := svc.BulkAddTag(context.Background(), ids, "crm", "alice")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("BulkAddTag: %v", err)
+	}
+	if got := versionsOf(repo, ids); !slices.Equal(got, []int{1, 1, 2, 2}) {
+		t.Errorf("versions = %v, want only the two untagged types bumped", got)
+	}
+	for _, id := range ids {
+		if tags := repo.objectTypes[id].Tags; !slices.Equal(tags, []string{"crm"}) {
+			t.Errorf("tags of %s = %v, want [crm] once", id, tags)
+		}
+	}
+	if events := svc.publisher.(*fakePublisher).events; len(events) != 2 {
+		t.Errorf("events = %d, want one per changed type", len(events))
+	}
+}
+
+func TestBulkAddTagSavesChangesInOneBatch(t *testing.T) {
+	// Arrange
+	repo, ids := taggedObjectTypes()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_ = svc.BulkAddTag(context.Background(), ids, "crm", "alice")
+
+	// Assert
+	if repo.batchUpdates != 1 || len(repo.versions) != 2 {
+		t.Errorf("batch updates = %d with %d versions, want one batch of 2", repo.batchUpdates, len(repo.versions))
+	}
+	if got := repo.versions[0].ChangeDescription; got != "added tag crm" {
+		t.Errorf("change description = %q, want %q", got, "added tag crm")
+	}
+}
+
+func TestRepeatedBulkAddTagChangesNothing(t *testing.T) {
+	// Arrange
+	repo, ids := taggedObjectTypes()
+	svc := newTestObjectTypeService(repo)
+	_ = svc.BulkAddTag(context.Background(), ids, "crm", "alice")
+	publisher := svc.publisher.(*fakePublisher)
+	publisher.events = nil
+
+	// Act
+	err := svc.BulkAddTag(context.Background(), ids, "crm", "alice")
+
+	// Assert
+	if err != nil || repo.batchUpdates != 1 || len(publisher.events) != 0 {
+		t.Errorf("repeat = %v with %d batches and %d events, want a no-op", err, repo.batchUpdates, len(publisher.events))
+	}
+	if got := versionsOf(repo, ids); !slices.Equal(got, []int{1, 1, 2, 2}) {
+		t.Errorf("versions = %v, want them unchanged by the repeat", got)
+	}
+}
+
+func TestBulkRemoveTagOnlyVersionsTypesWithTheTag(t *testing.T) {
+	// Arrange
+	repo, ids := taggedObjectTypes()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	err := svc.BulkRemoveTag(context.Background(), ids, "crm", "alice")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("BulkRemoveTag: %v", err)
+	}
+	if got := versionsOf(repo, ids); !slices.Equal(got, []int{2, 2, 1, 1}) {
+		t.Errorf("versions = %v, want only the two tagged types bumped", got)
+	}
+	for _, id := range ids {
+		if repo.objectTypes[id].HasTag("crm") {
+			t.Errorf("%s still tagged crm", id)
+		}
+	}
+}
+
+func TestBulkAddTagWithUnknownIDChangesNothing(t *testing.T) {
+	// Arrange
+	repo, ids := taggedObjectTypes()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	err := svc.BulkAddTag(context.Background(), append(ids, uuid.New()), "crm", "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNotFound) {
+		t.Errorf("BulkAddTag = %v, want ErrObjectTypeNotFound", err)
+	}
+	if got := versionsOf(repo, ids); !slices.Equal(got, []int{1, 1, 1, 1}) {
+		t.Errorf("versions = %v, want no type changed", got)
+	}
+}
+
+// racingBatchRepo bumps the stored version of the first object type right
+// after each GetByIDs, as a concurrent writer would
+type racingBatchRepo struct {
+	*fakeObjectTypeRepo
+}
+
+func (r *racingBatchRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error) {
+	found, err := r.fakeObjectTypeRepo.GetByIDs(ctx, ids)
+	if err == nil && len(found) > 0 {
+		raced := found[0].Copy()
+		raced.Version++
+		_ = r.fakeObjectTypeRepo.Update(ctx, raced, "")
+	}
+	return found, err
+}
+
+func TestBulkAddTagRacingAnUpdateChangesNothing(t *testing.T) {
+	// Arrange
+	repo, ids := taggedObjectTypes()
+	svc := newTestObjectTypeService(&racingBatchRepo{repo})
+
+	// Act
+	err := svc.BulkAddTag(context.Background(), ids, "vip", "alice")
+
+	// Assert
+	if !errors.Is(err, ErrConcurrentUpdate) {
+		t.Errorf("BulkAddTag = %v, want ErrConcurrentUpdate", err)
+	}
+	for _, id := range ids {
+		if repo.objectTypes[id].HasTag("vip") {
+			t.Errorf("%s tagged vip, want the whole batch rejected", id)
+		}
+	}
+}
+
+func TestBulkTagRejectsInvalidRequests(t *testing.T) {
+	repo, ids := taggedObjectTypes()
+	svc := newTestObjectTypeService(repo)
+	tooMany := make([]uuid.UUID, MaxBatchSize+1)
+
+	cases := []struct {
+		name string
+		ids  []uuid.UUID
+		tag  string
+	}{
+		{"empty tag", ids, ""},
+		{"no IDs", nil, "crm"},
+		{"too many IDs", tooMany, "crm"},
+	}
+
+	for _, tc := range cases {
+		// Act
+		err := svc.BulkAddTag(context.Background(), tc.ids, tc.tag, "alice")
+
+		// Assert
+		if !errors.Is(err, repository.ErrInvalidInput) {
+			t.Errorf("%s: BulkAddTag = %v, want ErrInvalidInput", tc.name, err)
+		}
+	}
+}
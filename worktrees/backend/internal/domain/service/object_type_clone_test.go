@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// cloneSource returns a repository holding a Customer object type with
+// nested category, tags, metadata and property validators
+func cloneSource() (*fakeObjectTypeRepo, *entity.ObjectType) {
+	category, description := "sales", "A customer"
+	source := &entity.ObjectType{
+		ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 7,
+		Category: &category, Description: &description, Tags: []string{"crm"},
+		Metadata: map[string]interface{}{"owner": map[string]interface{}{"team": "sales"}},
+		Properties: []entity.Property{{
+			ID: uuid.New(), Name: "status", DisplayName: "Status", DataType: entity.DataTypeString,
+			Validators: []entity.Validator{{Type: entity.ValidatorEnum, Value: []interface{}{"active", "closed"}}},
+		}},
+	}
+	return newFakeObjectTypeRepo(source), source
+}
+
+func TestCloneCopiesDefinitionAtVersionOne(t *testing.T) {
+	// Arrange
+	repo, source := cloneSource()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	clone, err := svc.Clone(context.Background(), source.ID, "Prospect", "Prospect", "bob")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	if clone.ID == source.ID || clone.Version != 1 || clone.CreatedBy != "bob" || clone.Name != "Prospect" {
+		t.Errorf("clone = %s v%d by %s named %s, want a new Prospect at version 1 by bob", clone.ID, clone.Version, clone.CreatedBy, clone.Name)
+	}
+	if *clone.Category != "sales" || clone.Tags[0] != "crm" || len(clone.Properties) != 1 || clone.Properties[0].Name != "status" {
+		t.Errorf("clone = %+v, want the source's category, tags and properties", clone)
+	}
+	if clone.Properties[0].ID == source.Properties[0].ID {
+		t.Error("clone property kept the source property ID")
+	}
+}
+
+func TestCloneIsIndependentOfSource(t *testing.T) {
+	// Arrange
+	repo, source := cloneSource()
+	svc := newTestObjectTypeService(repo)
+	clone, _ := svc.Clone(context.Background(), source.ID, "Prospect", "", "bob")
+
+	// Act
+	*source.Category = "support"
+	*source.Description = "Changed"
+	source.Tags[0] = "erp"
+	source.Metadata["owner"].(map[string]interface{})["team"] = "support"
+	source.Properties[0].DisplayName = "Changed"
+	source.Properties[0].Validators[0].Value.([]interface{})[0] = "pending"
+
+	// Assert
+	stored, _ := repo.GetByID(context.Background(), clone.ID)
+	for _, cloned := range []*entity.ObjectType{clone, stored} {
+		if *cloned.Category != "sales" || *cloned.Description != "A customer" || cloned.Tags[0] != "crm" {
+			t.Errorf("clone category %q, description %q, tags %v changed with the source", *cloned.Category, *cloned.Description, cloned.Tags)
+		}
+		if team := cloned.Metadata["owner"].(map[string]interface{})["team"]; team != "sales" {
+			t.Errorf("clone metadata team = %v, want sales", team)
+		}
+		property := cloned.Properties[0]
+		if property.DisplayName != "Status" || property.Validators[0].Value.([]interface{})[0] != "active" {
+			t.Errorf("clone property = %+v, changed with the source", property)
+		}
+	}
+}
+
+func TestCloneRejectsTakenName(t *testing.T) {
+	// Arrange
+	repo, source := cloneSource()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, err := svc.Clone(context.Background(), source.ID, "Customer", "", "bob")
+
+	// Assert
+	if err != entity.ErrObjectTypeNameExists {
+		t.Errorf("Clone = %v, want ErrObjectTypeNameExists", err)
+	}
+}
+
+func TestClonePublishesCreatedEvent(t *testing.T) {
+	// Arrange
+	repo, source := cloneSource()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	clone, _ := svc.Clone(context.Background(), source.ID, "Prospect", "", "bob")
+
+	// Assert
+	events := svc.publisher.(*fakePublisher).events
+	if len(events) != 1 || events[0].Type != messaging.EventObjectTypeCreated || events[0].EntityID != clone.ID.String() {
+		t.Errorf("events = %+v, want one created event for the clone", events)
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrInvalidProposal is returned when a proposed definition fails validation
+var ErrInvalidProposal = fmt.Errorf("%w: invalid proposed definition", repository.ErrInvalidInput)
+
+// CompatibilityReport classifies the changes between the current definition of
+// an object type and a proposed one
+type CompatibilityReport struct {
+	ObjectTypeID uuid.UUID             `json:"objectTypeId"`
+	Version      int                   `json:"version"`
+	Compatible   bool                  `json:"compatible"`
+	Changes      []CompatibilityChange `json:"changes"`
+}
+
+// CompatibilityChange is a field change with its compatibility verdict.
+// Incompatible changes may break existing records or their readers.
+type CompatibilityChange struct {
+	repository.FieldChange
+	Compatible bool   `json:"compatible"`
+	Reason     string `json:"reason"`
+}
+
+// CheckCompatibility diffs proposed against the current definition of the
+// object type without saving anything. The report is compatible only when
+// every change is.
+func (s *ObjectTypeService) CheckCompatibility(ctx context.Context, id uuid.UUID, proposed *entity.ObjectType) (*CompatibilityReport, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.CheckCompatibility",
+		trace.WithAttributes(attribute.String("object_type.id", id.String())))
+	defer span.End()
+
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	proposed.ID = id
+	if err := proposed.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProposal, err)
+	}
+
+	report := compatibilityReport(current, proposed)
+	span.SetAttributes(attribute.Bool("compatibility.compatible", report.Compatible))
+	return report, nil
+}
+
+// compatibilityReport classifies every change from current to proposed
+func compatibilityReport(current, proposed *entity.ObjectType) *CompatibilityReport {
+	report := &CompatibilityReport{
+		ObjectTypeID: current.ID,
+		Version:      current.Version,
+		Compatible:   true,
+		Changes:      []CompatibilityChange{},
+	}
+	for _, change := range repository.CompareObjectTypes(current, proposed) {
+		compatible, reason := classifyChange(change)
+		report.Changes = append(report.Changes, CompatibilityChange{
+			FieldChange: change,
+			Compatible:  compatible,
+			Reason:      reason,
+		})
+		if !compatible {
+			report.Compatible = false
+		}
+	}
+	return report
+}
+
+// classifyChange reports whether a change from CompareObjectTypes is
+// backward compatible, and why
+func classifyChange(change repository.FieldChange) (bool, string) {
+	path := strings.SplitN(change.Field, ".", 3)
+	if path[0] != "properties" {
+		switch change.Field {
+		case "name":
+			return false, "clients address the object type by name"
+		case "parentId":
+			return false, "changing the parent changes the inherited properties"
+		}
+		return true, "descriptive change"
+	}
+
+	if len(path) == 2 {
+		switch change.Type {
+		case repository.ChangeTypeRemoved:
+			return false, "existing records and readers may use the removed property"
+		case repository.ChangeTypeAdded:
+			if p, ok := change.NewValue.(entity.Property); ok && p.Required && p.DefaultValue == nil {
+				return false, "existing records have no value for a new required property"
+			}
+			return true, "new optional property"
+		}
+	}
+
+	switch path[2] {
+	case "dataType":
+		oldType, _ := change.OldValue.(entity.DataType)
+		newType, _ := change.NewValue.(entity.DataType)
+		if oldType == entity.DataTypeDate && newType == entity.DataTypeDateTime {
+			return true, "DATE values are valid DATETIME values"
+		}
+		return false, fmt.Sprintf("existing %s values may not be valid %s values", oldType, newType)
+	case "required":
+		if required, _ := change.NewValue.(bool); required {
+			return false, "existing records may have no value for the property"
+		}
+		return true, "property is no longer required"
+	case "unique":
+		if unique, _ := change.NewValue.(bool); unique {
+			return false, "existing values may not be unique"
+		}
+		return true, "property is no longer unique"
+	case "elementType":
+		if newType, _ := change.NewValue.(entity.DataType); newType == "" {
+			return true, "array elements are no longer typed"
+		}
+		return false, "existing elements may not match the new element type"
+	case "referencedObjectTypeId":
+		if newID, _ := change.NewValue.(*uuid.UUID); newID == nil {
+			return true, "references are no longer restricted to one object type"
+		}
+		return false, "existing references may point to another object type"
+	case "validators", "itemValidators":
+		oldValidators, _ := change.OldValue.([]entity.Validator)
+		newValidators, _ := change.NewValue.([]entity.Validator)
+		for _, v := range newValidators {
+			if !validatorRelaxes(oldValidators, v) {
+				return false, fmt.Sprintf("%s validator may reject existing values", v.Type)
+			}
+		}
+		return true, "validators only relaxed"
+	}
+	return true, "descriptive change"
+}
+
+// validatorRelaxes reports whether v accepts every value some validator of
+// the same type in existing accepted
+func validatorRelaxes(existing []entity.Validator, v entity.Validator) bool {
+	for _, old := range existing {
+		if old.Type != v.Type {
+			continue
+		}
+		if reflect.DeepEqual(old.Value, v.Value) {
+			return true
+		}
+
+		switch v.Type {
+		case entity.ValidatorMin, entity.ValidatorMinLength:
+			oldBound, ok1 := old.Value.(float64)
+			newBound, ok2 := v.Value.(float64)
+			if ok1 && ok2 && newBound <= oldBound {
+				return true
+			}
+		case entity.ValidatorMax, entity.ValidatorMaxLength:
+			oldBound, ok1 := old.Value.(float64)
+			newBound, ok2 := v.Value.(float64)
+			if ok1 && ok2 && newBound >= oldBound {
+				return true
+			}
+		case entity.ValidatorEnum:
+			oldValues, ok1 := old.Value.([]interface{})
+			newValues, ok2 := v.Value.([]interface{})
+			if ok1 && ok2 && containsAll(newValues, oldValues) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsAll reports whether values contains every element of subset
+func containsAll(values, subset []interface{}) bool {
+	for _, want := range subset {
+		found := false
+		for _, v := range values {
+			if reflect.DeepEqual(v, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// customerWithEmail returns a Customer object type with an optional email
+// property capped at 255 characters
+func customerWithEmail() *entity.ObjectType {
+	return &entity.ObjectType{
+		ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1,
+		Properties: []entity.Property{{
+			Name: "email", DisplayName: "Email", DataType: entity.DataTypeString,
+			Validators: []entity.Validator{{Type: entity.ValidatorMaxLength, Value: float64(255)}},
+		}},
+	}
+}
+
+// checkCompatibility stores customerWithEmail, lets change edit a copy and
+// checks the copy's compatibility
+func checkCompatibility(t *testing.T, change func(proposed *entity.ObjectType)) *CompatibilityReport {
+	t.Helper()
+	current := customerWithEmail()
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(current))
+	proposed := current.Copy()
+	change(proposed)
+
+	report, err := svc.CheckCompatibility(context.Background(), current.ID, proposed)
+	if err != nil {
+		t.Fatalf("CheckCompatibility: %v", err)
+	}
+	return report
+}
+
+func TestAddedRequiredPropertyIsIncompatible(t *testing.T) {
+	// Act
+	report := checkCompatibility(t, func(proposed *entity.ObjectType) {
+		proposed.Properties = append(proposed.Properties,
+			entity.Property{Name: "taxId", DisplayName: "Tax ID", DataType: entity.DataTypeString, Required: true})
+	})
+
+	// Assert
+	if report.Compatible || len(report.Changes) != 1 || report.Changes[0].Compatible {
+		t.Errorf("report = %+v, want one incompatible change", report)
+	}
+}
+
+func TestAddedOptionalPropertyIsCompatible(t *testing.T) {
+	// Act
+	report := checkCompatibility(t, func(proposed *entity.ObjectType) {
+		proposed.Properties = append(proposed.Properties,
+			entity.Property{Name: "nickname", DisplayName: "Nickname", DataType: entity.DataTypeString})
+	})
+
+	// Assert
+	if !report.Compatible || len(report.Changes) != 1 || !report.Changes[0].Compatible {
+		t.Errorf("report = %+v, want one compatible change", report)
+	}
+}
+
+func TestAddedRequiredPropertyWithDefaultIsCompatible(t *testing.T) {
+	// Act
+	report := checkCompatibility(t, func(proposed *entity.ObjectType) {
+		proposed.Properties = append(proposed.Properties,
+			entity.Property{Name: "tier", DisplayName: "Tier", DataType: entity.DataTypeString, Required: true, DefaultValue: "basic"})
+	})
+
+	// Assert
+	if !report.Compatible {
+		t.Errorf("report = %+v, want a required property with a default to be compatible", report)
+	}
+}
+
+func TestRemovedPropertyIsIncompatible(t *testing.T) {
+	// Act
+	report := checkCompatibility(t, func(proposed *entity.ObjectType) {
+		proposed.Properties = nil
+	})
+
+	// Assert
+	if report.Compatible {
+		t.Errorf("report = %+v, want removing a property to be incompatible", report)
+	}
+}
+
+func TestTightenedValidatorIsIncompatible(t *testing.T) {
+	// Act
+	report := checkCompatibility(t, func(proposed *entity.ObjectType) {
+		proposed.Properties[0].Validators = []entity.Validator{{Type: entity.ValidatorMaxLength, Value: float64(100)}}
+	})
+
+	// Assert
+	if report.Compatible {
+		t.Errorf("report = %+v, want a lower maxLength to be incompatible", report)
+	}
+}
+
+func TestRelaxedValidatorIsCompatible(t *testing.T) {
+	// Act
+	report := checkCompatibility(t, func(proposed *entity.ObjectType) {
+		proposed.Properties[0].Validators = []entity.Validator{{Type: entity.ValidatorMaxLength, Value: float64(320)}}
+	})
+
+	// Assert
+	if !report.Compatible || len(report.Changes) != 1 {
+		t.Errorf("report = %+v, want a higher maxLength to be compatible", report)
+	}
+}
+
+func TestDescriptiveChangeIsCompatible(t *testing.T) {
+	// Act
+	report := checkCompatibility(t, func(proposed *entity.ObjectType) {
+		proposed.DisplayName = "Client"
+	})
+
+	// Assert
+	if !report.Compatible || len(report.Changes) != 1 {
+		t.Errorf("report = %+v, want a display name change to be compatible", report)
+	}
+}
+
+func TestCheckCompatibilitySavesNothing(t *testing.T) {
+	// Arrange
+	current := customerWithEmail()
+	repo := newFakeObjectTypeRepo(current)
+	svc := newTestObjectTypeService(repo)
+	proposed := current.Copy()
+	proposed.DisplayName = "Client"
+
+	// Act
+	_, _ = svc.CheckCompatibility(context.Background(), current.ID, proposed)
+
+	// Assert
+	stored, _ := repo.GetByID(context.Background(), current.ID)
+	if stored.DisplayName != "Customer" || stored.Version != 1 || len(repo.versions) != 0 {
+		t.Errorf("stored = %+v, want the definition unchanged", stored)
+	}
+}
+
+func TestCheckCompatibilityRejectsInvalidProposal(t *testing.T) {
+	// Arrange
+	current := customerWithEmail()
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(current))
+	proposed := current.Copy()
+	proposed.Name = ""
+
+	// Act
+	_, err := svc.CheckCompatibility(context.Background(), current.ID, proposed)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidProposal) {
+		t.Errorf("CheckCompatibility = %v, want ErrInvalidProposal", err)
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+func TestGetDefaultsIncludesInheritedDefaults(t *testing.T) {
+	// Arrange
+	party := &entity.ObjectType{ID: uuid.New(), Name: "Party", DisplayName: "Party", Version: 1,
+		Properties: []entity.Property{{Name: "country", DisplayName: "Country", DataType: entity.DataTypeString, DefaultValue: "DE"}}}
+	customer := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1, ParentID: &party.ID,
+		Properties: []entity.Property{{Name: "tier", DisplayName: "Tier", DataType: entity.DataTypeString, DefaultValue: "basic"}}}
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(party, customer))
+
+	// Act
+	defaults, err := svc.GetDefaults(context.Background(), customer.ID)
+
+	// Assert
+	if err != nil || defaults["tier"] != "basic" || defaults["country"] != "DE" {
+		t.Errorf("GetDefaults = %v (err %v), want tier basic and inherited country DE", defaults, err)
+	}
+}
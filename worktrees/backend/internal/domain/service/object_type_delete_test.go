@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// linkedDelete is a Customer object type with one Customer -> Account link
+// type, ready to be deleted
+type linkedDelete struct {
+	svc      *ObjectTypeService
+	repo     *fakeObjectTypeRepo
+	customer *entity.ObjectType
+	linkType *entity.LinkType
+}
+
+// newLinkedDelete stores Customer and Account linked by a link type carrying
+// constraints
+func newLinkedDelete(constraints entity.LinkConstraints) *linkedDelete {
+	customer := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1}
+	account := &entity.ObjectType{ID: uuid.New(), Name: "Account", DisplayName: "Account", Version: 1}
+	linkType := &entity.LinkType{
+		ID: uuid.New(), Name: "customerAccounts", DisplayName: "Customer accounts",
+		SourceObjectTypeID: customer.ID, TargetObjectTypeID: account.ID,
+		Cardinality: entity.CardinalityOneToMany, Constraints: constraints,
+	}
+
+	repo := newFakeObjectTypeRepo(customer, account)
+	repo.links = newFakeLinkTypeRepo(linkType)
+	svc := NewObjectTypeService(repo, repo.links, newFakeCache(), DefaultCacheTTLs(), &fakePublisher{},
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	return &linkedDelete{svc: svc, repo: repo, customer: customer, linkType: linkType}
+}
+
+// delete deletes the Customer object type
+func (d *linkedDelete) delete(force bool) error {
+	return d.svc.DeleteObjectType(context.Background(), d.customer.ID, "alice", force)
+}
+
+func TestDeleteBlockedByPreventDeleteLink(t *testing.T) {
+	// Arrange
+	d := newLinkedDelete(entity.LinkConstraints{PreventDelete: true})
+
+	// Act
+	err := d.delete(false)
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeProtected) {
+		t.Errorf("DeleteObjectType = %v, want ErrObjectTypeProtected", err)
+	}
+	if d.customer.IsDeleted || d.linkType.IsDeleted {
+		t.Error("a protected object type or its link type was deleted")
+	}
+}
+
+func TestForcedDeleteStillBlockedByPreventDeleteLink(t *testing.T) {
+	// Arrange
+	d := newLinkedDelete(entity.LinkConstraints{PreventDelete: true})
+
+	// Act
+	err := d.delete(true)
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeProtected) || d.customer.IsDeleted {
+		t.Errorf("DeleteObjectType(force) = %v, want ErrObjectTypeProtected and nothing deleted", err)
+	}
+}
+
+func TestDeleteCascadesToCascadeDeleteLink(t *testing.T) {
+	// Arrange
+	d := newLinkedDelete(entity.LinkConstraints{CascadeDelete: true})
+
+	// Act
+	err := d.delete(false)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DeleteObjectType = %v, want nil without force", err)
+	}
+	if !d.customer.IsDeleted || !d.linkType.IsDeleted {
+		t.Errorf("object type deleted = %v, link type deleted = %v, want both", d.customer.IsDeleted, d.linkType.IsDeleted)
+	}
+}
+
+func TestDeleteCascadePublishesLinkTypeDeleted(t *testing.T) {
+	// Arrange
+	d := newLinkedDelete(entity.LinkConstraints{CascadeDelete: true})
+
+	// Act
+	_ = d.delete(false)
+
+	// Assert
+	events := d.svc.publisher.(*fakePublisher).events
+	found := false
+	for _, event := range events {
+		if event.EntityID == d.linkType.ID.String() {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("events = %v, want one for the cascaded link type", events)
+	}
+}
+
+func TestDeleteWithUnconstrainedLinkNeedsForce(t *testing.T) {
+	// Arrange
+	d := newLinkedDelete(entity.LinkConstraints{})
+
+	// Act
+	err := d.delete(false)
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeInUse) || d.customer.IsDeleted {
+		t.Errorf("DeleteObjectType = %v, want ErrObjectTypeInUse and nothing deleted", err)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// customerInput describes a Customer object type with an email property
+func customerInput() CreateObjectTypeInput {
+	return CreateObjectTypeInput{
+		Name:        "Customer",
+		DisplayName: "Customer",
+		Properties:  []PropertyInput{{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString}},
+	}
+}
+
+func TestDryRunCreateReturnsObjectTypeUnsaved(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	result, err := svc.DryRunCreate(context.Background(), customerInput(), "alice")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DryRunCreate: %v", err)
+	}
+	if result.Saved || result.ObjectType.Name != "Customer" || len(result.ObjectType.Properties) != 1 {
+		t.Errorf("result = %+v, want the Customer object type marked unsaved", result)
+	}
+	if len(repo.objectTypes) != 0 {
+		t.Errorf("stored object types = %d, want none", len(repo.objectTypes))
+	}
+	if events := svc.publisher.(*fakePublisher).events; len(events) != 0 {
+		t.Errorf("events = %v, want none", events)
+	}
+}
+
+func TestDryRunCreateLeavesCreatedCounter(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+
+	// Act
+	_, _ = svc.DryRunCreate(context.Background(), customerInput(), "alice")
+
+	// Assert
+	if got := counterValue(t, svc.metrics.ObjectTypeCreated); got != 0 {
+		t.Errorf("objecttype_created_total = %v, want 0 after a dry run", got)
+	}
+}
+
+func TestDryRunCreateRunsTheChecksOfCreate(t *testing.T) {
+	taken := customerInput()
+	invalid := customerInput()
+	invalid.Name = "Invalid"
+	invalid.Properties = append(invalid.Properties, invalid.Properties[0])
+
+	cases := []struct {
+		name  string
+		input CreateObjectTypeInput
+	}{
+		{"taken name", taken},
+		{"duplicate property", invalid},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		repo := newFakeObjectTypeRepo()
+		svc := newTestObjectTypeService(repo)
+		_, _ = svc.CreateObjectType(context.Background(), customerInput(), "alice")
+		_, createErr := svc.CreateObjectType(context.Background(), tc.input, "alice")
+
+		// Act
+		_, err := svc.DryRunCreate(context.Background(), tc.input, "alice")
+
+		// Assert
+		if err == nil || createErr == nil || err.Error() != createErr.Error() {
+			t.Errorf("%s: DryRunCreate = %v, want the error of CreateObjectType (%v)", tc.name, err, createErr)
+		}
+	}
+}
+
+func TestDryRunUpdateReturnsChangesUnsaved(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	displayName := "Client"
+
+	// Act
+	result, err := svc.DryRunUpdate(context.Background(), objectType.ID, UpdateObjectTypeInput{DisplayName: &displayName}, "alice")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DryRunUpdate: %v", err)
+	}
+	if result.Saved || result.ObjectType.DisplayName != "Client" || result.ObjectType.Version != 2 {
+		t.Errorf("result = %+v, want version 2 renamed Client, unsaved", result.ObjectType)
+	}
+	if result.Compatibility == nil || len(result.Compatibility.Changes) != 1 {
+		t.Errorf("compatibility = %+v, want the display name change", result.Compatibility)
+	}
+	stored := repo.objectTypes[objectType.ID]
+	if stored.DisplayName != "Customer" || stored.Version != 1 || len(repo.versions) != 0 {
+		t.Errorf("stored = %s at version %d with %d versions, want it unchanged", stored.DisplayName, stored.Version, len(repo.versions))
+	}
+	if events := svc.publisher.(*fakePublisher).events; len(events) != 0 {
+		t.Errorf("events = %v, want none", events)
+	}
+}
+
+func TestDryRunUpdateLeavesCachedObjectType(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	_, _ = svc.GetByID(context.Background(), objectType.ID)
+	displayName := "Client"
+
+	// Act
+	_, _ = svc.DryRunUpdate(context.Background(), objectType.ID, UpdateObjectTypeInput{DisplayName: &displayName}, "alice")
+
+	// Assert
+	cached, err := svc.GetByID(context.Background(), objectType.ID)
+	if err != nil || cached.DisplayName != "Customer" {
+		t.Errorf("GetByID after dry run = %+v, %v, want the cached Customer", cached, err)
+	}
+	if hits := cacheLookups(t, svc, "object_type_by_id", metrics.CacheHit); hits != 1 {
+		t.Errorf("cache hits = %v, want the second read served from cache", hits)
+	}
+}
+
+func TestDryRunUpdateWithStaleVersionConflicts(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	stale := 0
+
+	// Act
+	_, err := svc.DryRunUpdate(context.Background(), objectType.ID, UpdateObjectTypeInput{ExpectedVersion: &stale}, "alice")
+
+	// Assert
+	if !errors.Is(err, ErrConcurrentUpdate) {
+		t.Errorf("DryRunUpdate = %v, want ErrConcurrentUpdate", err)
+	}
+}
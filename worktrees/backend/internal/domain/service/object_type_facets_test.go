@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// createInCategory creates an object type named name in category with tags
+func createInCategory(t *testing.T, svc *ObjectTypeService, name, category string, tags ...string) *entity.ObjectType {
+	t.Helper()
+	objectType, err := svc.CreateObjectType(context.Background(),
+		CreateObjectTypeInput{Name: name, DisplayName: name, Category: &category, Tags: tags}, "alice")
+	if err != nil {
+		t.Fatalf("CreateObjectType(%s): %v", name, err)
+	}
+	return objectType
+}
+
+// categoryCount returns the count listed for category, or 0
+func categoryCount(categories []repository.CategoryCount, category string) int64 {
+	for _, c := range categories {
+		if c.Category == category {
+			return c.Count
+		}
+	}
+	return 0
+}
+
+// tagCount returns the count listed for tag, or 0
+func tagCount(tags []repository.TagCount, tag string) int64 {
+	for _, c := range tags {
+		if c.Tag == tag {
+			return c.Count
+		}
+	}
+	return 0
+}
+
+func TestCategoryCountsUpdateAfterCreate(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+	createInCategory(t, svc, "Customer", "sales")
+	if _, err := svc.ListCategories(context.Background()); err != nil {
+		t.Fatalf("ListCategories: %v", err)
+	}
+
+	// Act
+	createInCategory(t, svc, "Lead", "sales")
+	categories, err := svc.ListCategories(context.Background())
+
+	// Assert
+	if err != nil || categoryCount(categories, "sales") != 2 {
+		t.Errorf("ListCategories = %v (err %v), want sales counted twice", categories, err)
+	}
+}
+
+func TestTagCountsUpdateAfterCreate(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+	createInCategory(t, svc, "Customer", "sales", "crm")
+	if _, err := svc.ListTags(context.Background()); err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+
+	// Act
+	createInCategory(t, svc, "Invoice", "billing", "crm", "finance")
+	tags, err := svc.ListTags(context.Background())
+
+	// Assert
+	if err != nil || tagCount(tags, "crm") != 2 || tagCount(tags, "finance") != 1 {
+		t.Errorf("ListTags = %v (err %v), want crm twice and finance once", tags, err)
+	}
+}
+
+func TestCategoryCountsUpdateAfterRecategorizing(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+	customer := createInCategory(t, svc, "Customer", "sales")
+	_, _ = svc.ListCategories(context.Background())
+	billing := "billing"
+
+	// Act
+	if _, err := svc.UpdateObjectType(context.Background(), customer.ID, UpdateObjectTypeInput{Category: &billing}, "alice"); err != nil {
+		t.Fatalf("UpdateObjectType: %v", err)
+	}
+	categories, _ := svc.ListCategories(context.Background())
+
+	// Assert
+	if categoryCount(categories, "sales") != 0 || categoryCount(categories, "billing") != 1 {
+		t.Errorf("ListCategories = %v, want the type counted under billing only", categories)
+	}
+}
+
+func TestCategoriesAreServedFromCache(t *testing.T) {
+	// Arrange
+	sales := "sales"
+	repo := newFakeObjectTypeRepo(&entity.ObjectType{ID: uuid.New(), Name: "Customer", Category: &sales})
+	svc := newTestObjectTypeService(repo)
+	_, _ = svc.ListCategories(context.Background())
+	_ = repo.Create(context.Background(), &entity.ObjectType{ID: uuid.New(), Name: "Lead", Category: &sales})
+
+	// Act
+	categories, _ := svc.ListCategories(context.Background())
+
+	// Assert
+	if categoryCount(categories, "sales") != 1 {
+		t.Errorf("ListCategories = %v, want the cached count of 1", categories)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// slowObjectTypeRepo counts GetByID calls and holds each one long enough
+// for concurrent callers to miss the cache together
+type slowObjectTypeRepo struct {
+	*fakeObjectTypeRepo
+	calls atomic.Int32
+}
+
+func (r *slowObjectTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	r.calls.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	return r.fakeObjectTypeRepo.GetByID(ctx, id)
+}
+
+// getConcurrently calls GetByID for id from count goroutines released
+// together and returns the errors they got
+func getConcurrently(svc *ObjectTypeService, id uuid.UUID, count int) []error {
+	start := make(chan struct{})
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = svc.GetByID(context.Background(), id)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
+
+func TestGetByIDCollapsesConcurrentColdCacheMisses(t *testing.T) {
+	// Arrange
+	objectType := &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 1}
+	repo := &slowObjectTypeRepo{fakeObjectTypeRepo: newFakeObjectTypeRepo(objectType)}
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	getConcurrently(svc, objectType.ID, 100)
+
+	// Assert
+	if calls := repo.calls.Load(); calls != 1 {
+		t.Errorf("repository GetByID calls = %d, want 1", calls)
+	}
+}
+
+func TestGetByIDGivesEveryConcurrentCallerTheObjectType(t *testing.T) {
+	// Arrange
+	objectType := &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 1}
+	svc := newTestObjectTypeService(&slowObjectTypeRepo{fakeObjectTypeRepo: newFakeObjectTypeRepo(objectType)})
+
+	// Act
+	errs := getConcurrently(svc, objectType.ID, 100)
+
+	// Assert
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("GetByID = %v, want nil for every caller", err)
+		}
+	}
+}
+
+func TestGetByIDRemembersMissingObjectType(t *testing.T) {
+	// Arrange
+	repo := &slowObjectTypeRepo{fakeObjectTypeRepo: newFakeObjectTypeRepo()}
+	svc := newTestObjectTypeService(repo)
+	id := uuid.New()
+	_, _ = svc.GetByID(context.Background(), id)
+
+	// Act
+	_, err := svc.GetByID(context.Background(), id)
+
+	// Assert
+	if calls := repo.calls.Load(); err != entity.ErrObjectTypeNotFound || calls != 1 {
+		t.Errorf("GetByID = %v after %d repository calls, want %v after 1", err, calls, entity.ErrObjectTypeNotFound)
+	}
+}
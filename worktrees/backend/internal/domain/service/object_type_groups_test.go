@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+func TestGetPropertiesGroupedGroupsCreatedProperties(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+	created, err := svc.CreateObjectType(context.Background(), CreateObjectTypeInput{
+		Name: "Customer", DisplayName: "Customer",
+		Properties: []PropertyInput{
+			{Name: "iban", DisplayName: "IBAN", DataType: entity.DataTypeString, Group: "Billing", Order: 2},
+			{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString, Group: "Contact", Order: 1},
+			{Name: "phone", DisplayName: "Phone", DataType: entity.DataTypeString, Group: "Contact", Order: 3},
+		},
+	}, "alice")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Act
+	groups, err := svc.GetPropertiesGrouped(context.Background(), created.ID)
+
+	// Assert
+	if err != nil || len(groups) != 2 {
+		t.Fatalf("GetPropertiesGrouped = %v (err %v), want two groups", groups, err)
+	}
+	contact, billing := groups[0], groups[1]
+	if contact.Name != "Contact" || len(contact.Properties) != 2 ||
+		contact.Properties[0].Name != "email" || contact.Properties[1].Name != "phone" {
+		t.Errorf("first group = %+v, want Contact with email then phone", contact)
+	}
+	if billing.Name != "Billing" || len(billing.Properties) != 1 || billing.Properties[0].Name != "iban" {
+		t.Errorf("second group = %+v, want Billing with iban", billing)
+	}
+}
+
+func TestGetPropertiesGroupedUnknownObjectType(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+
+	// Act
+	_, err := svc.GetPropertiesGrouped(context.Background(), uuid.New())
+
+	// Assert
+	if err != entity.ErrObjectTypeNotFound {
+		t.Errorf("GetPropertiesGrouped = %v, want ErrObjectTypeNotFound", err)
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"go.uber.org/zap"
+)
+
+// IndexChanges describes the storage indexes added and removed by EnsureIndexes
+type IndexChanges struct {
+	ObjectTypeID uuid.UUID                `json:"objectTypeId"`
+	Added        []entity.ObjectTypeIndex `json:"added"`
+	Removed      []entity.ObjectTypeIndex `json:"removed"`
+}
+
+// IsEmpty reports whether no index changed
+func (c *IndexChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// UniquePropertyChanges describes a change to the set of unique properties
+// of an object type
+type UniquePropertyChanges struct {
+	ObjectTypeID uuid.UUID `json:"objectTypeId"`
+	// Unique lists the property names unique after the change
+	Unique  []string `json:"unique"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// GetUniqueProperties returns the own and inherited properties of an object
+// type whose values must be unique, for instance storage to build constraints
+func (s *ObjectTypeService) GetUniqueProperties(ctx context.Context, id uuid.UUID) ([]entity.Property, error) {
+	objectType, err := s.GetByIDResolved(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return objectType.UniqueProperties(), nil
+}
+
+// EnsureIndexes reconciles the storage indexes recorded for an object type
+// with the Indexed and Unique flags of its properties. Only the difference is
+// written: a property whose uniqueness changed has its index replaced, and an
+// unchanged object type results in no writes and no event.
+func (s *ObjectTypeService) EnsureIndexes(ctx context.Context, objectTypeID uuid.UUID, userID string) (*IndexChanges, error) {
+	objectType, err := s.GetByIDResolved(ctx, objectTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.ListIndexes(ctx, objectTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := objectType.DesiredIndexes()
+	changes := diffIndexes(objectTypeID, desired, current)
+	if changes.IsEmpty() {
+		return changes, nil
+	}
+
+	now := time.Now()
+	for i := range changes.Added {
+		changes.Added[i].CreatedAt = now
+		changes.Added[i].CreatedBy = userID
+	}
+
+	removed := make([]string, len(changes.Removed))
+	for i, idx := range changes.Removed {
+		removed[i] = idx.PropertyName
+	}
+
+	if err := s.repo.SyncIndexes(ctx, objectTypeID, changes.Added, removed); err != nil {
+		return nil, fmt.Errorf("failed to sync indexes: %w", err)
+	}
+
+	// Publish event for the downstream materializer
+	event := messaging.Event{
+		ID:            uuid.New().String(),
+		Type:          messaging.EventObjectTypeIndexesChanged,
+		EntityID:      objectTypeID.String(),
+		Actor:         userID,
+		Timestamp:     now,
+		Data:          changes,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	s.logger.Info("Object type indexes changed",
+		zap.String("id", objectTypeID.String()),
+		zap.Int("added", len(changes.Added)),
+		zap.Int("removed", len(changes.Removed)))
+
+	if unique := diffUniqueProperties(objectTypeID, current, desired); unique != nil {
+		event := messaging.Event{
+			ID:            uuid.New().String(),
+			Type:          messaging.EventObjectTypeUniquePropertiesChanged,
+			EntityID:      objectTypeID.String(),
+			Actor:         userID,
+			Timestamp:     now,
+			Data:          unique,
+			CorrelationID: messaging.CorrelationIDFromContext(ctx),
+			TenantID:      repository.TenantFromContext(ctx),
+		}
+
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			s.logger.Error("Failed to publish event", zap.Error(err))
+		}
+	}
+	return changes, nil
+}
+
+// ensureIndexes runs EnsureIndexes after a write, logging rather than failing
+// the operation since the definition itself was saved
+func (s *ObjectTypeService) ensureIndexes(ctx context.Context, objectTypeID uuid.UUID, userID string) {
+	if _, err := s.EnsureIndexes(ctx, objectTypeID, userID); err != nil {
+		s.logger.Error("Failed to ensure indexes",
+			zap.String("id", objectTypeID.String()),
+			zap.Error(err))
+	}
+}
+
+// diffUniqueProperties compares the unique properties of the recorded and
+// desired indexes, returning nil when they are the same
+func diffUniqueProperties(objectTypeID uuid.UUID, current []*entity.ObjectTypeIndex, desired []entity.ObjectTypeIndex) *UniquePropertyChanges {
+	wasUnique := make(map[string]bool)
+	for _, idx := range current {
+		if idx.Unique {
+			wasUnique[idx.PropertyName] = true
+		}
+	}
+
+	changes := &UniquePropertyChanges{
+		ObjectTypeID: objectTypeID,
+		Unique:       []string{},
+		Added:        []string{},
+		Removed:      []string{},
+	}
+	isUnique := make(map[string]bool)
+	for _, idx := range desired {
+		if !idx.Unique {
+			continue
+		}
+		isUnique[idx.PropertyName] = true
+		changes.Unique = append(changes.Unique, idx.PropertyName)
+		if !wasUnique[idx.PropertyName] {
+			changes.Added = append(changes.Added, idx.PropertyName)
+		}
+	}
+	for _, idx := range current {
+		if idx.Unique && !isUnique[idx.PropertyName] {
+			changes.Removed = append(changes.Removed, idx.PropertyName)
+		}
+	}
+
+	if len(changes.Added) == 0 && len(changes.Removed) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// diffIndexes compares the desired indexes with those already recorded
+func diffIndexes(objectTypeID uuid.UUID, desired []entity.ObjectTypeIndex, current []*entity.ObjectTypeIndex) *IndexChanges {
+	changes := &IndexChanges{
+		ObjectTypeID: objectTypeID,
+		Added:        []entity.ObjectTypeIndex{},
+		Removed:      []entity.ObjectTypeIndex{},
+	}
+
+	existing := make(map[string]*entity.ObjectTypeIndex, len(current))
+	for _, idx := range current {
+		existing[idx.PropertyName] = idx
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	for _, idx := range desired {
+		wanted[idx.PropertyName] = true
+
+		recorded, ok := existing[idx.PropertyName]
+		if ok && recorded.Unique == idx.Unique && recorded.Name == idx.Name {
+			continue
+		}
+		if ok {
+			changes.Removed = append(changes.Removed, *recorded)
+		}
+		changes.Added = append(changes.Added, idx)
+	}
+
+	for _, idx := range current {
+		if !wanted[idx.PropertyName] {
+			changes.Removed = append(changes.Removed, *idx)
+		}
+	}
+
+	return changes
+}
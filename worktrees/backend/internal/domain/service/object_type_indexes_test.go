@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// indexSync is one SyncIndexes write
+type indexSync struct {
+	added   []string
+	removed []string
+}
+
+// syncRecordingRepo records the index writes EnsureIndexes makes
+type syncRecordingRepo struct {
+	*fakeObjectTypeRepo
+	syncs []indexSync
+}
+
+func (r *syncRecordingRepo) SyncIndexes(ctx context.Context, objectTypeID uuid.UUID, added []entity.ObjectTypeIndex, removed []string) error {
+	write := indexSync{removed: removed}
+	for _, idx := range added {
+		write.added = append(write.added, idx.PropertyName)
+	}
+	r.syncs = append(r.syncs, write)
+	return r.fakeObjectTypeRepo.SyncIndexes(ctx, objectTypeID, added, removed)
+}
+
+// indexedObjectType returns an object type whose email and code properties
+// are indexed and whose name property is not
+func indexedObjectType() *entity.ObjectType {
+	return &entity.ObjectType{
+		ID:          uuid.New(),
+		Name:        "Customer",
+		DisplayName: "Customer",
+		Version:     1,
+		Properties: []entity.Property{
+			{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString, Indexed: true},
+			{Name: "code", DisplayName: "Code", DataType: entity.DataTypeString, Indexed: true},
+			{Name: "name", DisplayName: "Name", DataType: entity.DataTypeString},
+		},
+	}
+}
+
+// reindex records the indexes of objectType, applies change to the stored
+// definition and runs EnsureIndexes again, returning the repository and the
+// publisher of the second run
+func reindex(t *testing.T, change func(*entity.ObjectType)) (*syncRecordingRepo, *fakePublisher) {
+	t.Helper()
+	objectType := indexedObjectType()
+	repo := &syncRecordingRepo{fakeObjectTypeRepo: newFakeObjectTypeRepo(objectType)}
+	if _, err := newTestObjectTypeService(repo).EnsureIndexes(context.Background(), objectType.ID, "alice"); err != nil {
+		t.Fatalf("EnsureIndexes: %v", err)
+	}
+	repo.syncs = nil
+
+	change(repo.objectTypes[objectType.ID])
+	svc := newTestObjectTypeService(repo)
+	if _, err := svc.EnsureIndexes(context.Background(), objectType.ID, "alice"); err != nil {
+		t.Fatalf("EnsureIndexes: %v", err)
+	}
+	return repo, svc.publisher.(*fakePublisher)
+}
+
+func TestEnsureIndexesWritesNothingForUnchangedFlags(t *testing.T) {
+	// Act
+	repo, _ := reindex(t, func(*entity.ObjectType) {})
+
+	// Assert
+	if len(repo.syncs) != 0 {
+		t.Errorf("index writes = %v, want none", repo.syncs)
+	}
+}
+
+func TestEnsureIndexesPublishesNothingForUnchangedFlags(t *testing.T) {
+	// Act
+	_, publisher := reindex(t, func(*entity.ObjectType) {})
+
+	// Assert
+	if len(publisher.events) != 0 {
+		t.Errorf("events = %v, want none", publisher.events)
+	}
+}
+
+func TestEnsureIndexesAddsOnlyNewlyIndexedProperty(t *testing.T) {
+	// Act
+	repo, _ := reindex(t, func(ot *entity.ObjectType) { ot.Properties[2].Indexed = true })
+
+	// Assert
+	if len(repo.syncs) != 1 || !slices.Equal(repo.syncs[0].added, []string{"name"}) || len(repo.syncs[0].removed) != 0 {
+		t.Errorf("index writes = %v, want name added", repo.syncs)
+	}
+}
+
+func TestEnsureIndexesRemovesOnlyUnflaggedProperty(t *testing.T) {
+	// Act
+	repo, _ := reindex(t, func(ot *entity.ObjectType) { ot.Properties[1].Indexed = false })
+
+	// Assert
+	if len(repo.syncs) != 1 || !slices.Equal(repo.syncs[0].removed, []string{"code"}) || len(repo.syncs[0].added) != 0 {
+		t.Errorf("index writes = %v, want code removed", repo.syncs)
+	}
+}
+
+func TestEnsureIndexesReplacesIndexWhoseUniquenessChanged(t *testing.T) {
+	// Act
+	repo, _ := reindex(t, func(ot *entity.ObjectType) { ot.Properties[0].Unique = true })
+
+	// Assert
+	if len(repo.syncs) != 1 || !slices.Equal(repo.syncs[0].removed, []string{"email"}) || !slices.Equal(repo.syncs[0].added, []string{"email"}) {
+		t.Errorf("index writes = %v, want email replaced", repo.syncs)
+	}
+}
+
+func TestEnsureIndexesPublishesIndexChange(t *testing.T) {
+	// Act
+	_, publisher := reindex(t, func(ot *entity.ObjectType) { ot.Properties[2].Indexed = true })
+
+	// Assert
+	if len(publisher.events) != 1 || publisher.events[0].Type != messaging.EventObjectTypeIndexesChanged {
+		t.Errorf("events = %v, want one %s", publisher.events, messaging.EventObjectTypeIndexesChanged)
+	}
+}
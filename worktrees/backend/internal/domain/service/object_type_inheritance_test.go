@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// inheriting returns an object type named name with parent, if any, and
+// properties
+func inheriting(name string, parent *entity.ObjectType, properties ...entity.Property) *entity.ObjectType {
+	ot := &entity.ObjectType{ID: uuid.New(), Name: name, DisplayName: name, Version: 1, Properties: properties}
+	if parent != nil {
+		ot.ParentID = &parent.ID
+	}
+	return ot
+}
+
+// property returns a property named name of dataType, displayed as displayName
+func property(name, displayName string, dataType entity.DataType) entity.Property {
+	return entity.Property{Name: name, DisplayName: displayName, DataType: dataType}
+}
+
+func TestSettingAParentThatInheritsFromTheChildIsACycle(t *testing.T) {
+	// Arrange
+	party := inheriting("Party", nil)
+	customer := inheriting("Customer", party)
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(party, customer))
+
+	// Act
+	_, err := svc.UpdateObjectType(context.Background(), party.ID, UpdateObjectTypeInput{ParentID: &customer.ID}, "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrInheritanceCycle) {
+		t.Errorf("UpdateObjectType = %v, want ErrInheritanceCycle", err)
+	}
+}
+
+func TestParentChainWithACycleIsRejected(t *testing.T) {
+	// Arrange
+	left := inheriting("Left", nil)
+	right := inheriting("Right", left)
+	left.ParentID = &right.ID
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(left, right))
+
+	// Act
+	_, err := svc.CreateObjectType(context.Background(), CreateObjectTypeInput{
+		Name: "Customer", DisplayName: "Customer", ParentID: &left.ID,
+	}, "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrInheritanceCycle) {
+		t.Errorf("CreateObjectType = %v, want ErrInheritanceCycle", err)
+	}
+}
+
+func TestOverridingAnInheritedPropertyWithAnotherDataTypeIsRejected(t *testing.T) {
+	// Arrange
+	root := inheriting("Root", nil, property("code", "Code", entity.DataTypeString))
+	middle := inheriting("Middle", root)
+	cases := []struct {
+		name   string
+		parent *entity.ObjectType
+	}{
+		{"parent", root},
+		{"grandparent", middle},
+	}
+
+	for _, tc := range cases {
+		svc := newTestObjectTypeService(newFakeObjectTypeRepo(root, middle))
+
+		// Act
+		_, err := svc.CreateObjectType(context.Background(), CreateObjectTypeInput{
+			Name: "Leaf", DisplayName: "Leaf", ParentID: &tc.parent.ID,
+			Properties: []PropertyInput{{Name: "code", DisplayName: "Code", DataType: entity.DataTypeNumber}},
+		}, "alice")
+
+		// Assert
+		if !errors.Is(err, entity.ErrInheritanceConflict) || !errors.Is(err, entity.ErrValidationFailed) {
+			t.Errorf("%s: CreateObjectType = %v, want ErrInheritanceConflict as a validation failure", tc.name, err)
+		}
+	}
+}
+
+func TestResolvedPropertiesMergeTheChainWithTheChildWinning(t *testing.T) {
+	// Arrange
+	root := inheriting("Root", nil,
+		property("id", "ID", entity.DataTypeString),
+		property("name", "Root name", entity.DataTypeString))
+	middle := inheriting("Middle", root, property("name", "Middle name", entity.DataTypeString))
+	leaf := inheriting("Leaf", middle,
+		property("name", "Leaf name", entity.DataTypeString),
+		property("extra", "Extra", entity.DataTypeNumber))
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(root, middle, leaf))
+
+	// Act
+	resolved, err := svc.GetByIDResolved(context.Background(), leaf.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetByIDResolved: %v", err)
+	}
+	want := []entity.Property{
+		property("id", "ID", entity.DataTypeString),
+		property("name", "Leaf name", entity.DataTypeString),
+		property("extra", "Extra", entity.DataTypeNumber),
+	}
+	if len(resolved.ResolvedProperties) != len(want) {
+		t.Fatalf("resolved = %+v, want %+v", resolved.ResolvedProperties, want)
+	}
+	for i, prop := range resolved.ResolvedProperties {
+		if prop.Name != want[i].Name || prop.DisplayName != want[i].DisplayName || prop.DataType != want[i].DataType {
+			t.Errorf("resolved[%d] = %s %q %s, want %s %q %s",
+				i, prop.Name, prop.DisplayName, prop.DataType, want[i].Name, want[i].DisplayName, want[i].DataType)
+		}
+	}
+	if len(resolved.Properties) != 2 {
+		t.Errorf("own properties = %+v, want Leaf's two", resolved.Properties)
+	}
+}
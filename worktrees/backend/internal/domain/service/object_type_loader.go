@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+const (
+	// defaultLoaderWait is how long a batch collects keys before it is fetched
+	defaultLoaderWait = 2 * time.Millisecond
+	// defaultLoaderMaxBatch caps the number of IDs fetched in one query
+	defaultLoaderMaxBatch = 100
+)
+
+// ObjectTypeFetcher loads several object types at once
+type ObjectTypeFetcher func(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error)
+
+// ObjectTypeLoader batches and memoizes object type lookups by ID for the
+// lifetime of a single request. Loads issued within a short window are
+// combined into one fetch, so resolving the source and target of N link
+// types costs a single query instead of 2N.
+type ObjectTypeLoader struct {
+	fetch    ObjectTypeFetcher
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[uuid.UUID]*objectTypeResult
+	batch   *objectTypeBatch
+}
+
+// objectTypeResult is the memoized outcome of loading one ID
+type objectTypeResult struct {
+	objectType *entity.ObjectType
+	err        error
+	done       chan struct{}
+}
+
+// objectTypeBatch collects the IDs of a pending fetch
+type objectTypeBatch struct {
+	ids     []uuid.UUID
+	results []*objectTypeResult
+	closed  bool
+}
+
+// NewObjectTypeLoader creates a request-scoped loader backed by the service
+func NewObjectTypeLoader(s *ObjectTypeService) *ObjectTypeLoader {
+	return NewObjectTypeLoaderWithFetcher(s.GetByIDs)
+}
+
+// NewObjectTypeLoaderWithFetcher creates a request-scoped loader using fetch
+func NewObjectTypeLoaderWithFetcher(fetch ObjectTypeFetcher) *ObjectTypeLoader {
+	return &ObjectTypeLoader{
+		fetch:    fetch,
+		wait:     defaultLoaderWait,
+		maxBatch: defaultLoaderMaxBatch,
+		results:  make(map[uuid.UUID]*objectTypeResult),
+	}
+}
+
+// Load returns the object type with the given ID, or
+// entity.ErrObjectTypeNotFound if it does not exist
+func (l *ObjectTypeLoader) Load(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	result := l.enqueue(ctx, id)
+
+	select {
+	case <-result.done:
+		return result.objectType, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// LoadMany returns the object types with the given IDs in the same order.
+// The first error encountered is returned alongside the partial results.
+func (l *ObjectTypeLoader) LoadMany(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error) {
+	pending := make([]*objectTypeResult, len(ids))
+	for i, id := range ids {
+		pending[i] = l.enqueue(ctx, id)
+	}
+
+	objectTypes := make([]*entity.ObjectType, len(ids))
+	var firstErr error
+	for i, result := range pending {
+		select {
+		case <-result.done:
+		case <-ctx.Done():
+			return objectTypes, ctx.Err()
+		}
+
+		objectTypes[i] = result.objectType
+		if result.err != nil && firstErr == nil {
+			firstErr = result.err
+		}
+	}
+
+	return objectTypes, firstErr
+}
+
+// enqueue returns the memoized result for id, adding it to the pending batch
+// on first use
+func (l *ObjectTypeLoader) enqueue(ctx context.Context, id uuid.UUID) *objectTypeResult {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if result, ok := l.results[id]; ok {
+		return result
+	}
+
+	result := &objectTypeResult{done: make(chan struct{})}
+	l.results[id] = result
+
+	if l.batch == nil {
+		l.batch = &objectTypeBatch{}
+		batch := l.batch
+		time.AfterFunc(l.wait, func() { l.dispatch(ctx, batch) })
+	}
+
+	l.batch.ids = append(l.batch.ids, id)
+	l.batch.results = append(l.batch.results, result)
+
+	if len(l.batch.ids) >= l.maxBatch {
+		batch := l.batch
+		l.batch = nil
+		go l.dispatch(ctx, batch)
+	}
+
+	return result
+}
+
+// dispatch fetches a batch once and resolves all of its results
+func (l *ObjectTypeLoader) dispatch(ctx context.Context, batch *objectTypeBatch) {
+	l.mu.Lock()
+	if batch.closed {
+		l.mu.Unlock()
+		return
+	}
+	batch.closed = true
+	if l.batch == batch {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	objectTypes, err := l.fetch(context.WithoutCancel(ctx), batch.ids)
+
+	byID := make(map[uuid.UUID]*entity.ObjectType, len(objectTypes))
+	for _, ot := range objectTypes {
+		byID[ot.ID] = ot
+	}
+
+	for i, result := range batch.results {
+		switch {
+		case err != nil:
+			result.err = err
+		case byID[batch.ids[i]] == nil:
+			result.err = entity.ErrObjectTypeNotFound
+		default:
+			result.objectType = byID[batch.ids[i]]
+		}
+		close(result.done)
+	}
+}
+
+// objectTypeLoaderKey is the context key for the request-scoped loader
+type objectTypeLoaderKey struct{}
+
+// WithObjectTypeLoader returns a context carrying the loader
+func WithObjectTypeLoader(ctx context.Context, loader *ObjectTypeLoader) context.Context {
+	return context.WithValue(ctx, objectTypeLoaderKey{}, loader)
+}
+
+// ObjectTypeLoaderFromContext returns the loader installed on ctx, if any
+func ObjectTypeLoaderFromContext(ctx context.Context) (*ObjectTypeLoader, bool) {
+	loader, ok := ctx.Value(objectTypeLoaderKey{}).(*ObjectTypeLoader)
+	return loader, ok
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// seedObjectTypes returns a repository holding count object types and their IDs
+func seedObjectTypes(count int) (*fakeObjectTypeRepo, []uuid.UUID) {
+	repo := newFakeObjectTypeRepo()
+	ids := make([]uuid.UUID, count)
+	for i := range ids {
+		ids[i] = uuid.New()
+		repo.objectTypes[ids[i]] = &entity.ObjectType{ID: ids[i], Name: "Type" + ids[i].String()[:8]}
+	}
+	return repo, ids
+}
+
+// loadConcurrently loads every ID from its own goroutine, as resolvers of a
+// page of links do, and returns the loaded object types by ID
+func loadConcurrently(loader *ObjectTypeLoader, ids []uuid.UUID) map[uuid.UUID]*entity.ObjectType {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	loaded := make(map[uuid.UUID]*entity.ObjectType)
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id uuid.UUID) {
+			defer wg.Done()
+			objectType, _ := loader.Load(context.Background(), id)
+			mu.Lock()
+			loaded[id] = objectType
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	return loaded
+}
+
+func TestLoaderResolvesPageOfLinksInOneRoundTrip(t *testing.T) {
+	// Arrange
+	repo, ids := seedObjectTypes(40) // source and target of 20 link types
+	loader := NewObjectTypeLoader(newTestObjectTypeService(repo))
+
+	// Act
+	loadConcurrently(loader, ids)
+
+	// Assert
+	if calls := repo.getByIDsCalls.Load(); calls != 1 {
+		t.Errorf("GetByIDs round trips = %d, want 1", calls)
+	}
+}
+
+func TestLoaderReturnsEachRequestedObjectType(t *testing.T) {
+	// Arrange
+	repo, ids := seedObjectTypes(10)
+	loader := NewObjectTypeLoader(newTestObjectTypeService(repo))
+
+	// Act
+	loaded := loadConcurrently(loader, ids)
+
+	// Assert
+	for _, id := range ids {
+		if loaded[id] == nil || loaded[id].ID != id {
+			t.Errorf("Load(%s) = %v", id, loaded[id])
+		}
+	}
+}
+
+func TestLoaderMemoizesLoadedIDs(t *testing.T) {
+	// Arrange
+	repo, ids := seedObjectTypes(1)
+	loader := NewObjectTypeLoader(newTestObjectTypeService(repo))
+	_, _ = loader.Load(context.Background(), ids[0])
+
+	// Act
+	_, _ = loader.Load(context.Background(), ids[0])
+
+	// Assert
+	if calls := repo.getByIDsCalls.Load(); calls != 1 {
+		t.Errorf("GetByIDs round trips = %d, want 1", calls)
+	}
+}
+
+func TestLoaderReportsMissingID(t *testing.T) {
+	// Arrange
+	repo, _ := seedObjectTypes(1)
+	loader := NewObjectTypeLoader(newTestObjectTypeService(repo))
+
+	// Act
+	_, err := loader.Load(context.Background(), uuid.New())
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNotFound) {
+		t.Errorf("err = %v, want %v", err, entity.ErrObjectTypeNotFound)
+	}
+}
+
+func TestLoaderSplitsBatchesAtMaxBatch(t *testing.T) {
+	// Arrange
+	repo, ids := seedObjectTypes(defaultLoaderMaxBatch + 1)
+	loader := NewObjectTypeLoader(newTestObjectTypeService(repo))
+
+	// Act
+	_, _ = loader.LoadMany(context.Background(), ids)
+
+	// Assert
+	if calls := repo.getByIDsCalls.Load(); calls != 2 {
+		t.Errorf("GetByIDs round trips = %d, want 2", calls)
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// counterValue returns the current value of a counter
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	t.Helper()
+	var metric dto.Metric
+	if err := counter.Write(&metric); err != nil {
+		t.Fatalf("read counter: %v", err)
+	}
+	return metric.GetCounter().GetValue()
+}
+
+func TestCreateObjectTypeIncrementsCreatedCounter(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+
+	// Act
+	_, err := svc.CreateObjectType(context.Background(), CreateObjectTypeInput{Name: "Customer", DisplayName: "Customer"}, "alice")
+
+	// Assert
+	if got := counterValue(t, svc.metrics.ObjectTypeCreated); err != nil || got != 1 {
+		t.Errorf("objecttype_created_total = %v (err %v), want 1", got, err)
+	}
+}
+
+func TestFailedCreateObjectTypeLeavesCreatedCounter(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+	input := CreateObjectTypeInput{Name: "Customer", DisplayName: "Customer"}
+	_, _ = svc.CreateObjectType(context.Background(), input, "alice")
+
+	// Act
+	_, _ = svc.CreateObjectType(context.Background(), input, "alice")
+
+	// Assert
+	if got := counterValue(t, svc.metrics.ObjectTypeCreated); got != 1 {
+		t.Errorf("objecttype_created_total = %v after a duplicate create, want 1", got)
+	}
+}
+
+// cacheLookups returns the cache_lookups_total count of lookup with result
+func cacheLookups(t *testing.T, svc *ObjectTypeService, lookup, result string) float64 {
+	t.Helper()
+	return counterValue(t, svc.metrics.CacheLookups.WithLabelValues(lookup, result))
+}
+
+func TestSecondGetByIDCountsACacheHit(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	_, _ = svc.GetByID(context.Background(), objectType.ID)
+
+	// Act
+	_, err := svc.GetByID(context.Background(), objectType.ID)
+
+	// Assert
+	hits := cacheLookups(t, svc, "object_type_by_id", metrics.CacheHit)
+	misses := cacheLookups(t, svc, "object_type_by_id", metrics.CacheMiss)
+	if err != nil || hits != 1 || misses != 1 {
+		t.Errorf("hits = %v, misses = %v (err %v), want one of each", hits, misses, err)
+	}
+}
+
+func TestKnownMissingGetByIDCountsACacheHit(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+	id := uuid.New()
+	_, _ = svc.GetByID(context.Background(), id)
+
+	// Act
+	_, _ = svc.GetByID(context.Background(), id)
+
+	// Assert
+	if hits := cacheLookups(t, svc, "object_type_by_id", metrics.CacheHit); hits != 1 {
+		t.Errorf("hits = %v, want the cached miss counted as a hit", hits)
+	}
+}
+
+func TestRepeatedSearchCountsACacheHit(t *testing.T) {
+	// Arrange
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo())
+	_, _ = svc.Search(context.Background(), "customer", 10, repository.SearchOptions{})
+
+	// Act
+	_, _ = svc.Search(context.Background(), "customer", 10, repository.SearchOptions{})
+
+	// Assert
+	hits := cacheLookups(t, svc, "object_type_search", metrics.CacheHit)
+	misses := cacheLookups(t, svc, "object_type_search", metrics.CacheMiss)
+	if hits != 1 || misses != 1 {
+		t.Errorf("hits = %v, misses = %v, want one of each", hits, misses)
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIVersion is the OpenAPI specification version of generated documents
+const openAPIVersion = "3.0.3"
+
+// ExportOpenAPI renders an OpenAPI 3 document, in YAML, describing CRUD
+// endpoints for instances of an object type. The instance schema is derived
+// from the object type's JSON Schema and each outgoing link type is exposed
+// as a read-only sub-resource.
+func (s *ObjectTypeService) ExportOpenAPI(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	objectType, err := s.GetByIDResolved(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	linkTypes, err := s.linkTypeRepo.GetBySourceObjectType(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link types: %w", err)
+	}
+
+	// Resolve link targets in one query to describe sub-resources by name
+	targetIDs := make([]uuid.UUID, 0, len(linkTypes))
+	for _, lt := range linkTypes {
+		targetIDs = append(targetIDs, lt.TargetObjectTypeID)
+	}
+	targets, err := s.repo.GetByIDs(ctx, targetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link targets: %w", err)
+	}
+	targetNames := make(map[uuid.UUID]string, len(targets))
+	for _, target := range targets {
+		targetNames[target.ID] = target.Name
+	}
+
+	document, err := yaml.Marshal(buildOpenAPIDocument(objectType, linkTypes, targetNames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+	}
+
+	return document, nil
+}
+
+// buildOpenAPIDocument assembles the OpenAPI document for an object type
+func buildOpenAPIDocument(objectType *entity.ObjectType, linkTypes []*entity.LinkType, targetNames map[uuid.UUID]string) map[string]interface{} {
+	name := objectType.Name
+	inputRef := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	instanceRef := map[string]interface{}{"$ref": "#/components/schemas/" + name + "Instance"}
+
+	// OpenAPI 3.0 schemas do not accept the JSON Schema meta keywords
+	inputSchema := buildJSONSchema(objectType)
+	delete(inputSchema, "$schema")
+	delete(inputSchema, "$id")
+	// OpenAPI 3.0 requires a non-empty required list when present
+	if len(inputSchema["required"].([]string)) == 0 {
+		delete(inputSchema, "required")
+	}
+
+	instanceProperties := map[string]interface{}{
+		"id": map[string]interface{}{"type": "string", "format": "uuid", "readOnly": true},
+	}
+	for key, value := range inputSchema["properties"].(map[string]interface{}) {
+		instanceProperties[key] = value
+	}
+	instanceSchema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           instanceProperties,
+		"required":             append([]string{"id"}, requiredProperties(objectType)...),
+		"additionalProperties": false,
+	}
+
+	idParameter := map[string]interface{}{
+		"name":     "id",
+		"in":       "path",
+		"required": true,
+		"schema":   map[string]interface{}{"type": "string", "format": "uuid"},
+	}
+
+	jsonContent := func(schema interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		}
+	}
+	response := func(description string, schema interface{}) map[string]interface{} {
+		r := map[string]interface{}{"description": description}
+		if schema != nil {
+			r["content"] = jsonContent(schema)
+		}
+		return r
+	}
+	notFound := map[string]interface{}{"$ref": "#/components/responses/NotFound"}
+	badRequest := map[string]interface{}{"$ref": "#/components/responses/BadRequest"}
+
+	collectionPath := "/" + name
+	itemPath := collectionPath + "/{id}"
+
+	paths := map[string]interface{}{
+		collectionPath: map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": "create" + name,
+				"summary":     "Create a " + objectType.DisplayName,
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  jsonContent(inputRef),
+				},
+				"responses": map[string]interface{}{
+					"201": response("Created", instanceRef),
+					"400": badRequest,
+				},
+			},
+		},
+		itemPath: map[string]interface{}{
+			"parameters": []interface{}{idParameter},
+			"get": map[string]interface{}{
+				"operationId": "get" + name,
+				"summary":     "Get a " + objectType.DisplayName,
+				"responses": map[string]interface{}{
+					"200": response("OK", instanceRef),
+					"404": notFound,
+				},
+			},
+			"put": map[string]interface{}{
+				"operationId": "update" + name,
+				"summary":     "Update a " + objectType.DisplayName,
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  jsonContent(inputRef),
+				},
+				"responses": map[string]interface{}{
+					"200": response("OK", instanceRef),
+					"400": badRequest,
+					"404": notFound,
+				},
+			},
+			"delete": map[string]interface{}{
+				"operationId": "delete" + name,
+				"summary":     "Delete a " + objectType.DisplayName,
+				"responses": map[string]interface{}{
+					"204": response("Deleted", nil),
+					"404": notFound,
+				},
+			},
+		},
+	}
+
+	linkedInstance := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{"type": "string", "format": "uuid"},
+		},
+		"required": []string{"id"},
+	}
+	for _, lt := range linkTypes {
+		description := fmt.Sprintf("%s linked through %s", targetNames[lt.TargetObjectTypeID], lt.DisplayName)
+		paths[itemPath+"/"+lt.Name] = map[string]interface{}{
+			"parameters": []interface{}{idParameter},
+			"get": map[string]interface{}{
+				"operationId": "list" + name + "_" + lt.Name,
+				"summary":     description,
+				"responses": map[string]interface{}{
+					"200": response("OK", map[string]interface{}{
+						"type":  "array",
+						"items": linkedInstance,
+					}),
+					"404": notFound,
+				},
+			},
+		}
+	}
+
+	errorSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"error":   map[string]interface{}{"type": "string"},
+			"details": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"error"},
+	}
+
+	info := map[string]interface{}{
+		"title":   objectType.DisplayName + " API",
+		"version": fmt.Sprintf("%d", objectType.Version),
+	}
+	if objectType.Description != nil {
+		info["description"] = *objectType.Description
+	}
+
+	return map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info":    info,
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				name:              inputSchema,
+				name + "Instance": instanceSchema,
+				"ApiError":        errorSchema,
+			},
+			"responses": map[string]interface{}{
+				"NotFound":   response("Not found", map[string]interface{}{"$ref": "#/components/schemas/ApiError"}),
+				"BadRequest": response("Invalid request", map[string]interface{}{"$ref": "#/components/schemas/ApiError"}),
+			},
+		},
+	}
+}
+
+// requiredProperties returns the names of the required properties of an object type
+func requiredProperties(objectType *entity.ObjectType) []string {
+	properties := objectType.ResolvedProperties
+	if properties == nil {
+		properties = objectType.Properties
+	}
+
+	var required []string
+	for _, prop := range properties {
+		if prop.Required {
+			required = append(required, prop.Name)
+		}
+	}
+	return required
+}
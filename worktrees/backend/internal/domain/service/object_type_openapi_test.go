@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// loadOpenAPI exports the OpenAPI document of the schema fixture type, with
+// an outgoing link type to an Account, and parses it with an OpenAPI loader
+func loadOpenAPI(t *testing.T) (*openapi3.Loader, *openapi3.T) {
+	t.Helper()
+	customer := schemaFixtureType()
+	account := &entity.ObjectType{ID: uuid.New(), Name: "Account", DisplayName: "Account", Version: 1}
+	links := newFakeLinkTypeRepo(&entity.LinkType{
+		ID:                 uuid.New(),
+		Name:               "accounts",
+		DisplayName:        "Accounts",
+		SourceObjectTypeID: customer.ID,
+		TargetObjectTypeID: account.ID,
+	})
+	svc := NewObjectTypeService(newFakeObjectTypeRepo(customer, account), links, newFakeCache(), DefaultCacheTTLs(),
+		&fakePublisher{}, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+
+	document, err := svc.ExportOpenAPI(context.Background(), customer.ID)
+	if err != nil {
+		t.Fatalf("ExportOpenAPI: %v", err)
+	}
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromData(document)
+	if err != nil {
+		t.Fatalf("load OpenAPI document: %v", err)
+	}
+	return loader, spec
+}
+
+func TestExportOpenAPIIsValidDocument(t *testing.T) {
+	// Arrange
+	loader, spec := loadOpenAPI(t)
+
+	// Act
+	err := spec.Validate(loader.Context)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
+
+func TestExportOpenAPIDescribesInstanceEndpoints(t *testing.T) {
+	// Act
+	_, spec := loadOpenAPI(t)
+
+	// Assert
+	item := spec.Paths.Find("/Customer/{id}")
+	if spec.Paths.Find("/Customer") == nil || item == nil || item.Get == nil || item.Put == nil || item.Delete == nil {
+		t.Errorf("paths = %v, want create, get, update and delete of Customer", spec.Paths.InMatchingOrder())
+	}
+}
+
+func TestExportOpenAPIExposesLinkSubResource(t *testing.T) {
+	// Act
+	_, spec := loadOpenAPI(t)
+
+	// Assert
+	if link := spec.Paths.Find("/Customer/{id}/accounts"); link == nil || link.Get == nil {
+		t.Errorf("paths = %v, want GET /Customer/{id}/accounts", spec.Paths.InMatchingOrder())
+	}
+}
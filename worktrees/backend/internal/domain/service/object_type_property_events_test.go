@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// threePropertyInputs are the properties of the object type updated by
+// updateProperties
+func threePropertyInputs() []PropertyInput {
+	return []PropertyInput{
+		{Name: "name", DisplayName: "Name", DataType: entity.DataTypeString},
+		{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString},
+		{Name: "score", DisplayName: "Score", DataType: entity.DataTypeNumber},
+	}
+}
+
+// updateProperties updates an object type holding threePropertyInputs to
+// properties and returns the events published for the update
+func updateProperties(t *testing.T, properties []PropertyInput) []messaging.Event {
+	t.Helper()
+	objectType := &entity.ObjectType{
+		ID:          uuid.New(),
+		Name:        "Customer",
+		DisplayName: "Customer",
+		Version:     1,
+		Properties:  buildProperties(threePropertyInputs()),
+	}
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(objectType))
+	if _, err := svc.UpdateObjectType(context.Background(), objectType.ID, UpdateObjectTypeInput{Properties: properties}, "alice"); err != nil {
+		t.Fatalf("UpdateObjectType: %v", err)
+	}
+	return svc.publisher.(*fakePublisher).events
+}
+
+// eventsOfType returns the events of type eventType
+func eventsOfType(events []messaging.Event, eventType messaging.EventType) []messaging.Event {
+	var matched []messaging.Event
+	for _, event := range events {
+		if event.Type == eventType {
+			matched = append(matched, event)
+		}
+	}
+	return matched
+}
+
+func TestAddingOnePropertyPublishesOnePropertyAdded(t *testing.T) {
+	// Act
+	events := updateProperties(t, append(threePropertyInputs(), PropertyInput{Name: "phone", DisplayName: "Phone", DataType: entity.DataTypeString}))
+
+	// Assert
+	if added := eventsOfType(events, messaging.EventPropertyAdded); len(added) != 1 {
+		t.Errorf("property added events = %d, want 1", len(added))
+	}
+}
+
+func TestAddingOnePropertyPublishesNoOtherPropertyEvents(t *testing.T) {
+	// Act
+	events := updateProperties(t, append(threePropertyInputs(), PropertyInput{Name: "phone", DisplayName: "Phone", DataType: entity.DataTypeString}))
+
+	// Assert
+	updated := eventsOfType(events, messaging.EventPropertyUpdated)
+	removed := eventsOfType(events, messaging.EventPropertyRemoved)
+	if len(updated)+len(removed) != 0 {
+		t.Errorf("updated and removed events = %v %v, want none", updated, removed)
+	}
+}
+
+func TestPropertyAddedEventCarriesNewDefinition(t *testing.T) {
+	// Act
+	events := updateProperties(t, append(threePropertyInputs(), PropertyInput{Name: "phone", DisplayName: "Phone", DataType: entity.DataTypeString}))
+
+	// Assert
+	data := eventsOfType(events, messaging.EventPropertyAdded)[0].Data.(map[string]interface{})
+	after, ok := data["after"].(entity.Property)
+	if data["property"] != "phone" || !ok || after.DisplayName != "Phone" || data["before"] != nil {
+		t.Errorf("event data = %v, want phone with its definition after", data)
+	}
+}
+
+func TestRemovingOnePropertyPublishesOnePropertyRemoved(t *testing.T) {
+	// Act
+	events := updateProperties(t, threePropertyInputs()[:2])
+
+	// Assert
+	if removed := eventsOfType(events, messaging.EventPropertyRemoved); len(removed) != 1 {
+		t.Errorf("property removed events = %d, want 1", len(removed))
+	}
+}
+
+func TestChangingOnePropertyPublishesOnePropertyUpdated(t *testing.T) {
+	// Arrange
+	properties := threePropertyInputs()
+	properties[1].Required = true
+	properties[1].DisplayName = "E-mail"
+
+	// Act
+	events := updateProperties(t, properties)
+
+	// Assert
+	if updated := eventsOfType(events, messaging.EventPropertyUpdated); len(updated) != 1 {
+		t.Errorf("property updated events = %d, want 1 for email", len(updated))
+	}
+}
+
+func TestUpdateWithoutPropertiesPublishesNoPropertyEvents(t *testing.T) {
+	// Act
+	events := updateProperties(t, nil)
+
+	// Assert
+	if len(events) != 1 || events[0].Type != messaging.EventObjectTypeUpdated {
+		t.Errorf("events = %v, want only %s", events, messaging.EventObjectTypeUpdated)
+	}
+}
+
+func TestDeprecatingAPropertyPublishesPropertyUpdated(t *testing.T) {
+	// Arrange
+	message := "Use contactEmail"
+	properties := threePropertyInputs()
+	properties[1].Deprecated = true
+	properties[1].DeprecationMessage = &message
+
+	// Act
+	events := updateProperties(t, properties)
+
+	// Assert
+	updated := eventsOfType(events, messaging.EventPropertyUpdated)
+	if len(updated) != 1 {
+		t.Fatalf("property updated events = %d, want 1 for email", len(updated))
+	}
+	data := updated[0].Data.(map[string]interface{})
+	after, ok := data["after"].(entity.Property)
+	if data["property"] != "email" || !ok || !after.Deprecated || after.DeprecationMessage == nil || *after.DeprecationMessage != message {
+		t.Errorf("event data = %v, want email deprecated with its message", data)
+	}
+}
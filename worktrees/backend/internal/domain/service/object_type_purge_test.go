@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// deletedObjectType returns a soft-deleted object type named name, deleted at
+// deletedAt
+func deletedObjectType(name string, deletedAt time.Time) *entity.ObjectType {
+	return &entity.ObjectType{ID: uuid.New(), Name: name, DisplayName: name, Version: 1, IsDeleted: true, UpdatedAt: deletedAt}
+}
+
+func TestPurgeObjectTypeRefusesAnObjectTypeThatIsNotDeleted(t *testing.T) {
+	// Arrange
+	live := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1}
+	cases := []struct {
+		name string
+		id   uuid.UUID
+		want error
+	}{
+		{"live", live.ID, entity.ErrObjectTypeNotDeleted},
+		{"unknown", uuid.New(), entity.ErrObjectTypeNotFound},
+	}
+
+	for _, tc := range cases {
+		repo := newFakeObjectTypeRepo(live)
+		publisher := &fakePublisher{}
+		svc := newTestObjectTypeService(repo)
+		svc.publisher = publisher
+		cache := svc.cache.(*fakeCache)
+		key := fmt.Sprintf("object_type:%s", tc.id)
+		_ = cache.Set(context.Background(), key, live, time.Minute)
+
+		// Act
+		err := svc.PurgeObjectType(context.Background(), tc.id, "alice")
+
+		// Assert
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: PurgeObjectType = %v, want %v", tc.name, err, tc.want)
+		}
+		if repo.objectTypes[live.ID] == nil || len(publisher.events) != 0 || !cache.has(key) {
+			t.Errorf("%s: purge left %d events and cached = %v, want nothing touched",
+				tc.name, len(publisher.events), cache.has(key))
+		}
+	}
+}
+
+func TestPurgeObjectTypeRemovesADeletedObjectTypeAndItsDeletedLinks(t *testing.T) {
+	// Arrange
+	customer := deletedObjectType("Customer", time.Now())
+	account := &entity.ObjectType{ID: uuid.New(), Name: "Account", DisplayName: "Account", Version: 1}
+	deletedLink := &entity.LinkType{ID: uuid.New(), Name: "customerAccounts", IsDeleted: true,
+		SourceObjectTypeID: customer.ID, TargetObjectTypeID: account.ID}
+	otherLink := &entity.LinkType{ID: uuid.New(), Name: "accountAccounts", IsDeleted: true,
+		SourceObjectTypeID: account.ID, TargetObjectTypeID: account.ID}
+	repo := newFakeObjectTypeRepo(customer, account)
+	repo.links = newFakeLinkTypeRepo(deletedLink, otherLink)
+	publisher := &fakePublisher{}
+	svc := newTestObjectTypeService(repo)
+	svc.publisher = publisher
+	cache := svc.cache.(*fakeCache)
+	key := fmt.Sprintf("object_type:%s", customer.ID)
+	_ = cache.Set(context.Background(), key, customer, time.Minute)
+
+	// Act
+	err := svc.PurgeObjectType(context.Background(), customer.ID, "alice")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PurgeObjectType: %v", err)
+	}
+	if repo.objectTypes[customer.ID] != nil || repo.objectTypes[account.ID] == nil {
+		t.Errorf("object types = %v, want Customer alone purged", repo.objectTypes)
+	}
+	if repo.links.linkTypes[deletedLink.ID] != nil || repo.links.linkTypes[otherLink.ID] == nil {
+		t.Errorf("link types = %v, want customerAccounts alone purged", repo.links.linkTypes)
+	}
+	if cache.has(key) {
+		t.Errorf("cache still holds %s", key)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != messaging.EventObjectTypePurged ||
+		publisher.events[0].EntityID != customer.ID.String() || publisher.events[0].Actor != "alice" {
+		t.Errorf("events = %+v, want one ObjectTypePurged for Customer by alice", publisher.events)
+	}
+}
+
+func TestListDeletedReturnsDeletedObjectTypesMostRecentFirst(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	oldest := deletedObjectType("Oldest", now.Add(-3*time.Hour))
+	middle := deletedObjectType("Middle", now.Add(-2*time.Hour))
+	newest := deletedObjectType("Newest", now.Add(-time.Hour))
+	zip, city := property("zip", "Zip", entity.DataTypeString), property("city", "City", entity.DataTypeString)
+	zip.Order, city.Order = 1, 0
+	newest.Properties = []entity.Property{zip, city}
+	live := &entity.ObjectType{ID: uuid.New(), Name: "Live", DisplayName: "Live", Version: 1, UpdatedAt: now}
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(oldest, live, newest, middle))
+
+	// Act
+	deleted, err := svc.ListDeleted(context.Background(), 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListDeleted: %v", err)
+	}
+	if len(deleted) != 2 || deleted[0].Name != "Newest" || deleted[1].Name != "Middle" {
+		t.Fatalf("deleted = %+v, want Newest then Middle", deleted)
+	}
+	if props := deleted[0].Properties; props[0].Name != "city" || props[1].Name != "zip" {
+		t.Errorf("properties = %+v, want them in their order", props)
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// newDeletableObjectTypeService returns a service over an empty repository
+// whose object types have no link types
+func newDeletableObjectTypeService() *ObjectTypeService {
+	return NewObjectTypeService(newFakeObjectTypeRepo(), newFakeLinkTypeRepo(), newFakeCache(), DefaultCacheTTLs(),
+		&fakePublisher{}, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+}
+
+// createCustomer creates an object type named Customer
+func createCustomer(svc *ObjectTypeService) (*entity.ObjectType, error) {
+	return svc.CreateObjectType(context.Background(), CreateObjectTypeInput{Name: "Customer", DisplayName: "Customer"}, "alice")
+}
+
+func TestRecreateAfterDeleteSucceeds(t *testing.T) {
+	// Arrange
+	svc := newDeletableObjectTypeService()
+	original, err := createCustomer(svc)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := svc.DeleteObjectType(context.Background(), original.ID, "alice", false); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	// Act
+	recreated, err := createCustomer(svc)
+
+	// Assert
+	if err != nil || recreated.ID == original.ID {
+		t.Errorf("recreate = %v, want a new Customer", err)
+	}
+}
+
+func TestGetByNameAfterRecreateReturnsTheNewObjectType(t *testing.T) {
+	// Arrange
+	svc := newDeletableObjectTypeService()
+	original, _ := createCustomer(svc)
+	if cached, err := svc.GetByName(context.Background(), "Customer"); err != nil || cached.ID != original.ID {
+		t.Fatalf("GetByName before delete = %v, %v, want the original Customer", cached, err)
+	}
+	_ = svc.DeleteObjectType(context.Background(), original.ID, "alice", false)
+	recreated, _ := createCustomer(svc)
+
+	// Act
+	found, err := svc.GetByName(context.Background(), "Customer")
+
+	// Assert
+	if err != nil || found.ID != recreated.ID {
+		t.Errorf("GetByName after recreate = %v, %v, want the recreated Customer %s", found, err, recreated.ID)
+	}
+}
+
+func TestGetByNameAfterRestoreReturnsTheRestoredObjectType(t *testing.T) {
+	// Arrange
+	svc := newDeletableObjectTypeService()
+	original, _ := createCustomer(svc)
+	_ = svc.DeleteObjectType(context.Background(), original.ID, "alice", false)
+	replacement, _ := createCustomer(svc)
+	_, _ = svc.GetByName(context.Background(), "Customer")
+	_ = svc.DeleteObjectType(context.Background(), replacement.ID, "alice", false)
+
+	// Act
+	_, restoreErr := svc.RestoreObjectType(context.Background(), original.ID, "alice")
+	found, err := svc.GetByName(context.Background(), "Customer")
+
+	// Assert
+	if restoreErr != nil || err != nil || found.ID != original.ID {
+		t.Errorf("GetByName after restore = %v, %v (restore %v), want the restored Customer %s", found, err, restoreErr, original.ID)
+	}
+}
+
+func TestCreateWithLiveNameStillConflicts(t *testing.T) {
+	// Arrange
+	svc := newDeletableObjectTypeService()
+	_, _ = createCustomer(svc)
+
+	// Act
+	_, err := createCustomer(svc)
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNameExists) {
+		t.Errorf("create = %v, want ErrObjectTypeNameExists", err)
+	}
+}
+
+func TestGetByNameIncludingDeletedListsLiveThenDeleted(t *testing.T) {
+	// Arrange
+	svc := newDeletableObjectTypeService()
+	deleted, _ := createCustomer(svc)
+	_ = svc.DeleteObjectType(context.Background(), deleted.ID, "alice", false)
+	live, _ := createCustomer(svc)
+
+	// Act
+	objectTypes, err := svc.GetByNameIncludingDeleted(context.Background(), "Customer")
+
+	// Assert
+	if err != nil || len(objectTypes) != 2 || objectTypes[0].ID != live.ID || objectTypes[1].ID != deleted.ID {
+		t.Errorf("GetByNameIncludingDeleted = %v (err %v), want the live then the deleted Customer", objectTypes, err)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// accountType returns an Account object type, deleted or not
+func accountType(deleted bool) *entity.ObjectType {
+	return &entity.ObjectType{ID: uuid.New(), Name: "Account", DisplayName: "Account", Version: 1, IsDeleted: deleted}
+}
+
+// createReferencing creates a Customer whose account property references
+// target
+func createReferencing(target *entity.ObjectType) error {
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(target))
+	_, err := svc.CreateObjectType(context.Background(), CreateObjectTypeInput{
+		Name: "Customer", DisplayName: "Customer",
+		Properties: []PropertyInput{{
+			Name: "account", DisplayName: "Account", DataType: entity.DataTypeReference, ReferencedObjectTypeID: &target.ID,
+		}},
+	}, "alice")
+	return err
+}
+
+func TestCreateWithReferenceToExistingTypeSucceeds(t *testing.T) {
+	// Act
+	err := createReferencing(accountType(false))
+
+	// Assert
+	if err != nil {
+		t.Errorf("CreateObjectType = %v, want nil", err)
+	}
+}
+
+func TestCreateWithReferenceToDeletedTypeFails(t *testing.T) {
+	// Act
+	err := createReferencing(accountType(true))
+
+	// Assert
+	if !errors.Is(err, entity.ErrReferencedTypeMissing) || !errors.Is(err, entity.ErrValidationFailed) {
+		t.Errorf("CreateObjectType = %v, want ErrReferencedTypeMissing as a validation failure", err)
+	}
+}
+
+// referencingCustomer returns a Customer whose account property references
+// target
+func referencingCustomer(target *entity.ObjectType) *entity.ObjectType {
+	return &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1,
+		Properties: []entity.Property{{
+			Name: "account", DisplayName: "Account", DataType: entity.DataTypeReference, ReferencedObjectTypeID: &target.ID,
+		}}}
+}
+
+func TestValidateInstanceAcceptsReferenceToExistingType(t *testing.T) {
+	// Arrange
+	account := accountType(false)
+	customer := referencingCustomer(account)
+	svc := newTestValidationService(account, customer)
+
+	// Act
+	report, err := svc.ValidateInstance(context.Background(), customer.ID, map[string]interface{}{"account": uuid.NewString()})
+
+	// Assert
+	if err != nil || len(report.Errors) != 0 {
+		t.Errorf("ValidateInstance = %+v (err %v), want no errors", report, err)
+	}
+}
+
+func TestValidateInstanceReportsReferenceToDeletedType(t *testing.T) {
+	// Arrange
+	account := accountType(true)
+	customer := referencingCustomer(account)
+	svc := newTestValidationService(account, customer)
+
+	// Act
+	report, err := svc.ValidateInstance(context.Background(), customer.ID, map[string]interface{}{"account": uuid.NewString()})
+
+	// Assert
+	if err != nil || errorCodes(report)["account"] != ValidationCodeBrokenReference {
+		t.Errorf("ValidateInstance = %+v (err %v), want a broken_reference error on account", report, err)
+	}
+}
+
+func TestValidateInstanceSkipsNullReference(t *testing.T) {
+	// Arrange
+	account := accountType(true)
+	customer := referencingCustomer(account)
+	svc := newTestValidationService(account, customer)
+
+	// Act
+	report, _ := svc.ValidateInstance(context.Background(), customer.ID, map[string]interface{}{"account": nil})
+
+	// Assert
+	if len(report.Errors) != 0 {
+		t.Errorf("errors = %+v, want a null reference left unchecked", report.Errors)
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// threePropertyType returns a repository holding an object type with the
+// properties a, b and c in that order
+func threePropertyType() (*fakeObjectTypeRepo, *entity.ObjectType) {
+	objectType := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1, Properties: []entity.Property{
+		{Name: "a", DisplayName: "A", DataType: entity.DataTypeString},
+		{Name: "b", DisplayName: "B", DataType: entity.DataTypeString},
+		{Name: "c", DisplayName: "C", DataType: entity.DataTypeString},
+	}}
+	return newFakeObjectTypeRepo(objectType), objectType
+}
+
+// propertyNames returns the names of an object type's properties in order
+func propertyNames(objectType *entity.ObjectType) []string {
+	names := make([]string, len(objectType.Properties))
+	for i, prop := range objectType.Properties {
+		names[i] = prop.Name
+	}
+	return names
+}
+
+func TestReorderPropertiesReturnsRequestedOrder(t *testing.T) {
+	// Arrange
+	repo, objectType := threePropertyType()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	reordered, _ := svc.ReorderProperties(context.Background(), objectType.ID, []string{"c", "a", "b"}, "alice")
+
+	// Assert
+	if names := propertyNames(reordered); !slices.Equal(names, []string{"c", "a", "b"}) {
+		t.Errorf("properties = %v, want [c a b]", names)
+	}
+}
+
+func TestReorderedPropertiesComeBackInRequestedOrder(t *testing.T) {
+	// Arrange
+	repo, objectType := threePropertyType()
+	svc := newTestObjectTypeService(repo)
+	_, _ = svc.GetByID(context.Background(), objectType.ID)
+	_, _ = svc.ReorderProperties(context.Background(), objectType.ID, []string{"b", "c", "a"}, "alice")
+
+	// Act
+	read, _ := svc.GetByID(context.Background(), objectType.ID)
+
+	// Assert
+	if names := propertyNames(read); !slices.Equal(names, []string{"b", "c", "a"}) {
+		t.Errorf("properties = %v, want [b c a]", names)
+	}
+}
+
+func TestReorderPropertiesSavesNewVersion(t *testing.T) {
+	// Arrange
+	repo, objectType := threePropertyType()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	reordered, _ := svc.ReorderProperties(context.Background(), objectType.ID, []string{"c", "b", "a"}, "alice")
+
+	// Assert
+	if reordered.Version != 2 {
+		t.Errorf("version = %d, want 2", reordered.Version)
+	}
+}
+
+func TestReorderPropertiesRejectsMissingProperty(t *testing.T) {
+	// Arrange
+	repo, objectType := threePropertyType()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, err := svc.ReorderProperties(context.Background(), objectType.ID, []string{"c", "a"}, "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrInvalidPropertyOrder) {
+		t.Errorf("err = %v, want %v", err, entity.ErrInvalidPropertyOrder)
+	}
+}
+
+func TestReorderPropertiesRejectsRepeatedProperty(t *testing.T) {
+	// Arrange
+	repo, objectType := threePropertyType()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, err := svc.ReorderProperties(context.Background(), objectType.ID, []string{"c", "a", "a"}, "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrInvalidPropertyOrder) {
+		t.Errorf("err = %v, want %v", err, entity.ErrInvalidPropertyOrder)
+	}
+}
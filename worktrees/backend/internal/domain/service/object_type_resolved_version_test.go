@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// historyStart is the time the first version of a history is written
+var historyStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// recordVersion writes ot to repo as its current definition and as the
+// version row of ot.Version, created hours after historyStart
+func recordVersion(repo *fakeObjectTypeRepo, ot *entity.ObjectType, hours int) {
+	at := historyStart.Add(time.Duration(hours) * time.Hour)
+	snapshot := ot.Copy()
+	snapshot.UpdatedAt = at
+	repo.objectTypes[ot.ID] = snapshot
+	repo.versions = append(repo.versions, &repository.ObjectTypeVersion{
+		ID:           uuid.New(),
+		ObjectTypeID: ot.ID,
+		Version:      ot.Version,
+		Snapshot:     *snapshot.Copy(),
+		CreatedAt:    at,
+	})
+}
+
+// regionProperty returns a region property defaulting to region
+func regionProperty(region string) entity.Property {
+	return entity.Property{Name: "region", DisplayName: "Region", DataType: entity.DataTypeString, DefaultValue: region}
+}
+
+// stringProperty returns a plain string property named name
+func stringProperty(name string) entity.Property {
+	return entity.Property{Name: name, DisplayName: name, DataType: entity.DataTypeString}
+}
+
+// changedParentHistory records a parent whose region default changed from EU
+// to US, and a tier property was added, between versions 1 and 2 of its child:
+//
+//	hour 1: parent v1 (region=EU)
+//	hour 2: child v1
+//	hour 3: parent v2 (region=US, tier)
+//	hour 4: child v2
+func changedParentHistory() (*fakeObjectTypeRepo, *entity.ObjectType, *entity.ObjectType) {
+	repo := newFakeObjectTypeRepo()
+	parent := &entity.ObjectType{ID: uuid.New(), Name: "Party", DisplayName: "Party", Version: 1,
+		Properties: []entity.Property{regionProperty("EU")}}
+	child := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1,
+		ParentID: &parent.ID, Properties: []entity.Property{stringProperty("email")}}
+
+	recordVersion(repo, parent, 1)
+	recordVersion(repo, child, 2)
+	parent.Version = 2
+	parent.Properties = []entity.Property{regionProperty("US"), stringProperty("tier")}
+	recordVersion(repo, parent, 3)
+	child.Version = 2
+	child.Properties = append(child.Properties, stringProperty("phone"))
+	recordVersion(repo, child, 4)
+	return repo, parent, child
+}
+
+// resolvedNames returns the names of the resolved properties of ot
+func resolvedNames(ot *entity.ObjectType) []string {
+	var names []string
+	for _, prop := range ot.ResolvedProperties {
+		names = append(names, prop.Name)
+	}
+	return names
+}
+
+// resolvedDefault returns the default of the resolved property named name
+func resolvedDefault(ot *entity.ObjectType, name string) interface{} {
+	for _, prop := range ot.ResolvedProperties {
+		if prop.Name == name {
+			return prop.DefaultValue
+		}
+	}
+	return nil
+}
+
+func TestResolvedVersionUsesParentAsItWasThen(t *testing.T) {
+	// Arrange
+	repo, _, child := changedParentHistory()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	resolved, err := svc.GetResolvedVersion(context.Background(), child.ID, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetResolvedVersion: %v", err)
+	}
+	if names := resolvedNames(resolved); !slices.Equal(names, []string{"region", "email"}) {
+		t.Errorf("resolved properties = %v, want [region email] without the later tier", names)
+	}
+	if got := resolvedDefault(resolved, "region"); got != "EU" {
+		t.Errorf("region default = %v, want EU from parent version 1", got)
+	}
+}
+
+func TestResolvedVersionAfterParentChangeUsesNewParent(t *testing.T) {
+	// Arrange
+	repo, _, child := changedParentHistory()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	resolved, err := svc.GetResolvedVersion(context.Background(), child.ID, 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetResolvedVersion: %v", err)
+	}
+	if names := resolvedNames(resolved); !slices.Equal(names, []string{"region", "tier", "email", "phone"}) {
+		t.Errorf("resolved properties = %v, want [region tier email phone]", names)
+	}
+	if got := resolvedDefault(resolved, "region"); got != "US" {
+		t.Errorf("region default = %v, want US from parent version 2", got)
+	}
+}
+
+func TestResolvedVersionFollowsParentOfSnapshot(t *testing.T) {
+	// Arrange
+	repo, parent, child := changedParentHistory()
+	other := &entity.ObjectType{ID: uuid.New(), Name: "Account", DisplayName: "Account", Version: 1,
+		Properties: []entity.Property{stringProperty("iban")}}
+	recordVersion(repo, other, 5)
+	child.Version = 3
+	child.ParentID = &other.ID
+	recordVersion(repo, child, 6)
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	resolved, err := svc.GetResolvedVersion(context.Background(), child.ID, 2)
+
+	// Assert
+	if err != nil || resolvedDefault(resolved, "region") != "US" || slices.Contains(resolvedNames(resolved), "iban") {
+		t.Errorf("resolved = %v (err %v), want the properties of %s, the parent at version 2", resolvedNames(resolved), err, parent.Name)
+	}
+}
+
+func TestResolvedVersionOlderThanParentHistoryUsesEarliestParentVersion(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	parent := &entity.ObjectType{ID: uuid.New(), Name: "Party", DisplayName: "Party", Version: 1,
+		Properties: []entity.Property{regionProperty("EU")}}
+	child := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1,
+		ParentID: &parent.ID, Properties: []entity.Property{stringProperty("email")}}
+	recordVersion(repo, child, 1)
+	recordVersion(repo, parent, 2)
+	parent.Version = 2
+	parent.Properties = []entity.Property{regionProperty("US")}
+	recordVersion(repo, parent, 3)
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	resolved, err := svc.GetResolvedVersion(context.Background(), child.ID, 1)
+
+	// Assert
+	if got := resolvedDefault(resolved, "region"); err != nil || got != "EU" {
+		t.Errorf("region default = %v (err %v), want EU from the earliest parent version", got, err)
+	}
+}
+
+func TestResolvedVersionWithoutParentHistoryUsesCurrentParent(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	parent := &entity.ObjectType{ID: uuid.New(), Name: "Party", DisplayName: "Party", Version: 1,
+		Properties: []entity.Property{regionProperty("EU")}}
+	repo.objectTypes[parent.ID] = parent
+	child := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1,
+		ParentID: &parent.ID, Properties: []entity.Property{stringProperty("email")}}
+	recordVersion(repo, child, 1)
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	resolved, err := svc.GetResolvedVersion(context.Background(), child.ID, 1)
+
+	// Assert
+	if got := resolvedDefault(resolved, "region"); err != nil || got != "EU" {
+		t.Errorf("region default = %v (err %v), want EU from the current parent", got, err)
+	}
+}
+
+func TestResolvedVersionOfUnknownVersionIsNotFound(t *testing.T) {
+	// Arrange
+	repo, _, child := changedParentHistory()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, err := svc.GetResolvedVersion(context.Background(), child.ID, 9)
+
+	// Assert
+	if !errors.Is(err, entity.ErrVersionNotFound) {
+		t.Errorf("GetResolvedVersion = %v, want ErrVersionNotFound", err)
+	}
+}
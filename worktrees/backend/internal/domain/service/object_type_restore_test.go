@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// deletedCustomer returns a repository holding a soft-deleted Customer and
+// the deleted object type
+func deletedCustomer() (*fakeObjectTypeRepo, *entity.ObjectType) {
+	deleted := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1, IsDeleted: true}
+	return newFakeObjectTypeRepo(deleted), deleted
+}
+
+func TestRestoreObjectTypeRejectsReusedName(t *testing.T) {
+	// Arrange
+	repo, deleted := deletedCustomer()
+	reused := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1}
+	repo.objectTypes[reused.ID] = reused
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, err := svc.RestoreObjectType(context.Background(), deleted.ID, "admin")
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNameExists) {
+		t.Errorf("err = %v, want %v", err, entity.ErrObjectTypeNameExists)
+	}
+}
+
+func TestRestoreObjectTypeKeepsItDeletedWhenNameReused(t *testing.T) {
+	// Arrange
+	repo, deleted := deletedCustomer()
+	reused := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1}
+	repo.objectTypes[reused.ID] = reused
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, _ = svc.RestoreObjectType(context.Background(), deleted.ID, "admin")
+
+	// Assert
+	if _, err := svc.GetByID(context.Background(), deleted.ID); !errors.Is(err, entity.ErrObjectTypeNotFound) {
+		t.Errorf("GetByID = %v, want the object type still deleted", err)
+	}
+}
+
+func TestRestoreObjectTypeSucceedsOnceReusedNameIsDeleted(t *testing.T) {
+	// Arrange
+	repo, deleted := deletedCustomer()
+	reused := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1, IsDeleted: true}
+	repo.objectTypes[reused.ID] = reused
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	restored, err := svc.RestoreObjectType(context.Background(), deleted.ID, "admin")
+
+	// Assert
+	if err != nil || restored.ID != deleted.ID {
+		t.Errorf("RestoreObjectType = %v, %v, want the restored object type", restored, err)
+	}
+}
+
+func TestRestoreObjectTypeClearsRememberedMiss(t *testing.T) {
+	// Arrange
+	repo, deleted := deletedCustomer()
+	svc := newTestObjectTypeService(repo)
+	_, _ = svc.GetByID(context.Background(), deleted.ID)
+
+	// Act
+	_, _ = svc.RestoreObjectType(context.Background(), deleted.ID, "admin")
+
+	// Assert
+	if _, err := svc.GetByID(context.Background(), deleted.ID); err != nil {
+		t.Errorf("GetByID = %v, want the restored object type", err)
+	}
+}
+
+func TestRestoreObjectTypePublishesRestoredEvent(t *testing.T) {
+	// Arrange
+	repo, deleted := deletedCustomer()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, _ = svc.RestoreObjectType(context.Background(), deleted.ID, "admin")
+
+	// Assert
+	events := svc.publisher.(*fakePublisher).events
+	if len(events) == 0 || events[0].Type != messaging.EventObjectTypeRestored {
+		t.Errorf("events = %v, want %s first", events, messaging.EventObjectTypeRestored)
+	}
+}
+
+func TestRestoreObjectTypeRejectsLiveObjectType(t *testing.T) {
+	// Arrange
+	live := &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 1}
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(live))
+
+	// Act
+	_, err := svc.RestoreObjectType(context.Background(), live.ID, "admin")
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNotDeleted) {
+		t.Errorf("err = %v, want %v", err, entity.ErrObjectTypeNotDeleted)
+	}
+}
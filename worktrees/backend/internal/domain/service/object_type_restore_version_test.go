@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// customerAtV2 returns a repository holding Customer at version 2, with
+// version 1 recorded as v1
+func customerAtV2(v1 *entity.ObjectType, others ...*entity.ObjectType) (*fakeObjectTypeRepo, *entity.ObjectType) {
+	current := &entity.ObjectType{ID: v1.ID, Name: "Customer", DisplayName: "Customer", Version: 2}
+	repo := newFakeObjectTypeRepo(append(others, current)...)
+	repo.versions = append(repo.versions, &repository.ObjectTypeVersion{
+		ID: uuid.New(), ObjectTypeID: v1.ID, Version: 1, Snapshot: *v1,
+	})
+	return repo, current
+}
+
+func TestRestoreVersionReferencingMissingTypeIsRejected(t *testing.T) {
+	// Arrange
+	account := accountType(true)
+	v1 := referencingCustomer(account)
+	repo, current := customerAtV2(v1, account)
+	publisher := &fakePublisher{}
+	svc := newTestObjectTypeService(repo)
+	svc.publisher = publisher
+
+	// Act
+	_, err := svc.RestoreVersion(context.Background(), current.ID, 1, "alice")
+
+	// Assert
+	if !errors.Is(err, entity.ErrReferencedTypeMissing) || !errors.Is(err, entity.ErrValidationFailed) {
+		t.Fatalf("RestoreVersion = %v, want ErrReferencedTypeMissing as a validation failure", err)
+	}
+	if stored := repo.objectTypes[current.ID]; stored.Version != 2 || len(stored.Properties) != 0 {
+		t.Errorf("stored = version %d with %d properties, want version 2 unchanged", stored.Version, len(stored.Properties))
+	}
+	if len(repo.versions) != 1 || len(publisher.events) != 0 {
+		t.Errorf("versions = %d, events = %d, want no new version and no event", len(repo.versions), len(publisher.events))
+	}
+}
+
+func TestRestoreVersionSavesANewVersionAndPublishes(t *testing.T) {
+	// Arrange
+	party := &entity.ObjectType{ID: uuid.New(), Name: "Party", DisplayName: "Party", Version: 1}
+	v1 := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Client", Version: 1, ParentID: &party.ID,
+		Properties: []entity.Property{{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString}}}
+	repo, current := customerAtV2(v1, party)
+	publisher := &fakePublisher{}
+	svc := newTestObjectTypeService(repo)
+	svc.publisher = publisher
+
+	// Act
+	restored, err := svc.RestoreVersion(context.Background(), current.ID, 1, "alice")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RestoreVersion: %v", err)
+	}
+	stored := repo.objectTypes[current.ID]
+	if restored.Version != 3 || stored.Version != 3 || stored.DisplayName != "Client" ||
+		stored.ParentID == nil || *stored.ParentID != party.ID || len(stored.Properties) != 1 {
+		t.Errorf("stored = %+v, want version 3 with the definition and parent of version 1", stored)
+	}
+	if last := repo.versions[len(repo.versions)-1]; last.Version != 3 || last.ChangeDescription != "restored from v1" {
+		t.Errorf("recorded version %d %q, want 3 restored from v1", last.Version, last.ChangeDescription)
+	}
+	if repo.updateAudit == nil || repo.updateAudit.Actor != "alice" || repo.updateAudit.Action != entity.AuditActionUpdate {
+		t.Errorf("audit = %+v, want an update by alice", repo.updateAudit)
+	}
+	var types []messaging.EventType
+	for _, evt := range publisher.events {
+		types = append(types, evt.Type)
+	}
+	if len(types) != 2 || types[0] != messaging.EventObjectTypeUpdated || types[1] != messaging.EventPropertyAdded ||
+		publisher.events[0].Metadata["restoredFromVersion"] != 1 {
+		t.Errorf("events = %v, want the update from version 1 then email added", types)
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// jsonSchemaDraft07 is the meta-schema URI of generated schemas
+const jsonSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// ExportJSONSchema renders an object type, including inherited properties,
+// as a Draft-07 JSON Schema describing a valid instance
+func (s *ObjectTypeService) ExportJSONSchema(ctx context.Context, id uuid.UUID) (json.RawMessage, error) {
+	objectType, err := s.GetByIDResolved(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := json.Marshal(buildJSONSchema(objectType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON schema: %w", err)
+	}
+
+	return schema, nil
+}
+
+// GetDefaults returns the default value of every property of an object type,
+// including inherited properties, keyed by property name. Properties without
+// a default are omitted.
+func (s *ObjectTypeService) GetDefaults(ctx context.Context, id uuid.UUID) (map[string]interface{}, error) {
+	objectType, err := s.GetByIDResolved(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return objectType.ApplyDefaults(nil), nil
+}
+
+// GetPropertiesGrouped returns the properties of an object type split into
+// their groups, see entity.GroupProperties
+func (s *ObjectTypeService) GetPropertiesGrouped(ctx context.Context, id uuid.UUID) ([]entity.PropertyGroup, error) {
+	objectType, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.GroupProperties(objectType.Properties), nil
+}
+
+// buildJSONSchema maps an object type to a JSON Schema document
+func buildJSONSchema(objectType *entity.ObjectType) map[string]interface{} {
+	properties := objectType.ResolvedProperties
+	if properties == nil {
+		properties = objectType.Properties
+	}
+
+	schemaProperties := make(map[string]interface{}, len(properties))
+	required := []string{}
+	for _, prop := range properties {
+		schemaProperties[prop.Name] = propertyJSONSchema(prop)
+		if prop.Required {
+			required = append(required, prop.Name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"$schema":              jsonSchemaDraft07,
+		"$id":                  fmt.Sprintf("urn:oms:object-type:%s:v%d", objectType.ID, objectType.Version),
+		"title":                objectType.DisplayName,
+		"type":                 "object",
+		"properties":           schemaProperties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+	if objectType.Description != nil {
+		schema["description"] = *objectType.Description
+	}
+
+	return schema
+}
+
+// propertyJSONSchema maps a single property and its validators to a JSON Schema
+func propertyJSONSchema(prop entity.Property) map[string]interface{} {
+	schema := dataTypeJSONSchema(prop.DataType)
+	schema["title"] = prop.DisplayName
+	if prop.Description != nil {
+		schema["description"] = *prop.Description
+	}
+	if prop.DefaultValue != nil {
+		schema["default"] = prop.DefaultValue
+	}
+	// Not a draft-07 keyword, but understood by OpenAPI and later drafts
+	if prop.Deprecated {
+		schema["deprecated"] = true
+	}
+	applyValidatorSchema(schema, prop.Validators)
+
+	if prop.ElementType != "" {
+		items := dataTypeJSONSchema(prop.ElementType)
+		applyValidatorSchema(items, prop.ItemValidators)
+		schema["items"] = items
+	}
+
+	return schema
+}
+
+// applyValidatorSchema adds the JSON Schema keywords of validators to schema
+func applyValidatorSchema(schema map[string]interface{}, validators []entity.Validator) {
+	for _, v := range validators {
+		switch v.Type {
+		case entity.ValidatorMinLength:
+			if n, ok := v.Value.(float64); ok {
+				schema["minLength"] = int(n)
+			}
+		case entity.ValidatorMaxLength:
+			if n, ok := v.Value.(float64); ok {
+				schema["maxLength"] = int(n)
+			}
+		case entity.ValidatorPattern:
+			schema["pattern"] = v.Value
+		case entity.ValidatorMin:
+			schema["minimum"] = v.Value
+		case entity.ValidatorMax:
+			schema["maximum"] = v.Value
+		case entity.ValidatorEnum:
+			schema["enum"] = v.Value
+		case entity.ValidatorFormat:
+			if format, ok := v.Value.(string); ok {
+				// JSON Schema names the url format "uri"
+				if format == entity.FormatURL {
+					format = "uri"
+				}
+				schema["format"] = format
+			}
+		}
+		// Expression validators span several properties and have no JSON Schema equivalent
+	}
+}
+
+// dataTypeJSONSchema returns the base JSON Schema for a data type
+func dataTypeJSONSchema(dataType entity.DataType) map[string]interface{} {
+	switch dataType {
+	case entity.DataTypeString:
+		return map[string]interface{}{"type": "string"}
+	case entity.DataTypeNumber:
+		return map[string]interface{}{"type": "number"}
+	case entity.DataTypeBoolean:
+		return map[string]interface{}{"type": "boolean"}
+	case entity.DataTypeDate:
+		return map[string]interface{}{"type": "string", "format": "date"}
+	case entity.DataTypeDateTime:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case entity.DataTypeArray:
+		return map[string]interface{}{"type": "array"}
+	case entity.DataTypeObject:
+		return map[string]interface{}{"type": "object"}
+	case entity.DataTypeReference:
+		return map[string]interface{}{"type": "string", "format": "uuid"}
+	case entity.DataTypeGeoPoint:
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"lat": map[string]interface{}{"type": "number", "minimum": -90, "maximum": 90},
+				"lng": map[string]interface{}{"type": "number", "minimum": -180, "maximum": 180},
+			},
+			"required":             []string{"lat", "lng"},
+			"additionalProperties": false,
+		}
+	case entity.DataTypeCurrency:
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"amount":       map[string]interface{}{"type": "number"},
+				"currencyCode": map[string]interface{}{"type": "string", "pattern": "^[A-Z]{3}$"},
+			},
+			"required":             []string{"amount", "currencyCode"},
+			"additionalProperties": false,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// schemaFixtureType is the object type testdata/customer.schema.json describes
+func schemaFixtureType() *entity.ObjectType {
+	description := "A paying customer"
+	emailDescription := "Primary contact address"
+	return &entity.ObjectType{
+		ID:          uuid.MustParse("7d3c1f4e-2b8a-4c6d-9e0f-1a2b3c4d5e6f"),
+		Name:        "Customer",
+		DisplayName: "Customer",
+		Description: &description,
+		Version:     3,
+		Properties: []entity.Property{
+			{Name: "name", DisplayName: "Name", DataType: entity.DataTypeString, Required: true, Validators: []entity.Validator{
+				{Type: entity.ValidatorMinLength, Value: float64(1)},
+				{Type: entity.ValidatorMaxLength, Value: float64(100)},
+			}},
+			{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString, Description: &emailDescription, Validators: []entity.Validator{
+				{Type: entity.ValidatorFormat, Value: entity.FormatEmail},
+			}},
+			{Name: "website", DisplayName: "Website", DataType: entity.DataTypeString, Validators: []entity.Validator{
+				{Type: entity.ValidatorFormat, Value: entity.FormatURL},
+			}},
+			{Name: "score", DisplayName: "Score", DataType: entity.DataTypeNumber, DefaultValue: float64(0), Validators: []entity.Validator{
+				{Type: entity.ValidatorMin, Value: float64(0)},
+				{Type: entity.ValidatorMax, Value: float64(10)},
+			}},
+			{Name: "active", DisplayName: "Active", DataType: entity.DataTypeBoolean},
+			{Name: "joinedOn", DisplayName: "Joined on", DataType: entity.DataTypeDate, Required: true},
+			{Name: "lastSeenAt", DisplayName: "Last seen at", DataType: entity.DataTypeDateTime},
+			{Name: "tier", DisplayName: "Tier", DataType: entity.DataTypeString, Validators: []entity.Validator{
+				{Type: entity.ValidatorEnum, Value: []interface{}{"free", "pro"}},
+			}},
+			{Name: "tags", DisplayName: "Tags", DataType: entity.DataTypeArray, ElementType: entity.DataTypeString, ItemValidators: []entity.Validator{
+				{Type: entity.ValidatorMaxLength, Value: float64(20)},
+			}},
+			{Name: "accountManager", DisplayName: "Account manager", DataType: entity.DataTypeReference},
+			{Name: "location", DisplayName: "Location", DataType: entity.DataTypeGeoPoint},
+			{Name: "balance", DisplayName: "Balance", DataType: entity.DataTypeCurrency},
+			{Name: "renewsOn", DisplayName: "Renews on", DataType: entity.DataTypeDate, Validators: []entity.Validator{
+				{Type: entity.ValidatorExpression, Value: "renewsOn >= joinedOn"},
+			}},
+		},
+	}
+}
+
+// decodeJSON decodes data into a generic value so documents compare
+// independently of key order and formatting
+func decodeJSON(t *testing.T, data []byte) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatalf("decode JSON: %v", err)
+	}
+	return value
+}
+
+func TestExportJSONSchemaMatchesFixture(t *testing.T) {
+	// Arrange
+	objectType := schemaFixtureType()
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(objectType))
+	fixture, err := os.ReadFile("testdata/customer.schema.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	// Act
+	schema, err := svc.ExportJSONSchema(context.Background(), objectType.ID)
+
+	// Assert
+	if err != nil || !reflect.DeepEqual(decodeJSON(t, schema), decodeJSON(t, fixture)) {
+		t.Errorf("ExportJSONSchema = %s, %v; want testdata/customer.schema.json", schema, err)
+	}
+}
+
+func TestExportJSONSchemaIncludesInheritedProperties(t *testing.T) {
+	// Arrange
+	parent := &entity.ObjectType{ID: uuid.New(), Name: "Party", Properties: []entity.Property{
+		{Name: "legalName", DisplayName: "Legal name", DataType: entity.DataTypeString, Required: true},
+	}}
+	child := &entity.ObjectType{ID: uuid.New(), Name: "Customer", ParentID: &parent.ID}
+	svc := newTestObjectTypeService(newFakeObjectTypeRepo(parent, child))
+
+	// Act
+	data, _ := svc.ExportJSONSchema(context.Background(), child.ID)
+
+	// Assert
+	var schema struct {
+		Properties map[string]interface{} `json:"properties"`
+		Required   []string               `json:"required"`
+	}
+	_ = json.Unmarshal(data, &schema)
+	if schema.Properties["legalName"] == nil || len(schema.Required) != 1 {
+		t.Errorf("schema = %s, want the inherited required legalName", data)
+	}
+}
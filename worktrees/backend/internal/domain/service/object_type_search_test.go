@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+func TestSearchNonPositiveLimitUsesDefault(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, _ = svc.Search(context.Background(), "customer", 0, repository.SearchOptions{})
+
+	// Assert
+	if repo.searchLimit != DefaultSearchLimit {
+		t.Errorf("repository limit = %d, want %d", repo.searchLimit, DefaultSearchLimit)
+	}
+}
+
+func TestSearchNegativeLimitUsesDefault(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, _ = svc.Search(context.Background(), "customer", -5, repository.SearchOptions{})
+
+	// Assert
+	if repo.searchLimit != DefaultSearchLimit {
+		t.Errorf("repository limit = %d, want %d", repo.searchLimit, DefaultSearchLimit)
+	}
+}
+
+func TestSearchLimitIsCapped(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	_, _ = svc.Search(context.Background(), "customer", MaxSearchLimit+1, repository.SearchOptions{})
+
+	// Assert
+	if repo.searchLimit != MaxSearchLimit {
+		t.Errorf("repository limit = %d, want %d", repo.searchLimit, MaxSearchLimit)
+	}
+}
@@ -2,37 +2,62 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/openfoundry/oms/internal/domain/entity"
 	"github.com/openfoundry/oms/internal/domain/repository"
 	"github.com/openfoundry/oms/internal/infrastructure/cache"
 	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 // ObjectTypeService handles business logic for object types
 type ObjectTypeService struct {
-	repo      repository.ObjectTypeRepository
-	cache     cache.CacheService
-	publisher messaging.EventPublisher
-	logger    *zap.Logger
+	repo         repository.ObjectTypeRepository
+	linkTypeRepo repository.LinkTypeRepository
+	cache        cache.CacheService
+	cacheTTLs    CacheTTLs
+	publisher    messaging.EventPublisher
+	metrics      *metrics.Metrics
+	logger       *zap.Logger
+
+	// loads collapses concurrent cache misses for the same key into one query
+	loads singleflight.Group
 }
 
+// notFoundCacheTTL is how long a lookup of a nonexistent object type is remembered
+const notFoundCacheTTL = 30 * time.Second
+
 // NewObjectTypeService creates a new object type service
 func NewObjectTypeService(
 	repo repository.ObjectTypeRepository,
+	linkTypeRepo repository.LinkTypeRepository,
 	cache cache.CacheService,
+	cacheTTLs CacheTTLs,
 	publisher messaging.EventPublisher,
+	metrics *metrics.Metrics,
 	logger *zap.Logger,
 ) *ObjectTypeService {
 	return &ObjectTypeService{
-		repo:      repo,
-		cache:     cache,
-		publisher: publisher,
-		logger:    logger,
+		repo:         repo,
+		linkTypeRepo: linkTypeRepo,
+		cache:        cache,
+		cacheTTLs:    cacheTTLs,
+		publisher:    publisher,
+		metrics:      metrics,
+		logger:       logger,
 	}
 }
 
@@ -45,6 +70,7 @@ type CreateObjectTypeInput struct {
 	Tags         []string                       `json:"tags"`
 	Properties   []PropertyInput                `json:"properties"`
 	Metadata     map[string]interface{}         `json:"metadata"`
+	ParentID     *uuid.UUID                     `json:"parentId,omitempty"`
 }
 
 // PropertyInput represents input for creating a property
@@ -59,21 +85,24 @@ type PropertyInput struct {
 	Description  *string                `json:"description,omitempty"`
 	Validators   []entity.Validator     `json:"validators,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata"`
-}
+	Order        int                    `json:"order"`
 
-// CreateObjectType creates a new object type
-func (s *ObjectTypeService) CreateObjectType(ctx context.Context, input CreateObjectTypeInput, userID string) (*entity.ObjectType, error) {
-	s.logger.Info("Creating object type", zap.String("name", input.Name), zap.String("user", userID))
+	ElementType       entity.DataType    `json:"elementType,omitempty"`
+	ItemValidators    []entity.Validator `json:"itemValidators,omitempty"`
+	AllowNestedArrays bool               `json:"allowNestedArrays,omitempty"`
 
-	// Check if name already exists
-	existing, _ := s.repo.GetByName(ctx, input.Name)
-	if existing != nil {
-		return nil, entity.ErrObjectTypeNameExists
-	}
+	ReferencedObjectTypeID *uuid.UUID `json:"referencedObjectTypeId,omitempty"`
+
+	Group string `json:"group,omitempty"`
 
-	// Build properties
-	properties := make([]entity.Property, len(input.Properties))
-	for i, propInput := range input.Properties {
+	Deprecated         bool    `json:"deprecated,omitempty"`
+	DeprecationMessage *string `json:"deprecationMessage,omitempty"`
+}
+
+// buildProperties converts property inputs into property entities with fresh IDs
+func buildProperties(inputs []PropertyInput) []entity.Property {
+	properties := make([]entity.Property, len(inputs))
+	for i, propInput := range inputs {
 		properties[i] = entity.Property{
 			ID:           uuid.New(),
 			Name:         propInput.Name,
@@ -86,8 +115,114 @@ func (s *ObjectTypeService) CreateObjectType(ctx context.Context, input CreateOb
 			Description:  propInput.Description,
 			Validators:   propInput.Validators,
 			Metadata:     propInput.Metadata,
+			Order:        propInput.Order,
+
+			ElementType:       propInput.ElementType,
+			ItemValidators:    propInput.ItemValidators,
+			AllowNestedArrays: propInput.AllowNestedArrays,
+
+			ReferencedObjectTypeID: propInput.ReferencedObjectTypeID,
+
+			Group: propInput.Group,
+
+			Deprecated:         propInput.Deprecated,
+			DeprecationMessage: propInput.DeprecationMessage,
+		}
+	}
+	return properties
+}
+
+// sortProperties orders the properties of each object type by Order. It runs
+// before results are cached or shared so readers never see stored order.
+func sortProperties(objectTypes ...*entity.ObjectType) {
+	for _, objectType := range objectTypes {
+		if objectType != nil {
+			objectType.SortProperties()
 		}
 	}
+}
+
+// CreateObjectType creates a new object type
+func (s *ObjectTypeService) CreateObjectType(ctx context.Context, input CreateObjectTypeInput, userID string) (*entity.ObjectType, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.CreateObjectType")
+	defer span.End()
+
+	s.logger.Info("Creating object type", zap.String("name", input.Name), zap.String("user", userID))
+
+	objectType, err := s.prepareCreate(ctx, input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save to repository
+	span.SetAttributes(attribute.String("object_type.id", objectType.ID.String()))
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityObjectType, objectType.ID, entity.AuditActionCreate, userID, nil, objectType)
+	if err := s.repo.Create(auditCtx, objectType); err != nil {
+		recordSpanError(span, err)
+		s.logger.Error("Failed to create object type", zap.Error(err))
+		return nil, fmt.Errorf("failed to create object type: %w", err)
+	}
+
+	// Invalidate cache
+	s.invalidateCache(ctx, objectType)
+
+	// Publish event
+	event := messaging.Event{
+		ID:            uuid.New().String(),
+		Type:          messaging.EventObjectTypeCreated,
+		EntityID:      objectType.ID.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          objectType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	// Record storage indexes for indexed and unique properties
+	s.ensureIndexes(ctx, objectType.ID, userID)
+
+	s.metrics.ObjectTypeCreated.Inc()
+	s.logger.Info("Object type created successfully", zap.String("id", objectType.ID.String()))
+	return objectType, nil
+}
+
+// DryRunResult is what a create or update would have saved. Saved is always
+// false; it is there so clients can tell a dry run from a real write.
+type DryRunResult struct {
+	ObjectType *entity.ObjectType `json:"objectType"`
+	Saved      bool               `json:"saved"`
+	// Compatibility classifies the changes an update would make
+	Compatibility *CompatibilityReport `json:"compatibility,omitempty"`
+}
+
+// DryRunCreate runs the checks of CreateObjectType and returns the object
+// type it would create, without saving it or publishing an event
+func (s *ObjectTypeService) DryRunCreate(ctx context.Context, input CreateObjectTypeInput, userID string) (*DryRunResult, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.DryRunCreate")
+	defer span.End()
+
+	objectType, err := s.prepareCreate(ctx, input, userID)
+	if err != nil {
+		return nil, err
+	}
+	sortProperties(objectType)
+
+	return &DryRunResult{ObjectType: objectType}, nil
+}
+
+// prepareCreate builds the object type described by input and runs every
+// check made before it is saved
+func (s *ObjectTypeService) prepareCreate(ctx context.Context, input CreateObjectTypeInput, userID string) (*entity.ObjectType, error) {
+	// Check if name already exists
+	existing, _ := s.repo.GetByName(ctx, input.Name)
+	if existing != nil {
+		return nil, entity.ErrObjectTypeNameExists
+	}
 
 	// Create object type entity
 	objectType := &entity.ObjectType{
@@ -97,8 +232,9 @@ func (s *ObjectTypeService) CreateObjectType(ctx context.Context, input CreateOb
 		Description: input.Description,
 		Category:    input.Category,
 		Tags:        input.Tags,
-		Properties:  properties,
+		Properties:  buildProperties(input.Properties),
 		Metadata:    input.Metadata,
+		ParentID:    input.ParentID,
 		Version:     1,
 		IsDeleted:   false,
 		CreatedAt:   time.Now(),
@@ -109,26 +245,92 @@ func (s *ObjectTypeService) CreateObjectType(ctx context.Context, input CreateOb
 
 	// Validate object type
 	if err := objectType.Validate(); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", entity.ErrValidationFailed, err)
+	}
+	if err := s.validateInheritance(ctx, objectType); err != nil {
+		return nil, err
+	}
+	if err := s.validateReferences(ctx, objectType); err != nil {
+		return nil, err
+	}
+
+	return objectType, nil
+}
+
+// Clone creates a new object type named newName from the object type with the
+// given id. Properties, tags, metadata, category, description and parent are
+// deep copied, so later changes to either type do not affect the other.
+// Properties get new IDs and the clone starts at version 1. An empty
+// newDisplayName keeps the source's display name.
+func (s *ObjectTypeService) Clone(ctx context.Context, id uuid.UUID, newName, newDisplayName string, userID string) (*entity.ObjectType, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.Clone",
+		trace.WithAttributes(attribute.String("object_type.source_id", id.String())))
+	defer span.End()
+
+	s.logger.Info("Cloning object type",
+		zap.String("id", id.String()),
+		zap.String("name", newName),
+		zap.String("user", userID))
+
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if name already exists
+	existing, _ := s.repo.GetByName(ctx, newName)
+	if existing != nil {
+		return nil, entity.ErrObjectTypeNameExists
+	}
+
+	now := time.Now()
+	objectType := source.Copy()
+	objectType.ID = uuid.New()
+	objectType.Name = newName
+	if newDisplayName != "" {
+		objectType.DisplayName = newDisplayName
+	}
+	for i := range objectType.Properties {
+		objectType.Properties[i].ID = uuid.New()
+	}
+	objectType.ResolvedProperties = nil
+	objectType.Version = 1
+	objectType.IsDeleted = false
+	objectType.CreatedAt = now
+	objectType.CreatedBy = userID
+	objectType.UpdatedAt = now
+	objectType.UpdatedBy = userID
+
+	// Validate object type
+	if err := objectType.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrValidationFailed, err)
+	}
+	if err := s.validateInheritance(ctx, objectType); err != nil {
+		return nil, err
 	}
 
 	// Save to repository
-	if err := s.repo.Create(ctx, objectType); err != nil {
-		s.logger.Error("Failed to create object type", zap.Error(err))
-		return nil, fmt.Errorf("failed to create object type: %w", err)
+	span.SetAttributes(attribute.String("object_type.id", objectType.ID.String()))
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityObjectType, objectType.ID, entity.AuditActionCreate, userID, nil, objectType)
+	if err := s.repo.Create(auditCtx, objectType); err != nil {
+		recordSpanError(span, err)
+		s.logger.Error("Failed to clone object type", zap.Error(err))
+		return nil, fmt.Errorf("failed to clone object type: %w", err)
 	}
 
 	// Invalidate cache
-	s.invalidateCache(ctx, objectType.ID)
+	s.invalidateCache(ctx, objectType)
 
 	// Publish event
 	event := messaging.Event{
-		ID:        uuid.New().String(),
-		Type:      messaging.EventObjectTypeCreated,
-		EntityID:  objectType.ID.String(),
-		Actor:     userID,
-		Timestamp: time.Now(),
-		Data:      objectType,
+		ID:            uuid.New().String(),
+		Type:          messaging.EventObjectTypeCreated,
+		EntityID:      objectType.ID.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          objectType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
 	}
 
 	if err := s.publisher.Publish(ctx, event); err != nil {
@@ -136,29 +338,264 @@ func (s *ObjectTypeService) CreateObjectType(ctx context.Context, input CreateOb
 		s.logger.Error("Failed to publish event", zap.Error(err))
 	}
 
-	s.logger.Info("Object type created successfully", zap.String("id", objectType.ID.String()))
+	// Record storage indexes for indexed and unique properties
+	s.ensureIndexes(ctx, objectType.ID, userID)
+
+	s.metrics.ObjectTypeCreated.Inc()
+	s.logger.Info("Object type cloned successfully",
+		zap.String("source_id", id.String()),
+		zap.String("id", objectType.ID.String()))
 	return objectType, nil
 }
 
+// MaxBatchSize bounds the number of items accepted by a batch operation
+const MaxBatchSize = 100
+
+// BatchResult reports the outcome of one item in a batch operation
+type BatchResult struct {
+	Index int        `json:"index"`
+	ID    *uuid.UUID `json:"id,omitempty"`
+	Error string     `json:"error,omitempty"`
+}
+
+// BatchCreateObjectTypes creates object types in a single transaction. Every
+// input is validated up front; if any item is invalid or fails to persist,
+// nothing is created and ErrBatchFailed is returned along with per-item results.
+func (s *ObjectTypeService) BatchCreateObjectTypes(ctx context.Context, inputs []CreateObjectTypeInput, userID string) ([]BatchResult, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.BatchCreateObjectTypes",
+		trace.WithAttributes(attribute.Int("batch.size", len(inputs))))
+	defer span.End()
+
+	s.logger.Info("Batch creating object types", zap.Int("count", len(inputs)), zap.String("user", userID))
+
+	if len(inputs) == 0 || len(inputs) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: batch must contain between 1 and %d items", entity.ErrBatchFailed, MaxBatchSize)
+	}
+
+	results := make([]BatchResult, len(inputs))
+	objectTypes := make([]*entity.ObjectType, len(inputs))
+	names := make(map[string]int, len(inputs))
+	failed := false
+
+	for i, input := range inputs {
+		results[i].Index = i
+		now := time.Now()
+		objectType := &entity.ObjectType{
+			ID:          uuid.New(),
+			Name:        input.Name,
+			DisplayName: input.DisplayName,
+			Description: input.Description,
+			Category:    input.Category,
+			Tags:        input.Tags,
+			Properties:  buildProperties(input.Properties),
+			Metadata:    input.Metadata,
+			ParentID:    input.ParentID,
+			Version:     1,
+			CreatedAt:   now,
+			CreatedBy:   userID,
+			UpdatedAt:   now,
+			UpdatedBy:   userID,
+		}
+		objectTypes[i] = objectType
+
+		if err := s.validateBatchItem(ctx, objectType, names, i); err != nil {
+			results[i].Error = err.Error()
+			failed = true
+		}
+	}
+
+	if failed {
+		return results, fmt.Errorf("%w: validation failed", entity.ErrBatchFailed)
+	}
+
+	// Save to repository
+	if err := s.repo.BatchCreate(withAuditActor(ctx, userID), objectTypes); err != nil {
+		recordSpanError(span, err)
+		var itemErr *repository.BatchItemError
+		if errors.As(err, &itemErr) && itemErr.Index >= 0 && itemErr.Index < len(results) {
+			results[itemErr.Index].Error = itemErr.Err.Error()
+			return results, fmt.Errorf("%w: %v", entity.ErrBatchFailed, err)
+		}
+		s.logger.Error("Failed to batch create object types", zap.Error(err))
+		return nil, fmt.Errorf("failed to batch create object types: %w", err)
+	}
+
+	events := make([]messaging.Event, len(objectTypes))
+	for i, objectType := range objectTypes {
+		id := objectType.ID
+		results[i].ID = &id
+		s.invalidateCache(ctx, objectType)
+		events[i] = messaging.Event{
+			ID:            uuid.New().String(),
+			Type:          messaging.EventObjectTypeCreated,
+			EntityID:      objectType.ID.String(),
+			Actor:         userID,
+			Timestamp:     time.Now(),
+			Data:          objectType,
+			CorrelationID: messaging.CorrelationIDFromContext(ctx),
+			TenantID:      repository.TenantFromContext(ctx),
+		}
+	}
+
+	if err := s.publisher.PublishBatch(ctx, events); err != nil {
+		s.logger.Error("Failed to publish events", zap.Error(err))
+	}
+
+	for _, objectType := range objectTypes {
+		s.ensureIndexes(ctx, objectType.ID, userID)
+	}
+
+	s.metrics.ObjectTypeCreated.Add(float64(len(objectTypes)))
+	s.logger.Info("Object types batch created successfully", zap.Int("count", len(objectTypes)))
+	return results, nil
+}
+
+// validateBatchItem validates one batch item, including name clashes with
+// earlier items in the same batch and with existing object types
+func (s *ObjectTypeService) validateBatchItem(ctx context.Context, objectType *entity.ObjectType, names map[string]int, index int) error {
+	if err := objectType.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", entity.ErrValidationFailed, err)
+	}
+
+	if other, ok := names[objectType.Name]; ok {
+		return fmt.Errorf("%w: duplicates item %d", entity.ErrObjectTypeNameExists, other)
+	}
+	names[objectType.Name] = index
+
+	if existing, _ := s.repo.GetByName(ctx, objectType.Name); existing != nil {
+		return entity.ErrObjectTypeNameExists
+	}
+
+	if err := s.validateInheritance(ctx, objectType); err != nil {
+		return err
+	}
+	return s.validateReferences(ctx, objectType)
+}
+
 // GetByID retrieves an object type by ID
 func (s *ObjectTypeService) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
 	// Try cache first
 	cacheKey := fmt.Sprintf("object_type:%s", id.String())
 	var cached *entity.ObjectType
 	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && cached != nil {
+		s.metrics.ObserveCacheLookup("object_type_by_id", true)
 		return cached, nil
 	}
 
-	// Get from repository
-	objectType, err := s.repo.GetByID(ctx, id)
+	// Known-missing IDs are answered without touching the database
+	missingKey := fmt.Sprintf("object_type:missing:%s", id.String())
+	if missing, err := s.cache.Exists(ctx, missingKey); err == nil && missing {
+		s.metrics.ObserveCacheLookup("object_type_by_id", true)
+		return nil, entity.ErrObjectTypeNotFound
+	}
+	s.metrics.ObserveCacheLookup("object_type_by_id", false)
+
+	// Get from repository, sharing one query between concurrent misses of
+	// the same tenant
+	result, err, _ := s.loads.Do(repository.TenantFromContext(ctx)+":"+cacheKey, func() (interface{}, error) {
+		// Detach from the first caller's cancellation, which would fail every waiter
+		loadCtx := context.WithoutCancel(ctx)
+
+		objectType, err := s.repo.GetByID(loadCtx, id)
+		if err == entity.ErrObjectTypeNotFound {
+			_ = s.cache.Set(loadCtx, missingKey, true, notFoundCacheTTL)
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		sortProperties(objectType)
+
+		// Cache the result
+		_ = s.cache.Set(loadCtx, cacheKey, objectType, s.cacheTTLs.ObjectType)
+
+		return objectType, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Cache the result
-	_ = s.cache.Set(ctx, cacheKey, objectType, 5*time.Minute)
+	return result.(*entity.ObjectType), nil
+}
 
-	return objectType, nil
+// GetByIDs retrieves several object types, reading cached ones in one cache
+// round-trip and the rest in one repository round-trip. The result follows
+// the order of ids; missing IDs and repeats are omitted.
+func (s *ObjectTypeService) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.GetByIDs",
+		trace.WithAttributes(attribute.Int("ids.count", len(ids))))
+	defer span.End()
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf("object_type:%s", id.String())
+	}
+
+	// A failing cache is treated as all misses
+	values, err := s.cache.MGet(ctx, keys)
+	if err != nil {
+		values = make([][]byte, len(ids))
+	}
+
+	found := make(map[uuid.UUID]*entity.ObjectType, len(ids))
+	queued := make(map[uuid.UUID]bool)
+	var misses []uuid.UUID
+	for i, id := range ids {
+		if found[id] != nil || queued[id] {
+			continue
+		}
+
+		var cached *entity.ObjectType
+		if values[i] != nil && json.Unmarshal(values[i], &cached) == nil && cached != nil {
+			found[id] = cached
+			continue
+		}
+		queued[id] = true
+		misses = append(misses, id)
+	}
+	span.SetAttributes(attribute.Int("cache.misses", len(misses)))
+
+	if len(misses) > 0 {
+		fetched, err := s.repo.GetByIDs(ctx, misses)
+		if err != nil {
+			recordSpanError(span, err)
+			return nil, err
+		}
+		sortProperties(fetched...)
+
+		for _, objectType := range fetched {
+			found[objectType.ID] = objectType
+			_ = s.cache.Set(ctx, fmt.Sprintf("object_type:%s", objectType.ID.String()), objectType, s.cacheTTLs.ObjectType)
+		}
+	}
+
+	objectTypes := make([]*entity.ObjectType, 0, len(found))
+	for _, id := range ids {
+		if objectType := found[id]; objectType != nil {
+			objectTypes = append(objectTypes, objectType)
+			delete(found, id)
+		}
+	}
+
+	return objectTypes, nil
+}
+
+// GetByIDResolved retrieves an object type by ID with its inherited properties
+// merged into ResolvedProperties
+func (s *ObjectTypeService) GetByIDResolved(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	objectType, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.loadAncestors(ctx, objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *objectType
+	resolved.ResolvedProperties = objectType.ResolveProperties(ancestors)
+	return &resolved, nil
 }
 
 // GetByName retrieves an object type by name
@@ -175,13 +612,32 @@ func (s *ObjectTypeService) GetByName(ctx context.Context, name string) (*entity
 	if err != nil {
 		return nil, err
 	}
+	sortProperties(objectType)
 
 	// Cache the result
-	_ = s.cache.Set(ctx, cacheKey, objectType, 5*time.Minute)
+	_ = s.cache.Set(ctx, cacheKey, objectType, s.cacheTTLs.ObjectType)
 
 	return objectType, nil
 }
 
+// GetByNameIncludingDeleted retrieves the live object type named name, if
+// any, followed by soft-deleted ones that held the name. It bypasses the cache
+// and is meant for diagnosing name conflicts.
+func (s *ObjectTypeService) GetByNameIncludingDeleted(ctx context.Context, name string) ([]*entity.ObjectType, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.GetByNameIncludingDeleted")
+	defer span.End()
+
+	objectTypes, err := s.repo.GetByNameIncludingDeleted(ctx, name)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+	sortProperties(objectTypes...)
+
+	span.SetAttributes(attribute.Int("result.count", len(objectTypes)))
+	return objectTypes, nil
+}
+
 // UpdateObjectTypeInput represents input for updating an object type
 type UpdateObjectTypeInput struct {
 	DisplayName *string                        `json:"displayName,omitempty"`
@@ -190,10 +646,32 @@ type UpdateObjectTypeInput struct {
 	Tags        []string                       `json:"tags,omitempty"`
 	Properties  []PropertyInput                `json:"properties,omitempty"`
 	Metadata    map[string]interface{}         `json:"metadata,omitempty"`
+	ParentID    *uuid.UUID                     `json:"parentId,omitempty"`
+	// ExpectedVersion, when set, is the version the client last read; the
+	// update is rejected with ErrConcurrentUpdate if the stored version differs
+	ExpectedVersion *int                       `json:"expectedVersion,omitempty"`
+	// ChangeDescription optionally records why the change was made in the
+	// version history
+	ChangeDescription *string                  `json:"changeDescription,omitempty"`
 }
 
+// ErrConcurrentUpdate indicates the object type was modified by another writer
+// since the caller read it
+var ErrConcurrentUpdate = fmt.Errorf("object type was modified concurrently: %w", repository.ErrOptimisticLock)
+
+// MaxChangeDescriptionLength bounds the change description of an update
+const MaxChangeDescriptionLength = 1000
+
+// ErrChangeDescriptionTooLong indicates a change description above
+// MaxChangeDescriptionLength
+var ErrChangeDescriptionTooLong = fmt.Errorf("%w: change description must be at most %d characters", repository.ErrInvalidInput, MaxChangeDescriptionLength)
+
 // UpdateObjectType updates an existing object type
 func (s *ObjectTypeService) UpdateObjectType(ctx context.Context, id uuid.UUID, input UpdateObjectTypeInput, userID string) (*entity.ObjectType, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.UpdateObjectType",
+		trace.WithAttributes(attribute.String("object_type.id", id.String())))
+	defer span.End()
+
 	s.logger.Info("Updating object type", zap.String("id", id.String()), zap.String("user", userID))
 
 	// Get existing object type
@@ -202,145 +680,965 @@ func (s *ObjectTypeService) UpdateObjectType(ctx context.Context, id uuid.UUID,
 		return nil, err
 	}
 
-	// Apply updates
-	if input.DisplayName != nil {
-		objectType.DisplayName = *input.DisplayName
-	}
-	if input.Description != nil {
-		objectType.Description = input.Description
-	}
-	if input.Category != nil {
-		objectType.Category = input.Category
-	}
-	if input.Tags != nil {
-		objectType.Tags = input.Tags
-	}
-	if input.Properties != nil {
-		// Convert property inputs
-		properties := make([]entity.Property, len(input.Properties))
-		for i, propInput := range input.Properties {
-			properties[i] = entity.Property{
-				ID:           uuid.New(),
-				Name:         propInput.Name,
-				DisplayName:  propInput.DisplayName,
-				DataType:     propInput.DataType,
-				Required:     propInput.Required,
-				Unique:       propInput.Unique,
-				Indexed:      propInput.Indexed,
-				DefaultValue: propInput.DefaultValue,
-				Description:  propInput.Description,
-				Validators:   propInput.Validators,
-				Metadata:     propInput.Metadata,
-			}
-		}
-		objectType.Properties = properties
-	}
-	if input.Metadata != nil {
-		objectType.Metadata = input.Metadata
-	}
-
-	// Update metadata
-	objectType.IncrementVersion()
-	objectType.SetUpdatedBy(userID)
+	// Keep the old properties to report property-level changes
+	oldProperties := objectType.Properties
+	before := objectType.Copy()
 
-	// Validate
-	if err := objectType.Validate(); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+	changeDescription, err := s.applyUpdate(ctx, objectType, input, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Save to repository
-	if err := s.repo.Update(ctx, objectType); err != nil {
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityObjectType, id, entity.AuditActionUpdate, userID, before, objectType)
+	if err := s.repo.Update(auditCtx, objectType, changeDescription); err != nil {
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return nil, ErrConcurrentUpdate
+		}
+		recordSpanError(span, err)
 		s.logger.Error("Failed to update object type", zap.Error(err))
 		return nil, fmt.Errorf("failed to update object type: %w", err)
 	}
 
 	// Invalidate cache
-	s.invalidateCache(ctx, objectType.ID)
+	s.invalidateCache(ctx, objectType)
 
 	// Publish event
 	event := messaging.Event{
-		ID:        uuid.New().String(),
-		Type:      messaging.EventObjectTypeUpdated,
-		EntityID:  objectType.ID.String(),
-		Actor:     userID,
-		Timestamp: time.Now(),
-		Data:      objectType,
+		ID:            uuid.New().String(),
+		Type:          messaging.EventObjectTypeUpdated,
+		EntityID:      objectType.ID.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          objectType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
 	}
 
 	if err := s.publisher.Publish(ctx, event); err != nil {
 		s.logger.Error("Failed to publish event", zap.Error(err))
 	}
+	if input.Properties != nil {
+		s.publishPropertyEvents(ctx, objectType, oldProperties, userID)
+	}
+
+	// Property flags may have changed
+	s.ensureIndexes(ctx, objectType.ID, userID)
 
+	s.metrics.ObjectTypeUpdated.Inc()
 	s.logger.Info("Object type updated successfully", zap.String("id", objectType.ID.String()))
 	return objectType, nil
 }
 
-// DeleteObjectType soft deletes an object type
-func (s *ObjectTypeService) DeleteObjectType(ctx context.Context, id uuid.UUID, userID string) error {
-	s.logger.Info("Deleting object type", zap.String("id", id.String()), zap.String("user", userID))
+// DryRunUpdate runs the checks of UpdateObjectType and returns the object
+// type it would save, with the compatibility of the changes, without saving
+// it or publishing an event
+func (s *ObjectTypeService) DryRunUpdate(ctx context.Context, id uuid.UUID, input UpdateObjectTypeInput, userID string) (*DryRunResult, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.DryRunUpdate",
+		trace.WithAttributes(attribute.String("object_type.id", id.String())))
+	defer span.End()
 
-	// Check if object type exists
-	objectType, err := s.repo.GetByID(ctx, id)
+	current, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// TODO: Check for dependencies (e.g., instances, link types)
-
-	// Soft delete
-	if err := s.repo.Delete(ctx, id); err != nil {
-		s.logger.Error("Failed to delete object type", zap.Error(err))
-		return fmt.Errorf("failed to delete object type: %w", err)
+	objectType := current.Copy()
+	if _, err := s.applyUpdate(ctx, objectType, input, userID); err != nil {
+		return nil, err
 	}
+	sortProperties(objectType)
 
-	// Invalidate cache
-	s.invalidateCache(ctx, id)
+	return &DryRunResult{
+		ObjectType:    objectType,
+		Compatibility: compatibilityReport(current, objectType),
+	}, nil
+}
 
-	// Publish event
-	event := messaging.Event{
-		ID:        uuid.New().String(),
-		Type:      messaging.EventObjectTypeDeleted,
-		EntityID:  id.String(),
-		Actor:     userID,
-		Timestamp: time.Now(),
-		Data: map[string]interface{}{
-			"objectTypeId": id.String(),
-			"name":        objectType.Name,
-		},
+// applyUpdate applies input to objectType, bumps its version and runs every
+// check made before it is saved. It returns the trimmed change description.
+func (s *ObjectTypeService) applyUpdate(ctx context.Context, objectType *entity.ObjectType, input UpdateObjectTypeInput, userID string) (string, error) {
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != objectType.Version {
+		return "", ErrConcurrentUpdate
 	}
 
-	if err := s.publisher.Publish(ctx, event); err != nil {
-		s.logger.Error("Failed to publish event", zap.Error(err))
+	// Apply updates
+	if input.DisplayName != nil {
+		objectType.DisplayName = *input.DisplayName
+	}
+	if input.Description != nil {
+		objectType.Description = input.Description
+	}
+	if input.Category != nil {
+		objectType.Category = input.Category
+	}
+	if input.Tags != nil {
+		objectType.Tags = input.Tags
+	}
+	if input.Properties != nil {
+		objectType.Properties = buildProperties(input.Properties)
+	}
+	if input.Metadata != nil {
+		objectType.Metadata = input.Metadata
+	}
+	if input.ParentID != nil {
+		objectType.ParentID = input.ParentID
+	}
+
+	// Update metadata
+	objectType.IncrementVersion()
+	objectType.SetUpdatedBy(userID)
+
+	if err := s.validateUpdated(ctx, objectType); err != nil {
+		return "", err
+	}
+
+	changeDescription := ""
+	if input.ChangeDescription != nil {
+		changeDescription = strings.TrimSpace(*input.ChangeDescription)
+	}
+	if utf8.RuneCountInString(changeDescription) > MaxChangeDescriptionLength {
+		return "", ErrChangeDescriptionTooLong
+	}
+
+	return changeDescription, nil
+}
+
+// validateUpdated runs the checks an updated object type must pass before it
+// is saved: its own rules, its parent chain and its references
+func (s *ObjectTypeService) validateUpdated(ctx context.Context, objectType *entity.ObjectType) error {
+	if err := objectType.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", entity.ErrValidationFailed, err)
+	}
+	if err := s.validateInheritance(ctx, objectType); err != nil {
+		return err
+	}
+	return s.validateReferences(ctx, objectType)
+}
+
+// publishPropertyEvents publishes one event per property added, updated or
+// removed between oldProperties and the object type's current properties.
+// Each carries the property name and its definition before and after.
+func (s *ObjectTypeService) publishPropertyEvents(ctx context.Context, objectType *entity.ObjectType, oldProperties []entity.Property, userID string) {
+	changes := repository.CompareProperties(oldProperties, objectType.Properties)
+	if len(changes) == 0 {
+		return
+	}
+
+	before := make(map[string]entity.Property, len(oldProperties))
+	for _, p := range oldProperties {
+		before[p.Name] = p
+	}
+	after := make(map[string]entity.Property, len(objectType.Properties))
+	for _, p := range objectType.Properties {
+		after[p.Name] = p
+	}
+
+	// Attribute changes of one property collapse into a single event
+	var events []messaging.Event
+	seen := make(map[string]bool)
+	for _, change := range changes {
+		name := strings.SplitN(strings.TrimPrefix(change.Field, "properties."), ".", 2)[0]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		eventType := messaging.EventPropertyUpdated
+		var oldValue, newValue interface{}
+		switch change.Type {
+		case repository.ChangeTypeAdded:
+			eventType = messaging.EventPropertyAdded
+			newValue = after[name]
+		case repository.ChangeTypeRemoved:
+			eventType = messaging.EventPropertyRemoved
+			oldValue = before[name]
+		default:
+			oldValue, newValue = before[name], after[name]
+		}
+
+		events = append(events, messaging.Event{
+			ID:        uuid.New().String(),
+			Type:      eventType,
+			EntityID:  objectType.ID.String(),
+			Actor:     userID,
+			Timestamp: time.Now(),
+			Data: map[string]interface{}{
+				"objectTypeId": objectType.ID.String(),
+				"version":      objectType.Version,
+				"property":     name,
+				"before":       oldValue,
+				"after":        newValue,
+			},
+			CorrelationID: messaging.CorrelationIDFromContext(ctx),
+			TenantID:      repository.TenantFromContext(ctx),
+		})
+	}
+
+	if err := s.publisher.PublishBatch(ctx, events); err != nil {
+		s.logger.Error("Failed to publish property events", zap.Error(err))
+	}
+}
+
+// ReorderProperties sets the display order of an object type's own properties
+// to the order of names, which must list each of them exactly once. The change
+// is saved as a new version, so a concurrent update fails with
+// ErrConcurrentUpdate instead of being overwritten.
+func (s *ObjectTypeService) ReorderProperties(ctx context.Context, id uuid.UUID, names []string, userID string) (*entity.ObjectType, error) {
+	s.logger.Info("Reordering object type properties", zap.String("id", id.String()), zap.String("user", userID))
+
+	objectType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := objectType.Copy()
+	if err := objectType.ReorderProperties(names); err != nil {
+		return nil, err
+	}
+
+	objectType.IncrementVersion()
+	objectType.SetUpdatedBy(userID)
+
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityObjectType, id, entity.AuditActionUpdate, userID, before, objectType)
+	if err := s.repo.Update(auditCtx, objectType, "reordered properties"); err != nil {
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return nil, ErrConcurrentUpdate
+		}
+		s.logger.Error("Failed to reorder object type properties", zap.Error(err))
+		return nil, fmt.Errorf("failed to reorder properties: %w", err)
+	}
+
+	// Invalidate cache
+	s.invalidateCache(ctx, objectType)
+
+	// Publish event
+	event := messaging.Event{
+		ID:            uuid.New().String(),
+		Type:          messaging.EventObjectTypeUpdated,
+		EntityID:      objectType.ID.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          objectType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	s.metrics.ObjectTypeUpdated.Inc()
+	return objectType, nil
+}
+
+// AddTag adds tag to an object type. Adding a tag it already has is a no-op
+// and does not create a new version.
+func (s *ObjectTypeService) AddTag(ctx context.Context, id uuid.UUID, tag, userID string) (*entity.ObjectType, error) {
+	return s.changeTags(ctx, id, userID, "added tag "+tag, addTag(tag))
+}
+
+// RemoveTag removes tag from an object type. Removing a tag it does not have
+// is a no-op and does not create a new version.
+func (s *ObjectTypeService) RemoveTag(ctx context.Context, id uuid.UUID, tag, userID string) (*entity.ObjectType, error) {
+	return s.changeTags(ctx, id, userID, "removed tag "+tag, removeTag(tag))
+}
+
+// addTag returns a tag change adding tag, reporting whether it was missing
+func addTag(tag string) func(*entity.ObjectType) bool {
+	return func(ot *entity.ObjectType) bool {
+		if ot.HasTag(tag) {
+			return false
+		}
+		ot.AddTag(tag)
+		return true
+	}
+}
+
+// removeTag returns a tag change removing tag, reporting whether it was present
+func removeTag(tag string) func(*entity.ObjectType) bool {
+	return func(ot *entity.ObjectType) bool {
+		if !ot.HasTag(tag) {
+			return false
+		}
+		ot.RemoveTag(tag)
+		return true
+	}
+}
+
+// BulkAddTag adds tag to every object type in ids in a single transaction.
+// Types that already have the tag are left unchanged and get no new version.
+func (s *ObjectTypeService) BulkAddTag(ctx context.Context, ids []uuid.UUID, tag, userID string) error {
+	return s.bulkChangeTags(ctx, ids, tag, userID, "added tag "+tag, addTag(tag))
+}
+
+// BulkRemoveTag removes tag from every object type in ids in a single
+// transaction. Types without the tag are left unchanged and get no new version.
+func (s *ObjectTypeService) BulkRemoveTag(ctx context.Context, ids []uuid.UUID, tag, userID string) error {
+	return s.bulkChangeTags(ctx, ids, tag, userID, "removed tag "+tag, removeTag(tag))
+}
+
+// bulkChangeTags applies change to each object type in ids and saves the
+// modified ones as new versions in one transaction, so either all of them
+// change or none does
+func (s *ObjectTypeService) bulkChangeTags(ctx context.Context, ids []uuid.UUID, tag, userID, description string, change func(*entity.ObjectType) bool) error {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.bulkChangeTags",
+		trace.WithAttributes(attribute.Int("batch.size", len(ids))))
+	defer span.End()
+
+	s.logger.Info("Bulk changing object type tags",
+		zap.Int("count", len(ids)),
+		zap.String("change", description),
+		zap.String("user", userID))
+
+	if tag == "" {
+		return fmt.Errorf("%w: tag is required", repository.ErrInvalidInput)
+	}
+	if len(ids) == 0 || len(ids) > MaxBatchSize {
+		return fmt.Errorf("%w: bulk tag change must name between 1 and %d object types", repository.ErrInvalidInput, MaxBatchSize)
+	}
+
+	objectTypes, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load object types: %w", err)
+	}
+	found := make(map[uuid.UUID]bool, len(objectTypes))
+	for _, objectType := range objectTypes {
+		found[objectType.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return fmt.Errorf("%w: %s", entity.ErrObjectTypeNotFound, id.String())
+		}
+	}
+
+	var changed []*entity.ObjectType
+	for _, objectType := range objectTypes {
+		if !change(objectType) {
+			continue
+		}
+		objectType.IncrementVersion()
+		objectType.SetUpdatedBy(userID)
+		changed = append(changed, objectType)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if err := s.repo.BatchUpdate(withAuditActor(ctx, userID), changed, description); err != nil {
+		recordSpanError(span, err)
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return ErrConcurrentUpdate
+		}
+		if errors.Is(err, entity.ErrObjectTypeNotFound) {
+			return err
+		}
+		s.logger.Error("Failed to bulk change object type tags", zap.Error(err))
+		return fmt.Errorf("failed to change tags: %w", err)
+	}
+
+	events := make([]messaging.Event, len(changed))
+	for i, objectType := range changed {
+		// Invalidate cache
+		s.invalidateCache(ctx, objectType)
+
+		events[i] = messaging.Event{
+			ID:            uuid.New().String(),
+			Type:          messaging.EventObjectTypeUpdated,
+			EntityID:      objectType.ID.String(),
+			Actor:         userID,
+			Timestamp:     time.Now(),
+			Data:          objectType,
+			CorrelationID: messaging.CorrelationIDFromContext(ctx),
+			TenantID:      repository.TenantFromContext(ctx),
+		}
+	}
+
+	if err := s.publisher.PublishBatch(ctx, events); err != nil {
+		s.logger.Error("Failed to publish events", zap.Error(err))
+	}
+
+	s.metrics.ObjectTypeUpdated.Add(float64(len(changed)))
+	s.logger.Info("Object type tags changed",
+		zap.Int("changed", len(changed)),
+		zap.Int("unchanged", len(objectTypes)-len(changed)))
+	return nil
+}
+
+// changeTags applies change to the stored object type and saves it as a new
+// version when change reports a modification. Concurrent writers fail with
+// ErrConcurrentUpdate instead of overwriting each other's tags.
+func (s *ObjectTypeService) changeTags(ctx context.Context, id uuid.UUID, userID, description string, change func(*entity.ObjectType) bool) (*entity.ObjectType, error) {
+	s.logger.Info("Changing object type tags",
+		zap.String("id", id.String()),
+		zap.String("change", description),
+		zap.String("user", userID))
+
+	objectType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := objectType.Copy()
+	if !change(objectType) {
+		return objectType, nil
+	}
+
+	objectType.IncrementVersion()
+	objectType.SetUpdatedBy(userID)
+
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityObjectType, id, entity.AuditActionUpdate, userID, before, objectType)
+	if err := s.repo.Update(auditCtx, objectType, description); err != nil {
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return nil, ErrConcurrentUpdate
+		}
+		s.logger.Error("Failed to change object type tags", zap.Error(err))
+		return nil, fmt.Errorf("failed to change tags: %w", err)
+	}
+
+	// Invalidate cache
+	s.invalidateCache(ctx, objectType)
+
+	// Publish event
+	event := messaging.Event{
+		ID:            uuid.New().String(),
+		Type:          messaging.EventObjectTypeUpdated,
+		EntityID:      objectType.ID.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          objectType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	s.metrics.ObjectTypeUpdated.Inc()
+	return objectType, nil
+}
+
+// DeleteObjectType soft deletes an object type. Deletion is refused with
+// ErrObjectTypeInUse while link types reference the object type, unless force
+// is set, in which case the dependent link types are soft deleted with it.
+// Link types with CascadeDelete are soft deleted with it even without force,
+// and link types with PreventDelete refuse deletion with
+// ErrObjectTypeProtected even when forced.
+func (s *ObjectTypeService) DeleteObjectType(ctx context.Context, id uuid.UUID, userID string, force bool) error {
+	s.logger.Info("Deleting object type",
+		zap.String("id", id.String()),
+		zap.String("user", userID),
+		zap.Bool("force", force))
+
+	// Check if object type exists
+	objectType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Check for link types depending on this object type
+	dependents, err := s.findDependentLinkTypes(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check dependencies: %w", err)
+	}
+
+	if err := checkDependents(dependents, force, nil); err != nil {
+		return err
+	}
+
+	// Soft delete
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityObjectType, id, entity.AuditActionDelete, userID, objectType, nil)
+	var deletedLinkTypeIDs []uuid.UUID
+	if len(dependents) > 0 {
+		deletedLinkTypeIDs, err = s.repo.DeleteCascade(auditCtx, id)
+	} else {
+		err = s.repo.Delete(auditCtx, id)
+	}
+	if err != nil {
+		s.logger.Error("Failed to delete object type", zap.Error(err))
+		return fmt.Errorf("failed to delete object type: %w", err)
+	}
+
+	// Invalidate cache
+	s.invalidateCache(ctx, objectType)
+	s.publishCascadedLinkTypeDeletes(ctx, deletedLinkTypeIDs, id, userID)
+
+	// Publish event
+	event := messaging.Event{
+		ID:        uuid.New().String(),
+		Type:      messaging.EventObjectTypeDeleted,
+		EntityID:  id.String(),
+		Actor:     userID,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"objectTypeId": id.String(),
+			"name":         objectType.Name,
+		},
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	s.metrics.ObjectTypeDeleted.Inc()
+	s.logger.Info("Object type deleted successfully", zap.String("id", id.String()))
+	return nil
+}
+
+// BatchDeleteObjectTypes soft deletes object types in a single transaction.
+// Each ID gets the dependency checks of DeleteObjectType without force,
+// except that link types between object types deleted together do not
+// block. When any item fails, nothing is deleted and ErrBatchFailed is
+// returned along with per-item results. With continueOnError the remaining
+// items are still deleted and failures are only reported in the results;
+// ErrBatchFailed is then returned only when no item could be deleted.
+func (s *ObjectTypeService) BatchDeleteObjectTypes(ctx context.Context, ids []uuid.UUID, userID string, continueOnError bool) ([]BatchResult, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.BatchDeleteObjectTypes",
+		trace.WithAttributes(attribute.Int("batch.size", len(ids))))
+	defer span.End()
+
+	s.logger.Info("Batch deleting object types",
+		zap.Int("count", len(ids)),
+		zap.String("user", userID),
+		zap.Bool("continue_on_error", continueOnError))
+
+	if len(ids) == 0 || len(ids) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: batch must contain between 1 and %d items", entity.ErrBatchFailed, MaxBatchSize)
+	}
+
+	results := make([]BatchResult, len(ids))
+	deleting := make(map[uuid.UUID]bool, len(ids))
+	for i := range ids {
+		id := ids[i]
+		results[i] = BatchResult{Index: i, ID: &id}
+		deleting[id] = true
+	}
+
+	// Check every item before deleting anything
+	positions := make(map[uuid.UUID]int, len(ids))
+	objectTypes := make([]*entity.ObjectType, len(ids))
+	failed := false
+	for i, id := range ids {
+		if other, ok := positions[id]; ok {
+			results[i].Error = fmt.Sprintf("duplicates item %d", other)
+			failed = true
+			continue
+		}
+		positions[id] = i
+
+		objectType, err := s.repo.GetByID(ctx, id)
+		if err == nil {
+			var dependents []*entity.LinkType
+			dependents, err = s.findDependentLinkTypes(ctx, id)
+			if err == nil {
+				err = checkDependents(dependents, false, deleting)
+			}
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			failed = true
+			continue
+		}
+		objectTypes[i] = objectType
+	}
+
+	if failed && !continueOnError {
+		return results, fmt.Errorf("%w: dependency check failed", entity.ErrBatchFailed)
+	}
+
+	var batch []uuid.UUID
+	var batchIndexes []int
+	for i, objectType := range objectTypes {
+		if objectType != nil {
+			batch = append(batch, objectType.ID)
+			batchIndexes = append(batchIndexes, i)
+		}
+	}
+	if len(batch) == 0 {
+		return results, fmt.Errorf("%w: no object type could be deleted", entity.ErrBatchFailed)
+	}
+
+	deletedLinkTypeIDs, err := s.repo.BatchDelete(withAuditActor(ctx, userID), batch)
+	if err != nil {
+		recordSpanError(span, err)
+		var itemErr *repository.BatchItemError
+		if errors.As(err, &itemErr) && itemErr.Index >= 0 && itemErr.Index < len(batchIndexes) {
+			results[batchIndexes[itemErr.Index]].Error = itemErr.Err.Error()
+			return results, fmt.Errorf("%w: %v", entity.ErrBatchFailed, err)
+		}
+		s.logger.Error("Failed to batch delete object types", zap.Error(err))
+		return nil, fmt.Errorf("failed to batch delete object types: %w", err)
+	}
+
+	events := make([]messaging.Event, len(batch))
+	for j, id := range batch {
+		s.invalidateCache(ctx, objectTypes[batchIndexes[j]])
+		s.publishCascadedLinkTypeDeletes(ctx, deletedLinkTypeIDs[j], id, userID)
+		events[j] = messaging.Event{
+			ID:        uuid.New().String(),
+			Type:      messaging.EventObjectTypeDeleted,
+			EntityID:  id.String(),
+			Actor:     userID,
+			Timestamp: time.Now(),
+			Data: map[string]interface{}{
+				"objectTypeId": id.String(),
+				"name":         objectTypes[batchIndexes[j]].Name,
+			},
+			CorrelationID: messaging.CorrelationIDFromContext(ctx),
+			TenantID:      repository.TenantFromContext(ctx),
+		}
+	}
+
+	if err := s.publisher.PublishBatch(ctx, events); err != nil {
+		s.logger.Error("Failed to publish events", zap.Error(err))
+	}
+
+	s.metrics.ObjectTypeDeleted.Add(float64(len(batch)))
+	s.logger.Info("Object types batch deleted successfully",
+		zap.Int("count", len(batch)),
+		zap.Int("failed", len(ids)-len(batch)))
+	return results, nil
+}
+
+// checkDependents enforces the delete constraints of the link types that
+// depend on an object type. Link types with PreventDelete always block.
+// Link types without a constraint block unless force is set or both of
+// their ends are in deleting.
+func checkDependents(dependents []*entity.LinkType, force bool, deleting map[uuid.UUID]bool) error {
+	var protecting, blocking []string
+	for _, linkType := range dependents {
+		switch {
+		case linkType.Constraints.PreventDelete:
+			protecting = append(protecting, linkType.Name)
+		case linkType.Constraints.CascadeDelete, force:
+		case deleting[linkType.SourceObjectTypeID] && deleting[linkType.TargetObjectTypeID]:
+		default:
+			blocking = append(blocking, linkType.Name)
+		}
+	}
+
+	if len(protecting) > 0 {
+		return entity.ErrObjectTypeProtectedBy(protecting)
+	}
+	if len(blocking) > 0 {
+		return entity.ErrObjectTypeInUseBy(blocking)
 	}
-
-	s.logger.Info("Object type deleted successfully", zap.String("id", id.String()))
 	return nil
 }
 
+// PurgeObjectType permanently removes a soft-deleted object type and its version
+// history. Active object types are refused with ErrObjectTypeNotDeleted.
+func (s *ObjectTypeService) PurgeObjectType(ctx context.Context, id uuid.UUID, userID string) error {
+	s.logger.Info("Purging object type", zap.String("id", id.String()), zap.String("user", userID))
+
+	if err := s.repo.Purge(withAuditActor(ctx, userID), id); err != nil {
+		if errors.Is(err, entity.ErrObjectTypeNotFound) || errors.Is(err, entity.ErrObjectTypeNotDeleted) {
+			return err
+		}
+		s.logger.Error("Failed to purge object type", zap.Error(err))
+		return fmt.Errorf("failed to purge object type: %w", err)
+	}
+
+	// Invalidate cache; the name was released when the object type was
+	// deleted, so only its ID can still be cached
+	s.invalidateCache(ctx, &entity.ObjectType{ID: id})
+
+	// Publish event
+	event := messaging.Event{
+		ID:        uuid.New().String(),
+		Type:      messaging.EventObjectTypePurged,
+		EntityID:  id.String(),
+		Actor:     userID,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"objectTypeId": id.String(),
+		},
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	s.logger.Info("Object type purged successfully", zap.String("id", id.String()))
+	return nil
+}
+
+// RestoreObjectType brings a soft-deleted object type back. Link types removed
+// along with it stay deleted. A live object type that has since taken the name
+// blocks the restore with ErrObjectTypeNameExists.
+func (s *ObjectTypeService) RestoreObjectType(ctx context.Context, id uuid.UUID, userID string) (*entity.ObjectType, error) {
+	s.logger.Info("Restoring object type", zap.String("id", id.String()), zap.String("user", userID))
+
+	if err := s.repo.Restore(withAuditActor(ctx, userID), id); err != nil {
+		switch err {
+		case entity.ErrObjectTypeNotFound, entity.ErrObjectTypeNotDeleted,
+			entity.ErrObjectTypeNameExists, entity.ErrParentDeleted:
+			return nil, err
+		}
+		s.logger.Error("Failed to restore object type", zap.Error(err))
+		return nil, fmt.Errorf("failed to restore object type: %w", err)
+	}
+
+	objectType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache, including a remembered miss from while it was deleted
+	s.invalidateCache(ctx, objectType)
+
+	// Publish event
+	event := messaging.Event{
+		ID:            uuid.New().String(),
+		Type:          messaging.EventObjectTypeRestored,
+		EntityID:      id.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          objectType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+
+	// Storage indexes were left in place by the soft delete; resync in case
+	s.ensureIndexes(ctx, id, userID)
+
+	s.logger.Info("Object type restored successfully", zap.String("id", id.String()))
+	return objectType, nil
+}
+
+// ListDeleted retrieves soft-deleted object types that are eligible for purging
+func (s *ObjectTypeService) ListDeleted(ctx context.Context, limit int) ([]*entity.ObjectType, error) {
+	objectTypes, err := s.repo.ListDeleted(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	sortProperties(objectTypes...)
+	return objectTypes, nil
+}
+
 // List retrieves a list of object types based on filter
 func (s *ObjectTypeService) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
-	return s.repo.List(ctx, filter)
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.List")
+	defer span.End()
+
+	objectTypes, err := s.repo.List(ctx, filter)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+	sortProperties(objectTypes...)
+
+	span.SetAttributes(attribute.Int("result.count", len(objectTypes)))
+	return objectTypes, nil
+}
+
+// Count counts object types matching the filter, ignoring pagination
+func (s *ObjectTypeService) Count(ctx context.Context, filter repository.ObjectTypeFilter) (int64, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.Count")
+	defer span.End()
+
+	count, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		recordSpanError(span, err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("result.count", count))
+	return count, nil
 }
 
-// Search searches for object types
-func (s *ObjectTypeService) Search(ctx context.Context, query string, limit int) ([]*entity.ObjectType, error) {
+// Search searches for object types. A non-positive limit falls back to
+// DefaultSearchLimit and larger limits are capped at MaxSearchLimit.
+func (s *ObjectTypeService) Search(ctx context.Context, query string, limit int, opts repository.SearchOptions) (*repository.SearchPage, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.Search",
+		trace.WithAttributes(attribute.String("search.query", query)))
+	defer span.End()
+
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	} else if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
 	// Try cache first
-	cacheKey := fmt.Sprintf("object_types:search:%s:%d", query, limit)
-	var cached []*entity.ObjectType
+	cacheKey := fmt.Sprintf("object_types:search:%s:%d:%s", query, limit, searchOptionsKey(opts))
+	var cached *repository.SearchPage
 	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && cached != nil {
+		s.metrics.ObserveCacheLookup("object_type_search", true)
 		return cached, nil
 	}
+	s.metrics.ObserveCacheLookup("object_type_search", false)
 
 	// Search in repository
-	results, err := s.repo.Search(ctx, query, limit)
+	page, err := s.repo.Search(ctx, query, limit, opts)
 	if err != nil {
+		recordSpanError(span, err)
 		return nil, err
 	}
+	sortProperties(page.Results...)
 
 	// Cache the results
-	_ = s.cache.Set(ctx, cacheKey, results, 2*time.Minute)
+	_ = s.cache.Set(ctx, cacheKey, page, s.cacheTTLs.Search)
 
-	return results, nil
+	return page, nil
+}
+
+// searchOptionsKey derives a cache key fragment from search options
+func searchOptionsKey(opts repository.SearchOptions) string {
+	data, _ := json.Marshal(opts)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+const (
+	// DefaultSearchLimit is used when Search is given a non-positive limit
+	DefaultSearchLimit = 10
+	// MaxSearchLimit caps the number of search results per page
+	MaxSearchLimit = 50
+	// MinSuggestPrefixLength is the shortest prefix SuggestNames accepts
+	MinSuggestPrefixLength = 2
+	// MaxSuggestLimit caps the number of name suggestions returned
+	MaxSuggestLimit = 20
+	// suggestCacheTTL is short since suggestions are requested per keystroke
+	suggestCacheTTL = 30 * time.Second
+)
+
+// ErrSuggestPrefixTooShort indicates a suggest prefix below MinSuggestPrefixLength
+var ErrSuggestPrefixTooShort = fmt.Errorf("%w: prefix must be at least %d characters", repository.ErrInvalidInput, MinSuggestPrefixLength)
+
+// SuggestNames returns object types whose name starts with prefix, ignoring
+// case, for autocomplete. The limit is capped at MaxSuggestLimit.
+func (s *ObjectTypeService) SuggestNames(ctx context.Context, prefix string, limit int) ([]repository.NameSuggestion, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if utf8.RuneCountInString(prefix) < MinSuggestPrefixLength {
+		return nil, ErrSuggestPrefixTooShort
+	}
+	if limit <= 0 || limit > MaxSuggestLimit {
+		limit = MaxSuggestLimit
+	}
+
+	// Try cache first
+	cacheKey := fmt.Sprintf("object_types:suggest:%s:%d", prefix, limit)
+	var cached []repository.NameSuggestion
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && cached != nil {
+		return cached, nil
+	}
+
+	suggestions, err := s.repo.SuggestNames(ctx, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, suggestions, suggestCacheTTL)
+
+	return suggestions, nil
+}
+
+// facetCacheTTL bounds how stale category and tag counts can be; writes
+// also clear them through invalidateCache
+const facetCacheTTL = time.Minute
+
+// ListCategories returns the categories in use with their object type counts
+func (s *ObjectTypeService) ListCategories(ctx context.Context) ([]repository.CategoryCount, error) {
+	cacheKey := "object_types:facets:categories"
+	var cached []repository.CategoryCount
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && cached != nil {
+		return cached, nil
+	}
+
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, categories, facetCacheTTL)
+
+	return categories, nil
+}
+
+// ListTags returns the tags in use with their object type counts
+func (s *ObjectTypeService) ListTags(ctx context.Context) ([]repository.TagCount, error) {
+	cacheKey := "object_types:facets:tags"
+	var cached []repository.TagCount
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && cached != nil {
+		return cached, nil
+	}
+
+	tags, err := s.repo.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, tags, facetCacheTTL)
+
+	return tags, nil
+}
+
+// ListVersions returns a page of an object type's version history, newest
+// first. The page size is clamped to repository.MaxVersionPageSize.
+func (s *ObjectTypeService) ListVersions(ctx context.Context, id uuid.UUID, filter repository.VersionFilter) ([]*repository.ObjectTypeVersion, error) {
+	if filter.PageSize <= 0 {
+		filter.PageSize = repository.DefaultVersionPageSize
+	}
+	if filter.PageSize > repository.MaxVersionPageSize {
+		filter.PageSize = repository.MaxVersionPageSize
+	}
+
+	versions, err := s.repo.ListVersions(ctx, id, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty first page may mean the object type does not exist
+	if len(versions) == 0 && filter.BeforeVersion == 0 {
+		if _, err := s.repo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
+}
+
+// GetResolvedVersion returns the given version of an object type with
+// ResolvedProperties set to its own and inherited properties, including their
+// defaults, as they were when that version was written.
+//
+// Ancestors are aligned by time: the version's UpdatedAt is the reference
+// time, and each ancestor in the chain, starting from the version's ParentID,
+// is taken at its latest version recorded at or before that time. Every
+// ancestor uses the same reference time, so changes a parent made after the
+// child version do not show up. An ancestor whose versions all postdate the
+// reference time is taken at its earliest version, and one with no recorded
+// versions at its current definition.
+func (s *ObjectTypeService) GetResolvedVersion(ctx context.Context, id uuid.UUID, version int) (*entity.ObjectType, error) {
+	objectType, err := s.repo.GetVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	at := objectType.UpdatedAt
+	ancestors, err := s.walkAncestors(objectType, func(parentID uuid.UUID) (*entity.ObjectType, error) {
+		parent, err := s.repo.GetVersionAt(ctx, parentID, at)
+		if errors.Is(err, entity.ErrVersionNotFound) {
+			return s.repo.GetByID(ctx, parentID)
+		}
+		return parent, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objectType.ResolvedProperties = objectType.ResolveProperties(ancestors)
+	return objectType, nil
 }
 
 // CompareVersions compares two versions of an object type
@@ -348,8 +1646,233 @@ func (s *ObjectTypeService) CompareVersions(ctx context.Context, id uuid.UUID, v
 	return s.repo.CompareVersions(ctx, id, v1, v2)
 }
 
-// invalidateCache invalidates cache entries for an object type
-func (s *ObjectTypeService) invalidateCache(ctx context.Context, id uuid.UUID) {
-	_ = s.cache.Delete(ctx, fmt.Sprintf("object_type:%s", id.String()))
+// CompareVersionRange returns the diff of each consecutive pair of versions
+// from fromVersion to toVersion
+func (s *ObjectTypeService) CompareVersionRange(ctx context.Context, id uuid.UUID, fromVersion, toVersion int) ([]*repository.VersionDiff, error) {
+	return s.repo.CompareVersionRange(ctx, id, fromVersion, toVersion)
+}
+
+// RestoreVersion reverts an object type to the definition captured in a prior version.
+// The restore is recorded as a new version rather than rewriting history, and
+// passes the checks of an update: a snapshot whose parent or referenced object
+// types no longer fit is rejected.
+func (s *ObjectTypeService) RestoreVersion(ctx context.Context, id uuid.UUID, version int, userID string) (*entity.ObjectType, error) {
+	s.logger.Info("Restoring object type version",
+		zap.String("id", id.String()),
+		zap.Int("version", version),
+		zap.String("user", userID))
+
+	objectType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := s.repo.GetVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the snapshot's definition on top of the current identity
+	oldProperties := objectType.Properties
+	before := objectType.Copy()
+	objectType.DisplayName = snapshot.DisplayName
+	objectType.Description = snapshot.Description
+	objectType.Category = snapshot.Category
+	objectType.Tags = snapshot.Tags
+	objectType.Properties = snapshot.Properties
+	objectType.BaseDatasets = snapshot.BaseDatasets
+	objectType.Metadata = snapshot.Metadata
+	objectType.ParentID = snapshot.ParentID
+	objectType.IncrementVersion()
+	objectType.SetUpdatedBy(userID)
+
+	if err := s.validateUpdated(ctx, objectType); err != nil {
+		return nil, err
+	}
+
+	auditCtx := withAuditEntry(ctx, entity.AuditEntityObjectType, id, entity.AuditActionUpdate, userID, before, objectType)
+	if err := s.repo.Update(auditCtx, objectType, fmt.Sprintf("restored from v%d", version)); err != nil {
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return nil, ErrConcurrentUpdate
+		}
+		s.logger.Error("Failed to restore object type version", zap.Error(err))
+		return nil, fmt.Errorf("failed to restore object type version: %w", err)
+	}
+
+	// Invalidate cache
+	s.invalidateCache(ctx, objectType)
+
+	// Publish event
+	event := messaging.Event{
+		ID:            uuid.New().String(),
+		Type:          messaging.EventObjectTypeUpdated,
+		EntityID:      objectType.ID.String(),
+		Actor:         userID,
+		Timestamp:     time.Now(),
+		Data:          objectType,
+		CorrelationID: messaging.CorrelationIDFromContext(ctx),
+		TenantID:      repository.TenantFromContext(ctx),
+		Metadata: map[string]interface{}{
+			"restoredFromVersion": version,
+		},
+	}
+
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event", zap.Error(err))
+	}
+	s.publishPropertyEvents(ctx, objectType, oldProperties, userID)
+
+	// The restored properties may carry different index flags
+	s.ensureIndexes(ctx, objectType.ID, userID)
+
+	s.metrics.ObjectTypeUpdated.Inc()
+	s.logger.Info("Object type version restored successfully",
+		zap.String("id", objectType.ID.String()),
+		zap.Int("version", objectType.Version))
+	return objectType, nil
+}
+
+// findDependentLinkTypes returns link types using the object type as source or target
+func (s *ObjectTypeService) findDependentLinkTypes(ctx context.Context, id uuid.UUID) ([]*entity.LinkType, error) {
+	outgoing, err := s.linkTypeRepo.GetBySourceObjectType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	incoming, err := s.linkTypeRepo.GetByTargetObjectType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Self-referencing link types appear in both lists
+	seen := make(map[uuid.UUID]bool)
+	var dependents []*entity.LinkType
+	for _, linkType := range append(outgoing, incoming...) {
+		if !seen[linkType.ID] {
+			seen[linkType.ID] = true
+			dependents = append(dependents, linkType)
+		}
+	}
+
+	return dependents, nil
+}
+
+// publishCascadedLinkTypeDeletes invalidates and announces link types removed by a cascading delete
+func (s *ObjectTypeService) publishCascadedLinkTypeDeletes(ctx context.Context, linkTypeIDs []uuid.UUID, objectTypeID uuid.UUID, userID string) {
+	if len(linkTypeIDs) == 0 {
+		return
+	}
+	s.metrics.LinkTypeDeleted.Add(float64(len(linkTypeIDs)))
+
+	events := make([]messaging.Event, len(linkTypeIDs))
+	for i, linkTypeID := range linkTypeIDs {
+		_ = s.cache.Delete(ctx, fmt.Sprintf("link_type:%s", linkTypeID.String()))
+		events[i] = messaging.Event{
+			ID:        uuid.New().String(),
+			Type:      messaging.EventLinkTypeDeleted,
+			EntityID:  linkTypeID.String(),
+			Actor:     userID,
+			Timestamp: time.Now(),
+			Data: map[string]interface{}{
+				"linkTypeId":               linkTypeID.String(),
+				"cascadedFromObjectTypeId": objectTypeID.String(),
+			},
+			CorrelationID: messaging.CorrelationIDFromContext(ctx),
+			TenantID:      repository.TenantFromContext(ctx),
+		}
+	}
+	_ = s.cache.InvalidatePattern(ctx, "link_types:*")
+
+	if err := s.publisher.PublishBatch(ctx, events); err != nil {
+		s.logger.Error("Failed to publish events", zap.Error(err))
+	}
+}
+
+// invalidateCache invalidates cache entries for an object type, including
+// the one GetByName keeps under its name
+func (s *ObjectTypeService) invalidateCache(ctx context.Context, objectType *entity.ObjectType) {
+	_ = s.cache.Delete(ctx, fmt.Sprintf("object_type:%s", objectType.ID.String()))
+	_ = s.cache.Delete(ctx, fmt.Sprintf("object_type:missing:%s", objectType.ID.String()))
+	if objectType.Name != "" {
+		_ = s.cache.Delete(ctx, fmt.Sprintf("object_type:name:%s", objectType.Name))
+	}
 	_ = s.cache.InvalidatePattern(ctx, "object_types:*")
-}
\ No newline at end of file
+}
+
+// maxInheritanceDepth bounds how far the parent chain is followed
+const maxInheritanceDepth = 32
+
+// loadAncestors walks the parent chain, returning ancestors from the immediate parent up to the root
+func (s *ObjectTypeService) loadAncestors(ctx context.Context, objectType *entity.ObjectType) ([]*entity.ObjectType, error) {
+	return s.walkAncestors(objectType, func(id uuid.UUID) (*entity.ObjectType, error) {
+		return s.repo.GetByID(ctx, id)
+	})
+}
+
+// walkAncestors walks the parent chain like loadAncestors, loading each
+// parent with load
+func (s *ObjectTypeService) walkAncestors(objectType *entity.ObjectType, load func(id uuid.UUID) (*entity.ObjectType, error)) ([]*entity.ObjectType, error) {
+	var ancestors []*entity.ObjectType
+	visited := map[uuid.UUID]bool{objectType.ID: true}
+
+	for parentID := objectType.ParentID; parentID != nil; {
+		if visited[*parentID] {
+			return nil, entity.ErrInheritanceCycle
+		}
+		if len(ancestors) >= maxInheritanceDepth {
+			return nil, fmt.Errorf("%w: inheritance deeper than %d levels", entity.ErrInvalidInheritance, maxInheritanceDepth)
+		}
+
+		parent, err := load(*parentID)
+		if err == entity.ErrObjectTypeNotFound {
+			return nil, fmt.Errorf("%w: parent object type %s not found", entity.ErrInvalidInheritance, parentID.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load parent object type %s: %w", parentID.String(), err)
+		}
+
+		visited[parent.ID] = true
+		ancestors = append(ancestors, parent)
+		parentID = parent.ParentID
+	}
+
+	return ancestors, nil
+}
+
+// validateReferences checks that the object types referenced by REFERENCE
+// properties exist and are not deleted. A property may reference the object
+// type it belongs to.
+func (s *ObjectTypeService) validateReferences(ctx context.Context, objectType *entity.ObjectType) error {
+	for _, prop := range objectType.Properties {
+		targetID := prop.ReferencedObjectTypeID
+		if targetID == nil || *targetID == objectType.ID {
+			continue
+		}
+
+		if _, err := s.repo.GetByID(ctx, *targetID); err != nil {
+			if errors.Is(err, entity.ErrObjectTypeNotFound) {
+				return fmt.Errorf("%w: %w", entity.ErrValidationFailed, entity.ErrReferencedTypeMissingFor(prop.Name, *targetID))
+			}
+			return fmt.Errorf("failed to check referenced object type: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateInheritance rejects cycles and property data type conflicts across the parent chain
+func (s *ObjectTypeService) validateInheritance(ctx context.Context, objectType *entity.ObjectType) error {
+	if objectType.ParentID == nil {
+		return nil
+	}
+
+	ancestors, err := s.loadAncestors(ctx, objectType)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := objectType.ValidateInheritance(ancestors); err != nil {
+		return fmt.Errorf("%w: %w", entity.ErrValidationFailed, err)
+	}
+
+	return nil
+}
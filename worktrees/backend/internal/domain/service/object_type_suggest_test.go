@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// suggestService returns a service whose repository holds Customer,
+// CustomerAccount and Order
+func suggestService() *ObjectTypeService {
+	repo := newFakeObjectTypeRepo()
+	for _, name := range []string{"Customer", "CustomerAccount", "Order"} {
+		id := uuid.New()
+		repo.objectTypes[id] = &entity.ObjectType{ID: id, Name: name, DisplayName: name}
+	}
+	return newTestObjectTypeService(repo)
+}
+
+func TestSuggestNamesIgnoresCaseOfPrefix(t *testing.T) {
+	// Arrange
+	svc := suggestService()
+
+	// Act
+	suggestions, _ := svc.SuggestNames(context.Background(), "CUS", 10)
+
+	// Assert
+	if len(suggestions) != 2 {
+		t.Errorf("suggestions = %v, want Customer and CustomerAccount", suggestions)
+	}
+}
+
+func TestSuggestNamesMatchesLowercasePrefix(t *testing.T) {
+	// Arrange
+	svc := suggestService()
+
+	// Act
+	suggestions, _ := svc.SuggestNames(context.Background(), "ord", 10)
+
+	// Assert
+	if len(suggestions) != 1 || suggestions[0].Name != "Order" {
+		t.Errorf("suggestions = %v, want Order", suggestions)
+	}
+}
+
+func TestSuggestNamesCapsLimit(t *testing.T) {
+	// Arrange
+	repo := newFakeObjectTypeRepo()
+	for i := 0; i < MaxSuggestLimit+10; i++ {
+		id := uuid.New()
+		repo.objectTypes[id] = &entity.ObjectType{ID: id, Name: fmt.Sprintf("Customer%d", i)}
+	}
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	suggestions, _ := svc.SuggestNames(context.Background(), "cus", 100)
+
+	// Assert
+	if len(suggestions) != MaxSuggestLimit {
+		t.Errorf("suggestions = %d, want %d", len(suggestions), MaxSuggestLimit)
+	}
+}
+
+func TestSuggestNamesRejectsShortPrefix(t *testing.T) {
+	// Arrange
+	svc := suggestService()
+
+	// Act
+	_, err := svc.SuggestNames(context.Background(), "c", 10)
+
+	// Assert
+	if !errors.Is(err, ErrSuggestPrefixTooShort) {
+		t.Errorf("err = %v, want %v", err, ErrSuggestPrefixTooShort)
+	}
+}
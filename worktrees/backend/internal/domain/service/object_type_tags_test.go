@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestAddTagTwiceAddsItOnce(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	if _, err := svc.AddTag(context.Background(), objectType.ID, "crm", "alice"); err != nil {
+		t.Fatalf("AddTag: %v", err)
+	}
+
+	// Act
+	updated, err := svc.AddTag(context.Background(), objectType.ID, "crm", "alice")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("AddTag = %v, want nil for a tag already present", err)
+	}
+	if !slices.Equal(updated.Tags, []string{"crm"}) || updated.Version != 2 {
+		t.Errorf("tags = %v at version %d, want [crm] at version 2", updated.Tags, updated.Version)
+	}
+}
+
+func TestAddExistingTagCreatesNoVersion(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	_, _ = svc.AddTag(context.Background(), objectType.ID, "crm", "alice")
+
+	// Act
+	_, _ = svc.AddTag(context.Background(), objectType.ID, "crm", "alice")
+
+	// Assert
+	if len(repo.versions) != 1 || repo.versions[0].ChangeDescription != "added tag crm" {
+		t.Errorf("versions = %v, want only the first add recorded", repo.versions)
+	}
+}
+
+func TestRemoveMissingTagIsNoOp(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+
+	// Act
+	updated, err := svc.RemoveTag(context.Background(), objectType.ID, "crm", "alice")
+
+	// Assert
+	events := svc.publisher.(*fakePublisher).events
+	if err != nil {
+		t.Fatalf("RemoveTag = %v, want nil for a tag not present", err)
+	}
+	if updated.Version != 1 || len(repo.versions) != 0 || len(events) != 0 {
+		t.Errorf("version %d with %d versions and %d events, want no change",
+			updated.Version, len(repo.versions), len(events))
+	}
+}
+
+func TestRemoveTagBumpsVersion(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	_, _ = svc.AddTag(context.Background(), objectType.ID, "crm", "alice")
+
+	// Act
+	updated, err := svc.RemoveTag(context.Background(), objectType.ID, "crm", "alice")
+
+	// Assert
+	if err != nil || len(updated.Tags) != 0 || updated.Version != 3 {
+		t.Errorf("RemoveTag = %v with tags %v at version %d, want no tags at version 3", err, updated.Tags, updated.Version)
+	}
+}
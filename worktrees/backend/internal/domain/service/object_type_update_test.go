@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// storedObjectType returns a repository holding one object type at version 1
+func storedObjectType() (*fakeObjectTypeRepo, *entity.ObjectType) {
+	objectType := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", Version: 1}
+	return newFakeObjectTypeRepo(objectType), objectType
+}
+
+func TestConcurrentUpdatesHaveExactlyOneWinner(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	expected := 1
+	var wins, conflicts atomic.Int32
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			displayName := string(rune('A' + i))
+			_, err := svc.UpdateObjectType(context.Background(), objectType.ID,
+				UpdateObjectTypeInput{DisplayName: &displayName, ExpectedVersion: &expected}, "alice")
+			switch {
+			case err == nil:
+				wins.Add(1)
+			case errors.Is(err, ErrConcurrentUpdate):
+				conflicts.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	if wins.Load() != 1 || conflicts.Load() != 9 {
+		t.Errorf("wins = %d, conflicts = %d; want 1 and 9", wins.Load(), conflicts.Load())
+	}
+}
+
+func TestUpdateWithStaleExpectedVersionIsRejected(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	stale := 0
+	displayName := "Client"
+
+	// Act
+	_, err := svc.UpdateObjectType(context.Background(), objectType.ID,
+		UpdateObjectTypeInput{DisplayName: &displayName, ExpectedVersion: &stale}, "alice")
+
+	// Assert
+	if !errors.Is(err, ErrConcurrentUpdate) {
+		t.Errorf("err = %v, want %v", err, ErrConcurrentUpdate)
+	}
+}
+
+func TestUpdateLosingTheVersionRaceIsRejected(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	displayName := "Client"
+	// Another writer saves version 2 between this update's read and write
+	racing := &racingObjectTypeRepo{fakeObjectTypeRepo: repo}
+	svc.repo = racing
+
+	// Act
+	_, err := svc.UpdateObjectType(context.Background(), objectType.ID, UpdateObjectTypeInput{DisplayName: &displayName}, "alice")
+
+	// Assert
+	if !errors.Is(err, ErrConcurrentUpdate) {
+		t.Errorf("err = %v, want %v", err, ErrConcurrentUpdate)
+	}
+}
+
+// racingObjectTypeRepo bumps the stored version right after each GetByID,
+// as a concurrent writer would
+type racingObjectTypeRepo struct {
+	*fakeObjectTypeRepo
+}
+
+func (r *racingObjectTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	objectType, err := r.fakeObjectTypeRepo.GetByID(ctx, id)
+	if err == nil {
+		raced := objectType.Copy()
+		raced.Version++
+		_ = r.fakeObjectTypeRepo.Update(ctx, raced, "")
+	}
+	return objectType, err
+}
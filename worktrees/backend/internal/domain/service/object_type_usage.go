@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UsageReport lists everything referencing an object type, i.e. what a change
+// to it may affect
+type UsageReport struct {
+	ObjectTypeID uuid.UUID `json:"objectTypeId"`
+	// LinkTypes use the object type as source or target
+	LinkTypes []*entity.LinkType `json:"linkTypes"`
+	// Children inherit directly from the object type
+	Children []*entity.ObjectType `json:"children"`
+	// ReferenceProperties are REFERENCE properties pointing at the object type
+	ReferenceProperties []PropertyReference `json:"referenceProperties"`
+}
+
+// PropertyReference names a property of another object type
+type PropertyReference struct {
+	ObjectTypeID   uuid.UUID `json:"objectTypeId"`
+	ObjectTypeName string    `json:"objectTypeName"`
+	PropertyName   string    `json:"propertyName"`
+}
+
+// GetUsage reports the live link types, child object types and REFERENCE
+// properties that depend on the object type
+func (s *ObjectTypeService) GetUsage(ctx context.Context, id uuid.UUID) (*UsageReport, error) {
+	ctx, span := tracer.Start(ctx, "ObjectTypeService.GetUsage",
+		trace.WithAttributes(attribute.String("object_type.id", id.String())))
+	defer span.End()
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	linkTypes, err := s.findDependentLinkTypes(ctx, id)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	children, err := s.repo.ListChildren(ctx, id)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	referencing, err := s.repo.ListReferencing(ctx, id)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	report := &UsageReport{
+		ObjectTypeID:        id,
+		LinkTypes:           append([]*entity.LinkType{}, linkTypes...),
+		Children:            append([]*entity.ObjectType{}, children...),
+		ReferenceProperties: []PropertyReference{},
+	}
+	for _, objectType := range referencing {
+		for _, prop := range objectType.Properties {
+			if prop.DataType == entity.DataTypeReference && prop.ReferencedObjectTypeID != nil && *prop.ReferencedObjectTypeID == id {
+				report.ReferenceProperties = append(report.ReferenceProperties, PropertyReference{
+					ObjectTypeID:   objectType.ID,
+					ObjectTypeName: objectType.Name,
+					PropertyName:   prop.Name,
+				})
+			}
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("usage.link_types", len(report.LinkTypes)),
+		attribute.Int("usage.children", len(report.Children)),
+		attribute.Int("usage.reference_properties", len(report.ReferenceProperties)))
+	return report, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// usageFixture holds a Customer object type and everything around it
+type usageFixture struct {
+	customer *entity.ObjectType
+	svc      *ObjectTypeService
+	// used names the link types, children and properties expected in the
+	// report of customer
+	usedLinks, usedChildren, usedProperties []string
+}
+
+// newUsageFixture stores Customer together with live and deleted link types,
+// children and REFERENCE properties using it, and ones using another type
+func newUsageFixture() usageFixture {
+	customer := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer"}
+	account := &entity.ObjectType{ID: uuid.New(), Name: "Account", DisplayName: "Account"}
+	reference := func(name string, target uuid.UUID) entity.Property {
+		return entity.Property{Name: name, DisplayName: name, DataType: entity.DataTypeReference, ReferencedObjectTypeID: &target}
+	}
+
+	objectTypes := []*entity.ObjectType{
+		customer,
+		account,
+		{ID: uuid.New(), Name: "VipCustomer", DisplayName: "VIP Customer", ParentID: &customer.ID},
+		{ID: uuid.New(), Name: "FormerCustomer", DisplayName: "Former", ParentID: &customer.ID, IsDeleted: true},
+		{ID: uuid.New(), Name: "Business", DisplayName: "Business", ParentID: &account.ID},
+		{ID: uuid.New(), Name: "Order", DisplayName: "Order", Properties: []entity.Property{
+			stringProperty("number"), reference("buyer", customer.ID), reference("payer", customer.ID),
+		}},
+		{ID: uuid.New(), Name: "Invoice", DisplayName: "Invoice", Properties: []entity.Property{
+			reference("account", account.ID),
+		}},
+		{ID: uuid.New(), Name: "Quote", DisplayName: "Quote", IsDeleted: true, Properties: []entity.Property{
+			reference("customer", customer.ID),
+		}},
+	}
+
+	links := newFakeLinkTypeRepo(
+		&entity.LinkType{ID: uuid.New(), Name: "places", SourceObjectTypeID: customer.ID, TargetObjectTypeID: account.ID},
+		&entity.LinkType{ID: uuid.New(), Name: "ownedBy", SourceObjectTypeID: account.ID, TargetObjectTypeID: customer.ID},
+		&entity.LinkType{ID: uuid.New(), Name: "refers", SourceObjectTypeID: customer.ID, TargetObjectTypeID: customer.ID},
+		&entity.LinkType{ID: uuid.New(), Name: "replaced", SourceObjectTypeID: customer.ID, TargetObjectTypeID: account.ID, IsDeleted: true},
+		&entity.LinkType{ID: uuid.New(), Name: "audits", SourceObjectTypeID: account.ID, TargetObjectTypeID: account.ID},
+	)
+	svc := NewObjectTypeService(newFakeObjectTypeRepo(objectTypes...), links, newFakeCache(), DefaultCacheTTLs(), &fakePublisher{},
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+
+	return usageFixture{
+		customer:       customer,
+		svc:            svc,
+		usedLinks:      []string{"ownedBy", "places", "refers"},
+		usedChildren:   []string{"VipCustomer"},
+		usedProperties: []string{"Order.buyer", "Order.payer"},
+	}
+}
+
+// usageNames returns the sorted names of the link types, children and
+// object type qualified properties in report
+func usageNames(report *UsageReport) (links, children, properties []string) {
+	for _, lt := range report.LinkTypes {
+		links = append(links, lt.Name)
+	}
+	for _, child := range report.Children {
+		children = append(children, child.Name)
+	}
+	for _, ref := range report.ReferenceProperties {
+		properties = append(properties, ref.ObjectTypeName+"."+ref.PropertyName)
+	}
+	slices.Sort(links)
+	slices.Sort(children)
+	slices.Sort(properties)
+	return links, children, properties
+}
+
+func TestGetUsageFindsEveryKindOfReference(t *testing.T) {
+	// Arrange
+	fixture := newUsageFixture()
+
+	// Act
+	report, err := fixture.svc.GetUsage(context.Background(), fixture.customer.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	links, children, properties := usageNames(report)
+	if !slices.Equal(links, fixture.usedLinks) {
+		t.Errorf("link types = %v, want %v with the self link once", links, fixture.usedLinks)
+	}
+	if !slices.Equal(children, fixture.usedChildren) {
+		t.Errorf("children = %v, want %v", children, fixture.usedChildren)
+	}
+	if !slices.Equal(properties, fixture.usedProperties) {
+		t.Errorf("reference properties = %v, want %v", properties, fixture.usedProperties)
+	}
+	if report.ObjectTypeID != fixture.customer.ID {
+		t.Errorf("report is for %s, want %s", report.ObjectTypeID, fixture.customer.ID)
+	}
+}
+
+func TestGetUsageOfUnusedObjectTypeIsEmpty(t *testing.T) {
+	// Arrange
+	fixture := newUsageFixture()
+	unused := &entity.ObjectType{ID: uuid.New(), Name: "Unused", DisplayName: "Unused"}
+	_ = fixture.svc.repo.Create(context.Background(), unused)
+
+	// Act
+	report, err := fixture.svc.GetUsage(context.Background(), unused.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if report.LinkTypes == nil || report.Children == nil || report.ReferenceProperties == nil {
+		t.Errorf("report = %+v, want empty lists rather than null", report)
+	}
+	if len(report.LinkTypes)+len(report.Children)+len(report.ReferenceProperties) != 0 {
+		t.Errorf("report = %+v, want no usage", report)
+	}
+}
+
+func TestGetUsageOfUnknownObjectTypeIsNotFound(t *testing.T) {
+	// Arrange
+	fixture := newUsageFixture()
+
+	// Act
+	_, err := fixture.svc.GetUsage(context.Background(), uuid.New())
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNotFound) {
+		t.Errorf("GetUsage = %v, want ErrObjectTypeNotFound", err)
+	}
+}
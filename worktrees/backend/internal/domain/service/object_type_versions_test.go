@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// updateWithDescription updates a stored object type with description and
+// returns its version history
+func updateWithDescription(t *testing.T, description *string) []*repository.ObjectTypeVersion {
+	t.Helper()
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	displayName := "Client"
+	input := UpdateObjectTypeInput{DisplayName: &displayName, ChangeDescription: description}
+	if _, err := svc.UpdateObjectType(context.Background(), objectType.ID, input, "alice"); err != nil {
+		t.Fatalf("UpdateObjectType: %v", err)
+	}
+
+	versions, err := svc.ListVersions(context.Background(), objectType.ID, repository.VersionFilter{})
+	if err != nil {
+		t.Fatalf("ListVersions: %v", err)
+	}
+	return versions
+}
+
+func TestChangeDescriptionRoundTripsIntoVersionHistory(t *testing.T) {
+	// Arrange
+	description := "Renamed to match the CRM"
+
+	// Act
+	versions := updateWithDescription(t, &description)
+
+	// Assert
+	if len(versions) != 1 || versions[0].ChangeDescription != description {
+		t.Errorf("versions = %v, want one described %q", versions, description)
+	}
+}
+
+func TestChangeDescriptionIsTrimmed(t *testing.T) {
+	// Arrange
+	description := "  Renamed  "
+
+	// Act
+	versions := updateWithDescription(t, &description)
+
+	// Assert
+	if versions[0].ChangeDescription != "Renamed" {
+		t.Errorf("change description = %q, want Renamed", versions[0].ChangeDescription)
+	}
+}
+
+func TestUpdateWithoutChangeDescriptionRecordsEmptyDescription(t *testing.T) {
+	// Act
+	versions := updateWithDescription(t, nil)
+
+	// Assert
+	if versions[0].ChangeDescription != "" {
+		t.Errorf("change description = %q, want empty", versions[0].ChangeDescription)
+	}
+}
+
+func TestUpdateRejectsOverlongChangeDescription(t *testing.T) {
+	// Arrange
+	repo, objectType := storedObjectType()
+	svc := newTestObjectTypeService(repo)
+	description := strings.Repeat("x", MaxChangeDescriptionLength+1)
+
+	// Act
+	_, err := svc.UpdateObjectType(context.Background(), objectType.ID, UpdateObjectTypeInput{ChangeDescription: &description}, "alice")
+
+	// Assert
+	if err != ErrChangeDescriptionTooLong {
+		t.Errorf("err = %v, want %v", err, ErrChangeDescriptionTooLong)
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// PurgeEventType is the type of the events summarizing a retention run, one
+// per tenant it purged definitions of
+const PurgeEventType = "retention.purged"
+
+// RetentionActor is the actor recorded in the audit log for retention purges
+const RetentionActor = "retention"
+
+// purgeBatchSize bounds the object types and the link types purged by one
+// retention run; the rest are left for the next run
+const purgeBatchSize = 500
+
+// RetentionConfig controls the permanent removal of soft-deleted object and
+// link types
+type RetentionConfig struct {
+	// Retention is how long soft-deleted definitions are kept before purging
+	Retention time.Duration
+	// Interval is the time between retention runs
+	Interval time.Duration
+	// DryRun reports what would be purged without deleting anything
+	DryRun bool
+}
+
+// PurgeSummary reports the definitions removed by one retention run, or
+// those that would be removed in a dry run
+type PurgeSummary struct {
+	Cutoff      time.Time   `json:"cutoff"`
+	DryRun      bool        `json:"dryRun"`
+	ObjectTypes []uuid.UUID `json:"objectTypes"`
+	LinkTypes   []uuid.UUID `json:"linkTypes"`
+	// Failed lists definitions whose purge failed; they are retried next run
+	Failed []uuid.UUID `json:"failed"`
+}
+
+// IsEmpty reports whether the run found nothing to purge
+func (s *PurgeSummary) IsEmpty() bool {
+	return len(s.ObjectTypes) == 0 && len(s.LinkTypes) == 0 && len(s.Failed) == 0
+}
+
+// newPurgeSummary returns an empty summary of a run purging up to cutoff
+func newPurgeSummary(cutoff time.Time, dryRun bool) *PurgeSummary {
+	return &PurgeSummary{
+		Cutoff:      cutoff,
+		DryRun:      dryRun,
+		ObjectTypes: []uuid.UUID{},
+		LinkTypes:   []uuid.UUID{},
+		Failed:      []uuid.UUID{},
+	}
+}
+
+// tenantSummaries splits a run's summary by tenant, so that each tenant is
+// only told about its own definitions
+type tenantSummaries map[string]*PurgeSummary
+
+// of returns the summary of tenant, starting it like run
+func (t tenantSummaries) of(tenant string, run *PurgeSummary) *PurgeSummary {
+	if t[tenant] == nil {
+		t[tenant] = newPurgeSummary(run.Cutoff, run.DryRun)
+	}
+	return t[tenant]
+}
+
+// RetentionService purges object and link types soft-deleted longer ago than
+// the retention period, together with their version history. Each run covers
+// every tenant.
+type RetentionService struct {
+	objectTypeRepo repository.ObjectTypeRepository
+	linkTypeRepo   repository.LinkTypeRepository
+	publisher      event.EventPublisher
+	config         RetentionConfig
+	logger         *zap.Logger
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(
+	objectTypeRepo repository.ObjectTypeRepository,
+	linkTypeRepo repository.LinkTypeRepository,
+	publisher event.EventPublisher,
+	config RetentionConfig,
+	logger *zap.Logger,
+) *RetentionService {
+	return &RetentionService{
+		objectTypeRepo: objectTypeRepo,
+		linkTypeRepo:   linkTypeRepo,
+		publisher:      publisher,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// Run purges expired definitions at start and then every interval until ctx
+// is done. Failed runs are logged and retried on the next tick.
+func (s *RetentionService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PurgeExpired(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			s.logger.Error("Retention run failed", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// PurgeExpired purges definitions soft-deleted before now minus the retention
+// period. Link types go first so that object types they pointed at become
+// purgeable. Object types that still have child object types, live link types
+// or link types that prevent deletes are kept. The returned summary covers
+// every tenant, while each tenant gets an event listing its own definitions.
+func (s *RetentionService) PurgeExpired(ctx context.Context, now time.Time) (*PurgeSummary, error) {
+	ctx, span := tracer.Start(ctx, "RetentionService.PurgeExpired")
+	defer span.End()
+
+	summary := newPurgeSummary(now.Add(-s.config.Retention), s.config.DryRun)
+	tenants := tenantSummaries{}
+
+	linkTypes, err := s.linkTypeRepo.ListPurgeable(ctx, summary.Cutoff, purgeBatchSize)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+	for _, linkType := range linkTypes {
+		tenant := tenants.of(linkType.TenantID, summary)
+		if !summary.DryRun {
+			if err := s.linkTypeRepo.Purge(withAuditActor(repository.WithTenant(ctx, linkType.TenantID), RetentionActor), linkType.ID); err != nil {
+				s.logger.Warn("Failed to purge link type",
+					zap.String("id", linkType.ID.String()),
+					zap.Error(err))
+				summary.Failed = append(summary.Failed, linkType.ID)
+				tenant.Failed = append(tenant.Failed, linkType.ID)
+				continue
+			}
+		}
+		summary.LinkTypes = append(summary.LinkTypes, linkType.ID)
+		tenant.LinkTypes = append(tenant.LinkTypes, linkType.ID)
+	}
+
+	// A dry run leaves the link types in place, so object types they block
+	// are not reported even though the real run would purge them
+	objectTypes, err := s.objectTypeRepo.ListPurgeable(ctx, summary.Cutoff, purgeBatchSize)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+	for _, objectType := range objectTypes {
+		tenant := tenants.of(objectType.TenantID, summary)
+		if !summary.DryRun {
+			if err := s.objectTypeRepo.Purge(withAuditActor(repository.WithTenant(ctx, objectType.TenantID), RetentionActor), objectType.ID); err != nil {
+				s.logger.Warn("Failed to purge object type",
+					zap.String("id", objectType.ID.String()),
+					zap.Error(err))
+				summary.Failed = append(summary.Failed, objectType.ID)
+				tenant.Failed = append(tenant.Failed, objectType.ID)
+				continue
+			}
+		}
+		summary.ObjectTypes = append(summary.ObjectTypes, objectType.ID)
+		tenant.ObjectTypes = append(tenant.ObjectTypes, objectType.ID)
+	}
+
+	if summary.IsEmpty() {
+		return summary, nil
+	}
+
+	// Publish one event per tenant; stream and webhook subscribers only see
+	// the events of their own tenant
+	names := make([]string, 0, len(tenants))
+	for name := range tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		evt := event.Event{
+			ID:            event.NewID(),
+			EventType:     PurgeEventType,
+			AggregateID:   "retention",
+			AggregateType: "retention",
+			Version:       1,
+			Timestamp:     now,
+			Data:          tenants[name],
+			TenantID:      name,
+		}
+
+		if err := s.publisher.Publish(ctx, evt); err != nil {
+			s.logger.Error("Failed to publish event", zap.String("tenant", name), zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Soft-deleted definitions purged",
+		zap.Time("cutoff", summary.Cutoff),
+		zap.Bool("dry_run", summary.DryRun),
+		zap.Int("object_types", len(summary.ObjectTypes)),
+		zap.Int("link_types", len(summary.LinkTypes)),
+		zap.Int("failed", len(summary.Failed)))
+	return summary, nil
+}
@@ -0,0 +1,410 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// retentionStore holds object and link types across tenants and answers
+// ListPurgeable as the Postgres queries do: soft-deleted before the cutoff,
+// oldest first, and for object types only those no child, live link type or
+// PreventDelete link type still references
+type retentionStore struct {
+	objectTypes map[uuid.UUID]*entity.ObjectType
+	linkTypes   map[uuid.UUID]*entity.LinkType
+	// failing names definitions whose Purge fails
+	failing map[uuid.UUID]bool
+	// purges records the tenant and audit actor of each successful Purge
+	purges []retentionPurge
+}
+
+// retentionPurge is a Purge made through a retentionStore
+type retentionPurge struct {
+	id     uuid.UUID
+	tenant string
+	actor  string
+}
+
+func newRetentionStore() *retentionStore {
+	return &retentionStore{
+		objectTypes: map[uuid.UUID]*entity.ObjectType{},
+		linkTypes:   map[uuid.UUID]*entity.LinkType{},
+		failing:     map[uuid.UUID]bool{},
+	}
+}
+
+// objectType adds an object type of tenant, soft deleted at deletedAt unless
+// it is zero
+func (s *retentionStore) objectType(tenant string, deletedAt time.Time) *entity.ObjectType {
+	ot := &entity.ObjectType{ID: uuid.New(), TenantID: tenant, Name: "Type", UpdatedAt: deletedAt, IsDeleted: !deletedAt.IsZero()}
+	s.objectTypes[ot.ID] = ot
+	return ot
+}
+
+// linkType adds a link type of tenant from source to target, soft deleted at
+// deletedAt unless it is zero
+func (s *retentionStore) linkType(tenant string, source, target *entity.ObjectType, deletedAt time.Time) *entity.LinkType {
+	lt := &entity.LinkType{
+		ID: uuid.New(), TenantID: tenant, Name: "link", UpdatedAt: deletedAt, IsDeleted: !deletedAt.IsZero(),
+		SourceObjectTypeID: source.ID, TargetObjectTypeID: target.ID,
+	}
+	s.linkTypes[lt.ID] = lt
+	return lt
+}
+
+// blocked reports whether a child or a link type keeps object type id
+func (s *retentionStore) blocked(id uuid.UUID) bool {
+	for _, child := range s.objectTypes {
+		if child.ParentID != nil && *child.ParentID == id {
+			return true
+		}
+	}
+	for _, lt := range s.linkTypes {
+		if (lt.SourceObjectTypeID == id || lt.TargetObjectTypeID == id) && (!lt.IsDeleted || lt.Constraints.PreventDelete) {
+			return true
+		}
+	}
+	return false
+}
+
+// purge removes id from definitions, recording the purge
+func purgeFrom[T any](s *retentionStore, ctx context.Context, definitions map[uuid.UUID]T, id uuid.UUID) error {
+	if s.failing[id] {
+		return errors.New("connection reset")
+	}
+	delete(definitions, id)
+	entry := repository.AuditEntryFromContext(ctx)
+	s.purges = append(s.purges, retentionPurge{id: id, tenant: repository.TenantFromContext(ctx), actor: entry.Actor})
+	return nil
+}
+
+// oldestFirst returns the matching definitions ordered by deletion time, at
+// most limit of them
+func oldestFirst[T any](definitions map[uuid.UUID]T, limit int, deletedAt func(T) time.Time, match func(T) bool) []T {
+	var matched []T
+	for _, definition := range definitions {
+		if match(definition) {
+			matched = append(matched, definition)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return deletedAt(matched[i]).Before(deletedAt(matched[j])) })
+	return matched[:min(limit, len(matched))]
+}
+
+// retentionObjectTypeRepo serves the object types of a retentionStore
+type retentionObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	store *retentionStore
+}
+
+func (r *retentionObjectTypeRepo) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.ObjectType, error) {
+	return oldestFirst(r.store.objectTypes, limit,
+		func(ot *entity.ObjectType) time.Time { return ot.UpdatedAt },
+		func(ot *entity.ObjectType) bool {
+			return ot.IsDeleted && ot.UpdatedAt.Before(deletedBefore) && !r.store.blocked(ot.ID)
+		}), nil
+}
+
+func (r *retentionObjectTypeRepo) Purge(ctx context.Context, id uuid.UUID) error {
+	return purgeFrom(r.store, ctx, r.store.objectTypes, id)
+}
+
+// retentionLinkTypeRepo serves the link types of a retentionStore
+type retentionLinkTypeRepo struct {
+	repository.LinkTypeRepository
+	store *retentionStore
+}
+
+func (r *retentionLinkTypeRepo) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.LinkType, error) {
+	return oldestFirst(r.store.linkTypes, limit,
+		func(lt *entity.LinkType) time.Time { return lt.UpdatedAt },
+		func(lt *entity.LinkType) bool { return lt.IsDeleted && lt.UpdatedAt.Before(deletedBefore) }), nil
+}
+
+func (r *retentionLinkTypeRepo) Purge(ctx context.Context, id uuid.UUID) error {
+	return purgeFrom(r.store, ctx, r.store.linkTypes, id)
+}
+
+// eventRecorder is an event.EventPublisher recording what it publishes
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (p *eventRecorder) Publish(ctx context.Context, evt event.Event) error {
+	p.events = append(p.events, evt)
+	return nil
+}
+
+func (p *eventRecorder) PublishBatch(ctx context.Context, events []event.Event) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+// retentionNow is the time the retention tests run at
+var retentionNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestRetentionService builds a RetentionService over store keeping
+// deletions for 30 days
+func newTestRetentionService(store *retentionStore, publisher *eventRecorder, dryRun bool) *RetentionService {
+	return NewRetentionService(&retentionObjectTypeRepo{store: store}, &retentionLinkTypeRepo{store: store}, publisher,
+		RetentionConfig{Retention: 30 * 24 * time.Hour, Interval: time.Hour, DryRun: dryRun}, zap.NewNop())
+}
+
+// daysAgo returns the time days before retentionNow
+func daysAgo(days int) time.Time {
+	return retentionNow.AddDate(0, 0, -days)
+}
+
+func TestPurgeExpiredPurgesOnlyDefinitionsDeletedBeforeTheCutoff(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	live := store.objectType("acme", time.Time{})
+	expired := store.objectType("acme", daysAgo(45))
+	recent := store.objectType("acme", daysAgo(10))
+	atCutoff := store.objectType("acme", daysAgo(30))
+	expiredLink := store.linkType("acme", live, live, daysAgo(31))
+	recentLink := store.linkType("acme", live, live, daysAgo(29))
+	svc := newTestRetentionService(store, &eventRecorder{}, false)
+
+	// Act
+	summary, err := svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PurgeExpired: %v", err)
+	}
+	if !slices.Equal(summary.ObjectTypes, []uuid.UUID{expired.ID}) || !slices.Equal(summary.LinkTypes, []uuid.UUID{expiredLink.ID}) {
+		t.Errorf("purged object types %v and link types %v, want only the ones deleted over 30 days ago",
+			summary.ObjectTypes, summary.LinkTypes)
+	}
+	for _, kept := range []*entity.ObjectType{live, recent, atCutoff} {
+		if _, ok := store.objectTypes[kept.ID]; !ok {
+			t.Errorf("object type deleted at %v was purged", kept.UpdatedAt)
+		}
+	}
+	if _, ok := store.linkTypes[recentLink.ID]; !ok {
+		t.Error("link type deleted 29 days ago was purged")
+	}
+	if !summary.Cutoff.Equal(daysAgo(30)) {
+		t.Errorf("cutoff = %v, want 30 days before now", summary.Cutoff)
+	}
+}
+
+func TestPurgeExpiredKeepsObjectTypesOthersDependOn(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	live := store.objectType("acme", time.Time{})
+	parent := store.objectType("acme", daysAgo(60))
+	child := store.objectType("acme", time.Time{})
+	child.ParentID = &parent.ID
+	linkedLive := store.objectType("acme", daysAgo(60))
+	store.linkType("acme", linkedLive, live, time.Time{})
+	protected := store.objectType("acme", daysAgo(60))
+	guard := store.linkType("acme", live, protected, daysAgo(5))
+	guard.Constraints.PreventDelete = true
+	unblocked := store.objectType("acme", daysAgo(60))
+	store.linkType("acme", unblocked, live, daysAgo(40))
+	svc := newTestRetentionService(store, &eventRecorder{}, false)
+
+	// Act
+	summary, err := svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PurgeExpired: %v", err)
+	}
+	if !slices.Equal(summary.ObjectTypes, []uuid.UUID{unblocked.ID}) {
+		t.Errorf("purged object types %v, want only the one whose expired link type went first", summary.ObjectTypes)
+	}
+	for name, kept := range map[string]*entity.ObjectType{"parent": parent, "live link": linkedLive, "PreventDelete": protected} {
+		if _, ok := store.objectTypes[kept.ID]; !ok {
+			t.Errorf("object type kept by its %s was purged", name)
+		}
+	}
+}
+
+func TestPurgeExpiredPurgesAsRetentionInTheOwningTenant(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	acme := store.objectType("acme", daysAgo(40))
+	globex := store.objectType("globex", daysAgo(40))
+	globexLive := store.objectType("globex", time.Time{})
+	globexLink := store.linkType("globex", globexLive, globexLive, daysAgo(40))
+	svc := newTestRetentionService(store, &eventRecorder{}, false)
+
+	// Act
+	_, _ = svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	tenants := map[uuid.UUID]string{acme.ID: "acme", globex.ID: "globex", globexLink.ID: "globex"}
+	if len(store.purges) != 3 {
+		t.Fatalf("purges = %v, want the two object types and the link type", store.purges)
+	}
+	for _, purge := range store.purges {
+		if purge.tenant != tenants[purge.id] || purge.actor != RetentionActor {
+			t.Errorf("purge of %s ran as %q in tenant %q, want %q in %q",
+				purge.id, purge.actor, purge.tenant, RetentionActor, tenants[purge.id])
+		}
+	}
+}
+
+func TestPurgeExpiredDryRunDeletesNothing(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	live := store.objectType("acme", time.Time{})
+	expired := store.objectType("acme", daysAgo(45))
+	store.objectType("acme", daysAgo(10))
+	expiredLink := store.linkType("acme", live, live, daysAgo(45))
+	publisher := &eventRecorder{}
+	svc := newTestRetentionService(store, publisher, true)
+
+	// Act
+	summary, err := svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	if err != nil || !summary.DryRun || !slices.Equal(summary.ObjectTypes, []uuid.UUID{expired.ID}) ||
+		!slices.Equal(summary.LinkTypes, []uuid.UUID{expiredLink.ID}) {
+		t.Errorf("PurgeExpired = %+v, %v, want a dry run reporting the expired object and link type", summary, err)
+	}
+	if len(store.purges) != 0 || len(store.objectTypes) != 3 || len(store.linkTypes) != 1 {
+		t.Errorf("dry run purged %d definitions, want none", len(store.purges))
+	}
+	if len(publisher.events) != 1 || !publisher.events[0].Data.(*PurgeSummary).DryRun {
+		t.Errorf("events = %+v, want one dry-run summary", publisher.events)
+	}
+}
+
+func TestPurgeExpiredPublishesSummaryEvent(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	live := store.objectType("acme", time.Time{})
+	expired := store.objectType("acme", daysAgo(45))
+	expiredLink := store.linkType("acme", live, live, daysAgo(45))
+	publisher := &eventRecorder{}
+	svc := newTestRetentionService(store, publisher, false)
+
+	// Act
+	summary, _ := svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	if len(publisher.events) != 1 {
+		t.Fatalf("events = %d, want 1", len(publisher.events))
+	}
+	evt := publisher.events[0]
+	if evt.EventType != PurgeEventType || evt.ID == "" || !evt.Timestamp.Equal(retentionNow) || evt.TenantID != "acme" {
+		t.Errorf("event = %+v, want a %s event of acme", evt, PurgeEventType)
+	}
+	if data := evt.Data.(*PurgeSummary); !reflect.DeepEqual(data, summary) {
+		t.Errorf("event summary = %+v, want %+v", data, summary)
+	}
+	if !slices.Equal(summary.ObjectTypes, []uuid.UUID{expired.ID}) || !slices.Equal(summary.LinkTypes, []uuid.UUID{expiredLink.ID}) {
+		t.Errorf("summary = %+v, want the purged object and link type", summary)
+	}
+}
+
+func TestPurgeExpiredTellsEachTenantOnlyAboutItsOwnDefinitions(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	acmeType := store.objectType("acme", daysAgo(45))
+	globexLive := store.objectType("globex", time.Time{})
+	globexLink := store.linkType("globex", globexLive, globexLive, daysAgo(40))
+	globexFailing := store.objectType("globex", daysAgo(50))
+	store.failing[globexFailing.ID] = true
+	publisher := &eventRecorder{}
+	svc := newTestRetentionService(store, publisher, false)
+
+	// Act
+	summary, _ := svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	if len(summary.ObjectTypes)+len(summary.LinkTypes)+len(summary.Failed) != 3 {
+		t.Errorf("summary = %+v, want all three definitions of both tenants", summary)
+	}
+	want := map[string]*PurgeSummary{
+		"acme": {Cutoff: summary.Cutoff, ObjectTypes: []uuid.UUID{acmeType.ID}, LinkTypes: []uuid.UUID{}, Failed: []uuid.UUID{}},
+		"globex": {Cutoff: summary.Cutoff, ObjectTypes: []uuid.UUID{}, LinkTypes: []uuid.UUID{globexLink.ID},
+			Failed: []uuid.UUID{globexFailing.ID}},
+	}
+	if len(publisher.events) != len(want) {
+		t.Fatalf("events = %d, want one per tenant", len(publisher.events))
+	}
+	for _, evt := range publisher.events {
+		if data := evt.Data.(*PurgeSummary); !reflect.DeepEqual(data, want[evt.TenantID]) {
+			t.Errorf("event of tenant %q = %+v, want %+v", evt.TenantID, data, want[evt.TenantID])
+		}
+	}
+}
+
+func TestPurgeExpiredWithNothingExpiredPublishesNothing(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	store.objectType("acme", daysAgo(1))
+	publisher := &eventRecorder{}
+	svc := newTestRetentionService(store, publisher, false)
+
+	// Act
+	summary, err := svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	if err != nil || !summary.IsEmpty() || len(publisher.events) != 0 {
+		t.Errorf("PurgeExpired = %+v, %v with %d events, want an empty summary and no event", summary, err, len(publisher.events))
+	}
+}
+
+func TestPurgeExpiredReportsFailuresAndContinues(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	failing := store.objectType("acme", daysAgo(50))
+	purged := store.objectType("acme", daysAgo(40))
+	store.failing[failing.ID] = true
+	svc := newTestRetentionService(store, &eventRecorder{}, false)
+
+	// Act
+	summary, err := svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PurgeExpired: %v", err)
+	}
+	if !slices.Equal(summary.Failed, []uuid.UUID{failing.ID}) || !slices.Equal(summary.ObjectTypes, []uuid.UUID{purged.ID}) {
+		t.Errorf("summary = %+v, want the failure reported and the other object type purged", summary)
+	}
+	if _, ok := store.objectTypes[failing.ID]; !ok {
+		t.Error("failed object type is gone, want it left for the next run")
+	}
+}
+
+func TestPurgeExpiredPurgesAtMostOneBatch(t *testing.T) {
+	// Arrange
+	store := newRetentionStore()
+	for i := 0; i < purgeBatchSize+3; i++ {
+		store.objectType("acme", daysAgo(40+i))
+	}
+	svc := newTestRetentionService(store, &eventRecorder{}, false)
+
+	// Act
+	summary, _ := svc.PurgeExpired(context.Background(), retentionNow)
+
+	// Assert
+	if len(summary.ObjectTypes) != purgeBatchSize || len(store.objectTypes) != 3 {
+		t.Errorf("purged %d with %d left, want %d purged and 3 left for the next run",
+			len(summary.ObjectTypes), len(store.objectTypes), purgeBatchSize)
+	}
+	for _, left := range store.objectTypes {
+		if left.UpdatedAt.Before(daysAgo(42)) {
+			t.Errorf("object type deleted at %v left, want the newest deletions left", left.UpdatedAt)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// Validation error codes
+const (
+	ValidationCodeRequired     = "required"
+	ValidationCodeUnknownField = "unknown_field"
+	ValidationCodeInvalidValue = "invalid_value"
+	// ValidationCodeBrokenReference marks a reference whose object type is missing
+	ValidationCodeBrokenReference = "broken_reference"
+)
+
+// FieldError is one violation found while validating an instance
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationReport lists every violation found in an instance
+type ValidationReport struct {
+	ObjectTypeID uuid.UUID    `json:"objectTypeId"`
+	Valid        bool         `json:"valid"`
+	Errors       []FieldError `json:"errors"`
+}
+
+// ValidationService validates instance data against object type definitions
+type ValidationService struct {
+	objectTypes *ObjectTypeService
+	logger      *zap.Logger
+}
+
+// NewValidationService creates a new validation service
+func NewValidationService(objectTypes *ObjectTypeService, logger *zap.Logger) *ValidationService {
+	return &ValidationService{
+		objectTypes: objectTypes,
+		logger:      logger,
+	}
+}
+
+// ValidateInstance checks values against the object type's own and inherited
+// properties. Rather than stopping at the first problem it reports missing
+// required fields, invalid values, failed expression validators and unknown
+// fields together. Values of REFERENCE properties are reported when the
+// referenced object type no longer exists or is deleted. The returned error
+// is only set when an object type cannot be loaded.
+func (s *ValidationService) ValidateInstance(ctx context.Context, objectTypeID uuid.UUID, values map[string]interface{}) (*ValidationReport, error) {
+	objectType, err := s.objectTypes.GetByIDResolved(ctx, objectTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ValidationReport{
+		ObjectTypeID: objectTypeID,
+		Errors:       []FieldError{},
+	}
+
+	known := make(map[string]bool, len(objectType.ResolvedProperties))
+	referenced := make(map[uuid.UUID]bool)
+	for i := range objectType.ResolvedProperties {
+		prop := &objectType.ResolvedProperties[i]
+		known[prop.Name] = true
+
+		value := values[prop.Name]
+		if prop.Required && value == nil {
+			report.Errors = append(report.Errors, FieldError{
+				Field:   prop.Name,
+				Code:    ValidationCodeRequired,
+				Message: "required property " + prop.Name + " is missing",
+			})
+			continue
+		}
+
+		if err := prop.ValidateValue(value); err != nil {
+			report.Errors = append(report.Errors, FieldError{
+				Field:   prop.Name,
+				Code:    ValidationCodeInvalidValue,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		if value != nil && prop.ReferencedObjectTypeID != nil {
+			exists, err := s.referencedTypeExists(ctx, *prop.ReferencedObjectTypeID, referenced)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				report.Errors = append(report.Errors, FieldError{
+					Field:   prop.Name,
+					Code:    ValidationCodeBrokenReference,
+					Message: entity.ErrReferencedTypeMissingFor(prop.Name, *prop.ReferencedObjectTypeID).Error(),
+				})
+			}
+		}
+	}
+
+	// Cross-field expression validators
+	for _, failure := range objectType.ExpressionErrors(values) {
+		report.Errors = append(report.Errors, FieldError{
+			Field:   failure.Property,
+			Code:    ValidationCodeInvalidValue,
+			Message: fmt.Sprintf("validation failed for %s: %v", failure.Property, failure.Err),
+		})
+	}
+
+	// Report unknown fields in a stable order
+	var unknown []string
+	for field := range values {
+		if !known[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	sort.Strings(unknown)
+	for _, field := range unknown {
+		report.Errors = append(report.Errors, FieldError{
+			Field:   field,
+			Code:    ValidationCodeUnknownField,
+			Message: "unknown property " + field,
+		})
+	}
+
+	report.Valid = len(report.Errors) == 0
+	return report, nil
+}
+
+// referencedTypeExists reports whether an object type exists and is not
+// deleted, remembering answers in seen
+func (s *ValidationService) referencedTypeExists(ctx context.Context, id uuid.UUID, seen map[uuid.UUID]bool) (bool, error) {
+	if exists, ok := seen[id]; ok {
+		return exists, nil
+	}
+
+	_, err := s.objectTypes.GetByID(ctx, id)
+	if err != nil && !errors.Is(err, entity.ErrObjectTypeNotFound) {
+		return false, err
+	}
+
+	seen[id] = err == nil
+	return seen[id], nil
+}
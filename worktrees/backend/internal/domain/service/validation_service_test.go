@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+)
+
+// newTestValidationService returns a validation service over objectTypes
+func newTestValidationService(objectTypes ...*entity.ObjectType) *ValidationService {
+	return NewValidationService(newTestObjectTypeService(newFakeObjectTypeRepo(objectTypes...)), zap.NewNop())
+}
+
+// contactType returns an object type inheriting a required legalName and
+// owning a required email and a name of at most 5 characters
+func contactType() (*entity.ObjectType, *entity.ObjectType) {
+	parent := &entity.ObjectType{ID: uuid.New(), Name: "Party", Properties: []entity.Property{
+		{Name: "legalName", DisplayName: "Legal name", DataType: entity.DataTypeString, Required: true},
+	}}
+	child := &entity.ObjectType{ID: uuid.New(), Name: "Contact", ParentID: &parent.ID, Properties: []entity.Property{
+		{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString, Required: true},
+		{Name: "name", DisplayName: "Name", DataType: entity.DataTypeString, Validators: []entity.Validator{
+			{Type: entity.ValidatorMaxLength, Value: float64(5)},
+		}},
+	}}
+	return parent, child
+}
+
+// errorCodes maps each reported field to its error code
+func errorCodes(report *ValidationReport) map[string]string {
+	codes := make(map[string]string, len(report.Errors))
+	for _, fieldErr := range report.Errors {
+		codes[fieldErr.Field] = fieldErr.Code
+	}
+	return codes
+}
+
+func TestValidateInstanceReportsEveryViolation(t *testing.T) {
+	// Arrange
+	parent, child := contactType()
+	svc := newTestValidationService(parent, child)
+
+	// Act
+	report, _ := svc.ValidateInstance(context.Background(), child.ID, map[string]interface{}{
+		"legalName": "Acme",
+		"name":      "far too long",
+		"nickname":  "Ace",
+	})
+
+	// Assert
+	want := map[string]string{
+		"email":    ValidationCodeRequired,
+		"name":     ValidationCodeInvalidValue,
+		"nickname": ValidationCodeUnknownField,
+	}
+	if got := errorCodes(report); len(got) != len(want) || got["email"] != want["email"] || got["name"] != want["name"] || got["nickname"] != want["nickname"] {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+}
+
+func TestValidateInstanceWithViolationsIsInvalid(t *testing.T) {
+	// Arrange
+	parent, child := contactType()
+	svc := newTestValidationService(parent, child)
+
+	// Act
+	report, _ := svc.ValidateInstance(context.Background(), child.ID, map[string]interface{}{"nickname": "Ace"})
+
+	// Assert
+	if report.Valid {
+		t.Error("report is valid, want invalid")
+	}
+}
+
+func TestValidateInstanceReportsMissingInheritedProperty(t *testing.T) {
+	// Arrange
+	parent, child := contactType()
+	svc := newTestValidationService(parent, child)
+
+	// Act
+	report, _ := svc.ValidateInstance(context.Background(), child.ID, map[string]interface{}{"email": "a@example.com"})
+
+	// Assert
+	if codes := errorCodes(report); codes["legalName"] != ValidationCodeRequired {
+		t.Errorf("errors = %v, want legalName required", codes)
+	}
+}
+
+func TestValidateInstanceAcceptsCompleteInstance(t *testing.T) {
+	// Arrange
+	parent, child := contactType()
+	svc := newTestValidationService(parent, child)
+
+	// Act
+	report, _ := svc.ValidateInstance(context.Background(), child.ID, map[string]interface{}{
+		"legalName": "Acme",
+		"email":     "a@example.com",
+		"name":      "Ace",
+	})
+
+	// Assert
+	if !report.Valid || len(report.Errors) != 0 {
+		t.Errorf("report = %+v, want valid", report)
+	}
+}
+
+func TestValidateInstanceOfUnknownObjectTypeFails(t *testing.T) {
+	// Arrange
+	svc := newTestValidationService()
+
+	// Act
+	_, err := svc.ValidateInstance(context.Background(), uuid.New(), map[string]interface{}{})
+
+	// Assert
+	if err != entity.ErrObjectTypeNotFound {
+		t.Errorf("err = %v, want %v", err, entity.ErrObjectTypeNotFound)
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"go.uber.org/zap"
+)
+
+// WebhookSubscriptionService manages the HTTP endpoints change events are
+// delivered to by messaging.WebhookDispatcher
+type WebhookSubscriptionService struct {
+	repo   repository.WebhookSubscriptionRepository
+	logger *zap.Logger
+}
+
+// NewWebhookSubscriptionService creates a new webhook subscription service
+func NewWebhookSubscriptionService(repo repository.WebhookSubscriptionRepository, logger *zap.Logger) *WebhookSubscriptionService {
+	return &WebhookSubscriptionService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateWebhookSubscriptionInput represents input for creating a webhook subscription
+type CreateWebhookSubscriptionInput struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"` // Empty subscribes to every change event
+}
+
+// UpdateWebhookSubscriptionInput represents input for updating a webhook
+// subscription; nil fields are left unchanged
+type UpdateWebhookSubscriptionInput struct {
+	URL        *string   `json:"url"`
+	Secret     *string   `json:"secret"`
+	EventTypes *[]string `json:"eventTypes"`
+	Active     *bool     `json:"active"`
+}
+
+// Create creates an active webhook subscription
+func (s *WebhookSubscriptionService) Create(ctx context.Context, input CreateWebhookSubscriptionInput, userID string) (*entity.WebhookSubscription, error) {
+	now := time.Now()
+	subscription := &entity.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        input.URL,
+		Secret:     input.Secret,
+		EventTypes: input.EventTypes,
+		Active:     true,
+		CreatedAt:  now,
+		CreatedBy:  userID,
+		UpdatedAt:  now,
+		UpdatedBy:  userID,
+	}
+	if subscription.EventTypes == nil {
+		subscription.EventTypes = []string{}
+	}
+
+	if err := validateWebhookSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook subscription created",
+		zap.String("id", subscription.ID.String()),
+		zap.String("url", subscription.URL),
+		zap.String("user", userID))
+
+	return subscription, nil
+}
+
+// GetByID retrieves a webhook subscription by ID
+func (s *WebhookSubscriptionService) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// List retrieves every webhook subscription
+func (s *WebhookSubscriptionService) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return s.repo.List(ctx)
+}
+
+// Update updates a webhook subscription
+func (s *WebhookSubscriptionService) Update(ctx context.Context, id uuid.UUID, input UpdateWebhookSubscriptionInput, userID string) (*entity.WebhookSubscription, error) {
+	subscription, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		subscription.URL = *input.URL
+	}
+	if input.Secret != nil {
+		subscription.Secret = *input.Secret
+	}
+	if input.EventTypes != nil {
+		subscription.EventTypes = *input.EventTypes
+		if subscription.EventTypes == nil {
+			subscription.EventTypes = []string{}
+		}
+	}
+	if input.Active != nil {
+		subscription.Active = *input.Active
+	}
+	subscription.UpdatedAt = time.Now()
+	subscription.UpdatedBy = userID
+
+	if err := validateWebhookSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook subscription updated",
+		zap.String("id", subscription.ID.String()),
+		zap.String("user", userID))
+
+	return subscription, nil
+}
+
+// Delete deletes a webhook subscription
+func (s *WebhookSubscriptionService) Delete(ctx context.Context, id uuid.UUID, userID string) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Info("Webhook subscription deleted",
+		zap.String("id", id.String()),
+		zap.String("user", userID))
+
+	return nil
+}
+
+// validateWebhookSubscription validates the subscription and checks it only
+// names change events the dispatcher delivers
+func validateWebhookSubscription(subscription *entity.WebhookSubscription) error {
+	if err := subscription.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", entity.ErrValidationFailed, err)
+	}
+
+	for _, eventType := range subscription.EventTypes {
+		if !isChangeEventType(eventType) {
+			return fmt.Errorf("%w: unknown event type %q", entity.ErrValidationFailed, eventType)
+		}
+	}
+
+	return nil
+}
+
+// isChangeEventType reports whether eventType is one of messaging.ChangeEventTypes
+func isChangeEventType(eventType string) bool {
+	for _, t := range messaging.ChangeEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
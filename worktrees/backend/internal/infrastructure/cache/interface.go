@@ -10,8 +10,16 @@ type CacheService interface {
 	// Get retrieves a value from cache
 	Get(ctx context.Context, key string, dest interface{}) error
 	
+	// MGet retrieves the raw JSON values of several keys in one round-trip.
+	// The result is aligned with keys; misses are nil.
+	MGet(ctx context.Context, keys []string) ([][]byte, error)
+	
 	// Set stores a value in cache with TTL
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
+	// SetNX stores a value with TTL only if key is absent, reporting whether
+	// it was stored
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
 	
 	// Delete removes a value from cache
 	Delete(ctx context.Context, key string) error
@@ -4,24 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/openfoundry/oms/internal/domain/entity"
 	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
 	"go.uber.org/zap"
 )
 
-// RedisCache implements the Cache interface using Redis
+// RedisCache implements the CacheService interface using Redis. Keys and
+// patterns are namespaced by the tenant on the context, so tenants never
+// see or invalidate each other's entries.
 type RedisCache struct {
-	client *redis.Client
-	logger *zap.Logger
-	ttl    time.Duration
+	client  *redis.Client
+	metrics *metrics.Metrics
+	logger  *zap.Logger
+	ttl     time.Duration
 }
 
+var _ CacheService = (*RedisCache)(nil)
+
 // NewRedisCache creates a new Redis cache instance
-func NewRedisCache(addr, password string, db int, ttl time.Duration, logger *zap.Logger) (*RedisCache, error) {
+func NewRedisCache(addr, password string, db int, ttl time.Duration, m *metrics.Metrics, logger *zap.Logger) (*RedisCache, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:         addr,
 		Password:     password,
@@ -42,9 +49,10 @@ func NewRedisCache(addr, password string, db int, ttl time.Duration, logger *zap
 	}
 
 	return &RedisCache{
-		client: client,
-		logger: logger,
-		ttl:    ttl,
+		client:  client,
+		metrics: m,
+		logger:  logger,
+		ttl:     ttl,
 	}, nil
 }
 
@@ -59,7 +67,7 @@ func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl
 		ttl = c.ttl
 	}
 
-	err = c.client.Set(ctx, key, data, ttl).Err()
+	err = c.client.Set(ctx, tenantKey(ctx, key), data, ttl).Err()
 	if err != nil {
 		c.logger.Error("Failed to set cache value", 
 			zap.String("key", key),
@@ -71,18 +79,45 @@ func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl
 	return nil
 }
 
+// SetNX stores a value in the cache unless the key already exists
+func (c *RedisCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+
+	stored, err := c.client.SetNX(ctx, tenantKey(ctx, key), data, ttl).Result()
+	if err != nil {
+		c.logger.Error("Failed to set cache value",
+			zap.String("key", key),
+			zap.Duration("ttl", ttl),
+			zap.Error(err))
+		return false, fmt.Errorf("failed to set cache value: %w", err)
+	}
+
+	return stored, nil
+}
+
 // Get retrieves a value from the cache
 func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) error {
-	data, err := c.client.Get(ctx, key).Bytes()
+	data, err := c.client.Get(ctx, tenantKey(ctx, key)).Bytes()
 	if err != nil {
 		if err == redis.Nil {
+			c.metrics.ObserveCacheRequest(keyspace(key), metrics.CacheMiss)
+			c.logger.Debug("Cache miss", zap.String("key", key))
 			return repository.ErrCacheMiss
 		}
+		c.metrics.ObserveCacheRequest(keyspace(key), metrics.CacheError)
 		c.logger.Error("Failed to get cache value", 
 			zap.String("key", key),
 			zap.Error(err))
 		return fmt.Errorf("failed to get cache value: %w", err)
 	}
+	c.metrics.ObserveCacheRequest(keyspace(key), metrics.CacheHit)
 
 	err = json.Unmarshal(data, dest)
 	if err != nil {
@@ -92,9 +127,60 @@ func (c *RedisCache) Get(ctx context.Context, key string, dest interface{}) erro
 	return nil
 }
 
+// MGet retrieves the raw values of several keys in one round-trip
+func (c *RedisCache) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	scoped := make([]string, len(keys))
+	for i, key := range keys {
+		scoped[i] = tenantKey(ctx, key)
+	}
+
+	results, err := c.client.MGet(ctx, scoped...).Result()
+	if err != nil {
+		for _, key := range keys {
+			c.metrics.ObserveCacheRequest(keyspace(key), metrics.CacheError)
+		}
+		c.logger.Error("Failed to get cache values",
+			zap.Int("keys", len(keys)),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get cache values: %w", err)
+	}
+
+	for i, result := range results {
+		if value, ok := result.(string); ok {
+			values[i] = []byte(value)
+			c.metrics.ObserveCacheRequest(keyspace(keys[i]), metrics.CacheHit)
+		} else {
+			c.metrics.ObserveCacheRequest(keyspace(keys[i]), metrics.CacheMiss)
+			c.logger.Debug("Cache miss", zap.String("key", keys[i]))
+		}
+	}
+
+	return values, nil
+}
+
+// tenantKey returns key, or a key pattern, within the namespace of the tenant
+// on ctx
+func tenantKey(ctx context.Context, key string) string {
+	return "tenant:" + repository.TenantFromContext(ctx) + ":" + key
+}
+
+// keyspace returns the first segment of a cache key, e.g. "object_type" for
+// "object_type:<id>", bounding the label values of cache metrics
+func keyspace(key string) string {
+	if i := strings.IndexByte(key, ':'); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
 // Delete removes a value from the cache
 func (c *RedisCache) Delete(ctx context.Context, key string) error {
-	err := c.client.Del(ctx, key).Err()
+	err := c.client.Del(ctx, tenantKey(ctx, key)).Err()
 	if err != nil {
 		c.logger.Error("Failed to delete cache value", 
 			zap.String("key", key),
@@ -105,8 +191,8 @@ func (c *RedisCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
-// Invalidate removes multiple keys from the cache
-func (c *RedisCache) Invalidate(ctx context.Context, pattern string) error {
+// InvalidatePattern removes all keys matching a glob-style pattern from the cache
+func (c *RedisCache) InvalidatePattern(ctx context.Context, pattern string) error {
 	// Use SCAN to find all matching keys
 	var cursor uint64
 	var keys []string
@@ -114,7 +200,7 @@ func (c *RedisCache) Invalidate(ctx context.Context, pattern string) error {
 	for {
 		var batch []string
 		var err error
-		batch, cursor, err = c.client.Scan(ctx, cursor, pattern, 100).Result()
+		batch, cursor, err = c.client.Scan(ctx, cursor, tenantKey(ctx, pattern), 100).Result()
 		if err != nil {
 			c.logger.Error("Failed to scan keys", 
 				zap.String("pattern", pattern),
@@ -155,6 +241,19 @@ func (c *RedisCache) Invalidate(ctx context.Context, pattern string) error {
 	return nil
 }
 
+// Exists reports whether a key is present in the cache
+func (c *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
+	count, err := c.client.Exists(ctx, tenantKey(ctx, key)).Result()
+	if err != nil {
+		c.logger.Error("Failed to check cache key", 
+			zap.String("key", key),
+			zap.Error(err))
+		return false, fmt.Errorf("failed to check cache key: %w", err)
+	}
+
+	return count > 0, nil
+}
+
 // Close closes the Redis connection
 func (c *RedisCache) Close() error {
 	return c.client.Close()
@@ -230,12 +329,12 @@ func (c *ObjectTypeCache) Delete(ctx context.Context, objectType *entity.ObjectT
 	}
 
 	// Invalidate any list caches
-	return c.cache.Invalidate(ctx, c.prefix+"list:*")
+	return c.cache.InvalidatePattern(ctx, c.prefix+"list:*")
 }
 
 // InvalidateAll removes all object types from cache
 func (c *ObjectTypeCache) InvalidateAll(ctx context.Context) error {
-	return c.cache.Invalidate(ctx, c.prefix+"*")
+	return c.cache.InvalidatePattern(ctx, c.prefix+"*")
 }
 
 // LinkTypeCache provides caching for link types
@@ -308,10 +407,10 @@ func (c *LinkTypeCache) Delete(ctx context.Context, linkType *entity.LinkType) e
 	}
 
 	// Invalidate any list caches
-	return c.cache.Invalidate(ctx, c.prefix+"list:*")
+	return c.cache.InvalidatePattern(ctx, c.prefix+"list:*")
 }
 
 // InvalidateAll removes all link types from cache
 func (c *LinkTypeCache) InvalidateAll(ctx context.Context) error {
-	return c.cache.Invalidate(ctx, c.prefix+"*")
+	return c.cache.InvalidatePattern(ctx, c.prefix+"*")
 }
\ No newline at end of file
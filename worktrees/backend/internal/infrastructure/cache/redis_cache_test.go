@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// newTestRedisCache returns a RedisCache connected to an in-memory Redis
+func newTestRedisCache(t *testing.T) (*miniredis.Miniredis, *RedisCache) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	cache, err := NewRedisCache(server.Addr(), "", 0, time.Minute, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewRedisCache: %v", err)
+	}
+	t.Cleanup(func() { cache.Close() })
+	return server, cache
+}
+
+func TestExistsReportsStoredKey(t *testing.T) {
+	// Arrange
+	_, cache := newTestRedisCache(t)
+	ctx := context.Background()
+	if err := cache.Set(ctx, "object_type:present", "value", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// Act
+	exists, err := cache.Exists(ctx, "object_type:present")
+
+	// Assert
+	if err != nil || !exists {
+		t.Errorf("Exists = %v, %v, want true, nil", exists, err)
+	}
+}
+
+func TestExistsReportsMissingKeyWithoutError(t *testing.T) {
+	// Arrange
+	_, cache := newTestRedisCache(t)
+
+	// Act
+	exists, err := cache.Exists(context.Background(), "object_type:absent")
+
+	// Assert
+	if err != nil || exists {
+		t.Errorf("Exists = %v, %v, want false, nil", exists, err)
+	}
+}
+
+func TestExistsReturnsRedisError(t *testing.T) {
+	// Arrange
+	server, cache := newTestRedisCache(t)
+	server.Close()
+
+	// Act
+	_, err := cache.Exists(context.Background(), "object_type:present")
+
+	// Assert
+	if err == nil {
+		t.Error("Exists = nil error, want the Redis error")
+	}
+}
+
+func TestInvalidatePatternRemovesMatchingKeys(t *testing.T) {
+	// Arrange
+	_, cache := newTestRedisCache(t)
+	ctx := context.Background()
+	_ = cache.Set(ctx, "object_type:search:a", "value", 0)
+
+	// Act
+	_ = cache.InvalidatePattern(ctx, "object_type:search:*")
+
+	// Assert
+	if exists, _ := cache.Exists(ctx, "object_type:search:a"); exists {
+		t.Error("InvalidatePattern left object_type:search:a in the cache")
+	}
+}
+
+// cacheRequests returns the cache_requests_total count of the object_type
+// keyspace with result
+func cacheRequests(t *testing.T, cache *RedisCache, result string) float64 {
+	t.Helper()
+	var metric dto.Metric
+	if err := cache.metrics.CacheRequests.WithLabelValues("object_type", result).Write(&metric); err != nil {
+		t.Fatalf("read counter: %v", err)
+	}
+	return metric.GetCounter().GetValue()
+}
+
+func TestGetCountsHitsAndMisses(t *testing.T) {
+	// Arrange
+	_, cache := newTestRedisCache(t)
+	ctx := context.Background()
+	_ = cache.Set(ctx, "object_type:present", "value", 0)
+	var value string
+
+	// Act
+	_ = cache.Get(ctx, "object_type:present", &value)
+	err := cache.Get(ctx, "object_type:absent", &value)
+
+	// Assert
+	if !errors.Is(err, repository.ErrCacheMiss) {
+		t.Errorf("Get = %v, want ErrCacheMiss", err)
+	}
+	hits, misses := cacheRequests(t, cache, metrics.CacheHit), cacheRequests(t, cache, metrics.CacheMiss)
+	if hits != 1 || misses != 1 {
+		t.Errorf("hits = %v, misses = %v, want one of each", hits, misses)
+	}
+}
+
+func TestGetCountsRedisFailureAsError(t *testing.T) {
+	// Arrange
+	server, cache := newTestRedisCache(t)
+	server.Close()
+	var value string
+
+	// Act
+	err := cache.Get(context.Background(), "object_type:present", &value)
+
+	// Assert
+	if err == nil || errors.Is(err, repository.ErrCacheMiss) {
+		t.Errorf("Get = %v, want a Redis error", err)
+	}
+	if failures, misses := cacheRequests(t, cache, metrics.CacheError), cacheRequests(t, cache, metrics.CacheMiss); failures != 1 || misses != 0 {
+		t.Errorf("errors = %v, misses = %v, want the failure counted as an error", failures, misses)
+	}
+}
+
+func TestTenantsDoNotShareCacheKeys(t *testing.T) {
+	// Arrange
+	_, cache := newTestRedisCache(t)
+	acme := repository.WithTenant(context.Background(), "acme")
+	globex := repository.WithTenant(context.Background(), "globex")
+	_ = cache.Set(acme, "object_type:name:Customer", "acme customer", 0)
+	var value string
+
+	// Act
+	err := cache.Get(globex, "object_type:name:Customer", &value)
+	values, _ := cache.MGet(globex, []string{"object_type:name:Customer"})
+	stored, _ := cache.SetNX(globex, "object_type:name:Customer", "globex customer", 0)
+
+	// Assert
+	if !errors.Is(err, repository.ErrCacheMiss) || values[0] != nil {
+		t.Errorf("globex read acme's key: Get = %q, %v, MGet = %q", value, err, values)
+	}
+	if !stored {
+		t.Error("SetNX in globex found acme's key taken")
+	}
+	if err := cache.Get(acme, "object_type:name:Customer", &value); err != nil || value != "acme customer" {
+		t.Errorf("acme Get = %q, %v, want its own value", value, err)
+	}
+}
+
+func TestInvalidationStaysWithinTheTenant(t *testing.T) {
+	// Arrange
+	_, cache := newTestRedisCache(t)
+	acme := repository.WithTenant(context.Background(), "acme")
+	globex := repository.WithTenant(context.Background(), "globex")
+	_ = cache.Set(acme, "object_type:list:a", "value", 0)
+	_ = cache.Set(globex, "object_type:list:a", "value", 0)
+	_ = cache.Set(acme, "object_type:1", "value", 0)
+	_ = cache.Set(globex, "object_type:1", "value", 0)
+
+	// Act
+	_ = cache.InvalidatePattern(globex, "object_type:list:*")
+	_ = cache.Delete(globex, "object_type:1")
+
+	// Assert
+	for _, key := range []string{"object_type:list:a", "object_type:1"} {
+		if exists, _ := cache.Exists(acme, key); !exists {
+			t.Errorf("globex invalidation removed acme's %s", key)
+		}
+		if exists, _ := cache.Exists(globex, key); exists {
+			t.Errorf("globex invalidation left its %s", key)
+		}
+	}
+}
+
+func TestTenantPatternCannotReachOtherTenants(t *testing.T) {
+	// Arrange
+	_, cache := newTestRedisCache(t)
+	acme := repository.WithTenant(context.Background(), "acme")
+	_ = cache.Set(acme, "object_type:1", "value", 0)
+
+	// Act
+	_ = cache.InvalidatePattern(repository.WithTenant(context.Background(), "globex"), "*")
+
+	// Assert
+	if exists, _ := cache.Exists(acme, "object_type:1"); !exists {
+		t.Error("a wildcard invalidation in globex removed acme's key")
+	}
+}
@@ -0,0 +1,293 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// migrationsTable records the applied schema version. It has the layout used
+// by golang-migrate, so both tools can be used on the same database.
+const migrationsTable = "schema_migrations"
+
+// migrationFilePattern matches migration files such as 000001_create_object_types.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// nonTransactionalPattern matches statements PostgreSQL refuses to run inside
+// a transaction block, such as CREATE INDEX CONCURRENTLY
+var nonTransactionalPattern = regexp.MustCompile(`(?i)\bCONCURRENTLY\b`)
+
+// ErrDirtySchema is returned when a previous migration failed part way and
+// the schema must be repaired by hand
+var ErrDirtySchema = errors.New("database schema is dirty")
+
+// Migration is a numbered pair of up and down SQL scripts
+type Migration struct {
+	Version  int64
+	Name     string
+	UpPath   string
+	DownPath string
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version int64  `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+// Migrator applies and rolls back the SQL migrations in a directory
+type Migrator struct {
+	db     *sql.DB
+	dir    string
+	logger *zap.Logger
+}
+
+// NewMigrator creates a migrator for the migrations in dir
+func NewMigrator(db *sql.DB, dir string, logger *zap.Logger) *Migrator {
+	return &Migrator{
+		db:     db,
+		dir:    dir,
+		logger: logger,
+	}
+}
+
+// LoadMigrations reads the migrations in dir ordered by version. Every
+// version must have an up script; down scripts are optional.
+func LoadMigrations(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration directory: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d has conflicting names %s and %s",
+				version, migration.Name, match[2])
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if match[3] == "up" {
+			migration.UpPath = path
+		} else {
+			migration.DownPath = path
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpPath == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Version returns the applied schema version, 0 when no migration has been
+// applied, and whether the last migration failed part way
+func (m *Migrator) Version(ctx context.Context) (int64, bool, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, false, err
+	}
+
+	var version int64
+	var dirty bool
+	err := m.db.QueryRowContext(ctx, "SELECT version, dirty FROM "+migrationsTable+" LIMIT 1").Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// Status lists every migration with whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, migration := range migrations {
+		statuses[i] = MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: migration.Version <= version,
+		}
+	}
+	return statuses, nil
+}
+
+// Up applies every pending migration in order and returns the versions applied
+func (m *Migrator) Up(ctx context.Context) ([]int64, error) {
+	migrations, err := LoadMigrations(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	version, err := m.cleanVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []int64
+	for _, migration := range migrations {
+		if migration.Version <= version {
+			continue
+		}
+		if err := m.run(ctx, migration.UpPath, migration.Version); err != nil {
+			return applied, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		m.logger.Info("Migration applied",
+			zap.Int64("version", migration.Version),
+			zap.String("name", migration.Name))
+		applied = append(applied, migration.Version)
+	}
+	return applied, nil
+}
+
+// Down rolls back the last n applied migrations and returns the versions
+// rolled back
+func (m *Migrator) Down(ctx context.Context, n int) ([]int64, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("number of migrations to roll back must be at least 1: %d", n)
+	}
+
+	migrations, err := LoadMigrations(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	version, err := m.cleanVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rolledBack []int64
+	for i := len(migrations) - 1; i >= 0 && len(rolledBack) < n; i-- {
+		migration := migrations[i]
+		if migration.Version > version {
+			continue
+		}
+		if migration.DownPath == "" {
+			return rolledBack, fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+		}
+
+		// The schema is left at the previous migration, or empty
+		var previous int64
+		if i > 0 {
+			previous = migrations[i-1].Version
+		}
+		if err := m.run(ctx, migration.DownPath, previous); err != nil {
+			return rolledBack, fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		m.logger.Info("Migration rolled back",
+			zap.Int64("version", migration.Version),
+			zap.String("name", migration.Name))
+		rolledBack = append(rolledBack, migration.Version)
+	}
+	return rolledBack, nil
+}
+
+// cleanVersion returns the applied version, refusing to continue from a
+// dirty schema
+func (m *Migrator) cleanVersion(ctx context.Context) (int64, error) {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d: repair it and reset the version in %s",
+			ErrDirtySchema, version, migrationsTable)
+	}
+	return version, nil
+}
+
+// run executes the script at path and records version as the applied version.
+// Scripts run in a transaction together with the version change, so a failing
+// script leaves no trace. Scripts PostgreSQL cannot run in a transaction are
+// run on their own, with the version marked dirty until they succeed.
+func (m *Migrator) run(ctx context.Context, path string, version int64) error {
+	script, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read migration: %w", err)
+	}
+
+	if nonTransactionalPattern.Match(script) {
+		if err := m.setVersion(ctx, m.db, version, true); err != nil {
+			return err
+		}
+		if _, err := m.db.ExecContext(ctx, string(script)); err != nil {
+			return err
+		}
+		return m.setVersion(ctx, m.db, version, false)
+	}
+
+	return Transaction(m.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			return err
+		}
+		return m.setVersion(ctx, tx, version, false)
+	})
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// setVersion replaces the recorded version; version 0 records that no
+// migration is applied
+func (m *Migrator) setVersion(ctx context.Context, exec execer, version int64, dirty bool) error {
+	if _, err := exec.ExecContext(ctx, "DELETE FROM "+migrationsTable); err != nil {
+		return fmt.Errorf("failed to update schema version: %w", err)
+	}
+	if version == 0 && !dirty {
+		return nil
+	}
+	if _, err := exec.ExecContext(ctx,
+		"INSERT INTO "+migrationsTable+" (version, dirty) VALUES ($1, $2)", version, dirty); err != nil {
+		return fmt.Errorf("failed to update schema version: %w", err)
+	}
+	return nil
+}
+
+// ensureTable creates the version table on first use
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx,
+		"CREATE TABLE IF NOT EXISTS "+migrationsTable+" (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("failed to create %s table: %w", migrationsTable, err)
+	}
+	return nil
+}
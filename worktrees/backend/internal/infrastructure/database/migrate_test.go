@@ -0,0 +1,428 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// schemaState is the part of a database a migration changes: the row of
+// schema_migrations and the scripts run so far
+type schemaState struct {
+	hasVersion bool
+	version    int64
+	dirty      bool
+	scripts    []string
+}
+
+func (s schemaState) clone() schemaState {
+	s.scripts = append([]string(nil), s.scripts...)
+	return s
+}
+
+// schemaDB is an in-memory database/sql driver that understands the
+// statements of Migrator. Any other statement is taken to be a migration
+// script and recorded; scripts containing FAIL return an error. Work done in
+// a transaction is only kept when it commits.
+type schemaDB struct {
+	mu        sync.Mutex
+	state     schemaState
+	outsideTx []string
+}
+
+// newSchemaDB returns a schemaDB and a *sql.DB backed by it
+func newSchemaDB(t *testing.T) (*schemaDB, *sql.DB) {
+	t.Helper()
+	fake := &schemaDB{}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+	return fake, db
+}
+
+// scripts returns the scripts that have been run and kept
+func (f *schemaDB) scripts() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.state.scripts...)
+}
+
+func (f *schemaDB) Connect(ctx context.Context) (driver.Conn, error) { return &schemaConn{db: f}, nil }
+func (f *schemaDB) Driver() driver.Driver                            { return stubDriver{} }
+
+type schemaConn struct {
+	db *schemaDB
+	tx *schemaState
+}
+
+func (c *schemaConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("schemaDB does not prepare statements")
+}
+func (c *schemaConn) Close() error { return nil }
+
+func (c *schemaConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	staged := c.db.state.clone()
+	c.tx = &staged
+	return c, nil
+}
+
+func (c *schemaConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.state, c.tx = *c.tx, nil
+	return nil
+}
+
+func (c *schemaConn) Rollback() error {
+	c.tx = nil
+	return nil
+}
+
+func (c *schemaConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	state := &c.db.state
+	if c.tx != nil {
+		state = c.tx
+	}
+
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS "+migrationsTable):
+	case strings.HasPrefix(query, "DELETE FROM "+migrationsTable):
+		state.hasVersion = false
+	case strings.HasPrefix(query, "INSERT INTO "+migrationsTable):
+		state.hasVersion = true
+		state.version = args[0].Value.(int64)
+		state.dirty = args[1].Value.(bool)
+	case strings.Contains(query, "FAIL"):
+		return nil, errors.New("script failed")
+	default:
+		state.scripts = append(state.scripts, query)
+		if c.tx == nil {
+			c.db.outsideTx = append(c.db.outsideTx, query)
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *schemaConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	rows := &schemaRows{}
+	if c.db.state.hasVersion {
+		rows.values = [][]driver.Value{{c.db.state.version, c.db.state.dirty}}
+	}
+	return rows, nil
+}
+
+type schemaRows struct {
+	values [][]driver.Value
+}
+
+func (r *schemaRows) Columns() []string { return []string{"version", "dirty"} }
+func (r *schemaRows) Close() error      { return nil }
+
+func (r *schemaRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// writeMigrations writes files, named by file name, to a temporary migration
+// directory
+func writeMigrations(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, script := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// threeMigrations is a migration set with up and down scripts for versions
+// 1, 2 and 5
+func threeMigrations() map[string]string {
+	return map[string]string{
+		"000001_create_a.up.sql":   "CREATE TABLE a ()",
+		"000001_create_a.down.sql": "DROP TABLE a",
+		"000002_create_b.up.sql":   "CREATE TABLE b ()",
+		"000002_create_b.down.sql": "DROP TABLE b",
+		"000005_create_c.up.sql":   "CREATE TABLE c ()",
+		"000005_create_c.down.sql": "DROP TABLE c",
+		"README.md":                "not a migration",
+	}
+}
+
+// newTestMigrator returns a migrator over a schemaDB for the migrations in files
+func newTestMigrator(t *testing.T, files map[string]string) (*schemaDB, *Migrator) {
+	t.Helper()
+	fake, db := newSchemaDB(t)
+	return fake, NewMigrator(db, writeMigrations(t, files), zap.NewNop())
+}
+
+func TestLoadMigrationsOrdersByVersion(t *testing.T) {
+	// Arrange
+	dir := writeMigrations(t, threeMigrations())
+
+	// Act
+	migrations, err := LoadMigrations(dir)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	var versions []int64
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+	}
+	if !reflect.DeepEqual(versions, []int64{1, 2, 5}) {
+		t.Errorf("versions = %v, want [1 2 5]", versions)
+	}
+	if migrations[1].Name != "create_b" || migrations[1].DownPath == "" {
+		t.Errorf("migration 2 = %+v, want create_b with a down script", migrations[1])
+	}
+}
+
+func TestLoadMigrationsRejectsInvalidSets(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string]string
+	}{
+		{"down script only", map[string]string{"000001_a.down.sql": "DROP TABLE a"}},
+		{"conflicting names", map[string]string{"000001_a.up.sql": "CREATE TABLE a ()", "000001_b.down.sql": "DROP TABLE b"}},
+	}
+
+	for _, tc := range cases {
+		// Act
+		_, err := LoadMigrations(writeMigrations(t, tc.files))
+
+		// Assert
+		if err == nil {
+			t.Errorf("%s: LoadMigrations = nil error, want an error", tc.name)
+		}
+	}
+}
+
+func TestShippedMigrationsLoadWithDownScripts(t *testing.T) {
+	// Act
+	migrations, err := LoadMigrations("migrations")
+
+	// Assert
+	if err != nil || len(migrations) == 0 {
+		t.Fatalf("LoadMigrations = %d migrations, %v, want the shipped set", len(migrations), err)
+	}
+	for i, migration := range migrations {
+		if migration.Version != int64(i+1) || migration.DownPath == "" {
+			t.Errorf("migration %d_%s: want version %d with a down script", migration.Version, migration.Name, i+1)
+		}
+	}
+}
+
+func TestUpAppliesPendingMigrationsInOrder(t *testing.T) {
+	// Arrange
+	fake, migrator := newTestMigrator(t, threeMigrations())
+	ctx := context.Background()
+
+	// Act
+	applied, err := migrator.Up(ctx)
+
+	// Assert
+	if err != nil || !reflect.DeepEqual(applied, []int64{1, 2, 5}) {
+		t.Fatalf("Up = %v, %v, want [1 2 5]", applied, err)
+	}
+	want := []string{"CREATE TABLE a ()", "CREATE TABLE b ()", "CREATE TABLE c ()"}
+	if got := fake.scripts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("scripts = %v, want %v", got, want)
+	}
+	if len(fake.outsideTx) != 0 {
+		t.Errorf("scripts run outside a transaction = %v, want none", fake.outsideTx)
+	}
+	if version, dirty, _ := migrator.Version(ctx); version != 5 || dirty {
+		t.Errorf("Version = %d (dirty %v), want 5", version, dirty)
+	}
+}
+
+func TestUpSkipsAppliedMigrations(t *testing.T) {
+	// Arrange
+	fake, migrator := newTestMigrator(t, threeMigrations())
+	ctx := context.Background()
+	_, _ = migrator.Up(ctx)
+
+	// Act
+	applied, err := migrator.Up(ctx)
+
+	// Assert
+	if err != nil || len(applied) != 0 {
+		t.Errorf("second Up = %v, %v, want nothing applied", applied, err)
+	}
+	if got := len(fake.scripts()); got != 3 {
+		t.Errorf("scripts run = %d, want each migration once", got)
+	}
+}
+
+func TestStatusReportsAppliedVersions(t *testing.T) {
+	// Arrange
+	_, migrator := newTestMigrator(t, threeMigrations())
+	ctx := context.Background()
+	_, _ = migrator.Up(ctx)
+	_, _ = migrator.Down(ctx, 1)
+
+	// Act
+	statuses, err := migrator.Status(ctx)
+
+	// Assert
+	want := []MigrationStatus{
+		{Version: 1, Name: "create_a", Applied: true},
+		{Version: 2, Name: "create_b", Applied: true},
+		{Version: 5, Name: "create_c", Applied: false},
+	}
+	if err != nil || !reflect.DeepEqual(statuses, want) {
+		t.Errorf("Status = %+v, %v, want %+v", statuses, err, want)
+	}
+}
+
+func TestDownRollsBackTheLastMigrations(t *testing.T) {
+	// Arrange
+	fake, migrator := newTestMigrator(t, threeMigrations())
+	ctx := context.Background()
+	_, _ = migrator.Up(ctx)
+
+	// Act
+	rolledBack, err := migrator.Down(ctx, 2)
+
+	// Assert
+	if err != nil || !reflect.DeepEqual(rolledBack, []int64{5, 2}) {
+		t.Fatalf("Down = %v, %v, want [5 2]", rolledBack, err)
+	}
+	if scripts := fake.scripts(); !reflect.DeepEqual(scripts[3:], []string{"DROP TABLE c", "DROP TABLE b"}) {
+		t.Errorf("rollback scripts = %v, want c then b dropped", scripts[3:])
+	}
+	if version, _, _ := migrator.Version(ctx); version != 1 {
+		t.Errorf("Version = %d, want 1", version)
+	}
+}
+
+func TestDownPastTheFirstMigrationLeavesNoVersion(t *testing.T) {
+	// Arrange
+	_, migrator := newTestMigrator(t, threeMigrations())
+	ctx := context.Background()
+	_, _ = migrator.Up(ctx)
+
+	// Act
+	rolledBack, err := migrator.Down(ctx, 10)
+
+	// Assert
+	if err != nil || len(rolledBack) != 3 {
+		t.Errorf("Down = %v, %v, want all three rolled back", rolledBack, err)
+	}
+	if version, dirty, _ := migrator.Version(ctx); version != 0 || dirty {
+		t.Errorf("Version = %d (dirty %v), want 0", version, dirty)
+	}
+}
+
+func TestDownRejectsNonPositiveCount(t *testing.T) {
+	// Arrange
+	_, migrator := newTestMigrator(t, threeMigrations())
+
+	// Act
+	_, err := migrator.Down(context.Background(), 0)
+
+	// Assert
+	if err == nil {
+		t.Error("Down(0) = nil error, want an error")
+	}
+}
+
+func TestDownWithoutDownScriptFails(t *testing.T) {
+	// Arrange
+	_, migrator := newTestMigrator(t, map[string]string{"000001_create_a.up.sql": "CREATE TABLE a ()"})
+	ctx := context.Background()
+	_, _ = migrator.Up(ctx)
+
+	// Act
+	_, err := migrator.Down(ctx, 1)
+
+	// Assert
+	if err == nil {
+		t.Error("Down = nil error, want the missing down script reported")
+	}
+	if version, _, _ := migrator.Version(ctx); version != 1 {
+		t.Errorf("Version = %d, want 1 still applied", version)
+	}
+}
+
+func TestFailedMigrationRollsBackItsTransaction(t *testing.T) {
+	// Arrange
+	files := threeMigrations()
+	files["000002_create_b.up.sql"] = "CREATE TABLE b (); FAIL"
+	fake, migrator := newTestMigrator(t, files)
+	ctx := context.Background()
+
+	// Act
+	applied, err := migrator.Up(ctx)
+
+	// Assert
+	if err == nil || !reflect.DeepEqual(applied, []int64{1}) {
+		t.Errorf("Up = %v, %v, want only 1 applied and an error", applied, err)
+	}
+	if version, dirty, _ := migrator.Version(ctx); version != 1 || dirty {
+		t.Errorf("Version = %d (dirty %v), want a clean 1", version, dirty)
+	}
+	if got := fake.scripts(); len(got) != 1 {
+		t.Errorf("scripts kept = %v, want only the first migration", got)
+	}
+}
+
+func TestConcurrentIndexRunsOutsideTransaction(t *testing.T) {
+	// Arrange
+	script := "CREATE INDEX CONCURRENTLY idx_a ON a (id)"
+	fake, migrator := newTestMigrator(t, map[string]string{"000001_index_a.up.sql": script})
+	ctx := context.Background()
+
+	// Act
+	_, err := migrator.Up(ctx)
+
+	// Assert
+	if err != nil || !reflect.DeepEqual(fake.outsideTx, []string{script}) {
+		t.Errorf("Up = %v, scripts outside a transaction = %v, want the index script", err, fake.outsideTx)
+	}
+	if version, dirty, _ := migrator.Version(ctx); version != 1 || dirty {
+		t.Errorf("Version = %d (dirty %v), want a clean 1", version, dirty)
+	}
+}
+
+func TestFailedConcurrentIndexLeavesSchemaDirty(t *testing.T) {
+	// Arrange
+	_, migrator := newTestMigrator(t, map[string]string{
+		"000001_index_a.up.sql": "CREATE INDEX CONCURRENTLY idx_a ON a (id); FAIL",
+	})
+	ctx := context.Background()
+	_, _ = migrator.Up(ctx)
+
+	// Act
+	_, err := migrator.Up(ctx)
+
+	// Assert
+	if !errors.Is(err, ErrDirtySchema) {
+		t.Errorf("Up after a failed index = %v, want ErrDirtySchema", err)
+	}
+	if version, dirty, _ := migrator.Version(ctx); version != 1 || !dirty {
+		t.Errorf("Version = %d (dirty %v), want a dirty 1", version, dirty)
+	}
+}
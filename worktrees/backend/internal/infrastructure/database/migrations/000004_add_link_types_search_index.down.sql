@@ -0,0 +1,2 @@
+-- Drop link type full-text search index
+DROP INDEX IF EXISTS idx_link_types_search;
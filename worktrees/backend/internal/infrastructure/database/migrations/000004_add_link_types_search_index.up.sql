@@ -0,0 +1,4 @@
+-- Full-text search index for link types
+CREATE INDEX IF NOT EXISTS idx_link_types_search ON link_types
+USING GIN (to_tsvector('english', name || ' ' || display_name || ' ' || COALESCE(description, '')))
+WHERE is_deleted = FALSE;
@@ -0,0 +1,5 @@
+-- Drop object type inheritance
+DROP INDEX IF EXISTS idx_object_types_parent_id;
+ALTER TABLE object_types
+    DROP CONSTRAINT IF EXISTS object_type_not_own_parent,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,6 @@
+-- Object type inheritance: a child type extends its parent's properties
+ALTER TABLE object_types
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES object_types(id),
+    ADD CONSTRAINT object_type_not_own_parent CHECK (parent_id IS NULL OR parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_object_types_parent_id ON object_types(parent_id) WHERE is_deleted = FALSE;
@@ -0,0 +1,2 @@
+-- Drop requested instance storage indexes
+DROP TABLE IF EXISTS object_type_indexes;
@@ -0,0 +1,15 @@
+-- Indexes requested on instance storage for indexed/unique properties,
+-- consumed by the downstream materializer
+CREATE TABLE IF NOT EXISTS object_type_indexes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    object_type_id UUID NOT NULL REFERENCES object_types(id) ON DELETE CASCADE,
+    property_name VARCHAR(64) NOT NULL,
+    index_name VARCHAR(63) NOT NULL,
+    is_unique BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+
+    UNIQUE(object_type_id, property_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_object_type_indexes_object_type_id ON object_type_indexes(object_type_id);
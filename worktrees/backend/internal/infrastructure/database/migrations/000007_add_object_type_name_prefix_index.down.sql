@@ -0,0 +1,2 @@
+-- Drop object type name prefix index
+DROP INDEX IF EXISTS idx_object_types_name_prefix;
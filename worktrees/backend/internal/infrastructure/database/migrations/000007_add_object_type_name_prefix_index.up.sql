@@ -0,0 +1,3 @@
+-- Case-insensitive prefix index for object type name suggestions
+CREATE INDEX IF NOT EXISTS idx_object_types_name_prefix ON object_types (lower(name) text_pattern_ops)
+WHERE is_deleted = FALSE;
@@ -0,0 +1,4 @@
+-- Fails if a deleted and a live object type share a name; purge one first
+DROP INDEX IF EXISTS idx_object_types_name;
+CREATE INDEX IF NOT EXISTS idx_object_types_name ON object_types(name) WHERE is_deleted = FALSE;
+ALTER TABLE object_types ADD CONSTRAINT object_types_name_key UNIQUE (name);
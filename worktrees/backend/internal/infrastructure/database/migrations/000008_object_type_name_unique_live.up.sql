@@ -0,0 +1,6 @@
+-- Names only need to be unique among live object types, so the name of a
+-- soft-deleted type can be reused. Restoring a type whose name has been
+-- reused is refused by the application.
+ALTER TABLE object_types DROP CONSTRAINT IF EXISTS object_types_name_key;
+DROP INDEX IF EXISTS idx_object_types_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_object_types_name ON object_types(name) WHERE is_deleted = FALSE;
@@ -0,0 +1,2 @@
+-- Drop link type constraints
+ALTER TABLE link_types DROP COLUMN IF EXISTS constraints;
@@ -0,0 +1,2 @@
+-- Delete behaviour of link types towards their endpoint object types
+ALTER TABLE link_types ADD COLUMN IF NOT EXISTS constraints JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,16 @@
+-- Published domain events, kept so consumers can rebuild state by replaying
+-- an aggregate's events. IDs are ULIDs, so ordering by id is publish order.
+CREATE TABLE IF NOT EXISTS events (
+    id VARCHAR(26) PRIMARY KEY,
+    event_type VARCHAR(255) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    aggregate_type VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL DEFAULT 0,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    data JSONB,
+    metadata JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_aggregate_id ON events (aggregate_id, id);
+CREATE INDEX IF NOT EXISTS idx_events_event_type ON events (event_type, id);
@@ -0,0 +1,4 @@
+-- Drop link type pairing
+DROP INDEX IF EXISTS idx_link_types_pair;
+ALTER TABLE link_types DROP COLUMN IF EXISTS is_inverse;
+ALTER TABLE link_types DROP COLUMN IF EXISTS pair_id;
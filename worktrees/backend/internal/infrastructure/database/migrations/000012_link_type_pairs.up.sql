@@ -0,0 +1,5 @@
+-- A link type created with an inverse points at its companion through
+-- pair_id; is_inverse marks the generated side, which cycle checks skip
+ALTER TABLE link_types ADD COLUMN IF NOT EXISTS pair_id UUID;
+ALTER TABLE link_types ADD COLUMN IF NOT EXISTS is_inverse BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_link_types_pair ON link_types(pair_id) WHERE pair_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Drop object type metadata index
+DROP INDEX IF EXISTS idx_object_types_metadata;
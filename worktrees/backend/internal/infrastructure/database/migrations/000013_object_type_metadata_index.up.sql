@@ -0,0 +1,3 @@
+-- GIN index serving metadata containment filters (metadata @> '{"key": "value"}')
+CREATE INDEX IF NOT EXISTS idx_object_types_metadata ON object_types USING GIN (metadata jsonb_path_ops)
+WHERE is_deleted = FALSE;
@@ -0,0 +1,2 @@
+-- Drop webhook subscriptions table
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,5 @@
+-- Drop audit log immutability and correlation ID
+DROP TRIGGER IF EXISTS audit_logs_immutable ON audit_logs;
+DROP FUNCTION IF EXISTS reject_audit_log_change();
+DROP INDEX IF EXISTS idx_audit_logs_correlation_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS correlation_id;
@@ -0,0 +1,14 @@
+-- Fails if two tenants share an object or link type name; purge or rename first
+DROP INDEX IF EXISTS idx_link_types_tenant_created_at;
+DROP INDEX IF EXISTS idx_object_types_tenant_created_at;
+
+DROP INDEX IF EXISTS idx_link_types_tenant_name;
+CREATE INDEX IF NOT EXISTS idx_link_types_name ON link_types(name) WHERE is_deleted = FALSE;
+ALTER TABLE link_types DROP CONSTRAINT IF EXISTS link_types_tenant_name_key;
+ALTER TABLE link_types ADD CONSTRAINT link_types_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_object_types_tenant_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_object_types_name ON object_types(name) WHERE is_deleted = FALSE;
+
+ALTER TABLE link_types DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE object_types DROP COLUMN IF EXISTS tenant_id;
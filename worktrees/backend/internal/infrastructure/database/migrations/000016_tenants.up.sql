@@ -0,0 +1,15 @@
+-- Scope object and link types to tenants. Existing definitions belong to the
+-- default tenant, and names only need to be unique within a tenant.
+ALTER TABLE object_types ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE link_types ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+DROP INDEX IF EXISTS idx_object_types_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_object_types_tenant_name ON object_types(tenant_id, name) WHERE is_deleted = FALSE;
+
+ALTER TABLE link_types DROP CONSTRAINT IF EXISTS link_types_name_key;
+ALTER TABLE link_types ADD CONSTRAINT link_types_tenant_name_key UNIQUE (tenant_id, name);
+DROP INDEX IF EXISTS idx_link_types_name;
+CREATE INDEX IF NOT EXISTS idx_link_types_tenant_name ON link_types(tenant_id, name) WHERE is_deleted = FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_object_types_tenant_created_at ON object_types(tenant_id, created_at DESC) WHERE is_deleted = FALSE;
+CREATE INDEX IF NOT EXISTS idx_link_types_tenant_created_at ON link_types(tenant_id, created_at DESC) WHERE is_deleted = FALSE;
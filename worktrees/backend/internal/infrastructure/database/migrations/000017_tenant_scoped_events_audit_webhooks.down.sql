@@ -0,0 +1,15 @@
+-- Drop tenant scoping of events, audit entries, webhook subscriptions and API keys
+DROP INDEX IF EXISTS idx_webhook_subscriptions_tenant_active;
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_active ON webhook_subscriptions(created_at) WHERE active = TRUE;
+
+DROP INDEX IF EXISTS idx_audit_logs_tenant_created_at;
+
+DROP INDEX IF EXISTS idx_events_tenant_event_type;
+CREATE INDEX IF NOT EXISTS idx_events_event_type ON events (event_type, id);
+DROP INDEX IF EXISTS idx_events_tenant_aggregate_id;
+CREATE INDEX IF NOT EXISTS idx_events_aggregate_id ON events (aggregate_id, id);
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhook_subscriptions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE events DROP COLUMN IF EXISTS tenant_id;
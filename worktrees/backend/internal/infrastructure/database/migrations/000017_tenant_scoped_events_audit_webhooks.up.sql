@@ -0,0 +1,16 @@
+-- Scope events, audit entries, webhook subscriptions and API keys to
+-- tenants. Existing rows belong to the default tenant.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+DROP INDEX IF EXISTS idx_events_aggregate_id;
+CREATE INDEX IF NOT EXISTS idx_events_tenant_aggregate_id ON events (tenant_id, aggregate_id, id);
+DROP INDEX IF EXISTS idx_events_event_type;
+CREATE INDEX IF NOT EXISTS idx_events_tenant_event_type ON events (tenant_id, event_type, id);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_tenant_created_at ON audit_logs(tenant_id, created_at DESC);
+
+DROP INDEX IF EXISTS idx_webhook_subscriptions_active;
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_tenant_active ON webhook_subscriptions(tenant_id, created_at) WHERE active = TRUE;
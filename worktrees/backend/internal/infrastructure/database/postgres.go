@@ -12,20 +12,24 @@ import (
 
 // NewPostgresDB creates a new PostgreSQL database connection
 func NewPostgresDB(cfg config.DatabaseConfig) (*sql.DB, error) {
-	// Build connection string
-	dsn := cfg.GetDSN()
+	return openPostgresDB(cfg.GetDSN(), cfg)
+}
+
+// NewPostgresReplicaDB connects to the read replica at cfg.ReplicaDSN with
+// the same pool settings as the primary
+func NewPostgresReplicaDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	return openPostgresDB(cfg.ReplicaDSN, cfg)
+}
 
+// openPostgresDB opens and pings the database at dsn
+func openPostgresDB(dsn string, cfg config.DatabaseConfig) (*sql.DB, error) {
 	// Open database connection
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Configure connection pool
-	db.SetMaxOpenConns(cfg.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.MaxIdleConns)
-	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
-	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	configurePool(db, cfg)
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -39,6 +43,14 @@ func NewPostgresDB(cfg config.DatabaseConfig) (*sql.DB, error) {
 	return db, nil
 }
 
+// configurePool applies the connection pool settings of cfg to db
+func configurePool(db *sql.DB, cfg config.DatabaseConfig) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
 // Transaction executes a function within a database transaction
 func Transaction(db *sql.DB, fn func(*sql.Tx) error) error {
 	tx, err := db.Begin()
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openfoundry/oms/internal/config"
+)
+
+// stubConnector opens connections that support nothing but being pooled
+type stubConnector struct{}
+
+func (stubConnector) Connect(ctx context.Context) (driver.Conn, error) { return stubConn{}, nil }
+func (stubConnector) Driver() driver.Driver                            { return stubDriver{} }
+
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) { return stubConn{}, nil }
+
+type stubConn struct{}
+
+func (stubConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("stub connections run no statements")
+}
+func (stubConn) Close() error { return nil }
+func (stubConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("stub connections run no transactions")
+}
+
+// pooledDB returns a database over stub connections configured with cfg
+func pooledDB(t *testing.T, cfg config.DatabaseConfig) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(stubConnector{})
+	t.Cleanup(func() { db.Close() })
+	configurePool(db, cfg)
+	return db
+}
+
+// checkout takes n connections from db at once and returns them to the pool
+func checkout(t *testing.T, db *sql.DB, n int) {
+	t.Helper()
+	conns := make([]*sql.Conn, n)
+	for i := range conns {
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			t.Fatalf("Conn: %v", err)
+		}
+		conns[i] = conn
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+func TestConfigurePoolSetsMaxOpenConns(t *testing.T) {
+	// Act
+	db := pooledDB(t, config.DatabaseConfig{MaxOpenConns: 7})
+
+	// Assert
+	if max := db.Stats().MaxOpenConnections; max != 7 {
+		t.Errorf("MaxOpenConnections = %d, want 7", max)
+	}
+}
+
+func TestConfigurePoolSetsMaxIdleConns(t *testing.T) {
+	// Arrange
+	db := pooledDB(t, config.DatabaseConfig{MaxOpenConns: 10, MaxIdleConns: 2})
+
+	// Act
+	checkout(t, db, 5)
+
+	// Assert
+	if stats := db.Stats(); stats.Idle != 2 || stats.MaxIdleClosed != 3 {
+		t.Errorf("idle = %d, closed for idling = %d; want 2 kept and 3 closed", stats.Idle, stats.MaxIdleClosed)
+	}
+}
+
+func TestConfigurePoolSetsConnMaxLifetime(t *testing.T) {
+	// Arrange
+	db := pooledDB(t, config.DatabaseConfig{MaxOpenConns: 1, MaxIdleConns: 1, ConnMaxLifetime: 10 * time.Millisecond})
+	checkout(t, db, 1)
+	time.Sleep(20 * time.Millisecond)
+
+	// Act
+	checkout(t, db, 1)
+
+	// Assert
+	if closed := db.Stats().MaxLifetimeClosed; closed < 1 {
+		t.Errorf("closed for lifetime = %d, want the expired connection replaced", closed)
+	}
+}
+
+func TestConfigurePoolSetsConnMaxIdleTime(t *testing.T) {
+	// Arrange
+	db := pooledDB(t, config.DatabaseConfig{MaxOpenConns: 1, MaxIdleConns: 1, ConnMaxIdleTime: 10 * time.Millisecond})
+
+	// Act
+	checkout(t, db, 1)
+
+	// Assert
+	deadline := time.Now().Add(3 * time.Second)
+	for db.Stats().MaxIdleTimeClosed == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("idle connection was never closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the user making the request, so
+// ReadWriteSplitter can route their reads after a write to the primary
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx, if any
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// ReadWriteSplitter routes writes to the primary database and read-only
+// queries to a replica. With a read-your-writes window set, an actor's
+// reads go to the primary for that long after each of their writes, so
+// they see their own changes despite replication lag.
+type ReadWriteSplitter struct {
+	primary *sql.DB
+	replica *sql.DB
+	// readYourWrites is how long an actor's reads stay on the primary after
+	// a write; 0 always reads from the replica
+	readYourWrites time.Duration
+
+	mu         sync.Mutex
+	lastWrites map[string]time.Time
+}
+
+// NewReadWriteSplitter creates a splitter over primary and replica. A nil
+// replica sends every query to the primary.
+func NewReadWriteSplitter(primary, replica *sql.DB, readYourWrites time.Duration) *ReadWriteSplitter {
+	return &ReadWriteSplitter{
+		primary:        primary,
+		replica:        replica,
+		readYourWrites: readYourWrites,
+		lastWrites:     make(map[string]time.Time),
+	}
+}
+
+// Writer returns the primary and records a write by the actor in ctx
+func (s *ReadWriteSplitter) Writer(ctx context.Context) *sql.DB {
+	if s.replica == nil || s.readYourWrites <= 0 {
+		return s.primary
+	}
+	actor := ActorFromContext(ctx)
+	if actor == "" {
+		return s.primary
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for a, at := range s.lastWrites {
+		if now.Sub(at) >= s.readYourWrites {
+			delete(s.lastWrites, a)
+		}
+	}
+	s.lastWrites[actor] = now
+	return s.primary
+}
+
+// Reader returns the replica, or the primary when there is no replica or
+// the actor in ctx wrote within the read-your-writes window
+func (s *ReadWriteSplitter) Reader(ctx context.Context) *sql.DB {
+	if s.replica == nil {
+		return s.primary
+	}
+	if s.readYourWrites > 0 {
+		if actor := ActorFromContext(ctx); actor != "" {
+			s.mu.Lock()
+			at, ok := s.lastWrites[actor]
+			s.mu.Unlock()
+			if ok && time.Since(at) < s.readYourWrites {
+				return s.primary
+			}
+		}
+	}
+	return s.replica
+}
+
+// Primary returns the primary database
+func (s *ReadWriteSplitter) Primary() *sql.DB {
+	return s.primary
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+// newStubDB returns a *sql.DB whose identity is all a splitter test needs
+func newStubDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(stubConnector{})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSplitterReadsFromReplicaAndWritesToPrimary(t *testing.T) {
+	// Arrange
+	primary, replica := newStubDB(t), newStubDB(t)
+	splitter := NewReadWriteSplitter(primary, replica, 0)
+	ctx := WithActor(context.Background(), "alice")
+
+	// Act
+	writer := splitter.Writer(ctx)
+	reader := splitter.Reader(ctx)
+
+	// Assert
+	if writer != primary {
+		t.Error("Writer did not return the primary")
+	}
+	if reader != replica {
+		t.Error("Reader after a write did not return the replica without a read-your-writes window")
+	}
+	if splitter.Primary() != primary {
+		t.Error("Primary did not return the primary")
+	}
+}
+
+func TestSplitterWithoutReplicaUsesPrimary(t *testing.T) {
+	// Arrange
+	primary := newStubDB(t)
+	splitter := NewReadWriteSplitter(primary, nil, time.Minute)
+
+	// Act
+	reader := splitter.Reader(context.Background())
+
+	// Assert
+	if reader != primary || splitter.Writer(context.Background()) != primary {
+		t.Error("reads and writes did not both go to the primary")
+	}
+}
+
+func TestSplitterReadsYourWritesFromPrimary(t *testing.T) {
+	// Arrange
+	primary, replica := newStubDB(t), newStubDB(t)
+	splitter := NewReadWriteSplitter(primary, replica, time.Hour)
+	alice := WithActor(context.Background(), "alice")
+	bob := WithActor(context.Background(), "bob")
+
+	// Act
+	before := splitter.Reader(alice)
+	splitter.Writer(alice)
+
+	// Assert
+	if before != replica {
+		t.Error("alice read from the primary before writing")
+	}
+	if splitter.Reader(alice) != primary {
+		t.Error("alice did not read her own write from the primary")
+	}
+	if splitter.Reader(bob) != replica {
+		t.Error("bob read from the primary after alice's write")
+	}
+	if splitter.Reader(context.Background()) != replica {
+		t.Error("an anonymous read went to the primary")
+	}
+}
+
+func TestSplitterReadsReplicaAfterWindow(t *testing.T) {
+	// Arrange
+	primary, replica := newStubDB(t), newStubDB(t)
+	splitter := NewReadWriteSplitter(primary, replica, 5*time.Millisecond)
+	alice := WithActor(context.Background(), "alice")
+	splitter.Writer(alice)
+
+	// Act
+	time.Sleep(10 * time.Millisecond)
+	reader := splitter.Reader(alice)
+
+	// Assert
+	if reader != replica {
+		t.Error("alice still read from the primary after the window")
+	}
+}
+
+func TestSplitterForgetsExpiredWrites(t *testing.T) {
+	// Arrange
+	primary, replica := newStubDB(t), newStubDB(t)
+	splitter := NewReadWriteSplitter(primary, replica, 5*time.Millisecond)
+	splitter.Writer(WithActor(context.Background(), "alice"))
+	time.Sleep(10 * time.Millisecond)
+
+	// Act
+	splitter.Writer(WithActor(context.Background(), "bob"))
+
+	// Assert
+	if _, ok := splitter.lastWrites["alice"]; ok || len(splitter.lastWrites) != 1 {
+		t.Errorf("tracked writers = %v, want only bob", splitter.lastWrites)
+	}
+}
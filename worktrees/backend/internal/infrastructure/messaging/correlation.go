@@ -0,0 +1,18 @@
+package messaging
+
+import "context"
+
+// correlationIDKey is the context key for the correlation ID
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context carrying the correlation ID that
+// events published under it are tagged with
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID on ctx, or "" if none
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
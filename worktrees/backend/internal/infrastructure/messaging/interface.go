@@ -12,6 +12,13 @@ const (
 	EventObjectTypeCreated EventType = "ObjectTypeCreated"
 	EventObjectTypeUpdated EventType = "ObjectTypeUpdated"
 	EventObjectTypeDeleted EventType = "ObjectTypeDeleted"
+	EventObjectTypePurged  EventType = "ObjectTypePurged"
+	EventObjectTypeRestored EventType = "ObjectTypeRestored"
+	EventObjectTypeIndexesChanged EventType = "ObjectTypeIndexesChanged"
+	EventObjectTypeUniquePropertiesChanged EventType = "ObjectTypeUniquePropertiesChanged"
+	EventPropertyAdded     EventType = "PropertyAdded"
+	EventPropertyUpdated   EventType = "PropertyUpdated"
+	EventPropertyRemoved   EventType = "PropertyRemoved"
 	EventLinkTypeCreated   EventType = "LinkTypeCreated"
 	EventLinkTypeUpdated   EventType = "LinkTypeUpdated"
 	EventLinkTypeDeleted   EventType = "LinkTypeDeleted"
@@ -27,6 +34,8 @@ type Event struct {
 	Data          interface{}            `json:"data"`
 	Metadata      map[string]interface{} `json:"metadata"`
 	CorrelationID string                 `json:"correlationId,omitempty"`
+	// TenantID is the tenant the changed entity belongs to
+	TenantID string `json:"tenantId,omitempty"`
 }
 
 // EventPublisher defines the interface for publishing events
@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/openfoundry/oms/internal/config"
 	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
@@ -41,13 +45,16 @@ func NewKafkaPublisher(brokers []string, topic string, logger *zap.Logger) *Kafk
 
 // Publish publishes an event to Kafka
 func (p *KafkaPublisher) Publish(ctx context.Context, evt event.Event) error {
+	evt = withTenant(ctx, evt)
+
 	// Marshal event data
 	data, err := json.Marshal(evt)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	// Create Kafka message
+	// Keyed by aggregate so one aggregate's events stay ordered in a
+	// partition; the event ID orders events across aggregates
 	message := kafka.Message{
 		Key:   []byte(evt.AggregateID),
 		Value: data,
@@ -55,9 +62,11 @@ func (p *KafkaPublisher) Publish(ctx context.Context, evt event.Event) error {
 			{Key: "event_type", Value: []byte(evt.EventType)},
 			{Key: "aggregate_type", Value: []byte(evt.AggregateType)},
 			{Key: "version", Value: []byte(fmt.Sprintf("%d", evt.Version))},
+			{Key: "tenant_id", Value: []byte(evt.TenantID)},
 		},
 		Time: evt.Timestamp,
 	}
+	injectTraceContext(ctx, &message)
 
 	// Publish to Kafka
 	err = p.writer.WriteMessages(ctx, message)
@@ -82,7 +91,7 @@ func (p *KafkaPublisher) Publish(ctx context.Context, evt event.Event) error {
 func (p *KafkaPublisher) PublishBatch(ctx context.Context, events []event.Event) error {
 	messages := make([]kafka.Message, 0, len(events))
 
-	for _, evt := range events {
+	for _, evt := range withTenants(ctx, events) {
 		data, err := json.Marshal(evt)
 		if err != nil {
 			return fmt.Errorf("failed to marshal event %s: %w", evt.ID, err)
@@ -95,9 +104,11 @@ func (p *KafkaPublisher) PublishBatch(ctx context.Context, events []event.Event)
 				{Key: "event_type", Value: []byte(evt.EventType)},
 				{Key: "aggregate_type", Value: []byte(evt.AggregateType)},
 				{Key: "version", Value: []byte(fmt.Sprintf("%d", evt.Version))},
+			{Key: "tenant_id", Value: []byte(evt.TenantID)},
 			},
 			Time: evt.Timestamp,
 		}
+		injectTraceContext(ctx, &message)
 
 		messages = append(messages, message)
 	}
@@ -124,16 +135,73 @@ func (p *KafkaPublisher) Close() error {
 
 // KafkaConsumer implements event consumption from Kafka
 type KafkaConsumer struct {
-	reader   *kafka.Reader
-	logger   *zap.Logger
-	handlers map[string]EventHandler
+	reader     messageReader
+	deadLetter messageWriter
+	config     ConsumerConfig
+	metrics    *metrics.Metrics
+	logger     *zap.Logger
+	handlers   map[string]KafkaEventHandler
+}
+
+// KafkaEventHandler handles an event consumed from Kafka
+type KafkaEventHandler func(ctx context.Context, event event.Event) error
+
+// ConsumerConfig controls how a KafkaConsumer retries failing messages
+type ConsumerConfig struct {
+	// MaxRetries is how many times a failing message is retried after the first attempt
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry; it doubles on each retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+	// DeadLetterTopic receives messages that still fail once retries are exhausted.
+	// When empty, such messages are logged and skipped.
+	DeadLetterTopic string
 }
 
-// EventHandler defines the interface for handling events
-type EventHandler func(ctx context.Context, event event.Event) error
+// DefaultConsumerConfig returns the retry settings used when none are configured
+func DefaultConsumerConfig() ConsumerConfig {
+	return ConsumerConfig{
+		MaxRetries:     3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// NewConsumerConfig builds retry settings from the Kafka configuration
+func NewConsumerConfig(cfg config.KafkaConfig) ConsumerConfig {
+	return ConsumerConfig{
+		MaxRetries:      cfg.MaxRetries,
+		InitialBackoff:  cfg.RetryBackoff,
+		MaxBackoff:      cfg.MaxRetryBackoff,
+		DeadLetterTopic: cfg.DeadLetterTopic,
+	}
+}
+
+// messageReader is the subset of *kafka.Reader used by KafkaConsumer
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// messageWriter is the subset of *kafka.Writer used for dead-letter forwarding
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Dead-letter headers describing why a message was forwarded
+const (
+	headerDeadLetterError             = "dlq_error"
+	headerDeadLetterAttempts          = "dlq_attempts"
+	headerDeadLetterOriginalTopic     = "dlq_original_topic"
+	headerDeadLetterOriginalPartition = "dlq_original_partition"
+	headerDeadLetterOriginalOffset    = "dlq_original_offset"
+)
 
 // NewKafkaConsumer creates a new Kafka event consumer
-func NewKafkaConsumer(brokers []string, topic, groupID string, logger *zap.Logger) *KafkaConsumer {
+func NewKafkaConsumer(brokers []string, topic, groupID string, config ConsumerConfig, m *metrics.Metrics, logger *zap.Logger) *KafkaConsumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:     brokers,
 		Topic:       topic,
@@ -145,19 +213,52 @@ func NewKafkaConsumer(brokers []string, topic, groupID string, logger *zap.Logge
 		ErrorLogger: kafka.LoggerFunc(logger.Sugar().Errorf),
 	})
 
+	var deadLetter messageWriter
+	if config.DeadLetterTopic != "" {
+		deadLetter = &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        config.DeadLetterTopic,
+			Balancer:     &kafka.LeastBytes{},
+			RequiredAcks: kafka.RequireAll,
+			MaxAttempts:  3,
+			Logger:       kafka.LoggerFunc(logger.Sugar().Debugf),
+			ErrorLogger:  kafka.LoggerFunc(logger.Sugar().Errorf),
+		}
+	}
+
+	return newKafkaConsumer(reader, deadLetter, config, m, logger)
+}
+
+// newKafkaConsumer wires a consumer around an existing reader and dead-letter writer
+func newKafkaConsumer(reader messageReader, deadLetter messageWriter, config ConsumerConfig, m *metrics.Metrics, logger *zap.Logger) *KafkaConsumer {
 	return &KafkaConsumer{
-		reader:   reader,
-		logger:   logger,
-		handlers: make(map[string]EventHandler),
+		reader:     reader,
+		deadLetter: deadLetter,
+		config:     config,
+		metrics:    m,
+		logger:     logger,
+		handlers:   make(map[string]KafkaEventHandler),
 	}
 }
 
 // RegisterHandler registers an event handler for a specific event type
-func (c *KafkaConsumer) RegisterHandler(eventType string, handler EventHandler) {
+func (c *KafkaConsumer) RegisterHandler(eventType string, handler KafkaEventHandler) {
 	c.handlers[eventType] = handler
 }
 
-// Start starts consuming events
+// Dispatch hands an event to the handler registered for its type, e.g. when
+// replaying stored events. Events without a handler are skipped, as they
+// are when consumed.
+func (c *KafkaConsumer) Dispatch(ctx context.Context, evt event.Event) error {
+	handler, exists := c.handlers[evt.EventType]
+	if !exists {
+		return nil
+	}
+	return handler(ctx, evt)
+}
+
+// Start consumes events until ctx is cancelled and returns ctx.Err(). A
+// message already fetched is handled and committed before it returns.
 func (c *KafkaConsumer) Start(ctx context.Context) error {
 	for {
 		select {
@@ -166,6 +267,9 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 		default:
 			message, err := c.reader.FetchMessage(ctx)
 			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
 				c.logger.Error("Failed to fetch message", zap.Error(err))
 				continue
 			}
@@ -177,9 +281,7 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 					zap.String("offset", fmt.Sprintf("%d", message.Offset)),
 					zap.Error(err))
 				// Commit anyway to avoid reprocessing
-				if err := c.reader.CommitMessages(ctx, message); err != nil {
-					c.logger.Error("Failed to commit message", zap.Error(err))
-				}
+				c.commit(ctx, message)
 				continue
 			}
 
@@ -189,32 +291,139 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 				c.logger.Warn("No handler registered for event type",
 					zap.String("event_type", evt.EventType))
 				// Commit anyway
-				if err := c.reader.CommitMessages(ctx, message); err != nil {
-					c.logger.Error("Failed to commit message", zap.Error(err))
-				}
+				c.commit(ctx, message)
 				continue
 			}
 
-			// Handle event
-			if err := handler(ctx, evt); err != nil {
-				c.logger.Error("Failed to handle event",
+			// Handle event, retrying and dead-lettering on repeated failure
+			if err := c.handle(ctx, message, evt, handler); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				c.logger.Error("Failed to process message",
 					zap.String("event_id", evt.ID),
-					zap.String("event_type", evt.EventType),
 					zap.Error(err))
-				// Don't commit on error - will retry
-				continue
 			}
+		}
+	}
+}
 
-			// Commit message
-			if err := c.reader.CommitMessages(ctx, message); err != nil {
-				c.logger.Error("Failed to commit message", zap.Error(err))
-			}
+// handle runs the handler with exponential backoff between attempts. Once
+// retries are exhausted the message is forwarded to the dead-letter topic
+// and committed so it no longer blocks the partition.
+func (c *KafkaConsumer) handle(ctx context.Context, message kafka.Message, evt event.Event, handler KafkaEventHandler) error {
+	// A message being handled is finished even if ctx is cancelled; only
+	// waiting for a retry is cut short. Handlers act in the event's tenant.
+	workCtx := repository.WithTenant(context.WithoutCancel(ctx), evt.Tenant())
+
+	// Continue the publisher's trace
+	handlerCtx := extractTraceContext(workCtx, &message)
+
+	attempts := 0
+	var handlerErr error
+	for {
+		attempts++
+		handlerErr = handler(handlerCtx, evt)
+		if handlerErr == nil {
+			c.commit(workCtx, message)
+			return nil
+		}
+
+		c.logger.Warn("Failed to handle event",
+			zap.String("event_id", evt.ID),
+			zap.String("event_type", evt.EventType),
+			zap.Int("attempt", attempts),
+			zap.Error(handlerErr))
+
+		if attempts > c.config.MaxRetries {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.backoff(attempts)):
+		}
+	}
+
+	if err := c.sendToDeadLetter(workCtx, message, handlerErr, attempts); err != nil {
+		// Leave the message uncommitted so it is redelivered after a restart
+		return fmt.Errorf("failed to dead-letter message at offset %d: %w", message.Offset, err)
+	}
+
+	c.commit(workCtx, message)
+	return nil
+}
+
+// backoff returns the delay before the given retry, doubling from InitialBackoff up to MaxBackoff
+func (c *KafkaConsumer) backoff(attempt int) time.Duration {
+	delay := c.config.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if c.config.MaxBackoff > 0 && delay >= c.config.MaxBackoff {
+			return c.config.MaxBackoff
 		}
 	}
+	return delay
+}
+
+// sendToDeadLetter forwards the original message with headers recording the failure
+func (c *KafkaConsumer) sendToDeadLetter(ctx context.Context, message kafka.Message, handlerErr error, attempts int) error {
+	if c.deadLetter == nil {
+		c.logger.Error("Dropping message after exhausting retries, no dead-letter topic configured",
+			zap.String("topic", message.Topic),
+			zap.Int64("offset", message.Offset),
+			zap.Int("attempts", attempts),
+			zap.Error(handlerErr))
+		return nil
+	}
+
+	headers := make([]kafka.Header, 0, len(message.Headers)+5)
+	headers = append(headers, message.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: headerDeadLetterError, Value: []byte(handlerErr.Error())},
+		kafka.Header{Key: headerDeadLetterAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: headerDeadLetterOriginalTopic, Value: []byte(message.Topic)},
+		kafka.Header{Key: headerDeadLetterOriginalPartition, Value: []byte(strconv.Itoa(message.Partition))},
+		kafka.Header{Key: headerDeadLetterOriginalOffset, Value: []byte(strconv.FormatInt(message.Offset, 10))},
+	)
+
+	dlqMessage := kafka.Message{
+		Key:     message.Key,
+		Value:   message.Value,
+		Headers: headers,
+		Time:    message.Time,
+	}
+
+	if err := c.deadLetter.WriteMessages(ctx, dlqMessage); err != nil {
+		return err
+	}
+
+	c.metrics.DeadLetterSent.WithLabelValues(message.Topic).Inc()
+	c.logger.Warn("Message sent to dead-letter topic",
+		zap.String("topic", message.Topic),
+		zap.Int64("offset", message.Offset),
+		zap.Int("attempts", attempts),
+		zap.String("dead_letter_topic", c.config.DeadLetterTopic))
+
+	return nil
 }
 
-// Close closes the Kafka reader
+// commit marks a message as processed. It is not cut short by cancellation of
+// ctx, so a message fetched before shutdown is not redelivered.
+func (c *KafkaConsumer) commit(ctx context.Context, message kafka.Message) {
+	if err := c.reader.CommitMessages(context.WithoutCancel(ctx), message); err != nil {
+		c.logger.Error("Failed to commit message", zap.Error(err))
+	}
+}
+
+// Close closes the Kafka reader and dead-letter writer
 func (c *KafkaConsumer) Close() error {
+	if c.deadLetter != nil {
+		if err := c.deadLetter.Close(); err != nil {
+			c.logger.Error("Failed to close dead-letter writer", zap.Error(err))
+		}
+	}
 	return c.reader.Close()
 }
 
@@ -278,6 +487,22 @@ func (p *ObjectTypeEventPublisher) PublishDeleted(ctx context.Context, objectTyp
 	return p.publisher.Publish(ctx, evt)
 }
 
+// PublishRestored publishes an object type restored event
+func (p *ObjectTypeEventPublisher) PublishRestored(ctx context.Context, objectTypeID, userID string, version int) error {
+	evt := event.Event{
+		ID:            generateEventID(),
+		EventType:     "object_type.restored",
+		AggregateID:   objectTypeID,
+		AggregateType: "object_type",
+		Version:       version,
+		Timestamp:     time.Now(),
+		UserID:        userID,
+		Data:          map[string]interface{}{"deleted": false},
+	}
+
+	return p.publisher.Publish(ctx, evt)
+}
+
 // LinkTypeEventPublisher publishes link type related events
 type LinkTypeEventPublisher struct {
 	publisher *KafkaPublisher
@@ -340,15 +565,5 @@ func (p *LinkTypeEventPublisher) PublishDeleted(ctx context.Context, linkTypeID,
 
 // generateEventID generates a unique event ID
 func generateEventID() string {
-	return fmt.Sprintf("evt_%d_%s", time.Now().UnixNano(), generateRandomString(8))
-}
-
-// generateRandomString generates a random string of specified length
-func generateRandomString(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = charset[time.Now().UnixNano()%int64(len(charset))]
-	}
-	return string(b)
+	return event.NewID()
 }
\ No newline at end of file
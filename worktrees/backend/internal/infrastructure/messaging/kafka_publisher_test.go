@@ -0,0 +1,308 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+func TestGenerateEventIDIsUnique(t *testing.T) {
+	// Arrange
+	const n = 10000
+	seen := make(map[string]bool, n)
+
+	// Act
+	for i := 0; i < n; i++ {
+		seen[generateEventID()] = true
+	}
+
+	// Assert
+	if len(seen) != n {
+		t.Errorf("generated %d unique IDs out of %d", len(seen), n)
+	}
+}
+
+func TestGenerateEventIDSortsInCreationOrder(t *testing.T) {
+	// Arrange
+	const n = 10000
+	ids := make([]string, n)
+
+	// Act
+	for i := range ids {
+		ids[i] = generateEventID()
+	}
+
+	// Assert
+	for i := 1; i < n; i++ {
+		if ids[i-1] >= ids[i] {
+			t.Fatalf("ID %d %q does not sort after ID %d %q", i, ids[i], i-1, ids[i-1])
+		}
+	}
+}
+
+// fakeReader serves queued messages and records commits. Once the queue is
+// empty it cancels the consumer, so Start returns after the last message.
+type fakeReader struct {
+	messages  []kafka.Message
+	committed []kafka.Message
+	cancel    context.CancelFunc
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.messages) == 0 {
+		r.cancel()
+		return kafka.Message{}, ctx.Err()
+	}
+	message := r.messages[0]
+	r.messages = r.messages[1:]
+	return message, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+// fakeWriter records the messages written to the dead-letter topic
+type fakeWriter struct {
+	messages []kafka.Message
+	err      error
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+// consumerRun is the outcome of consuming one message
+type consumerRun struct {
+	attempts   int
+	reader     *fakeReader
+	deadLetter *fakeWriter
+	metrics    *metrics.Metrics
+}
+
+// consumeOne runs a consumer over a single ObjectTypeCreated message whose
+// handler fails the first failures attempts
+func consumeOne(t *testing.T, failures int, deadLetter *fakeWriter) *consumerRun {
+	t.Helper()
+	data, _ := json.Marshal(event.Event{ID: "evt-1", EventType: "ObjectTypeCreated"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	run := &consumerRun{
+		reader:     &fakeReader{messages: []kafka.Message{{Topic: "oms.events", Offset: 7, Value: data}}, cancel: cancel},
+		deadLetter: deadLetter,
+		metrics:    metrics.NewMetrics(prometheus.NewRegistry()),
+	}
+	config := ConsumerConfig{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	var writer messageWriter
+	if deadLetter != nil {
+		config.DeadLetterTopic = "oms.events.dlq"
+		writer = deadLetter
+	}
+
+	consumer := newKafkaConsumer(run.reader, writer, config, run.metrics, zap.NewNop())
+	consumer.RegisterHandler("ObjectTypeCreated", func(ctx context.Context, evt event.Event) error {
+		run.attempts++
+		if run.attempts <= failures {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+	_ = consumer.Start(ctx)
+	return run
+}
+
+// header returns the value of the named header of message
+func header(message kafka.Message, key string) string {
+	for _, h := range message.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestConsumerRetriesUntilHandlerSucceeds(t *testing.T) {
+	// Act
+	run := consumeOne(t, 2, &fakeWriter{})
+
+	// Assert
+	if run.attempts != 3 || len(run.deadLetter.messages) != 0 {
+		t.Errorf("attempts = %d, dead-lettered = %d; want 3 and 0", run.attempts, len(run.deadLetter.messages))
+	}
+}
+
+func TestConsumerStopsRetryingAfterMaxRetries(t *testing.T) {
+	// Act
+	run := consumeOne(t, 100, &fakeWriter{})
+
+	// Assert
+	if run.attempts != 4 {
+		t.Errorf("attempts = %d, want 4", run.attempts)
+	}
+}
+
+func TestConsumerDeadLettersRepeatedlyFailingMessage(t *testing.T) {
+	// Act
+	run := consumeOne(t, 100, &fakeWriter{})
+
+	// Assert
+	if len(run.deadLetter.messages) != 1 {
+		t.Fatalf("dead-lettered %d messages, want 1", len(run.deadLetter.messages))
+	}
+	message := run.deadLetter.messages[0]
+	if header(message, headerDeadLetterAttempts) != "4" || header(message, headerDeadLetterOriginalOffset) != "7" ||
+		header(message, headerDeadLetterError) != "downstream unavailable" {
+		t.Errorf("dead-letter headers = %v", message.Headers)
+	}
+}
+
+func TestConsumerCommitsDeadLetteredMessage(t *testing.T) {
+	// Act
+	run := consumeOne(t, 100, &fakeWriter{})
+
+	// Assert
+	if len(run.reader.committed) != 1 {
+		t.Errorf("committed %d messages, want 1", len(run.reader.committed))
+	}
+}
+
+func TestConsumerCountsDeadLetterSends(t *testing.T) {
+	// Act
+	run := consumeOne(t, 100, &fakeWriter{})
+
+	// Assert
+	var metric dto.Metric
+	_ = run.metrics.DeadLetterSent.WithLabelValues("oms.events").Write(&metric)
+	if got := metric.GetCounter().GetValue(); got != 1 {
+		t.Errorf("kafka_dead_letter_total = %v, want 1", got)
+	}
+}
+
+func TestConsumerLeavesMessageUncommittedWhenDeadLetterFails(t *testing.T) {
+	// Act
+	run := consumeOne(t, 100, &fakeWriter{err: errors.New("broker down")})
+
+	// Assert
+	if len(run.reader.committed) != 0 {
+		t.Errorf("committed %d messages, want 0 so the message is redelivered", len(run.reader.committed))
+	}
+}
+
+func TestConsumerWithoutDeadLetterTopicSkipsFailingMessage(t *testing.T) {
+	// Act
+	run := consumeOne(t, 100, nil)
+
+	// Assert
+	if len(run.reader.committed) != 1 {
+		t.Errorf("committed %d messages, want 1", len(run.reader.committed))
+	}
+}
+
+func TestConsumerBackoffDoublesUpToMax(t *testing.T) {
+	// Arrange
+	consumer := newKafkaConsumer(nil, nil, ConsumerConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}, nil, zap.NewNop())
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+
+	// Act
+	got := make([]time.Duration, len(want))
+	for i := range got {
+		got[i] = consumer.backoff(i + 1)
+	}
+
+	// Assert
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("backoffs = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+// inFlightReader serves one message, then blocks until the consumer is
+// cancelled
+type inFlightReader struct {
+	mu        sync.Mutex
+	message   *kafka.Message
+	committed []kafka.Message
+}
+
+func (r *inFlightReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	message := r.message
+	r.message = nil
+	r.mu.Unlock()
+	if message != nil {
+		return *message, nil
+	}
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *inFlightReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *inFlightReader) Close() error { return nil }
+
+func TestConsumerFinishesInFlightMessageBeforeStopping(t *testing.T) {
+	// Arrange
+	data, _ := json.Marshal(event.Event{ID: "evt-1", EventType: "ObjectTypeCreated"})
+	reader := &inFlightReader{message: &kafka.Message{Topic: "oms.events", Offset: 7, Value: data}}
+	consumer := newKafkaConsumer(reader, nil, ConsumerConfig{MaxRetries: 1}, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	handling, release := make(chan struct{}), make(chan struct{})
+	consumer.RegisterHandler("ObjectTypeCreated", func(ctx context.Context, evt event.Event) error {
+		close(handling)
+		<-release
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		_ = consumer.Start(ctx)
+		close(stopped)
+	}()
+	<-handling
+
+	// Act
+	cancel()
+
+	// Assert
+	select {
+	case <-stopped:
+		t.Fatal("Start returned while a message was still being handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Start did not return after the in-flight message finished")
+	}
+	if len(reader.committed) != 1 {
+		t.Errorf("committed %d messages, want the in-flight message", len(reader.committed))
+	}
+}
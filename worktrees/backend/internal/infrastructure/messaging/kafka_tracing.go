@@ -0,0 +1,53 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+)
+
+// kafkaHeaderCarrier adapts Kafka message headers to an OpenTelemetry TextMapCarrier
+type kafkaHeaderCarrier struct {
+	headers *[]kafka.Header
+}
+
+// Get returns the value of the first header with the given key
+func (c kafkaHeaderCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces or appends a header
+func (c kafkaHeaderCarrier) Set(key, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// Keys lists the header keys
+func (c kafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, len(*c.headers))
+	for i, h := range *c.headers {
+		keys[i] = h.Key
+	}
+	return keys
+}
+
+// injectTraceContext writes the active trace context into the message headers
+func injectTraceContext(ctx context.Context, message *kafka.Message) {
+	otel.GetTextMapPropagator().Inject(ctx, kafkaHeaderCarrier{headers: &message.Headers})
+}
+
+// extractTraceContext continues the trace carried by the message headers
+func extractTraceContext(ctx context.Context, message *kafka.Message) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, kafkaHeaderCarrier{headers: &message.Headers})
+}
@@ -0,0 +1,86 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// StoringPublisher saves events to an event store before publishing them, so
+// every published event can later be replayed with event.ReplayEvents
+type StoringPublisher struct {
+	publisher event.EventPublisher
+	store     event.EventStore
+	logger    *zap.Logger
+}
+
+// NewStoringPublisher creates a publisher storing events before handing them
+// to publisher
+func NewStoringPublisher(publisher event.EventPublisher, store event.EventStore, logger *zap.Logger) *StoringPublisher {
+	return &StoringPublisher{
+		publisher: publisher,
+		store:     store,
+		logger:    logger,
+	}
+}
+
+// Publish stores and publishes an event. An event that cannot be stored is
+// not published, so consumers never see an event replay would miss. An
+// event without a tenant is published under the tenant on ctx.
+func (p *StoringPublisher) Publish(ctx context.Context, evt event.Event) error {
+	evt = withTenant(ctx, evt)
+	if err := p.store.Save(ctx, evt); err != nil {
+		p.logger.Error("Failed to store event",
+			zap.String("event_id", evt.ID),
+			zap.String("event_type", evt.EventType),
+			zap.Error(err))
+		return fmt.Errorf("failed to store event: %w", err)
+	}
+
+	return p.publisher.Publish(ctx, evt)
+}
+
+// PublishBatch stores and publishes multiple events
+func (p *StoringPublisher) PublishBatch(ctx context.Context, events []event.Event) error {
+	events = withTenants(ctx, events)
+	for _, evt := range events {
+		if err := p.store.Save(ctx, evt); err != nil {
+			p.logger.Error("Failed to store event",
+				zap.String("event_id", evt.ID),
+				zap.String("event_type", evt.EventType),
+				zap.Error(err))
+			return fmt.Errorf("failed to store event %s: %w", evt.ID, err)
+		}
+	}
+
+	return p.publisher.PublishBatch(ctx, events)
+}
+
+// withTenant sets the tenant of an event that has none to the tenant on ctx
+func withTenant(ctx context.Context, evt event.Event) event.Event {
+	if evt.TenantID == "" {
+		evt.TenantID = repository.TenantFromContext(ctx)
+	}
+	return evt
+}
+
+// withTenants applies withTenant to a copy of events
+func withTenants(ctx context.Context, events []event.Event) []event.Event {
+	stamped := make([]event.Event, len(events))
+	for i, evt := range events {
+		stamped[i] = withTenant(ctx, evt)
+	}
+	return stamped
+}
+
+// Close closes the wrapped publisher if it holds resources
+func (p *StoringPublisher) Close() error {
+	if closer, ok := p.publisher.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// memoryEventStore keeps saved events in order, like the Postgres store's
+// ORDER BY id for IDs issued in sequence
+type memoryEventStore struct {
+	mu      sync.Mutex
+	events  []event.Event
+	saveErr error
+}
+
+func (s *memoryEventStore) Save(ctx context.Context, evt event.Event) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, evt)
+	return nil
+}
+
+func (s *memoryEventStore) GetByAggregateID(ctx context.Context, aggregateID string) ([]event.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []event.Event
+	for _, evt := range s.events {
+		if evt.AggregateID == aggregateID && evt.TenantID == repository.TenantFromContext(ctx) {
+			events = append(events, evt)
+		}
+	}
+	return events, nil
+}
+
+func (s *memoryEventStore) GetByEventType(ctx context.Context, eventType string, limit int) ([]event.Event, error) {
+	return nil, nil
+}
+
+// recordingPublisher records the events handed to it
+type recordingPublisher struct {
+	events []event.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, evt event.Event) error {
+	p.events = append(p.events, evt)
+	return nil
+}
+
+func (p *recordingPublisher) PublishBatch(ctx context.Context, events []event.Event) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+// objectTypeLifecycle returns created, updated and deleted events of the
+// object type aggregateID
+func objectTypeLifecycle(aggregateID string) []event.Event {
+	var events []event.Event
+	for version, eventType := range []string{"object_type.created", "object_type.updated", "object_type.deleted"} {
+		events = append(events, event.Event{
+			ID: event.NewID(), EventType: eventType, AggregateID: aggregateID, AggregateType: "ObjectType", Version: version + 1,
+		})
+	}
+	return events
+}
+
+// publishAll publishes events one by one through a storing publisher over store
+func publishAll(t *testing.T, store *memoryEventStore, events []event.Event) *recordingPublisher {
+	t.Helper()
+	published := &recordingPublisher{}
+	publisher := NewStoringPublisher(published, store, zap.NewNop())
+	for _, evt := range events {
+		if err := publisher.Publish(context.Background(), evt); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	return published
+}
+
+func TestReplayRedispatchesStoredEventsInOrder(t *testing.T) {
+	// Arrange
+	store := &memoryEventStore{}
+	customer := objectTypeLifecycle("customer")
+	publishAll(t, store, append(customer, objectTypeLifecycle("account")...))
+	var replayed []string
+
+	// Act
+	err := event.ReplayEvents(context.Background(), store, "customer", func(ctx context.Context, evt event.Event) error {
+		replayed = append(replayed, evt.ID)
+		return nil
+	})
+
+	// Assert
+	want := []string{customer[0].ID, customer[1].ID, customer[2].ID}
+	if err != nil || !slices.Equal(replayed, want) {
+		t.Errorf("replayed %v (err %v), want %v", replayed, err, want)
+	}
+}
+
+func TestReplayRebuildsConsumerState(t *testing.T) {
+	// Arrange
+	store := &memoryEventStore{}
+	publishAll(t, store, objectTypeLifecycle("customer"))
+	state := map[string]int{}
+
+	// Act
+	_ = event.ReplayEvents(context.Background(), store, "customer", func(ctx context.Context, evt event.Event) error {
+		if evt.EventType == "object_type.deleted" {
+			delete(state, evt.AggregateID)
+		} else {
+			state[evt.AggregateID] = evt.Version
+		}
+		return nil
+	})
+
+	// Assert
+	if len(state) != 0 {
+		t.Errorf("state = %v, want the deleted object type gone", state)
+	}
+}
+
+func TestReplayStopsAtFirstHandlerError(t *testing.T) {
+	// Arrange
+	store := &memoryEventStore{}
+	publishAll(t, store, objectTypeLifecycle("customer"))
+	handled := 0
+
+	// Act
+	err := event.ReplayEvents(context.Background(), store, "customer", func(ctx context.Context, evt event.Event) error {
+		handled++
+		return errors.New("projection unavailable")
+	})
+
+	// Assert
+	if err == nil || handled != 1 {
+		t.Errorf("ReplayEvents = %v after %d events, want an error after the first", err, handled)
+	}
+}
+
+func TestStoringPublisherPublishesStoredEvents(t *testing.T) {
+	// Arrange
+	store := &memoryEventStore{}
+	events := objectTypeLifecycle("customer")
+
+	// Act
+	published := publishAll(t, store, events)
+
+	// Assert
+	if len(store.events) != 3 || len(published.events) != 3 {
+		t.Errorf("stored %d and published %d events, want 3 of each", len(store.events), len(published.events))
+	}
+}
+
+func TestStoringPublisherDoesNotPublishUnstoredEvent(t *testing.T) {
+	// Arrange
+	store := &memoryEventStore{saveErr: errors.New("database down")}
+	published := &recordingPublisher{}
+	publisher := NewStoringPublisher(published, store, zap.NewNop())
+
+	// Act
+	err := publisher.Publish(context.Background(), objectTypeLifecycle("customer")[0])
+
+	// Assert
+	if err == nil || len(published.events) != 0 {
+		t.Errorf("Publish = %v with %d published, want an error and nothing published", err, len(published.events))
+	}
+}
+
+func TestStoringPublisherStampsTenant(t *testing.T) {
+	// Arrange
+	store := &memoryEventStore{}
+	publisher := NewStoringPublisher(&recordingPublisher{}, store, zap.NewNop())
+	ctx := repository.WithTenant(context.Background(), "acme")
+
+	// Act
+	_ = publisher.Publish(ctx, objectTypeLifecycle("customer")[0])
+
+	// Assert
+	if store.events[0].TenantID != "acme" {
+		t.Errorf("tenant = %q, want acme", store.events[0].TenantID)
+	}
+}
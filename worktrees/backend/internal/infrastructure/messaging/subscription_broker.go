@@ -0,0 +1,157 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// ChangeEventTypes are the Kafka event types fanned out to change subscribers
+var ChangeEventTypes = []string{
+	"object_type.created",
+	"object_type.updated",
+	"object_type.deleted",
+	"object_type.restored",
+	"link_type.created",
+	"link_type.updated",
+	"link_type.deleted",
+}
+
+// DefaultSubscriptionBuffer is the per-subscriber buffer used when none is given
+const DefaultSubscriptionBuffer = 64
+
+// SubscriptionFilter selects the events a subscriber receives.
+// Empty fields match every event.
+type SubscriptionFilter struct {
+	AggregateType string // "object_type" or "link_type"
+	AggregateID   string
+	EventTypes    []string
+}
+
+// matches reports whether the event passes the filter
+func (f SubscriptionFilter) matches(evt event.Event) bool {
+	if f.AggregateType != "" && f.AggregateType != evt.AggregateType {
+		return false
+	}
+	if f.AggregateID != "" && f.AggregateID != evt.AggregateID {
+		return false
+	}
+	if len(f.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range f.EventTypes {
+		if eventType == evt.EventType {
+			return true
+		}
+	}
+	return false
+}
+
+// subscriber is a single registered listener
+type subscriber struct {
+	tenantID string
+	filter   SubscriptionFilter
+	ch       chan event.Event
+}
+
+// SubscriptionBroker fans out consumed events to in-process subscribers,
+// e.g. GraphQL subscription resolvers. Each subscriber has a bounded buffer;
+// events for a subscriber that is not keeping up are dropped rather than
+// queued, so a slow client cannot grow memory without bound.
+type SubscriptionBroker struct {
+	mu          sync.RWMutex
+	subscribers map[uint64]*subscriber
+	nextID      uint64
+	bufferSize  int
+	logger      *zap.Logger
+}
+
+// NewSubscriptionBroker creates a new subscription broker
+func NewSubscriptionBroker(bufferSize int, logger *zap.Logger) *SubscriptionBroker {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSubscriptionBuffer
+	}
+
+	return &SubscriptionBroker{
+		subscribers: make(map[uint64]*subscriber),
+		bufferSize:  bufferSize,
+		logger:      logger,
+	}
+}
+
+// Attach registers the broker as the handler for change events on a consumer
+func (b *SubscriptionBroker) Attach(consumer *KafkaConsumer) {
+	for _, eventType := range ChangeEventTypes {
+		consumer.RegisterHandler(eventType, b.Publish)
+	}
+}
+
+// Subscribe registers a subscriber and returns its event channel. The
+// subscriber only receives events of the tenant on ctx. The subscription is
+// removed and the channel closed when ctx is cancelled, typically when the
+// client disconnects.
+func (b *SubscriptionBroker) Subscribe(ctx context.Context, filter SubscriptionFilter) <-chan event.Event {
+	sub := &subscriber{
+		tenantID: repository.TenantFromContext(ctx),
+		filter:   filter,
+		ch:       make(chan event.Event, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(id)
+	}()
+
+	return sub.ch
+}
+
+// Publish delivers an event to every matching subscriber without blocking.
+// Its signature matches KafkaEventHandler.
+func (b *SubscriptionBroker) Publish(ctx context.Context, evt event.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for id, sub := range b.subscribers {
+		if sub.tenantID != evt.Tenant() || !sub.filter.matches(evt) {
+			continue
+		}
+
+		select {
+		case sub.ch <- evt:
+		default:
+			b.logger.Warn("Dropping event for slow subscriber",
+				zap.Uint64("subscriber_id", id),
+				zap.String("event_id", evt.ID),
+				zap.String("event_type", evt.EventType))
+		}
+	}
+
+	return nil
+}
+
+// SubscriberCount returns the number of active subscribers
+func (b *SubscriptionBroker) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// unsubscribe removes a subscriber and closes its channel
+func (b *SubscriptionBroker) unsubscribe(id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if sub, ok := b.subscribers[id]; ok {
+		delete(b.subscribers, id)
+		close(sub.ch)
+	}
+}
@@ -0,0 +1,186 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// subscribeAndPublish subscribes in tenant, publishes evt and reports
+// whether the subscriber received it
+func subscribeAndPublish(t *testing.T, tenant string, evt event.Event) bool {
+	t.Helper()
+	broker := NewSubscriptionBroker(1, zap.NewNop())
+	ctx, cancel := context.WithCancel(repository.WithTenant(context.Background(), tenant))
+	t.Cleanup(cancel)
+	events := broker.Subscribe(ctx, SubscriptionFilter{})
+
+	_ = broker.Publish(context.Background(), evt)
+
+	select {
+	case <-events:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestBrokerDeliversEventsOfSubscriberTenant(t *testing.T) {
+	// Arrange
+	evt := event.Event{ID: "1", EventType: "object_type.created", TenantID: "acme"}
+
+	// Act
+	received := subscribeAndPublish(t, "acme", evt)
+
+	// Assert
+	if !received {
+		t.Error("subscriber did not receive an event of its own tenant")
+	}
+}
+
+func TestBrokerDropsEventsOfOtherTenants(t *testing.T) {
+	// Arrange
+	evt := event.Event{ID: "1", EventType: "object_type.created", TenantID: "globex"}
+
+	// Act
+	received := subscribeAndPublish(t, "acme", evt)
+
+	// Assert
+	if received {
+		t.Error("subscriber received an event of another tenant")
+	}
+}
+
+func TestBrokerTreatsEventsWithoutTenantAsDefault(t *testing.T) {
+	// Arrange
+	evt := event.Event{ID: "1", EventType: "object_type.created"}
+
+	// Act
+	received := subscribeAndPublish(t, "acme", evt)
+
+	// Assert
+	if received {
+		t.Error("subscriber in acme received an event of the default tenant")
+	}
+}
+
+// received drains the events already buffered on events
+func received(events <-chan event.Event) []event.Event {
+	var drained []event.Event
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return drained
+			}
+			drained = append(drained, evt)
+		default:
+			return drained
+		}
+	}
+}
+
+func TestBrokerAttachedToConsumerDeliversCreatedEvent(t *testing.T) {
+	// Arrange
+	broker := NewSubscriptionBroker(DefaultSubscriptionBuffer, zap.NewNop())
+	subscriptionCtx, unsubscribe := context.WithCancel(context.Background())
+	t.Cleanup(unsubscribe)
+	events := broker.Subscribe(subscriptionCtx, SubscriptionFilter{AggregateType: "object_type"})
+
+	created := event.Event{ID: "evt-1", EventType: "object_type.created", AggregateType: "object_type", AggregateID: "ot-1"}
+	data, _ := json.Marshal(created)
+	consumerCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	reader := &fakeReader{messages: []kafka.Message{{Value: data}}, cancel: stop}
+	consumer := newKafkaConsumer(reader, nil, DefaultConsumerConfig(), metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	broker.Attach(consumer)
+
+	// Act
+	_ = consumer.Start(consumerCtx)
+
+	// Assert
+	if got := received(events); len(got) != 1 || got[0].AggregateID != "ot-1" {
+		t.Errorf("subscriber received %v, want the object_type.created event for ot-1", got)
+	}
+}
+
+func TestBrokerFiltersByAggregateID(t *testing.T) {
+	// Arrange
+	broker := NewSubscriptionBroker(DefaultSubscriptionBuffer, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := broker.Subscribe(ctx, SubscriptionFilter{AggregateID: "ot-1"})
+
+	// Act
+	_ = broker.Publish(ctx, event.Event{ID: "1", EventType: "object_type.updated", AggregateID: "ot-2"})
+	_ = broker.Publish(ctx, event.Event{ID: "2", EventType: "object_type.updated", AggregateID: "ot-1"})
+
+	// Assert
+	if got := received(events); len(got) != 1 || got[0].ID != "2" {
+		t.Errorf("subscriber received %v, want only event 2", got)
+	}
+}
+
+func TestBrokerFiltersByEventType(t *testing.T) {
+	// Arrange
+	broker := NewSubscriptionBroker(DefaultSubscriptionBuffer, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := broker.Subscribe(ctx, SubscriptionFilter{EventTypes: []string{"object_type.deleted"}})
+
+	// Act
+	_ = broker.Publish(ctx, event.Event{ID: "1", EventType: "object_type.updated"})
+	_ = broker.Publish(ctx, event.Event{ID: "2", EventType: "object_type.deleted"})
+
+	// Assert
+	if got := received(events); len(got) != 1 || got[0].ID != "2" {
+		t.Errorf("subscriber received %v, want only event 2", got)
+	}
+}
+
+func TestBrokerDropsEventsForSlowSubscriber(t *testing.T) {
+	// Arrange
+	broker := NewSubscriptionBroker(2, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := broker.Subscribe(ctx, SubscriptionFilter{})
+
+	// Act
+	for i := 0; i < 5; i++ {
+		_ = broker.Publish(ctx, event.Event{EventType: "object_type.updated"})
+	}
+
+	// Assert
+	if got := received(events); len(got) != 2 {
+		t.Errorf("subscriber buffered %d events, want the buffer size 2", len(got))
+	}
+}
+
+func TestBrokerRemovesSubscriberWhenContextIsCancelled(t *testing.T) {
+	// Arrange
+	broker := NewSubscriptionBroker(1, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	events := broker.Subscribe(ctx, SubscriptionFilter{})
+
+	// Act
+	cancel()
+
+	// Assert
+	select {
+	case _, open := <-events:
+		if open || broker.SubscriberCount() != 0 {
+			t.Errorf("channel open = %v, subscribers = %d; want closed and 0", open, broker.SubscriberCount())
+		}
+	case <-time.After(time.Second):
+		t.Error("channel not closed after the subscriber context was cancelled")
+	}
+}
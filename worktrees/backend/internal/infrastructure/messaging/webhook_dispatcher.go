@@ -0,0 +1,220 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openfoundry/oms/internal/config"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"go.uber.org/zap"
+)
+
+// Headers sent with every webhook delivery. The signature is
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed
+// by the subscription secret; see SignWebhookPayload.
+const (
+	HeaderWebhookEvent     = "X-OMS-Event"
+	HeaderWebhookDelivery  = "X-OMS-Delivery"
+	HeaderWebhookTimestamp = "X-OMS-Timestamp"
+	HeaderWebhookSignature = "X-OMS-Signature"
+)
+
+// Webhook delivery results recorded by WebhookDeliveries
+const (
+	WebhookDelivered = "delivered"
+	WebhookFailed    = "failed"
+)
+
+// WebhookConfig controls how a WebhookDispatcher delivers and retries
+type WebhookConfig struct {
+	// Timeout bounds a single delivery attempt
+	Timeout time.Duration
+	// MaxRetries is how many times a failing delivery is retried after the first attempt
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry; it doubles on each retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+}
+
+// NewWebhookConfig builds delivery settings from the webhook configuration
+func NewWebhookConfig(cfg config.WebhookConfig) WebhookConfig {
+	return WebhookConfig{
+		Timeout:        cfg.Timeout,
+		MaxRetries:     cfg.MaxRetries,
+		InitialBackoff: cfg.RetryBackoff,
+		MaxBackoff:     cfg.MaxRetryBackoff,
+	}
+}
+
+// WebhookDispatcher POSTs consumed change events to the active webhook
+// subscriptions whose event types match. Deliveries failing with a network
+// error or a 5xx response are retried with exponential backoff; other
+// responses are final.
+type WebhookDispatcher struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	client        *http.Client
+	config        WebhookConfig
+	metrics       *metrics.Metrics
+	logger        *zap.Logger
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(subscriptions repository.WebhookSubscriptionRepository, config WebhookConfig, m *metrics.Metrics, logger *zap.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		subscriptions: subscriptions,
+		client:        &http.Client{Timeout: config.Timeout},
+		config:        config,
+		metrics:       m,
+		logger:        logger,
+	}
+}
+
+// Attach registers the dispatcher as a handler for change events on a
+// consumer. A consumer has one handler per event type, so use a consumer
+// group of its own rather than the one feeding a SubscriptionBroker.
+func (d *WebhookDispatcher) Attach(consumer *KafkaConsumer) {
+	for _, eventType := range ChangeEventTypes {
+		consumer.RegisterHandler(eventType, d.Dispatch)
+	}
+}
+
+// Dispatch delivers an event to every matching subscription of the event's
+// tenant in parallel and waits for the deliveries to finish. A subscription that cannot be reached
+// is logged and skipped, so it does not cause redelivery to the others; only
+// failing to load the subscriptions is returned. Its signature matches
+// KafkaEventHandler.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, evt event.Event) error {
+	subscriptions, err := d.subscriptions.ListActive(repository.WithTenant(ctx, evt.Tenant()))
+	if err != nil {
+		return fmt.Errorf("failed to load webhook subscriptions: %w", err)
+	}
+
+	body, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(evt.EventType) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(subscription *entity.WebhookSubscription) {
+			defer wg.Done()
+			d.deliver(ctx, subscription, evt, body)
+		}(subscription)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// deliver POSTs body to a subscription, retrying failed attempts
+func (d *WebhookDispatcher) deliver(ctx context.Context, subscription *entity.WebhookSubscription, evt event.Event, body []byte) {
+	attempts := 0
+	for {
+		attempts++
+		retry, err := d.post(ctx, subscription, evt, body)
+		if err == nil {
+			d.metrics.WebhookDeliveries.WithLabelValues(WebhookDelivered).Inc()
+			return
+		}
+
+		d.logger.Warn("Failed to deliver webhook",
+			zap.String("subscription_id", subscription.ID.String()),
+			zap.String("event_id", evt.ID),
+			zap.String("event_type", evt.EventType),
+			zap.Int("attempt", attempts),
+			zap.Error(err))
+
+		if !retry || attempts > d.config.MaxRetries || !d.wait(ctx, attempts) {
+			break
+		}
+	}
+
+	d.metrics.WebhookDeliveries.WithLabelValues(WebhookFailed).Inc()
+	d.logger.Error("Giving up on webhook delivery",
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("url", subscription.URL),
+		zap.String("event_id", evt.ID))
+}
+
+// post makes one delivery attempt and reports whether a failure is worth retrying
+func (d *WebhookDispatcher) post(ctx context.Context, subscription *entity.WebhookSubscription, evt event.Event, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookEvent, evt.EventType)
+	req.Header.Set(HeaderWebhookDelivery, evt.ID)
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderWebhookSignature, SignWebhookPayload(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 500 {
+		return true, fmt.Errorf("endpoint responded %d", resp.StatusCode)
+	}
+	if resp.StatusCode >= 300 {
+		return false, fmt.Errorf("endpoint responded %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// wait sleeps until the given retry and reports false if ctx ends first
+func (d *WebhookDispatcher) wait(ctx context.Context, attempt int) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d.backoff(attempt)):
+		return true
+	}
+}
+
+// backoff returns the delay before the given retry, doubling from InitialBackoff up to MaxBackoff
+func (d *WebhookDispatcher) backoff(attempt int) time.Duration {
+	delay := d.config.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if d.config.MaxBackoff > 0 && delay >= d.config.MaxBackoff {
+			return d.config.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// SignWebhookPayload returns the X-OMS-Signature value for a delivery.
+// Receivers recompute it from the X-OMS-Timestamp header and the raw body,
+// compare it with hmac.Equal, and reject stale timestamps to stop replays.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,166 @@
+package messaging
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+type fakeWebhookSubscriptionRepo struct {
+	repository.WebhookSubscriptionRepository
+	active []*entity.WebhookSubscription
+}
+
+// ListActive returns the active subscriptions of the tenant on ctx
+func (r *fakeWebhookSubscriptionRepo) ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*entity.WebhookSubscription
+	for _, subscription := range r.active {
+		if subscription.TenantID == repository.TenantFromContext(ctx) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+// webhookReceiver records the requests an httptest server receives and
+// answers each with the next status in statuses, then 200
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestWebhookDispatcher(t *testing.T, receiver *webhookReceiver, secret string) *WebhookDispatcher {
+	t.Helper()
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	subscriptions := &fakeWebhookSubscriptionRepo{active: []*entity.WebhookSubscription{
+		{ID: uuid.New(), TenantID: entity.DefaultTenantID, URL: server.URL, Secret: secret, Active: true},
+	}}
+	config := WebhookConfig{Timeout: time.Second, MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	return NewWebhookDispatcher(subscriptions, config, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+}
+
+func testWebhookEvent() event.Event {
+	return event.Event{ID: "01HZX3Y5K8Q4V6N2M7P9R0S1T2", EventType: "ObjectTypeCreated", AggregateID: uuid.NewString(), Version: 1}
+}
+
+func TestDispatchSignsPayload(t *testing.T) {
+	// Arrange
+	receiver := &webhookReceiver{}
+	dispatcher := newTestWebhookDispatcher(t, receiver, "s3cret")
+
+	// Act
+	_ = dispatcher.Dispatch(context.Background(), testWebhookEvent())
+
+	// Assert
+	req, body := receiver.requests[0], receiver.bodies[0]
+	timestamp, _ := strconv.ParseInt(req.Header.Get(HeaderWebhookTimestamp), 10, 64)
+	if got, want := req.Header.Get(HeaderWebhookSignature), SignWebhookPayload("s3cret", timestamp, body); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+}
+
+func TestDispatchSignatureDependsOnSecret(t *testing.T) {
+	// Arrange
+	receiver := &webhookReceiver{}
+	dispatcher := newTestWebhookDispatcher(t, receiver, "s3cret")
+
+	// Act
+	_ = dispatcher.Dispatch(context.Background(), testWebhookEvent())
+
+	// Assert
+	req, body := receiver.requests[0], receiver.bodies[0]
+	timestamp, _ := strconv.ParseInt(req.Header.Get(HeaderWebhookTimestamp), 10, 64)
+	if req.Header.Get(HeaderWebhookSignature) == SignWebhookPayload("other", timestamp, body) {
+		t.Error("signature verified with the wrong secret")
+	}
+}
+
+func TestDispatchRetriesServerErrors(t *testing.T) {
+	// Arrange
+	receiver := &webhookReceiver{statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError}}
+	dispatcher := newTestWebhookDispatcher(t, receiver, "s3cret")
+
+	// Act
+	_ = dispatcher.Dispatch(context.Background(), testWebhookEvent())
+
+	// Assert
+	if len(receiver.requests) != 3 {
+		t.Errorf("attempts = %d, want 3", len(receiver.requests))
+	}
+}
+
+func TestDispatchDoesNotRetryClientErrors(t *testing.T) {
+	// Arrange
+	receiver := &webhookReceiver{statuses: []int{http.StatusBadRequest}}
+	dispatcher := newTestWebhookDispatcher(t, receiver, "s3cret")
+
+	// Act
+	_ = dispatcher.Dispatch(context.Background(), testWebhookEvent())
+
+	// Assert
+	if len(receiver.requests) != 1 {
+		t.Errorf("attempts = %d, want 1", len(receiver.requests))
+	}
+}
+
+func TestDispatchGivesUpAfterMaxRetries(t *testing.T) {
+	// Arrange
+	receiver := &webhookReceiver{statuses: []int{500, 500, 500, 500, 500, 500}}
+	dispatcher := newTestWebhookDispatcher(t, receiver, "s3cret")
+
+	// Act
+	_ = dispatcher.Dispatch(context.Background(), testWebhookEvent())
+
+	// Assert
+	if len(receiver.requests) != 4 {
+		t.Errorf("attempts = %d, want 4", len(receiver.requests))
+	}
+}
+
+func TestDispatchSkipsSubscriptionsOfOtherTenants(t *testing.T) {
+	// Arrange
+	receiver := &webhookReceiver{}
+	dispatcher := newTestWebhookDispatcher(t, receiver, "s3cret")
+	evt := testWebhookEvent()
+	evt.TenantID = "acme"
+
+	// Act
+	_ = dispatcher.Dispatch(context.Background(), evt)
+
+	// Assert
+	if len(receiver.requests) != 0 {
+		t.Errorf("deliveries = %d, want 0 for another tenant's event", len(receiver.requests))
+	}
+}
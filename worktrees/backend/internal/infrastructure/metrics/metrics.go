@@ -0,0 +1,183 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "oms"
+
+// Metrics holds the Prometheus collectors used across the service
+type Metrics struct {
+	registry *prometheus.Registry
+
+	ObjectTypeCreated prometheus.Counter
+	ObjectTypeUpdated prometheus.Counter
+	ObjectTypeDeleted prometheus.Counter
+	LinkTypeCreated   prometheus.Counter
+	LinkTypeUpdated   prometheus.Counter
+	LinkTypeDeleted   prometheus.Counter
+
+	// QueryDuration is labelled by repository and operation
+	QueryDuration *prometheus.HistogramVec
+	// SlowQueries counts repository operations over the slow query
+	// threshold, labelled by repository and operation
+	SlowQueries *prometheus.CounterVec
+	// HTTPRequestDuration is labelled by method, route and status
+	HTTPRequestDuration *prometheus.HistogramVec
+
+	// DeadLetterSent is labelled by the topic the message was consumed from
+	DeadLetterSent *prometheus.CounterVec
+	// WebhookDeliveries counts webhook deliveries by final result
+	WebhookDeliveries *prometheus.CounterVec
+
+	// CacheRequests counts cache reads, labelled by keyspace and result
+	CacheRequests *prometheus.CounterVec
+	// CacheLookups counts service lookups answered from the cache or not,
+	// labelled by lookup and result
+	CacheLookups *prometheus.CounterVec
+}
+
+// Cache results recorded by CacheRequests and CacheLookups. CacheError is a
+// failing cache, as opposed to a key that is not cached.
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+// NewMetrics creates the service collectors and registers them on the given
+// registry, so callers (and tests) own the registry they scrape
+func NewMetrics(registry *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		registry: registry,
+		ObjectTypeCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "objecttype_created_total",
+			Help:      "Number of object types created.",
+		}),
+		ObjectTypeUpdated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "objecttype_updated_total",
+			Help:      "Number of object type updates.",
+		}),
+		ObjectTypeDeleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "objecttype_deleted_total",
+			Help:      "Number of object types deleted.",
+		}),
+		LinkTypeCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "linktype_created_total",
+			Help:      "Number of link types created.",
+		}),
+		LinkTypeUpdated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "linktype_updated_total",
+			Help:      "Number of link type updates.",
+		}),
+		LinkTypeDeleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "linktype_deleted_total",
+			Help:      "Number of link types deleted.",
+		}),
+		QueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_query_duration_seconds",
+			Help:      "Latency of repository operations.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"repository", "operation"}),
+		SlowQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "repository_slow_queries_total",
+			Help:      "Number of repository operations slower than the slow query threshold.",
+		}, []string{"repository", "operation"}),
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of HTTP requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		DeadLetterSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "kafka_dead_letter_total",
+			Help:      "Number of messages forwarded to a dead-letter topic.",
+		}, []string{"topic"}),
+		WebhookDeliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "webhook_deliveries_total",
+			Help:      "Number of webhook deliveries by whether they succeeded after retries.",
+		}, []string{"result"}),
+		CacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_requests_total",
+			Help:      "Number of cache reads by keyspace and result.",
+		}, []string{"keyspace", "result"}),
+		CacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Number of service lookups by whether the cache answered them.",
+		}, []string{"lookup", "result"}),
+	}
+
+	registry.MustRegister(
+		m.ObjectTypeCreated,
+		m.ObjectTypeUpdated,
+		m.ObjectTypeDeleted,
+		m.LinkTypeCreated,
+		m.LinkTypeUpdated,
+		m.LinkTypeDeleted,
+		m.QueryDuration,
+		m.SlowQueries,
+		m.HTTPRequestDuration,
+		m.DeadLetterSent,
+		m.WebhookDeliveries,
+		m.CacheRequests,
+		m.CacheLookups,
+	)
+
+	return m
+}
+
+// NewDefaultRegistry creates a registry with the Go runtime and process collectors
+func NewDefaultRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// Registry returns the registry the collectors are registered on
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler returns an HTTP handler exposing the registry in Prometheus format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveQuery records the latency of a repository operation started at start
+func (m *Metrics) ObserveQuery(repository, operation string, start time.Time) {
+	m.QueryDuration.WithLabelValues(repository, operation).Observe(time.Since(start).Seconds())
+}
+
+// ObserveCacheRequest records the result of a cache read in keyspace
+func (m *Metrics) ObserveCacheRequest(keyspace, result string) {
+	m.CacheRequests.WithLabelValues(keyspace, result).Inc()
+}
+
+// ObserveCacheLookup records whether the cache answered a service lookup
+func (m *Metrics) ObserveCacheLookup(lookup string, hit bool) {
+	result := CacheMiss
+	if hit {
+		result = CacheHit
+	}
+	m.CacheLookups.WithLabelValues(lookup, result).Inc()
+}
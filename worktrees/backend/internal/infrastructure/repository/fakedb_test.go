@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/infrastructure/database"
+)
+
+// fakeStatement is a statement run against a fakeDB
+type fakeStatement struct {
+	query string
+	args  []interface{}
+}
+
+// fakeDB is an in-memory database/sql driver that records the statements it
+// runs instead of executing them. Queries are answered by rows, which
+// returns the column names and values for a query; nil answers every query
+// with no rows. Statements report affected rows, or 1 when it is nil. fail,
+// when set, returns the error a query or statement fails with.
+type fakeDB struct {
+	mu           sync.Mutex
+	statements   []fakeStatement
+	transactions []driver.TxOptions
+	rows         func(query string, args []interface{}) ([]string, [][]driver.Value)
+	affected     func(query string, args []interface{}) int64
+	fail         func(query string) error
+}
+
+// newFakeDB returns a fakeDB and a *sql.DB backed by it
+func newFakeDB(t *testing.T) (*fakeDB, *sql.DB) {
+	t.Helper()
+	fake := &fakeDB{}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+	return fake, db
+}
+
+// newFakeSplitter returns a fakeDB and a ReadWriteSplitter that sends every
+// query to it
+func newFakeSplitter(t *testing.T) (*fakeDB, *database.ReadWriteSplitter) {
+	t.Helper()
+	fake, db := newFakeDB(t)
+	return fake, database.NewReadWriteSplitter(db, nil, 0)
+}
+
+// queries returns the statements run so far whose text contains substr
+func (f *fakeDB) queries(substr string) []fakeStatement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []fakeStatement
+	for _, statement := range f.statements {
+		if strings.Contains(statement.query, substr) {
+			matched = append(matched, statement)
+		}
+	}
+	return matched
+}
+
+func (f *fakeDB) record(query string, named []driver.NamedValue) []interface{} {
+	args := make([]interface{}, len(named))
+	for i, arg := range named {
+		args[i] = arg.Value
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statements = append(f.statements, fakeStatement{query: query, args: args})
+	return args
+}
+
+func (f *fakeDB) Connect(ctx context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                            { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("fakeDB is opened through sql.OpenDB")
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakeDB does not prepare statements")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.transactions = append(c.db.transactions, opts)
+	return fakeTx{}, nil
+}
+
+// CheckNamedValue passes arguments through unconverted, so tests see the
+// values the repository bound
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	recorded := c.db.record(query, args)
+	if c.db.fail != nil {
+		if err := c.db.fail(query); err != nil {
+			return nil, err
+		}
+	}
+	if c.db.affected != nil {
+		return driver.RowsAffected(c.db.affected(query, recorded)), nil
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, named []driver.NamedValue) (driver.Rows, error) {
+	args := c.db.record(query, named)
+	if c.db.fail != nil {
+		if err := c.db.fail(query); err != nil {
+			return nil, err
+		}
+	}
+	rows := &fakeRows{}
+	if c.db.rows != nil {
+		rows.columns, rows.values = c.db.rows(query, args)
+	}
+	return rows, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// objectTypeRow returns the values scanObjectType reads for objectType
+func objectTypeRow(objectType *entity.ObjectType) []driver.Value {
+	createdAt := objectType.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	return []driver.Value{
+		objectType.ID.String(), objectType.Name, objectType.DisplayName, nil,
+		nil, []byte("{}"), []byte("[]"), []byte("[]"), []byte("{}"), nil,
+		int64(objectType.Version), createdAt, objectType.CreatedBy, createdAt, objectType.UpdatedBy,
+		entity.DefaultTenantID,
+	}
+}
+
+// objectTypeColumnNames are the columns of objectTypeRow
+var objectTypeColumnNames = strings.Fields(strings.NewReplacer(",", " ").Replace(objectTypeColumns))
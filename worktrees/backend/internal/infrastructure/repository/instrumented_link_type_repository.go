@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"go.uber.org/zap"
+)
+
+// InstrumentedLinkTypeRepository records query latency for a LinkTypeRepository
+type InstrumentedLinkTypeRepository struct {
+	next     repository.LinkTypeRepository
+	observer queryObserver
+}
+
+// NewInstrumentedLinkTypeRepository wraps a link type repository with latency metrics.
+// Operations taking at least slowQueryThreshold are logged and counted as
+// slow; 0 disables slow query reporting.
+func NewInstrumentedLinkTypeRepository(next repository.LinkTypeRepository, m *metrics.Metrics, slowQueryThreshold time.Duration, logger *zap.Logger) repository.LinkTypeRepository {
+	return &InstrumentedLinkTypeRepository{
+		next: next,
+		observer: queryObserver{
+			repository:    "link_type",
+			metrics:       m,
+			slowThreshold: slowQueryThreshold,
+			logger:        logger,
+		},
+	}
+}
+
+// Create implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) Create(ctx context.Context, linkType *entity.LinkType) error {
+	defer r.observer.observe("create", time.Now())
+	return r.next.Create(ctx, linkType)
+}
+
+// CreatePair implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) CreatePair(ctx context.Context, linkType, inverse *entity.LinkType) error {
+	defer r.observer.observe("create_pair", time.Now())
+	return r.next.CreatePair(ctx, linkType, inverse)
+}
+
+// GetByID implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.LinkType, error) {
+	defer r.observer.observe("get_by_id", time.Now())
+	return r.next.GetByID(ctx, id)
+}
+
+// GetByName implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) GetByName(ctx context.Context, name string) (*entity.LinkType, error) {
+	defer r.observer.observe("get_by_name", time.Now())
+	return r.next.GetByName(ctx, name)
+}
+
+// Update implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) Update(ctx context.Context, linkType *entity.LinkType) error {
+	defer r.observer.observe("update", time.Now())
+	return r.next.Update(ctx, linkType)
+}
+
+// Delete implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.observer.observe("delete", time.Now())
+	return r.next.Delete(ctx, id)
+}
+
+// Purge implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	defer r.observer.observe("purge", time.Now())
+	return r.next.Purge(ctx, id)
+}
+
+// ListPurgeable implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.LinkType, error) {
+	defer r.observer.observe("list_purgeable", time.Now())
+	return r.next.ListPurgeable(ctx, deletedBefore, limit)
+}
+
+// List implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) List(ctx context.Context, filter repository.LinkTypeFilter) ([]*entity.LinkType, error) {
+	defer r.observer.observe("list", time.Now())
+	return r.next.List(ctx, filter)
+}
+
+// Count implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) Count(ctx context.Context, filter repository.LinkTypeFilter) (int64, error) {
+	defer r.observer.observe("count", time.Now())
+	return r.next.Count(ctx, filter)
+}
+
+// Search implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) Search(ctx context.Context, query string, limit int) ([]*entity.LinkType, error) {
+	defer r.observer.observe("search", time.Now())
+	return r.next.Search(ctx, query, limit)
+}
+
+// GetBySourceObjectType implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) GetBySourceObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error) {
+	defer r.observer.observe("get_by_source_object_type", time.Now())
+	return r.next.GetBySourceObjectType(ctx, objectTypeID)
+}
+
+// GetByTargetObjectType implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) GetByTargetObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error) {
+	defer r.observer.observe("get_by_target_object_type", time.Now())
+	return r.next.GetByTargetObjectType(ctx, objectTypeID)
+}
+
+// GetByObjectTypes implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) GetByObjectTypes(ctx context.Context, sourceID, targetID uuid.UUID) ([]*entity.LinkType, error) {
+	defer r.observer.observe("get_by_object_types", time.Now())
+	return r.next.GetByObjectTypes(ctx, sourceID, targetID)
+}
+
+// CheckCircularReference implements repository.LinkTypeRepository
+func (r *InstrumentedLinkTypeRepository) CheckCircularReference(ctx context.Context, sourceID, targetID uuid.UUID) ([]uuid.UUID, error) {
+	defer r.observer.observe("check_circular_reference", time.Now())
+	return r.next.CheckCircularReference(ctx, sourceID, targetID)
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"go.uber.org/zap"
+)
+
+// InstrumentedObjectTypeRepository records query latency for a ObjectTypeRepository
+type InstrumentedObjectTypeRepository struct {
+	next     repository.ObjectTypeRepository
+	observer queryObserver
+}
+
+// NewInstrumentedObjectTypeRepository wraps a object type repository with latency metrics.
+// Operations taking at least slowQueryThreshold are logged and counted as
+// slow; 0 disables slow query reporting.
+func NewInstrumentedObjectTypeRepository(next repository.ObjectTypeRepository, m *metrics.Metrics, slowQueryThreshold time.Duration, logger *zap.Logger) repository.ObjectTypeRepository {
+	return &InstrumentedObjectTypeRepository{
+		next: next,
+		observer: queryObserver{
+			repository:    "object_type",
+			metrics:       m,
+			slowThreshold: slowQueryThreshold,
+			logger:        logger,
+		},
+	}
+}
+
+// Create implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) Create(ctx context.Context, objectType *entity.ObjectType) error {
+	defer r.observer.observe("create", time.Now())
+	return r.next.Create(ctx, objectType)
+}
+
+// GetByID implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	defer r.observer.observe("get_by_id", time.Now())
+	return r.next.GetByID(ctx, id)
+}
+
+// GetByIDs implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error) {
+	defer r.observer.observe("get_by_ids", time.Now())
+	return r.next.GetByIDs(ctx, ids)
+}
+
+// GetByName implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) GetByName(ctx context.Context, name string) (*entity.ObjectType, error) {
+	defer r.observer.observe("get_by_name", time.Now())
+	return r.next.GetByName(ctx, name)
+}
+
+// GetByNameIncludingDeleted implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) GetByNameIncludingDeleted(ctx context.Context, name string) ([]*entity.ObjectType, error) {
+	defer r.observer.observe("get_by_name_including_deleted", time.Now())
+	return r.next.GetByNameIncludingDeleted(ctx, name)
+}
+
+// Update implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) Update(ctx context.Context, objectType *entity.ObjectType, changeDescription string) error {
+	defer r.observer.observe("update", time.Now())
+	return r.next.Update(ctx, objectType, changeDescription)
+}
+
+// Delete implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.observer.observe("delete", time.Now())
+	return r.next.Delete(ctx, id)
+}
+
+// DeleteCascade implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) DeleteCascade(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	defer r.observer.observe("delete_cascade", time.Now())
+	return r.next.DeleteCascade(ctx, id)
+}
+
+// BatchDelete implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) BatchDelete(ctx context.Context, ids []uuid.UUID) ([][]uuid.UUID, error) {
+	defer r.observer.observe("batch_delete", time.Now())
+	return r.next.BatchDelete(ctx, ids)
+}
+
+// Purge implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	defer r.observer.observe("purge", time.Now())
+	return r.next.Purge(ctx, id)
+}
+
+// Restore implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	defer r.observer.observe("restore", time.Now())
+	return r.next.Restore(ctx, id)
+}
+
+// ListDeleted implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListDeleted(ctx context.Context, limit int) ([]*entity.ObjectType, error) {
+	defer r.observer.observe("list_deleted", time.Now())
+	return r.next.ListDeleted(ctx, limit)
+}
+
+// ListPurgeable implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.ObjectType, error) {
+	defer r.observer.observe("list_purgeable", time.Now())
+	return r.next.ListPurgeable(ctx, deletedBefore, limit)
+}
+
+// ListTenants implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListTenants(ctx context.Context) ([]string, error) {
+	defer r.observer.observe("list_tenants", time.Now())
+	return r.next.ListTenants(ctx)
+}
+
+// List implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
+	defer r.observer.observe("list", time.Now())
+	return r.next.List(ctx, filter)
+}
+
+// Count implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) Count(ctx context.Context, filter repository.ObjectTypeFilter) (int64, error) {
+	defer r.observer.observe("count", time.Now())
+	return r.next.Count(ctx, filter)
+}
+
+// Search implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) Search(ctx context.Context, query string, limit int, opts repository.SearchOptions) (*repository.SearchPage, error) {
+	defer r.observer.observe("search", time.Now())
+	return r.next.Search(ctx, query, limit, opts)
+}
+
+// ListChildren implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListChildren(ctx context.Context, id uuid.UUID) ([]*entity.ObjectType, error) {
+	defer r.observer.observe("list_children", time.Now())
+	return r.next.ListChildren(ctx, id)
+}
+
+// ListReferencing implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListReferencing(ctx context.Context, id uuid.UUID) ([]*entity.ObjectType, error) {
+	defer r.observer.observe("list_referencing", time.Now())
+	return r.next.ListReferencing(ctx, id)
+}
+
+// ListCategories implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListCategories(ctx context.Context) ([]repository.CategoryCount, error) {
+	defer r.observer.observe("list_categories", time.Now())
+	return r.next.ListCategories(ctx)
+}
+
+// ListTags implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListTags(ctx context.Context) ([]repository.TagCount, error) {
+	defer r.observer.observe("list_tags", time.Now())
+	return r.next.ListTags(ctx)
+}
+
+// SuggestNames implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) SuggestNames(ctx context.Context, prefix string, limit int) ([]repository.NameSuggestion, error) {
+	defer r.observer.observe("suggest_names", time.Now())
+	return r.next.SuggestNames(ctx, prefix, limit)
+}
+
+// GetVersion implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) GetVersion(ctx context.Context, id uuid.UUID, version int) (*entity.ObjectType, error) {
+	defer r.observer.observe("get_version", time.Now())
+	return r.next.GetVersion(ctx, id, version)
+}
+
+// GetVersionAt implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) GetVersionAt(ctx context.Context, id uuid.UUID, at time.Time) (*entity.ObjectType, error) {
+	defer r.observer.observe("get_version_at", time.Now())
+	return r.next.GetVersionAt(ctx, id, at)
+}
+
+// ListVersions implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListVersions(ctx context.Context, id uuid.UUID, filter repository.VersionFilter) ([]*repository.ObjectTypeVersion, error) {
+	defer r.observer.observe("list_versions", time.Now())
+	return r.next.ListVersions(ctx, id, filter)
+}
+
+// CompareVersions implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) CompareVersions(ctx context.Context, id uuid.UUID, v1, v2 int) (*repository.VersionDiff, error) {
+	defer r.observer.observe("compare_versions", time.Now())
+	return r.next.CompareVersions(ctx, id, v1, v2)
+}
+
+// CompareVersionRange implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) CompareVersionRange(ctx context.Context, id uuid.UUID, fromVersion, toVersion int) ([]*repository.VersionDiff, error) {
+	defer r.observer.observe("compare_version_range", time.Now())
+	return r.next.CompareVersionRange(ctx, id, fromVersion, toVersion)
+}
+
+// BatchCreate implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) BatchCreate(ctx context.Context, objectTypes []*entity.ObjectType) error {
+	defer r.observer.observe("batch_create", time.Now())
+	return r.next.BatchCreate(ctx, objectTypes)
+}
+
+// BatchUpdate implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) BatchUpdate(ctx context.Context, objectTypes []*entity.ObjectType, changeDescription string) error {
+	defer r.observer.observe("batch_update", time.Now())
+	return r.next.BatchUpdate(ctx, objectTypes, changeDescription)
+}
+
+// ListIndexes implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) ListIndexes(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.ObjectTypeIndex, error) {
+	defer r.observer.observe("list_indexes", time.Now())
+	return r.next.ListIndexes(ctx, objectTypeID)
+}
+
+// SyncIndexes implements repository.ObjectTypeRepository
+func (r *InstrumentedObjectTypeRepository) SyncIndexes(ctx context.Context, objectTypeID uuid.UUID, added []entity.ObjectTypeIndex, removed []string) error {
+	defer r.observer.observe("sync_indexes", time.Now())
+	return r.next.SyncIndexes(ctx, objectTypeID, added, removed)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// PostgresAPIKeyRepository implements APIKeyRepository using PostgreSQL
+type PostgresAPIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAPIKeyRepository creates a new PostgreSQL API key repository
+func NewPostgresAPIKeyRepository(db *sql.DB) repository.APIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+// GetByHash retrieves an API key by the hash of its secret
+func (r *PostgresAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	query := `
+		SELECT id, name, key_hash, principal, tenant_id, roles, permissions, created_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = $1`
+
+	var key entity.APIKey
+	err := r.db.QueryRowContext(ctx, query, keyHash).Scan(
+		&key.ID,
+		&key.Name,
+		&key.KeyHash,
+		&key.Principal,
+		&key.TenantID,
+		pq.Array(&key.Roles),
+		pq.Array(&key.Permissions),
+		&key.CreatedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// defaultAuditListLimit caps audit queries that do not set a limit
+const defaultAuditListLimit = 100
+
+// PostgresAuditLogRepository implements AuditLogRepository using PostgreSQL
+type PostgresAuditLogRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAuditLogRepository creates a new PostgreSQL audit log repository
+func NewPostgresAuditLogRepository(db *sql.DB) repository.AuditLogRepository {
+	return &PostgresAuditLogRepository{db: db}
+}
+
+// List retrieves audit entries of the tenant on ctx matching filter, newest first
+func (r *PostgresAuditLogRepository) List(ctx context.Context, filter repository.AuditFilter) ([]*entity.AuditEntry, error) {
+	query := `
+		SELECT id, tenant_id, entity_type, entity_id, action, actor, old_value, new_value,
+			   correlation_id, created_at
+		FROM audit_logs
+		WHERE tenant_id = $1`
+
+	args := []interface{}{repository.TenantFromContext(ctx)}
+	argCount := 1
+
+	if filter.EntityType != "" {
+		argCount++
+		query += ` AND entity_type = $` + strconv.Itoa(argCount)
+		args = append(args, filter.EntityType)
+	}
+
+	if filter.EntityID != nil {
+		argCount++
+		query += ` AND entity_id = $` + strconv.Itoa(argCount)
+		args = append(args, *filter.EntityID)
+	}
+
+	if filter.Actor != "" {
+		argCount++
+		query += ` AND actor = $` + strconv.Itoa(argCount)
+		args = append(args, filter.Actor)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditListLimit
+	}
+	argCount++
+	query += ` ORDER BY created_at DESC, id LIMIT $` + strconv.Itoa(argCount)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entity.AuditEntry
+	for rows.Next() {
+		var entry entity.AuditEntry
+		var oldValue, newValue []byte
+		var correlationID sql.NullString
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.TenantID,
+			&entry.EntityType,
+			&entry.EntityID,
+			&entry.Action,
+			&entry.Actor,
+			&oldValue,
+			&newValue,
+			&correlationID,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+
+		if oldValue != nil {
+			entry.OldValue = json.RawMessage(oldValue)
+		}
+		if newValue != nil {
+			entry.NewValue = json.RawMessage(newValue)
+		}
+		entry.CorrelationID = correlationID.String
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate audit entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// execer is the part of *sql.DB and *sql.Tx audit entries are written through
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// writeAuditEntry records the audit entry carried by ctx through tx. It is a
+// no-op when ctx carries none, or one naming only the actor, so unaudited
+// callers are unaffected.
+func writeAuditEntry(ctx context.Context, tx execer) error {
+	entry := repository.AuditEntryFromContext(ctx)
+	if entry == nil || entry.EntityType == "" {
+		return nil
+	}
+	return insertAuditEntry(ctx, tx, entry)
+}
+
+// writeAuditEntryFor records a change to another entity made by the same
+// call as the audit entry on ctx, under that entry's actor and correlation
+// ID. It is a no-op when ctx carries no entry.
+func writeAuditEntryFor(ctx context.Context, tx execer, entityType string, entityID uuid.UUID, action string, oldValue, newValue interface{}) error {
+	entry := repository.AuditEntryFromContext(ctx)
+	if entry == nil {
+		return nil
+	}
+	return insertAuditEntry(ctx, tx, &entity.AuditEntry{
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Action:        action,
+		Actor:         entry.Actor,
+		OldValue:      oldValue,
+		NewValue:      newValue,
+		CorrelationID: entry.CorrelationID,
+	})
+}
+
+// insertAuditEntry writes entry through tx, assigning its ID and time. The
+// entry belongs to the tenant on ctx.
+func insertAuditEntry(ctx context.Context, tx execer, entry *entity.AuditEntry) error {
+	entry.TenantID = repository.TenantFromContext(ctx)
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	oldValue, err := marshalAuditValue(entry.OldValue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit old value: %w", err)
+	}
+	newValue, err := marshalAuditValue(entry.NewValue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit new value: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_logs (
+			id, entity_type, entity_id, action, actor, old_value, new_value,
+			correlation_id, created_at, tenant_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	if _, err := tx.ExecContext(ctx, query,
+		entry.ID,
+		entry.EntityType,
+		entry.EntityID,
+		entry.Action,
+		entry.Actor,
+		oldValue,
+		newValue,
+		sql.NullString{String: entry.CorrelationID, Valid: entry.CorrelationID != ""},
+		entry.CreatedAt,
+		entry.TenantID,
+	); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// marshalAuditValue encodes an audit snapshot, leaving a missing one NULL
+func marshalAuditValue(value interface{}) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
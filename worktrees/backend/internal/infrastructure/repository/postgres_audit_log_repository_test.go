@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// recordingExecer records the arguments of each statement instead of
+// running it
+type recordingExecer struct {
+	calls [][]interface{}
+}
+
+func (e *recordingExecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	e.calls = append(e.calls, args)
+	return nil, nil
+}
+
+func TestWriteAuditEntrySkipsActorOnlyEntry(t *testing.T) {
+	// Arrange
+	tx := &recordingExecer{}
+	ctx := repository.WithAuditEntry(context.Background(), &entity.AuditEntry{Actor: "alice"})
+
+	// Act
+	_ = writeAuditEntry(ctx, tx)
+
+	// Assert
+	if len(tx.calls) != 0 {
+		t.Errorf("wrote %d audit rows for an actor-only entry, want 0", len(tx.calls))
+	}
+}
+
+func TestWriteAuditEntryForUsesActorFromContext(t *testing.T) {
+	// Arrange
+	tx := &recordingExecer{}
+	ctx := repository.WithAuditEntry(context.Background(), &entity.AuditEntry{Actor: "alice"})
+
+	// Act
+	_ = writeAuditEntryFor(ctx, tx, entity.AuditEntityLinkType, uuid.New(), entity.AuditActionDelete, nil, nil)
+
+	// Assert
+	if len(tx.calls) != 1 || tx.calls[0][4] != "alice" {
+		t.Errorf("audit rows = %v, want one row by alice", tx.calls)
+	}
+}
+
+func TestWriteAuditEntryForWithoutEntryIsNoop(t *testing.T) {
+	// Arrange
+	tx := &recordingExecer{}
+
+	// Act
+	_ = writeAuditEntryFor(context.Background(), tx, entity.AuditEntityLinkType, uuid.New(), entity.AuditActionDelete, nil, nil)
+
+	// Assert
+	if len(tx.calls) != 0 {
+		t.Errorf("wrote %d audit rows without an entry on ctx, want 0", len(tx.calls))
+	}
+}
+
+func TestWriteAuditEntryRecordsTenantFromContext(t *testing.T) {
+	// Arrange
+	tx := &recordingExecer{}
+	ctx := repository.WithTenant(context.Background(), "acme")
+	ctx = repository.WithAuditEntry(ctx, &entity.AuditEntry{
+		EntityType: entity.AuditEntityObjectType,
+		EntityID:   uuid.New(),
+		Action:     entity.AuditActionCreate,
+		Actor:      "alice",
+	})
+
+	// Act
+	_ = writeAuditEntry(ctx, tx)
+
+	// Assert
+	if len(tx.calls) != 1 || tx.calls[0][9] != "acme" {
+		t.Errorf("audit rows = %v, want one row in tenant acme", tx.calls)
+	}
+}
+
+func TestUpdateWritesAuditRowByActor(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	objectType := &entity.ObjectType{ID: uuid.New(), DisplayName: "Client", Version: 2}
+	ctx := repository.WithAuditEntry(context.Background(), &entity.AuditEntry{
+		EntityType: entity.AuditEntityObjectType,
+		EntityID:   objectType.ID,
+		Action:     entity.AuditActionUpdate,
+		Actor:      "bob",
+		NewValue:   objectType,
+	})
+
+	// Act
+	err := repo.Update(ctx, objectType, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	rows := fake.queries("INSERT INTO audit_logs")
+	if len(rows) != 1 {
+		t.Fatalf("audit rows = %d, want 1", len(rows))
+	}
+	args := rows[0].args
+	if args[1] != entity.AuditEntityObjectType || args[2] != objectType.ID || args[3] != entity.AuditActionUpdate || args[4] != "bob" {
+		t.Errorf("audit row = %v, want an object type update of %s by bob", args, objectType.ID)
+	}
+	if len(fake.transactions) != 1 {
+		t.Errorf("transactions = %d, want the change and its audit row in one", len(fake.transactions))
+	}
+}
+
+func TestFailedUpdateWritesNoAuditRow(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.affected = func(query string, args []interface{}) int64 {
+		if strings.Contains(query, "UPDATE object_types") {
+			return 0
+		}
+		return 1
+	}
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"exists"}, [][]driver.Value{{true}}
+	}
+	objectType := &entity.ObjectType{ID: uuid.New(), Version: 2}
+	ctx := repository.WithAuditEntry(context.Background(), &entity.AuditEntry{
+		EntityType: entity.AuditEntityObjectType,
+		EntityID:   objectType.ID,
+		Action:     entity.AuditActionUpdate,
+		Actor:      "bob",
+	})
+
+	// Act
+	_ = repo.Update(ctx, objectType, "")
+
+	// Assert
+	if rows := fake.queries("INSERT INTO audit_logs"); len(rows) != 0 {
+		t.Errorf("audit rows = %v, want none for an update that lost the race", rows)
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// eventColumns is the column list shared by all event queries
+const eventColumns = `id, event_type, aggregate_id, aggregate_type, version, timestamp, user_id, data, metadata, tenant_id`
+
+// PostgresEventStore implements EventStore using PostgreSQL
+type PostgresEventStore struct {
+	db *sql.DB
+}
+
+// NewPostgresEventStore creates a new PostgreSQL event store
+func NewPostgresEventStore(db *sql.DB) event.EventStore {
+	return &PostgresEventStore{db: db}
+}
+
+// Save stores an event. Saving an event already stored is a no-op, so
+// retried publishes do not duplicate events. An event without a tenant is
+// stored under the tenant on ctx.
+func (s *PostgresEventStore) Save(ctx context.Context, evt event.Event) error {
+	if evt.TenantID == "" {
+		evt.TenantID = repository.TenantFromContext(ctx)
+	}
+
+	dataJSON, err := json.Marshal(evt.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	metadataJSON, err := json.Marshal(evt.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO events (` + eventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO NOTHING`
+
+	_, err = s.db.ExecContext(ctx, query,
+		evt.ID,
+		evt.EventType,
+		evt.AggregateID,
+		evt.AggregateType,
+		evt.Version,
+		evt.Timestamp,
+		evt.UserID,
+		dataJSON,
+		metadataJSON,
+		evt.TenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save event: %w", err)
+	}
+
+	return nil
+}
+
+// GetByAggregateID returns the events of an aggregate in publish order
+func (s *PostgresEventStore) GetByAggregateID(ctx context.Context, aggregateID string) ([]event.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE tenant_id = $1 AND aggregate_id = $2
+		ORDER BY id ASC`
+
+	return s.query(ctx, query, repository.TenantFromContext(ctx), aggregateID)
+}
+
+// GetByEventType returns the latest limit events of a type, newest first
+func (s *PostgresEventStore) GetByEventType(ctx context.Context, eventType string, limit int) ([]event.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events
+		WHERE tenant_id = $1 AND event_type = $2
+		ORDER BY id DESC
+		LIMIT $3`
+
+	return s.query(ctx, query, repository.TenantFromContext(ctx), eventType, limit)
+}
+
+// query runs an event query and scans its rows
+func (s *PostgresEventStore) query(ctx context.Context, query string, args ...interface{}) ([]event.Event, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []event.Event{}
+	for rows.Next() {
+		var evt event.Event
+		var dataJSON, metadataJSON []byte
+
+		err := rows.Scan(
+			&evt.ID,
+			&evt.EventType,
+			&evt.AggregateID,
+			&evt.AggregateType,
+			&evt.Version,
+			&evt.Timestamp,
+			&evt.UserID,
+			&dataJSON,
+			&metadataJSON,
+			&evt.TenantID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		// Data decodes as it does for events consumed from Kafka
+		if len(dataJSON) > 0 {
+			if err := json.Unmarshal(dataJSON, &evt.Data); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal data of event %s: %w", evt.ID, err)
+			}
+		}
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &evt.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal metadata of event %s: %w", evt.ID, err)
+			}
+		}
+
+		events = append(events, evt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// eventColumnNames are the columns of eventRow
+var eventColumnNames = strings.Fields(strings.NewReplacer(",", " ").Replace(eventColumns))
+
+// eventRow returns the values query scans for an object type event
+func eventRow(id, eventType string, version int) []driver.Value {
+	return []driver.Value{
+		id, eventType, "customer", "ObjectType", int64(version), time.Now(), "alice",
+		[]byte(`{"name":"Customer"}`), []byte(`{}`), "acme",
+	}
+}
+
+func TestEventStoreSaveIgnoresDuplicateEvents(t *testing.T) {
+	// Arrange
+	fake, db := newFakeDB(t)
+	store := NewPostgresEventStore(db)
+	ctx := repository.WithTenant(context.Background(), "acme")
+
+	// Act
+	err := store.Save(ctx, event.Event{ID: event.NewID(), EventType: "object_type.created", AggregateID: "customer"})
+
+	// Assert
+	inserts := fake.queries("INSERT INTO events")
+	if err != nil || len(inserts) != 1 {
+		t.Fatalf("Save = %v with %d inserts, want one insert", err, len(inserts))
+	}
+	if !strings.Contains(inserts[0].query, "ON CONFLICT (id) DO NOTHING") {
+		t.Errorf("insert %q does not ignore duplicate IDs", inserts[0].query)
+	}
+	if tenant := inserts[0].args[9]; tenant != "acme" {
+		t.Errorf("tenant = %v, want acme from the context", tenant)
+	}
+}
+
+func TestEventStoreReplaysAggregateInPublishOrder(t *testing.T) {
+	// Arrange
+	fake, db := newFakeDB(t)
+	store := NewPostgresEventStore(db)
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return eventColumnNames, [][]driver.Value{
+			eventRow("1", "object_type.created", 1),
+			eventRow("2", "object_type.updated", 2),
+			eventRow("3", "object_type.deleted", 3),
+		}
+	}
+	var replayed []string
+
+	// Act
+	err := event.ReplayEvents(repository.WithTenant(context.Background(), "acme"), store, "customer",
+		func(ctx context.Context, evt event.Event) error {
+			replayed = append(replayed, evt.EventType)
+			return nil
+		})
+
+	// Assert
+	if err != nil || strings.Join(replayed, ",") != "object_type.created,object_type.updated,object_type.deleted" {
+		t.Errorf("replayed %v (err %v), want created, updated, deleted", replayed, err)
+	}
+	selects := fake.queries("FROM events")
+	if len(selects) != 1 || !strings.Contains(selects[0].query, "ORDER BY id ASC") {
+		t.Fatalf("queries = %v, want one select ordered by id", selects)
+	}
+	if selects[0].args[0] != "acme" || selects[0].args[1] != "customer" {
+		t.Errorf("args = %v, want tenant acme and aggregate customer", selects[0].args)
+	}
+}
@@ -0,0 +1,661 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/database"
+)
+
+// linkTypeColumns is the column list shared by all link type queries
+const linkTypeColumns = `id, name, display_name, source_object_type_id, target_object_type_id,
+			   cardinality, description, properties, metadata, constraints, version,
+			   created_at, created_by, updated_at, updated_by, pair_id, is_inverse, tenant_id`
+
+// PostgresLinkTypeRepository implements LinkTypeRepository using PostgreSQL
+type PostgresLinkTypeRepository struct {
+	db *database.ReadWriteSplitter
+	// cursors decodes the cursors List is given
+	cursors *repository.CursorCodec
+	// statementTimeout bounds List, Count and Search queries; 0 disables it
+	statementTimeout time.Duration
+}
+
+// NewPostgresLinkTypeRepository creates a new PostgreSQL link type repository
+func NewPostgresLinkTypeRepository(db *database.ReadWriteSplitter, cursors *repository.CursorCodec, statementTimeout time.Duration) repository.LinkTypeRepository {
+	return &PostgresLinkTypeRepository{db: db, cursors: cursors, statementTimeout: statementTimeout}
+}
+
+// Create creates a new link type
+func (r *PostgresLinkTypeRepository) Create(ctx context.Context, linkType *entity.LinkType) error {
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insert(ctx, tx, linkType); err != nil {
+		return err
+	}
+
+	if err := writeAuditEntry(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CreatePair creates a link type and its inverse in one transaction
+func (r *PostgresLinkTypeRepository) CreatePair(ctx context.Context, linkType, inverse *entity.LinkType) error {
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insert(ctx, tx, linkType); err != nil {
+		return err
+	}
+	if err := r.insert(ctx, tx, inverse); err != nil {
+		return err
+	}
+
+	if err := writeAuditEntry(ctx, tx); err != nil {
+		return err
+	}
+	if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityLinkType, inverse.ID, entity.AuditActionCreate, nil, inverse); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// insert writes a new link type and its initial version record through tx
+func (r *PostgresLinkTypeRepository) insert(ctx context.Context, tx interface{ ExecContext(context.Context, string, ...interface{}) (sql.Result, error) }, linkType *entity.LinkType) error {
+	// Serialize properties and metadata to JSON
+	propertiesJSON, err := json.Marshal(linkType.Properties)
+	if err != nil {
+		return fmt.Errorf("failed to marshal properties: %w", err)
+	}
+
+	metadataJSON, err := json.Marshal(linkType.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	constraintsJSON, err := json.Marshal(linkType.Constraints)
+	if err != nil {
+		return fmt.Errorf("failed to marshal constraints: %w", err)
+	}
+
+	linkType.TenantID = repository.TenantFromContext(ctx)
+
+	// Insert link type
+	query := `
+		INSERT INTO link_types (
+			id, name, display_name, source_object_type_id, target_object_type_id,
+			cardinality, description, properties, metadata, constraints, version, is_deleted,
+			created_at, created_by, updated_at, updated_by, pair_id, is_inverse, tenant_id
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
+		)`
+
+	_, err = tx.ExecContext(ctx, query,
+		linkType.ID,
+		linkType.Name,
+		linkType.DisplayName,
+		linkType.SourceObjectTypeID,
+		linkType.TargetObjectTypeID,
+		linkType.Cardinality,
+		linkType.Description,
+		propertiesJSON,
+		metadataJSON,
+		constraintsJSON,
+		linkType.Version,
+		linkType.IsDeleted,
+		linkType.CreatedAt,
+		linkType.CreatedBy,
+		linkType.UpdatedAt,
+		linkType.UpdatedBy,
+		linkType.PairID,
+		linkType.IsInverse,
+		linkType.TenantID,
+	)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505": // unique_violation
+				return entity.ErrLinkTypeNameExists
+			case "23503": // foreign_key_violation
+				return entity.ErrObjectTypeNotFound
+			}
+		}
+		return fmt.Errorf("failed to create link type: %w", err)
+	}
+
+	// Create initial version record
+	if err := r.createVersion(ctx, tx, linkType); err != nil {
+		return fmt.Errorf("failed to create version record: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a link type by ID
+func (r *PostgresLinkTypeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.LinkType, error) {
+	query := `
+		SELECT ` + linkTypeColumns + `
+		FROM link_types
+		WHERE id = $1 AND tenant_id = $2 AND is_deleted = FALSE`
+
+	return r.scanLinkType(r.db.Reader(ctx).QueryRowContext(ctx, query, id, repository.TenantFromContext(ctx)))
+}
+
+// GetByName retrieves a link type by name
+func (r *PostgresLinkTypeRepository) GetByName(ctx context.Context, name string) (*entity.LinkType, error) {
+	query := `
+		SELECT ` + linkTypeColumns + `
+		FROM link_types
+		WHERE name = $1 AND tenant_id = $2 AND is_deleted = FALSE`
+
+	return r.scanLinkType(r.db.Reader(ctx).QueryRowContext(ctx, query, name, repository.TenantFromContext(ctx)))
+}
+
+// Update updates an existing link type, including its pairing
+func (r *PostgresLinkTypeRepository) Update(ctx context.Context, linkType *entity.LinkType) error {
+	// Serialize properties and metadata to JSON
+	propertiesJSON, err := json.Marshal(linkType.Properties)
+	if err != nil {
+		return fmt.Errorf("failed to marshal properties: %w", err)
+	}
+
+	metadataJSON, err := json.Marshal(linkType.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	constraintsJSON, err := json.Marshal(linkType.Constraints)
+	if err != nil {
+		return fmt.Errorf("failed to marshal constraints: %w", err)
+	}
+
+	linkType.TenantID = repository.TenantFromContext(ctx)
+
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Update link type
+	query := `
+		UPDATE link_types SET
+			display_name = $2,
+			cardinality = $3,
+			description = $4,
+			properties = $5,
+			metadata = $6,
+			constraints = $7,
+			version = $8,
+			updated_at = $9,
+			updated_by = $10,
+			pair_id = $12,
+			is_inverse = $13
+		WHERE id = $1 AND tenant_id = $11 AND is_deleted = FALSE`
+
+	result, err := tx.ExecContext(ctx, query,
+		linkType.ID,
+		linkType.DisplayName,
+		linkType.Cardinality,
+		linkType.Description,
+		propertiesJSON,
+		metadataJSON,
+		constraintsJSON,
+		linkType.Version,
+		linkType.UpdatedAt,
+		linkType.UpdatedBy,
+		linkType.TenantID,
+		linkType.PairID,
+		linkType.IsInverse,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update link type: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrLinkTypeNotFound
+	}
+
+	// Create version record
+	if err := r.createVersion(ctx, tx, linkType); err != nil {
+		return fmt.Errorf("failed to create version record: %w", err)
+	}
+
+	if err := writeAuditEntry(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete soft deletes a link type
+func (r *PostgresLinkTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE link_types
+		SET is_deleted = TRUE, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND is_deleted = FALSE`
+
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, id, repository.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete link type: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrLinkTypeNotFound
+	}
+
+	if err := writeAuditEntry(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently removes a soft-deleted link type; its versions go with it
+// through ON DELETE CASCADE
+func (r *PostgresLinkTypeRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM link_types
+		WHERE id = $1 AND tenant_id = $2 AND is_deleted = TRUE
+		RETURNING ` + linkTypeColumns
+
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	purged, err := r.scanLinkType(tx.QueryRowContext(ctx, query, id, repository.TenantFromContext(ctx)))
+	if err != nil {
+		if errors.Is(err, entity.ErrLinkTypeNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to purge link type: %w", err)
+	}
+	purged.IsDeleted = true
+
+	if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityLinkType, id, entity.AuditActionPurge, purged, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListPurgeable retrieves link types soft-deleted before deletedBefore,
+// oldest deletions first. The primary is read since the result drives deletes.
+func (r *PostgresLinkTypeRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.LinkType, error) {
+	query := `
+		SELECT ` + linkTypeColumns + `
+		FROM link_types
+		WHERE is_deleted = TRUE AND updated_at < $1
+		ORDER BY updated_at, id
+		LIMIT $2`
+
+	return r.queryLinkTypes(ctx, r.db.Primary(), query, deletedBefore, limit)
+}
+
+// List retrieves a list of link types based on filter
+func (r *PostgresLinkTypeRepository) List(ctx context.Context, filter repository.LinkTypeFilter) ([]*entity.LinkType, error) {
+	query := `
+		SELECT ` + linkTypeColumns + `
+		FROM link_types
+		WHERE tenant_id = $1 AND is_deleted = FALSE`
+
+	args := []interface{}{repository.TenantFromContext(ctx)}
+	argCount := 1
+
+	// Handle cursor-based pagination
+	if filter.PageCursor != "" {
+		cursor, err := r.cursors.Decode(filter.PageCursor)
+		if err != nil || cursor.SortBy != repository.SortByCreatedAt {
+			return nil, fmt.Errorf("%w: invalid cursor", repository.ErrInvalidInput)
+		}
+
+		nanos, err := strconv.ParseInt(cursor.Value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor: %v", repository.ErrInvalidInput, err)
+		}
+
+		argCount++
+		query += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", argCount, argCount+1)
+		args = append(args, time.Unix(0, nanos), cursor.ID)
+		argCount++
+	}
+
+	// Apply filters
+	filterQuery, filterArgs := r.buildFilter(filter, argCount)
+	query += filterQuery
+	args = append(args, filterArgs...)
+	argCount += len(filterArgs)
+
+	// Order and limit
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.PageSize > 0 {
+		argCount++
+		query += fmt.Sprintf(" LIMIT $%d", argCount)
+		args = append(args, filter.PageSize)
+	}
+
+	return r.queryLinkTypesWithTimeout(ctx, query, args...)
+}
+
+// Count counts link types based on filter
+func (r *PostgresLinkTypeRepository) Count(ctx context.Context, filter repository.LinkTypeFilter) (int64, error) {
+	query := `SELECT COUNT(*) FROM link_types WHERE tenant_id = $1 AND is_deleted = FALSE`
+
+	filterQuery, filterArgs := r.buildFilter(filter, 1)
+	query += filterQuery
+	args := append([]interface{}{repository.TenantFromContext(ctx)}, filterArgs...)
+
+	var count int64
+	err := withStatementTimeout(ctx, r.db.Reader(ctx), r.statementTimeout, func(q queryer) error {
+		if err := q.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+			return fmt.Errorf("failed to count link types: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Search implements full-text search using PostgreSQL's tsvector
+func (r *PostgresLinkTypeRepository) Search(ctx context.Context, query string, limit int) ([]*entity.LinkType, error) {
+	sql := `
+		SELECT ` + linkTypeColumns + `
+		FROM link_types
+		WHERE to_tsvector('english', name || ' ' || display_name || ' ' || COALESCE(description, ''))
+		@@ plainto_tsquery('english', $1)
+		AND tenant_id = $3 AND is_deleted = FALSE
+		ORDER BY ts_rank(to_tsvector('english', name || ' ' || display_name || ' ' || COALESCE(description, '')),
+						plainto_tsquery('english', $1)) DESC
+		LIMIT $2`
+
+	results, err := r.queryLinkTypesWithTimeout(ctx, sql, query, limit, repository.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search link types: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetBySourceObjectType retrieves link types whose source is the given object type
+func (r *PostgresLinkTypeRepository) GetBySourceObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error) {
+	query := `
+		SELECT ` + linkTypeColumns + `
+		FROM link_types
+		WHERE source_object_type_id = $1 AND tenant_id = $2 AND is_deleted = FALSE
+		ORDER BY name`
+
+	return r.queryLinkTypes(ctx, r.db.Reader(ctx), query, objectTypeID, repository.TenantFromContext(ctx))
+}
+
+// GetByTargetObjectType retrieves link types whose target is the given object type
+func (r *PostgresLinkTypeRepository) GetByTargetObjectType(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.LinkType, error) {
+	query := `
+		SELECT ` + linkTypeColumns + `
+		FROM link_types
+		WHERE target_object_type_id = $1 AND tenant_id = $2 AND is_deleted = FALSE
+		ORDER BY name`
+
+	return r.queryLinkTypes(ctx, r.db.Reader(ctx), query, objectTypeID, repository.TenantFromContext(ctx))
+}
+
+// GetByObjectTypes retrieves link types between a source and a target object type
+func (r *PostgresLinkTypeRepository) GetByObjectTypes(ctx context.Context, sourceID, targetID uuid.UUID) ([]*entity.LinkType, error) {
+	query := `
+		SELECT ` + linkTypeColumns + `
+		FROM link_types
+		WHERE source_object_type_id = $1 AND target_object_type_id = $2 AND tenant_id = $3 AND is_deleted = FALSE
+		ORDER BY name`
+
+	return r.queryLinkTypes(ctx, r.db.Reader(ctx), query, sourceID, targetID, repository.TenantFromContext(ctx))
+}
+
+// maxCycleSearchDepth bounds the length of the paths explored by CheckCircularReference
+const maxCycleSearchDepth = 32
+
+// CheckCircularReference walks existing link types from targetID and returns
+// the shortest path back to sourceID, prefixed with sourceID, so the result
+// reads source -> target -> ... -> source. It returns nil when no such path
+// exists. A self-referencing link is reported as [source, source].
+func (r *PostgresLinkTypeRepository) CheckCircularReference(ctx context.Context, sourceID, targetID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		WITH RECURSIVE reachable(object_type_id, path) AS (
+			SELECT $1::uuid, ARRAY[$1::uuid]
+			UNION ALL
+			SELECT lt.target_object_type_id, r.path || lt.target_object_type_id
+			FROM link_types lt
+			JOIN reachable r ON lt.source_object_type_id = r.object_type_id
+			WHERE lt.is_deleted = FALSE
+			  AND lt.tenant_id = $4
+			  AND lt.is_inverse = FALSE
+			  AND NOT lt.target_object_type_id = ANY(r.path)
+			  AND array_length(r.path, 1) < $3
+		)
+		SELECT path::text[]
+		FROM reachable
+		WHERE object_type_id = $2
+		ORDER BY array_length(path, 1)
+		LIMIT 1`
+
+	// Checked before writes, so read the primary rather than a lagging replica
+	var path []string
+	err := r.db.Primary().QueryRowContext(ctx, query, targetID, sourceID, maxCycleSearchDepth, repository.TenantFromContext(ctx)).Scan(pq.Array(&path))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check circular reference: %w", err)
+	}
+
+	cycle := make([]uuid.UUID, 0, len(path)+1)
+	cycle = append(cycle, sourceID)
+	for _, idStr := range path {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cycle path: %w", err)
+		}
+		cycle = append(cycle, id)
+	}
+
+	return cycle, nil
+}
+
+// Helper methods
+
+func (r *PostgresLinkTypeRepository) buildFilter(filter repository.LinkTypeFilter, argCount int) (string, []interface{}) {
+	var query string
+	var args []interface{}
+
+	if filter.SourceObjectTypeID != nil {
+		argCount++
+		query += fmt.Sprintf(" AND source_object_type_id = $%d", argCount)
+		args = append(args, *filter.SourceObjectTypeID)
+	}
+
+	if filter.TargetObjectTypeID != nil {
+		argCount++
+		query += fmt.Sprintf(" AND target_object_type_id = $%d", argCount)
+		args = append(args, *filter.TargetObjectTypeID)
+	}
+
+	if filter.Cardinality != nil {
+		argCount++
+		query += fmt.Sprintf(" AND cardinality = $%d", argCount)
+		args = append(args, *filter.Cardinality)
+	}
+
+	return query, args
+}
+
+// queryLinkTypesWithTimeout runs queryLinkTypes under the statement timeout
+func (r *PostgresLinkTypeRepository) queryLinkTypesWithTimeout(ctx context.Context, query string, args ...interface{}) ([]*entity.LinkType, error) {
+	var linkTypes []*entity.LinkType
+	err := withStatementTimeout(ctx, r.db.Reader(ctx), r.statementTimeout, func(q queryer) error {
+		var err error
+		linkTypes, err = r.queryLinkTypes(ctx, q, query, args...)
+		return err
+	})
+	return linkTypes, err
+}
+
+func (r *PostgresLinkTypeRepository) queryLinkTypes(ctx context.Context, q queryer, query string, args ...interface{}) ([]*entity.LinkType, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query link types: %w", err)
+	}
+	defer rows.Close()
+
+	var linkTypes []*entity.LinkType
+	for rows.Next() {
+		lt, err := scanLinkTypeFrom(rows)
+		if err != nil {
+			return nil, err
+		}
+		linkTypes = append(linkTypes, lt)
+	}
+
+	return linkTypes, rows.Err()
+}
+
+func (r *PostgresLinkTypeRepository) scanLinkType(row *sql.Row) (*entity.LinkType, error) {
+	lt, err := scanLinkTypeFrom(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrLinkTypeNotFound
+		}
+		return nil, err
+	}
+
+	return lt, nil
+}
+
+// scanLinkTypeFrom scans a row of linkTypeColumns
+func scanLinkTypeFrom(scanner interface{ Scan(...interface{}) error }) (*entity.LinkType, error) {
+	var lt entity.LinkType
+	var propertiesJSON, metadataJSON, constraintsJSON []byte
+
+	err := scanner.Scan(
+		&lt.ID,
+		&lt.Name,
+		&lt.DisplayName,
+		&lt.SourceObjectTypeID,
+		&lt.TargetObjectTypeID,
+		&lt.Cardinality,
+		&lt.Description,
+		&propertiesJSON,
+		&metadataJSON,
+		&constraintsJSON,
+		&lt.Version,
+		&lt.CreatedAt,
+		&lt.CreatedBy,
+		&lt.UpdatedAt,
+		&lt.UpdatedBy,
+		&lt.PairID,
+		&lt.IsInverse,
+		&lt.TenantID,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan link type: %w", err)
+	}
+
+	// Unmarshal JSON fields
+	if len(propertiesJSON) > 0 {
+		if err := json.Unmarshal(propertiesJSON, &lt.Properties); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal properties: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(metadataJSON, &lt.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+
+	if err := json.Unmarshal(constraintsJSON, &lt.Constraints); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal constraints: %w", err)
+	}
+
+	return &lt, nil
+}
+
+func (r *PostgresLinkTypeRepository) createVersion(ctx context.Context, tx interface{ ExecContext(context.Context, string, ...interface{}) (sql.Result, error) }, linkType *entity.LinkType) error {
+	snapshotJSON, err := json.Marshal(linkType)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	query := `
+		INSERT INTO link_type_versions (
+			link_type_id, version, snapshot, created_at, created_by
+		) VALUES ($1, $2, $3, $4, $5)`
+
+	_, err = tx.ExecContext(ctx, query,
+		linkType.ID,
+		linkType.Version,
+		snapshotJSON,
+		linkType.UpdatedAt,
+		linkType.UpdatedBy,
+	)
+
+	return err
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+func newTestLinkTypeRepository(t *testing.T) (*fakeDB, *PostgresLinkTypeRepository) {
+	t.Helper()
+	fake, db := newFakeSplitter(t)
+	return fake, NewPostgresLinkTypeRepository(db, testCursors, 0).(*PostgresLinkTypeRepository)
+}
+
+func TestCheckCircularReferenceReturnsCycleFromSource(t *testing.T) {
+	// Arrange
+	fake, repo := newTestLinkTypeRepository(t)
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		// The path found from the new link's target back to its source
+		return []string{"path"}, [][]driver.Value{{[]byte(fmt.Sprintf("{%s,%s,%s}", a, b, c))}}
+	}
+
+	// Act
+	cycle, _ := repo.CheckCircularReference(context.Background(), c, a)
+
+	// Assert
+	if want := []uuid.UUID{c, a, b, c}; !slices.Equal(cycle, want) {
+		t.Errorf("cycle = %v, want %v", cycle, want)
+	}
+}
+
+func TestCheckCircularReferenceWithoutPathIsNoCycle(t *testing.T) {
+	// Arrange
+	_, repo := newTestLinkTypeRepository(t)
+
+	// Act
+	cycle, err := repo.CheckCircularReference(context.Background(), uuid.New(), uuid.New())
+
+	// Assert
+	if err != nil || cycle != nil {
+		t.Errorf("CheckCircularReference = %v, %v; want nil, nil", cycle, err)
+	}
+}
+
+func TestCheckCircularReferenceSearchesFromTargetWithinTenant(t *testing.T) {
+	// Arrange
+	fake, repo := newTestLinkTypeRepository(t)
+	source, target := uuid.New(), uuid.New()
+	ctx := repository.WithTenant(context.Background(), "acme")
+
+	// Act
+	_, _ = repo.CheckCircularReference(ctx, source, target)
+
+	// Assert
+	args := fake.queries("WITH RECURSIVE")[0].args
+	if args[0] != target || args[1] != source || args[2] != maxCycleSearchDepth || args[3] != "acme" {
+		t.Errorf("args = %v, want target, source, depth and tenant", args)
+	}
+}
+
+func TestGetByIDWithoutRowIsLinkTypeNotFound(t *testing.T) {
+	// Arrange
+	_, repo := newTestLinkTypeRepository(t)
+
+	// Act
+	_, err := repo.GetByID(context.Background(), uuid.New())
+
+	// Assert
+	if !errors.Is(err, entity.ErrLinkTypeNotFound) {
+		t.Errorf("err = %v, want %v", err, entity.ErrLinkTypeNotFound)
+	}
+}
+
+func TestSearchLinkTypesIsScopedToTheTenantAndSkipsDeleted(t *testing.T) {
+	// Arrange
+	fake, repo := newTestLinkTypeRepository(t)
+	places := &entity.LinkType{ID: uuid.New(), Name: "places", TenantID: "acme", Version: 1}
+	fake.rows = hiddenFrom("globex", linkTypeColumnNames, linkTypeRow(places))
+
+	// Act
+	found, err := repo.Search(repository.WithTenant(context.Background(), "acme"), "places", 10)
+	other, otherErr := repo.Search(repository.WithTenant(context.Background(), "globex"), "places", 10)
+
+	// Assert
+	if err != nil || len(found) != 1 || found[0].ID != places.ID {
+		t.Errorf("acme search = %v, %v, want its link type", found, err)
+	}
+	if otherErr != nil || len(other) != 0 {
+		t.Errorf("globex search = %v, %v, want nothing of acme", other, otherErr)
+	}
+	search := fake.queries("plainto_tsquery")[0]
+	if !strings.Contains(search.query, "is_deleted = FALSE") || !slices.Equal(search.args, []interface{}{"places", 10, "acme"}) {
+		t.Errorf("query %q with %v, want live link types of acme only", search.query, search.args)
+	}
+}
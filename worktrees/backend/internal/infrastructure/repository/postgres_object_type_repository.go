@@ -3,9 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -14,20 +15,40 @@ import (
 	"github.com/lib/pq"
 	"github.com/openfoundry/oms/internal/domain/entity"
 	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/database"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
+// objectTypeColumns is the column list scanObjectType reads
+const objectTypeColumns = `id, name, display_name, description, category, tags,
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id`
+
 // PostgresObjectTypeRepository implements ObjectTypeRepository using PostgreSQL
 type PostgresObjectTypeRepository struct {
-	db *sql.DB
+	db     *database.ReadWriteSplitter
+	logger *zap.Logger
+	// cursors encodes the cursors Search issues and decodes those List and
+	// Search are given
+	cursors *repository.CursorCodec
+	// statementTimeout bounds List, Count and Search queries; 0 disables it
+	statementTimeout time.Duration
 }
 
 // NewPostgresObjectTypeRepository creates a new PostgreSQL repository
-func NewPostgresObjectTypeRepository(db *sql.DB) repository.ObjectTypeRepository {
-	return &PostgresObjectTypeRepository{db: db}
+func NewPostgresObjectTypeRepository(db *database.ReadWriteSplitter, cursors *repository.CursorCodec, statementTimeout time.Duration, logger *zap.Logger) repository.ObjectTypeRepository {
+	return &PostgresObjectTypeRepository{db: db, logger: logger, cursors: cursors, statementTimeout: statementTimeout}
 }
 
 // Create creates a new object type
 func (r *PostgresObjectTypeRepository) Create(ctx context.Context, objectType *entity.ObjectType) error {
+	ctx, span := tracer.Start(ctx, "PostgresObjectTypeRepository.Create", trace.WithAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("object_type.id", objectType.ID.String()),))
+	defer span.End()
+
 	// Serialize properties and metadata to JSON
 	propertiesJSON, err := json.Marshal(objectType.Properties)
 	if err != nil {
@@ -44,17 +65,25 @@ func (r *PostgresObjectTypeRepository) Create(ctx context.Context, objectType *e
 		return fmt.Errorf("failed to marshal base datasets: %w", err)
 	}
 
+	objectType.TenantID = repository.TenantFromContext(ctx)
+
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// Insert object type
 	query := `
 		INSERT INTO object_types (
 			id, name, display_name, description, category, tags,
 			properties, base_datasets, metadata, version, is_deleted,
-			created_at, created_by, updated_at, updated_by
+			created_at, created_by, updated_at, updated_by, parent_id, tenant_id
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
 		)`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		objectType.ID,
 		objectType.Name,
 		objectType.DisplayName,
@@ -70,22 +99,31 @@ func (r *PostgresObjectTypeRepository) Create(ctx context.Context, objectType *e
 		objectType.CreatedBy,
 		objectType.UpdatedAt,
 		objectType.UpdatedBy,
+		objectType.ParentID,
+		objectType.TenantID,
 	)
 
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			if pqErr.Code == "23505" { // unique_violation
-				return entity.ErrObjectTypeNameExists
-			}
+		recordSpanError(span, err)
+		if isNameConflict(err) {
+			return entity.ErrObjectTypeNameExists
 		}
 		return fmt.Errorf("failed to create object type: %w", err)
 	}
 
 	// Create initial version record
-	if err := r.createVersion(ctx, objectType); err != nil {
+	if err := r.createVersionTx(ctx, tx, objectType, ""); err != nil {
 		return fmt.Errorf("failed to create version record: %w", err)
 	}
 
+	if err := writeAuditEntry(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -93,28 +131,125 @@ func (r *PostgresObjectTypeRepository) Create(ctx context.Context, objectType *e
 func (r *PostgresObjectTypeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
 	query := `
 		SELECT id, name, display_name, description, category, tags,
-			   properties, base_datasets, metadata, version,
-			   created_at, created_by, updated_at, updated_by
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id
+		FROM object_types
+		WHERE id = $1 AND tenant_id = $2 AND is_deleted = FALSE`
+
+	return r.scanObjectType(r.db.Reader(ctx).QueryRowContext(ctx, query, id, repository.TenantFromContext(ctx)))
+}
+
+// GetByIDs retrieves the object types with the given IDs in a single query.
+// IDs that do not exist or are deleted are omitted; the result is unordered.
+func (r *PostgresObjectTypeRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ObjectType, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, name, display_name, description, category, tags,
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id
 		FROM object_types
-		WHERE id = $1 AND is_deleted = FALSE`
+		WHERE id = ANY($1::uuid[]) AND tenant_id = $2 AND is_deleted = FALSE`
+
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
 
-	return r.scanObjectType(r.db.QueryRowContext(ctx, query, id))
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, pq.Array(idStrings), repository.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object types by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var objectTypes []*entity.ObjectType
+	for rows.Next() {
+		ot, err := r.scanObjectTypeFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		objectTypes = append(objectTypes, ot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return objectTypes, nil
 }
 
 // GetByName retrieves an object type by name
 func (r *PostgresObjectTypeRepository) GetByName(ctx context.Context, name string) (*entity.ObjectType, error) {
 	query := `
 		SELECT id, name, display_name, description, category, tags,
-			   properties, base_datasets, metadata, version,
-			   created_at, created_by, updated_at, updated_by
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id
+		FROM object_types
+		WHERE name = $1 AND tenant_id = $2 AND is_deleted = FALSE`
+
+	return r.scanObjectType(r.db.Reader(ctx).QueryRowContext(ctx, query, name, repository.TenantFromContext(ctx)))
+}
+
+// GetByNameIncludingDeleted retrieves every object type that has held name:
+// the live one, if any, followed by soft-deleted ones, most recently deleted
+// first
+func (r *PostgresObjectTypeRepository) GetByNameIncludingDeleted(ctx context.Context, name string) ([]*entity.ObjectType, error) {
+	live, err := r.listByName(ctx, name, false)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := r.listByName(ctx, name, true)
+	if err != nil {
+		return nil, err
+	}
+	return append(live, deleted...), nil
+}
+
+// listByName retrieves the live or the soft-deleted object types named name
+func (r *PostgresObjectTypeRepository) listByName(ctx context.Context, name string, isDeleted bool) ([]*entity.ObjectType, error) {
+	query := `
+		SELECT id, name, display_name, description, category, tags,
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id
 		FROM object_types
-		WHERE name = $1 AND is_deleted = FALSE`
+		WHERE name = $1 AND tenant_id = $3 AND is_deleted = $2
+		ORDER BY updated_at DESC, id DESC`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, name, isDeleted, repository.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object types by name: %w", err)
+	}
+	defer rows.Close()
+
+	var objectTypes []*entity.ObjectType
+	for rows.Next() {
+		ot, err := r.scanObjectTypeFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		ot.IsDeleted = isDeleted
+		objectTypes = append(objectTypes, ot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return r.scanObjectType(r.db.QueryRowContext(ctx, query, name))
+	return objectTypes, nil
 }
 
-// Update updates an existing object type
-func (r *PostgresObjectTypeRepository) Update(ctx context.Context, objectType *entity.ObjectType) error {
+// Update updates an existing object type. The write only applies if the stored
+// version is objectType.Version - 1, i.e. the version the caller read before
+// incrementing; otherwise repository.ErrOptimisticLock is returned.
+// changeDescription is recorded on the new version.
+func (r *PostgresObjectTypeRepository) Update(ctx context.Context, objectType *entity.ObjectType, changeDescription string) error {
+	ctx, span := tracer.Start(ctx, "PostgresObjectTypeRepository.Update", trace.WithAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("object_type.id", objectType.ID.String()),))
+	defer span.End()
+
 	// Serialize properties and metadata to JSON
 	propertiesJSON, err := json.Marshal(objectType.Properties)
 	if err != nil {
@@ -131,6 +266,14 @@ func (r *PostgresObjectTypeRepository) Update(ctx context.Context, objectType *e
 		return fmt.Errorf("failed to marshal base datasets: %w", err)
 	}
 
+	objectType.TenantID = repository.TenantFromContext(ctx)
+
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// Update object type
 	query := `
 		UPDATE object_types SET
@@ -143,10 +286,11 @@ func (r *PostgresObjectTypeRepository) Update(ctx context.Context, objectType *e
 			metadata = $8,
 			version = $9,
 			updated_at = $10,
-			updated_by = $11
-		WHERE id = $1 AND is_deleted = FALSE`
+			updated_by = $11,
+			parent_id = $12
+		WHERE id = $1 AND version = $13 AND tenant_id = $14 AND is_deleted = FALSE`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		objectType.ID,
 		objectType.DisplayName,
 		objectType.Description,
@@ -158,9 +302,13 @@ func (r *PostgresObjectTypeRepository) Update(ctx context.Context, objectType *e
 		objectType.Version,
 		objectType.UpdatedAt,
 		objectType.UpdatedBy,
+		objectType.ParentID,
+		objectType.Version-1,
+		objectType.TenantID,
 	)
 
 	if err != nil {
+		recordSpanError(span, err)
 		return fmt.Errorf("failed to update object type: %w", err)
 	}
 
@@ -169,88 +317,758 @@ func (r *PostgresObjectTypeRepository) Update(ctx context.Context, objectType *e
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return entity.ErrObjectTypeNotFound
+	if rowsAffected == 0 {
+		// Distinguish a missing row from one another writer has moved on
+		var exists bool
+		if err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM object_types WHERE id = $1 AND tenant_id = $2 AND is_deleted = FALSE)`,
+			objectType.ID, objectType.TenantID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check object type existence: %w", err)
+		}
+		if !exists {
+			return entity.ErrObjectTypeNotFound
+		}
+		return repository.ErrOptimisticLock
+	}
+
+	// Create version record
+	if err := r.createVersionTx(ctx, tx, objectType, changeDescription); err != nil {
+		return fmt.Errorf("failed to create version record: %w", err)
+	}
+
+	if err := writeAuditEntry(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete soft deletes an object type
+func (r *PostgresObjectTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE object_types 
+		SET is_deleted = TRUE, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND is_deleted = FALSE`
+
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, id, repository.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete object type: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrObjectTypeNotFound
+	}
+
+	if err := writeAuditEntry(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCascade soft deletes an object type and every link type referencing it
+// in a single transaction, returning the IDs of the deleted link types
+func (r *PostgresObjectTypeRepository) DeleteCascade(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, linkTypeIDs, err := r.deleteCascadeTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeAuditEntry(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return linkTypeIDs, nil
+}
+
+// BatchDelete soft deletes object types and the link types referencing them
+// in a single transaction. It returns the IDs of the deleted link types per
+// object type, in the order of ids.
+func (r *PostgresObjectTypeRepository) BatchDelete(ctx context.Context, ids []uuid.UUID) ([][]uuid.UUID, error) {
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	linkTypeIDs := make([][]uuid.UUID, len(ids))
+	for i, id := range ids {
+		var deleted *entity.ObjectType
+		deleted, linkTypeIDs[i], err = r.deleteCascadeTx(ctx, tx, id)
+		if err != nil {
+			return nil, &repository.BatchItemError{Index: i, Err: err}
+		}
+		if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityObjectType, id, entity.AuditActionDelete, deleted, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return linkTypeIDs, nil
+}
+
+// deleteCascadeTx soft deletes an object type and the live link types
+// referencing it within tx, auditing each link type. It returns the deleted
+// object type and the IDs of the deleted link types.
+func (r *PostgresObjectTypeRepository) deleteCascadeTx(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*entity.ObjectType, []uuid.UUID, error) {
+	tenantID := repository.TenantFromContext(ctx)
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE link_types
+		SET is_deleted = TRUE, updated_at = NOW()
+		WHERE (source_object_type_id = $1 OR target_object_type_id = $1) AND tenant_id = $2 AND is_deleted = FALSE
+		RETURNING `+linkTypeColumns, id, tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to delete dependent link types: %w", err)
+	}
+
+	var linkTypes []*entity.LinkType
+	for rows.Next() {
+		linkType, err := scanLinkTypeFrom(rows)
+		if err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		linkTypes = append(linkTypes, linkType)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to delete dependent link types: %w", err)
+	}
+
+	linkTypeIDs := make([]uuid.UUID, len(linkTypes))
+	for i, linkType := range linkTypes {
+		linkTypeIDs[i] = linkType.ID
+		if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityLinkType, linkType.ID, entity.AuditActionDelete, linkType, nil); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	deleted, err := r.scanObjectType(tx.QueryRowContext(ctx, `
+		UPDATE object_types
+		SET is_deleted = TRUE, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND is_deleted = FALSE
+		RETURNING `+objectTypeColumns, id, tenantID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return deleted, linkTypeIDs, nil
+}
+
+// Purge permanently removes a soft-deleted object type together with its
+// version history and any soft-deleted link types still referencing it
+func (r *PostgresObjectTypeRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var isDeleted bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT is_deleted FROM object_types WHERE id = $1 AND tenant_id = $2 FOR UPDATE`,
+		id, repository.TenantFromContext(ctx)).Scan(&isDeleted)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.ErrObjectTypeNotFound
+		}
+		return fmt.Errorf("failed to load object type: %w", err)
+	}
+
+	if !isDeleted {
+		return entity.ErrObjectTypeNotDeleted
+	}
+
+	// Soft-deleted link types would otherwise block the delete through their foreign keys
+	rows, err := tx.QueryContext(ctx, `
+		DELETE FROM link_types
+		WHERE (source_object_type_id = $1 OR target_object_type_id = $1) AND is_deleted = TRUE
+		RETURNING `+linkTypeColumns, id)
+	if err != nil {
+		return fmt.Errorf("failed to purge dependent link types: %w", err)
+	}
+	var linkTypes []*entity.LinkType
+	for rows.Next() {
+		linkType, err := scanLinkTypeFrom(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		linkType.IsDeleted = true
+		linkTypes = append(linkTypes, linkType)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to purge dependent link types: %w", err)
+	}
+	for _, linkType := range linkTypes {
+		if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityLinkType, linkType.ID, entity.AuditActionPurge, linkType, nil); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM object_type_versions WHERE object_type_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to purge object type versions: %w", err)
+	}
+
+	purged, err := r.scanObjectType(tx.QueryRowContext(ctx, `
+		DELETE FROM object_types WHERE id = $1
+		RETURNING `+objectTypeColumns, id))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("%w: %s", entity.ErrObjectTypeInUse, pqErr.Detail)
+		}
+		return fmt.Errorf("failed to purge object type: %w", err)
+	}
+	purged.IsDeleted = true
+
+	if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityObjectType, id, entity.AuditActionPurge, purged, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListPurgeable retrieves object types soft-deleted before deletedBefore that
+// can be purged without breaking a reference, oldest deletions first. The
+// primary is read since the result drives deletes.
+func (r *PostgresObjectTypeRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.ObjectType, error) {
+	query := `
+		SELECT id, name, display_name, description, category, tags,
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id
+		FROM object_types o
+		WHERE is_deleted = TRUE AND updated_at < $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM object_types c WHERE c.parent_id = o.id)
+		  AND NOT EXISTS (
+			SELECT 1 FROM link_types lt
+			WHERE (lt.source_object_type_id = o.id OR lt.target_object_type_id = o.id)
+			  AND (lt.is_deleted = FALSE OR lt.constraints @> '{"preventDelete": true}'))
+		ORDER BY updated_at, id
+		LIMIT $2`
+
+	rows, err := r.db.Primary().QueryContext(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purgeable object types: %w", err)
+	}
+	defer rows.Close()
+
+	var objectTypes []*entity.ObjectType
+	for rows.Next() {
+		ot, err := r.scanObjectTypeFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		ot.IsDeleted = true
+		objectTypes = append(objectTypes, ot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return objectTypes, nil
+}
+
+// ListTenants retrieves the tenants that have live object types, sorted
+func (r *PostgresObjectTypeRepository) ListTenants(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, `
+		SELECT DISTINCT tenant_id FROM object_types WHERE is_deleted = FALSE ORDER BY tenant_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []string
+	for rows.Next() {
+		var tenant string
+		if err := rows.Scan(&tenant); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenants = append(tenants, tenant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
+
+// Restore reverses a soft delete. It fails with ErrObjectTypeNameExists if a
+// live object type has taken the name since, and with ErrParentDeleted if the
+// parent has been deleted in the meantime.
+func (r *PostgresObjectTypeRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var (
+		name      string
+		parentID  uuid.NullUUID
+		isDeleted bool
+	)
+	tenantID := repository.TenantFromContext(ctx)
+	err = tx.QueryRowContext(ctx, `
+		SELECT name, parent_id, is_deleted FROM object_types WHERE id = $1 AND tenant_id = $2 FOR UPDATE`,
+		id, tenantID).Scan(&name, &parentID, &isDeleted)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.ErrObjectTypeNotFound
+		}
+		return fmt.Errorf("failed to load object type: %w", err)
+	}
+
+	if !isDeleted {
+		return entity.ErrObjectTypeNotDeleted
+	}
+
+	if parentID.Valid {
+		var parentDeleted bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT is_deleted FROM object_types WHERE id = $1 AND tenant_id = $2`, parentID.UUID, tenantID).Scan(&parentDeleted)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to load parent object type: %w", err)
+		}
+		if err == sql.ErrNoRows || parentDeleted {
+			return entity.ErrParentDeleted
+		}
+	}
+
+	var nameTaken bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM object_types WHERE name = $1 AND tenant_id = $2 AND is_deleted = FALSE)`, name, tenantID).Scan(&nameTaken)
+	if err != nil {
+		return fmt.Errorf("failed to check object type name: %w", err)
+	}
+	if nameTaken {
+		return entity.ErrObjectTypeNameExists
+	}
+
+	restored, err := r.scanObjectType(tx.QueryRowContext(ctx, `
+		UPDATE object_types SET is_deleted = FALSE, updated_at = NOW() WHERE id = $1
+		RETURNING `+objectTypeColumns, id))
+	if err != nil {
+		// A concurrent create can still claim the name before commit
+		if isNameConflict(err) {
+			return entity.ErrObjectTypeNameExists
+		}
+		return fmt.Errorf("failed to restore object type: %w", err)
+	}
+
+	if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityObjectType, id, entity.AuditActionRestore, nil, restored); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeleted retrieves soft-deleted object types eligible for purging, most recently deleted first
+func (r *PostgresObjectTypeRepository) ListDeleted(ctx context.Context, limit int) ([]*entity.ObjectType, error) {
+	query := `
+		SELECT id, name, display_name, description, category, tags,
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id
+		FROM object_types
+		WHERE is_deleted = TRUE AND tenant_id = $2
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $1`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, limit, repository.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted object types: %w", err)
+	}
+	defer rows.Close()
+
+	var objectTypes []*entity.ObjectType
+	for rows.Next() {
+		ot, err := r.scanObjectTypeFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		ot.IsDeleted = true
+		objectTypes = append(objectTypes, ot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return objectTypes, nil
+}
+
+// List retrieves a list of object types based on filter
+func (r *PostgresObjectTypeRepository) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
+	ctx, span := tracer.Start(ctx, "PostgresObjectTypeRepository.List", trace.WithAttributes(
+		attribute.String("db.system", "postgresql"),))
+	defer span.End()
+
+	query := `
+		SELECT id, name, display_name, description, category, tags,
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id
+		FROM object_types
+		WHERE tenant_id = $1 AND ` + deletedCondition(filter)
+
+	args := []interface{}{repository.TenantFromContext(ctx)}
+	argCount := 1
+
+	sortColumn, sortDesc, err := r.resolveSort(filter.SortBy, filter.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle cursor-based pagination. PageCursor pages forward, Before pages
+	// backward by querying in the opposite order and reversing the results.
+	if filter.PageCursor != "" && filter.Before != "" {
+		return nil, fmt.Errorf("%w: cursor and before cannot be combined", repository.ErrInvalidInput)
+	}
+
+	backward := filter.Before != ""
+	descending := sortDesc != backward
+
+	if filter.PageCursor != "" || backward {
+		encoded := filter.PageCursor
+		if backward {
+			encoded = filter.Before
+		}
+
+		cursor, err := r.cursors.Decode(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor: %v", repository.ErrInvalidInput, err)
+		}
+		if cursor.SortBy != sortColumn {
+			return nil, fmt.Errorf("%w: cursor was issued for sort field %q", repository.ErrInvalidInput, cursor.SortBy)
+		}
+
+		value, err := r.cursorValue(sortColumn, cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor: %v", repository.ErrInvalidInput, err)
+		}
+
+		comparison := ">"
+		if descending {
+			comparison = "<"
+		}
+
+		argCount++
+		query += fmt.Sprintf(" AND (%s, id) %s ($%d, $%d)", sortColumn, comparison, argCount, argCount+1)
+		args = append(args, value, cursor.ID)
+		argCount++
+	}
+
+	// Apply filters
+	if filter.Category != nil {
+		argCount++
+		query += fmt.Sprintf(" AND category = $%d", argCount)
+		args = append(args, *filter.Category)
+	}
+
+	if len(filter.Tags) > 0 {
+		argCount++
+		query += fmt.Sprintf(" AND tags && $%d", argCount)
+		args = append(args, pq.Array(filter.Tags))
+	}
+
+	if len(filter.MetadataFilters) > 0 {
+		metadataJSON, err := json.Marshal(filter.MetadataFilters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata filters: %w", err)
+		}
+		argCount++
+		query += fmt.Sprintf(" AND metadata @> $%d", argCount)
+		args = append(args, metadataJSON)
+	}
+
+	// Order and limit
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", sortColumn, direction, direction)
+	argCount++
+	query += fmt.Sprintf(" LIMIT $%d", argCount)
+	args = append(args, r.listPageSize(filter.PageSize))
+
+	var objectTypes []*entity.ObjectType
+	err = withStatementTimeout(ctx, r.db.Reader(ctx), r.statementTimeout, func(q queryer) error {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to list object types: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			ot, err := r.scanObjectTypeFromRows(rows)
+			if err != nil {
+				return err
+			}
+			objectTypes = append(objectTypes, ot)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	// Restore newest-first ordering for backward pages
+	if backward {
+		for i, j := 0, len(objectTypes)-1; i < j; i, j = i+1, j-1 {
+			objectTypes[i], objectTypes[j] = objectTypes[j], objectTypes[i]
+		}
+	}
+
+	return objectTypes, nil
+}
+
+// deletedCondition selects live object types unless the filter asks for
+// deleted ones
+func deletedCondition(filter repository.ObjectTypeFilter) string {
+	switch {
+	case filter.IncludeDeleted:
+		return "TRUE"
+	case filter.IsDeleted != nil && *filter.IsDeleted:
+		return "is_deleted = TRUE"
+	default:
+		return "is_deleted = FALSE"
+	}
+}
+
+// Count counts object types based on filter
+func (r *PostgresObjectTypeRepository) Count(ctx context.Context, filter repository.ObjectTypeFilter) (int64, error) {
+	query := `SELECT COUNT(*) FROM object_types WHERE tenant_id = $1 AND ` + deletedCondition(filter)
+
+	args := []interface{}{repository.TenantFromContext(ctx)}
+	argCount := 1
+
+	// Apply filters
+	if filter.Category != nil {
+		argCount++
+		query += fmt.Sprintf(" AND category = $%d", argCount)
+		args = append(args, *filter.Category)
+	}
+
+	if len(filter.Tags) > 0 {
+		argCount++
+		query += fmt.Sprintf(" AND tags && $%d", argCount)
+		args = append(args, pq.Array(filter.Tags))
+	}
+
+	if len(filter.MetadataFilters) > 0 {
+		metadataJSON, err := json.Marshal(filter.MetadataFilters)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal metadata filters: %w", err)
+		}
+		argCount++
+		query += fmt.Sprintf(" AND metadata @> $%d", argCount)
+		args = append(args, metadataJSON)
 	}
 
-	// Create version record
-	if err := r.createVersion(ctx, objectType); err != nil {
-		return fmt.Errorf("failed to create version record: %w", err)
+	var count int64
+	err := withStatementTimeout(ctx, r.db.Reader(ctx), r.statementTimeout, func(q queryer) error {
+		if err := q.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+			return fmt.Errorf("failed to count object types: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
+	return count, nil
 }
 
-// Delete soft deletes an object type
-func (r *PostgresObjectTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `
-		UPDATE object_types 
-		SET is_deleted = TRUE, updated_at = NOW()
-		WHERE id = $1 AND is_deleted = FALSE`
+// Search implements full-text search using PostgreSQL's tsvector. Matches are
+// ordered by rank with ties broken by ID, which keeps cursor pages stable.
+func (r *PostgresObjectTypeRepository) Search(ctx context.Context, query string, limit int, opts repository.SearchOptions) (*repository.SearchPage, error) {
+	ctx, span := tracer.Start(ctx, "PostgresObjectTypeRepository.Search", trace.WithAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("search.query", query),))
+	defer span.End()
 
-	result, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete object type: %w", err)
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: search limit must be positive", repository.ErrInvalidInput)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	tsquery, query, err := buildTSQuery(opts.Mode, query)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, err
 	}
-
-	if rowsAffected == 0 {
-		return entity.ErrObjectTypeNotFound
+	if query == "" {
+		// Nothing searchable survived sanitization
+		return &repository.SearchPage{Results: []*entity.ObjectType{}}, nil
 	}
 
-	return nil
-}
+	rank := `ts_rank(to_tsvector('english', name || ' ' || display_name || ' ' || COALESCE(description, '')), ` + tsquery + `)`
 
-// List retrieves a list of object types based on filter
-func (r *PostgresObjectTypeRepository) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
-	query := `
+	sql := `
 		SELECT id, name, display_name, description, category, tags,
-			   properties, base_datasets, metadata, version,
-			   created_at, created_by, updated_at, updated_by
-		FROM object_types
-		WHERE is_deleted = FALSE`
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id, ` + rank + `
+		FROM object_types 
+		WHERE to_tsvector('english', name || ' ' || display_name || ' ' || COALESCE(description, '')) 
+		@@ ` + tsquery + `
+		AND tenant_id = $2 AND is_deleted = FALSE`
 
-	var args []interface{}
-	argCount := 0
+	args := []interface{}{query, repository.TenantFromContext(ctx)}
+	argCount := 2
 
-	// Handle cursor-based pagination
-	if filter.PageCursor != "" {
-		cursor, err := r.decodeCursor(filter.PageCursor)
+	if opts.Cursor != "" {
+		cursor, err := r.cursors.Decode(opts.Cursor)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %w", err)
+			return nil, fmt.Errorf("%w: invalid cursor: %v", repository.ErrInvalidInput, err)
 		}
-		argCount++
-		query += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", argCount, argCount+1)
-		args = append(args, cursor.Timestamp, cursor.ID)
-		argCount++
+		if cursor.SortBy != repository.SearchCursorSortBy {
+			return nil, fmt.Errorf("%w: cursor was not issued by search", repository.ErrInvalidInput)
+		}
+		cursorRank, err := strconv.ParseFloat(cursor.Value, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor: %v", repository.ErrInvalidInput, err)
+		}
+
+		// Matches rank descending with ties broken by ID ascending
+		sql += fmt.Sprintf(" AND (%s < $%d::real OR (%s = $%d::real AND id > $%d))",
+			rank, argCount+1, rank, argCount+1, argCount+2)
+		args = append(args, cursorRank, cursor.ID)
+		argCount += 2
 	}
 
-	// Apply filters
-	if filter.Category != nil {
+	if opts.Category != nil {
 		argCount++
-		query += fmt.Sprintf(" AND category = $%d", argCount)
-		args = append(args, *filter.Category)
+		sql += fmt.Sprintf(" AND category = $%d", argCount)
+		args = append(args, *opts.Category)
 	}
 
-	if len(filter.Tags) > 0 {
+	if len(opts.Tags) > 0 {
 		argCount++
-		query += fmt.Sprintf(" AND tags && $%d", argCount)
-		args = append(args, pq.Array(filter.Tags))
+		sql += fmt.Sprintf(" AND tags && $%d", argCount)
+		args = append(args, pq.Array(opts.Tags))
 	}
 
-	// Order and limit
-	query += " ORDER BY created_at DESC, id DESC"
-	if filter.PageSize > 0 {
+	if opts.CreatedAfter != nil {
 		argCount++
-		query += fmt.Sprintf(" LIMIT $%d", argCount)
-		args = append(args, filter.PageSize)
+		sql += fmt.Sprintf(" AND created_at > $%d", argCount)
+		args = append(args, *opts.CreatedAfter)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	// Fetch one extra match to tell whether another page follows
+	argCount++
+	sql += fmt.Sprintf(`
+		ORDER BY %s DESC, id
+		LIMIT $%d`, rank, argCount)
+	args = append(args, limit+1)
+
+	var results []*entity.ObjectType
+	var ranks []float32
+	err = withStatementTimeout(ctx, r.db.Reader(ctx), r.statementTimeout, func(q queryer) error {
+		rows, err := q.QueryContext(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("failed to search object types: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var matchRank float32
+			ot, err := r.scanObjectTypeFromRows(rows, &matchRank)
+			if err != nil {
+				return err
+			}
+			results = append(results, ot)
+			ranks = append(ranks, matchRank)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	page := &repository.SearchPage{Results: results}
+	if len(results) > limit {
+		last := results[limit-1]
+		page.Results = results[:limit]
+		page.NextCursor = r.cursors.Encode(repository.PageCursor{
+			SortBy: repository.SearchCursorSortBy,
+			Value:  strconv.FormatFloat(float64(ranks[limit-1]), 'g', -1, 32),
+			ID:     last.ID,
+		})
+	}
+
+	return page, nil
+}
+
+// likeEscaper escapes LIKE wildcards so a prefix matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListChildren retrieves the live object types inheriting directly from id
+func (r *PostgresObjectTypeRepository) ListChildren(ctx context.Context, id uuid.UUID) ([]*entity.ObjectType, error) {
+	return r.listLive(ctx, `parent_id = $1`, id)
+}
+
+// ListReferencing retrieves the live object types with a REFERENCE property
+// pointing at id
+func (r *PostgresObjectTypeRepository) ListReferencing(ctx context.Context, id uuid.UUID) ([]*entity.ObjectType, error) {
+	return r.listLive(ctx, `properties @> jsonb_build_array(jsonb_build_object('referencedObjectTypeId', $1::text))`, id.String())
+}
+
+// listLive retrieves the live object types of the tenant on ctx matching
+// condition, by name
+func (r *PostgresObjectTypeRepository) listLive(ctx context.Context, condition string, args ...interface{}) ([]*entity.ObjectType, error) {
+	args = append(args, repository.TenantFromContext(ctx))
+	query := `
+		SELECT id, name, display_name, description, category, tags,
+			   properties, base_datasets, metadata, parent_id, version,
+			   created_at, created_by, updated_at, updated_by, tenant_id
+		FROM object_types
+		WHERE ` + condition + fmt.Sprintf(` AND tenant_id = $%d`, len(args)) + ` AND is_deleted = FALSE
+		ORDER BY name`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list object types: %w", err)
 	}
@@ -265,82 +1083,156 @@ func (r *PostgresObjectTypeRepository) List(ctx context.Context, filter reposito
 		objectTypes = append(objectTypes, ot)
 	}
 
-	return objectTypes, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return objectTypes, nil
 }
 
-// Count counts object types based on filter
-func (r *PostgresObjectTypeRepository) Count(ctx context.Context, filter repository.ObjectTypeFilter) (int64, error) {
-	query := `SELECT COUNT(*) FROM object_types WHERE is_deleted = FALSE`
+// SuggestNames returns object types whose name starts with prefix, ignoring
+// case, shortest names first
+func (r *PostgresObjectTypeRepository) SuggestNames(ctx context.Context, prefix string, limit int) ([]repository.NameSuggestion, error) {
+	// lower(name) LIKE 'x%' is served by idx_object_types_name_prefix
+	query := `
+		SELECT id, name, display_name
+		FROM object_types
+		WHERE lower(name) LIKE $1 AND tenant_id = $3 AND is_deleted = FALSE
+		ORDER BY length(name), name
+		LIMIT $2`
 
-	var args []interface{}
-	argCount := 0
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, likeEscaper.Replace(strings.ToLower(prefix))+"%", limit, repository.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest object type names: %w", err)
+	}
+	defer rows.Close()
 
-	// Apply filters
-	if filter.Category != nil {
-		argCount++
-		query += fmt.Sprintf(" AND category = $%d", argCount)
-		args = append(args, *filter.Category)
+	suggestions := []repository.NameSuggestion{}
+	for rows.Next() {
+		var suggestion repository.NameSuggestion
+		if err := rows.Scan(&suggestion.ID, &suggestion.Name, &suggestion.DisplayName); err != nil {
+			return nil, fmt.Errorf("failed to scan name suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
 	}
 
-	if len(filter.Tags) > 0 {
-		argCount++
-		query += fmt.Sprintf(" AND tags && $%d", argCount)
-		args = append(args, pq.Array(filter.Tags))
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	var count int64
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	return suggestions, nil
+}
+
+// ListCategories counts live object types per category, most used first.
+// Object types without a category are not counted.
+func (r *PostgresObjectTypeRepository) ListCategories(ctx context.Context) ([]repository.CategoryCount, error) {
+	query := `
+		SELECT category, COUNT(*)
+		FROM object_types
+		WHERE tenant_id = $1 AND is_deleted = FALSE AND category IS NOT NULL AND category <> ''
+		GROUP BY category
+		ORDER BY COUNT(*) DESC, category`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, repository.TenantFromContext(ctx))
 	if err != nil {
-		return 0, fmt.Errorf("failed to count object types: %w", err)
+		return nil, fmt.Errorf("failed to list categories: %w", err)
 	}
+	defer rows.Close()
 
-	return count, nil
+	categories := []repository.CategoryCount{}
+	for rows.Next() {
+		var category repository.CategoryCount
+		if err := rows.Scan(&category.Category, &category.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
 }
 
-// Search implements full-text search using PostgreSQL's tsvector
-func (r *PostgresObjectTypeRepository) Search(ctx context.Context, query string, limit int) ([]*entity.ObjectType, error) {
-	sql := `
-		SELECT id, name, display_name, description, category, tags,
-			   properties, base_datasets, metadata, version,
-			   created_at, created_by, updated_at, updated_by
-		FROM object_types 
-		WHERE to_tsvector('english', name || ' ' || display_name || ' ' || COALESCE(description, '')) 
-		@@ plainto_tsquery('english', $1)
-		AND is_deleted = FALSE
-		ORDER BY ts_rank(to_tsvector('english', name || ' ' || display_name || ' ' || COALESCE(description, '')), 
-						plainto_tsquery('english', $1)) DESC
-		LIMIT $2`
+// ListTags counts live object types per tag, most used first
+func (r *PostgresObjectTypeRepository) ListTags(ctx context.Context) ([]repository.TagCount, error) {
+	query := `
+		SELECT tag, COUNT(*)
+		FROM object_types, unnest(tags) AS tag
+		WHERE tenant_id = $1 AND is_deleted = FALSE
+		GROUP BY tag
+		ORDER BY COUNT(*) DESC, tag`
 
-	rows, err := r.db.QueryContext(ctx, sql, query, limit)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, repository.TenantFromContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to search object types: %w", err)
+		return nil, fmt.Errorf("failed to list tags: %w", err)
 	}
 	defer rows.Close()
 
-	var results []*entity.ObjectType
+	tags := []repository.TagCount{}
 	for rows.Next() {
-		ot, err := r.scanObjectTypeFromRows(rows)
-		if err != nil {
-			return nil, err
+		var tag repository.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
 		}
-		results = append(results, ot)
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return results, rows.Err()
+	return tags, nil
 }
 
 // GetVersion retrieves a specific version of an object type
 func (r *PostgresObjectTypeRepository) GetVersion(ctx context.Context, id uuid.UUID, version int) (*entity.ObjectType, error) {
+	return r.getVersion(ctx, r.db.Reader(ctx), id, version)
+}
+
+// getVersion retrieves a specific version of an object type through q
+func (r *PostgresObjectTypeRepository) getVersion(ctx context.Context, q queryer, id uuid.UUID, version int) (*entity.ObjectType, error) {
 	query := `
 		SELECT snapshot
 		FROM object_type_versions
-		WHERE object_type_id = $1 AND version = $2`
+		WHERE object_type_id = $1 AND version = $2 AND ` + ownedByTenant(3)
 
 	var snapshotJSON []byte
-	err := r.db.QueryRowContext(ctx, query, id, version).Scan(&snapshotJSON)
+	err := q.QueryRowContext(ctx, query, id, version, repository.TenantFromContext(ctx)).Scan(&snapshotJSON)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, entity.ErrObjectTypeNotFound
+			return nil, entity.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+
+	var objectType entity.ObjectType
+	if err := json.Unmarshal(snapshotJSON, &objectType); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+	}
+
+	return &objectType, nil
+}
+
+// GetVersionAt retrieves the latest version recorded at or before at, falling
+// back to the earliest version when every version is newer
+func (r *PostgresObjectTypeRepository) GetVersionAt(ctx context.Context, id uuid.UUID, at time.Time) (*entity.ObjectType, error) {
+	// Versions at or before at sort first, newest first; later ones follow,
+	// oldest first
+	query := `
+		SELECT snapshot
+		FROM object_type_versions
+		WHERE object_type_id = $1 AND ` + ownedByTenant(3) + `
+		ORDER BY created_at <= $2 DESC,
+			CASE WHEN created_at <= $2 THEN -version ELSE version END
+		LIMIT 1`
+
+	var snapshotJSON []byte
+	err := r.db.Reader(ctx).QueryRowContext(ctx, query, id, at, repository.TenantFromContext(ctx)).Scan(&snapshotJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.ErrVersionNotFound
 		}
 		return nil, fmt.Errorf("failed to get version: %w", err)
 	}
@@ -353,15 +1245,21 @@ func (r *PostgresObjectTypeRepository) GetVersion(ctx context.Context, id uuid.U
 	return &objectType, nil
 }
 
-// ListVersions lists all versions of an object type
-func (r *PostgresObjectTypeRepository) ListVersions(ctx context.Context, id uuid.UUID) ([]*repository.ObjectTypeVersion, error) {
+// ListVersions lists a page of versions of an object type, newest first
+func (r *PostgresObjectTypeRepository) ListVersions(ctx context.Context, id uuid.UUID, filter repository.VersionFilter) ([]*repository.ObjectTypeVersion, error) {
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = repository.DefaultVersionPageSize
+	}
+
 	query := `
 		SELECT id, object_type_id, version, snapshot, change_description, created_at, created_by
 		FROM object_type_versions
-		WHERE object_type_id = $1
-		ORDER BY version DESC`
+		WHERE object_type_id = $1 AND ($2 = 0 OR version < $2) AND ` + ownedByTenant(4) + `
+		ORDER BY version DESC
+		LIMIT $3`
 
-	rows, err := r.db.QueryContext(ctx, query, id)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, id, filter.BeforeVersion, pageSize, repository.TenantFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list versions: %w", err)
 	}
@@ -371,19 +1269,21 @@ func (r *PostgresObjectTypeRepository) ListVersions(ctx context.Context, id uuid
 	for rows.Next() {
 		var v repository.ObjectTypeVersion
 		var snapshotJSON []byte
+		var changeDescription sql.NullString
 
 		err := rows.Scan(
 			&v.ID,
 			&v.ObjectTypeID,
 			&v.Version,
 			&snapshotJSON,
-			&v.ChangeDescription,
+			&changeDescription,
 			&v.CreatedAt,
 			&v.CreatedBy,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan version: %w", err)
 		}
+		v.ChangeDescription = changeDescription.String
 
 		if err := json.Unmarshal(snapshotJSON, &v.Snapshot); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
@@ -413,75 +1313,123 @@ func (r *PostgresObjectTypeRepository) CompareVersions(ctx context.Context, id u
 		ObjectTypeID: id,
 		Version1:     v1,
 		Version2:     v2,
-		Changes:      []repository.FieldChange{},
+		Changes:      repository.CompareObjectTypes(version1, version2),
 	}
 
-	// Compare basic fields
-	if version1.Name != version2.Name {
-		diff.Changes = append(diff.Changes, repository.FieldChange{
-			Field:    "name",
-			OldValue: version1.Name,
-			NewValue: version2.Name,
-			Type:     repository.ChangeTypeModified,
-		})
+	return diff, nil
+}
+
+// CompareVersionRange compares each consecutive pair of versions from
+// fromVersion to toVersion, loading all their snapshots in one query
+func (r *PostgresObjectTypeRepository) CompareVersionRange(ctx context.Context, id uuid.UUID, fromVersion, toVersion int) ([]*repository.VersionDiff, error) {
+	if fromVersion < 1 || toVersion <= fromVersion {
+		return nil, fmt.Errorf("%w: version range must satisfy 1 <= from < to", repository.ErrInvalidInput)
+	}
+	if toVersion-fromVersion > repository.MaxVersionRange {
+		return nil, fmt.Errorf("%w: version range spans more than %d versions", repository.ErrInvalidInput, repository.MaxVersionRange)
 	}
 
-	if version1.DisplayName != version2.DisplayName {
-		diff.Changes = append(diff.Changes, repository.FieldChange{
-			Field:    "displayName",
-			OldValue: version1.DisplayName,
-			NewValue: version2.DisplayName,
-			Type:     repository.ChangeTypeModified,
-		})
+	query := `
+		SELECT version, snapshot
+		FROM object_type_versions
+		WHERE object_type_id = $1 AND version BETWEEN $2 AND $3 AND ` + ownedByTenant(4) + `
+		ORDER BY version`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, id, fromVersion, toVersion, repository.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := make(map[int]*entity.ObjectType)
+	for rows.Next() {
+		var version int
+		var snapshotJSON []byte
+		if err := rows.Scan(&version, &snapshotJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan version: %w", err)
+		}
+
+		var objectType entity.ObjectType
+		if err := json.Unmarshal(snapshotJSON, &objectType); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+		}
+		snapshots[version] = &objectType
 	}
 
-	// Compare properties
-	propChanges := r.compareProperties(version1.Properties, version2.Properties)
-	diff.Changes = append(diff.Changes, propChanges...)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate versions: %w", err)
+	}
 
-	return diff, nil
+	diffs := make([]*repository.VersionDiff, 0, toVersion-fromVersion)
+	for version := fromVersion; version < toVersion; version++ {
+		older, newer := snapshots[version], snapshots[version+1]
+		if older == nil {
+			return nil, fmt.Errorf("failed to get version %d: %w", version, entity.ErrVersionNotFound)
+		}
+		if newer == nil {
+			return nil, fmt.Errorf("failed to get version %d: %w", version+1, entity.ErrVersionNotFound)
+		}
+
+		diffs = append(diffs, &repository.VersionDiff{
+			ObjectTypeID: id,
+			Version1:     version,
+			Version2:     version + 1,
+			Changes:      repository.CompareObjectTypes(older, newer),
+		})
+	}
+
+	return diffs, nil
 }
 
 // BatchCreate creates multiple object types
 func (r *PostgresObjectTypeRepository) BatchCreate(ctx context.Context, objectTypes []*entity.ObjectType) error {
 	// Use transaction for batch operation
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	tenantID := repository.TenantFromContext(ctx)
 	stmt, err := tx.PrepareContext(ctx, `
 		INSERT INTO object_types (
 			id, name, display_name, description, category, tags,
 			properties, base_datasets, metadata, version, is_deleted,
-			created_at, created_by, updated_at, updated_by
+			created_at, created_by, updated_at, updated_by, parent_id, tenant_id
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
 		)`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	for _, ot := range objectTypes {
+	for i, ot := range objectTypes {
 		propertiesJSON, _ := json.Marshal(ot.Properties)
 		metadataJSON, _ := json.Marshal(ot.Metadata)
 		baseDatasetsJSON, _ := json.Marshal(ot.BaseDatasets)
+		ot.TenantID = tenantID
 
 		_, err := stmt.ExecContext(ctx,
 			ot.ID, ot.Name, ot.DisplayName, ot.Description, ot.Category,
 			pq.Array(ot.Tags), propertiesJSON, baseDatasetsJSON, metadataJSON,
 			ot.Version, ot.IsDeleted, ot.CreatedAt, ot.CreatedBy,
-			ot.UpdatedAt, ot.UpdatedBy,
+			ot.UpdatedAt, ot.UpdatedBy, ot.ParentID, ot.TenantID,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to insert object type %s: %w", ot.Name, err)
+			if isNameConflict(err) {
+				return &repository.BatchItemError{Index: i, Err: entity.ErrObjectTypeNameExists}
+			}
+			return &repository.BatchItemError{Index: i, Err: fmt.Errorf("failed to insert object type %s: %w", ot.Name, err)}
 		}
 
 		// Create version record
-		if err := r.createVersionTx(ctx, tx, ot); err != nil {
-			return fmt.Errorf("failed to create version for %s: %w", ot.Name, err)
+		if err := r.createVersionTx(ctx, tx, ot, ""); err != nil {
+			return &repository.BatchItemError{Index: i, Err: fmt.Errorf("failed to create version for %s: %w", ot.Name, err)}
+		}
+
+		if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityObjectType, ot.ID, entity.AuditActionCreate, nil, ot); err != nil {
+			return err
 		}
 	}
 
@@ -489,14 +1437,15 @@ func (r *PostgresObjectTypeRepository) BatchCreate(ctx context.Context, objectTy
 }
 
 // BatchUpdate updates multiple object types
-func (r *PostgresObjectTypeRepository) BatchUpdate(ctx context.Context, objectTypes []*entity.ObjectType) error {
+func (r *PostgresObjectTypeRepository) BatchUpdate(ctx context.Context, objectTypes []*entity.ObjectType, changeDescription string) error {
 	// Use transaction for batch operation
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	tenantID := repository.TenantFromContext(ctx)
 	stmt, err := tx.PrepareContext(ctx, `
 		UPDATE object_types SET
 			display_name = $2,
@@ -508,38 +1457,166 @@ func (r *PostgresObjectTypeRepository) BatchUpdate(ctx context.Context, objectTy
 			metadata = $8,
 			version = $9,
 			updated_at = $10,
-			updated_by = $11
-		WHERE id = $1 AND is_deleted = FALSE`)
+			updated_by = $11,
+			parent_id = $12
+		WHERE id = $1 AND version = $13 AND tenant_id = $14 AND is_deleted = FALSE`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	for _, ot := range objectTypes {
+	for i, ot := range objectTypes {
 		propertiesJSON, _ := json.Marshal(ot.Properties)
 		metadataJSON, _ := json.Marshal(ot.Metadata)
 		baseDatasetsJSON, _ := json.Marshal(ot.BaseDatasets)
+		ot.TenantID = tenantID
+
+		// Lock the stored row, which is also the audited old value
+		before, err := r.scanObjectType(tx.QueryRowContext(ctx, `
+			SELECT `+objectTypeColumns+`
+			FROM object_types
+			WHERE id = $1 AND tenant_id = $2 AND is_deleted = FALSE
+			FOR UPDATE`, ot.ID, ot.TenantID))
+		if err != nil {
+			if errors.Is(err, entity.ErrObjectTypeNotFound) {
+				return &repository.BatchItemError{Index: i, Err: err}
+			}
+			return fmt.Errorf("failed to load object type: %w", err)
+		}
+		if before.Version != ot.Version-1 {
+			return &repository.BatchItemError{Index: i, Err: repository.ErrOptimisticLock}
+		}
 
-		_, err := stmt.ExecContext(ctx,
+		if _, err := stmt.ExecContext(ctx,
 			ot.ID, ot.DisplayName, ot.Description, ot.Category,
 			pq.Array(ot.Tags), propertiesJSON, baseDatasetsJSON, metadataJSON,
-			ot.Version, ot.UpdatedAt, ot.UpdatedBy,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to update object type %s: %w", ot.Name, err)
+			ot.Version, ot.UpdatedAt, ot.UpdatedBy, ot.ParentID, ot.Version-1,
+			ot.TenantID,
+		); err != nil {
+			return &repository.BatchItemError{Index: i, Err: fmt.Errorf("failed to update object type %s: %w", ot.Name, err)}
 		}
 
 		// Create version record
-		if err := r.createVersionTx(ctx, tx, ot); err != nil {
-			return fmt.Errorf("failed to create version for %s: %w", ot.Name, err)
+		if err := r.createVersionTx(ctx, tx, ot, changeDescription); err != nil {
+			return &repository.BatchItemError{Index: i, Err: fmt.Errorf("failed to create version for %s: %w", ot.Name, err)}
+		}
+
+		if err := writeAuditEntryFor(ctx, tx, entity.AuditEntityObjectType, ot.ID, entity.AuditActionUpdate, before, ot); err != nil {
+			return err
 		}
 	}
 
 	return tx.Commit()
 }
 
+// ListIndexes retrieves the storage indexes recorded for an object type
+func (r *PostgresObjectTypeRepository) ListIndexes(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.ObjectTypeIndex, error) {
+	query := `
+		SELECT object_type_id, property_name, index_name, is_unique, created_at, created_by
+		FROM object_type_indexes
+		WHERE object_type_id = $1 AND ` + ownedByTenant(2) + `
+		ORDER BY property_name`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, objectTypeID, repository.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list object type indexes: %w", err)
+	}
+	defer rows.Close()
+
+	var indexes []*entity.ObjectTypeIndex
+	for rows.Next() {
+		var idx entity.ObjectTypeIndex
+		if err := rows.Scan(
+			&idx.ObjectTypeID,
+			&idx.PropertyName,
+			&idx.Name,
+			&idx.Unique,
+			&idx.CreatedAt,
+			&idx.CreatedBy,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan object type index: %w", err)
+		}
+		indexes = append(indexes, &idx)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+// SyncIndexes removes the indexes of the given properties and records the added
+// ones in a single transaction. Already recorded indexes are left untouched.
+func (r *PostgresObjectTypeRepository) SyncIndexes(ctx context.Context, objectTypeID uuid.UUID, added []entity.ObjectTypeIndex, removed []string) error {
+	tx, err := r.db.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM object_types WHERE id = $1 AND tenant_id = $2)`,
+		objectTypeID, repository.TenantFromContext(ctx)).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check object type existence: %w", err)
+	}
+	if !exists {
+		return entity.ErrObjectTypeNotFound
+	}
+
+	if len(removed) > 0 {
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM object_type_indexes
+			WHERE object_type_id = $1 AND property_name = ANY($2)`,
+			objectTypeID, pq.Array(removed)); err != nil {
+			return fmt.Errorf("failed to remove object type indexes: %w", err)
+		}
+	}
+
+	for _, idx := range added {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO object_type_indexes (
+				object_type_id, property_name, index_name, is_unique, created_at, created_by
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (object_type_id, property_name) DO NOTHING`,
+			objectTypeID, idx.PropertyName, idx.Name, idx.Unique, idx.CreatedAt, idx.CreatedBy); err != nil {
+			return fmt.Errorf("failed to record index for %s: %w", idx.PropertyName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // Helper methods
 
+// searchWordPattern matches the words kept from a prefix query
+var searchWordPattern = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+
+// buildTSQuery returns the tsquery expression for a search mode, bound to $1,
+// and the value to bind. Prefix queries are rebuilt from bare words so user
+// input can never inject tsquery operators.
+func buildTSQuery(mode, query string) (string, string, error) {
+	switch mode {
+	case "", repository.SearchModePlain:
+		return "plainto_tsquery('english', $1)", query, nil
+	case repository.SearchModePhrase:
+		return "phraseto_tsquery('english', $1)", query, nil
+	case repository.SearchModePrefix:
+		words := searchWordPattern.FindAllString(query, -1)
+		for i, word := range words {
+			words[i] = word + ":*"
+		}
+		return "to_tsquery('english', $1)", strings.Join(words, " & "), nil
+	default:
+		return "", "", fmt.Errorf("%w: unknown search mode %q", repository.ErrInvalidInput, mode)
+	}
+}
+
 func (r *PostgresObjectTypeRepository) scanObjectType(row *sql.Row) (*entity.ObjectType, error) {
 	var ot entity.ObjectType
 	var propertiesJSON, baseDatasetsJSON, metadataJSON []byte
@@ -554,11 +1631,13 @@ func (r *PostgresObjectTypeRepository) scanObjectType(row *sql.Row) (*entity.Obj
 		&propertiesJSON,
 		&baseDatasetsJSON,
 		&metadataJSON,
+		&ot.ParentID,
 		&ot.Version,
 		&ot.CreatedAt,
 		&ot.CreatedBy,
 		&ot.UpdatedAt,
 		&ot.UpdatedBy,
+		&ot.TenantID,
 	)
 
 	if err != nil {
@@ -584,11 +1663,11 @@ func (r *PostgresObjectTypeRepository) scanObjectType(row *sql.Row) (*entity.Obj
 	return &ot, nil
 }
 
-func (r *PostgresObjectTypeRepository) scanObjectTypeFromRows(rows *sql.Rows) (*entity.ObjectType, error) {
+func (r *PostgresObjectTypeRepository) scanObjectTypeFromRows(rows *sql.Rows, extra ...interface{}) (*entity.ObjectType, error) {
 	var ot entity.ObjectType
 	var propertiesJSON, baseDatasetsJSON, metadataJSON []byte
 
-	err := rows.Scan(
+	dest := []interface{}{
 		&ot.ID,
 		&ot.Name,
 		&ot.DisplayName,
@@ -598,12 +1677,16 @@ func (r *PostgresObjectTypeRepository) scanObjectTypeFromRows(rows *sql.Rows) (*
 		&propertiesJSON,
 		&baseDatasetsJSON,
 		&metadataJSON,
+		&ot.ParentID,
 		&ot.Version,
 		&ot.CreatedAt,
 		&ot.CreatedBy,
 		&ot.UpdatedAt,
 		&ot.UpdatedBy,
-	)
+		&ot.TenantID,
+	}
+	// Trailing columns selected after the object type, such as a search rank
+	err := rows.Scan(append(dest, extra...)...)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan object type: %w", err)
@@ -625,11 +1708,7 @@ func (r *PostgresObjectTypeRepository) scanObjectTypeFromRows(rows *sql.Rows) (*
 	return &ot, nil
 }
 
-func (r *PostgresObjectTypeRepository) createVersion(ctx context.Context, objectType *entity.ObjectType) error {
-	return r.createVersionTx(ctx, r.db, objectType)
-}
-
-func (r *PostgresObjectTypeRepository) createVersionTx(ctx context.Context, tx interface{ ExecContext(context.Context, string, ...interface{}) (sql.Result, error) }, objectType *entity.ObjectType) error {
+func (r *PostgresObjectTypeRepository) createVersionTx(ctx context.Context, tx interface{ ExecContext(context.Context, string, ...interface{}) (sql.Result, error) }, objectType *entity.ObjectType, changeDescription string) error {
 	snapshotJSON, err := json.Marshal(objectType)
 	if err != nil {
 		return fmt.Errorf("failed to marshal snapshot: %w", err)
@@ -637,13 +1716,14 @@ func (r *PostgresObjectTypeRepository) createVersionTx(ctx context.Context, tx i
 
 	query := `
 		INSERT INTO object_type_versions (
-			object_type_id, version, snapshot, created_at, created_by
-		) VALUES ($1, $2, $3, $4, $5)`
+			object_type_id, version, snapshot, change_description, created_at, created_by
+		) VALUES ($1, $2, $3, $4, $5, $6)`
 
 	_, err = tx.ExecContext(ctx, query,
 		objectType.ID,
 		objectType.Version,
 		snapshotJSON,
+		changeDescription,
 		objectType.UpdatedAt,
 		objectType.UpdatedBy,
 	)
@@ -651,86 +1731,78 @@ func (r *PostgresObjectTypeRepository) createVersionTx(ctx context.Context, tx i
 	return err
 }
 
-func (r *PostgresObjectTypeRepository) compareProperties(props1, props2 []entity.Property) []repository.FieldChange {
-	var changes []repository.FieldChange
+// ownedByTenant returns a condition restricting object_type_id to the object
+// types of the tenant bound to parameter n
+func ownedByTenant(n int) string {
+	return fmt.Sprintf("object_type_id IN (SELECT id FROM object_types WHERE tenant_id = $%d)", n)
+}
 
-	// Create maps for easier comparison
-	props1Map := make(map[string]entity.Property)
-	props2Map := make(map[string]entity.Property)
+// objectTypeNameIndex is the unique index on the names of live object types
+// within a tenant. Soft-deleted object types are outside it, so their names
+// can be reused.
+const objectTypeNameIndex = "idx_object_types_tenant_name"
 
-	for _, p := range props1 {
-		props1Map[p.Name] = p
-	}
-	for _, p := range props2 {
-		props2Map[p.Name] = p
-	}
+// isNameConflict reports whether err is a violation of objectTypeNameIndex,
+// as opposed to any other unique constraint on object types
+func isNameConflict(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == objectTypeNameIndex // unique_violation
+}
 
-	// Check for removed and modified properties
-	for name, p1 := range props1Map {
-		if p2, exists := props2Map[name]; exists {
-			// Check if property was modified
-			if p1.DataType != p2.DataType || p1.Required != p2.Required {
-				changes = append(changes, repository.FieldChange{
-					Field:    fmt.Sprintf("properties.%s", name),
-					OldValue: p1,
-					NewValue: p2,
-					Type:     repository.ChangeTypeModified,
-				})
-			}
-		} else {
-			// Property was removed
-			changes = append(changes, repository.FieldChange{
-				Field:    fmt.Sprintf("properties.%s", name),
-				OldValue: p1,
-				NewValue: nil,
-				Type:     repository.ChangeTypeRemoved,
-			})
-		}
+// listPageSize applies the default page size and caps it at
+// repository.MaxListPageSize, so no caller can read the whole table at once
+func (r *PostgresObjectTypeRepository) listPageSize(requested int) int {
+	if requested <= 0 {
+		return repository.DefaultListPageSize
 	}
-
-	// Check for added properties
-	for name, p2 := range props2Map {
-		if _, exists := props1Map[name]; !exists {
-			changes = append(changes, repository.FieldChange{
-				Field:    fmt.Sprintf("properties.%s", name),
-				OldValue: nil,
-				NewValue: p2,
-				Type:     repository.ChangeTypeAdded,
-			})
-		}
+	if requested > repository.MaxListPageSize {
+		r.logger.Warn("List page size exceeds maximum, capping",
+			zap.Int("requested", requested),
+			zap.Int("max", repository.MaxListPageSize))
+		return repository.MaxListPageSize
 	}
-
-	return changes
+	return requested
 }
 
-func (r *PostgresObjectTypeRepository) encodeCursor(timestamp time.Time, id uuid.UUID) string {
-	data := fmt.Sprintf("%d:%s", timestamp.Unix(), id.String())
-	return base64.StdEncoding.EncodeToString([]byte(data))
-}
-
-func (r *PostgresObjectTypeRepository) decodeCursor(cursor string) (*repository.PageCursor, error) {
-	data, err := base64.StdEncoding.DecodeString(cursor)
-	if err != nil {
-		return nil, err
+// resolveSort maps the requested sort onto a whitelisted column and direction
+func (r *PostgresObjectTypeRepository) resolveSort(sortBy, sortOrder string) (string, bool, error) {
+	if sortBy == "" {
+		sortBy = repository.SortByCreatedAt
 	}
 
-	parts := strings.Split(string(data), ":")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid cursor format")
+	valid := false
+	for _, field := range repository.ObjectTypeSortFields {
+		if sortBy == field {
+			valid = true
+			break
+		}
 	}
-
-	timestamp, err := strconv.ParseInt(parts[0], 10, 64)
-	if err != nil {
-		return nil, err
+	if !valid {
+		return "", false, fmt.Errorf("%w: unsupported sort field %q", repository.ErrInvalidInput, sortBy)
 	}
 
-	id, err := uuid.Parse(parts[1])
-	if err != nil {
-		return nil, err
+	switch strings.ToLower(sortOrder) {
+	case "", "desc":
+		return sortBy, true, nil
+	case "asc":
+		return sortBy, false, nil
+	default:
+		return "", false, fmt.Errorf("%w: unsupported sort order %q", repository.ErrInvalidInput, sortOrder)
 	}
+}
 
-	return &repository.PageCursor{
-		Timestamp: time.Unix(timestamp, 0),
-		ID:        id,
-	}, nil
-}
\ No newline at end of file
+// cursorValue converts a cursor's encoded sort key into a query argument for the column
+func (r *PostgresObjectTypeRepository) cursorValue(sortColumn, value string) (interface{}, error) {
+	switch sortColumn {
+	case repository.SortByCreatedAt, repository.SortByUpdatedAt:
+		nanos, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(0, nanos), nil
+	case repository.SortByVersion:
+		return strconv.Atoi(value)
+	default:
+		return value, nil
+	}
+}
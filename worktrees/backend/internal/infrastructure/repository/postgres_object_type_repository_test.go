@@ -0,0 +1,904 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// testCursors signs the cursors of the repositories under test
+var testCursors = repository.NewCursorCodec([]byte("test-key"), time.Hour, false)
+
+func newTestObjectTypeRepository(t *testing.T) (*fakeDB, *PostgresObjectTypeRepository) {
+	t.Helper()
+	fake, db := newFakeSplitter(t)
+	return fake, NewPostgresObjectTypeRepository(db, testCursors, 0, zap.NewNop()).(*PostgresObjectTypeRepository)
+}
+
+// listQuery runs List with filter and returns the query it issued
+func listQuery(t *testing.T, filter repository.ObjectTypeFilter) string {
+	t.Helper()
+	fake, repo := newTestObjectTypeRepository(t)
+	if _, err := repo.List(context.Background(), filter); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	return fake.queries("FROM object_types")[0].query
+}
+
+func TestListOrdersBySortFieldInEachDirection(t *testing.T) {
+	// Arrange
+	var missing []string
+
+	// Act
+	for _, field := range repository.ObjectTypeSortFields {
+		for order, direction := range map[string]string{"asc": "ASC", "desc": "DESC"} {
+			query := listQuery(t, repository.ObjectTypeFilter{SortBy: field, SortOrder: order})
+			if want := "ORDER BY " + field + " " + direction + ", id " + direction; !strings.Contains(query, want) {
+				missing = append(missing, want)
+			}
+		}
+	}
+
+	// Assert
+	if len(missing) > 0 {
+		t.Errorf("List queries lack %v", missing)
+	}
+}
+
+func TestListDefaultsToNewestFirst(t *testing.T) {
+	// Act
+	query := listQuery(t, repository.ObjectTypeFilter{})
+
+	// Assert
+	if !strings.Contains(query, "ORDER BY created_at DESC, id DESC") {
+		t.Errorf("query = %q, want created_at DESC ordering", query)
+	}
+}
+
+func TestListRejectsUnknownSortField(t *testing.T) {
+	// Arrange
+	_, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_, err := repo.List(context.Background(), repository.ObjectTypeFilter{SortBy: "password"})
+
+	// Assert
+	if !errors.Is(err, repository.ErrInvalidInput) {
+		t.Errorf("err = %v, want %v", err, repository.ErrInvalidInput)
+	}
+}
+
+func TestListRejectsUnknownSortOrder(t *testing.T) {
+	// Arrange
+	_, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_, err := repo.List(context.Background(), repository.ObjectTypeFilter{SortBy: repository.SortByName, SortOrder: "sideways"})
+
+	// Assert
+	if !errors.Is(err, repository.ErrInvalidInput) {
+		t.Errorf("err = %v, want %v", err, repository.ErrInvalidInput)
+	}
+}
+
+func TestListCursorFollowsAscendingSort(t *testing.T) {
+	// Arrange
+	cursor := testCursors.Encode(repository.NewObjectTypeCursor(&entity.ObjectType{ID: uuid.New(), Name: "m"}, repository.SortByName))
+
+	// Act
+	query := listQuery(t, repository.ObjectTypeFilter{SortBy: repository.SortByName, SortOrder: "asc", PageCursor: cursor})
+
+	// Assert
+	if !strings.Contains(query, "(name, id) > ($2, $3)") {
+		t.Errorf("query = %q, want items after the cursor in name order", query)
+	}
+}
+
+func TestListCursorFollowsDescendingSort(t *testing.T) {
+	// Arrange
+	cursor := testCursors.Encode(repository.NewObjectTypeCursor(&entity.ObjectType{ID: uuid.New(), Version: 3}, repository.SortByVersion))
+
+	// Act
+	query := listQuery(t, repository.ObjectTypeFilter{SortBy: repository.SortByVersion, SortOrder: "desc", PageCursor: cursor})
+
+	// Assert
+	if !strings.Contains(query, "(version, id) < ($2, $3)") {
+		t.Errorf("query = %q, want items after the cursor in descending version order", query)
+	}
+}
+
+func TestListRejectsCursorForAnotherSortField(t *testing.T) {
+	// Arrange
+	_, repo := newTestObjectTypeRepository(t)
+	cursor := testCursors.Encode(repository.NewObjectTypeCursor(&entity.ObjectType{ID: uuid.New(), Name: "m"}, repository.SortByName))
+
+	// Act
+	_, err := repo.List(context.Background(), repository.ObjectTypeFilter{SortBy: repository.SortByVersion, PageCursor: cursor})
+
+	// Assert
+	if !errors.Is(err, repository.ErrInvalidInput) {
+		t.Errorf("err = %v, want %v", err, repository.ErrInvalidInput)
+	}
+}
+
+func TestListRejectsCursorSignedWithAnotherKey(t *testing.T) {
+	// Arrange
+	_, repo := newTestObjectTypeRepository(t)
+	foreign := repository.NewCursorCodec([]byte("other-key"), time.Hour, false)
+	cursor := foreign.Encode(repository.NewObjectTypeCursor(&entity.ObjectType{ID: uuid.New(), Name: "m"}, repository.SortByName))
+
+	// Act
+	_, err := repo.List(context.Background(), repository.ObjectTypeFilter{SortBy: repository.SortByName, PageCursor: cursor})
+
+	// Assert
+	if !errors.Is(err, repository.ErrInvalidInput) || !strings.Contains(err.Error(), "signature") {
+		t.Errorf("err = %v, want an invalid signature", err)
+	}
+}
+
+func TestListBackwardPageKeepsDisplayOrder(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	a := &entity.ObjectType{ID: uuid.New(), Name: "a", CreatedAt: time.Now()}
+	b := &entity.ObjectType{ID: uuid.New(), Name: "b", CreatedAt: time.Now()}
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		// A backward query reads away from the cursor, so b comes back first
+		return objectTypeColumnNames, [][]driver.Value{objectTypeRow(b), objectTypeRow(a)}
+	}
+	cursor := testCursors.Encode(repository.NewObjectTypeCursor(&entity.ObjectType{ID: uuid.New(), Name: "c"}, repository.SortByName))
+
+	// Act
+	objectTypes, _ := repo.List(context.Background(), repository.ObjectTypeFilter{SortBy: repository.SortByName, SortOrder: "asc", Before: cursor})
+
+	// Assert
+	if len(objectTypes) != 2 || objectTypes[0].Name != "a" || objectTypes[1].Name != "b" {
+		t.Errorf("backward page = %v, want [a b]", objectTypes)
+	}
+}
+
+// updateWithNoRowsMatched runs Update while the UPDATE matches no row and
+// the object type exists or not
+func updateWithNoRowsMatched(t *testing.T, exists bool) error {
+	t.Helper()
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.affected = func(query string, args []interface{}) int64 {
+		if strings.Contains(query, "UPDATE object_types") {
+			return 0
+		}
+		return 1
+	}
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"exists"}, [][]driver.Value{{exists}}
+	}
+	return repo.Update(context.Background(), &entity.ObjectType{ID: uuid.New(), Version: 3}, "")
+}
+
+func TestUpdateOnlyAppliesToTheVersionRead(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_ = repo.Update(context.Background(), &entity.ObjectType{ID: uuid.New(), Version: 3}, "")
+
+	// Assert
+	update := fake.queries("UPDATE object_types")[0]
+	if !strings.Contains(update.query, "version = $13") || update.args[12] != 2 {
+		t.Errorf("UPDATE guards version with %v, want 2", update.args[12])
+	}
+}
+
+func TestUpdateOfMovedOnVersionIsOptimisticLockFailure(t *testing.T) {
+	// Act
+	err := updateWithNoRowsMatched(t, true)
+
+	// Assert
+	if !errors.Is(err, repository.ErrOptimisticLock) {
+		t.Errorf("err = %v, want %v", err, repository.ErrOptimisticLock)
+	}
+}
+
+func TestUpdateOfMissingObjectTypeIsNotFound(t *testing.T) {
+	// Act
+	err := updateWithNoRowsMatched(t, false)
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNotFound) {
+		t.Errorf("err = %v, want %v", err, entity.ErrObjectTypeNotFound)
+	}
+}
+
+// syncIndexes runs SyncIndexes for an existing object type and returns the
+// fake database it wrote to
+func syncIndexes(t *testing.T, added []entity.ObjectTypeIndex, removed []string) *fakeDB {
+	t.Helper()
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"exists"}, [][]driver.Value{{true}}
+	}
+	if err := repo.SyncIndexes(context.Background(), uuid.New(), added, removed); err != nil {
+		t.Fatalf("SyncIndexes: %v", err)
+	}
+	return fake
+}
+
+func TestSyncIndexesInsertsOnlyAddedIndexes(t *testing.T) {
+	// Act
+	fake := syncIndexes(t, []entity.ObjectTypeIndex{{PropertyName: "email", Name: "idx_email"}}, nil)
+
+	// Assert
+	inserts := fake.queries("INSERT INTO object_type_indexes")
+	if len(inserts) != 1 || inserts[0].args[1] != "email" {
+		t.Errorf("inserts = %v, want one for email", inserts)
+	}
+}
+
+func TestSyncIndexesSkipsDeleteWhenNothingRemoved(t *testing.T) {
+	// Act
+	fake := syncIndexes(t, []entity.ObjectTypeIndex{{PropertyName: "email", Name: "idx_email"}}, nil)
+
+	// Assert
+	if deletes := fake.queries("DELETE FROM object_type_indexes"); len(deletes) != 0 {
+		t.Errorf("deletes = %v, want none", deletes)
+	}
+}
+
+func TestSyncIndexesIgnoresDuplicateIndex(t *testing.T) {
+	// Act
+	fake := syncIndexes(t, []entity.ObjectTypeIndex{{PropertyName: "email", Name: "idx_email"}}, nil)
+
+	// Assert
+	if insert := fake.queries("INSERT INTO object_type_indexes")[0]; !strings.Contains(insert.query, "ON CONFLICT (object_type_id, property_name) DO NOTHING") {
+		t.Errorf("insert = %q, want duplicates ignored", insert.query)
+	}
+}
+
+// searchQuery runs Search for text with opts and returns the statement it issued
+func searchQuery(t *testing.T, text string, opts repository.SearchOptions) fakeStatement {
+	t.Helper()
+	fake, repo := newTestObjectTypeRepository(t)
+	if _, err := repo.Search(context.Background(), text, 10, opts); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	return fake.queries("FROM object_types")[0]
+}
+
+func TestSearchCombinesTextQueryWithTagFilter(t *testing.T) {
+	// Act
+	search := searchQuery(t, "customer", repository.SearchOptions{Tags: []string{"crm", "sales"}})
+
+	// Assert
+	if !strings.Contains(search.query, "@@ plainto_tsquery('english', $1)") || !strings.Contains(search.query, "AND tags && $3") {
+		t.Errorf("query = %q, want the text match and the tag filter", search.query)
+	}
+}
+
+func TestSearchBindsTextAndTags(t *testing.T) {
+	// Act
+	search := searchQuery(t, "customer", repository.SearchOptions{Tags: []string{"crm", "sales"}})
+
+	// Assert
+	tags, ok := search.args[2].(*pq.StringArray)
+	if search.args[0] != "customer" || !ok || !slices.Equal(*tags, []string{"crm", "sales"}) {
+		t.Errorf("args = %v, want customer and the tags [crm sales]", search.args)
+	}
+}
+
+func TestSearchWithTagFilterKeepsRankOrdering(t *testing.T) {
+	// Act
+	search := searchQuery(t, "customer", repository.SearchOptions{Tags: []string{"crm"}})
+
+	// Assert
+	if !strings.Contains(search.query, "ORDER BY ts_rank(") {
+		t.Errorf("query = %q, want results ordered by ts_rank", search.query)
+	}
+}
+
+func TestSearchCombinesCategoryAndTagFilters(t *testing.T) {
+	// Arrange
+	category := "sales"
+
+	// Act
+	search := searchQuery(t, "customer", repository.SearchOptions{Category: &category, Tags: []string{"crm"}})
+
+	// Assert
+	if !strings.Contains(search.query, "AND category = $3") || !strings.Contains(search.query, "AND tags && $4") {
+		t.Errorf("query = %q, want category and tag predicates", search.query)
+	}
+}
+
+func TestSearchWithoutFiltersAddsNoPredicates(t *testing.T) {
+	// Act
+	search := searchQuery(t, "customer", repository.SearchOptions{})
+
+	// Assert
+	if strings.Contains(search.query, "tags &&") || strings.Contains(search.query, "category =") {
+		t.Errorf("query = %q, want no filter predicates", search.query)
+	}
+}
+
+func TestSearchDefaultsToPlainMode(t *testing.T) {
+	// Act
+	search := searchQuery(t, "customer account", repository.SearchOptions{})
+
+	// Assert
+	if !strings.Contains(search.query, "plainto_tsquery('english', $1)") {
+		t.Errorf("query = %q, want plainto_tsquery", search.query)
+	}
+}
+
+func TestSearchPhraseModeMatchesWordsInOrder(t *testing.T) {
+	// Act
+	search := searchQuery(t, "customer account", repository.SearchOptions{Mode: repository.SearchModePhrase})
+
+	// Assert
+	// phraseto_tsquery joins the words with <->, which only matches them
+	// adjacent and in the order typed
+	if !strings.Contains(search.query, "phraseto_tsquery('english', $1)") || search.args[0] != "customer account" {
+		t.Errorf("query = %q with %v, want phraseto_tsquery of the words as typed", search.query, search.args[0])
+	}
+}
+
+func TestSearchPhraseModeKeepsReversedWordsDistinct(t *testing.T) {
+	// Act
+	forward := searchQuery(t, "customer account", repository.SearchOptions{Mode: repository.SearchModePhrase})
+	reversed := searchQuery(t, "account customer", repository.SearchOptions{Mode: repository.SearchModePhrase})
+
+	// Assert
+	if forward.args[0] == reversed.args[0] {
+		t.Errorf("phrase %v and its reverse bind the same query", forward.args[0])
+	}
+}
+
+func TestSearchPrefixModeMatchesEveryWordAsPrefix(t *testing.T) {
+	// Act
+	search := searchQuery(t, "cust acc", repository.SearchOptions{Mode: repository.SearchModePrefix})
+
+	// Assert
+	if !strings.Contains(search.query, "to_tsquery('english', $1)") || search.args[0] != "cust:* & acc:*" {
+		t.Errorf("query = %q with %v, want to_tsquery of cust:* & acc:*", search.query, search.args[0])
+	}
+}
+
+func TestSearchPrefixModeDropsTSQueryOperators(t *testing.T) {
+	// Act
+	search := searchQuery(t, "cust & !acc | (x:*", repository.SearchOptions{Mode: repository.SearchModePrefix})
+
+	// Assert
+	if search.args[0] != "cust:* & acc:* & x:*" {
+		t.Errorf("bound query = %v, want cust:* & acc:* & x:*", search.args[0])
+	}
+}
+
+func TestSearchPrefixModeWithoutWordsSkipsQuery(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	page, err := repo.Search(context.Background(), "&|!", 10, repository.SearchOptions{Mode: repository.SearchModePrefix})
+
+	// Assert
+	if err != nil || len(page.Results) != 0 || len(fake.queries("FROM object_types")) != 0 {
+		t.Errorf("Search = %v, %v, want no results without querying", page, err)
+	}
+}
+
+func TestSearchRejectsUnknownMode(t *testing.T) {
+	// Arrange
+	_, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_, err := repo.Search(context.Background(), "customer", 10, repository.SearchOptions{Mode: "regex"})
+
+	// Assert
+	if !errors.Is(err, repository.ErrInvalidInput) {
+		t.Errorf("err = %v, want %v", err, repository.ErrInvalidInput)
+	}
+}
+
+// suggestStatement runs SuggestNames for prefix and returns the statement it issued
+func suggestStatement(t *testing.T, prefix string) fakeStatement {
+	t.Helper()
+	fake, repo := newTestObjectTypeRepository(t)
+	if _, err := repo.SuggestNames(context.Background(), prefix, 20); err != nil {
+		t.Fatalf("SuggestNames: %v", err)
+	}
+	return fake.queries("FROM object_types")[0]
+}
+
+func TestSuggestNamesMatchesLowercasedName(t *testing.T) {
+	// Act
+	suggest := suggestStatement(t, "CuS")
+
+	// Assert
+	if !strings.Contains(suggest.query, "lower(name) LIKE $1") || suggest.args[0] != "cus%" {
+		t.Errorf("query = %q with %v, want lower(name) LIKE cus%%", suggest.query, suggest.args[0])
+	}
+}
+
+func TestSuggestNamesEscapesLikeWildcards(t *testing.T) {
+	// Act
+	suggest := suggestStatement(t, "a_b%")
+
+	// Assert
+	if suggest.args[0] != `a\_b\%%` {
+		t.Errorf("pattern = %v, want wildcards escaped", suggest.args[0])
+	}
+}
+
+// restoreWithNameTaken runs Restore for a deleted object type while a live
+// one owns its name or not
+func restoreWithNameTaken(t *testing.T, nameTaken bool) (*fakeDB, error) {
+	t.Helper()
+	fake, repo := newTestObjectTypeRepository(t)
+	deleted := &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 1}
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		switch {
+		case strings.Contains(query, "SELECT name, parent_id, is_deleted"):
+			return []string{"name", "parent_id", "is_deleted"}, [][]driver.Value{{"Customer", nil, true}}
+		case strings.Contains(query, "SELECT EXISTS"):
+			return []string{"exists"}, [][]driver.Value{{nameTaken}}
+		case strings.Contains(query, "RETURNING"):
+			return objectTypeColumnNames, [][]driver.Value{objectTypeRow(deleted)}
+		}
+		return nil, nil
+	}
+	return fake, repo.Restore(context.Background(), deleted.ID)
+}
+
+func TestRestoreRejectsNameReusedByLiveObjectType(t *testing.T) {
+	// Act
+	_, err := restoreWithNameTaken(t, true)
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNameExists) {
+		t.Errorf("err = %v, want %v", err, entity.ErrObjectTypeNameExists)
+	}
+}
+
+func TestRestoreLeavesRowDeletedWhenNameReused(t *testing.T) {
+	// Act
+	fake, _ := restoreWithNameTaken(t, true)
+
+	// Assert
+	if updates := fake.queries("UPDATE object_types SET is_deleted = FALSE"); len(updates) != 0 {
+		t.Errorf("updates = %v, want none", updates)
+	}
+}
+
+func TestRestoreClearsDeletedFlagWhenNameFree(t *testing.T) {
+	// Act
+	fake, err := restoreWithNameTaken(t, false)
+
+	// Assert
+	if updates := fake.queries("UPDATE object_types SET is_deleted = FALSE"); err != nil || len(updates) != 1 {
+		t.Errorf("Restore = %v with updates %v, want one update", err, updates)
+	}
+}
+
+func TestUpdateRecordsChangeDescriptionOnVersion(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_ = repo.Update(context.Background(), &entity.ObjectType{ID: uuid.New(), Version: 2}, "Renamed to match the CRM")
+
+	// Assert
+	inserts := fake.queries("INSERT INTO object_type_versions")
+	if len(inserts) != 1 || inserts[0].args[3] != "Renamed to match the CRM" {
+		t.Errorf("version inserts = %v, want the change description", inserts)
+	}
+}
+
+func TestListVersionsReturnsChangeDescription(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"id", "object_type_id", "version", "snapshot", "change_description", "created_at", "created_by"},
+			[][]driver.Value{{uuid.NewString(), uuid.NewString(), int64(2), []byte("{}"), "Renamed to match the CRM", time.Now(), "alice"}}
+	}
+
+	// Act
+	versions, _ := repo.ListVersions(context.Background(), uuid.New(), repository.VersionFilter{})
+
+	// Assert
+	if len(versions) != 1 || versions[0].ChangeDescription != "Renamed to match the CRM" {
+		t.Errorf("versions = %v, want the change description", versions)
+	}
+}
+
+func TestListVersionsBindsCursorAndPageSize(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_, _ = repo.ListVersions(context.Background(), uuid.New(), repository.VersionFilter{PageSize: 20, BeforeVersion: 31})
+
+	// Assert
+	queries := fake.queries("FROM object_type_versions")
+	if len(queries) != 1 || !strings.Contains(queries[0].query, "ORDER BY version DESC") ||
+		queries[0].args[1] != 31 || queries[0].args[2] != 20 {
+		t.Errorf("queries = %v, want newest first before version 31, limit 20", queries)
+	}
+}
+
+func TestListVersionsDefaultsPageSize(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_, _ = repo.ListVersions(context.Background(), uuid.New(), repository.VersionFilter{})
+
+	// Assert
+	queries := fake.queries("FROM object_type_versions")
+	if len(queries) != 1 || queries[0].args[2] != repository.DefaultVersionPageSize {
+		t.Errorf("queries = %v, want limit %d", queries, repository.DefaultVersionPageSize)
+	}
+}
+
+func TestListCategoriesScansCountsOfLiveRows(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"category", "count"}, [][]driver.Value{{"sales", int64(2)}, {"billing", int64(1)}}
+	}
+
+	// Act
+	categories, err := repo.ListCategories(context.Background())
+
+	// Assert
+	query := fake.queries("GROUP BY category")[0].query
+	if !strings.Contains(query, "is_deleted = FALSE") {
+		t.Errorf("query = %s, want deleted rows excluded", query)
+	}
+	if err != nil || len(categories) != 2 || categories[0] != (repository.CategoryCount{Category: "sales", Count: 2}) {
+		t.Errorf("ListCategories = %v (err %v), want sales:2 then billing:1", categories, err)
+	}
+}
+
+func TestListTagsCountsUnnestedTagsOfLiveRows(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"tag", "count"}, [][]driver.Value{{"crm", int64(3)}}
+	}
+
+	// Act
+	tags, err := repo.ListTags(context.Background())
+
+	// Assert
+	query := fake.queries("GROUP BY tag")[0].query
+	if !strings.Contains(query, "unnest(tags)") || !strings.Contains(query, "is_deleted = FALSE") {
+		t.Errorf("query = %s, want live rows counted over unnest(tags)", query)
+	}
+	if err != nil || len(tags) != 1 || tags[0] != (repository.TagCount{Tag: "crm", Count: 3}) {
+		t.Errorf("ListTags = %v (err %v), want crm:3", tags, err)
+	}
+}
+
+func TestCountBindsCategoryAndTagFilters(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	category := "sales"
+
+	// Act
+	_, _ = repo.Count(context.Background(), repository.ObjectTypeFilter{Category: &category, Tags: []string{"crm"}})
+
+	// Assert
+	statement := fake.queries("SELECT COUNT(*) FROM object_types")[0]
+	if !strings.Contains(statement.query, "AND category = $2") || !strings.Contains(statement.query, "AND tags && $3") {
+		t.Errorf("query = %s, want category and tag predicates", statement.query)
+	}
+	if len(statement.args) != 3 || statement.args[1] != "sales" {
+		t.Errorf("args = %v, want tenant, sales and the tags", statement.args)
+	}
+}
+
+func TestGetByIDsQueriesAllIDsInOneStatement(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	// Act
+	_, err := repo.GetByIDs(context.Background(), ids)
+
+	// Assert
+	statements := fake.queries("WHERE id = ANY($1::uuid[])")
+	if err != nil || len(statements) != 1 {
+		t.Fatalf("GetByIDs = %v with %d queries, want one ANY query", err, len(statements))
+	}
+	bound, ok := statements[0].args[0].(*pq.StringArray)
+	if !ok || !slices.Equal(*bound, []string{ids[0].String(), ids[1].String()}) {
+		t.Errorf("ids arg = %v, want %v", statements[0].args[0], ids)
+	}
+}
+
+func TestGetByIDsWithoutIDsSkipsTheQuery(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	objectTypes, err := repo.GetByIDs(context.Background(), nil)
+
+	// Assert
+	if err != nil || objectTypes != nil || len(fake.queries("")) != 0 {
+		t.Errorf("GetByIDs = %v (err %v), want nil without a query", objectTypes, err)
+	}
+}
+
+func TestListAlwaysBindsACappedLimit(t *testing.T) {
+	cases := []struct {
+		name      string
+		requested int
+		want      int
+	}{
+		{"unset", 0, repository.DefaultListPageSize},
+		{"negative", -5, repository.DefaultListPageSize},
+		{"within the cap", 50, 50},
+		{"at the cap", repository.MaxListPageSize, repository.MaxListPageSize},
+		{"above the cap", 100000, repository.MaxListPageSize},
+	}
+	for _, tc := range cases {
+		// Arrange
+		fake, repo := newTestObjectTypeRepository(t)
+
+		// Act
+		_, _ = repo.List(context.Background(), repository.ObjectTypeFilter{PageSize: tc.requested})
+
+		// Assert
+		statement := fake.queries("FROM object_types")[0]
+		if !strings.Contains(statement.query, "LIMIT") || statement.args[len(statement.args)-1] != tc.want {
+			t.Errorf("%s: query %s with args %v, want LIMIT %d", tc.name, statement.query, statement.args, tc.want)
+		}
+	}
+}
+
+func TestListWarnsWhenCappingPageSize(t *testing.T) {
+	// Arrange
+	_, db := newFakeSplitter(t)
+	core, logs := observer.New(zap.WarnLevel)
+	repo := NewPostgresObjectTypeRepository(db, testCursors, 0, zap.New(core))
+
+	// Act
+	_, _ = repo.List(context.Background(), repository.ObjectTypeFilter{PageSize: repository.MaxListPageSize + 1})
+	_, _ = repo.List(context.Background(), repository.ObjectTypeFilter{PageSize: repository.MaxListPageSize})
+
+	// Assert
+	if logs.Len() != 1 {
+		t.Errorf("warnings = %d, want one for the capped request", logs.Len())
+	}
+}
+
+func TestIsNameConflictMatchesOnlyTheLiveNameIndex(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"live name index", &pq.Error{Code: "23505", Constraint: objectTypeNameIndex}, true},
+		{"wrapped", fmt.Errorf("insert: %w", &pq.Error{Code: "23505", Constraint: objectTypeNameIndex}), true},
+		{"other unique index", &pq.Error{Code: "23505", Constraint: "object_types_pkey"}, false},
+		{"other error on the index", &pq.Error{Code: "23503", Constraint: objectTypeNameIndex}, false},
+		{"not a database error", errors.New("connection reset"), false},
+	}
+	for _, tc := range cases {
+		// Act
+		got := isNameConflict(tc.err)
+
+		// Assert
+		if got != tc.want {
+			t.Errorf("%s: isNameConflict = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestGetByNameIncludingDeletedReturnsLiveBeforeDeleted(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	live := &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 1}
+	deleted := &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 3}
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		if args[1] == true {
+			return objectTypeColumnNames, [][]driver.Value{objectTypeRow(deleted)}
+		}
+		return objectTypeColumnNames, [][]driver.Value{objectTypeRow(live)}
+	}
+
+	// Act
+	objectTypes, err := repo.GetByNameIncludingDeleted(context.Background(), "Customer")
+
+	// Assert
+	if err != nil || len(objectTypes) != 2 || objectTypes[0].ID != live.ID || objectTypes[1].ID != deleted.ID {
+		t.Errorf("GetByNameIncludingDeleted = %v (err %v), want the live then the deleted Customer", objectTypes, err)
+	}
+}
+
+func TestGetVersionAtPrefersLatestVersionAtOrBeforeTime(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := repo.GetVersionAt(context.Background(), uuid.New(), at)
+
+	// Assert
+	if !errors.Is(err, entity.ErrVersionNotFound) {
+		t.Errorf("GetVersionAt without versions = %v, want ErrVersionNotFound", err)
+	}
+	queries := fake.queries("FROM object_type_versions")
+	if len(queries) != 1 || !strings.Contains(queries[0].query, "ORDER BY created_at <= $2 DESC") || queries[0].args[1] != at {
+		t.Errorf("queries = %v, want versions at or before $2 = %v first", queries, at)
+	}
+}
+
+func TestGetVersionAtReturnsSnapshot(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"snapshot"}, [][]driver.Value{{[]byte(`{"name":"Customer","version":2}`)}}
+	}
+
+	// Act
+	objectType, err := repo.GetVersionAt(context.Background(), uuid.New(), time.Now())
+
+	// Assert
+	if err != nil || objectType.Name != "Customer" || objectType.Version != 2 {
+		t.Errorf("GetVersionAt = %+v, %v, want the Customer snapshot at version 2", objectType, err)
+	}
+}
+
+// metadataArg decodes the metadata containment argument of statement
+func metadataArg(t *testing.T, statement fakeStatement) map[string]string {
+	t.Helper()
+	for _, arg := range statement.args {
+		raw, ok := arg.([]byte)
+		if !ok {
+			continue
+		}
+		var filters map[string]string
+		if err := json.Unmarshal(raw, &filters); err != nil {
+			t.Fatalf("decode metadata argument %s: %v", raw, err)
+		}
+		return filters
+	}
+	t.Fatalf("statement %q binds no metadata argument", statement.query)
+	return nil
+}
+
+func TestListAndCountFilterByMetadataContainment(t *testing.T) {
+	cases := []struct {
+		name    string
+		filters map[string]string
+	}{
+		{"one key", map[string]string{"owner": "sales"}},
+		{"two keys", map[string]string{"owner": "sales", "tier": "gold"}},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		fake, repo := newTestObjectTypeRepository(t)
+		fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+			if strings.Contains(query, "COUNT(") {
+				return []string{"count"}, [][]driver.Value{{int64(0)}}
+			}
+			return nil, nil
+		}
+		filter := repository.ObjectTypeFilter{MetadataFilters: tc.filters}
+
+		// Act
+		_, listErr := repo.List(context.Background(), filter)
+		_, countErr := repo.Count(context.Background(), filter)
+
+		// Assert
+		statements := fake.queries("metadata @> $")
+		if listErr != nil || countErr != nil || len(statements) != 2 {
+			t.Errorf("%s: List %v, Count %v, %d statements, want a containment filter in both", tc.name, listErr, countErr, len(statements))
+			continue
+		}
+		for _, statement := range statements {
+			if got := metadataArg(t, statement); !maps.Equal(got, tc.filters) {
+				t.Errorf("%s: metadata argument = %v, want %v", tc.name, got, tc.filters)
+			}
+		}
+	}
+}
+
+func TestListWithoutMetadataFiltersSkipsContainment(t *testing.T) {
+	// Act
+	query := listQuery(t, repository.ObjectTypeFilter{})
+
+	// Assert
+	if strings.Contains(query, "metadata @>") {
+		t.Errorf("query = %q, want no metadata condition", query)
+	}
+}
+
+func TestListAndCountHonourDeletionFilter(t *testing.T) {
+	deleted, live := true, false
+	cases := []struct {
+		name   string
+		filter repository.ObjectTypeFilter
+		want   string
+	}{
+		{"unset", repository.ObjectTypeFilter{}, "is_deleted = FALSE"},
+		{"live", repository.ObjectTypeFilter{IsDeleted: &live}, "is_deleted = FALSE"},
+		{"deleted", repository.ObjectTypeFilter{IsDeleted: &deleted}, "is_deleted = TRUE"},
+		{"all", repository.ObjectTypeFilter{IsDeleted: &live, IncludeDeleted: true}, "tenant_id = $1 AND TRUE"},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		fake, repo := newTestObjectTypeRepository(t)
+		fake.rows = countRows(0)
+
+		// Act
+		_, _ = repo.List(context.Background(), tc.filter)
+		_, _ = repo.Count(context.Background(), tc.filter)
+
+		// Assert
+		statements := fake.queries("FROM object_types")
+		if len(statements) != 2 {
+			t.Errorf("%s: %d queries, want List and Count", tc.name, len(statements))
+			continue
+		}
+		for _, statement := range statements {
+			query := strings.Join(strings.Fields(statement.query), " ")
+			if !strings.Contains(query, tc.want) {
+				t.Errorf("%s: query %q, want %q", tc.name, query, tc.want)
+			}
+			if tc.want != "is_deleted = FALSE" && strings.Contains(query, "is_deleted = FALSE") {
+				t.Errorf("%s: query %q still excludes deleted rows", tc.name, query)
+			}
+		}
+	}
+}
+
+func TestListChildrenMatchesLiveObjectTypesByParent(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	id := uuid.New()
+
+	// Act
+	_, err := repo.ListChildren(repository.WithTenant(context.Background(), "acme"), id)
+
+	// Assert
+	list := fake.queries("FROM object_types")[0]
+	if err != nil || !strings.Contains(list.query, "parent_id = $1") || !strings.Contains(list.query, "is_deleted = FALSE") {
+		t.Errorf("ListChildren = %v with query %q, want live object types by parent_id", err, list.query)
+	}
+	if !slices.Equal(list.args, []interface{}{id, "acme"}) {
+		t.Errorf("args = %v, want the parent ID and tenant", list.args)
+	}
+}
+
+func TestListReferencingMatchesReferencePropertiesByID(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	id := uuid.New()
+
+	// Act
+	_, err := repo.ListReferencing(repository.WithTenant(context.Background(), "acme"), id)
+
+	// Assert
+	list := fake.queries("FROM object_types")[0]
+	if err != nil || !strings.Contains(list.query, "properties @> jsonb_build_array(jsonb_build_object('referencedObjectTypeId', $1::text))") {
+		t.Errorf("ListReferencing = %v with query %q, want a containment match on referencedObjectTypeId", err, list.query)
+	}
+	if !slices.Equal(list.args, []interface{}{id.String(), "acme"}) {
+		t.Errorf("args = %v, want the ID as text and the tenant", list.args)
+	}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// webhookSubscriptionColumns lists the columns scanned by scanWebhookSubscriptions
+const webhookSubscriptionColumns = `id, url, secret, event_types, active, created_at, created_by, updated_at, updated_by, tenant_id`
+
+// PostgresWebhookSubscriptionRepository implements WebhookSubscriptionRepository using PostgreSQL
+type PostgresWebhookSubscriptionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookSubscriptionRepository creates a new PostgreSQL webhook subscription repository
+func NewPostgresWebhookSubscriptionRepository(db *sql.DB) repository.WebhookSubscriptionRepository {
+	return &PostgresWebhookSubscriptionRepository{db: db}
+}
+
+// Create creates a new webhook subscription in the tenant on ctx
+func (r *PostgresWebhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	subscription.TenantID = repository.TenantFromContext(ctx)
+
+	query := `
+		INSERT INTO webhook_subscriptions (` + webhookSubscriptionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.URL,
+		subscription.Secret,
+		pq.Array(subscription.EventTypes),
+		subscription.Active,
+		subscription.CreatedAt,
+		subscription.CreatedBy,
+		subscription.UpdatedAt,
+		subscription.UpdatedBy,
+		subscription.TenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a webhook subscription by ID
+func (r *PostgresWebhookSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`
+
+	subscriptions, err := r.query(ctx, query, id, repository.TenantFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(subscriptions) == 0 {
+		return nil, entity.ErrWebhookSubscriptionNotFound
+	}
+
+	return subscriptions[0], nil
+}
+
+// List retrieves every webhook subscription, oldest first
+func (r *PostgresWebhookSubscriptionRepository) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE tenant_id = $1 ORDER BY created_at, id`
+	return r.query(ctx, query, repository.TenantFromContext(ctx))
+}
+
+// ListActive retrieves the webhook subscriptions events are delivered to
+func (r *PostgresWebhookSubscriptionRepository) ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE tenant_id = $1 AND active = TRUE ORDER BY created_at, id`
+	return r.query(ctx, query, repository.TenantFromContext(ctx))
+}
+
+// Update updates an existing webhook subscription
+func (r *PostgresWebhookSubscriptionRepository) Update(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscriptions SET
+			url = $2,
+			secret = $3,
+			event_types = $4,
+			active = $5,
+			updated_at = $6,
+			updated_by = $7
+		WHERE id = $1 AND tenant_id = $8`
+
+	result, err := r.db.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.URL,
+		subscription.Secret,
+		pq.Array(subscription.EventTypes),
+		subscription.Active,
+		subscription.UpdatedAt,
+		subscription.UpdatedBy,
+		repository.TenantFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a webhook subscription
+func (r *PostgresWebhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`,
+		id, repository.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// query runs a select over webhookSubscriptionColumns
+func (r *PostgresWebhookSubscriptionRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entity.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*entity.WebhookSubscription
+	for rows.Next() {
+		var subscription entity.WebhookSubscription
+		if err := rows.Scan(
+			&subscription.ID,
+			&subscription.URL,
+			&subscription.Secret,
+			pq.Array(&subscription.EventTypes),
+			&subscription.Active,
+			&subscription.CreatedAt,
+			&subscription.CreatedBy,
+			&subscription.UpdatedAt,
+			&subscription.UpdatedBy,
+			&subscription.TenantID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, &subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"go.uber.org/zap"
+)
+
+// queryObserver records the latency of a repository's operations, and logs
+// and counts the ones slower than slowThreshold
+type queryObserver struct {
+	repository    string
+	metrics       *metrics.Metrics
+	slowThreshold time.Duration
+	logger        *zap.Logger
+}
+
+// observe records an operation started at start; a slowThreshold of 0
+// disables slow query reporting
+func (o queryObserver) observe(operation string, start time.Time) {
+	elapsed := time.Since(start)
+	o.metrics.ObserveQuery(o.repository, operation, start)
+	if o.slowThreshold <= 0 || elapsed < o.slowThreshold {
+		return
+	}
+
+	o.metrics.SlowQueries.WithLabelValues(o.repository, operation).Inc()
+	o.logger.Warn("Slow repository query",
+		zap.String("repository", o.repository),
+		zap.String("operation", operation),
+		zap.Duration("duration", elapsed),
+		zap.Duration("threshold", o.slowThreshold))
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// observeElapsed records one GetByID that took elapsed through an observer
+// with threshold, returning the slow query count and the warnings logged
+func observeElapsed(t *testing.T, threshold, elapsed time.Duration) (float64, int) {
+	t.Helper()
+	core, logs := observer.New(zap.WarnLevel)
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	o := queryObserver{repository: "object_type", metrics: m, slowThreshold: threshold, logger: zap.New(core)}
+
+	o.observe("GetByID", time.Now().Add(-elapsed))
+
+	var metric dto.Metric
+	if err := m.SlowQueries.WithLabelValues("object_type", "GetByID").Write(&metric); err != nil {
+		t.Fatalf("read counter: %v", err)
+	}
+	return metric.GetCounter().GetValue(), logs.Len()
+}
+
+func TestQueryObserverReportsSlowQueries(t *testing.T) {
+	cases := []struct {
+		name      string
+		threshold time.Duration
+		elapsed   time.Duration
+		slow      bool
+	}{
+		{"over the threshold", 100 * time.Millisecond, 250 * time.Millisecond, true},
+		{"under the threshold", 100 * time.Millisecond, 10 * time.Millisecond, false},
+		{"reporting disabled", 0, time.Hour, false},
+	}
+	for _, tc := range cases {
+		// Act
+		count, warnings := observeElapsed(t, tc.threshold, tc.elapsed)
+
+		// Assert
+		want := 0
+		if tc.slow {
+			want = 1
+		}
+		if count != float64(want) || warnings != want {
+			t.Errorf("%s: slow queries = %v with %d warnings, want %d", tc.name, count, warnings, want)
+		}
+	}
+}
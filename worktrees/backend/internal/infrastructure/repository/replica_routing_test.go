@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/database"
+)
+
+// replicatedRepositories are object and link type repositories over a
+// primary and a replica fakeDB
+type replicatedRepositories struct {
+	primary, replica *fakeDB
+	objectTypes      *PostgresObjectTypeRepository
+	linkTypes        *PostgresLinkTypeRepository
+}
+
+// newReplicatedRepositories builds repositories whose splitter reads from a
+// replica, keeping each actor's reads on the primary for window after their
+// writes
+func newReplicatedRepositories(t *testing.T, window time.Duration) replicatedRepositories {
+	t.Helper()
+	primary, primaryDB := newFakeDB(t)
+	replica, replicaDB := newFakeDB(t)
+	splitter := database.NewReadWriteSplitter(primaryDB, replicaDB, window)
+	return replicatedRepositories{
+		primary:     primary,
+		replica:     replica,
+		objectTypes: NewPostgresObjectTypeRepository(splitter, testCursors, 0, zap.NewNop()).(*PostgresObjectTypeRepository),
+		linkTypes:   NewPostgresLinkTypeRepository(splitter, testCursors, 0).(*PostgresLinkTypeRepository),
+	}
+}
+
+// repositoryCall is a named call to one of replicatedRepositories
+type repositoryCall struct {
+	name string
+	call func(ctx context.Context, repos replicatedRepositories) error
+}
+
+// readCalls are the read-only repository methods served by the replica
+func readCalls() []repositoryCall {
+	id := uuid.New()
+	return []repositoryCall{
+		{"object GetByID", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.GetByID(ctx, id)
+			return err
+		}},
+		{"object GetByName", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.GetByName(ctx, "Customer")
+			return err
+		}},
+		{"object List", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.List(ctx, repository.ObjectTypeFilter{PageSize: 10})
+			return err
+		}},
+		{"object Count", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.Count(ctx, repository.ObjectTypeFilter{})
+			return err
+		}},
+		{"object Search", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.Search(ctx, "customer", 10, repository.SearchOptions{})
+			return err
+		}},
+		{"object GetVersion", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.GetVersion(ctx, id, 1)
+			return err
+		}},
+		{"object GetVersionAt", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.GetVersionAt(ctx, id, time.Now())
+			return err
+		}},
+		{"object ListVersions", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.ListVersions(ctx, id, repository.VersionFilter{PageSize: 10})
+			return err
+		}},
+		{"object CompareVersionRange", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.CompareVersionRange(ctx, id, 1, 2)
+			return err
+		}},
+		{"link GetByID", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.GetByID(ctx, id)
+			return err
+		}},
+		{"link GetByName", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.GetByName(ctx, "places")
+			return err
+		}},
+		{"link List", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.List(ctx, repository.LinkTypeFilter{PageSize: 10})
+			return err
+		}},
+		{"link Count", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.Count(ctx, repository.LinkTypeFilter{})
+			return err
+		}},
+		{"link Search", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.Search(ctx, "places", 10)
+			return err
+		}},
+		{"link GetBySourceObjectType", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.GetBySourceObjectType(ctx, id)
+			return err
+		}},
+	}
+}
+
+// primaryCalls are writes, and reads guarding writes, that must see the
+// primary
+func primaryCalls() []repositoryCall {
+	id := uuid.New()
+	return []repositoryCall{
+		{"object Update", func(ctx context.Context, r replicatedRepositories) error {
+			return r.objectTypes.Update(ctx, &entity.ObjectType{ID: id, Version: 2}, "")
+		}},
+		{"object Delete", func(ctx context.Context, r replicatedRepositories) error {
+			return r.objectTypes.Delete(ctx, id)
+		}},
+		{"link Delete", func(ctx context.Context, r replicatedRepositories) error {
+			return r.linkTypes.Delete(ctx, id)
+		}},
+		{"link CheckCircularReference", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.CheckCircularReference(ctx, id, uuid.New())
+			return err
+		}},
+	}
+}
+
+func TestReadMethodsUseTheReplica(t *testing.T) {
+	for _, tc := range readCalls() {
+		// Arrange
+		repos := newReplicatedRepositories(t, 0)
+
+		// Act
+		_ = tc.call(context.Background(), repos)
+
+		// Assert
+		if len(repos.replica.queries("")) == 0 || len(repos.primary.queries("")) != 0 {
+			t.Errorf("%s: %d replica and %d primary statements, want only the replica",
+				tc.name, len(repos.replica.queries("")), len(repos.primary.queries("")))
+		}
+	}
+}
+
+func TestWritesUseThePrimary(t *testing.T) {
+	for _, tc := range primaryCalls() {
+		// Arrange
+		repos := newReplicatedRepositories(t, 0)
+
+		// Act
+		_ = tc.call(context.Background(), repos)
+
+		// Assert
+		if len(repos.primary.queries("")) == 0 || len(repos.replica.queries("")) != 0 {
+			t.Errorf("%s: %d primary and %d replica statements, want only the primary",
+				tc.name, len(repos.primary.queries("")), len(repos.replica.queries("")))
+		}
+	}
+}
+
+func TestReadAfterOwnWriteUsesThePrimary(t *testing.T) {
+	// Arrange
+	repos := newReplicatedRepositories(t, time.Hour)
+	alice := database.WithActor(context.Background(), "alice")
+	_ = repos.objectTypes.Delete(alice, uuid.New())
+	written := len(repos.primary.queries(""))
+
+	// Act
+	_, _ = repos.objectTypes.GetByID(alice, uuid.New())
+	_, _ = repos.objectTypes.GetByID(database.WithActor(context.Background(), "bob"), uuid.New())
+
+	// Assert
+	if read := len(repos.primary.queries("")) - written; read != 1 {
+		t.Errorf("primary served %d reads after alice's write, want alice's one", read)
+	}
+	if len(repos.replica.queries("")) != 1 {
+		t.Errorf("replica served %d reads, want bob's one", len(repos.replica.queries("")))
+	}
+}
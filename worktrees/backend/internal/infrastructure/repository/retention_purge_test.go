@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// linkTypeRow returns the values scanLinkTypeFrom reads for linkType
+func linkTypeRow(linkType *entity.LinkType) []driver.Value {
+	return []driver.Value{
+		linkType.ID.String(), linkType.Name, linkType.DisplayName,
+		linkType.SourceObjectTypeID.String(), linkType.TargetObjectTypeID.String(), string(entity.CardinalityOneToMany),
+		nil, []byte("[]"), []byte("{}"), []byte(`{"cascadeDelete":false,"preventDelete":false}`), int64(linkType.Version),
+		time.Now(), linkType.CreatedBy, time.Now(), linkType.UpdatedBy, nil, false, linkType.TenantID,
+	}
+}
+
+// linkTypeColumnNames are the columns of linkTypeRow
+var linkTypeColumnNames = strings.Fields(strings.NewReplacer(",", " ").Replace(linkTypeColumns))
+
+func TestListPurgeableObjectTypesReadsThePrimaryAcrossTenants(t *testing.T) {
+	// Arrange
+	repos := newReplicatedRepositories(t, 0)
+	deleted := &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 3}
+	repos.primary.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return objectTypeColumnNames, [][]driver.Value{objectTypeRow(deleted)}
+	}
+	cutoff := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	purgeable, err := repos.objectTypes.ListPurgeable(repository.WithTenant(context.Background(), "acme"), cutoff, 50)
+
+	// Assert
+	if err != nil || len(purgeable) != 1 || purgeable[0].ID != deleted.ID || !purgeable[0].IsDeleted {
+		t.Fatalf("ListPurgeable = %v, %v, want the deleted object type", purgeable, err)
+	}
+	queries := repos.primary.queries("FROM object_types o")
+	if len(queries) != 1 || len(repos.replica.queries("")) != 0 {
+		t.Fatalf("primary ran %d purgeable queries and replica %d statements, want the primary only",
+			len(queries), len(repos.replica.queries("")))
+	}
+	if !slices.Equal(queries[0].args, []interface{}{cutoff, 50}) {
+		t.Errorf("args = %v, want the cutoff and limit without a tenant", queries[0].args)
+	}
+}
+
+func TestListPurgeableObjectTypesSkipsReferencedObjectTypes(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_, _ = repo.ListPurgeable(context.Background(), time.Now(), 10)
+
+	// Assert
+	query := fake.queries("FROM object_types o")[0].query
+	conditions := map[string]string{
+		"soft deleted before the cutoff": "is_deleted = TRUE AND updated_at < $1",
+		"children":                       "c.parent_id = o.id",
+		"live link types":                "lt.is_deleted = FALSE",
+		"PreventDelete link types":       `lt.constraints @> '{"preventDelete": true}'`,
+		"oldest first":                   "ORDER BY updated_at, id",
+	}
+	for name, condition := range conditions {
+		if !strings.Contains(query, condition) {
+			t.Errorf("purgeable query does not check %s: %s", name, query)
+		}
+	}
+}
+
+func TestListPurgeableLinkTypesReadsThePrimary(t *testing.T) {
+	// Arrange
+	repos := newReplicatedRepositories(t, 0)
+	cutoff := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := repos.linkTypes.ListPurgeable(repository.WithTenant(context.Background(), "acme"), cutoff, 50)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListPurgeable: %v", err)
+	}
+	queries := repos.primary.queries("FROM link_types")
+	if len(queries) != 1 || len(repos.replica.queries("")) != 0 {
+		t.Fatalf("primary ran %d purgeable queries and replica %d statements, want the primary only",
+			len(queries), len(repos.replica.queries("")))
+	}
+	if !strings.Contains(queries[0].query, "is_deleted = TRUE AND updated_at < $1") || len(queries[0].args) != 2 || queries[0].args[0] != cutoff {
+		t.Errorf("query %q with %v, want soft-deleted link types before the cutoff of any tenant", queries[0].query, queries[0].args)
+	}
+}
+
+func TestPurgeLinkTypeDeletesInTenantAndAudits(t *testing.T) {
+	// Arrange
+	fake, repo := newTestLinkTypeRepository(t)
+	linkType := &entity.LinkType{ID: uuid.New(), Name: "places", TenantID: "acme", Version: 2}
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return linkTypeColumnNames, [][]driver.Value{linkTypeRow(linkType)}
+	}
+	ctx := repository.WithAuditEntry(repository.WithTenant(context.Background(), "acme"), &entity.AuditEntry{Actor: "retention"})
+
+	// Act
+	err := repo.Purge(ctx, linkType.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	deletes := fake.queries("DELETE FROM link_types")
+	if len(deletes) != 1 || !strings.Contains(deletes[0].query, "is_deleted = TRUE") ||
+		!slices.Equal(deletes[0].args, []interface{}{linkType.ID, "acme"}) {
+		t.Fatalf("deletes = %v, want one of the soft-deleted link type in acme", deletes)
+	}
+	audits := fake.queries("INSERT INTO audit_logs")
+	if len(audits) != 1 || audits[0].args[3] != entity.AuditActionPurge || audits[0].args[4] != "retention" {
+		t.Errorf("audit rows = %v, want one purge by retention", audits)
+	}
+	if len(fake.transactions) != 1 {
+		t.Errorf("transactions = %d, want the purge and its audit row in one", len(fake.transactions))
+	}
+}
+
+func TestPurgeLinkTypeNotSoftDeletedIsNotFound(t *testing.T) {
+	// Arrange
+	fake, repo := newTestLinkTypeRepository(t)
+	ctx := repository.WithAuditEntry(context.Background(), &entity.AuditEntry{Actor: "retention"})
+
+	// Act
+	err := repo.Purge(ctx, uuid.New())
+
+	// Assert
+	if !errors.Is(err, entity.ErrLinkTypeNotFound) {
+		t.Errorf("Purge = %v, want ErrLinkTypeNotFound", err)
+	}
+	if audits := fake.queries("INSERT INTO audit_logs"); len(audits) != 0 {
+		t.Errorf("audit rows = %d, want none", len(audits))
+	}
+}
+
+func TestPurgeObjectTypeNotSoftDeletedIsRefused(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"is_deleted"}, [][]driver.Value{{false}}
+	}
+
+	// Act
+	err := repo.Purge(context.Background(), uuid.New())
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNotDeleted) {
+		t.Errorf("Purge = %v, want ErrObjectTypeNotDeleted", err)
+	}
+	if deletes := fake.queries("DELETE FROM"); len(deletes) != 0 {
+		t.Errorf("deletes = %v, want none of a live object type", deletes)
+	}
+}
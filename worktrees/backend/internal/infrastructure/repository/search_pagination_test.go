@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// rankedMatch is an object type matching a search with the given rank
+type rankedMatch struct {
+	objectType *entity.ObjectType
+	rank       float32
+}
+
+// rankedMatches returns seven matches, three ranked 0.5, two 0.25 and two
+// 0.125, so a page of two splits every group of ties
+func rankedMatches() []rankedMatch {
+	var matches []rankedMatch
+	for _, rank := range []float32{0.5, 0.5, 0.5, 0.25, 0.25, 0.125, 0.125} {
+		id := uuid.New()
+		matches = append(matches, rankedMatch{
+			objectType: &entity.ObjectType{ID: id, Name: "Customer" + id.String()[:8], DisplayName: "Customer"},
+			rank:       rank,
+		})
+	}
+	return matches
+}
+
+// searchOrder returns the IDs of matches ranked descending with ties broken
+// by ID, the order search promises
+func searchOrder(matches []rankedMatch) []uuid.UUID {
+	sorted := slices.Clone(matches)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].rank != sorted[j].rank {
+			return sorted[i].rank > sorted[j].rank
+		}
+		return sorted[i].objectType.ID.String() < sorted[j].objectType.ID.String()
+	})
+	ids := make([]uuid.UUID, len(sorted))
+	for i, match := range sorted {
+		ids[i] = match.objectType.ID
+	}
+	return ids
+}
+
+// answerSearch answers search queries from matches as Postgres would: in
+// search order, after the cursor's rank and ID when one is bound, up to the
+// bound limit
+func answerSearch(matches []rankedMatch) func(string, []interface{}) ([]string, [][]driver.Value) {
+	return func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		columns := append(slices.Clone(objectTypeColumnNames), "rank")
+		byID := make(map[uuid.UUID]rankedMatch, len(matches))
+		for _, match := range matches {
+			byID[match.objectType.ID] = match
+		}
+
+		var values [][]driver.Value
+		for _, id := range searchOrder(matches) {
+			match := byID[id]
+			if strings.Contains(query, "id > $4") {
+				afterRank, afterID := args[2].(float64), args[3].(uuid.UUID)
+				rank := float64(match.rank)
+				if rank > afterRank || (rank == afterRank && id.String() <= afterID.String()) {
+					continue
+				}
+			}
+			values = append(values, append(objectTypeRow(match.objectType), float64(match.rank)))
+		}
+
+		if limit := args[len(args)-1].(int); len(values) > limit {
+			values = values[:limit]
+		}
+		return columns, values
+	}
+}
+
+func TestSearchPagesThroughMoreMatchesThanTheLimit(t *testing.T) {
+	// Arrange
+	matches := rankedMatches()
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = answerSearch(matches)
+	var seen []uuid.UUID
+	pages := 0
+	cursor := ""
+
+	// Act
+	for {
+		page, err := repo.Search(context.Background(), "customer", 2, repository.SearchOptions{Cursor: cursor})
+		if err != nil {
+			t.Fatalf("Search page %d: %v", pages+1, err)
+		}
+		pages++
+		if len(page.Results) > 2 {
+			t.Errorf("page %d has %d results, want at most the limit of 2", pages, len(page.Results))
+		}
+		for _, ot := range page.Results {
+			seen = append(seen, ot.ID)
+		}
+		if page.NextCursor == "" || pages > len(matches) {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	// Assert
+	if pages != 4 {
+		t.Errorf("pages = %d, want 4 pages of at most 2 for 7 matches", pages)
+	}
+	if want := searchOrder(matches); !slices.Equal(seen, want) {
+		t.Errorf("paged results = %v, want each match once in rank then ID order %v", seen, want)
+	}
+}
+
+func TestSearchLastPageHasNoNextCursor(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = answerSearch(rankedMatches())
+
+	// Act
+	page, err := repo.Search(context.Background(), "customer", 7, repository.SearchOptions{})
+
+	// Assert
+	if err != nil || len(page.Results) != 7 || page.NextCursor != "" {
+		t.Errorf("Search = %d results, cursor %q, %v, want all 7 and no next cursor", len(page.Results), page.NextCursor, err)
+	}
+}
+
+func TestSearchOrdersTiesByID(t *testing.T) {
+	// Act
+	search := searchQuery(t, "customer", repository.SearchOptions{})
+
+	// Assert
+	if !strings.Contains(search.query, "DESC, id") {
+		t.Errorf("query = %q, want rank ties broken by id", search.query)
+	}
+}
+
+func TestSearchRejectsListCursor(t *testing.T) {
+	// Arrange
+	_, repo := newTestObjectTypeRepository(t)
+	listCursor := testCursors.Encode(repository.PageCursor{SortBy: "name", Value: "Customer", ID: uuid.New()})
+
+	// Act
+	_, err := repo.Search(context.Background(), "customer", 2, repository.SearchOptions{Cursor: listCursor})
+
+	// Assert
+	if !errors.Is(err, repository.ErrInvalidInput) {
+		t.Errorf("Search = %v, want ErrInvalidInput for a cursor issued by List", err)
+	}
+}
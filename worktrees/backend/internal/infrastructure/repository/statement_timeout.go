@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// queryer runs read queries on a *sql.DB or *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// withStatementTimeout runs fn in a read-only transaction in which PostgreSQL
+// cancels any statement running longer than timeout, so a pathological query
+// cannot hold a connection indefinitely. A cancelled statement is reported as
+// repository.ErrQueryTimeout. A timeout of 0 runs fn directly on db.
+func withStatementTimeout(ctx context.Context, db *sql.DB, timeout time.Duration, fn func(q queryer) error) error {
+	if timeout <= 0 {
+		return statementTimeoutError(ctx, fn(db))
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// SET does not accept bind parameters; the value is a whole number of milliseconds
+	millis := timeout.Milliseconds()
+	if millis < 1 {
+		millis = 1
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", millis)); err != nil {
+		return fmt.Errorf("failed to set statement timeout: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		return statementTimeoutError(ctx, err)
+	}
+	return tx.Commit()
+}
+
+// statementTimeoutError maps a statement cancelled by statement_timeout to
+// repository.ErrQueryTimeout. Cancellations caused by ctx are left as they are.
+func statementTimeoutError(ctx context.Context, err error) error {
+	var pqErr *pq.Error
+	if err == nil || ctx.Err() != nil || !errors.As(err, &pqErr) || pqErr.Code != "57014" { // query_canceled
+		return err
+	}
+	return fmt.Errorf("%w: %s", repository.ErrQueryTimeout, pqErr.Message)
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// statementTimeoutSQL is the statement that bounds queries to 250ms
+const statementTimeoutSQL = "SET LOCAL statement_timeout = 250"
+
+// queryCanceled is the error PostgreSQL returns for a statement cancelled by
+// statement_timeout
+var queryCanceled = &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"}
+
+// slowQueries makes fake fail every query containing substr the way
+// PostgreSQL does once statement_timeout fires
+func slowQueries(fake *fakeDB, substr string) {
+	fake.fail = func(query string) error {
+		if strings.Contains(query, substr) {
+			return queryCanceled
+		}
+		return nil
+	}
+}
+
+// newTimedObjectTypeRepository returns a repository whose List, Count and
+// Search queries are bounded to 250ms
+func newTimedObjectTypeRepository(t *testing.T) (*fakeDB, *PostgresObjectTypeRepository) {
+	t.Helper()
+	fake, db := newFakeSplitter(t)
+	return fake, NewPostgresObjectTypeRepository(db, testCursors, 250*time.Millisecond, zap.NewNop()).(*PostgresObjectTypeRepository)
+}
+
+func TestListSetsStatementTimeoutInReadOnlyTransaction(t *testing.T) {
+	// Arrange
+	fake, repo := newTimedObjectTypeRepository(t)
+
+	// Act
+	_, err := repo.List(context.Background(), repository.ObjectTypeFilter{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(fake.statements) < 2 || fake.statements[0].query != statementTimeoutSQL {
+		t.Fatalf("statements = %v, want %q before the list query", fake.statements, statementTimeoutSQL)
+	}
+	if len(fake.transactions) != 1 || !fake.transactions[0].ReadOnly {
+		t.Errorf("transactions = %+v, want one read-only transaction", fake.transactions)
+	}
+}
+
+func TestZeroStatementTimeoutQueriesWithoutTransaction(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+
+	// Act
+	_, _ = repo.List(context.Background(), repository.ObjectTypeFilter{})
+
+	// Assert
+	if got := fake.queries("statement_timeout"); len(got) != 0 {
+		t.Errorf("statements = %v, want no statement timeout", got)
+	}
+	if len(fake.transactions) != 0 {
+		t.Errorf("transactions = %+v, want none", fake.transactions)
+	}
+}
+
+func TestSlowQueriesReturnQueryTimeout(t *testing.T) {
+	fake, objectTypes := newTimedObjectTypeRepository(t)
+	slowQueries(fake, "FROM object_types")
+	linkFake, splitter := newFakeSplitter(t)
+	linkTypes := NewPostgresLinkTypeRepository(splitter, testCursors, 250*time.Millisecond)
+	slowQueries(linkFake, "FROM link_types")
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		run  func() error
+	}{
+		{"object type List", func() error {
+			_, err := objectTypes.List(ctx, repository.ObjectTypeFilter{})
+			return err
+		}},
+		{"object type Count", func() error {
+			_, err := objectTypes.Count(ctx, repository.ObjectTypeFilter{})
+			return err
+		}},
+		{"object type Search", func() error {
+			_, err := objectTypes.Search(ctx, "customer", 10, repository.SearchOptions{})
+			return err
+		}},
+		{"link type List", func() error {
+			_, err := linkTypes.List(ctx, repository.LinkTypeFilter{})
+			return err
+		}},
+		{"link type Count", func() error {
+			_, err := linkTypes.Count(ctx, repository.LinkTypeFilter{})
+			return err
+		}},
+	}
+
+	for _, tc := range cases {
+		// Act
+		err := tc.run()
+
+		// Assert
+		if !errors.Is(err, repository.ErrQueryTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s = %v, want ErrQueryTimeout wrapping context.DeadlineExceeded", tc.name, err)
+		}
+	}
+}
+
+func TestCancelledRequestIsNotReportedAsQueryTimeout(t *testing.T) {
+	// Arrange
+	fake, repo := newTimedObjectTypeRepository(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	fake.fail = func(query string) error {
+		if strings.Contains(query, "FROM object_types") {
+			cancel()
+			return &pq.Error{Code: "57014", Message: "canceling statement due to user request"}
+		}
+		return nil
+	}
+
+	// Act
+	_, err := repo.List(ctx, repository.ObjectTypeFilter{})
+
+	// Assert
+	if err == nil || errors.Is(err, repository.ErrQueryTimeout) {
+		t.Errorf("List = %v, want the cancellation returned as it is", err)
+	}
+}
+
+func TestOtherQueryErrorsAreNotQueryTimeouts(t *testing.T) {
+	// Arrange
+	fake, repo := newTimedObjectTypeRepository(t)
+	fake.fail = func(query string) error {
+		if strings.Contains(query, "FROM object_types") {
+			return &pq.Error{Code: "42P01", Message: "relation does not exist"}
+		}
+		return nil
+	}
+
+	// Act
+	_, err := repo.List(context.Background(), repository.ObjectTypeFilter{})
+
+	// Assert
+	if err == nil || errors.Is(err, repository.ErrQueryTimeout) {
+		t.Errorf("List = %v, want the query error", err)
+	}
+}
+
+func TestStatementTimeoutRoundsUpToOneMillisecond(t *testing.T) {
+	// Arrange
+	fake, db := newFakeDB(t)
+
+	// Act
+	err := withStatementTimeout(context.Background(), db, time.Microsecond, func(q queryer) error {
+		_, err := q.QueryContext(context.Background(), "SELECT 1")
+		return err
+	})
+
+	// Assert
+	if got := fake.queries("statement_timeout"); err != nil || len(got) != 1 || got[0].query != "SET LOCAL statement_timeout = 1" {
+		t.Errorf("statements = %v (err %v), want a 1ms timeout", got, err)
+	}
+}
+
+// countRows answers count queries with n
+func countRows(n int64) func(string, []interface{}) ([]string, [][]driver.Value) {
+	return func(string, []interface{}) ([]string, [][]driver.Value) {
+		return []string{"count"}, [][]driver.Value{{n}}
+	}
+}
+
+func TestCountWithinTimeoutReturnsCount(t *testing.T) {
+	// Arrange
+	fake, repo := newTimedObjectTypeRepository(t)
+	fake.rows = countRows(3)
+
+	// Act
+	count, err := repo.Count(context.Background(), repository.ObjectTypeFilter{})
+
+	// Assert
+	if err != nil || count != 3 {
+		t.Errorf("Count = %d, %v, want 3", count, err)
+	}
+	if got := fake.queries("statement_timeout"); len(got) != 1 {
+		t.Errorf("statements = %v, want the count bounded by the timeout", got)
+	}
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// tenantScopedCalls are repository methods that may only see or change the
+// definitions of the tenant on ctx
+func tenantScopedCalls() []repositoryCall {
+	id := uuid.New()
+	calls := append(readCalls(), primaryCalls()...)
+	return append(calls, []repositoryCall{
+		{"object Create", func(ctx context.Context, r replicatedRepositories) error {
+			return r.objectTypes.Create(ctx, &entity.ObjectType{ID: id, Name: "Customer", Version: 1})
+		}},
+		{"object GetByIDs", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.GetByIDs(ctx, []uuid.UUID{id})
+			return err
+		}},
+		{"object GetByNameIncludingDeleted", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.GetByNameIncludingDeleted(ctx, "Customer")
+			return err
+		}},
+		{"object Restore", func(ctx context.Context, r replicatedRepositories) error {
+			return r.objectTypes.Restore(ctx, id)
+		}},
+		{"object ListDeleted", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.ListDeleted(ctx, 10)
+			return err
+		}},
+		{"object SuggestNames", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.SuggestNames(ctx, "Cus", 10)
+			return err
+		}},
+		{"object ListCategories", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.ListCategories(ctx)
+			return err
+		}},
+		{"object ListTags", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.ListTags(ctx)
+			return err
+		}},
+		{"object ListIndexes", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.objectTypes.ListIndexes(ctx, id)
+			return err
+		}},
+		{"link GetByTargetObjectType", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.GetByTargetObjectType(ctx, id)
+			return err
+		}},
+		{"link GetByObjectTypes", func(ctx context.Context, r replicatedRepositories) error {
+			_, err := r.linkTypes.GetByObjectTypes(ctx, id, uuid.New())
+			return err
+		}},
+		{"link Purge", func(ctx context.Context, r replicatedRepositories) error {
+			return r.linkTypes.Purge(ctx, id)
+		}},
+	}...)
+}
+
+func TestRepositoryStatementsAreScopedToTheTenant(t *testing.T) {
+	for _, tc := range tenantScopedCalls() {
+		// Arrange
+		repos := newReplicatedRepositories(t, 0)
+		ctx := repository.WithTenant(context.Background(), "acme")
+
+		// Act
+		_ = tc.call(ctx, repos)
+
+		// Assert
+		statements := append(repos.primary.queries(""), repos.replica.queries("")...)
+		if len(statements) == 0 {
+			t.Errorf("%s: ran no statements", tc.name)
+		}
+		for _, statement := range statements {
+			// A version row follows the tenant-scoped write of its object
+			// type in the same transaction
+			if strings.Contains(statement.query, "INSERT INTO object_type_versions") {
+				continue
+			}
+			if !slices.Contains(statement.args, interface{}("acme")) {
+				t.Errorf("%s: statement %q with %v is not bound to the tenant", tc.name, statement.query, statement.args)
+			}
+		}
+	}
+}
+
+// hiddenFrom answers queries with row unless they are bound to tenant, as
+// Postgres does for a definition of another tenant: a query that is not
+// scoped to a tenant sees it
+func hiddenFrom(tenant string, columns []string, row []driver.Value) func(string, []interface{}) ([]string, [][]driver.Value) {
+	return func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		if slices.Contains(args, interface{}(tenant)) {
+			return columns, nil
+		}
+		return columns, [][]driver.Value{row}
+	}
+}
+
+func TestGetObjectTypeOfAnotherTenantIsNotFound(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	customer := &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 1}
+	fake.rows = hiddenFrom("globex", objectTypeColumnNames, objectTypeRow(customer))
+	acme := repository.WithTenant(context.Background(), "acme")
+	globex := repository.WithTenant(context.Background(), "globex")
+
+	// Act
+	_, byIDErr := repo.GetByID(globex, customer.ID)
+	_, byNameErr := repo.GetByName(globex, "Customer")
+	own, ownErr := repo.GetByID(acme, customer.ID)
+
+	// Assert
+	if !errors.Is(byIDErr, entity.ErrObjectTypeNotFound) || !errors.Is(byNameErr, entity.ErrObjectTypeNotFound) {
+		t.Errorf("globex reads of acme's object type = %v, %v, want ErrObjectTypeNotFound", byIDErr, byNameErr)
+	}
+	if ownErr != nil || own.ID != customer.ID {
+		t.Errorf("acme read of its object type = %v, %v, want it found", own, ownErr)
+	}
+}
+
+func TestGetLinkTypeOfAnotherTenantIsNotFound(t *testing.T) {
+	// Arrange
+	fake, repo := newTestLinkTypeRepository(t)
+	places := &entity.LinkType{ID: uuid.New(), Name: "places", TenantID: "acme", Version: 1}
+	fake.rows = hiddenFrom("globex", linkTypeColumnNames, linkTypeRow(places))
+	globex := repository.WithTenant(context.Background(), "globex")
+
+	// Act
+	_, byIDErr := repo.GetByID(globex, places.ID)
+	_, byNameErr := repo.GetByName(globex, "places")
+
+	// Assert
+	if !errors.Is(byIDErr, entity.ErrLinkTypeNotFound) || !errors.Is(byNameErr, entity.ErrLinkTypeNotFound) {
+		t.Errorf("globex reads of acme's link type = %v, %v, want ErrLinkTypeNotFound", byIDErr, byNameErr)
+	}
+}
+
+func TestCreateObjectTypeStoresTheRequestTenant(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	objectType := &entity.ObjectType{ID: uuid.New(), Name: "Customer", TenantID: "globex", Version: 1}
+
+	// Act
+	err := repo.Create(repository.WithTenant(context.Background(), "acme"), objectType)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	inserts := fake.queries("INSERT INTO object_types")
+	if len(inserts) != 1 || inserts[0].args[len(inserts[0].args)-1] != "acme" || objectType.TenantID != "acme" {
+		t.Errorf("inserted %v as tenant %q, want the request tenant acme", inserts, objectType.TenantID)
+	}
+}
+
+func TestCreateObjectTypeNameTakenInTheTenantIsANameConflict(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.fail = func(query string) error {
+		if strings.Contains(query, "INSERT INTO object_types") {
+			return &pq.Error{Code: "23505", Constraint: objectTypeNameIndex}
+		}
+		return nil
+	}
+
+	// Act
+	err := repo.Create(repository.WithTenant(context.Background(), "acme"), &entity.ObjectType{ID: uuid.New(), Name: "Customer", Version: 1})
+
+	// Assert
+	if !errors.Is(err, entity.ErrObjectTypeNameExists) {
+		t.Errorf("Create = %v, want ErrObjectTypeNameExists", err)
+	}
+}
+
+func TestListTenantsSpansTenants(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	fake.rows = func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		return []string{"tenant_id"}, [][]driver.Value{{"acme"}, {"globex"}}
+	}
+
+	// Act
+	tenants, err := repo.ListTenants(repository.WithTenant(context.Background(), "acme"))
+
+	// Assert
+	if err != nil || !slices.Equal(tenants, []string{"acme", "globex"}) {
+		t.Errorf("ListTenants = %v, %v, want both tenants", tenants, err)
+	}
+	if args := fake.queries("")[0].args; len(args) != 0 {
+		t.Errorf("args = %v, want no tenant bound", args)
+	}
+}
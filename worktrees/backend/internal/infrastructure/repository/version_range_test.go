@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// customerHistory returns five snapshots of a Customer object type, keyed by
+// version:
+//
+//	v1: email
+//	v2: renamed Client
+//	v3: phone added
+//	v4: email removed, tagged crm
+//	v5: described
+func customerHistory(id uuid.UUID) map[int]*entity.ObjectType {
+	email := entity.Property{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString}
+	phone := entity.Property{Name: "phone", DisplayName: "Phone", DataType: entity.DataTypeString}
+	description := "A buyer"
+
+	v1 := &entity.ObjectType{ID: id, Name: "Customer", DisplayName: "Customer", Version: 1, Properties: []entity.Property{email}}
+	v2 := v1.Copy()
+	v2.Version, v2.DisplayName = 2, "Client"
+	v3 := v2.Copy()
+	v3.Version, v3.Properties = 3, []entity.Property{email, phone}
+	v4 := v3.Copy()
+	v4.Version, v4.Properties, v4.Tags = 4, []entity.Property{phone}, []string{"crm"}
+	v5 := v4.Copy()
+	v5.Version, v5.Description = 5, &description
+	return map[int]*entity.ObjectType{1: v1, 2: v2, 3: v3, 4: v4, 5: v5}
+}
+
+// answerVersions answers version queries with the snapshots of history
+// between the bound from and to versions, in version order
+func answerVersions(history map[int]*entity.ObjectType) func(string, []interface{}) ([]string, [][]driver.Value) {
+	return func(query string, args []interface{}) ([]string, [][]driver.Value) {
+		from, to := args[1].(int), args[2].(int)
+		var rows [][]driver.Value
+		for version := from; version <= to; version++ {
+			if snapshot, ok := history[version]; ok {
+				data, _ := json.Marshal(snapshot)
+				rows = append(rows, []driver.Value{int64(version), data})
+			}
+		}
+		return []string{"version", "snapshot"}, rows
+	}
+}
+
+// changedFields returns the field and type of each change in diff
+func changedFields(diff *repository.VersionDiff) []string {
+	var fields []string
+	for _, change := range diff.Changes {
+		fields = append(fields, change.Field+" "+string(change.Type))
+	}
+	return fields
+}
+
+func TestCompareVersionRangeDiffsEachConsecutivePair(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	id := uuid.New()
+	fake.rows = answerVersions(customerHistory(id))
+
+	// Act
+	diffs, err := repo.CompareVersionRange(context.Background(), id, 1, 4)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CompareVersionRange: %v", err)
+	}
+	if len(diffs) != 3 {
+		t.Fatalf("diffs = %d, want 3 for versions 1 to 4", len(diffs))
+	}
+	want := [][]string{
+		{"displayName modified"},
+		{"properties.phone added"},
+		{"tags modified", "properties.email removed"},
+	}
+	for i, diff := range diffs {
+		if diff.Version1 != i+1 || diff.Version2 != i+2 || diff.ObjectTypeID != id {
+			t.Errorf("diff %d compares %d to %d, want %d to %d", i, diff.Version1, diff.Version2, i+1, i+2)
+		}
+		if got := changedFields(diff); !slices.Equal(got, want[i]) {
+			t.Errorf("changes from v%d to v%d = %v, want %v", diff.Version1, diff.Version2, got, want[i])
+		}
+	}
+}
+
+func TestCompareVersionRangeLoadsSnapshotsInOneQuery(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	id := uuid.New()
+	fake.rows = answerVersions(customerHistory(id))
+
+	// Act
+	_, _ = repo.CompareVersionRange(repository.WithTenant(context.Background(), "acme"), id, 2, 5)
+
+	// Assert
+	queries := fake.queries("FROM object_type_versions")
+	if len(queries) != 1 {
+		t.Fatalf("version queries = %d, want 1", len(queries))
+	}
+	if !slices.Equal(queries[0].args, []interface{}{id, 2, 5, "acme"}) {
+		t.Errorf("args = %v, want the ID, range and tenant", queries[0].args)
+	}
+}
+
+func TestCompareVersionRangeOfAdjacentVersionsIsOneDiff(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	id := uuid.New()
+	fake.rows = answerVersions(customerHistory(id))
+
+	// Act
+	diffs, err := repo.CompareVersionRange(context.Background(), id, 4, 5)
+
+	// Assert
+	if err != nil || len(diffs) != 1 || !slices.Equal(changedFields(diffs[0]), []string{"description modified"}) {
+		t.Errorf("CompareVersionRange = %v, %v, want the one description change", diffs, err)
+	}
+}
+
+func TestCompareVersionRangeWithMissingVersionIsNotFound(t *testing.T) {
+	// Arrange
+	fake, repo := newTestObjectTypeRepository(t)
+	id := uuid.New()
+	history := customerHistory(id)
+	delete(history, 3)
+	fake.rows = answerVersions(history)
+
+	// Act
+	_, err := repo.CompareVersionRange(context.Background(), id, 1, 5)
+
+	// Assert
+	if !errors.Is(err, entity.ErrVersionNotFound) || !strings.Contains(err.Error(), "version 3") {
+		t.Errorf("CompareVersionRange = %v, want ErrVersionNotFound naming version 3", err)
+	}
+}
+
+func TestCompareVersionRangeRejectsInvalidRanges(t *testing.T) {
+	cases := []struct {
+		name     string
+		from, to int
+	}{
+		{"from zero", 0, 3},
+		{"equal", 3, 3},
+		{"reversed", 4, 2},
+		{"too long", 1, 2 + repository.MaxVersionRange},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		fake, repo := newTestObjectTypeRepository(t)
+
+		// Act
+		_, err := repo.CompareVersionRange(context.Background(), uuid.New(), tc.from, tc.to)
+
+		// Assert
+		if !errors.Is(err, repository.ErrInvalidInput) {
+			t.Errorf("%s: CompareVersionRange = %v, want ErrInvalidInput", tc.name, err)
+		}
+		if queries := fake.queries("FROM object_type_versions"); len(queries) != 0 {
+			t.Errorf("%s: ran %d queries, want none", tc.name, len(queries))
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package apierror defines the error responses of the REST API. Every error
+// body has a stable machine-readable code, a human-readable message and
+// optional details.
+package apierror
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Response is the body of every error response
+type Response struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// Request and transport error codes
+const (
+	CodeInvalidRequest         = "INVALID_REQUEST"
+	CodeValidationFailed       = "VALIDATION_FAILED"
+	CodeUnauthenticated        = "UNAUTHENTICATED"
+	CodeForbidden              = "FORBIDDEN"
+	CodeRateLimited            = "RATE_LIMITED"
+	CodePreconditionFailed     = "PRECONDITION_FAILED"
+	CodeIdempotencyKeyInUse    = "IDEMPOTENCY_KEY_IN_USE"
+	CodeIdempotencyKeyConflict = "IDEMPOTENCY_KEY_CONFLICT"
+	CodeRequestTooLarge        = "REQUEST_TOO_LARGE"
+	CodeNotImplemented         = "NOT_IMPLEMENTED"
+	CodeInternal               = "INTERNAL_ERROR"
+)
+
+// Write sends an error response. details is omitted when nil.
+func Write(c *gin.Context, status int, code, message string, details interface{}) {
+	c.JSON(status, Response{Code: code, Message: message, Details: details})
+}
+
+// Abort sends an error response and stops the handler chain
+func Abort(c *gin.Context, status int, code, message string, details interface{}) {
+	c.AbortWithStatusJSON(status, Response{Code: code, Message: message, Details: details})
+}
+
+// Respond sends the response mapped from a domain error, see Lookup. The
+// error text becomes the details when it adds to the mapped message.
+// Unmapped errors are logged with fields, through the request-scoped logger
+// when there is one, and reported as INTERNAL_ERROR with fallback as the
+// message, so internal details never reach clients.
+func Respond(c *gin.Context, log *zap.Logger, err error, fallback string, fields ...zap.Field) {
+	if m, ok := Lookup(err); ok {
+		var details interface{}
+		if err != m.Err {
+			details = err.Error()
+		}
+		Write(c, m.Status, m.Code, m.Message, details)
+		return
+	}
+
+	logger.FromContext(c.Request.Context(), log).Error(fallback, append(fields, zap.Error(err))...)
+	Write(c, http.StatusInternalServerError, CodeInternal, fallback, nil)
+}
@@ -0,0 +1,120 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+)
+
+// Domain error codes
+const (
+	CodeObjectTypeNotFound         = "OBJECT_TYPE_NOT_FOUND"
+	CodeObjectTypeNameExists       = "OBJECT_TYPE_NAME_EXISTS"
+	CodeObjectTypeInUse            = "OBJECT_TYPE_IN_USE"
+	CodeObjectTypeProtected        = "OBJECT_TYPE_PROTECTED"
+	CodeObjectTypeNotDeleted       = "OBJECT_TYPE_NOT_DELETED"
+	CodeVersionNotFound            = "VERSION_NOT_FOUND"
+	CodeInheritanceCycle           = "INHERITANCE_CYCLE"
+	CodeInheritanceConflict        = "INHERITANCE_CONFLICT"
+	CodeInvalidInheritance         = "INVALID_INHERITANCE"
+	CodeParentDeleted              = "PARENT_DELETED"
+	CodeBatchFailed                = "BATCH_FAILED"
+	CodePropertyNotFound           = "PROPERTY_NOT_FOUND"
+	CodeInvalidPropertyOrder       = "INVALID_PROPERTY_ORDER"
+	CodeReferencedTypeMissing      = "REFERENCED_TYPE_MISSING"
+	CodeTooManyProperties          = "TOO_MANY_PROPERTIES"
+	CodeLinkTypeNotFound           = "LINK_TYPE_NOT_FOUND"
+	CodeLinkTypeNameExists         = "LINK_TYPE_NAME_EXISTS"
+	CodeCircularReference          = "CIRCULAR_REFERENCE"
+	CodeInvalidTraversalDirection  = "INVALID_TRAVERSAL_DIRECTION"
+	CodeConflictingLinkConstraints = "CONFLICTING_LINK_CONSTRAINTS"
+	CodeInvalidMetadata            = "INVALID_METADATA"
+	CodeConcurrentUpdate           = "CONCURRENT_UPDATE"
+	CodeChangeDescriptionTooLong   = "CHANGE_DESCRIPTION_TOO_LONG"
+	CodeInvalidProposal            = "INVALID_PROPOSAL"
+	CodeSuggestPrefixTooShort      = "SUGGEST_PREFIX_TOO_SHORT"
+	CodeInvalidBundle              = "INVALID_BUNDLE"
+	CodeImportConflict             = "IMPORT_CONFLICT"
+	CodeIncompatibleCardinality    = "INCOMPATIBLE_CARDINALITY_CHANGE"
+	CodeNotFound                   = "NOT_FOUND"
+	CodeAlreadyExists              = "ALREADY_EXISTS"
+	CodeQueryTimeout               = "QUERY_TIMEOUT"
+	CodeWebhookNotFound            = "WEBHOOK_SUBSCRIPTION_NOT_FOUND"
+	CodeCacheWarmInProgress        = "CACHE_WARM_IN_PROGRESS"
+)
+
+// Mapping ties a domain error to its HTTP status, code and client message
+type Mapping struct {
+	Err     error
+	Status  int
+	Code    string
+	Message string
+}
+
+// Mappings lists the known domain errors. Errors wrapping others come
+// before the errors they wrap, so the most specific mapping wins.
+var Mappings = []Mapping{
+	// Object type errors
+	{entity.ErrObjectTypeNotFound, http.StatusNotFound, CodeObjectTypeNotFound, "Object type not found"},
+	{entity.ErrObjectTypeNameExists, http.StatusConflict, CodeObjectTypeNameExists, "Object type name already exists"},
+	{entity.ErrObjectTypeInUse, http.StatusConflict, CodeObjectTypeInUse, "Object type is referenced by link types"},
+	{entity.ErrObjectTypeProtected, http.StatusConflict, CodeObjectTypeProtected, "Object type is protected by link types that prevent deletes"},
+	{entity.ErrObjectTypeNotDeleted, http.StatusConflict, CodeObjectTypeNotDeleted, "Object type must be deleted first"},
+	{entity.ErrVersionNotFound, http.StatusNotFound, CodeVersionNotFound, "Version not found"},
+	{entity.ErrInheritanceCycle, http.StatusBadRequest, CodeInheritanceCycle, "Invalid inheritance"},
+	{entity.ErrInheritanceConflict, http.StatusBadRequest, CodeInheritanceConflict, "Invalid inheritance"},
+	{entity.ErrInvalidInheritance, http.StatusBadRequest, CodeInvalidInheritance, "Invalid inheritance"},
+	{entity.ErrInvalidObjectType, http.StatusBadRequest, CodeValidationFailed, "Invalid object type"},
+	{entity.ErrParentDeleted, http.StatusConflict, CodeParentDeleted, "Parent object type is deleted"},
+	{entity.ErrBatchFailed, http.StatusBadRequest, CodeBatchFailed, "Batch rejected"},
+
+	// Property errors
+	{entity.ErrPropertyNotFound, http.StatusNotFound, CodePropertyNotFound, "Property not found"},
+	{entity.ErrInvalidPropertyOrder, http.StatusBadRequest, CodeInvalidPropertyOrder, "Invalid property order"},
+	{entity.ErrReferencedTypeMissing, http.StatusBadRequest, CodeReferencedTypeMissing, "Referenced object type not found"},
+	{entity.ErrTooManyProperties, http.StatusBadRequest, CodeTooManyProperties, "Too many properties"},
+
+	// Link type errors
+	{entity.ErrLinkTypeNotFound, http.StatusNotFound, CodeLinkTypeNotFound, "Link type not found"},
+	{entity.ErrLinkTypeNameExists, http.StatusConflict, CodeLinkTypeNameExists, "Link type name already exists"},
+	{entity.ErrCircularReference, http.StatusConflict, CodeCircularReference, "Link type would create a circular reference"},
+	{entity.ErrInvalidTraversalDirection, http.StatusBadRequest, CodeInvalidTraversalDirection, "Invalid traversal direction"},
+	{entity.ErrConflictingLinkConstraints, http.StatusBadRequest, CodeConflictingLinkConstraints, "Link type cannot both cascade and prevent deletes"},
+
+	// Webhook errors
+	{entity.ErrWebhookSubscriptionNotFound, http.StatusNotFound, CodeWebhookNotFound, "Webhook subscription not found"},
+
+	// Validation errors
+	{entity.ErrInvalidMetadata, http.StatusBadRequest, CodeInvalidMetadata, "Invalid metadata"},
+	{entity.ErrValidationFailed, http.StatusBadRequest, CodeValidationFailed, "Validation failed"},
+
+	// Service errors
+	{service.ErrConcurrentUpdate, http.StatusConflict, CodeConcurrentUpdate, "Object type was modified by another request"},
+	{service.ErrChangeDescriptionTooLong, http.StatusBadRequest, CodeChangeDescriptionTooLong, "Invalid change description"},
+	{service.ErrSuggestPrefixTooShort, http.StatusBadRequest, CodeSuggestPrefixTooShort, "Invalid prefix"},
+	{service.ErrInvalidProposal, http.StatusBadRequest, CodeInvalidProposal, "Invalid proposed definition"},
+	{service.ErrInvalidBundle, http.StatusBadRequest, CodeInvalidBundle, "Invalid bundle"},
+	{service.ErrImportConflict, http.StatusConflict, CodeImportConflict, "Bundle conflicts with existing definitions"},
+	{service.ErrIncompatibleCardinalityChange, http.StatusConflict, CodeIncompatibleCardinality, "Cardinality change may be violated by existing links; retry with force to apply it"},
+	{service.ErrCacheWarmInProgress, http.StatusConflict, CodeCacheWarmInProgress, "Cache warming is already in progress"},
+
+	// Repository errors
+	{repository.ErrOptimisticLock, http.StatusConflict, CodeConcurrentUpdate, "Resource was modified by another request"},
+	{repository.ErrNotFound, http.StatusNotFound, CodeNotFound, "Resource not found"},
+	{repository.ErrAlreadyExists, http.StatusConflict, CodeAlreadyExists, "Resource already exists"},
+	{repository.ErrInvalidInput, http.StatusBadRequest, CodeValidationFailed, "Invalid input"},
+	{repository.ErrQueryTimeout, http.StatusGatewayTimeout, CodeQueryTimeout, "Query took too long; narrow the filter and retry"},
+}
+
+// Lookup returns the first mapping whose error err matches
+func Lookup(err error) (Mapping, bool) {
+	for _, m := range Mappings {
+		if errors.Is(err, m.Err) {
+			return m, true
+		}
+	}
+	return Mapping{}, false
+}
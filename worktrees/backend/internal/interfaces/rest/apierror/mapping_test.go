@@ -0,0 +1,155 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+)
+
+func TestLookupInvalidInheritance(t *testing.T) {
+	// Arrange
+	err := fmt.Errorf("%w: parent object type x not found", entity.ErrInvalidInheritance)
+
+	// Act
+	m, _ := Lookup(err)
+
+	// Assert
+	if m.Code != CodeInvalidInheritance {
+		t.Errorf("code = %q, want %q", m.Code, CodeInvalidInheritance)
+	}
+}
+
+func TestLookupInvalidObjectTypeIsNotInheritance(t *testing.T) {
+	// Arrange
+	err := fmt.Errorf("%w: something else", entity.ErrInvalidObjectType)
+
+	// Act
+	m, _ := Lookup(err)
+
+	// Assert
+	if m.Code == CodeInvalidInheritance {
+		t.Errorf("code = %q, want a code other than %q", m.Code, CodeInvalidInheritance)
+	}
+}
+
+func TestLookupMapsKnownErrors(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{entity.ErrObjectTypeNotFound, http.StatusNotFound, CodeObjectTypeNotFound},
+		{entity.ErrObjectTypeNameExists, http.StatusConflict, CodeObjectTypeNameExists},
+		{entity.ErrObjectTypeInUseBy([]string{"customerAccounts"}), http.StatusConflict, CodeObjectTypeInUse},
+		{entity.ErrObjectTypeProtectedBy([]string{"customerAccounts"}), http.StatusConflict, CodeObjectTypeProtected},
+		{entity.ErrObjectTypeNotDeleted, http.StatusConflict, CodeObjectTypeNotDeleted},
+		{entity.ErrVersionNotFound, http.StatusNotFound, CodeVersionNotFound},
+		{entity.ErrInheritanceCycle, http.StatusBadRequest, CodeInheritanceCycle},
+		{entity.ErrPropertyNotFound, http.StatusNotFound, CodePropertyNotFound},
+		{entity.ErrLinkTypeNotFound, http.StatusNotFound, CodeLinkTypeNotFound},
+		{entity.ErrLinkTypeNameExists, http.StatusConflict, CodeLinkTypeNameExists},
+		{entity.ErrCircularReference, http.StatusConflict, CodeCircularReference},
+		{entity.ErrInvalidMetadata, http.StatusBadRequest, CodeInvalidMetadata},
+		{fmt.Errorf("%w: name is required", entity.ErrValidationFailed), http.StatusBadRequest, CodeValidationFailed},
+		{service.ErrConcurrentUpdate, http.StatusConflict, CodeConcurrentUpdate},
+		{service.ErrInvalidProposal, http.StatusBadRequest, CodeInvalidProposal},
+		{repository.ErrOptimisticLock, http.StatusConflict, CodeConcurrentUpdate},
+		{fmt.Errorf("load: %w", repository.ErrNotFound), http.StatusNotFound, CodeNotFound},
+		{repository.ErrAlreadyExists, http.StatusConflict, CodeAlreadyExists},
+		{repository.ErrInvalidInput, http.StatusBadRequest, CodeValidationFailed},
+		{repository.ErrQueryTimeout, http.StatusGatewayTimeout, CodeQueryTimeout},
+	}
+
+	for _, tc := range cases {
+		// Act
+		m, ok := Lookup(tc.err)
+
+		// Assert
+		if !ok || m.Status != tc.status || m.Code != tc.code {
+			t.Errorf("Lookup(%v) = %d %s (found %v), want %d %s", tc.err, m.Status, m.Code, ok, tc.status, tc.code)
+		}
+	}
+}
+
+func TestEveryMappingIsReachable(t *testing.T) {
+	for _, want := range Mappings {
+		// Act
+		m, _ := Lookup(want.Err)
+
+		// Assert
+		if m.Code != want.Code || m.Status != want.Status {
+			t.Errorf("Lookup(%v) = %d %s, want %d %s; an earlier mapping shadows it",
+				want.Err, m.Status, m.Code, want.Status, want.Code)
+		}
+	}
+}
+
+func TestLookupUnknownError(t *testing.T) {
+	// Act
+	_, ok := Lookup(errors.New("connection reset"))
+
+	// Assert
+	if ok {
+		t.Error("Lookup found a mapping for an unknown error")
+	}
+}
+
+// respond runs Respond for err and decodes the response
+func respond(t *testing.T, err error) (int, Response) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	Respond(c, zap.NewNop(), err, "Failed to load object type")
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return w.Code, resp
+}
+
+func TestRespondWritesMappedError(t *testing.T) {
+	// Act
+	status, resp := respond(t, entity.ErrObjectTypeNotFound)
+
+	// Assert
+	if status != http.StatusNotFound || resp.Code != CodeObjectTypeNotFound || resp.Details != nil {
+		t.Errorf("response = %d %+v, want 404 %s without details", status, resp, CodeObjectTypeNotFound)
+	}
+}
+
+func TestRespondAddsWrappedErrorAsDetails(t *testing.T) {
+	// Arrange
+	err := entity.ErrObjectTypeInUseBy([]string{"customerAccounts"})
+
+	// Act
+	_, resp := respond(t, err)
+
+	// Assert
+	if resp.Details != err.Error() {
+		t.Errorf("details = %v, want %q", resp.Details, err.Error())
+	}
+}
+
+func TestRespondHidesUnknownError(t *testing.T) {
+	// Act
+	status, resp := respond(t, errors.New("pq: password authentication failed"))
+
+	// Assert
+	if status != http.StatusInternalServerError || resp.Code != CodeInternal || resp.Message != "Failed to load object type" || resp.Details != nil {
+		t.Errorf("response = %d %+v, want 500 %s with only the fallback message", status, resp, CodeInternal)
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"go.uber.org/zap"
+)
+
+// AuditHandler handles audit log requests
+type AuditHandler struct {
+	service *service.AuditService
+	logger  *zap.Logger
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(service *service.AuditService, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// List handles GET /api/v1/audit
+func (h *AuditHandler) List(c *gin.Context) {
+	filter := repository.AuditFilter{
+		EntityType: c.Query("entity_type"),
+		Actor:      c.Query("actor"),
+		Limit:      100,
+	}
+
+	if entityID := c.Query("entity_id"); entityID != "" {
+		id, err := uuid.Parse(entityID)
+		if err != nil {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid entity ID", nil)
+			return
+		}
+		filter.EntityID = &id
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 500 {
+			filter.Limit = l
+		}
+	}
+
+	entries, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to retrieve audit log")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  entries,
+		"count": len(entries),
+	})
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"go.uber.org/zap"
+)
+
+// CacheHandler handles cache administration requests
+type CacheHandler struct {
+	warmer *service.CacheWarmer
+	logger *zap.Logger
+}
+
+// NewCacheHandler creates a new cache handler
+func NewCacheHandler(warmer *service.CacheWarmer, logger *zap.Logger) *CacheHandler {
+	return &CacheHandler{
+		warmer: warmer,
+		logger: logger,
+	}
+}
+
+// Warm handles POST /api/v1/admin/cache/warm. The run continues in the
+// background after the response; poll WarmStatus for its progress.
+func (h *CacheHandler) Warm(c *gin.Context) {
+	if err := h.warmer.Start(context.WithoutCancel(c.Request.Context())); err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to start cache warming")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, h.warmer.Status())
+}
+
+// WarmStatus handles GET /api/v1/admin/cache/warm
+func (h *CacheHandler) WarmStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.warmer.Status())
+}
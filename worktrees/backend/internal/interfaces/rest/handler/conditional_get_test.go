@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// conditionalGet runs GET path through router with the If-None-Match header
+// set to ifNoneMatch, unless it is empty
+func conditionalGet(router *gin.Engine, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// objectTypeRouter serves GET /api/v1/object-types/:id from repo
+func objectTypeRouter(repo repository.ObjectTypeRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewObjectTypeService(repo, nil, invalidatingCache{}, service.DefaultCacheTTLs(), nil,
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	router := gin.New()
+	router.GET("/api/v1/object-types/:id", NewObjectTypeHandler(svc, testCursors, zap.NewNop()).Get)
+	return router
+}
+
+// storedLinkTypeRepo answers GetByID with its one link type
+type storedLinkTypeRepo struct {
+	repository.LinkTypeRepository
+	linkType *entity.LinkType
+}
+
+func (r *storedLinkTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.LinkType, error) {
+	if id != r.linkType.ID {
+		return nil, entity.ErrLinkTypeNotFound
+	}
+	copied := *r.linkType
+	return &copied, nil
+}
+
+// linkTypeRouter serves GET /api/v1/link-types/:id from repo
+func linkTypeRouter(repo repository.LinkTypeRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewLinkTypeService(repo, nil, missCache{}, service.DefaultCacheTTLs(), nil,
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	router := gin.New()
+	router.GET("/api/v1/link-types/:id", NewLinkTypeHandler(svc, testCursors, zap.NewNop()).Get)
+	return router
+}
+
+func TestGetObjectTypeWithMatchingETagIsNotModified(t *testing.T) {
+	// Arrange
+	repo := &storedObjectTypeRepo{objectType: objectTypeWithDeprecatedFax()}
+	router := objectTypeRouter(repo)
+	path := "/api/v1/object-types/" + repo.objectType.ID.String()
+	etag := conditionalGet(router, path, "").Header().Get("ETag")
+
+	// Act
+	w := conditionalGet(router, path, etag)
+
+	// Assert
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("status = %d with %d body bytes, want 304 and no body", w.Code, w.Body.Len())
+	}
+	if got := w.Header().Get("ETag"); got != etag {
+		t.Errorf("ETag = %s, want %s repeated on the 304", got, etag)
+	}
+}
+
+func TestGetObjectTypeWithStaleETagReturnsNewVersion(t *testing.T) {
+	// Arrange
+	repo := &storedObjectTypeRepo{objectType: objectTypeWithDeprecatedFax()}
+	router := objectTypeRouter(repo)
+	path := "/api/v1/object-types/" + repo.objectType.ID.String()
+	stale := conditionalGet(router, path, "").Header().Get("ETag")
+	repo.objectType.Version = 2
+
+	// Act
+	w := conditionalGet(router, path, stale)
+
+	// Assert
+	if w.Code != http.StatusOK || w.Body.Len() == 0 {
+		t.Errorf("status = %d with %d body bytes, want 200 with the object type", w.Code, w.Body.Len())
+	}
+	if got := w.Header().Get("ETag"); got == stale || got == "" {
+		t.Errorf("ETag = %q, want a new one for version 2", got)
+	}
+}
+
+func TestGetObjectTypeIfNoneMatchForms(t *testing.T) {
+	repo := &storedObjectTypeRepo{objectType: objectTypeWithDeprecatedFax()}
+	router := objectTypeRouter(repo)
+	path := "/api/v1/object-types/" + repo.objectType.ID.String()
+	etag := conditionalGet(router, path, "").Header().Get("ETag")
+
+	cases := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{"weak", "W/" + etag, http.StatusNotModified},
+		{"list", `"0-stale", ` + etag, http.StatusNotModified},
+		{"wildcard", "*", http.StatusNotModified},
+		{"other", `"1-0000000000000000"`, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		// Act
+		w := conditionalGet(router, path, tc.ifNoneMatch)
+
+		// Assert
+		if w.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, tc.want)
+		}
+	}
+}
+
+func TestGetObjectTypeETagOfOtherRepresentationIsIgnored(t *testing.T) {
+	// Arrange
+	repo := &storedObjectTypeRepo{objectType: objectTypeWithDeprecatedFax()}
+	router := objectTypeRouter(repo)
+	path := "/api/v1/object-types/" + repo.objectType.ID.String()
+	full := conditionalGet(router, path, "").Header().Get("ETag")
+
+	// Act
+	w := conditionalGet(router, path+"?include_deprecated=false", full)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 for a different representation", w.Code)
+	}
+}
+
+func TestGetResolvedObjectTypeIsAlwaysSentInFull(t *testing.T) {
+	// Arrange
+	repo := &storedObjectTypeRepo{objectType: objectTypeWithDeprecatedFax()}
+	router := objectTypeRouter(repo)
+	path := "/api/v1/object-types/" + repo.objectType.ID.String() + "?resolve_inherited=true"
+	etag := conditionalGet(router, path, "").Header().Get("ETag")
+
+	// Act
+	w := conditionalGet(router, path, etag)
+
+	// Assert
+	if etag == "" || w.Code != http.StatusOK {
+		t.Errorf("status = %d for ETag %q, want 200 since ancestors may have changed", w.Code, etag)
+	}
+}
+
+func TestGetLinkTypeWithMatchingETagIsNotModified(t *testing.T) {
+	// Arrange
+	repo := &storedLinkTypeRepo{linkType: &entity.LinkType{ID: uuid.New(), Name: "places", Version: 1}}
+	router := linkTypeRouter(repo)
+	path := "/api/v1/link-types/" + repo.linkType.ID.String()
+	etag := conditionalGet(router, path, "").Header().Get("ETag")
+
+	// Act
+	w := conditionalGet(router, path, etag)
+
+	// Assert
+	if etag == "" || w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("status = %d with %d body bytes for ETag %q, want 304 and no body", w.Code, w.Body.Len(), etag)
+	}
+}
+
+func TestGetLinkTypeWithStaleETagReturnsNewVersion(t *testing.T) {
+	// Arrange
+	repo := &storedLinkTypeRepo{linkType: &entity.LinkType{ID: uuid.New(), Name: "places", Version: 1}}
+	router := linkTypeRouter(repo)
+	path := "/api/v1/link-types/" + repo.linkType.ID.String()
+	stale := conditionalGet(router, path, "").Header().Get("ETag")
+	repo.linkType.Version = 2
+
+	// Act
+	w := conditionalGet(router, path, stale)
+
+	// Assert
+	if w.Code != http.StatusOK || w.Body.Len() == 0 {
+		t.Errorf("status = %d with %d body bytes, want 200 with the link type", w.Code, w.Body.Len())
+	}
+	if got := w.Header().Get("ETag"); got == stale {
+		t.Errorf("ETag = %s, want a new one for version 2", got)
+	}
+}
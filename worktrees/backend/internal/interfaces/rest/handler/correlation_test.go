@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
+)
+
+// creatingObjectTypeRepo accepts every new object type without storing it
+type creatingObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+}
+
+func (r *creatingObjectTypeRepo) GetByName(ctx context.Context, name string) (*entity.ObjectType, error) {
+	return nil, entity.ErrObjectTypeNotFound
+}
+
+func (r *creatingObjectTypeRepo) Create(ctx context.Context, objectType *entity.ObjectType) error {
+	return nil
+}
+
+func (r *creatingObjectTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	return nil, entity.ErrObjectTypeNotFound
+}
+
+// invalidatingCache misses on every read and accepts invalidations
+type invalidatingCache struct {
+	missCache
+}
+
+func (invalidatingCache) Delete(ctx context.Context, key string) error { return nil }
+
+func (invalidatingCache) InvalidatePattern(ctx context.Context, pattern string) error { return nil }
+
+func (invalidatingCache) Exists(ctx context.Context, key string) (bool, error) {
+	return false, errors.New("cache miss")
+}
+
+// recordingPublisher records the events published to it
+type recordingPublisher struct {
+	messaging.EventPublisher
+	events []messaging.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event messaging.Event) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+// createWithCorrelationID runs POST /api/v1/object-types through the
+// correlation middleware, sending correlationID unless it is empty, and
+// returns the response and the events published
+func createWithCorrelationID(t *testing.T, correlationID string) (*httptest.ResponseRecorder, []messaging.Event) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	publisher := &recordingPublisher{}
+	svc := service.NewObjectTypeService(&creatingObjectTypeRepo{}, nil, invalidatingCache{}, service.DefaultCacheTTLs(),
+		publisher, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+
+	router := gin.New()
+	router.Use(middleware.CorrelationID(zap.NewNop()), func(c *gin.Context) { c.Set("user_id", "alice") })
+	router.POST("/api/v1/object-types", h.Create)
+
+	body := `{"name":"customer","displayName":"Customer","properties":[{"name":"email","displayName":"Email","dataType":"STRING"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/object-types", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if correlationID != "" {
+		req.Header.Set(middleware.CorrelationIDHeader, correlationID)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, publisher.events
+}
+
+func TestCreatedEventCarriesIncomingCorrelationID(t *testing.T) {
+	// Act
+	w, events := createWithCorrelationID(t, "req-42")
+
+	// Assert
+	if w.Code != http.StatusCreated || len(events) != 1 {
+		t.Fatalf("response %d with %d events, want 201 and one event: %s", w.Code, len(events), w.Body.String())
+	}
+	if events[0].CorrelationID != "req-42" {
+		t.Errorf("event correlation ID = %q, want req-42", events[0].CorrelationID)
+	}
+	if header := w.Header().Get(middleware.CorrelationIDHeader); header != "req-42" {
+		t.Errorf("response correlation ID = %q, want req-42", header)
+	}
+}
+
+func TestCreatedEventCarriesGeneratedCorrelationID(t *testing.T) {
+	// Act
+	w, events := createWithCorrelationID(t, "")
+
+	// Assert
+	header := w.Header().Get(middleware.CorrelationIDHeader)
+	if header == "" || len(events) != 1 || events[0].CorrelationID != header {
+		t.Errorf("response correlation ID %q and events %v, want one event tagged with the generated ID", header, events)
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseIfMatchWildcardHasNoVersion(t *testing.T) {
+	// Arrange
+	header := "*"
+
+	// Act
+	version, err := parseIfMatch(header)
+
+	// Assert
+	if err != nil || version != nil {
+		t.Errorf("parseIfMatch(%q) = %v, %v; want nil, nil", header, version, err)
+	}
+}
+
+func TestParseIfMatchQuotedVersion(t *testing.T) {
+	// Arrange
+	header := `W/"7"`
+
+	// Act
+	version, err := parseIfMatch(header)
+
+	// Assert
+	if err != nil || version == nil || *version != 7 {
+		t.Errorf("parseIfMatch(%q) = %v, %v; want 7", header, version, err)
+	}
+}
+
+func TestParseIfMatchRejectsGarbage(t *testing.T) {
+	// Arrange
+	header := `"abc"`
+
+	// Act
+	_, err := parseIfMatch(header)
+
+	// Assert
+	if err == nil {
+		t.Errorf("parseIfMatch(%q) returned no error", header)
+	}
+}
+
+func TestParseIfMatchRepresentationETag(t *testing.T) {
+	// Arrange
+	header := representationETag(uuid.New(), 4, representationOptions{Grouped: true})
+
+	// Act
+	version, err := parseIfMatch(header)
+
+	// Assert
+	if err != nil || version == nil || *version != 4 {
+		t.Errorf("parseIfMatch(%q) = %v, %v; want 4", header, version, err)
+	}
+}
+
+func TestRepresentationETagDiffersByFields(t *testing.T) {
+	// Arrange
+	id := uuid.New()
+
+	// Act
+	full := representationETag(id, 1, representationOptions{})
+	projected := representationETag(id, 1, representationOptions{Fields: []string{"name"}})
+
+	// Assert
+	if full == projected {
+		t.Errorf("full and projected representations share ETag %s", full)
+	}
+}
+
+func TestRepresentationETagIgnoresFieldOrder(t *testing.T) {
+	// Arrange
+	id := uuid.New()
+
+	// Act
+	a := representationETag(id, 1, representationOptions{Fields: []string{"name", "tags"}})
+	b := representationETag(id, 1, representationOptions{Fields: []string{"tags", "name", "name"}})
+
+	// Assert
+	if a != b {
+		t.Errorf("ETags differ for the same fields: %s != %s", a, b)
+	}
+}
+
+func TestRepresentationETagDiffersByOptions(t *testing.T) {
+	// Arrange
+	id := uuid.New()
+	variants := []representationOptions{
+		{},
+		{Grouped: true},
+		{ExcludeDeprecated: true},
+		{ResolveInherited: true},
+	}
+	seen := map[string]bool{}
+
+	// Act
+	for _, opts := range variants {
+		seen[representationETag(id, 1, opts)] = true
+	}
+
+	// Assert
+	if len(seen) != len(variants) {
+		t.Errorf("got %d distinct ETags for %d representations", len(seen), len(variants))
+	}
+}
+
+func TestRepresentationETagDiffersByID(t *testing.T) {
+	// Arrange
+	a, b := uuid.New(), uuid.New()
+
+	// Act
+	etagA := representationETag(a, 1, representationOptions{})
+	etagB := representationETag(b, 1, representationOptions{})
+
+	// Assert
+	if etagA == etagB {
+		t.Errorf("different ids share ETag %s", etagA)
+	}
+}
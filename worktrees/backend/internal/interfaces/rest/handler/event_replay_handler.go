@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"go.uber.org/zap"
+)
+
+// EventReplayHandler handles event replay requests
+type EventReplayHandler struct {
+	store    event.EventStore
+	dispatch event.EventHandler
+	logger   *zap.Logger
+}
+
+// NewEventReplayHandler creates a new event replay handler. Replayed events
+// are handed to dispatch, typically KafkaConsumer.Dispatch.
+func NewEventReplayHandler(store event.EventStore, dispatch event.EventHandler, logger *zap.Logger) *EventReplayHandler {
+	return &EventReplayHandler{
+		store:    store,
+		dispatch: dispatch,
+		logger:   logger,
+	}
+}
+
+// Replay handles POST /api/v1/events/:aggregateId/replay
+func (h *EventReplayHandler) Replay(c *gin.Context) {
+	aggregateID := c.Param("aggregateId")
+
+	replayed := 0
+	err := event.ReplayEvents(c.Request.Context(), h.store, aggregateID,
+		func(ctx context.Context, evt event.Event) error {
+			if err := h.dispatch(ctx, evt); err != nil {
+				return err
+			}
+			replayed++
+			return nil
+		})
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to replay events",
+			zap.String("aggregate_id", aggregateID),
+			zap.Int("replayed", replayed))
+		return
+	}
+
+	h.logger.Info("Events replayed",
+		zap.String("aggregate_id", aggregateID),
+		zap.Int("replayed", replayed))
+
+	c.JSON(http.StatusOK, gin.H{
+		"aggregateId": aggregateID,
+		"replayed":    replayed,
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"go.uber.org/zap"
+)
+
+// DefaultStreamHeartbeat is the interval between heartbeat comments used when
+// none is given
+const DefaultStreamHeartbeat = 15 * time.Second
+
+// streamAggregateTypes are the accepted values of the aggregate_type filter
+var streamAggregateTypes = map[string]bool{"object_type": true, "link_type": true}
+
+// EventStreamHandler streams consumed events to clients as Server-Sent Events
+type EventStreamHandler struct {
+	broker    *messaging.SubscriptionBroker
+	heartbeat time.Duration
+	logger    *zap.Logger
+}
+
+// NewEventStreamHandler creates a new event stream handler. The broker must be
+// attached to the KafkaConsumer reading the event topic.
+func NewEventStreamHandler(broker *messaging.SubscriptionBroker, heartbeat time.Duration, logger *zap.Logger) *EventStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = DefaultStreamHeartbeat
+	}
+
+	return &EventStreamHandler{
+		broker:    broker,
+		heartbeat: heartbeat,
+		logger:    logger,
+	}
+}
+
+// Stream handles GET /api/v1/events/stream. Clients only receive events of
+// their own tenant, filtered by the optional aggregate_type and event_type query parameters; event_type takes a
+// comma-separated list. A heartbeat comment is sent while no events arrive so
+// idle connections are not closed by proxies. The subscription ends when the
+// client disconnects.
+func (h *EventStreamHandler) Stream(c *gin.Context) {
+	filter := messaging.SubscriptionFilter{AggregateType: c.Query("aggregate_type")}
+	if filter.AggregateType != "" && !streamAggregateTypes[filter.AggregateType] {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "invalid aggregate_type",
+			"must be object_type or link_type")
+		return
+	}
+	if eventTypes := c.Query("event_type"); eventTypes != "" {
+		for _, eventType := range strings.Split(eventTypes, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				filter.EventTypes = append(filter.EventTypes, eventType)
+			}
+		}
+	}
+
+	ctx := c.Request.Context()
+	events := h.broker.Subscribe(ctx, filter)
+
+	// The stream outlives the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("Failed to clear write deadline for event stream", zap.Error(err))
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	h.logger.Info("Event stream opened",
+		zap.String("aggregate_type", filter.AggregateType),
+		zap.Strings("event_types", filter.EventTypes))
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Info("Event stream closed")
+			return
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := h.writeEvent(c, evt); err != nil {
+				h.logger.Warn("Failed to write event to stream",
+					zap.String("event_id", evt.ID),
+					zap.Error(err))
+				return
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeEvent writes evt as a single SSE frame and flushes it to the client
+func (h *EventStreamHandler) writeEvent(c *gin.Context, evt event.Event) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", evt.ID, evt.EventType, data); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
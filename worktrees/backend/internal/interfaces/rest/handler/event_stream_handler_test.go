@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/event"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// eventStream is an open GET /api/v1/events/stream connection
+type eventStream struct {
+	broker *messaging.SubscriptionBroker
+	lines  *bufio.Scanner
+	cancel context.CancelFunc
+}
+
+// openEventStream starts a server streaming from a new broker with heartbeat
+// and connects to it with query, returning once the stream is subscribed
+func openEventStream(t *testing.T, heartbeat time.Duration, query string) *eventStream {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	broker := messaging.NewSubscriptionBroker(0, zap.NewNop())
+	router := gin.New()
+	router.GET("/api/v1/events/stream", NewEventStreamHandler(broker, heartbeat, zap.NewNop()).Stream)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/events/stream?"+query, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("response %d %q, want a 200 event stream", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	waitFor(t, func() bool { return broker.SubscriberCount() == 1 })
+	return &eventStream{broker: broker, lines: bufio.NewScanner(resp.Body), cancel: cancel}
+}
+
+// waitFor polls condition until it holds, failing the test after a second
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within a second")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// consume hands evt to the broker as the Kafka consumer does
+func (s *eventStream) consume(evt event.Event) {
+	_ = s.broker.Publish(context.Background(), evt)
+}
+
+// frame reads the next SSE frame, returning its lines
+func (s *eventStream) frame(t *testing.T) []string {
+	t.Helper()
+	var lines []string
+	for s.lines.Scan() {
+		if s.lines.Text() == "" {
+			return lines
+		}
+		lines = append(lines, s.lines.Text())
+	}
+	t.Fatalf("stream ended: %v", s.lines.Err())
+	return nil
+}
+
+func TestEventStreamWritesMatchingEventsAsFrames(t *testing.T) {
+	// Arrange
+	stream := openEventStream(t, time.Hour, "aggregate_type=object_type&event_type=object_type.created,object_type.deleted")
+
+	// Act
+	stream.consume(event.Event{ID: "evt-1", EventType: "link_type.created", AggregateType: "link_type"})
+	stream.consume(event.Event{ID: "evt-2", EventType: "object_type.updated", AggregateType: "object_type"})
+	stream.consume(event.Event{ID: "evt-3", EventType: "object_type.created", AggregateType: "object_type", AggregateID: "customer"})
+
+	// Assert
+	frame := stream.frame(t)
+	if len(frame) != 3 || frame[0] != "id: evt-3" || frame[1] != "event: object_type.created" ||
+		!strings.HasPrefix(frame[2], "data: ") || !strings.Contains(frame[2], `"aggregateId":"customer"`) {
+		t.Errorf("frame = %q, want only evt-3 with its JSON body", frame)
+	}
+}
+
+func TestEventStreamSendsHeartbeatsWhileIdle(t *testing.T) {
+	// Arrange
+	stream := openEventStream(t, 10*time.Millisecond, "")
+
+	// Act
+	frame := stream.frame(t)
+
+	// Assert
+	if len(frame) != 1 || frame[0] != ": heartbeat" {
+		t.Errorf("frame = %q, want a heartbeat comment", frame)
+	}
+}
+
+func TestEventStreamUnsubscribesOnDisconnect(t *testing.T) {
+	// Arrange
+	stream := openEventStream(t, time.Hour, "")
+
+	// Act
+	stream.cancel()
+
+	// Assert
+	waitFor(t, func() bool { return stream.broker.SubscriberCount() == 0 })
+}
+
+func TestEventStreamRejectsUnknownAggregateType(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	broker := messaging.NewSubscriptionBroker(0, zap.NewNop())
+	h := NewEventStreamHandler(broker, time.Hour, zap.NewNop())
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/events/stream?aggregate_type=dataset", nil)
+
+	// Act
+	h.Stream(c)
+
+	// Assert
+	if w.Code != http.StatusBadRequest || broker.SubscriberCount() != 0 {
+		t.Errorf("status = %d with %d subscribers, want 400 and no subscription", w.Code, broker.SubscriberCount())
+	}
+}
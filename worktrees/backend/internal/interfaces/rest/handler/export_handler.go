@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
+	"go.uber.org/zap"
+)
+
+// maxBundleSize bounds the request body accepted by Import
+const maxBundleSize = 32 << 20
+
+// ExportHandler handles ontology export and import requests
+type ExportHandler struct {
+	service *service.ExportService
+	logger  *zap.Logger
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(service *service.ExportService, logger *zap.Logger) *ExportHandler {
+	return &ExportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Export handles GET /api/v1/export
+func (h *ExportHandler) Export(c *gin.Context) {
+	bundle, err := h.service.ExportBundle(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to export bundle")
+		return
+	}
+
+	filename := fmt.Sprintf("ontology-%s.json", time.Now().UTC().Format("20060102T150405Z"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/json", bundle)
+}
+
+// Import handles POST /api/v1/import?dry_run=true&on_conflict=skip
+func (h *ExportHandler) Import(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	opts := service.ImportOptions{
+		OnConflict: service.ConflictStrategy(c.DefaultQuery("on_conflict", string(service.ConflictFail))),
+	}
+	if dryRunStr := c.Query("dry_run"); dryRunStr != "" {
+		dryRun, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid dry_run value", nil)
+			return
+		}
+		opts.DryRun = dryRun
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBundleSize+1))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Failed to read request body", nil)
+		return
+	}
+	if len(data) > maxBundleSize {
+		apierror.Write(c, http.StatusRequestEntityTooLarge, apierror.CodeInvalidRequest, "Bundle too large", nil)
+		return
+	}
+
+	result, err := h.service.ImportBundle(c.Request.Context(), data, userID, opts)
+	if err != nil {
+		var importErr *service.ImportError
+		if !errors.As(err, &importErr) {
+			apierror.Respond(c, h.logger, err, "Failed to import bundle",
+				zap.String("user_id", userID),
+				zap.Bool("dry_run", opts.DryRun))
+			return
+		}
+
+		// Entries applied before the failed write are reported alongside the
+		// error, since they are kept
+		status, code, message := http.StatusInternalServerError, apierror.CodeInternal, "Failed to import bundle"
+		if m, ok := apierror.Lookup(importErr.Err); ok {
+			status, code, message = m.Status, m.Code, m.Message
+		} else {
+			h.logger.Error("Failed to import bundle",
+				zap.String("user_id", userID),
+				zap.Int("applied", len(result.Items)),
+				zap.Error(err))
+		}
+		apierror.Write(c, status, code, message, gin.H{
+			"error":  importErr.Err.Error(),
+			"result": result,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
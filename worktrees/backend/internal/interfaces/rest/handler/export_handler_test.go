@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+)
+
+// partialBundle creates Account, then fails on Invoice, whose order property
+// references an object type that does not exist
+const partialBundle = `{"formatVersion":1,"objectTypes":[
+	{"name":"Account","displayName":"Account","properties":[]},
+	{"name":"Invoice","displayName":"Invoice","properties":[{"name":"order","displayName":"Order","dataType":"REFERENCE",
+		"referencedObjectTypeId":"0b8f3a52-2f7a-4c1e-9d55-7a8e0c5b1f00"}]}]}`
+
+func TestImportFailingPartwayReportsTheAppliedEntries(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	objectTypes := service.NewObjectTypeService(&creatingObjectTypeRepo{}, nil, invalidatingCache{}, service.DefaultCacheTTLs(),
+		&recordingPublisher{}, m, zap.NewNop())
+	linkTypes := service.NewLinkTypeService(nil, nil, invalidatingCache{}, service.DefaultCacheTTLs(), &recordingPublisher{}, m, zap.NewNop())
+	h := NewExportHandler(service.NewExportService(objectTypes, linkTypes, testCursors, zap.NewNop()), zap.NewNop())
+	router := gin.New()
+	router.POST("/api/v1/import", func(c *gin.Context) {
+		c.Set("user_id", "alice")
+		h.Import(c)
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/import", strings.NewReader(partialBundle)))
+
+	// Assert
+	var resp struct {
+		apierror.Response
+		Details struct {
+			Error  string               `json:"error"`
+			Result service.ImportResult `json:"result"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if w.Code != http.StatusBadRequest || resp.Code != apierror.CodeReferencedTypeMissing {
+		t.Errorf("status = %d %s, want 400 %s", w.Code, resp.Code, apierror.CodeReferencedTypeMissing)
+	}
+	items := resp.Details.Result.Items
+	if len(items) != 1 || items[0].Name != "Account" || !strings.Contains(resp.Details.Error, "Invoice") {
+		t.Errorf("details = %+v, want Account applied before Invoice failed", resp.Details)
+	}
+}
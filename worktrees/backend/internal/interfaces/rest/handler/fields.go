@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+)
+
+// objectTypeFields are the JSON field names of an object type, the names
+// accepted by ?fields= on object type reads
+var objectTypeFields = jsonFieldNames(reflect.TypeOf(entity.ObjectType{}))
+
+// jsonFieldNames returns the JSON names of the exported fields of struct type t
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseFields reads a sparse fieldset from ?fields=, a comma-separated list
+// of names from allowed. It returns nil when the parameter is absent, and
+// writes a 400 response and returns false when it names unknown fields.
+func parseFields(c *gin.Context, allowed []string) ([]string, bool) {
+	param := c.Query("fields")
+	if param == "" {
+		return nil, true
+	}
+
+	known := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		known[name] = true
+	}
+
+	var fields, invalid []string
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			invalid = append(invalid, name)
+			continue
+		}
+		fields = append(fields, name)
+	}
+	if len(invalid) > 0 || len(fields) == 0 {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid fields parameter", gin.H{
+			"invalid": invalid,
+			"allowed": allowed,
+		})
+		return nil, false
+	}
+	return fields, true
+}
+
+// projectFields returns the JSON object v reduced to fields. Requested fields
+// that v omits, such as empty omitempty fields, stay absent.
+func projectFields(v interface{}, fields []string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, name := range fields {
+		if value, ok := all[name]; ok {
+			projected[name] = value
+		}
+	}
+	return projected, nil
+}
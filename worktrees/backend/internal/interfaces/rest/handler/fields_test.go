@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+)
+
+// listAndGetRepo serves List and GetByID from one stored object type
+type listAndGetRepo struct {
+	storedObjectTypeRepo
+}
+
+func (r *listAndGetRepo) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
+	return []*entity.ObjectType{r.objectType}, nil
+}
+
+// fieldsGet runs GET path against a router serving List and Get of the
+// object type in repo
+func fieldsGet(repo *listAndGetRepo, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewObjectTypeService(repo, nil, invalidatingCache{}, service.DefaultCacheTTLs(), nil,
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+	router := gin.New()
+	router.GET("/api/v1/object-types", h.List)
+	router.GET("/api/v1/object-types/:id", h.Get)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+// keysOf returns the sorted keys of the JSON object data
+func keysOf(t *testing.T, data []byte) []string {
+	t.Helper()
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		t.Fatalf("decode object: %v", err)
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fieldsRepo returns a repository holding objectTypeWithDeprecatedFax
+func fieldsRepo() *listAndGetRepo {
+	return &listAndGetRepo{storedObjectTypeRepo{objectType: objectTypeWithDeprecatedFax()}}
+}
+
+func TestGetWithFieldsReturnsOnlyThoseFields(t *testing.T) {
+	// Arrange
+	repo := fieldsRepo()
+
+	// Act
+	w := fieldsGet(repo, "/api/v1/object-types/"+repo.objectType.ID.String()+"?fields=id,name,displayName")
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+	if keys := keysOf(t, w.Body.Bytes()); !slices.Equal(keys, []string{"displayName", "id", "name"}) {
+		t.Errorf("fields = %v, want [displayName id name]", keys)
+	}
+}
+
+func TestListWithFieldsReturnsOnlyThoseFields(t *testing.T) {
+	// Arrange
+	repo := fieldsRepo()
+
+	// Act
+	w := fieldsGet(repo, "/api/v1/object-types?fields=id,%20name,displayName")
+
+	// Assert
+	var resp listResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("status = %d, %v, want a 200 list", w.Code, err)
+	}
+	if len(resp.Data) != 1 {
+		t.Fatalf("data = %d items, want 1", len(resp.Data))
+	}
+	if keys := keysOf(t, resp.Data[0]); !slices.Equal(keys, []string{"displayName", "id", "name"}) {
+		t.Errorf("fields = %v, want [displayName id name]", keys)
+	}
+}
+
+func TestWithoutFieldsReturnsTheWholeObjectType(t *testing.T) {
+	// Arrange
+	repo := fieldsRepo()
+
+	// Act
+	w := fieldsGet(repo, "/api/v1/object-types/"+repo.objectType.ID.String())
+
+	// Assert
+	keys := keysOf(t, w.Body.Bytes())
+	for _, want := range []string{"id", "name", "properties", "metadata", "version"} {
+		if !slices.Contains(keys, want) {
+			t.Errorf("fields = %v, missing %s", keys, want)
+		}
+	}
+}
+
+func TestInvalidFieldsAreRejected(t *testing.T) {
+	repo := fieldsRepo()
+	get := "/api/v1/object-types/" + repo.objectType.ID.String()
+	cases := []struct {
+		name    string
+		path    string
+		invalid []string
+	}{
+		{"get unknown", get + "?fields=id,secret", []string{"secret"}},
+		{"list unknown", "/api/v1/object-types?fields=name,secret,other", []string{"secret", "other"}},
+		{"hidden field", get + "?fields=IsDeleted", []string{"IsDeleted"}},
+		{"wrong case", get + "?fields=Name", []string{"Name"}},
+		{"groups ungrouped", get + "?fields=propertyGroups", []string{"propertyGroups"}},
+		{"groups on list", "/api/v1/object-types?grouped=true&fields=propertyGroups", []string{"propertyGroups"}},
+		{"only commas", get + "?fields=,,", nil},
+	}
+
+	for _, tc := range cases {
+		// Act
+		w := fieldsGet(repo, tc.path)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", tc.name, w.Code)
+			continue
+		}
+		var resp struct {
+			apierror.Response
+			Details struct {
+				Invalid []string `json:"invalid"`
+				Allowed []string `json:"allowed"`
+			} `json:"details"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: decode error: %v", tc.name, err)
+		}
+		if resp.Code != apierror.CodeInvalidRequest || !slices.Equal(resp.Details.Invalid, tc.invalid) {
+			t.Errorf("%s: error %s naming %v, want %s naming %v",
+				tc.name, resp.Code, resp.Details.Invalid, apierror.CodeInvalidRequest, tc.invalid)
+		}
+		if !slices.Contains(resp.Details.Allowed, "displayName") {
+			t.Errorf("%s: allowed = %v, want the object type fields", tc.name, resp.Details.Allowed)
+		}
+	}
+}
+
+func TestGroupedGetAcceptsPropertyGroupsField(t *testing.T) {
+	// Arrange
+	repo := fieldsRepo()
+
+	// Act
+	w := fieldsGet(repo, "/api/v1/object-types/"+repo.objectType.ID.String()+"?grouped=true&fields=name,propertyGroups")
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+	if keys := keysOf(t, w.Body.Bytes()); !slices.Equal(keys, []string{"name", "propertyGroups"}) {
+		t.Errorf("fields = %v, want [name propertyGroups]", keys)
+	}
+}
+
+func TestRequestedEmptyOptionalFieldsStayAbsent(t *testing.T) {
+	// Arrange
+	repo := fieldsRepo()
+
+	// Act
+	w := fieldsGet(repo, "/api/v1/object-types/"+repo.objectType.ID.String()+"?fields=name,description,parentId")
+
+	// Assert
+	if keys := keysOf(t, w.Body.Bytes()); !slices.Equal(keys, []string{"name"}) {
+		t.Errorf("fields = %v, want only name since description and parentId are unset", keys)
+	}
+}
+
+func TestObjectTypeFieldsAreTheJSONNames(t *testing.T) {
+	// Act
+	names := jsonFieldNames(reflect.TypeOf(entity.ObjectType{}))
+
+	// Assert
+	for _, want := range []string{"id", "tenantId", "displayName", "resolvedProperties"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("field names = %v, missing %s", names, want)
+		}
+	}
+	for _, unwanted := range []string{"IsDeleted", "-", "ID"} {
+		if slices.Contains(names, unwanted) {
+			t.Errorf("field names = %v, include %s", names, unwanted)
+		}
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("field names = %v, want them sorted", names)
+	}
+}
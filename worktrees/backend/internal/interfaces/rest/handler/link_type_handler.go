@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
+	"github.com/openfoundry/oms/internal/pkg/validator"
+	"go.uber.org/zap"
+)
+
+// LinkTypeHandler handles link type related requests
+type LinkTypeHandler struct {
+	service *service.LinkTypeService
+	// cursors encodes the page cursors of list responses
+	cursors *repository.CursorCodec
+	logger  *zap.Logger
+}
+
+// NewLinkTypeHandler creates a new link type handler
+func NewLinkTypeHandler(service *service.LinkTypeService, cursors *repository.CursorCodec, logger *zap.Logger) *LinkTypeHandler {
+	return &LinkTypeHandler{
+		service: service,
+		cursors: cursors,
+		logger:  logger,
+	}
+}
+
+// List handles GET /api/v1/link-types
+func (h *LinkTypeHandler) List(c *gin.Context) {
+	// Parse query parameters
+	filter, ok := parseLinkTypeFilter(c)
+	if !ok {
+		return
+	}
+	filter.PageSize = 20 // Default page size
+
+	// Parse pagination
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 && pageSize <= 100 {
+			filter.PageSize = pageSize
+		}
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		filter.PageCursor = cursor
+	}
+
+	// Get link types, fetching one past the page to learn whether more follow
+	query := filter
+	query.PageSize++
+	linkTypes, err := h.service.List(c.Request.Context(), query)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to retrieve link types")
+		return
+	}
+	hasMore := len(linkTypes) > filter.PageSize
+	if hasMore {
+		linkTypes = linkTypes[:filter.PageSize]
+	}
+
+	// Generate next cursor if needed
+	var nextCursor string
+	if hasMore {
+		lastItem := linkTypes[len(linkTypes)-1]
+		nextCursor = h.cursors.Encode(repository.NewLinkTypeCursor(lastItem))
+	}
+
+	pagination := gin.H{
+		"next_cursor": nextCursor,
+		"page_size":   filter.PageSize,
+		"has_more":    hasMore,
+	}
+
+	// The total costs a second query, so it is only counted on request
+	if c.Query("include_total") == "true" {
+		total, err := h.service.Count(c.Request.Context(), filter)
+		if err != nil {
+			apierror.Respond(c, h.logger, err, "Failed to count link types")
+			return
+		}
+		pagination["total_count"] = total
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       linkTypes,
+		"pagination": pagination,
+	})
+}
+
+// Count handles GET /api/v1/link-types/count
+func (h *LinkTypeHandler) Count(c *gin.Context) {
+	filter, ok := parseLinkTypeFilter(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.service.Count(c.Request.Context(), filter)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to count link types")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count": count,
+	})
+}
+
+// Create handles POST /api/v1/link-types
+func (h *LinkTypeHandler) Create(c *gin.Context) {
+	var input service.CreateLinkTypeInput
+
+	// Bind and validate input
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	// Additional validation
+	if err := validator.ValidateObjectTypeName(input.Name); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid link type name", err.Error())
+		return
+	}
+	if input.InverseName != "" {
+		if err := validator.ValidateObjectTypeName(input.InverseName); err != nil {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid inverse link type name", err.Error())
+			return
+		}
+	}
+
+	// Sanitize input to prevent XSS
+	input.Name = validator.SanitizeString(input.Name)
+	input.DisplayName = validator.SanitizeString(input.DisplayName)
+	input.InverseName = validator.SanitizeString(input.InverseName)
+	input.InverseDisplayName = validator.SanitizeString(input.InverseDisplayName)
+	if input.Description != nil {
+		sanitized := validator.SanitizeString(*input.Description)
+		input.Description = &sanitized
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	// Create link type
+	linkType, err := h.service.CreateLinkType(c.Request.Context(), input, userID)
+	if err != nil {
+		// A missing endpoint is a problem with the request, not a missing resource
+		if errors.Is(err, entity.ErrObjectTypeNotFound) {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeReferencedTypeMissing, "Referenced object type not found", err.Error())
+			return
+		}
+		apierror.Respond(c, h.logger, err, "Failed to create link type",
+			zap.String("user_id", userID),
+			zap.String("name", input.Name))
+		return
+	}
+
+	c.JSON(http.StatusCreated, linkType)
+}
+
+// Get handles GET /api/v1/link-types/:id
+func (h *LinkTypeHandler) Get(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid link type ID", nil)
+		return
+	}
+
+	// Get link type
+	linkType, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to retrieve link type",
+			zap.String("id", id.String()))
+		return
+	}
+
+	etag := representationETag(linkType.ID, linkType.Version, representationOptions{})
+	c.Header("ETag", etag)
+	if matchesETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, linkType)
+}
+
+// Graph handles GET /api/v1/object-types/:id/graph
+func (h *LinkTypeHandler) Graph(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	depth := service.DefaultTraversalDepth
+	if depthStr := c.Query("depth"); depthStr != "" {
+		depth, err = strconv.Atoi(depthStr)
+		if err != nil || depth < 1 {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid depth", nil)
+			return
+		}
+	}
+
+	graph, err := h.service.TraverseLinks(c.Request.Context(), id, depth, c.Query("direction"))
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to traverse link graph",
+			zap.String("id", id.String()))
+		return
+	}
+
+	c.JSON(http.StatusOK, graph)
+}
+
+// Update handles PUT /api/v1/link-types/:id
+func (h *LinkTypeHandler) Update(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid link type ID", nil)
+		return
+	}
+
+	var input service.UpdateLinkTypeInput
+
+	// Bind and validate input
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	// Sanitize input to prevent XSS
+	if input.DisplayName != nil {
+		sanitized := validator.SanitizeString(*input.DisplayName)
+		input.DisplayName = &sanitized
+	}
+	if input.Description != nil {
+		sanitized := validator.SanitizeString(*input.Description)
+		input.Description = &sanitized
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	// Cardinality may only be tightened when forced
+	if c.Query("force") == "true" {
+		input.Force = true
+	}
+
+	// Update link type
+	linkType, err := h.service.UpdateLinkType(c.Request.Context(), id, input, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to update link type",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, linkType)
+}
+
+// Delete handles DELETE /api/v1/link-types/:id. With ?delete_pair=true the
+// inverse of a paired link type is deleted too.
+func (h *LinkTypeHandler) Delete(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid link type ID", nil)
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	// Delete link type, and its inverse pair when requested
+	deletePair := c.Query("delete_pair") == "true"
+	err = h.service.DeleteLinkType(c.Request.Context(), id, userID, deletePair)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to delete link type",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Search handles GET /api/v1/link-types/search
+func (h *LinkTypeHandler) Search(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Search query is required", nil)
+		return
+	}
+
+	// Sanitize query
+	query = validator.SanitizeString(query)
+
+	// Parse limit
+	limit := service.DefaultSearchLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= service.MaxSearchLimit {
+			limit = l
+		}
+	}
+
+	// Search link types
+	results, err := h.service.SearchLinkTypes(c.Request.Context(), query, limit)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Search failed", zap.String("query", query))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":   query,
+		"results": results,
+		"count":   len(results),
+	})
+}
+
+// parseLinkTypeFilter parses the object type and cardinality filters shared
+// by List and Count. It writes a 400 response and returns false when a
+// parameter is invalid.
+func parseLinkTypeFilter(c *gin.Context) (repository.LinkTypeFilter, bool) {
+	var filter repository.LinkTypeFilter
+
+	// Parse object type filters
+	if sourceStr := c.Query("source_object_type_id"); sourceStr != "" {
+		sourceID, err := uuid.Parse(sourceStr)
+		if err != nil {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid source object type ID", nil)
+			return filter, false
+		}
+		filter.SourceObjectTypeID = &sourceID
+	}
+
+	if targetStr := c.Query("target_object_type_id"); targetStr != "" {
+		targetID, err := uuid.Parse(targetStr)
+		if err != nil {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid target object type ID", nil)
+			return filter, false
+		}
+		filter.TargetObjectTypeID = &targetID
+	}
+
+	// Parse cardinality filter
+	if cardinalityStr := c.Query("cardinality"); cardinalityStr != "" {
+		cardinality := entity.Cardinality(cardinalityStr)
+		if !cardinality.IsValid() {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid cardinality", nil)
+			return filter, false
+		}
+		filter.Cardinality = &cardinality
+	}
+
+	return filter, true
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
+)
+
+// missCache misses on every read and discards writes
+type missCache struct {
+	cache.CacheService
+}
+
+func (missCache) Get(ctx context.Context, key string, dest interface{}) error {
+	return errors.New("cache miss")
+}
+
+func (missCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return nil
+}
+
+// failingLinkTypeRepo answers GetByID with err
+type failingLinkTypeRepo struct {
+	repository.LinkTypeRepository
+	err error
+}
+
+func (r *failingLinkTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.LinkType, error) {
+	return nil, r.err
+}
+
+// getLinkType runs GET /api/v1/link-types/:id for a random UUID against a
+// handler whose repository fails with repoErr, returning the status code
+func getLinkType(t *testing.T, repoErr error) int {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := service.NewLinkTypeService(&failingLinkTypeRepo{err: repoErr}, nil, missCache{}, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	h := NewLinkTypeHandler(svc, testCursors, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	id := uuid.New()
+	c.Params = gin.Params{{Key: "id", Value: id.String()}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/link-types/"+id.String(), nil)
+	h.Get(c)
+	return w.Code
+}
+
+func TestGetLinkTypeUnknownIDIsNotFound(t *testing.T) {
+	// Act
+	status := getLinkType(t, entity.ErrLinkTypeNotFound)
+
+	// Assert
+	if status != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestGetLinkTypeWrappedRepositoryNotFoundIsNotFound(t *testing.T) {
+	// Act
+	status := getLinkType(t, fmt.Errorf("link type lookup: %w", repository.ErrNotFound))
+
+	// Assert
+	if status != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestGetLinkTypeOtherFailureIsInternalError(t *testing.T) {
+	// Act
+	status := getLinkType(t, errors.New("connection reset"))
+
+	// Assert
+	if status != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", status, http.StatusInternalServerError)
+	}
+}
+
+// countingLinkTypeRepo lists and counts its link types matching a filter's
+// cardinality
+type countingLinkTypeRepo struct {
+	repository.LinkTypeRepository
+	linkTypes []*entity.LinkType
+}
+
+func (r *countingLinkTypeRepo) Count(ctx context.Context, filter repository.LinkTypeFilter) (int64, error) {
+	return int64(len(r.matching(filter))), nil
+}
+
+// List returns the first page of the matching link types
+func (r *countingLinkTypeRepo) List(ctx context.Context, filter repository.LinkTypeFilter) ([]*entity.LinkType, error) {
+	matched := r.matching(filter)
+	if len(matched) > filter.PageSize {
+		matched = matched[:filter.PageSize]
+	}
+	return matched, nil
+}
+
+// matching returns the link types matching filter
+func (r *countingLinkTypeRepo) matching(filter repository.LinkTypeFilter) []*entity.LinkType {
+	var matched []*entity.LinkType
+	for _, linkType := range r.linkTypes {
+		if filter.Cardinality == nil || linkType.Cardinality == *filter.Cardinality {
+			matched = append(matched, linkType)
+		}
+	}
+	return matched
+}
+
+// countedLinkTypes returns a repository of one ONE_TO_MANY and two
+// MANY_TO_MANY link types
+func countedLinkTypes() *countingLinkTypeRepo {
+	return &countingLinkTypeRepo{linkTypes: []*entity.LinkType{
+		{ID: uuid.New(), Cardinality: entity.CardinalityOneToMany},
+		{ID: uuid.New(), Cardinality: entity.CardinalityManyToMany},
+		{ID: uuid.New(), Cardinality: entity.CardinalityManyToMany},
+	}}
+}
+
+// countLinkTypes runs GET /api/v1/link-types/count?query against a handler
+// over countedLinkTypes
+func countLinkTypes(t *testing.T, query string) (int, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	repo := countedLinkTypes()
+	svc := service.NewLinkTypeService(repo, nil, missCache{}, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	h := NewLinkTypeHandler(svc, testCursors, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/link-types/count?"+query, nil)
+	h.Count(c)
+	return w.Code, w.Body.String()
+}
+
+func TestCountLinkTypesRespectsCardinalityFilter(t *testing.T) {
+	// Act
+	status, body := countLinkTypes(t, "cardinality=MANY_TO_MANY")
+
+	// Assert
+	if status != http.StatusOK || body != `{"count":2}` {
+		t.Errorf("response = %d %s, want 200 {\"count\":2}", status, body)
+	}
+}
+
+func TestCountLinkTypesRejectsInvalidFilter(t *testing.T) {
+	// Act
+	status, _ := countLinkTypes(t, "cardinality=SOME")
+
+	// Assert
+	if status != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+// listLinkTypes runs GET /api/v1/link-types?query against a handler over
+// countedLinkTypes and returns the decoded response
+func listLinkTypes(t *testing.T, query string) listResponse {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	svc := service.NewLinkTypeService(countedLinkTypes(), nil, missCache{}, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	h := NewLinkTypeHandler(svc, testCursors, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/link-types?"+query, nil)
+	h.List(c)
+
+	var resp listResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response (status %d): %v", w.Code, err)
+	}
+	return resp
+}
+
+func TestListLinkTypesIncludesTotalMatchingFilterOnRequest(t *testing.T) {
+	cases := []struct {
+		query string
+		total int64
+	}{
+		{"", 3},
+		{"cardinality=MANY_TO_MANY", 2},
+		{"cardinality=ONE_TO_MANY", 1},
+	}
+
+	for _, tc := range cases {
+		// Act
+		resp := listLinkTypes(t, "page_size=1&include_total=true&"+tc.query)
+
+		// Assert
+		if total := resp.Pagination.TotalCount; total == nil || *total != tc.total {
+			t.Errorf("%q: total_count = %v, want %d", tc.query, total, tc.total)
+		}
+		if resp.Pagination.HasMore != (tc.total > 1) {
+			t.Errorf("%q: has_more = %v, want %v", tc.query, resp.Pagination.HasMore, tc.total > 1)
+		}
+	}
+}
+
+func TestListLinkTypesOmitsTotalByDefault(t *testing.T) {
+	// Act
+	resp := listLinkTypes(t, "page_size=1")
+
+	// Assert
+	if resp.Pagination.TotalCount != nil {
+		t.Errorf("total_count = %v, want none", *resp.Pagination.TotalCount)
+	}
+}
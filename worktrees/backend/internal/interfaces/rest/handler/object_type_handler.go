@@ -1,10 +1,17 @@
 package handler
 
 import (
-	"encoding/base64"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +19,7 @@ import (
 	"github.com/openfoundry/oms/internal/domain/entity"
 	"github.com/openfoundry/oms/internal/domain/repository"
 	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
 	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
 	"github.com/openfoundry/oms/internal/pkg/validator"
 	"go.uber.org/zap"
@@ -20,13 +28,16 @@ import (
 // ObjectTypeHandler handles object type related requests
 type ObjectTypeHandler struct {
 	service *service.ObjectTypeService
+	// cursors encodes the page cursors of list responses
+	cursors *repository.CursorCodec
 	logger  *zap.Logger
 }
 
 // NewObjectTypeHandler creates a new object type handler
-func NewObjectTypeHandler(service *service.ObjectTypeService, logger *zap.Logger) *ObjectTypeHandler {
+func NewObjectTypeHandler(service *service.ObjectTypeService, cursors *repository.CursorCodec, logger *zap.Logger) *ObjectTypeHandler {
 	return &ObjectTypeHandler{
 		service: service,
+		cursors: cursors,
 		logger:  logger,
 	}
 }
@@ -34,19 +45,15 @@ func NewObjectTypeHandler(service *service.ObjectTypeService, logger *zap.Logger
 // List handles GET /api/v1/object-types
 func (h *ObjectTypeHandler) List(c *gin.Context) {
 	// Parse query parameters
-	filter := repository.ObjectTypeFilter{
-		PageSize: 20, // Default page size
-	}
-
-	// Parse category filter
-	if category := c.Query("category"); category != "" {
-		filter.Category = &category
+	filter, ok := parseObjectTypeFilter(c)
+	if !ok {
+		return
 	}
-
-	// Parse tags filter
-	if tags := c.QueryArray("tags"); len(tags) > 0 {
-		filter.Tags = tags
+	fields, ok := parseFields(c, objectTypeFields)
+	if !ok {
+		return
 	}
+	filter.PageSize = 20 // Default page size
 
 	// Parse pagination
 	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
@@ -58,60 +65,112 @@ func (h *ObjectTypeHandler) List(c *gin.Context) {
 	if cursor := c.Query("cursor"); cursor != "" {
 		filter.PageCursor = cursor
 	}
+	if before := c.Query("before"); before != "" {
+		filter.Before = before
+	}
 
 	// Parse sort
-	if sortBy := c.Query("sort_by"); sortBy != "" {
-		filter.SortBy = sortBy
+	sortBy, err := validator.ValidateSortBy(c.Query("sort_by"), repository.ObjectTypeSortFields)
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid sort parameters", err.Error())
+		return
 	}
-	if sortOrder := c.Query("sort_order"); sortOrder != "" {
-		filter.SortOrder = sortOrder
+	sortOrder, err := validator.ValidateSortOrder(c.Query("sort_order"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid sort parameters", err.Error())
+		return
 	}
+	filter.SortBy = sortBy
+	filter.SortOrder = sortOrder
 
-	// Get object types
-	objectTypes, err := h.service.List(c.Request.Context(), filter)
+	// Get object types, fetching one past the page to learn whether more
+	// follow in the direction of travel
+	query := filter
+	query.PageSize++
+	objectTypes, err := h.service.List(c.Request.Context(), query)
 	if err != nil {
-		h.logger.Error("Failed to list object types", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve object types",
-		})
+		apierror.Respond(c, h.logger, err, "Failed to retrieve object types")
 		return
 	}
+	extra := len(objectTypes) > filter.PageSize
 
-	// Generate next cursor if needed
-	var nextCursor string
-	if len(objectTypes) == filter.PageSize {
-		lastItem := objectTypes[len(objectTypes)-1]
-		nextCursor = encodeCursor(lastItem.CreatedAt, lastItem.ID)
+	// The extra item shows more follow in the direction of travel; having
+	// come from a cursor shows items exist on the other side
+	var hasNext, hasPrevious bool
+	if filter.Before != "" {
+		hasNext, hasPrevious = true, extra
+		if extra {
+			// Backward pages are reversed, so the extra item comes first
+			objectTypes = objectTypes[1:]
+		}
+	} else {
+		hasNext, hasPrevious = extra, filter.PageCursor != ""
+		if extra {
+			objectTypes = objectTypes[:filter.PageSize]
+		}
+	}
+
+	// Generate cursors if needed
+	var nextCursor, prevCursor string
+	if len(objectTypes) > 0 {
+		if hasNext {
+			nextCursor = h.encodeCursor(objectTypes[len(objectTypes)-1], filter.SortBy)
+		}
+		if hasPrevious {
+			prevCursor = h.encodeCursor(objectTypes[0], filter.SortBy)
+		}
+	}
+
+	pagination := gin.H{
+		"next_cursor":  nextCursor,
+		"prev_cursor":  prevCursor,
+		"page_size":    filter.PageSize,
+		"has_more":     hasNext,
+		"has_previous": hasPrevious,
+	}
+
+	// The total costs a second query, so it is only counted on request
+	if c.Query("include_total") == "true" {
+		total, err := h.service.Count(c.Request.Context(), filter)
+		if err != nil {
+			apierror.Respond(c, h.logger, err, "Failed to count object types")
+			return
+		}
+		pagination["total_count"] = total
+	}
+
+	var data interface{} = objectTypes
+	if fields != nil {
+		projected := make([]map[string]json.RawMessage, len(objectTypes))
+		for i, objectType := range objectTypes {
+			if projected[i], err = projectFields(objectType, fields); err != nil {
+				apierror.Respond(c, h.logger, err, "Failed to encode object types")
+				return
+			}
+		}
+		data = projected
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": objectTypes,
-		"pagination": gin.H{
-			"next_cursor": nextCursor,
-			"page_size":   filter.PageSize,
-		},
+		"data":       data,
+		"pagination": pagination,
 	})
 }
 
-// Create handles POST /api/v1/object-types
+// Create handles POST /api/v1/object-types. With ?dry_run=true the request is
+// checked and the object type that would be created is returned unsaved.
 func (h *ObjectTypeHandler) Create(c *gin.Context) {
 	var input service.CreateObjectTypeInput
 
 	// Bind and validate input
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-			"details": err.Error(),
-		})
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
 		return
 	}
 
 	// Additional validation
 	if err := validator.ValidateObjectTypeName(input.Name); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid object type name",
-			"details": err.Error(),
-		})
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type name", err.Error())
 		return
 	}
 
@@ -126,78 +185,335 @@ func (h *ObjectTypeHandler) Create(c *gin.Context) {
 	// Get user ID from context
 	userID := middleware.GetUserID(c)
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	// A dry run reports what would be created without saving it
+	if c.Query("dry_run") == "true" {
+		result, err := h.service.DryRunCreate(c.Request.Context(), input, userID)
+		if err != nil {
+			apierror.Respond(c, h.logger, err, "Failed to check object type creation",
+				zap.String("user_id", userID),
+				zap.String("name", input.Name))
+			return
+		}
+		c.JSON(http.StatusOK, result)
 		return
 	}
 
 	// Create object type
 	objectType, err := h.service.CreateObjectType(c.Request.Context(), input, userID)
 	if err != nil {
-		h.logger.Error("Failed to create object type", 
+		apierror.Respond(c, h.logger, err, "Failed to create object type",
 			zap.String("user_id", userID),
-			zap.String("name", input.Name),
-			zap.Error(err))
-
-		// Handle specific errors
-		switch err {
-		case entity.ErrObjectTypeNameExists:
-			c.JSON(http.StatusConflict, gin.H{
-				"error": "Object type name already exists",
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to create object type",
-			})
-		}
+			zap.String("name", input.Name))
 		return
 	}
 
 	c.JSON(http.StatusCreated, objectType)
 }
 
-// Get handles GET /api/v1/object-types/:id
+// BatchCreateRequest is the body of a batch object type creation
+type BatchCreateRequest struct {
+	Items []service.CreateObjectTypeInput `json:"items"`
+}
+
+// BatchCreate handles POST /api/v1/object-types/batch
+func (h *ObjectTypeHandler) BatchCreate(c *gin.Context) {
+	var request BatchCreateRequest
+
+	// Bind and validate input
+	if err := c.ShouldBindJSON(&request); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	// Sanitize input to prevent XSS
+	for i := range request.Items {
+		item := &request.Items[i]
+		item.Name = validator.SanitizeString(item.Name)
+		item.DisplayName = validator.SanitizeString(item.DisplayName)
+		if item.Description != nil {
+			sanitized := validator.SanitizeString(*item.Description)
+			item.Description = &sanitized
+		}
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	// Create object types
+	results, err := h.service.BatchCreateObjectTypes(c.Request.Context(), request.Items, userID)
+	if err != nil {
+		// The per-item results explain why the batch was rejected
+		if errors.Is(err, entity.ErrBatchFailed) {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeBatchFailed,
+				"Batch rejected, no object types were created", results)
+			return
+		}
+
+		apierror.Respond(c, h.logger, err, "Failed to create object types",
+			zap.String("user_id", userID),
+			zap.Int("count", len(request.Items)))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"results": results,
+		"count":   len(results),
+	})
+}
+
+// BatchDeleteRequest is the body of a batch object type deletion
+type BatchDeleteRequest struct {
+	IDs             []uuid.UUID `json:"ids"`
+	ContinueOnError bool        `json:"continueOnError"`
+}
+
+// BatchDelete handles POST /api/v1/object-types/batch-delete
+func (h *ObjectTypeHandler) BatchDelete(c *gin.Context) {
+	var request BatchDeleteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	results, err := h.service.BatchDeleteObjectTypes(c.Request.Context(), request.IDs, userID, request.ContinueOnError)
+	if err != nil {
+		// The per-item results explain why the batch was rejected
+		if errors.Is(err, entity.ErrBatchFailed) {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeBatchFailed,
+				"Batch rejected, no object types were deleted", results)
+			return
+		}
+
+		apierror.Respond(c, h.logger, err, "Failed to delete object types",
+			zap.String("user_id", userID),
+			zap.Int("count", len(request.IDs)))
+		return
+	}
+
+	deleted := 0
+	for _, result := range results {
+		if result.Error == "" {
+			deleted++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"deleted": deleted,
+		"failed":  len(results) - deleted,
+	})
+}
+
+// BatchGetRequest is the body of a batch object type lookup
+type BatchGetRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// BatchGet handles POST /api/v1/object-types/batch-get. Object types are
+// returned in request order; IDs not found are listed under missing.
+func (h *ObjectTypeHandler) BatchGet(c *gin.Context) {
+	var request BatchGetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if len(request.IDs) == 0 || len(request.IDs) > service.MaxBatchSize {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest,
+			fmt.Sprintf("ids must contain between 1 and %d items", service.MaxBatchSize), nil)
+		return
+	}
+
+	objectTypes, err := h.service.GetByIDs(c.Request.Context(), request.IDs)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to retrieve object types",
+			zap.Int("count", len(request.IDs)))
+		return
+	}
+
+	found := make(map[uuid.UUID]bool, len(objectTypes))
+	for _, objectType := range objectTypes {
+		found[objectType.ID] = true
+	}
+	missing := []uuid.UUID{}
+	for _, id := range request.IDs {
+		if !found[id] {
+			found[id] = true
+			missing = append(missing, id)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    objectTypes,
+		"missing": missing,
+	})
+}
+
+// Get handles GET /api/v1/object-types/:id. Deprecated properties are
+// included unless ?include_deprecated=false.
 func (h *ObjectTypeHandler) Get(c *gin.Context) {
 	// Parse ID
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid object type ID",
-		})
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	grouped := c.Query("grouped") == "true"
+	allowedFields := objectTypeFields
+	if grouped {
+		allowedFields = append([]string{"propertyGroups"}, objectTypeFields...)
+	}
+	fields, ok := parseFields(c, allowedFields)
+	if !ok {
 		return
 	}
 
-	// Get object type
-	objectType, err := h.service.GetByID(c.Request.Context(), id)
+	// Get object type, optionally merging inherited properties
+	var objectType *entity.ObjectType
+	if c.Query("resolve_inherited") == "true" {
+		objectType, err = h.service.GetByIDResolved(c.Request.Context(), id)
+	} else {
+		objectType, err = h.service.GetByID(c.Request.Context(), id)
+	}
 	if err != nil {
-		if err == entity.ErrObjectTypeNotFound {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Object type not found",
-			})
+		apierror.Respond(c, h.logger, err, "Failed to retrieve object type",
+			zap.String("id", id.String()))
+		return
+	}
+
+	// Filter a copy; the object type may be shared with the cache
+	if c.Query("include_deprecated") == "false" {
+		filtered := *objectType
+		filtered.Properties = entity.WithoutDeprecated(objectType.Properties)
+		if objectType.ResolvedProperties != nil {
+			filtered.ResolvedProperties = entity.WithoutDeprecated(objectType.ResolvedProperties)
+		}
+		objectType = &filtered
+	}
+
+	etag := representationETag(objectType.ID, objectType.Version, representationOptions{
+		Fields:            fields,
+		Grouped:           grouped,
+		ExcludeDeprecated: c.Query("include_deprecated") == "false",
+		ResolveInherited:  c.Query("resolve_inherited") == "true",
+	})
+	c.Header("ETag", etag)
+	// Resolved properties also change with the ancestors, which the version
+	// does not track, so they are always sent in full
+	if c.Query("resolve_inherited") != "true" && matchesETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	var response interface{} = objectType
+	if grouped {
+		properties := objectType.ResolvedProperties
+		if properties == nil {
+			properties = objectType.Properties
+		}
+		response = groupedObjectType{
+			ObjectType:     objectType,
+			PropertyGroups: entity.GroupProperties(properties),
+		}
+	}
+	if fields != nil {
+		projected, err := projectFields(response, fields)
+		if err != nil {
+			apierror.Respond(c, h.logger, err, "Failed to encode object type",
+				zap.String("id", id.String()))
 			return
 		}
+		response = projected
+	}
+	c.JSON(http.StatusOK, response)
+}
 
-		h.logger.Error("Failed to get object type", 
-			zap.String("id", id.String()),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve object type",
-		})
+// groupedObjectType is an object type with its properties also split into
+// groups, returned for ?grouped=true
+type groupedObjectType struct {
+	*entity.ObjectType
+	PropertyGroups []entity.PropertyGroup `json:"propertyGroups"`
+}
+
+// Schema handles GET /api/v1/object-types/:id/schema.json
+func (h *ObjectTypeHandler) Schema(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, objectType)
+	schema, err := h.service.ExportJSONSchema(c.Request.Context(), id)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to export JSON schema",
+			zap.String("id", id.String()))
+		return
+	}
+
+	c.Data(http.StatusOK, "application/schema+json", schema)
+}
+
+// Defaults handles GET /api/v1/object-types/:id/defaults
+func (h *ObjectTypeHandler) Defaults(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	defaults, err := h.service.GetDefaults(c.Request.Context(), id)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to get defaults",
+			zap.String("id", id.String()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"defaults": defaults,
+	})
 }
 
-// Update handles PUT /api/v1/object-types/:id
+// OpenAPI handles GET /api/v1/object-types/:id/openapi.yaml
+func (h *ObjectTypeHandler) OpenAPI(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	document, err := h.service.ExportOpenAPI(c.Request.Context(), id)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to export OpenAPI document",
+			zap.String("id", id.String()))
+		return
+	}
+
+	c.Data(http.StatusOK, "application/yaml", document)
+}
+
+// Update handles PUT /api/v1/object-types/:id. With ?dry_run=true the update
+// is checked and returned unsaved, with the compatibility of its changes.
 func (h *ObjectTypeHandler) Update(c *gin.Context) {
 	// Parse ID
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid object type ID",
-		})
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
 		return
 	}
 
@@ -205,13 +521,20 @@ func (h *ObjectTypeHandler) Update(c *gin.Context) {
 
 	// Bind and validate input
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-			"details": err.Error(),
-		})
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
 		return
 	}
 
+	// An If-Match header takes precedence over expectedVersion in the body
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		version, err := parseIfMatch(ifMatch)
+		if err != nil {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid If-Match header", nil)
+			return
+		}
+		input.ExpectedVersion = version
+	}
+
 	// Sanitize input to prevent XSS
 	if input.DisplayName != nil {
 		sanitized := validator.SanitizeString(*input.DisplayName)
@@ -221,195 +544,822 @@ func (h *ObjectTypeHandler) Update(c *gin.Context) {
 		sanitized := validator.SanitizeString(*input.Description)
 		input.Description = &sanitized
 	}
+	if input.ChangeDescription != nil {
+		sanitized := validator.SanitizeString(*input.ChangeDescription)
+		input.ChangeDescription = &sanitized
+	}
 
 	// Get user ID from context
 	userID := middleware.GetUserID(c)
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
 		return
 	}
 
-	// Update object type
-	objectType, err := h.service.UpdateObjectType(c.Request.Context(), id, input, userID)
-	if err != nil {
-		if err == entity.ErrObjectTypeNotFound {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Object type not found",
-			})
+	// A dry run reports what would be saved without saving it
+	if c.Query("dry_run") == "true" {
+		result, err := h.service.DryRunUpdate(c.Request.Context(), id, input, userID)
+		if err != nil {
+			apierror.Respond(c, h.logger, err, "Failed to check object type update",
+				zap.String("id", id.String()),
+				zap.String("user_id", userID))
 			return
 		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
 
-		h.logger.Error("Failed to update object type", 
+	// Update object type
+	objectType, err := h.service.UpdateObjectType(c.Request.Context(), id, input, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to update object type",
 			zap.String("id", id.String()),
-			zap.String("user_id", userID),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to update object type",
-		})
+			zap.String("user_id", userID))
 		return
 	}
 
+	c.Header("ETag", representationETag(objectType.ID, objectType.Version, representationOptions{}))
 	c.JSON(http.StatusOK, objectType)
 }
 
-// Delete handles DELETE /api/v1/object-types/:id
-func (h *ObjectTypeHandler) Delete(c *gin.Context) {
-	// Parse ID
+// CheckCompatibility handles POST /api/v1/object-types/:id/check-compatibility.
+// The body is a full proposed definition; nothing is saved.
+func (h *ObjectTypeHandler) CheckCompatibility(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid object type ID",
-		})
-		return
-	}
-
-	// Get user ID from context
-	userID := middleware.GetUserID(c)
-	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
 		return
 	}
 
-	// Check if user has permission to delete
-	if !middleware.HasRole(c, "admin") {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Insufficient permissions",
-		})
+	var proposed entity.ObjectType
+	if err := c.ShouldBindJSON(&proposed); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
 		return
 	}
 
-	// Delete object type
-	err = h.service.DeleteObjectType(c.Request.Context(), id, userID)
+	report, err := h.service.CheckCompatibility(c.Request.Context(), id, &proposed)
 	if err != nil {
-		if err == entity.ErrObjectTypeNotFound {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Object type not found",
-			})
-			return
-		}
-
-		h.logger.Error("Failed to delete object type", 
-			zap.String("id", id.String()),
-			zap.String("user_id", userID),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to delete object type",
-		})
+		apierror.Respond(c, h.logger, err, "Failed to check compatibility",
+			zap.String("id", id.String()))
 		return
 	}
 
-	c.JSON(http.StatusNoContent, nil)
+	c.JSON(http.StatusOK, report)
 }
 
-// Search handles GET /api/v1/search
-func (h *ObjectTypeHandler) Search(c *gin.Context) {
-	query := c.Query("q")
-	if query == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Search query is required",
-		})
+// GetUsage handles GET /api/v1/object-types/:id/usage
+func (h *ObjectTypeHandler) GetUsage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
 		return
 	}
 
-	// Sanitize query
-	query = validator.SanitizeString(query)
-
-	// Parse limit
-	limit := 10
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
-			limit = l
-		}
-	}
-
-	// Search object types
-	results, err := h.service.Search(c.Request.Context(), query, limit)
+	report, err := h.service.GetUsage(c.Request.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to search object types", 
-			zap.String("query", query),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Search failed",
-		})
+		apierror.Respond(c, h.logger, err, "Failed to retrieve object type usage",
+			zap.String("id", id.String()))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"query": query,
-		"results": results,
-		"count": len(results),
-	})
+	c.JSON(http.StatusOK, report)
 }
 
-// CompareVersions handles GET /api/v1/object-types/:id/versions/compare
-func (h *ObjectTypeHandler) CompareVersions(c *gin.Context) {
-	// Parse ID
+// AddTagRequest is the body of a tag addition
+type AddTagRequest struct {
+	Tag string `json:"tag" binding:"required"`
+}
+
+// AddTag handles POST /api/v1/object-types/:id/tags
+func (h *ObjectTypeHandler) AddTag(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid object type ID",
-		})
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
 		return
 	}
 
-	// Parse version parameters
-	v1Str := c.Query("v1")
-	v2Str := c.Query("v2")
+	var req AddTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
 
-	if v1Str == "" || v2Str == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Both v1 and v2 version parameters are required",
-		})
+	h.changeTag(c, id, req.Tag, h.service.AddTag)
+}
+
+// RemoveTag handles DELETE /api/v1/object-types/:id/tags/:tag
+func (h *ObjectTypeHandler) RemoveTag(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
 		return
 	}
 
-	v1, err := strconv.Atoi(v1Str)
-	if err != nil || v1 < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid v1 version number",
-		})
+	h.changeTag(c, id, c.Param("tag"), h.service.RemoveTag)
+}
+
+// changeTag sanitizes tag and applies change, writing the response
+func (h *ObjectTypeHandler) changeTag(c *gin.Context, id uuid.UUID, tag string,
+	change func(ctx context.Context, id uuid.UUID, tag, userID string) (*entity.ObjectType, error)) {
+	tags := validator.SanitizeTags([]string{tag})
+	if len(tags) == 0 {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Tag must not be empty", nil)
 		return
 	}
 
-	v2, err := strconv.Atoi(v2Str)
-	if err != nil || v2 < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid v2 version number",
-		})
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
 		return
 	}
 
-	// Compare versions
-	diff, err := h.service.CompareVersions(c.Request.Context(), id, v1, v2)
+	objectType, err := change(c.Request.Context(), id, tags[0], userID)
 	if err != nil {
-		if err == entity.ErrObjectTypeNotFound {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Object type not found",
-			})
-			return
-		}
-
-		h.logger.Error("Failed to compare versions", 
+		apierror.Respond(c, h.logger, err, "Failed to change tags",
 			zap.String("id", id.String()),
-			zap.Int("v1", v1),
-			zap.Int("v2", v2),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to compare versions",
-		})
+			zap.String("tag", tags[0]),
+			zap.String("user_id", userID))
 		return
 	}
 
-	c.JSON(http.StatusOK, diff)
+	c.Header("ETag", representationETag(objectType.ID, objectType.Version, representationOptions{}))
+	c.JSON(http.StatusOK, objectType)
+}
+
+// Bulk tag actions
+const (
+	BulkTagActionAdd    = "add"
+	BulkTagActionRemove = "remove"
+)
+
+// BulkTagRequest is the body of a bulk tag change
+type BulkTagRequest struct {
+	IDs    []uuid.UUID `json:"ids" binding:"required"`
+	Tag    string      `json:"tag" binding:"required"`
+	Action string      `json:"action" binding:"required"`
+}
+
+// BulkTag handles POST /api/v1/object-types/tags/bulk
+func (h *ObjectTypeHandler) BulkTag(c *gin.Context) {
+	var req BulkTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	var change func(ctx context.Context, ids []uuid.UUID, tag, userID string) error
+	switch req.Action {
+	case BulkTagActionAdd:
+		change = h.service.BulkAddTag
+	case BulkTagActionRemove:
+		change = h.service.BulkRemoveTag
+	default:
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid action", "must be add or remove")
+		return
+	}
+
+	tags := validator.SanitizeTags([]string{req.Tag})
+	if len(tags) == 0 {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Tag must not be empty", nil)
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	if err := change(c.Request.Context(), req.IDs, tags[0], userID); err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to change tags",
+			zap.Int("count", len(req.IDs)),
+			zap.String("tag", tags[0]),
+			zap.String("action", req.Action),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// ReorderPropertiesRequest is the body of a property reorder
+type ReorderPropertiesRequest struct {
+	Properties []string `json:"properties" binding:"required"`
+}
+
+// ReorderProperties handles PATCH /api/v1/object-types/:id/properties/reorder
+func (h *ObjectTypeHandler) ReorderProperties(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	var req ReorderPropertiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	objectType, err := h.service.ReorderProperties(c.Request.Context(), id, req.Properties, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to reorder properties",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.Header("ETag", representationETag(objectType.ID, objectType.Version, representationOptions{}))
+	c.JSON(http.StatusOK, objectType)
+}
+
+// Delete handles DELETE /api/v1/object-types/:id
+func (h *ObjectTypeHandler) Delete(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	// Purge permanently removes an already soft-deleted object type. The
+	// router only guards objecttype:delete, so purging is checked here.
+	if c.Query("purge") == "true" {
+		if !middleware.HasPermission(c, middleware.PermObjectTypePurge) {
+			apierror.Write(c, http.StatusForbidden, apierror.CodeForbidden, "insufficient permissions",
+				"missing permission "+middleware.PermObjectTypePurge)
+			return
+		}
+		h.purge(c, id, userID)
+		return
+	}
+
+	// Delete object type, cascading to dependent link types only when forced
+	force := c.Query("force") == "true"
+	err = h.service.DeleteObjectType(c.Request.Context(), id, userID, force)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to delete object type",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// purge handles DELETE /api/v1/object-types/:id?purge=true
+func (h *ObjectTypeHandler) purge(c *gin.Context, id uuid.UUID, userID string) {
+	err := h.service.PurgeObjectType(c.Request.Context(), id, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to purge object type",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// ListDeleted handles GET /api/v1/object-types/deleted
+func (h *ObjectTypeHandler) ListDeleted(c *gin.Context) {
+	// Parse limit
+	limit := 50
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	objectTypes, err := h.service.ListDeleted(c.Request.Context(), limit)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to retrieve deleted object types")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  objectTypes,
+		"count": len(objectTypes),
+	})
+}
+
+// Search handles GET /api/v1/search
+func (h *ObjectTypeHandler) Search(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Search query is required", nil)
+		return
+	}
+
+	// Sanitize query
+	query = validator.SanitizeString(query)
+
+	// Parse limit
+	limit := service.DefaultSearchLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= service.MaxSearchLimit {
+			limit = l
+		}
+	}
+
+	// Parse search mode
+	mode, err := validator.ValidateSearchMode(c.Query("mode"), repository.SearchModes)
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid search mode", err.Error())
+		return
+	}
+
+	// Parse field filters
+	opts := repository.SearchOptions{Mode: mode}
+	if category := c.Query("category"); category != "" {
+		opts.Category = &category
+	}
+	if tags := c.QueryArray("tags"); len(tags) > 0 {
+		opts.Tags = tags
+	}
+	if createdAfterStr := c.Query("created_after"); createdAfterStr != "" {
+		createdAfter, err := time.Parse(time.RFC3339, createdAfterStr)
+		if err != nil {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid created_after, expected RFC3339 timestamp", nil)
+			return
+		}
+		opts.CreatedAfter = &createdAfter
+	}
+	opts.Cursor = c.Query("cursor")
+
+	// Search object types
+	page, err := h.service.Search(c.Request.Context(), query, limit, opts)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Search failed", zap.String("query", query))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query": query,
+		"results": page.Results,
+		"count": len(page.Results),
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// Suggest handles GET /api/v1/object-types/suggest
+func (h *ObjectTypeHandler) Suggest(c *gin.Context) {
+	prefix := validator.SanitizeString(c.Query("prefix"))
+
+	// Parse limit; the service caps it at MaxSuggestLimit
+	limit := 10
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	suggestions, err := h.service.SuggestNames(c.Request.Context(), prefix, limit)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to suggest object type names",
+			zap.String("prefix", prefix))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"prefix":      prefix,
+		"suggestions": suggestions,
+		"count":       len(suggestions),
+	})
+}
+
+// Count handles GET /api/v1/object-types/count
+func (h *ObjectTypeHandler) Count(c *gin.Context) {
+	filter, ok := parseObjectTypeFilter(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.service.Count(c.Request.Context(), filter)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to count object types")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count": count,
+	})
+}
+
+// Facets handles GET /api/v1/object-types/facets
+func (h *ObjectTypeHandler) Facets(c *gin.Context) {
+	categories, err := h.service.ListCategories(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to list facets")
+		return
+	}
+
+	tags, err := h.service.ListTags(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to list facets")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"categories": categories,
+		"tags":       tags,
+	})
+}
+
+// ListVersions handles GET /api/v1/object-types/:id/versions?page_size=20&cursor=42
+func (h *ObjectTypeHandler) ListVersions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	filter := repository.VersionFilter{
+		PageSize: repository.DefaultVersionPageSize,
+	}
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 && pageSize <= repository.MaxVersionPageSize {
+			filter.PageSize = pageSize
+		}
+	}
+
+	// The cursor is the oldest version of the previous page
+	if cursor := c.Query("cursor"); cursor != "" {
+		before, err := strconv.Atoi(cursor)
+		if err != nil || before < 1 {
+			apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid cursor", nil)
+			return
+		}
+		filter.BeforeVersion = before
+	}
+
+	versions, err := h.service.ListVersions(c.Request.Context(), id, filter)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to list versions",
+			zap.String("id", id.String()))
+		return
+	}
+
+	// A full page means older versions may follow
+	var nextCursor string
+	if len(versions) == filter.PageSize && versions[len(versions)-1].Version > 1 {
+		nextCursor = strconv.Itoa(versions[len(versions)-1].Version)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": versions,
+		"pagination": gin.H{
+			"next_cursor": nextCursor,
+			"page_size":   filter.PageSize,
+		},
+	})
+}
+
+// CompareVersions handles GET /api/v1/object-types/:id/versions/compare
+func (h *ObjectTypeHandler) CompareVersions(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	// Parse version parameters
+	v1Str := c.Query("v1")
+	v2Str := c.Query("v2")
+
+	if v1Str == "" || v2Str == "" {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Both v1 and v2 version parameters are required", nil)
+		return
+	}
+
+	v1, err := strconv.Atoi(v1Str)
+	if err != nil || v1 < 1 {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid v1 version number", nil)
+		return
+	}
+
+	v2, err := strconv.Atoi(v2Str)
+	if err != nil || v2 < 1 {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid v2 version number", nil)
+		return
+	}
+
+	// Compare versions
+	diff, err := h.service.CompareVersions(c.Request.Context(), id, v1, v2)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to compare versions",
+			zap.String("id", id.String()),
+			zap.Int("v1", v1),
+			zap.Int("v2", v2))
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// VersionHistory handles GET /api/v1/object-types/:id/versions/history,
+// diffing each consecutive pair of versions from ?from= to ?to=
+func (h *ObjectTypeHandler) VersionHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil || from < 1 {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid from version number", nil)
+		return
+	}
+
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil || to <= from {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid to version number, must be greater than from", nil)
+		return
+	}
+
+	diffs, err := h.service.CompareVersionRange(c.Request.Context(), id, from, to)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to compare version range",
+			zap.String("id", id.String()),
+			zap.Int("from", from),
+			zap.Int("to", to))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  diffs,
+		"count": len(diffs),
+	})
+}
+
+// ResolvedVersion handles GET /api/v1/object-types/:id/versions/:version/resolved
+func (h *ObjectTypeHandler) ResolvedVersion(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	// Parse version
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid version number", nil)
+		return
+	}
+
+	objectType, err := h.service.GetResolvedVersion(c.Request.Context(), id, version)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to resolve object type version",
+			zap.String("id", id.String()),
+			zap.Int("version", version))
+		return
+	}
+
+	c.JSON(http.StatusOK, objectType)
+}
+
+// RestoreVersion handles POST /api/v1/object-types/:id/versions/:version/restore
+func (h *ObjectTypeHandler) RestoreVersion(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	// Parse version
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid version number", nil)
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	// Restore version
+	objectType, err := h.service.RestoreVersion(c.Request.Context(), id, version, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to restore object type version",
+			zap.String("id", id.String()),
+			zap.Int("version", version),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, objectType)
+}
+
+// CloneObjectTypeRequest is the body of an object type clone
+type CloneObjectTypeRequest struct {
+	Name        string `json:"name" binding:"required"`
+	DisplayName string `json:"displayName"`
+}
+
+// Clone handles POST /api/v1/object-types/:id/clone
+func (h *ObjectTypeHandler) Clone(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	var req CloneObjectTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	// Additional validation
+	if err := validator.ValidateObjectTypeName(req.Name); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type name", err.Error())
+		return
+	}
+
+	// Sanitize input to prevent XSS
+	req.Name = validator.SanitizeString(req.Name)
+	req.DisplayName = validator.SanitizeString(req.DisplayName)
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	objectType, err := h.service.Clone(c.Request.Context(), id, req.Name, req.DisplayName, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to clone object type",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID),
+			zap.String("name", req.Name))
+		return
+	}
+
+	c.JSON(http.StatusCreated, objectType)
+}
+
+// Restore handles POST /api/v1/object-types/:id/restore
+func (h *ObjectTypeHandler) Restore(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	objectType, err := h.service.RestoreObjectType(c.Request.Context(), id, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to restore object type",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.Header("ETag", representationETag(objectType.ID, objectType.Version, representationOptions{}))
+	c.JSON(http.StatusOK, objectType)
+}
+
+// metadataFilterPrefix marks query parameters that filter on a metadata key
+const metadataFilterPrefix = "metadata."
+
+// parseObjectTypeFilter parses the category, tags, metadata and deletion
+// filters shared by List and Count. It writes an error response and returns
+// false when a parameter is invalid or not permitted.
+func parseObjectTypeFilter(c *gin.Context) (repository.ObjectTypeFilter, bool) {
+	var filter repository.ObjectTypeFilter
+
+	// Parse deletion filter; deleted object types are only listed for
+	// callers allowed to manage them
+	switch isDeleted := c.Query("is_deleted"); isDeleted {
+	case "", "false":
+	case "true", "all":
+		if !middleware.HasPermission(c, middleware.PermObjectTypePurge) {
+			apierror.Write(c, http.StatusForbidden, apierror.CodeForbidden, "insufficient permissions",
+				"missing permission "+middleware.PermObjectTypePurge)
+			return filter, false
+		}
+		if isDeleted == "all" {
+			filter.IncludeDeleted = true
+		} else {
+			deleted := true
+			filter.IsDeleted = &deleted
+		}
+	default:
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid is_deleted filter",
+			"must be true, false or all")
+		return filter, false
+	}
+
+	// Parse category filter
+	if category := c.Query("category"); category != "" {
+		filter.Category = &category
+	}
+
+	// Parse tags filter
+	if tags := c.QueryArray("tags"); len(tags) > 0 {
+		filter.Tags = tags
+	}
+
+	// Parse metadata filters given as ?metadata.<key>=<value>
+	for param, values := range c.Request.URL.Query() {
+		key := strings.TrimPrefix(param, metadataFilterPrefix)
+		if key == param || key == "" || len(values) == 0 {
+			continue
+		}
+		if filter.MetadataFilters == nil {
+			filter.MetadataFilters = make(map[string]string)
+		}
+		filter.MetadataFilters[key] = values[0]
+	}
+
+	return filter, true
+}
+
+// representationOptions are the query parameters that shape the body of an
+// object type response. The zero value is the default representation.
+type representationOptions struct {
+	Fields            []string
+	Grouped           bool
+	ExcludeDeprecated bool
+	ResolveInherited  bool
+}
+
+// representationETag formats a strong ETag for one representation of an
+// object or link type. The version leads so If-Match can read it back; the
+// hash covers the id, the version and the normalized options, so two
+// representations of the same version never share an ETag.
+func representationETag(id uuid.UUID, version int, opts representationOptions) string {
+	fields := append([]string(nil), opts.Fields...)
+	sort.Strings(fields)
+	fields = slices.Compact(fields)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%d\x00%s\x00%t\x00%t\x00%t", id, version,
+		strings.Join(fields, ","), opts.Grouped, opts.ExcludeDeprecated, opts.ResolveInherited)
+	return strconv.Quote(strconv.Itoa(version) + "-" + hex.EncodeToString(h.Sum(nil)[:8]))
+}
+
+// matchesETag reports whether an If-None-Match header value lists etag or is
+// "*". Comparison is weak, so W/ prefixes are ignored.
+func matchesETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIfMatch parses the expected version from an If-Match header value.
+// "*" only requires the resource to exist, which Update checks anyway, so it
+// yields no version.
+func parseIfMatch(value string) (*int, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+	if value == "*" {
+		return nil, nil
+	}
+	value, _, _ = strings.Cut(strings.Trim(value, `"`), "-")
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
 }
 
 // Helper function to encode cursor
-func encodeCursor(timestamp time.Time, id uuid.UUID) string {
-	// This should match the implementation in the repository
-	data := fmt.Sprintf("%d:%s", timestamp.Unix(), id.String())
-	return base64.StdEncoding.EncodeToString([]byte(data))
+func (h *ObjectTypeHandler) encodeCursor(objectType *entity.ObjectType, sortBy string) string {
+	return h.cursors.Encode(repository.NewObjectTypeCursor(objectType, sortBy))
 }
\ No newline at end of file
@@ -0,0 +1,951 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
+)
+
+// testCursors signs the page cursors of the handlers under test
+var testCursors = repository.NewCursorCodec([]byte("test-key"), time.Hour, false)
+
+// fakeObjectTypeRepo serves List from a fixed slice; unimplemented methods
+// panic through the nil embedded interface
+type fakeObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	objectTypes []*entity.ObjectType
+}
+
+func (r *fakeObjectTypeRepo) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
+	if filter.PageSize < len(r.objectTypes) {
+		return r.objectTypes[:filter.PageSize], nil
+	}
+	return r.objectTypes, nil
+}
+
+// listResponse is the part of a list response the tests inspect
+type listResponse struct {
+	Data       []json.RawMessage `json:"data"`
+	Pagination struct {
+		NextCursor  string `json:"next_cursor"`
+		PrevCursor  string `json:"prev_cursor"`
+		HasMore     bool   `json:"has_more"`
+		HasPrevious bool   `json:"has_previous"`
+		TotalCount  *int64 `json:"total_count"`
+	} `json:"pagination"`
+}
+
+// pagingObjectTypeRepo serves List from a slice in created_at DESC order,
+// honouring cursors the way the Postgres repository does: a backward page
+// is returned in display order with the extra item first
+type pagingObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	objectTypes []*entity.ObjectType
+}
+
+func (r *pagingObjectTypeRepo) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
+	position := func(encoded string) int {
+		cursor, err := testCursors.Decode(encoded)
+		if err != nil {
+			return -1
+		}
+		for i, objectType := range r.objectTypes {
+			if objectType.ID == cursor.ID {
+				return i
+			}
+		}
+		return -1
+	}
+
+	if filter.Before != "" {
+		end := position(filter.Before)
+		return r.objectTypes[max(0, end-filter.PageSize):end], nil
+	}
+	start := position(filter.PageCursor) + 1
+	return r.objectTypes[start:min(len(r.objectTypes), start+filter.PageSize)], nil
+}
+
+// newObjectTypes returns count object types, newest first
+func newObjectTypes(count int) []*entity.ObjectType {
+	objectTypes := make([]*entity.ObjectType, count)
+	for i := range objectTypes {
+		objectTypes[i] = &entity.ObjectType{
+			ID:        uuid.New(),
+			CreatedAt: time.Now().Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	return objectTypes
+}
+
+// listObjectTypes runs GET /api/v1/object-types?page_size=pageSize&extra
+// against a handler whose repository holds count object types
+func listObjectTypes(t *testing.T, count, pageSize int, extra string) listResponse {
+	t.Helper()
+	repo := &fakeObjectTypeRepo{objectTypes: newObjectTypes(count)}
+	return listObjectTypesFrom(t, repo, "page_size="+strconv.Itoa(pageSize)+"&"+extra)
+}
+
+// listObjectTypesFrom runs GET /api/v1/object-types?query against a handler
+// around repo
+func listObjectTypesFrom(t *testing.T, repo repository.ObjectTypeRepository, query string) listResponse {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := service.NewObjectTypeService(repo, nil, nil, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/object-types?"+query, nil)
+	h.List(c)
+
+	var resp listResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response (status %d): %v", w.Code, err)
+	}
+	return resp
+}
+
+func TestListExactFullPageHasNoMore(t *testing.T) {
+	// Arrange
+	count, pageSize := 3, 3
+
+	// Act
+	resp := listObjectTypes(t, count, pageSize, "")
+
+	// Assert
+	if resp.Pagination.HasMore {
+		t.Errorf("has_more = true for the last, exactly full page")
+	}
+}
+
+func TestListWithMoreItemsHasMore(t *testing.T) {
+	// Arrange
+	count, pageSize := 3, 2
+
+	// Act
+	resp := listObjectTypes(t, count, pageSize, "")
+
+	// Assert
+	if !resp.Pagination.HasMore {
+		t.Errorf("has_more = false with items beyond the page")
+	}
+}
+
+func TestListTrimsTheExtraItem(t *testing.T) {
+	// Arrange
+	count, pageSize := 3, 2
+
+	// Act
+	resp := listObjectTypes(t, count, pageSize, "")
+
+	// Assert
+	if len(resp.Data) != pageSize {
+		t.Errorf("got %d items, want %d", len(resp.Data), pageSize)
+	}
+}
+
+func TestListFirstPageHasNoPrevious(t *testing.T) {
+	// Arrange
+	count, pageSize := 3, 2
+
+	// Act
+	resp := listObjectTypes(t, count, pageSize, "")
+
+	// Assert
+	if resp.Pagination.HasPrevious {
+		t.Errorf("has_previous = true on the first page")
+	}
+}
+
+func TestListAfterCursorHasPrevious(t *testing.T) {
+	// Arrange
+	cursor := testCursors.Encode(repository.NewObjectTypeCursor(&entity.ObjectType{ID: uuid.New(), CreatedAt: time.Now()}, repository.SortByCreatedAt))
+
+	// Act
+	resp := listObjectTypes(t, 3, 2, "cursor="+url.QueryEscape(cursor))
+
+	// Assert
+	if !resp.Pagination.HasPrevious || resp.Pagination.PrevCursor == "" {
+		t.Errorf("has_previous = %v, prev_cursor = %q after a cursor; want true and a cursor",
+			resp.Pagination.HasPrevious, resp.Pagination.PrevCursor)
+	}
+}
+
+func TestListBeforeCursorWithMoreHasPrevious(t *testing.T) {
+	// Arrange
+	cursor := testCursors.Encode(repository.NewObjectTypeCursor(&entity.ObjectType{ID: uuid.New(), CreatedAt: time.Now()}, repository.SortByCreatedAt))
+
+	// Act
+	resp := listObjectTypes(t, 3, 2, "before="+url.QueryEscape(cursor))
+
+	// Assert
+	if !resp.Pagination.HasPrevious {
+		t.Errorf("has_previous = false with items before the page")
+	}
+}
+
+func TestListBeforeCursorHasNext(t *testing.T) {
+	// Arrange
+	cursor := testCursors.Encode(repository.NewObjectTypeCursor(&entity.ObjectType{ID: uuid.New(), CreatedAt: time.Now()}, repository.SortByCreatedAt))
+
+	// Act
+	resp := listObjectTypes(t, 1, 2, "before="+url.QueryEscape(cursor))
+
+	// Assert
+	if !resp.Pagination.HasMore || resp.Pagination.NextCursor == "" {
+		t.Errorf("has_more = %v, next_cursor = %q before a cursor; want true and a cursor",
+			resp.Pagination.HasMore, resp.Pagination.NextCursor)
+	}
+}
+
+// ids returns the IDs of the items in a list response
+func (r listResponse) ids(t *testing.T) []string {
+	t.Helper()
+	ids := make([]string, len(r.Data))
+	for i, raw := range r.Data {
+		var item struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			t.Fatalf("decode item: %v", err)
+		}
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestListPagingForwardThenBackwardReturnsTheSamePage(t *testing.T) {
+	// Arrange
+	repo := &pagingObjectTypeRepo{objectTypes: newObjectTypes(5)}
+	first := listObjectTypesFrom(t, repo, "page_size=2")
+	second := listObjectTypesFrom(t, repo, "page_size=2&cursor="+url.QueryEscape(first.Pagination.NextCursor))
+
+	// Act
+	back := listObjectTypesFrom(t, repo, "page_size=2&before="+url.QueryEscape(second.Pagination.PrevCursor))
+
+	// Assert
+	if got, want := back.ids(t), first.ids(t); !slices.Equal(got, want) {
+		t.Errorf("paging back returned %v, want the first page %v", got, want)
+	}
+}
+
+func TestListPagingBackToTheStartHasNoPrevious(t *testing.T) {
+	// Arrange
+	repo := &pagingObjectTypeRepo{objectTypes: newObjectTypes(5)}
+	first := listObjectTypesFrom(t, repo, "page_size=2")
+	second := listObjectTypesFrom(t, repo, "page_size=2&cursor="+url.QueryEscape(first.Pagination.NextCursor))
+
+	// Act
+	back := listObjectTypesFrom(t, repo, "page_size=2&before="+url.QueryEscape(second.Pagination.PrevCursor))
+
+	// Assert
+	if back.Pagination.HasPrevious {
+		t.Error("has_previous = true after paging back to the first page")
+	}
+}
+
+func TestListPagingForwardVisitsEveryItemOnce(t *testing.T) {
+	// Arrange
+	repo := &pagingObjectTypeRepo{objectTypes: newObjectTypes(5)}
+	var seen []string
+
+	// Act
+	query := "page_size=2"
+	for {
+		page := listObjectTypesFrom(t, repo, query)
+		seen = append(seen, page.ids(t)...)
+		if !page.Pagination.HasMore {
+			break
+		}
+		query = "page_size=2&cursor=" + url.QueryEscape(page.Pagination.NextCursor)
+	}
+
+	// Assert
+	want := make([]string, len(repo.objectTypes))
+	for i, objectType := range repo.objectTypes {
+		want[i] = objectType.ID.String()
+	}
+	if !slices.Equal(seen, want) {
+		t.Errorf("paged through %v, want %v", seen, want)
+	}
+}
+
+// versionedObjectTypeRepo holds versions 1..len(versions) of one object type
+// and pages them newest first like the Postgres repository
+type versionedObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	versions []*repository.ObjectTypeVersion
+}
+
+func (r *versionedObjectTypeRepo) ListVersions(ctx context.Context, id uuid.UUID, filter repository.VersionFilter) ([]*repository.ObjectTypeVersion, error) {
+	var page []*repository.ObjectTypeVersion
+	for i := len(r.versions) - 1; i >= 0 && len(page) < filter.PageSize; i-- {
+		if filter.BeforeVersion == 0 || r.versions[i].Version < filter.BeforeVersion {
+			page = append(page, r.versions[i])
+		}
+	}
+	return page, nil
+}
+
+// newVersionedObjectTypeRepo seeds count versions of the object type id
+func newVersionedObjectTypeRepo(id uuid.UUID, count int) *versionedObjectTypeRepo {
+	repo := &versionedObjectTypeRepo{}
+	for version := 1; version <= count; version++ {
+		repo.versions = append(repo.versions, &repository.ObjectTypeVersion{ID: uuid.New(), ObjectTypeID: id, Version: version})
+	}
+	return repo
+}
+
+// versionPage is the part of a version list response the tests inspect
+type versionPage struct {
+	Data []struct {
+		Version int `json:"version"`
+	} `json:"data"`
+	Pagination struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"pagination"`
+}
+
+// listVersions runs GET /api/v1/object-types/:id/versions?query against a
+// handler around repo
+func listVersions(t *testing.T, repo repository.ObjectTypeRepository, id uuid.UUID, query string) versionPage {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := service.NewObjectTypeService(repo, nil, nil, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: id.String()}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/object-types/"+id.String()+"/versions?"+query, nil)
+	h.ListVersions(c)
+
+	var page versionPage
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("decode response (status %d): %v", w.Code, err)
+	}
+	return page
+}
+
+func TestListVersionsPagesThroughFiftyVersionsNewestFirst(t *testing.T) {
+	// Arrange
+	id := uuid.New()
+	repo := newVersionedObjectTypeRepo(id, 50)
+	var seen []int
+	pages := 0
+
+	// Act
+	query := "page_size=20"
+	for {
+		page := listVersions(t, repo, id, query)
+		pages++
+		for _, v := range page.Data {
+			seen = append(seen, v.Version)
+		}
+		if page.Pagination.NextCursor == "" {
+			break
+		}
+		query = "page_size=20&cursor=" + page.Pagination.NextCursor
+	}
+
+	// Assert
+	want := make([]int, 50)
+	for i := range want {
+		want[i] = 50 - i
+	}
+	if pages != 3 || !slices.Equal(seen, want) {
+		t.Errorf("paged through %v in %d pages, want 50..1 in 3 pages", seen, pages)
+	}
+}
+
+func TestListVersionsLastFullPageHasNoCursor(t *testing.T) {
+	// Arrange
+	id := uuid.New()
+	repo := newVersionedObjectTypeRepo(id, 40)
+
+	// Act
+	page := listVersions(t, repo, id, "page_size=20&cursor=21")
+
+	// Assert
+	if len(page.Data) != 20 || page.Pagination.NextCursor != "" {
+		t.Errorf("page has %d versions and cursor %q, want 20 and no cursor after version 1",
+			len(page.Data), page.Pagination.NextCursor)
+	}
+}
+
+func TestListVersionsDefaultsPageSize(t *testing.T) {
+	// Arrange
+	id := uuid.New()
+	repo := newVersionedObjectTypeRepo(id, 50)
+
+	// Act
+	page := listVersions(t, repo, id, "")
+
+	// Assert
+	if len(page.Data) != repository.DefaultVersionPageSize {
+		t.Errorf("page has %d versions, want the default %d", len(page.Data), repository.DefaultVersionPageSize)
+	}
+}
+
+func TestAddTagRejectsBlankTag(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	svc := service.NewObjectTypeService(&fakeObjectTypeRepo{}, nil, nil, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+	id := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: id.String()}}
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/object-types/"+id.String()+"/tags", strings.NewReader(`{"tag": "   "}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Act
+	h.AddTag(c)
+
+	// Assert
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d for a tag that sanitizes to nothing", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestBulkTagRejectsInvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewObjectTypeService(&fakeObjectTypeRepo{}, nil, nil, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+	id := uuid.New().String()
+
+	cases := []struct {
+		name string
+		body string
+	}{
+		{"unknown action", `{"ids": ["` + id + `"], "tag": "crm", "action": "toggle"}`},
+		{"blank tag", `{"ids": ["` + id + `"], "tag": "   ", "action": "add"}`},
+		{"missing IDs", `{"tag": "crm", "action": "add"}`},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/object-types/tags/bulk", strings.NewReader(tc.body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		// Act
+		h.BulkTag(c)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+// countingObjectTypeRepo counts its live object types matching a filter's
+// category, tags and metadata, like the Postgres repository's category =,
+// tags && and metadata @>
+type countingObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	objectTypes []*entity.ObjectType
+	// counts counts Count calls
+	counts int
+}
+
+func (r *countingObjectTypeRepo) Count(ctx context.Context, filter repository.ObjectTypeFilter) (int64, error) {
+	r.counts++
+	return int64(len(r.matching(filter))), nil
+}
+
+// List returns the first page of the matching object types
+func (r *countingObjectTypeRepo) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
+	matched := r.matching(filter)
+	if len(matched) > filter.PageSize {
+		matched = matched[:filter.PageSize]
+	}
+	return matched, nil
+}
+
+// matching returns the live object types matching filter
+func (r *countingObjectTypeRepo) matching(filter repository.ObjectTypeFilter) []*entity.ObjectType {
+	var matched []*entity.ObjectType
+	for _, objectType := range r.objectTypes {
+		if objectType.IsDeleted {
+			continue
+		}
+		if filter.Category != nil && (objectType.Category == nil || *objectType.Category != *filter.Category) {
+			continue
+		}
+		if len(filter.Tags) > 0 && !slices.ContainsFunc(filter.Tags, objectType.HasTag) {
+			continue
+		}
+		if !containsMetadata(objectType.Metadata, filter.MetadataFilters) {
+			continue
+		}
+		matched = append(matched, objectType)
+	}
+	return matched
+}
+
+// containsMetadata reports whether metadata holds every key and value of want
+func containsMetadata(metadata map[string]interface{}, want map[string]string) bool {
+	for key, value := range want {
+		if metadata[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// countedObjectTypes returns a repository of a sales Customer tagged crm, a
+// sales Lead tagged crm and prospect, and an uncategorized Invoice tagged
+// finance. Customer and Lead are owned by the sales team at gold and silver
+// tier; Invoice is owned by the finance team at gold tier.
+func countedObjectTypes() *countingObjectTypeRepo {
+	sales := "sales"
+	return &countingObjectTypeRepo{objectTypes: []*entity.ObjectType{
+		{ID: uuid.New(), Name: "Customer", Category: &sales, Tags: []string{"crm"},
+			Metadata: map[string]interface{}{"owner": "sales", "tier": "gold"}},
+		{ID: uuid.New(), Name: "Lead", Category: &sales, Tags: []string{"crm", "prospect"},
+			Metadata: map[string]interface{}{"owner": "sales", "tier": "silver"}},
+		{ID: uuid.New(), Name: "Invoice", Tags: []string{"finance"},
+			Metadata: map[string]interface{}{"owner": "finance", "tier": "gold"}},
+	}}
+}
+
+// countObjectTypes runs GET /api/v1/object-types/count?query against a
+// handler over countedObjectTypes
+func countObjectTypes(t *testing.T, query string) int64 {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	repo := countedObjectTypes()
+	svc := service.NewObjectTypeService(repo, nil, nil, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/object-types/count?"+query, nil)
+	h.Count(c)
+
+	var resp struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response (status %d): %v", w.Code, err)
+	}
+	return resp.Count
+}
+
+func TestCountWithoutFiltersCountsEverything(t *testing.T) {
+	// Act
+	count := countObjectTypes(t, "")
+
+	// Assert
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+}
+
+func TestCountRespectsCategoryFilter(t *testing.T) {
+	// Act
+	count := countObjectTypes(t, "category=sales")
+
+	// Assert
+	if count != 2 {
+		t.Errorf("count = %d, want 2 in sales", count)
+	}
+}
+
+func TestCountRespectsTagFilter(t *testing.T) {
+	// Act
+	count := countObjectTypes(t, "tags=prospect&tags=finance")
+
+	// Assert
+	if count != 2 {
+		t.Errorf("count = %d, want 2 tagged prospect or finance", count)
+	}
+}
+
+func TestCountRespectsCategoryAndTagFilters(t *testing.T) {
+	// Act
+	count := countObjectTypes(t, "category=sales&tags=prospect")
+
+	// Assert
+	if count != 1 {
+		t.Errorf("count = %d, want 1 in sales tagged prospect", count)
+	}
+}
+
+func TestCountRespectsMetadataFilter(t *testing.T) {
+	// Act
+	count := countObjectTypes(t, "metadata.owner=sales")
+
+	// Assert
+	if count != 2 {
+		t.Errorf("count = %d, want 2 owned by sales", count)
+	}
+}
+
+func TestCountRequiresEveryMetadataFilter(t *testing.T) {
+	// Act
+	count := countObjectTypes(t, "metadata.owner=sales&metadata.tier=gold")
+
+	// Assert
+	if count != 1 {
+		t.Errorf("count = %d, want 1 owned by sales at gold tier", count)
+	}
+}
+
+func TestParseObjectTypeFilterReadsMetadataParameters(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/object-types?metadata.owner=sales&metadata.tier=gold&metadata.=x&metadataowner=y", nil)
+
+	// Act
+	filter, ok := parseObjectTypeFilter(c)
+
+	// Assert
+	want := map[string]string{"owner": "sales", "tier": "gold"}
+	if !ok || len(filter.MetadataFilters) != len(want) {
+		t.Fatalf("metadata filters = %v, want %v", filter.MetadataFilters, want)
+	}
+	for key, value := range want {
+		if filter.MetadataFilters[key] != value {
+			t.Errorf("metadata filters = %v, want %v", filter.MetadataFilters, want)
+		}
+	}
+}
+
+func TestListIncludesTotalMatchingFilterOnRequest(t *testing.T) {
+	cases := []struct {
+		query string
+		total int64
+	}{
+		{"", 3},
+		{"category=sales", 2},
+		{"tags=finance", 1},
+		{"metadata.tier=gold", 2},
+		{"category=sales&tags=prospect", 1},
+	}
+
+	for _, tc := range cases {
+		// Act
+		resp := listObjectTypesFrom(t, countedObjectTypes(), "page_size=1&include_total=true&"+tc.query)
+
+		// Assert
+		if total := resp.Pagination.TotalCount; total == nil || *total != tc.total {
+			t.Errorf("%q: total_count = %v, want %d", tc.query, total, tc.total)
+		}
+		if len(resp.Data) != 1 || resp.Pagination.HasMore != (tc.total > 1) {
+			t.Errorf("%q: %d items with has_more %v, want one page of the total", tc.query, len(resp.Data), resp.Pagination.HasMore)
+		}
+	}
+}
+
+func TestListOmitsTotalByDefault(t *testing.T) {
+	// Arrange
+	repo := countedObjectTypes()
+
+	// Act
+	resp := listObjectTypesFrom(t, repo, "page_size=1")
+
+	// Assert
+	if resp.Pagination.TotalCount != nil || repo.counts != 0 {
+		t.Errorf("total_count = %v after %d counts, want none and no count query", resp.Pagination.TotalCount, repo.counts)
+	}
+}
+
+func TestParseObjectTypeFilterDeletionValues(t *testing.T) {
+	admin := []string{middleware.PermObjectTypeWrite, middleware.PermObjectTypePurge}
+	editor := []string{middleware.PermObjectTypeWrite}
+	cases := []struct {
+		value          string
+		permissions    []string
+		status         int
+		isDeleted      *bool
+		includeDeleted bool
+	}{
+		{"", editor, http.StatusOK, nil, false},
+		{"false", editor, http.StatusOK, nil, false},
+		{"true", admin, http.StatusOK, boolPtr(true), false},
+		{"all", admin, http.StatusOK, nil, true},
+		{"true", editor, http.StatusForbidden, nil, false},
+		{"all", editor, http.StatusForbidden, nil, false},
+		{"maybe", admin, http.StatusBadRequest, nil, false},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/object-types?is_deleted="+tc.value, nil)
+		c.Set("user_permissions", tc.permissions)
+
+		// Act
+		filter, ok := parseObjectTypeFilter(c)
+
+		// Assert
+		if ok != (tc.status == http.StatusOK) || (!ok && w.Code != tc.status) {
+			t.Errorf("is_deleted=%s with %v: ok %v, status %d, want %d", tc.value, tc.permissions, ok, w.Code, tc.status)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if (filter.IsDeleted == nil) != (tc.isDeleted == nil) || (filter.IsDeleted != nil && *filter.IsDeleted != *tc.isDeleted) ||
+			filter.IncludeDeleted != tc.includeDeleted {
+			t.Errorf("is_deleted=%s: filter IsDeleted %v, IncludeDeleted %v, want %v, %v",
+				tc.value, filter.IsDeleted, filter.IncludeDeleted, tc.isDeleted, tc.includeDeleted)
+		}
+	}
+}
+
+// boolPtr returns a pointer to b
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestCountIgnoresPaginationParameters(t *testing.T) {
+	// Act
+	count := countObjectTypes(t, "page_size=1")
+
+	// Assert
+	if count != 3 {
+		t.Errorf("count = %d, want 3 regardless of page_size", count)
+	}
+}
+
+// storedObjectTypeRepo serves GetByID from a single stored object type
+type storedObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	objectType *entity.ObjectType
+}
+
+func (r *storedObjectTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	if id != r.objectType.ID {
+		return nil, entity.ErrObjectTypeNotFound
+	}
+	return r.objectType, nil
+}
+
+// objectTypeWithDeprecatedFax returns an object type whose fax property is
+// deprecated
+func objectTypeWithDeprecatedFax() *entity.ObjectType {
+	message := "Use email"
+	return &entity.ObjectType{
+		ID:          uuid.New(),
+		Name:        "customer",
+		DisplayName: "Customer",
+		Version:     1,
+		Properties: []entity.Property{
+			{Name: "email", DisplayName: "Email", DataType: entity.DataTypeString, Order: 1},
+			{Name: "fax", DisplayName: "Fax", DataType: entity.DataTypeString, Order: 2, Deprecated: true, DeprecationMessage: &message},
+		},
+	}
+}
+
+// getObjectTypeProperties runs GET /api/v1/object-types/:id?query against a
+// handler around repo and returns the names of the properties in the response
+func getObjectTypeProperties(t *testing.T, repo *storedObjectTypeRepo, query string) []string {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	svc := service.NewObjectTypeService(repo, nil, invalidatingCache{}, service.DefaultCacheTTLs(), nil,
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+	router := gin.New()
+	router.GET("/api/v1/object-types/:id", h.Get)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/object-types/"+repo.objectType.ID.String()+"?"+query, nil))
+
+	var resp struct {
+		Properties []entity.Property `json:"properties"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("response %d: %s", w.Code, w.Body.String())
+	}
+	var names []string
+	for _, prop := range resp.Properties {
+		names = append(names, prop.Name)
+	}
+	return names
+}
+
+func TestGetIncludesDeprecatedPropertiesByDefault(t *testing.T) {
+	// Arrange
+	repo := &storedObjectTypeRepo{objectType: objectTypeWithDeprecatedFax()}
+
+	// Act
+	names := getObjectTypeProperties(t, repo, "")
+
+	// Assert
+	if !slices.Equal(names, []string{"email", "fax"}) {
+		t.Errorf("properties = %v, want [email fax]", names)
+	}
+}
+
+func TestGetExcludesDeprecatedPropertiesOnRequest(t *testing.T) {
+	// Arrange
+	repo := &storedObjectTypeRepo{objectType: objectTypeWithDeprecatedFax()}
+
+	// Act
+	names := getObjectTypeProperties(t, repo, "include_deprecated=false")
+
+	// Assert
+	if !slices.Equal(names, []string{"email"}) {
+		t.Errorf("properties = %v, want [email]", names)
+	}
+	if len(repo.objectType.Properties) != 2 {
+		t.Errorf("stored properties = %d, want the loaded object type left unfiltered", len(repo.objectType.Properties))
+	}
+}
+
+func TestExcludingDeprecatedPropertiesChangesETag(t *testing.T) {
+	// Arrange
+	id := uuid.New()
+
+	// Act
+	full := representationETag(id, 1, representationOptions{})
+	filtered := representationETag(id, 1, representationOptions{ExcludeDeprecated: true})
+
+	// Assert
+	if full == filtered {
+		t.Errorf("full and filtered representations share ETag %s", full)
+	}
+}
+
+// recordingCreateRepo counts the object types created through it
+type recordingCreateRepo struct {
+	creatingObjectTypeRepo
+	creates int
+}
+
+func (r *recordingCreateRepo) Create(ctx context.Context, objectType *entity.ObjectType) error {
+	r.creates++
+	return nil
+}
+
+func TestDryRunCreateRespondsUnsavedWithoutEvent(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	publisher := &recordingPublisher{}
+	repo := &recordingCreateRepo{}
+	svc := service.NewObjectTypeService(repo, nil, invalidatingCache{}, service.DefaultCacheTTLs(),
+		publisher, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("user_id", "alice") })
+	router.POST("/api/v1/object-types", h.Create)
+	body := `{"name":"customer","displayName":"Customer","properties":[{"name":"email","displayName":"Email","dataType":"STRING"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/object-types?dry_run=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"saved":false`) {
+		t.Errorf("response = %d %s, want 200 with saved false", w.Code, w.Body.String())
+	}
+	if repo.creates != 0 || len(publisher.events) != 0 {
+		t.Errorf("creates = %d, events = %d, want neither", repo.creates, len(publisher.events))
+	}
+}
+
+// searchPageRepo answers Search with page, recording the options it was
+// given
+type searchPageRepo struct {
+	repository.ObjectTypeRepository
+	page *repository.SearchPage
+	opts repository.SearchOptions
+}
+
+func (r *searchPageRepo) Search(ctx context.Context, query string, limit int, opts repository.SearchOptions) (*repository.SearchPage, error) {
+	r.opts = opts
+	return r.page, nil
+}
+
+func TestSearchReturnsNextCursorAndPassesCursorOn(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	repo := &searchPageRepo{page: &repository.SearchPage{
+		Results:    newObjectTypes(2),
+		NextCursor: "page-3",
+	}}
+	svc := service.NewObjectTypeService(repo, nil, missCache{}, service.DefaultCacheTTLs(), nil,
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/search?q=customer&limit=2&cursor=page-2", nil)
+
+	// Act
+	h.Search(c)
+
+	// Assert
+	var resp struct {
+		Count      int    `json:"count"`
+		NextCursor string `json:"next_cursor"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("response %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Count != 2 || resp.NextCursor != "page-3" {
+		t.Errorf("response = %+v, want 2 results and next_cursor page-3", resp)
+	}
+	if repo.opts.Cursor != "page-2" {
+		t.Errorf("repository cursor = %q, want page-2 from the request", repo.opts.Cursor)
+	}
+}
+
+func TestVersionHistoryRejectsInvalidRanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewObjectTypeService(&fakeObjectTypeRepo{}, nil, nil, service.DefaultCacheTTLs(), nil, nil, zap.NewNop())
+	router := gin.New()
+	router.GET("/api/v1/object-types/:id/versions/history", NewObjectTypeHandler(svc, testCursors, zap.NewNop()).VersionHistory)
+	id := uuid.NewString()
+
+	cases := []struct {
+		name  string
+		query string
+	}{
+		{"missing from", "to=3"},
+		{"from zero", "from=0&to=3"},
+		{"missing to", "from=1"},
+		{"to equal to from", "from=3&to=3"},
+		{"to before from", "from=4&to=2"},
+		{"not a number", "from=one&to=3"},
+	}
+
+	for _, tc := range cases {
+		// Act
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/object-types/"+id+"/versions/history?"+tc.query, nil))
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
+)
+
+// purgingObjectTypeRepo purges and lists the soft-deleted object types it
+// holds, refusing to purge a live one like the Postgres repository
+type purgingObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	objectTypes map[uuid.UUID]*entity.ObjectType
+	// listLimit is the limit of the last ListDeleted
+	listLimit int
+}
+
+func newPurgingObjectTypeRepo(objectTypes ...*entity.ObjectType) *purgingObjectTypeRepo {
+	r := &purgingObjectTypeRepo{objectTypes: map[uuid.UUID]*entity.ObjectType{}}
+	for _, ot := range objectTypes {
+		r.objectTypes[ot.ID] = ot
+	}
+	return r
+}
+
+func (r *purgingObjectTypeRepo) Purge(ctx context.Context, id uuid.UUID) error {
+	ot, ok := r.objectTypes[id]
+	if !ok {
+		return entity.ErrObjectTypeNotFound
+	}
+	if !ot.IsDeleted {
+		return entity.ErrObjectTypeNotDeleted
+	}
+	delete(r.objectTypes, id)
+	return nil
+}
+
+func (r *purgingObjectTypeRepo) ListDeleted(ctx context.Context, limit int) ([]*entity.ObjectType, error) {
+	r.listLimit = limit
+	var deleted []*entity.ObjectType
+	for _, ot := range r.objectTypes {
+		if ot.IsDeleted && len(deleted) < limit {
+			deleted = append(deleted, ot)
+		}
+	}
+	return deleted, nil
+}
+
+// purgeRouter serves the object type delete and deleted listing routes of a
+// handler around repo to a user holding permissions
+func purgeRouter(repo *purgingObjectTypeRepo, publisher *recordingPublisher, permissions ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewObjectTypeService(repo, nil, invalidatingCache{}, service.DefaultCacheTTLs(),
+		publisher, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "alice")
+		c.Set("user_permissions", permissions)
+	})
+	router.DELETE("/api/v1/object-types/:id", h.Delete)
+	router.GET("/api/v1/object-types/deleted", h.ListDeleted)
+	return router
+}
+
+func TestPurgeRequiresThePurgePermissionAndADeletedObjectType(t *testing.T) {
+	editor := []string{middleware.PermObjectTypeWrite, middleware.PermObjectTypeDelete}
+	admin := []string{middleware.PermObjectTypeWrite, middleware.PermObjectTypeDelete, middleware.PermObjectTypePurge}
+	cases := []struct {
+		name        string
+		permissions []string
+		deleted     bool
+		status      int
+		code        string
+	}{
+		{"without permission", editor, true, http.StatusForbidden, apierror.CodeForbidden},
+		{"live object type", admin, false, http.StatusConflict, apierror.CodeObjectTypeNotDeleted},
+		{"deleted object type", admin, true, http.StatusNoContent, ""},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		objectType := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", IsDeleted: tc.deleted}
+		repo := newPurgingObjectTypeRepo(objectType)
+		publisher := &recordingPublisher{}
+		w := httptest.NewRecorder()
+
+		// Act
+		purgeRouter(repo, publisher, tc.permissions...).ServeHTTP(w,
+			httptest.NewRequest(http.MethodDelete, "/api/v1/object-types/"+objectType.ID.String()+"?purge=true", nil))
+
+		// Assert
+		var resp apierror.Response
+		if tc.code != "" {
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Errorf("%s: decode response: %v", tc.name, err)
+				continue
+			}
+		}
+		if w.Code != tc.status || resp.Code != tc.code {
+			t.Errorf("%s: response = %d %s, want %d %s", tc.name, w.Code, w.Body.String(), tc.status, tc.code)
+		}
+		purged := repo.objectTypes[objectType.ID] == nil
+		if want := tc.status == http.StatusNoContent; purged != want || (len(publisher.events) == 1) != want {
+			t.Errorf("%s: purged = %v with %d events, want purged = %v with one event only then", tc.name, purged, len(publisher.events), want)
+		}
+	}
+}
+
+func TestListDeletedRespondsWithTheDeletedObjectTypes(t *testing.T) {
+	cases := []struct {
+		query string
+		limit int
+	}{
+		{"", 50},
+		{"?limit=1", 1},
+		{"?limit=500", 50},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		deleted := &entity.ObjectType{ID: uuid.New(), Name: "Customer", DisplayName: "Customer", IsDeleted: true, UpdatedAt: time.Now()}
+		live := &entity.ObjectType{ID: uuid.New(), Name: "Account", DisplayName: "Account"}
+		repo := newPurgingObjectTypeRepo(deleted, live)
+		w := httptest.NewRecorder()
+
+		// Act
+		purgeRouter(repo, &recordingPublisher{}, middleware.PermObjectTypePurge).ServeHTTP(w,
+			httptest.NewRequest(http.MethodGet, "/api/v1/object-types/deleted"+tc.query, nil))
+
+		// Assert
+		var resp struct {
+			Data  []entity.ObjectType `json:"data"`
+			Count int                 `json:"count"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Errorf("%q: decode response (status %d): %v", tc.query, w.Code, err)
+			continue
+		}
+		if w.Code != http.StatusOK || resp.Count != 1 || len(resp.Data) != 1 || resp.Data[0].ID != deleted.ID {
+			t.Errorf("%q: response = %d %s, want Customer alone", tc.query, w.Code, w.Body.String())
+		}
+		if repo.listLimit != tc.limit {
+			t.Errorf("%q: limit = %d, want %d", tc.query, repo.listLimit, tc.limit)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
+)
+
+// tenantJWTSecret signs the tokens of the tenant isolation tests
+const tenantJWTSecret = "tenant-secret"
+
+// tenantObjectTypeRepo keeps the object types of each tenant apart, as the
+// Postgres repository does by scoping every query to the tenant on ctx
+type tenantObjectTypeRepo struct {
+	repository.ObjectTypeRepository
+	byTenant map[string]map[uuid.UUID]*entity.ObjectType
+}
+
+// tenant returns the object types of the tenant on ctx
+func (r *tenantObjectTypeRepo) tenant(ctx context.Context) map[uuid.UUID]*entity.ObjectType {
+	tenant := repository.TenantFromContext(ctx)
+	if r.byTenant[tenant] == nil {
+		r.byTenant[tenant] = map[uuid.UUID]*entity.ObjectType{}
+	}
+	return r.byTenant[tenant]
+}
+
+func (r *tenantObjectTypeRepo) Create(ctx context.Context, objectType *entity.ObjectType) error {
+	if existing, _ := r.GetByName(ctx, objectType.Name); existing != nil {
+		return entity.ErrObjectTypeNameExists
+	}
+	objectType.TenantID = repository.TenantFromContext(ctx)
+	r.tenant(ctx)[objectType.ID] = objectType.Copy()
+	return nil
+}
+
+func (r *tenantObjectTypeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.ObjectType, error) {
+	if objectType, ok := r.tenant(ctx)[id]; ok {
+		return objectType.Copy(), nil
+	}
+	return nil, entity.ErrObjectTypeNotFound
+}
+
+func (r *tenantObjectTypeRepo) GetByName(ctx context.Context, name string) (*entity.ObjectType, error) {
+	for _, objectType := range r.tenant(ctx) {
+		if objectType.Name == name {
+			return objectType.Copy(), nil
+		}
+	}
+	return nil, entity.ErrObjectTypeNotFound
+}
+
+func (r *tenantObjectTypeRepo) List(ctx context.Context, filter repository.ObjectTypeFilter) ([]*entity.ObjectType, error) {
+	var objectTypes []*entity.ObjectType
+	for _, objectType := range r.tenant(ctx) {
+		objectTypes = append(objectTypes, objectType.Copy())
+	}
+	return objectTypes, nil
+}
+
+func (r *tenantObjectTypeRepo) ListIndexes(ctx context.Context, objectTypeID uuid.UUID) ([]*entity.ObjectTypeIndex, error) {
+	return nil, nil
+}
+
+// tenantRouter serves object type reads and creates behind JWT auth, with a
+// Redis cache shared by every tenant
+func tenantRouter(t *testing.T) (*gin.Engine, *recordingPublisher) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	server := miniredis.RunT(t)
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	redisCache, err := cache.NewRedisCache(server.Addr(), "", 0, time.Minute, m, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewRedisCache: %v", err)
+	}
+	t.Cleanup(func() { redisCache.Close() })
+
+	publisher := &recordingPublisher{}
+	repo := &tenantObjectTypeRepo{byTenant: map[string]map[uuid.UUID]*entity.ObjectType{}}
+	svc := service.NewObjectTypeService(repo, nil, redisCache, service.DefaultCacheTTLs(), publisher, m, zap.NewNop())
+	h := NewObjectTypeHandler(svc, testCursors, zap.NewNop())
+
+	router := gin.New()
+	api := router.Group("/api/v1", middleware.Auth(middleware.JWTOptions{Secret: tenantJWTSecret}))
+	api.POST("/object-types", h.Create)
+	api.GET("/object-types", h.List)
+	api.GET("/object-types/:id", h.Get)
+	return router, publisher
+}
+
+// asTenant runs method path with body, if any, bearing a token of tenant
+func asTenant(t *testing.T, router *gin.Engine, tenant, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, middleware.Claims{
+		Tenant: tenant,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "alice",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}).SignedString([]byte(tenantJWTSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// customerBody creates an object type named customer
+const customerBody = `{"name":"customer","displayName":"Customer","properties":[{"name":"email","displayName":"Email","dataType":"STRING"}]}`
+
+// createCustomer creates customer in tenant and returns its ID
+func createCustomer(t *testing.T, router *gin.Engine, tenant string) string {
+	t.Helper()
+	w := asTenant(t, router, tenant, http.MethodPost, "/api/v1/object-types", customerBody)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create in %s = %d, want 201: %s", tenant, w.Code, w.Body)
+	}
+	var created entity.ObjectType
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode created object type: %v", err)
+	}
+	if created.TenantID != tenant {
+		t.Errorf("created in tenant %q, want %q", created.TenantID, tenant)
+	}
+	return created.ID.String()
+}
+
+func TestTenantsCanCreateIdenticallyNamedObjectTypes(t *testing.T) {
+	// Arrange
+	router, publisher := tenantRouter(t)
+
+	// Act
+	acmeID := createCustomer(t, router, "acme")
+	globexID := createCustomer(t, router, "globex")
+
+	// Assert
+	if acmeID == globexID {
+		t.Errorf("both tenants got object type %s, want one each", acmeID)
+	}
+	if len(publisher.events) != 2 || publisher.events[0].TenantID != "acme" || publisher.events[1].TenantID != "globex" {
+		t.Errorf("events = %+v, want one created event in each tenant", publisher.events)
+	}
+}
+
+func TestNameTakenInOwnTenantConflicts(t *testing.T) {
+	// Arrange
+	router, _ := tenantRouter(t)
+	createCustomer(t, router, "acme")
+	createCustomer(t, router, "globex")
+
+	// Act
+	w := asTenant(t, router, "acme", http.MethodPost, "/api/v1/object-types", customerBody)
+
+	// Assert
+	if w.Code != http.StatusConflict {
+		t.Errorf("second customer in acme = %d, want 409: %s", w.Code, w.Body)
+	}
+}
+
+func TestTenantCannotReadAnotherTenantsObjectType(t *testing.T) {
+	// Arrange
+	router, _ := tenantRouter(t)
+	acmeID := createCustomer(t, router, "acme")
+	path := "/api/v1/object-types/" + acmeID
+	if w := asTenant(t, router, "acme", http.MethodGet, path, ""); w.Code != http.StatusOK {
+		t.Fatalf("acme read of its object type = %d, want 200", w.Code)
+	}
+
+	// Act
+	w := asTenant(t, router, "globex", http.MethodGet, path, "")
+
+	// Assert
+	if w.Code != http.StatusNotFound {
+		t.Errorf("globex read of acme's object type = %d, want 404: %s", w.Code, w.Body)
+	}
+}
+
+func TestListOnlyShowsOwnTenantsObjectTypes(t *testing.T) {
+	// Arrange
+	router, _ := tenantRouter(t)
+	acmeID := createCustomer(t, router, "acme")
+	createCustomer(t, router, "globex")
+
+	// Act
+	w := asTenant(t, router, "acme", http.MethodGet, "/api/v1/object-types", "")
+
+	// Assert
+	var resp struct {
+		Data []entity.ObjectType `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode list (status %d): %v", w.Code, err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID.String() != acmeID || resp.Data[0].TenantID != "acme" {
+		t.Errorf("acme list = %+v, want only its own customer", resp.Data)
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"go.uber.org/zap"
+)
+
+// ValidationHandler handles instance validation requests
+type ValidationHandler struct {
+	service *service.ValidationService
+	logger  *zap.Logger
+}
+
+// NewValidationHandler creates a new validation handler
+func NewValidationHandler(service *service.ValidationService, logger *zap.Logger) *ValidationHandler {
+	return &ValidationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Validate handles POST /api/v1/object-types/:id/validate. The body is the
+// instance to check as a JSON object of property values. Invalid instances
+// still get 200; the report's valid flag and errors describe the outcome.
+func (h *ValidationHandler) Validate(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid object type ID", nil)
+		return
+	}
+
+	var values map[string]interface{}
+	if err := c.ShouldBindJSON(&values); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	report, err := h.service.ValidateInstance(c.Request.Context(), id, values)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to validate instance",
+			zap.String("id", id.String()))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/domain/service"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
+	"go.uber.org/zap"
+)
+
+// WebhookSubscriptionHandler handles webhook subscription requests
+type WebhookSubscriptionHandler struct {
+	service *service.WebhookSubscriptionService
+	logger  *zap.Logger
+}
+
+// NewWebhookSubscriptionHandler creates a new webhook subscription handler
+func NewWebhookSubscriptionHandler(service *service.WebhookSubscriptionService, logger *zap.Logger) *WebhookSubscriptionHandler {
+	return &WebhookSubscriptionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// List handles GET /api/v1/webhook-subscriptions
+func (h *WebhookSubscriptionHandler) List(c *gin.Context) {
+	subscriptions, err := h.service.List(c.Request.Context())
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to retrieve webhook subscriptions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": subscriptions,
+	})
+}
+
+// Create handles POST /api/v1/webhook-subscriptions
+func (h *WebhookSubscriptionHandler) Create(c *gin.Context) {
+	var input service.CreateWebhookSubscriptionInput
+
+	// Bind and validate input
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	subscription, err := h.service.Create(c.Request.Context(), input, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to create webhook subscription",
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// Get handles GET /api/v1/webhook-subscriptions/:id
+func (h *WebhookSubscriptionHandler) Get(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid webhook subscription ID", nil)
+		return
+	}
+
+	subscription, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to retrieve webhook subscription",
+			zap.String("id", id.String()))
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// Update handles PUT /api/v1/webhook-subscriptions/:id
+func (h *WebhookSubscriptionHandler) Update(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid webhook subscription ID", nil)
+		return
+	}
+
+	var input service.UpdateWebhookSubscriptionInput
+
+	// Bind and validate input
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	subscription, err := h.service.Update(c.Request.Context(), id, input, userID)
+	if err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to update webhook subscription",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// Delete handles DELETE /api/v1/webhook-subscriptions/:id
+func (h *WebhookSubscriptionHandler) Delete(c *gin.Context) {
+	// Parse ID
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierror.Write(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid webhook subscription ID", nil)
+		return
+	}
+
+	// Get user ID from context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		apierror.Write(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "User not authenticated", nil)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id, userID); err != nil {
+		apierror.Respond(c, h.logger, err, "Failed to delete webhook subscription",
+			zap.String("id", id.String()),
+			zap.String("user_id", userID))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// fakeAPIKeys holds a single API key
+type fakeAPIKeys struct {
+	key *entity.APIKey
+}
+
+func (r *fakeAPIKeys) GetByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	if keyHash != r.key.KeyHash {
+		return nil, entity.ErrAPIKeyNotFound
+	}
+	return r.key, nil
+}
+
+// serveAPIKey authenticates one request with the API key "secret" issued in
+// tenant and returns the response and the tenant the handler ran in
+func serveAPIKey(tenant string) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	keys := &fakeAPIKeys{key: &entity.APIKey{
+		ID:        uuid.New(),
+		KeyHash:   entity.HashAPIKey("secret"),
+		Principal: "svc-sync",
+		TenantID:  tenant,
+	}}
+
+	var seen string
+	router := gin.New()
+	router.GET("/", APIKeyAuth("X-API-Key", keys), func(c *gin.Context) {
+		seen = repository.TenantFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-API-Key", "secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, seen
+}
+
+func TestAPIKeyActsInKeyTenant(t *testing.T) {
+	// Arrange
+	tenant := "acme"
+
+	// Act
+	_, seen := serveAPIKey(tenant)
+
+	// Assert
+	if seen != tenant {
+		t.Errorf("tenant = %q, want %q", seen, tenant)
+	}
+}
+
+func TestAPIKeyWithInvalidTenantIsRejected(t *testing.T) {
+	// Arrange
+	tenant := "Not A Tenant"
+
+	// Act
+	w, _ := serveAPIKey(tenant)
+
+	// Assert
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+// authenticated is what the handler behind Authenticate saw
+type authenticated struct {
+	status int
+	userID string
+	method string
+}
+
+// serveAuthenticate runs one request with headers through Authenticate,
+// which accepts a JWT or the API key "secret" held by svc-sync
+func serveAuthenticate(headers map[string]string) authenticated {
+	gin.SetMode(gin.TestMode)
+	keys := &fakeAPIKeys{key: &entity.APIKey{
+		ID:        uuid.New(),
+		KeyHash:   entity.HashAPIKey("secret"),
+		Principal: "svc-sync",
+		TenantID:  entity.DefaultTenantID,
+		Roles:     []string{"editor"},
+	}}
+
+	var seen authenticated
+	router := gin.New()
+	router.GET("/", Authenticate(JWTOptions{Secret: testJWTSecret}, "X-API-Key", keys), func(c *gin.Context) {
+		seen.userID = GetUserID(c)
+		seen.method = GetAuthMethod(c)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	seen.status = w.Code
+	return seen
+}
+
+func TestValidAPIKeyAuthenticatesPrincipal(t *testing.T) {
+	// Act
+	seen := serveAuthenticate(map[string]string{"X-API-Key": "secret"})
+
+	// Assert
+	if seen.status != http.StatusOK || seen.userID != "svc-sync" {
+		t.Errorf("status = %d, user = %q, want 200 as svc-sync", seen.status, seen.userID)
+	}
+}
+
+func TestValidAPIKeyRecordsAuthMethod(t *testing.T) {
+	// Act
+	seen := serveAuthenticate(map[string]string{"X-API-Key": "secret"})
+
+	// Assert
+	if seen.method != AuthMethodAPIKey {
+		t.Errorf("auth method = %q, want %q", seen.method, AuthMethodAPIKey)
+	}
+}
+
+func TestInvalidAPIKeyIsUnauthorized(t *testing.T) {
+	// Act
+	seen := serveAuthenticate(map[string]string{"X-API-Key": "guess"})
+
+	// Assert
+	if seen.status != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", seen.status, http.StatusUnauthorized)
+	}
+}
+
+func TestMissingCredentialsAreUnauthorized(t *testing.T) {
+	// Act
+	seen := serveAuthenticate(nil)
+
+	// Assert
+	if seen.status != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", seen.status, http.StatusUnauthorized)
+	}
+}
+
+func TestMissingAPIKeyHeaderIsUnauthorized(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", APIKeyAuth("X-API-Key", &fakeAPIKeys{}), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRevokedAPIKeyIsUnauthorized(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	revokedAt := time.Now().Add(-time.Minute)
+	keys := &fakeAPIKeys{key: &entity.APIKey{KeyHash: entity.HashAPIKey("secret"), Principal: "svc-sync", TenantID: entity.DefaultTenantID, RevokedAt: &revokedAt}}
+	router := gin.New()
+	router.GET("/", APIKeyAuth("X-API-Key", keys), func(c *gin.Context) { c.Status(http.StatusOK) })
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-API-Key", "secret")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthenticateStillAcceptsJWT(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{Roles: []string{"editor"}})
+
+	// Act
+	seen := serveAuthenticate(map[string]string{"Authorization": "Bearer " + token})
+
+	// Assert
+	if seen.status != http.StatusOK || seen.method != AuthMethodJWT {
+		t.Errorf("status = %d, auth method = %q, want 200 via %q", seen.status, seen.method, AuthMethodJWT)
+	}
+}
@@ -7,49 +7,73 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/database"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
 )
 
+// Claims are the JWT claims understood by Auth. Roles grant permissions through
+// RolePermissions; Permissions grants additional ones directly. Tenant
+// confines the request to that tenant's object and link types; tokens
+// without it act in entity.DefaultTenantID.
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	Tenant      string   `json:"tenant,omitempty"`
+}
+
+// JWTOptions configures token verification in Auth. Tokens are verified as
+// RS256 against JWKS when it is set, and as HS256 with Secret otherwise.
+type JWTOptions struct {
+	Secret string
+	JWKS   *JWKS
+	// Issuer and Audience, when set, must match the token's iss claim and
+	// appear among its aud claims
+	Issuer   string
+	Audience string
+}
+
 // Auth creates an authentication middleware with enhanced security
-func Auth(jwtSecret string) gin.HandlerFunc {
+func Auth(opts JWTOptions) gin.HandlerFunc {
+	method := jwt.SigningMethodHS256.Name
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		return []byte(opts.Secret), nil
+	}
+	if opts.JWKS != nil {
+		method = jwt.SigningMethodRS256.Name
+		keyFunc = opts.JWKS.KeyFunc
+	}
+
 	return func(c *gin.Context) {
 		// Get authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "authorization header missing",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "authorization header missing", nil)
 			return
 		}
 
 		// Extract token
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "invalid authorization header format",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "invalid authorization header format", nil)
 			return
 		}
 
 		// Parse and validate token with options
-		parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
-		token, err := parser.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
+		parser := jwt.NewParser(jwt.WithValidMethods([]string{method}))
+		token, err := parser.ParseWithClaims(tokenString, &Claims{}, keyFunc)
 
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "invalid token",
-				"details": err.Error(),
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "invalid token", err.Error())
 			return
 		}
 
 		// Validate claims
-		claims, ok := token.Claims.(*jwt.RegisteredClaims)
+		claims, ok := token.Claims.(*Claims)
 		if !ok || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "invalid token claims",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "invalid token claims", nil)
 			return
 		}
 
@@ -58,44 +82,90 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 		
 		// Check expiration
 		if claims.ExpiresAt != nil && now.After(claims.ExpiresAt.Time) {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "token expired",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "token expired", nil)
 			return
 		}
 
 		// Check not before
 		if claims.NotBefore != nil && now.Before(claims.NotBefore.Time) {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "token not yet valid",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "token not yet valid", nil)
 			return
 		}
 
 		// Check issued at
 		if claims.IssuedAt != nil && now.Before(claims.IssuedAt.Time) {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "token issued in the future",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "token issued in the future", nil)
 			return
 		}
 
+		// Check issuer and audience when configured
+		if opts.Issuer != "" && claims.Issuer != opts.Issuer {
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "invalid token issuer", nil)
+			return
+		}
+		if opts.Audience != "" && !hasAudience(claims.Audience, opts.Audience) {
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "invalid token audience", nil)
+			return
+		}
+
+		tenant := claims.Tenant
+		if tenant == "" {
+			tenant = entity.DefaultTenantID
+		}
+		if !entity.IsValidTenantID(tenant) {
+			apierror.Abort(c, http.StatusUnauthorized, apierror.CodeUnauthenticated, "invalid token tenant", nil)
+			return
+		}
+		setTenantID(c, tenant)
+
 		// Set user ID in context
 		if claims.Subject != "" {
-			c.Set("user_id", claims.Subject)
+			setUserID(c, claims.Subject)
 		}
 		
-		// Extract custom claims for roles
-		if customClaims, ok := token.Claims.(jwt.MapClaims); ok {
-			if roles, ok := customClaims["roles"].([]interface{}); ok {
-				c.Set("user_roles", roles)
-			}
-		}
+		// Set roles and the permissions they grant in context
+		c.Set("user_roles", claims.Roles)
+		c.Set("user_permissions", resolvePermissions(claims.Roles, claims.Permissions))
+		c.Set("auth_method", AuthMethodJWT)
 
 		c.Next()
 	}
 }
 
+// hasAudience reports whether audience is among the token's aud claims
+func hasAudience(claimed jwt.ClaimStrings, audience string) bool {
+	for _, aud := range claimed {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// setUserID records the authenticated user in the gin context and in the
+// request context, where the database routing of their reads can see it
+func setUserID(c *gin.Context, userID string) {
+	c.Set("user_id", userID)
+	c.Request = c.Request.WithContext(database.WithActor(c.Request.Context(), userID))
+}
+
+// setTenantID records the tenant the request acts in in the gin context and
+// in the request context, where the repositories scope their queries by it
+func setTenantID(c *gin.Context, tenantID string) {
+	c.Set("tenant_id", tenantID)
+	c.Request = c.Request.WithContext(repository.WithTenant(c.Request.Context(), tenantID))
+}
+
+// GetTenantID extracts the tenant ID from context
+func GetTenantID(c *gin.Context) string {
+	if tenantID, exists := c.Get("tenant_id"); exists {
+		if id, ok := tenantID.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
 // GetUserID extracts user ID from context
 func GetUserID(c *gin.Context) string {
 	if userID, exists := c.Get("user_id"); exists {
@@ -109,14 +179,8 @@ func GetUserID(c *gin.Context) string {
 // GetUserRoles extracts user roles from context
 func GetUserRoles(c *gin.Context) []string {
 	if roles, exists := c.Get("user_roles"); exists {
-		if r, ok := roles.([]interface{}); ok {
-			result := make([]string, len(r))
-			for i, role := range r {
-				if s, ok := role.(string); ok {
-					result[i] = s
-				}
-			}
-			return result
+		if r, ok := roles.([]string); ok && r != nil {
+			return r
 		}
 	}
 	return []string{}
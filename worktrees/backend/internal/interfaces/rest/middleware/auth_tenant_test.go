@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/openfoundry/oms/internal/domain/entity"
+	"github.com/openfoundry/oms/internal/domain/repository"
+)
+
+// serveTenantToken runs one request bearing a token with the tenant claim
+// through Auth and returns the status and the tenant the handler ran in
+func serveTenantToken(t *testing.T, tenant string) (int, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	var seen string
+	router := gin.New()
+	router.GET("/", Auth(JWTOptions{Secret: testJWTSecret}), func(c *gin.Context) {
+		if GetTenantID(c) != repository.TenantFromContext(c.Request.Context()) {
+			t.Errorf("gin tenant %q differs from request tenant %q", GetTenantID(c), repository.TenantFromContext(c.Request.Context()))
+		}
+		seen = repository.TenantFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, Claims{Tenant: tenant}))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code, seen
+}
+
+func TestAuthActsInTokenTenant(t *testing.T) {
+	cases := []struct {
+		name   string
+		tenant string
+		want   string
+	}{
+		{"tenant claim", "acme", "acme"},
+		{"other tenant", "globex-eu_2", "globex-eu_2"},
+		{"no tenant claim", "", entity.DefaultTenantID},
+	}
+
+	for _, tc := range cases {
+		// Act
+		status, seen := serveTenantToken(t, tc.tenant)
+
+		// Assert
+		if status != http.StatusOK || seen != tc.want {
+			t.Errorf("%s: status %d in tenant %q, want 200 in %q", tc.name, status, seen, tc.want)
+		}
+	}
+}
+
+func TestAuthRejectsMalformedTenant(t *testing.T) {
+	tenants := []string{"Acme", "acme corp", "-acme", "acme:*", "acme/../globex"}
+
+	for _, tenant := range tenants {
+		// Act
+		status, seen := serveTenantToken(t, tenant)
+
+		// Assert
+		if status != http.StatusUnauthorized || seen != "" {
+			t.Errorf("tenant %q: status %d reaching tenant %q, want 401", tenant, status, seen)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// serveAuth runs one request bearing token through Auth with opts and
+// returns the status code
+func serveAuth(opts JWTOptions, token string) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", Auth(opts), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestAuthChecksIssuerAndAudience(t *testing.T) {
+	configured := JWTOptions{Secret: testJWTSecret, Issuer: "https://auth.example.com", Audience: "oms"}
+	cases := []struct {
+		name   string
+		opts   JWTOptions
+		issuer string
+		aud    jwt.ClaimStrings
+		want   int
+	}{
+		{"matching", configured, "https://auth.example.com", jwt.ClaimStrings{"oms"}, http.StatusOK},
+		{"audience among several", configured, "https://auth.example.com", jwt.ClaimStrings{"billing", "oms"}, http.StatusOK},
+		{"mismatching issuer", configured, "https://other.example.com", jwt.ClaimStrings{"oms"}, http.StatusUnauthorized},
+		{"mismatching audience", configured, "https://auth.example.com", jwt.ClaimStrings{"billing"}, http.StatusUnauthorized},
+		{"missing issuer", configured, "", jwt.ClaimStrings{"oms"}, http.StatusUnauthorized},
+		{"missing audience", configured, "https://auth.example.com", nil, http.StatusUnauthorized},
+		{"issuer only configured", JWTOptions{Secret: testJWTSecret, Issuer: "https://auth.example.com"}, "https://auth.example.com", nil, http.StatusOK},
+		{"audience only configured", JWTOptions{Secret: testJWTSecret, Audience: "oms"}, "", jwt.ClaimStrings{"oms"}, http.StatusOK},
+		{"not configured", JWTOptions{Secret: testJWTSecret}, "https://other.example.com", jwt.ClaimStrings{"billing"}, http.StatusOK},
+		{"not configured without claims", JWTOptions{Secret: testJWTSecret}, "", nil, http.StatusOK},
+	}
+	for _, tc := range cases {
+		// Arrange
+		token := signToken(t, Claims{RegisteredClaims: jwt.RegisteredClaims{Issuer: tc.issuer, Audience: tc.aud}})
+
+		// Act
+		status := serveAuth(tc.opts, token)
+
+		// Assert
+		if status != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, status, tc.want)
+		}
+	}
+}
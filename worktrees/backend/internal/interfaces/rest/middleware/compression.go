@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionConfig holds response compression settings
+type CompressionConfig struct {
+	// MinSize is the smallest body in bytes worth compressing
+	MinSize int
+	// Level is a compress/flate level, e.g. flate.DefaultCompression
+	Level int
+	// ExcludedPaths are path prefixes that are never compressed, such as
+	// streaming endpoints that flush as they go
+	ExcludedPaths []string
+}
+
+// Compression creates a middleware compressing responses with gzip or
+// deflate, as negotiated through Accept-Encoding. Bodies are buffered until
+// MinSize bytes have been written, so smaller responses are sent as they
+// are. Responses that already carry a Content-Encoding are left untouched.
+func Compression(cfg CompressionConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range cfg.ExcludedPaths {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, cfg: cfg}
+		c.Writer = writer
+		defer writer.finish()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header,
+// preferring gzip, or returns "" when neither is acceptable
+func negotiateEncoding(header string) string {
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				continue
+			}
+		}
+		accepted[name] = true
+	}
+
+	switch {
+	case accepted["gzip"] || accepted["*"]:
+		return "gzip"
+	case accepted["deflate"]:
+		return "deflate"
+	default:
+		return ""
+	}
+}
+
+// compressWriter buffers the start of a response until it is known to be
+// large enough to compress, then streams the rest through the compressor
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding   string
+	cfg        CompressionConfig
+	buffer     []byte
+	decided    bool
+	compressor io.WriteCloser
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		return w.writeThrough(data)
+	}
+
+	w.buffer = append(w.buffer, data...)
+	if len(w.buffer) >= w.cfg.MinSize {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what has been buffered, compressed if it is large enough
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if flusher, ok := w.compressor.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// writeThrough writes to the compressor once compression has started, and
+// to the client otherwise
+func (w *compressWriter) writeThrough(data []byte) (int, error) {
+	if w.compressor != nil {
+		return w.compressor.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// decide starts compressing if the buffered body is large enough and the
+// response can be compressed, then writes out the buffer
+func (w *compressWriter) decide() error {
+	w.decided = true
+
+	if len(w.buffer) > 0 && len(w.buffer) >= w.cfg.MinSize && w.compressible() {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+
+		var err error
+		if w.encoding == "gzip" {
+			w.compressor, err = gzip.NewWriterLevel(w.ResponseWriter, w.cfg.Level)
+		} else {
+			w.compressor, err = flate.NewWriter(w.ResponseWriter, w.cfg.Level)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	buffered := w.buffer
+	w.buffer = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	_, err := w.writeThrough(buffered)
+	return err
+}
+
+// compressible reports whether the response may be re-encoded
+func (w *compressWriter) compressible() bool {
+	switch w.Status() {
+	case http.StatusNoContent, http.StatusNotModified:
+		return false
+	}
+	header := w.Header()
+	return header.Get("Content-Encoding") == "" &&
+		!strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+}
+
+// finish writes out a response that stayed below MinSize and completes the
+// compressed stream
+func (w *compressWriter) finish() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.compressor != nil {
+		_ = w.compressor.Close()
+	}
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// compressionConfig compresses bodies of at least 1 KiB, except under
+// /api/v1/events/stream
+func compressionConfig() CompressionConfig {
+	return CompressionConfig{
+		MinSize:       1024,
+		Level:         flate.DefaultCompression,
+		ExcludedPaths: []string{"/api/v1/events/stream"},
+	}
+}
+
+// largeList is a JSON list body well above the compression threshold
+var largeList = `{"data":[` + strings.Repeat(`{"name":"Customer","displayName":"Customer"},`, 100) + `{}]}`
+
+// compressedGet serves GET path through Compression with cfg, answering with
+// status and body as JSON, or as an event stream under /api/v1/events, and
+// sends acceptEncoding unless it is empty
+func compressedGet(cfg CompressionConfig, path, acceptEncoding string, status int, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Compression(cfg))
+	router.GET("/api/v1/*path", func(c *gin.Context) {
+		contentType := "application/json"
+		if strings.HasPrefix(c.Request.URL.Path, "/api/v1/events") {
+			contentType = "text/event-stream"
+		}
+		c.Data(status, contentType, []byte(body))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// gunzip decompresses a gzip body
+func gunzip(t *testing.T, body io.Reader) string {
+	t.Helper()
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read gzip body: %v", err)
+	}
+	return string(decompressed)
+}
+
+func TestLargeListIsGzippedWhenRequested(t *testing.T) {
+	// Act
+	w := compressedGet(compressionConfig(), "/api/v1/object-types", "gzip, deflate", http.StatusOK, largeList)
+
+	// Assert
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	if w.Body.Len() >= len(largeList) {
+		t.Errorf("body = %d bytes, want fewer than the %d uncompressed", w.Body.Len(), len(largeList))
+	}
+	if got := gunzip(t, w.Body); got != largeList {
+		t.Errorf("decompressed body differs from the list response")
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("Vary = %q, want Accept-Encoding", got)
+	}
+}
+
+func TestLargeListIsSentPlainWhenCompressionIsNotRequested(t *testing.T) {
+	// Act
+	w := compressedGet(compressionConfig(), "/api/v1/object-types", "", http.StatusOK, largeList)
+
+	// Assert
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want none", got)
+	}
+	if w.Body.String() != largeList {
+		t.Errorf("body = %d bytes, want the %d byte list unchanged", w.Body.Len(), len(largeList))
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("Vary = %q, want Accept-Encoding", got)
+	}
+}
+
+func TestLargeListIsDeflatedWhenOnlyDeflateIsAccepted(t *testing.T) {
+	// Act
+	w := compressedGet(compressionConfig(), "/api/v1/object-types", "deflate", http.StatusOK, largeList)
+
+	// Assert
+	if got := w.Header().Get("Content-Encoding"); got != "deflate" {
+		t.Fatalf("Content-Encoding = %q, want deflate", got)
+	}
+	decompressed, err := io.ReadAll(flate.NewReader(w.Body))
+	if err != nil || string(decompressed) != largeList {
+		t.Errorf("inflated body = %d bytes, %v, want the list response", len(decompressed), err)
+	}
+}
+
+func TestSmallResponseIsNotCompressed(t *testing.T) {
+	// Act
+	w := compressedGet(compressionConfig(), "/api/v1/object-types/count", "gzip", http.StatusOK, `{"count":3}`)
+
+	// Assert
+	if got := w.Header().Get("Content-Encoding"); got != "" || w.Body.String() != `{"count":3}` {
+		t.Errorf("Content-Encoding = %q with body %q, want the small body sent plain", got, w.Body.String())
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := []struct {
+		header string
+		want   string
+	}{
+		{"gzip", "gzip"},
+		{"deflate, gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"GZIP;q=0.5", "gzip"},
+		{"*", "gzip"},
+		{"gzip;q=0, deflate", "deflate"},
+		{"gzip;q=0", ""},
+		{"br, identity", ""},
+		{"", ""},
+	}
+
+	for _, tc := range cases {
+		// Act
+		got := negotiateEncoding(tc.header)
+
+		// Assert
+		if got != tc.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tc.header, got, tc.want)
+		}
+	}
+}
+
+func TestEventStreamIsNotCompressed(t *testing.T) {
+	paths := []string{"/api/v1/events/stream", "/api/v1/events/other"}
+
+	for _, path := range paths {
+		// Act
+		w := compressedGet(compressionConfig(), path, "gzip", http.StatusOK, largeList)
+
+		// Assert
+		if got := w.Header().Get("Content-Encoding"); got != "" || w.Body.String() != largeList {
+			t.Errorf("%s: Content-Encoding = %q, want the event stream sent plain", path, got)
+		}
+	}
+}
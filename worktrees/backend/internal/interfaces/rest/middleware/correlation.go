@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+	"github.com/openfoundry/oms/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CorrelationIDHeader carries the correlation ID of a request and its response
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// maxCorrelationIDLength bounds caller-supplied correlation IDs
+const maxCorrelationIDLength = 128
+
+// CorrelationID creates a middleware assigning each request a correlation
+// ID, taken from the X-Correlation-ID header or generated. The ID is echoed
+// in the response, tagged on the events the request publishes and added to
+// the request-scoped logger.
+func CorrelationID(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if correlationID == "" || len(correlationID) > maxCorrelationIDLength {
+			correlationID = uuid.New().String()
+		}
+
+		c.Set("correlation_id", correlationID)
+		c.Header(CorrelationIDHeader, correlationID)
+
+		ctx := messaging.WithCorrelationID(c.Request.Context(), correlationID)
+		ctx = logger.NewContext(ctx, log.With(zap.String("correlation_id", correlationID)))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// GetCorrelationID extracts the correlation ID from context
+func GetCorrelationID(c *gin.Context) string {
+	if correlationID, exists := c.Get("correlation_id"); exists {
+		if id, ok := correlationID.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
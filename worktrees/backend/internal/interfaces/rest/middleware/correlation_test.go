@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/infrastructure/messaging"
+)
+
+// correlate sends a request with the X-Correlation-ID header set to
+// correlationID through CorrelationID, returning the response and the ID
+// found on the request context
+func correlate(correlationID string) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CorrelationID(zap.NewNop()))
+	var onContext string
+	router.GET("/", func(c *gin.Context) {
+		onContext = messaging.CorrelationIDFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(CorrelationIDHeader, correlationID)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, onContext
+}
+
+func TestCorrelationIDKeepsIncomingID(t *testing.T) {
+	// Act
+	w, onContext := correlate("req-42")
+
+	// Assert
+	if header := w.Header().Get(CorrelationIDHeader); header != "req-42" || onContext != "req-42" {
+		t.Errorf("response %q and context %q, want req-42", header, onContext)
+	}
+}
+
+func TestCorrelationIDReplacesOversizedID(t *testing.T) {
+	// Arrange
+	oversized := strings.Repeat("x", maxCorrelationIDLength+1)
+
+	// Act
+	w, onContext := correlate(oversized)
+
+	// Assert
+	header := w.Header().Get(CorrelationIDHeader)
+	if header == oversized || header == "" || onContext != header {
+		t.Errorf("response %q and context %q, want one generated ID", header, onContext)
+	}
+}
@@ -1,44 +1,112 @@
 package middleware
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Cors creates a CORS middleware
-func Cors(allowedOrigins string) gin.HandlerFunc {
+// CorsConfig configures the Cors middleware. List fields are comma-separated.
+type CorsConfig struct {
+	// AllowedOrigins lists exact origins, "*" for any origin, or patterns with
+	// a wildcard subdomain such as https://*.example.com
+	AllowedOrigins string
+	AllowedMethods string
+	AllowedHeaders string
+	// AllowCredentials lets browsers send cookies and auth headers. It only
+	// applies to origins matched by an exact or subdomain entry, never "*".
+	AllowCredentials bool
+}
+
+// Cors creates a CORS middleware. Requests from origins that are not allowed
+// get no CORS headers, and their preflight requests are rejected with 403.
+func Cors(cfg CorsConfig) gin.HandlerFunc {
+	origins := splitList(cfg.AllowedOrigins)
+	methods := strings.Join(splitList(cfg.AllowedMethods), ", ")
+	headers := strings.Join(splitList(cfg.AllowedHeaders), ", ")
+
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
-		
-		// Parse allowed origins
-		origins := strings.Split(allowedOrigins, ",")
-		allowed := false
-		
-		for _, o := range origins {
-			o = strings.TrimSpace(o)
-			if o == "*" || o == origin {
-				allowed = true
-				break
+		preflight := c.Request.Method == http.MethodOptions &&
+			c.Request.Header.Get("Access-Control-Request-Method") != ""
+
+		if origin != "" {
+			c.Header("Vary", "Origin")
+
+			matched, wildcard := matchOrigin(origins, origin)
+			if !matched {
+				if preflight {
+					c.AbortWithStatus(http.StatusForbidden)
+					return
+				}
+				c.Next()
+				return
+			}
+
+			if wildcard {
+				// Browsers reject credentials with a wildcard origin
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else {
+				c.Header("Access-Control-Allow-Origin", origin)
+				if cfg.AllowCredentials {
+					c.Header("Access-Control-Allow-Credentials", "true")
+				}
 			}
 		}
-		
-		if allowed {
-			c.Header("Access-Control-Allow-Origin", origin)
-		} else if allowedOrigins == "*" {
-			c.Header("Access-Control-Allow-Origin", "*")
-		}
-		
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Max-Age", "86400")
-		
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+
+		if c.Request.Method == http.MethodOptions {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			c.Header("Access-Control-Max-Age", "86400")
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
-		
+
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// matchOrigin reports whether origin is allowed and whether it matched only
+// through the "*" entry
+func matchOrigin(allowed []string, origin string) (matched bool, wildcard bool) {
+	for _, o := range allowed {
+		if o == origin || matchSubdomain(o, origin) {
+			return true, false
+		}
+	}
+	for _, o := range allowed {
+		if o == "*" {
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// matchSubdomain reports whether origin matches a pattern such as
+// https://*.example.com. The wildcard stands for one or more subdomain labels
+// and never matches the bare domain.
+func matchSubdomain(pattern, origin string) bool {
+	i := strings.Index(pattern, "*.")
+	if i < 0 {
+		return false
+	}
+	prefix, suffix := pattern[:i], pattern[i+1:]
+	if !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+		return false
+	}
+
+	sub := origin[len(prefix) : len(origin)-len(suffix)]
+	return sub != "" && !strings.ContainsAny(sub, "/:@")
+}
+
+// splitList splits a comma-separated list, dropping blank entries
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
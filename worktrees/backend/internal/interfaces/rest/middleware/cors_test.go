@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// preflight sends an OPTIONS preflight from origin through Cors with cfg
+func preflight(cfg CorsConfig, origin string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Cors(cfg))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// corsConfig allows app.example.com exactly and any example.org subdomain,
+// with credentials
+func corsConfig() CorsConfig {
+	return CorsConfig{
+		AllowedOrigins:   "https://app.example.com, https://*.example.org",
+		AllowedMethods:   "GET,POST",
+		AllowedHeaders:   "Authorization, Content-Type",
+		AllowCredentials: true,
+	}
+}
+
+func TestPreflightFromAllowedOriginEchoesOrigin(t *testing.T) {
+	// Act
+	w := preflight(corsConfig(), "https://app.example.com")
+
+	// Assert
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Allow-Origin = %q, want the request origin", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Allow-Credentials = %q, want true", got)
+	}
+}
+
+func TestPreflightSendsConfiguredMethodsAndHeaders(t *testing.T) {
+	// Act
+	w := preflight(corsConfig(), "https://app.example.com")
+
+	// Assert
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+		t.Errorf("Allow-Methods = %q, want %q", got, "GET, POST")
+	}
+	if got := w.Header().Get("Access-Control-Allow-Headers"); got != "Authorization, Content-Type" {
+		t.Errorf("Allow-Headers = %q, want %q", got, "Authorization, Content-Type")
+	}
+}
+
+func TestPreflightFromDisallowedOriginIsForbidden(t *testing.T) {
+	// Act
+	w := preflight(corsConfig(), "https://evil.example.net")
+
+	// Assert
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Allow-Origin = %q, want none", got)
+	}
+}
+
+func TestPreflightFromMatchingSubdomainIsAllowed(t *testing.T) {
+	// Act
+	w := preflight(corsConfig(), "https://eu.api.example.org")
+
+	// Assert
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://eu.api.example.org" {
+		t.Errorf("Allow-Origin = %q, want the request origin", got)
+	}
+}
+
+func TestSubdomainPatternDoesNotMatchBareDomain(t *testing.T) {
+	// Act
+	w := preflight(corsConfig(), "https://example.org")
+
+	// Assert
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestWildcardOriginNeverSendsCredentials(t *testing.T) {
+	// Arrange
+	cfg := corsConfig()
+	cfg.AllowedOrigins = "*"
+
+	// Act
+	w := preflight(cfg, "https://anywhere.example.net")
+
+	// Assert
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Allow-Origin = %q, want *", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Allow-Credentials = %q, want none with a wildcard origin", got)
+	}
+}
+
+func TestExactOriginKeepsCredentialsAlongsideWildcard(t *testing.T) {
+	// Arrange
+	cfg := corsConfig()
+	cfg.AllowedOrigins = "*, https://app.example.com"
+
+	// Act
+	w := preflight(cfg, "https://app.example.com")
+
+	// Assert
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+		w.Header().Get("Access-Control-Allow-Credentials") != "true" {
+		t.Errorf("headers = %v, want the origin echoed with credentials", w.Header())
+	}
+}
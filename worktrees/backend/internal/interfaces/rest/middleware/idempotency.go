@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"go.uber.org/zap"
+)
+
+// Idempotency headers
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+const (
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentBodySize bounds the request body buffered for hashing
+	maxIdempotentBodySize = 10 << 20
+	// idempotencyInFlightMaxTTL bounds how long a key stays claimed by a
+	// request that never completes, e.g. because the process died
+	idempotencyInFlightMaxTTL = time.Minute
+)
+
+// idempotencyRecord is the cached state of a request made with an
+// idempotency key. Response fields are set once the request has completed.
+type idempotencyRecord struct {
+	RequestHash string `json:"requestHash"`
+	Completed   bool   `json:"completed"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// responseRecorder captures the response body while writing it through
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency creates a middleware making requests that carry an
+// Idempotency-Key header safe to retry. The first successful response for a
+// key is stored for ttl and replayed for later requests with the same key,
+// instead of running the handler again. Keys are scoped per user. Reusing a
+// key with a different method, path or body is rejected with 422, and a retry
+// while the first request is still running with 409. Failed requests release
+// the key so they can be retried. Requests are let through without the
+// guarantee if the store is unavailable.
+func Idempotency(store cache.CacheService, ttl time.Duration, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			apierror.Abort(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "invalid idempotency key",
+				"must be at most 255 characters")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				apierror.Abort(c, http.StatusRequestEntityTooLarge, apierror.CodeRequestTooLarge, "request body too large",
+					fmt.Sprintf("must be at most %d bytes", maxIdempotentBodySize))
+				return
+			}
+			apierror.Abort(c, http.StatusBadRequest, apierror.CodeInvalidRequest, "failed to read request body", nil)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		cacheKey := "idempotency:" + GetUserID(c) + ":" + key
+		requestHash := hashRequest(c.Request.Method, c.Request.URL.Path, body)
+
+		var record idempotencyRecord
+		err = store.Get(ctx, cacheKey, &record)
+		switch {
+		case err == nil:
+			replayIdempotent(c, record, requestHash)
+			return
+		case !errors.Is(err, repository.ErrCacheMiss):
+			logger.Warn("Idempotency store unavailable, processing request without it",
+				zap.String("idempotency_key", key),
+				zap.Error(err))
+			c.Next()
+			return
+		}
+
+		// Claim the key so a concurrent retry cannot run the handler twice
+		inFlightTTL := ttl
+		if inFlightTTL > idempotencyInFlightMaxTTL {
+			inFlightTTL = idempotencyInFlightMaxTTL
+		}
+		claimed, err := store.SetNX(ctx, cacheKey, idempotencyRecord{RequestHash: requestHash}, inFlightTTL)
+		if err != nil {
+			logger.Warn("Idempotency store unavailable, processing request without it",
+				zap.String("idempotency_key", key),
+				zap.Error(err))
+			c.Next()
+			return
+		}
+		if !claimed {
+			apierror.Abort(c, http.StatusConflict, apierror.CodeIdempotencyKeyInUse,
+				"a request with this idempotency key is in progress", nil)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Settle the key even if the client went away while the handler ran,
+		// or the claim would block retries until it expires
+		ctx = context.WithoutCancel(ctx)
+		status := recorder.Status()
+		if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			if err := store.Delete(ctx, cacheKey); err != nil {
+				logger.Warn("Failed to release idempotency key",
+					zap.String("idempotency_key", key),
+					zap.Error(err))
+			}
+			return
+		}
+
+		record = idempotencyRecord{
+			RequestHash: requestHash,
+			Completed:   true,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.Set(ctx, cacheKey, record, ttl); err != nil {
+			logger.Warn("Failed to store idempotent response",
+				zap.String("idempotency_key", key),
+				zap.Error(err))
+		}
+	}
+}
+
+// replayIdempotent answers a request whose key has been seen before
+func replayIdempotent(c *gin.Context, record idempotencyRecord, requestHash string) {
+	if record.RequestHash != requestHash {
+		apierror.Abort(c, http.StatusUnprocessableEntity, apierror.CodeIdempotencyKeyConflict,
+			"idempotency key was used for a different request", nil)
+		return
+	}
+	if !record.Completed {
+		apierror.Abort(c, http.StatusConflict, apierror.CodeIdempotencyKeyInUse,
+			"a request with this idempotency key is in progress", nil)
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(record.Status, record.ContentType, record.Body)
+	c.Abort()
+}
+
+// hashRequest fingerprints a request so a reused key can be matched to it
+func hashRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
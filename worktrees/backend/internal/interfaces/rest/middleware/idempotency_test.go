@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/openfoundry/oms/internal/domain/repository"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// fakeStore is an empty idempotency store that records the context error
+// seen by the final write or release of a key
+type fakeStore struct {
+	cache.CacheService
+	settleErr error
+	settled   bool
+}
+
+func (s *fakeStore) Get(ctx context.Context, key string, dest interface{}) error {
+	return repository.ErrCacheMiss
+}
+
+func (s *fakeStore) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (s *fakeStore) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	s.settleErr, s.settled = ctx.Err(), true
+	return nil
+}
+
+func (s *fakeStore) Delete(ctx context.Context, key string) error {
+	s.settleErr, s.settled = ctx.Err(), true
+	return nil
+}
+
+// serveIdempotent sends one request with an Idempotency-Key through the
+// middleware; handler runs with the request's gin context
+func serveIdempotent(store *fakeStore, body []byte, ctx context.Context, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/", Idempotency(store, time.Hour, zap.NewNop()), handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)).WithContext(ctx)
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyRejectsOversizedBody(t *testing.T) {
+	// Arrange
+	body := bytes.Repeat([]byte("x"), maxIdempotentBodySize+1)
+
+	// Act
+	w := serveIdempotent(&fakeStore{}, body, context.Background(), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	// Assert
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestIdempotencyStoresResponseAfterClientCancels(t *testing.T) {
+	// Arrange
+	store := &fakeStore{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	serveIdempotent(store, []byte(`{}`), ctx, func(c *gin.Context) {
+		cancel()
+		c.Status(http.StatusCreated)
+	})
+
+	// Assert
+	if !store.settled || store.settleErr != nil {
+		t.Errorf("settled = %v with context error %v; want a live context", store.settled, store.settleErr)
+	}
+}
+
+func TestIdempotencyReleasesKeyAfterClientCancels(t *testing.T) {
+	// Arrange
+	store := &fakeStore{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	serveIdempotent(store, []byte(`{}`), ctx, func(c *gin.Context) {
+		cancel()
+		c.Status(http.StatusInternalServerError)
+	})
+
+	// Assert
+	if !store.settled || store.settleErr != nil {
+		t.Errorf("released = %v with context error %v; want a live context", store.settled, store.settleErr)
+	}
+}
+
+// idempotentAPI is a router whose create endpoints sit behind Idempotency
+// backed by an in-memory Redis. Each create answers with a new entity ID
+// unless fail is set.
+type idempotentAPI struct {
+	router  *gin.Engine
+	creates atomic.Int32
+	fail    atomic.Bool
+	// block, when set, holds each create until it is closed
+	block chan struct{}
+}
+
+// newIdempotentAPI builds an idempotentAPI
+func newIdempotentAPI(t *testing.T) *idempotentAPI {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	store, err := cache.NewRedisCache(miniredis.RunT(t).Addr(), "", 0, time.Minute,
+		metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewRedisCache: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	api := &idempotentAPI{router: gin.New()}
+	create := func(c *gin.Context) {
+		api.creates.Add(1)
+		if api.block != nil {
+			<-api.block
+		}
+		if api.fail.Load() {
+			c.JSON(http.StatusInternalServerError, gin.H{"code": "INTERNAL"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"id": uuid.NewString()})
+	}
+	setUser := func(c *gin.Context) { c.Set("user_id", c.GetHeader("X-Test-User")) }
+	api.router.POST("/object-types", setUser, Idempotency(store, time.Hour, zap.NewNop()), create)
+	api.router.POST("/link-types", setUser, Idempotency(store, time.Hour, zap.NewNop()), create)
+	return api
+}
+
+// post sends body to path as user with the idempotency key
+func (api *idempotentAPI) post(path, user, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", user)
+	req.Header.Set(IdempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	api.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplayReturnsTheSameEntity(t *testing.T) {
+	// Arrange
+	api := newIdempotentAPI(t)
+	first := api.post("/object-types", "alice", "key-1", `{"name":"Customer"}`)
+
+	// Act
+	replay := api.post("/object-types", "alice", "key-1", `{"name":"Customer"}`)
+
+	// Assert
+	if replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %s, want the original 201 %s", replay.Code, replay.Body.String(), first.Body.String())
+	}
+	if replay.Header().Get(IdempotentReplayedHeader) != "true" || first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Error("want only the replay marked Idempotent-Replayed")
+	}
+	if creates := api.creates.Load(); creates != 1 {
+		t.Errorf("creates = %d, want 1", creates)
+	}
+}
+
+func TestIdempotencyKeyReusedForDifferentRequestConflicts(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"different payload", "/object-types", `{"name":"Account"}`},
+		{"different endpoint", "/link-types", `{"name":"Customer"}`},
+	}
+	for _, tc := range cases {
+		// Arrange
+		api := newIdempotentAPI(t)
+		api.post("/object-types", "alice", "key-1", `{"name":"Customer"}`)
+
+		// Act
+		w := api.post(tc.path, "alice", "key-1", tc.body)
+
+		// Assert
+		if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "IDEMPOTENCY_KEY_CONFLICT") {
+			t.Errorf("%s: response = %d %s, want 422 IDEMPOTENCY_KEY_CONFLICT", tc.name, w.Code, w.Body.String())
+		}
+		if creates := api.creates.Load(); creates != 1 {
+			t.Errorf("%s: creates = %d, want 1", tc.name, creates)
+		}
+	}
+}
+
+func TestIdempotencyKeysAreScopedPerUser(t *testing.T) {
+	// Arrange
+	api := newIdempotentAPI(t)
+	alice := api.post("/object-types", "alice", "key-1", `{"name":"Customer"}`)
+
+	// Act
+	bob := api.post("/object-types", "bob", "key-1", `{"name":"Customer"}`)
+
+	// Assert
+	if bob.Code != http.StatusCreated || bob.Body.String() == alice.Body.String() || api.creates.Load() != 2 {
+		t.Errorf("bob got %d %s after %d creates, want his own entity", bob.Code, bob.Body.String(), api.creates.Load())
+	}
+}
+
+func TestIdempotencyFailedRequestCanBeRetried(t *testing.T) {
+	// Arrange
+	api := newIdempotentAPI(t)
+	api.fail.Store(true)
+	api.post("/object-types", "alice", "key-1", `{"name":"Customer"}`)
+	api.fail.Store(false)
+
+	// Act
+	retry := api.post("/object-types", "alice", "key-1", `{"name":"Customer"}`)
+
+	// Assert
+	if retry.Code != http.StatusCreated || api.creates.Load() != 2 {
+		t.Errorf("retry = %d after %d creates, want a fresh 201", retry.Code, api.creates.Load())
+	}
+}
+
+func TestIdempotencyRetryWhileInFlightIsInUse(t *testing.T) {
+	// Arrange
+	api := newIdempotentAPI(t)
+	api.block = make(chan struct{})
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- api.post("/object-types", "alice", "key-1", `{"name":"Customer"}`) }()
+	for api.creates.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Act
+	retry := api.post("/object-types", "alice", "key-1", `{"name":"Customer"}`)
+	close(api.block)
+	first := <-done
+
+	// Assert
+	if retry.Code != http.StatusConflict || !strings.Contains(retry.Body.String(), "IDEMPOTENCY_KEY_IN_USE") {
+		t.Errorf("retry = %d %s, want 409 IDEMPOTENCY_KEY_IN_USE", retry.Code, retry.Body.String())
+	}
+	if first.Code != http.StatusCreated || api.creates.Load() != 1 {
+		t.Errorf("first = %d after %d creates, want one 201", first.Code, api.creates.Load())
+	}
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// JWKS refresh intervals
+const (
+	// DefaultJWKSRefreshInterval is how long a fetched key set is used before
+	// it is fetched again
+	DefaultJWKSRefreshInterval = time.Hour
+	// jwksMinRefreshInterval limits refetches triggered by unknown key IDs, so
+	// tokens with made-up kids cannot flood the identity provider
+	jwksMinRefreshInterval = time.Minute
+	jwksFetchTimeout       = 10 * time.Second
+)
+
+// ErrUnknownKeyID is returned for tokens signed with a key missing from the key set
+var ErrUnknownKeyID = errors.New("unknown signing key")
+
+// JWKS verifies RS256 tokens against the RSA keys published at a JSON Web Key
+// Set URL. Keys are cached and refetched after the refresh interval, or
+// earlier when a token names a key that is not cached, which picks up key
+// rotation at the identity provider.
+type JWKS struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+	logger          *zap.Logger
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewJWKS creates a key set fetched from url. Keys are fetched on first use.
+func NewJWKS(url string, refreshInterval time.Duration, logger *zap.Logger) *JWKS {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultJWKSRefreshInterval
+	}
+
+	return &JWKS{
+		url:             url,
+		client:          &http.Client{Timeout: jwksFetchTimeout},
+		refreshInterval: refreshInterval,
+		logger:          logger,
+	}
+}
+
+// KeyFunc resolves the verification key named by a token's kid header. Its
+// signature matches jwt.Keyfunc.
+func (j *JWKS) KeyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("%w: token has no kid header", ErrUnknownKeyID)
+	}
+	return j.key(context.Background(), kid)
+}
+
+// key returns the cached key for kid, refetching the key set when it is stale
+// or does not contain kid
+func (j *JWKS) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	age := time.Since(j.fetchedAt)
+	key, ok := j.keys[kid]
+	if ok && age < j.refreshInterval {
+		return key, nil
+	}
+
+	if j.keys == nil || age >= j.refreshInterval || (!ok && age >= jwksMinRefreshInterval) {
+		keys, err := j.fetch(ctx)
+		if err != nil {
+			if j.keys == nil {
+				return nil, err
+			}
+			// Keep serving the previous key set while the provider is unreachable
+			j.logger.Warn("Failed to refresh JWKS, using cached keys",
+				zap.String("url", j.url),
+				zap.Error(err))
+		} else {
+			j.keys = keys
+		}
+		j.fetchedAt = time.Now()
+		key, ok = j.keys[kid]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeyID, kid)
+	}
+	return key, nil
+}
+
+// jsonWebKey is the subset of RFC 7517 JWK fields used for RSA keys
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetch downloads the key set and decodes its RSA signing keys
+func (j *JWKS) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || jwk.Kid == "" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := parseRSAPublicKey(jwk)
+		if err != nil {
+			j.logger.Warn("Skipping invalid JWKS key",
+				zap.String("kid", jwk.Kid),
+				zap.Error(err))
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	j.logger.Info("JWKS fetched",
+		zap.String("url", j.url),
+		zap.Int("keys", len(keys)))
+	return keys, nil
+}
+
+// parseRSAPublicKey decodes the base64url modulus and exponent of an RSA JWK
+func parseRSAPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
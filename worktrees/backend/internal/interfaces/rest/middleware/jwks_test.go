@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// signingKey is an RSA key pair published in a stub key set under kid
+type signingKey struct {
+	kid     string
+	private *rsa.PrivateKey
+}
+
+// newSigningKey generates an RSA key pair named kid
+func newSigningKey(t *testing.T, kid string) signingKey {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	return signingKey{kid: kid, private: private}
+}
+
+// sign returns an RS256 token issued to alice carrying kid in its header
+func (k signingKey) sign(t *testing.T, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   "alice",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(k.private)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+// jwksServer is a stub identity provider publishing a replaceable key set
+type jwksServer struct {
+	mu      sync.Mutex
+	keys    []signingKey
+	failing bool
+	fetches int
+}
+
+// publish replaces the published keys
+func (s *jwksServer) publish(keys ...signingKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+// fail makes every later fetch answer 500
+func (s *jwksServer) fail() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = true
+}
+
+// fetchCount returns how many times the key set was fetched
+func (s *jwksServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches++
+	if s.failing {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	set := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{Keys: []jsonWebKey{}}
+	for _, key := range s.keys {
+		set.Keys = append(set.Keys, jsonWebKey{
+			Kty: "RSA", Kid: key.kid, Use: "sig",
+			N: base64.RawURLEncoding.EncodeToString(key.private.N.Bytes()),
+			E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.private.E)).Bytes()),
+		})
+	}
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+// newJWKSFixture starts a stub key set publishing keys and returns it with a
+// JWKS reading from it
+func newJWKSFixture(t *testing.T, keys ...signingKey) (*jwksServer, *JWKS) {
+	t.Helper()
+	stub := &jwksServer{}
+	stub.publish(keys...)
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return stub, NewJWKS(server.URL, time.Hour, zap.NewNop())
+}
+
+// ageJWKS makes the cached key set look fetched age ago
+func ageJWKS(jwks *JWKS, age time.Duration) {
+	jwks.mu.Lock()
+	defer jwks.mu.Unlock()
+	jwks.fetchedAt = time.Now().Add(-age)
+}
+
+func TestJWKSAcceptsTokenSignedWithPublishedKey(t *testing.T) {
+	// Arrange
+	key := newSigningKey(t, "key-1")
+	_, jwks := newJWKSFixture(t, key)
+
+	// Act
+	status := serveAuth(JWTOptions{JWKS: jwks}, key.sign(t, "key-1"))
+
+	// Assert
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+}
+
+func TestJWKSRejectsInvalidTokens(t *testing.T) {
+	// Arrange
+	published := newSigningKey(t, "key-1")
+	unpublished := newSigningKey(t, "key-2")
+	_, jwks := newJWKSFixture(t, published)
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{"unknown kid", unpublished.sign(t, "key-2")},
+		{"missing kid", published.sign(t, "")},
+		{"kid of another key", unpublished.sign(t, "key-1")},
+		{"HS256 token", signToken(t, Claims{})},
+	}
+	for _, tc := range cases {
+		// Act
+		status := serveAuth(JWTOptions{Secret: testJWTSecret, JWKS: jwks}, tc.token)
+
+		// Assert
+		if status != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want %d", tc.name, status, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestJWKSCachesKeySet(t *testing.T) {
+	// Arrange
+	key := newSigningKey(t, "key-1")
+	stub, jwks := newJWKSFixture(t, key)
+	token := key.sign(t, "key-1")
+
+	// Act
+	for i := 0; i < 3; i++ {
+		serveAuth(JWTOptions{JWKS: jwks}, token)
+	}
+
+	// Assert
+	if fetches := stub.fetchCount(); fetches != 1 {
+		t.Errorf("fetches = %d, want 1", fetches)
+	}
+}
+
+func TestJWKSPicksUpRotatedKey(t *testing.T) {
+	// Arrange
+	old, rotated := newSigningKey(t, "key-1"), newSigningKey(t, "key-2")
+	stub, jwks := newJWKSFixture(t, old)
+	serveAuth(JWTOptions{JWKS: jwks}, old.sign(t, "key-1"))
+	stub.publish(rotated)
+	ageJWKS(jwks, 2*jwksMinRefreshInterval)
+
+	// Act
+	status := serveAuth(JWTOptions{JWKS: jwks}, rotated.sign(t, "key-2"))
+
+	// Assert
+	if status != http.StatusOK || stub.fetchCount() != 2 {
+		t.Errorf("status = %d after %d fetches, want 200 after a refetch", status, stub.fetchCount())
+	}
+}
+
+func TestJWKSLimitsRefetchesForUnknownKeys(t *testing.T) {
+	// Arrange
+	key := newSigningKey(t, "key-1")
+	stub, jwks := newJWKSFixture(t, key)
+	serveAuth(JWTOptions{JWKS: jwks}, key.sign(t, "key-1"))
+
+	// Act
+	for i := 0; i < 3; i++ {
+		serveAuth(JWTOptions{JWKS: jwks}, key.sign(t, "made-up"))
+	}
+
+	// Assert
+	if fetches := stub.fetchCount(); fetches != 1 {
+		t.Errorf("fetches = %d, want unknown kids answered from the cache", fetches)
+	}
+}
+
+func TestJWKSKeepsCachedKeysWhenRefreshFails(t *testing.T) {
+	// Arrange
+	key := newSigningKey(t, "key-1")
+	stub, jwks := newJWKSFixture(t, key)
+	token := key.sign(t, "key-1")
+	serveAuth(JWTOptions{JWKS: jwks}, token)
+	stub.fail()
+	ageJWKS(jwks, 2*time.Hour)
+
+	// Act
+	status := serveAuth(JWTOptions{JWKS: jwks}, token)
+
+	// Assert
+	if status != http.StatusOK || stub.fetchCount() != 2 {
+		t.Errorf("status = %d after %d fetches, want 200 from the cached keys after a failed refresh", status, stub.fetchCount())
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/domain/service"
+)
+
+// Loaders creates a middleware installing request-scoped data loaders on the
+// request context, so lookups made while serving one request are batched
+func Loaders(objectTypes *service.ObjectTypeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := service.WithObjectTypeLoader(c.Request.Context(), service.NewObjectTypeLoader(objectTypes))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -37,6 +37,14 @@ func Logger(logger *zap.Logger) gin.HandlerFunc {
 			zap.String("user_agent", c.Request.UserAgent()),
 		}
 
+		if correlationID := GetCorrelationID(c); correlationID != "" {
+			fields = append(fields, zap.String("correlation_id", correlationID))
+		}
+
+		if tenantID := GetTenantID(c); tenantID != "" {
+			fields = append(fields, zap.String("tenant_id", tenantID))
+		}
+
 		if errorMessage != "" {
 			fields = append(fields, zap.String("error", errorMessage))
 		}
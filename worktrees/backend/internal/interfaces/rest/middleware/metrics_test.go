@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+)
+
+// serveMetrics sends GET path to a router recording m, with one route
+// /things/:id answering 201
+func serveMetrics(m *metrics.Metrics, path string) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Metrics(m))
+	router.GET("/things/:id", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+// requestCount returns how many requests m recorded with the given labels
+func requestCount(t *testing.T, m *metrics.Metrics, method, route, status string) uint64 {
+	t.Helper()
+	var metric dto.Metric
+	observer := m.HTTPRequestDuration.WithLabelValues(method, route, status)
+	if err := observer.(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatalf("read histogram: %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestMetricsRecordsRequestByRouteTemplate(t *testing.T) {
+	// Arrange
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+
+	// Act
+	serveMetrics(m, "/things/42")
+
+	// Assert
+	if got := requestCount(t, m, http.MethodGet, "/things/:id", "201"); got != 1 {
+		t.Errorf("requests recorded for GET /things/:id 201 = %d, want 1", got)
+	}
+}
+
+func TestMetricsRecordsUnmatchedRoutesUnderOneLabel(t *testing.T) {
+	// Arrange
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+
+	// Act
+	serveMetrics(m, "/nope/1")
+	serveMetrics(m, "/nope/2")
+
+	// Assert
+	if got := requestCount(t, m, http.MethodGet, "unmatched", "404"); got != 2 {
+		t.Errorf("requests recorded for unmatched 404 = %d, want 2", got)
+	}
+}
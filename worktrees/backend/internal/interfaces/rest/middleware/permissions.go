@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+)
+
+// Permissions checked by route guards
+const (
+	PermObjectTypeWrite  = "objecttype:write"
+	PermObjectTypeDelete = "objecttype:delete"
+	// PermObjectTypePurge covers listing, restoring and purging deleted object
+	// types, and batch deletes
+	PermObjectTypePurge = "objecttype:purge"
+	PermLinkTypeWrite   = "linktype:write"
+	PermLinkTypeDelete  = "linktype:delete"
+	PermOntologyImport  = "ontology:import"
+	PermEventReplay     = "events:replay"
+	PermEventStream     = "events:stream"
+	PermWebhookManage   = "webhooks:manage"
+	PermAuditRead       = "audit:read"
+	PermCacheManage     = "cache:manage"
+)
+
+// RolePermissions maps each role to the permissions it grants
+var RolePermissions = map[string][]string{
+	"admin": {
+		PermObjectTypeWrite, PermObjectTypeDelete, PermObjectTypePurge,
+		PermLinkTypeWrite, PermLinkTypeDelete,
+		PermOntologyImport, PermEventReplay, PermEventStream,
+		PermWebhookManage, PermAuditRead, PermCacheManage,
+	},
+	"editor": {
+		PermObjectTypeWrite, PermObjectTypeDelete,
+		PermLinkTypeWrite, PermLinkTypeDelete,
+	},
+}
+
+// resolvePermissions returns the permissions granted by roles plus any
+// granted directly
+func resolvePermissions(roles, granted []string) []string {
+	seen := make(map[string]bool)
+	permissions := []string{}
+	add := func(perm string) {
+		if !seen[perm] {
+			seen[perm] = true
+			permissions = append(permissions, perm)
+		}
+	}
+
+	for _, role := range roles {
+		for _, perm := range RolePermissions[role] {
+			add(perm)
+		}
+	}
+	for _, perm := range granted {
+		add(perm)
+	}
+	return permissions
+}
+
+// GetUserPermissions extracts user permissions from context
+func GetUserPermissions(c *gin.Context) []string {
+	if permissions, exists := c.Get("user_permissions"); exists {
+		if p, ok := permissions.([]string); ok {
+			return p
+		}
+	}
+	return []string{}
+}
+
+// HasPermission checks if user has a specific permission
+func HasPermission(c *gin.Context, perm string) bool {
+	for _, p := range GetUserPermissions(c) {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireRole creates a middleware rejecting users that hold none of roles
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, role := range roles {
+			if HasRole(c, role) {
+				c.Next()
+				return
+			}
+		}
+
+		apierror.Abort(c, http.StatusForbidden, apierror.CodeForbidden, "insufficient permissions", nil)
+	}
+}
+
+// RequirePermission creates a middleware rejecting users without perm
+func RequirePermission(perm string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, perm) {
+			apierror.Abort(c, http.StatusForbidden, apierror.CodeForbidden, "insufficient permissions", "missing permission "+perm)
+			return
+		}
+
+		c.Next()
+	}
+}
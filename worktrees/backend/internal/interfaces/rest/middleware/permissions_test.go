@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testJWTSecret signs the HS256 tokens of these tests
+const testJWTSecret = "test-secret"
+
+// signToken returns an HS256 token for claims issued to alice
+func signToken(t *testing.T, claims Claims) string {
+	t.Helper()
+	claims.Subject = "alice"
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Hour))
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+// serveGuarded runs one request bearing token through Auth and guard and
+// returns the status code
+func serveGuarded(t *testing.T, token string, guard gin.HandlerFunc) int {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.DELETE("/", Auth(JWTOptions{Secret: testJWTSecret}), guard, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRequirePermissionAllowsEditorToDelete(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{Roles: []string{"editor"}})
+
+	// Act
+	status := serveGuarded(t, token, RequirePermission(PermObjectTypeDelete))
+
+	// Assert
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+}
+
+func TestRequirePermissionDeniesEditorPurge(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{Roles: []string{"editor"}})
+
+	// Act
+	status := serveGuarded(t, token, RequirePermission(PermObjectTypePurge))
+
+	// Assert
+	if status != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", status, http.StatusForbidden)
+	}
+}
+
+func TestRequirePermissionAllowsAdminPurge(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{Roles: []string{"admin"}})
+
+	// Act
+	status := serveGuarded(t, token, RequirePermission(PermObjectTypePurge))
+
+	// Assert
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+}
+
+func TestRequirePermissionDeniesUnknownRole(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{Roles: []string{"viewer"}})
+
+	// Act
+	status := serveGuarded(t, token, RequirePermission(PermObjectTypeWrite))
+
+	// Assert
+	if status != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", status, http.StatusForbidden)
+	}
+}
+
+func TestRequirePermissionAllowsDirectlyGrantedPermission(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{Permissions: []string{PermLinkTypeDelete}})
+
+	// Act
+	status := serveGuarded(t, token, RequirePermission(PermLinkTypeDelete))
+
+	// Assert
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+}
+
+func TestRequirePermissionDeniesTokenWithoutRolesClaim(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{})
+
+	// Act
+	status := serveGuarded(t, token, RequirePermission(PermObjectTypeWrite))
+
+	// Assert
+	if status != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", status, http.StatusForbidden)
+	}
+}
+
+func TestRequireRoleAllowsListedRole(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{Roles: []string{"editor"}})
+
+	// Act
+	status := serveGuarded(t, token, RequireRole("admin", "editor"))
+
+	// Assert
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+}
+
+func TestRequireRoleDeniesOtherRole(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{Roles: []string{"editor"}})
+
+	// Act
+	status := serveGuarded(t, token, RequireRole("admin"))
+
+	// Assert
+	if status != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", status, http.StatusForbidden)
+	}
+}
+
+func TestRequireRoleDeniesTokenWithoutRolesClaim(t *testing.T) {
+	// Arrange
+	token := signToken(t, Claims{})
+
+	// Act
+	status := serveGuarded(t, token, RequireRole("admin"))
+
+	// Assert
+	if status != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", status, http.StatusForbidden)
+	}
+}
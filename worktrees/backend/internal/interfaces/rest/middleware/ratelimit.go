@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
+	"go.uber.org/zap"
+)
+
+// RateLimitStore holds the token buckets used by RateLimit. The in-memory
+// store limits per instance; a shared store such as Redis can be swapped in to
+// limit across replicas.
+type RateLimitStore interface {
+	// Take consumes one token from the bucket for key. When the bucket is
+	// empty it reports false and how long until a token is available.
+	Take(ctx context.Context, key string, rate float64, burst int) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// RateLimit creates a middleware allowing each client rate requests per second
+// with bursts of up to burst requests. Clients are identified by their
+// authenticated user ID, or by remote IP when unauthenticated, so it should be
+// installed after Auth. Rejected requests get 429 with a Retry-After header.
+func RateLimit(store RateLimitStore, rate float64, burst int, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := "ip:" + c.ClientIP()
+		if userID := GetUserID(c); userID != "" {
+			key = "user:" + userID
+		}
+
+		allowed, retryAfter, err := store.Take(c.Request.Context(), key, rate, burst)
+		if err != nil {
+			// Fail open: an unavailable limiter should not take the API down
+			logger.Warn("Rate limiter unavailable", zap.String("key", key), zap.Error(err))
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			apierror.Abort(c, http.StatusTooManyRequests, apierror.CodeRateLimited, "rate limit exceeded", nil)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitSweepInterval is how often idle buckets are dropped from memory
+const rateLimitSweepInterval = time.Minute
+
+// tokenBucket is the state of one client's bucket
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// MemoryRateLimitStore keeps token buckets in process memory
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimitStore creates an in-memory rate limit store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return newMemoryRateLimitStore(time.Now)
+}
+
+// newMemoryRateLimitStore creates a store reading time from now
+func newMemoryRateLimitStore(now func() time.Time) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: now(),
+		now:       now,
+	}
+}
+
+// Take implements RateLimitStore
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= rateLimitSweepInterval {
+		s.sweep(now, rate, burst)
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(burst), last: now}
+		s.buckets[key] = bucket
+	}
+
+	// Refill for the time elapsed since the last request
+	bucket.tokens = math.Min(float64(burst), bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / rate * float64(time.Second))
+		return false, wait, nil
+	}
+
+	bucket.tokens--
+	return true, 0, nil
+}
+
+// sweep drops buckets that have refilled completely, since a fresh bucket
+// behaves the same
+func (s *MemoryRateLimitStore) sweep(now time.Time, rate float64, burst int) {
+	for key, bucket := range s.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*rate >= float64(burst) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
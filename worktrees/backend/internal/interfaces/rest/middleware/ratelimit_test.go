@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// fakeClock is a settable time source
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// newTestRateLimitStore returns an in-memory store on a fake clock
+func newTestRateLimitStore() (*MemoryRateLimitStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	return newMemoryRateLimitStore(clock.Now), clock
+}
+
+// takeN takes n tokens for key at rate 2/s with a burst of 3 and returns
+// how many were allowed
+func takeN(store *MemoryRateLimitStore, key string, n int) int {
+	allowed := 0
+	for i := 0; i < n; i++ {
+		if ok, _, _ := store.Take(context.Background(), key, 2, 3); ok {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func TestRateLimitAllowsBurst(t *testing.T) {
+	// Arrange
+	store, _ := newTestRateLimitStore()
+
+	// Act
+	allowed := takeN(store, "user:alice", 5)
+
+	// Assert
+	if allowed != 3 {
+		t.Errorf("allowed = %d, want the burst of 3", allowed)
+	}
+}
+
+func TestRateLimitRefillsAtRate(t *testing.T) {
+	// Arrange
+	store, clock := newTestRateLimitStore()
+	takeN(store, "user:alice", 3)
+	clock.now = clock.now.Add(time.Second)
+
+	// Act
+	allowed := takeN(store, "user:alice", 5)
+
+	// Assert
+	if allowed != 2 {
+		t.Errorf("allowed = %d, want 2 refilled in one second at 2/s", allowed)
+	}
+}
+
+func TestRateLimitRefillStopsAtBurst(t *testing.T) {
+	// Arrange
+	store, clock := newTestRateLimitStore()
+	takeN(store, "user:alice", 3)
+	clock.now = clock.now.Add(time.Hour)
+
+	// Act
+	allowed := takeN(store, "user:alice", 10)
+
+	// Assert
+	if allowed != 3 {
+		t.Errorf("allowed = %d, want no more than the burst of 3", allowed)
+	}
+}
+
+func TestRateLimitReportsWaitForNextToken(t *testing.T) {
+	// Arrange
+	store, _ := newTestRateLimitStore()
+	takeN(store, "user:alice", 3)
+
+	// Act
+	_, retryAfter, _ := store.Take(context.Background(), "user:alice", 2, 3)
+
+	// Assert
+	if retryAfter != 500*time.Millisecond {
+		t.Errorf("retryAfter = %v, want 500ms at 2/s", retryAfter)
+	}
+}
+
+func TestRateLimitKeepsClientsApart(t *testing.T) {
+	// Arrange
+	store, _ := newTestRateLimitStore()
+	takeN(store, "user:alice", 3)
+
+	// Act
+	allowed := takeN(store, "user:bob", 1)
+
+	// Assert
+	if allowed != 1 {
+		t.Error("bob was limited by alice's requests")
+	}
+}
+
+func TestRateLimitMiddlewareRejectsWithRetryAfter(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	store, _ := newTestRateLimitStore()
+	router := gin.New()
+	router.GET("/", RateLimit(store, 2, 1, zap.NewNop()), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "1" {
+		t.Errorf("status = %d, Retry-After = %q, want 429 and 1", w.Code, w.Header().Get("Retry-After"))
+	}
+}
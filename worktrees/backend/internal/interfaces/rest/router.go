@@ -6,12 +6,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/openfoundry/oms/internal/config"
+	"github.com/openfoundry/oms/internal/infrastructure/cache"
+	"github.com/openfoundry/oms/internal/infrastructure/metrics"
+	"github.com/openfoundry/oms/internal/infrastructure/repository"
+	"github.com/openfoundry/oms/internal/interfaces/rest/apierror"
 	"github.com/openfoundry/oms/internal/interfaces/rest/middleware"
 	"go.uber.org/zap"
 )
 
 // NewRouter creates a new HTTP router
-func NewRouter(cfg *config.Config, db *sql.DB, logger *zap.Logger) http.Handler {
+func NewRouter(cfg *config.Config, db *sql.DB, cacheService cache.CacheService, m *metrics.Metrics, logger *zap.Logger) http.Handler {
 	// Set Gin mode based on environment
 	if cfg.Server.Mode == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -22,8 +26,18 @@ func NewRouter(cfg *config.Config, db *sql.DB, logger *zap.Logger) http.Handler
 
 	// Global middleware
 	router.Use(gin.Recovery())
+	router.Use(middleware.CorrelationID(logger))
 	router.Use(middleware.Logger(logger))
-	router.Use(middleware.Cors(cfg.Security.AllowedOrigins))
+	router.Use(middleware.Cors(middleware.CorsConfig{
+		AllowedOrigins:   cfg.Security.AllowedOrigins,
+		AllowedMethods:   cfg.Security.CORSAllowedMethods,
+		AllowedHeaders:   cfg.Security.CORSAllowedHeaders,
+		AllowCredentials: cfg.Security.CORSAllowCredentials,
+	}))
+	router.Use(middleware.Tracing("oms"))
+	if cfg.Metrics.Enabled {
+		router.Use(middleware.Metrics(m))
+	}
 
 	// Health check endpoints
 	router.GET("/health/live", func(c *gin.Context) {
@@ -46,31 +60,116 @@ func NewRouter(cfg *config.Config, db *sql.DB, logger *zap.Logger) http.Handler
 	// API routes
 	v1 := router.Group("/api/v1")
 	{
-		// Authentication middleware for API routes
-		v1.Use(middleware.Auth(cfg.Security.JWTSecret))
+		// Compress large responses; the event stream flushes per event and
+		// is left alone
+		if cfg.Server.CompressionEnabled {
+			v1.Use(middleware.Compression(middleware.CompressionConfig{
+				MinSize:       cfg.Server.CompressionMinSize,
+				Level:         cfg.Server.CompressionLevel,
+				ExcludedPaths: []string{"/api/v1/events/stream"},
+			}))
+		}
+
+		// Authentication middleware for API routes: bearer JWT or API key
+		apiKeys := repository.NewPostgresAPIKeyRepository(db)
+		jwtOpts := middleware.JWTOptions{
+			Secret:   cfg.Security.JWTSecret,
+			Issuer:   cfg.Security.JWTIssuer,
+			Audience: cfg.Security.JWTAudience,
+		}
+		if cfg.Security.JWTJWKSURL != "" {
+			jwtOpts.JWKS = middleware.NewJWKS(cfg.Security.JWTJWKSURL, cfg.Security.JWTJWKSRefreshInterval, logger)
+		}
+		v1.Use(middleware.Authenticate(jwtOpts, cfg.Security.APIKeyHeader, apiKeys))
 
-		// Object types endpoints
+		// Per-client rate limiting, keyed by the authenticated user
+		if cfg.Security.RateLimitRPS > 0 {
+			v1.Use(middleware.RateLimit(middleware.NewMemoryRateLimitStore(),
+				cfg.Security.RateLimitRPS, cfg.Security.RateLimitBurst, logger))
+		}
+
+		// Create endpoints replay the stored response for a repeated
+		// Idempotency-Key instead of creating again
+		idempotent := middleware.Idempotency(cacheService, cfg.Server.IdempotencyTTL, logger)
+
+		// Object types endpoints; writes are guarded by permissions granted
+		// through JWT roles (see middleware.RolePermissions)
 		objectTypes := v1.Group("/object-types")
 		{
 			objectTypes.GET("", handleListObjectTypes)
-			objectTypes.POST("", handleCreateObjectType)
+			objectTypes.POST("", middleware.RequirePermission(middleware.PermObjectTypeWrite), idempotent, handleCreateObjectType)
+			objectTypes.POST("/batch", middleware.RequirePermission(middleware.PermObjectTypeWrite), idempotent, handleBatchCreateObjectTypes)
+			objectTypes.POST("/batch-get", handleBatchGetObjectTypes)
+			objectTypes.POST("/tags/bulk", middleware.RequirePermission(middleware.PermObjectTypeWrite), handleBulkTagObjectTypes)
+			objectTypes.POST("/batch-delete", middleware.RequirePermission(middleware.PermObjectTypePurge), handleBatchDeleteObjectTypes)
+			objectTypes.GET("/deleted", middleware.RequirePermission(middleware.PermObjectTypePurge), handleListDeletedObjectTypes)
+			objectTypes.GET("/suggest", handleSuggestObjectTypeNames)
+			objectTypes.GET("/facets", handleGetObjectTypeFacets)
+			objectTypes.GET("/count", handleCountObjectTypes)
 			objectTypes.GET("/:id", handleGetObjectType)
-			objectTypes.PUT("/:id", handleUpdateObjectType)
-			objectTypes.DELETE("/:id", handleDeleteObjectType)
+			objectTypes.PUT("/:id", middleware.RequirePermission(middleware.PermObjectTypeWrite), handleUpdateObjectType)
+			objectTypes.POST("/:id/tags", middleware.RequirePermission(middleware.PermObjectTypeWrite), handleAddObjectTypeTag)
+			objectTypes.DELETE("/:id/tags/:tag", middleware.RequirePermission(middleware.PermObjectTypeWrite), handleRemoveObjectTypeTag)
+			objectTypes.PATCH("/:id/properties/reorder", middleware.RequirePermission(middleware.PermObjectTypeWrite), handleReorderObjectTypeProperties)
+			objectTypes.DELETE("/:id", middleware.RequirePermission(middleware.PermObjectTypeDelete), handleDeleteObjectType)
+			objectTypes.GET("/:id/graph", handleGetObjectTypeGraph)
+			objectTypes.GET("/:id/schema.json", handleGetObjectTypeSchema)
+			objectTypes.GET("/:id/openapi.yaml", handleGetObjectTypeOpenAPI)
+			objectTypes.GET("/:id/defaults", handleGetObjectTypeDefaults)
+			objectTypes.POST("/:id/validate", handleValidateObjectTypeInstance)
+			objectTypes.POST("/:id/check-compatibility", handleCheckObjectTypeCompatibility)
+			objectTypes.GET("/:id/usage", handleGetObjectTypeUsage)
+			objectTypes.POST("/:id/clone", middleware.RequirePermission(middleware.PermObjectTypeWrite), idempotent, handleCloneObjectType)
+			objectTypes.POST("/:id/restore", middleware.RequirePermission(middleware.PermObjectTypePurge), handleRestoreObjectType)
+			objectTypes.GET("/:id/versions", handleListObjectTypeVersions)
+			objectTypes.GET("/:id/versions/history", handleGetObjectTypeVersionHistory)
+			objectTypes.GET("/:id/versions/:version/resolved", handleGetResolvedObjectTypeVersion)
+			objectTypes.POST("/:id/versions/:version/restore", middleware.RequirePermission(middleware.PermObjectTypeWrite), handleRestoreObjectTypeVersion)
 		}
 
 		// Link types endpoints
 		linkTypes := v1.Group("/link-types")
 		{
 			linkTypes.GET("", handleListLinkTypes)
-			linkTypes.POST("", handleCreateLinkType)
+			linkTypes.POST("", middleware.RequirePermission(middleware.PermLinkTypeWrite), idempotent, handleCreateLinkType)
+			linkTypes.GET("/search", handleSearchLinkTypes)
+			linkTypes.GET("/count", handleCountLinkTypes)
 			linkTypes.GET("/:id", handleGetLinkType)
-			linkTypes.PUT("/:id", handleUpdateLinkType)
-			linkTypes.DELETE("/:id", handleDeleteLinkType)
+			linkTypes.PUT("/:id", middleware.RequirePermission(middleware.PermLinkTypeWrite), handleUpdateLinkType)
+			linkTypes.DELETE("/:id", middleware.RequirePermission(middleware.PermLinkTypeDelete), handleDeleteLinkType)
 		}
 
 		// Search endpoint
 		v1.GET("/search", handleSearch)
+
+		// Ontology bundle endpoints
+		v1.GET("/export", handleExportBundle)
+		v1.POST("/import", middleware.RequirePermission(middleware.PermOntologyImport), handleImportBundle)
+
+		// Event replay re-dispatches an aggregate's stored events to consumers
+		v1.POST("/events/:aggregateId/replay", middleware.RequirePermission(middleware.PermEventReplay), handleReplayEvents)
+		// Event stream pushes consumed events to clients as Server-Sent Events
+		v1.GET("/events/stream", middleware.RequirePermission(middleware.PermEventStream), handleStreamEvents)
+
+		// Webhook subscriptions receive change events over HTTP
+		webhooks := v1.Group("/webhook-subscriptions", middleware.RequirePermission(middleware.PermWebhookManage))
+		{
+			webhooks.GET("", handleListWebhookSubscriptions)
+			webhooks.POST("", idempotent, handleCreateWebhookSubscription)
+			webhooks.GET("/:id", handleGetWebhookSubscription)
+			webhooks.PUT("/:id", handleUpdateWebhookSubscription)
+			webhooks.DELETE("/:id", handleDeleteWebhookSubscription)
+		}
+
+		// Audit log of object and link type mutations
+		v1.GET("/audit", middleware.RequirePermission(middleware.PermAuditRead), handleListAuditEntries)
+
+		// Cache warming loads every object and link type into Redis
+		cacheAdmin := v1.Group("/admin/cache", middleware.RequirePermission(middleware.PermCacheManage))
+		{
+			cacheAdmin.POST("/warm", handleWarmCache)
+			cacheAdmin.GET("/warm", handleGetCacheWarmStatus)
+		}
 	}
 
 	// GraphQL endpoint (to be implemented)
@@ -79,7 +178,7 @@ func NewRouter(cfg *config.Config, db *sql.DB, logger *zap.Logger) http.Handler
 
 	// Metrics endpoint
 	if cfg.Metrics.Enabled {
-		router.GET(cfg.Metrics.Path, handleMetrics)
+		router.GET(cfg.Metrics.Path, gin.WrapH(m.Handler()))
 	}
 
 	return router
@@ -87,57 +186,205 @@ func NewRouter(cfg *config.Config, db *sql.DB, logger *zap.Logger) http.Handler
 
 // Placeholder handlers - to be implemented
 func handleListObjectTypes(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleCreateObjectType(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleGetObjectType(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleUpdateObjectType(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleDeleteObjectType(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleBatchCreateObjectTypes(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetObjectTypeGraph(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetObjectTypeSchema(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetObjectTypeOpenAPI(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleListDeletedObjectTypes(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleSuggestObjectTypeNames(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetObjectTypeDefaults(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleBatchGetObjectTypes(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleBatchDeleteObjectTypes(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleCheckObjectTypeCompatibility(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetObjectTypeUsage(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetObjectTypeVersionHistory(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleValidateObjectTypeInstance(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleReorderObjectTypeProperties(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleCloneObjectType(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleRestoreObjectType(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetObjectTypeFacets(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleCountObjectTypes(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleBulkTagObjectTypes(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleAddObjectTypeTag(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleRemoveObjectTypeTag(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleListObjectTypeVersions(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetResolvedObjectTypeVersion(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleRestoreObjectTypeVersion(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleListLinkTypes(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleCreateLinkType(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleGetLinkType(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleUpdateLinkType(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleDeleteLinkType(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleSearchLinkTypes(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleCountLinkTypes(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleSearch(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleExportBundle(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleImportBundle(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleReplayEvents(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleStreamEvents(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleListWebhookSubscriptions(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleCreateWebhookSubscription(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetWebhookSubscription(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleUpdateWebhookSubscription(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleDeleteWebhookSubscription(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleListAuditEntries(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleWarmCache(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
+}
+
+func handleGetCacheWarmStatus(c *gin.Context) {
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleGraphQL(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	apierror.Write(c, http.StatusNotImplemented, apierror.CodeNotImplemented, "not implemented", nil)
 }
 
 func handleGraphQLPlayground(c *gin.Context) {
 	c.String(http.StatusNotImplemented, "GraphQL Playground not implemented")
 }
-
-func handleMetrics(c *gin.Context) {
-	c.String(http.StatusNotImplemented, "Metrics not implemented")
-}
\ No newline at end of file
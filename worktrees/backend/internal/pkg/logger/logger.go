@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"os"
 
 	"go.uber.org/zap"
@@ -55,4 +56,20 @@ func WithContext(logger *zap.Logger, ctx map[string]interface{}) *zap.Logger {
 		fields = append(fields, zap.Any(k, v))
 	}
 	return logger.With(fields...)
+}
+
+// contextKey is the context key for the request-scoped logger
+type contextKey struct{}
+
+// NewContext returns a context carrying a request-scoped logger
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger on ctx, or fallback if there is none
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return fallback
 }
\ No newline at end of file
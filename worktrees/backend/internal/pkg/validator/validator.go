@@ -19,8 +19,25 @@ var (
 	
 	// URL pattern
 	urlPattern = regexp.MustCompile(`^https?://[^\s/$.?#].[^\s]*$`)
+
+	// snake_case pattern: lowercase words separated by single underscores
+	snakeCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+)
+
+// Name case policies
+const (
+	NameCaseAny   = "any"        // Only the default name patterns apply
+	NameCaseCamel = "camelCase"  // No underscores; type names may start uppercase
+	NameCaseSnake = "snake_case" // Lowercase words separated by single underscores
 )
 
+// NameCasePolicies lists the valid name case policies
+var NameCasePolicies = []string{NameCaseAny, NameCaseCamel, NameCaseSnake}
+
+// NameCasePolicy is the case convention object type and property names must
+// follow on top of their default patterns, set from configuration at startup
+var NameCasePolicy = NameCaseAny
+
 // ValidateObjectTypeName validates an object type name
 func ValidateObjectTypeName(name string) error {
 	if name == "" {
@@ -34,6 +51,10 @@ func ValidateObjectTypeName(name string) error {
 	if !objectTypeNamePattern.MatchString(name) {
 		return fmt.Errorf("name must start with a letter and contain only alphanumeric characters and underscores")
 	}
+
+	if err := ValidateNameCase("name", name); err != nil {
+		return err
+	}
 	
 	// Check for reserved words
 	reserved := []string{"system", "meta", "internal", "private", "public"}
@@ -60,6 +81,10 @@ func ValidatePropertyName(name string) error {
 	if !propertyNamePattern.MatchString(name) {
 		return fmt.Errorf("property name must start with a lowercase letter and contain only alphanumeric characters and underscores")
 	}
+
+	if err := ValidateNameCase("property name", name); err != nil {
+		return err
+	}
 	
 	// Check for reserved property names
 	reserved := []string{"id", "createdAt", "updatedAt", "createdBy", "updatedBy", "version"}
@@ -72,6 +97,22 @@ func ValidatePropertyName(name string) error {
 	return nil
 }
 
+// ValidateNameCase checks a name that matched its default pattern against
+// NameCasePolicy. kind names the field in the error, e.g. "property name".
+func ValidateNameCase(kind, name string) error {
+	switch NameCasePolicy {
+	case NameCaseCamel:
+		if strings.Contains(name, "_") {
+			return fmt.Errorf("%s must be camelCase and contain no underscores", kind)
+		}
+	case NameCaseSnake:
+		if !snakeCasePattern.MatchString(name) {
+			return fmt.Errorf("%s must be snake_case: lowercase letters and digits separated by single underscores", kind)
+		}
+	}
+	return nil
+}
+
 // ValidateEmail validates an email address
 func ValidateEmail(email string) error {
 	if email == "" {
@@ -176,4 +217,20 @@ func ValidateSortBy(field string, allowedFields []string) (string, error) {
 	}
 	
 	return "", fmt.Errorf("invalid sort field: %s", field)
-}
\ No newline at end of file
+}
+
+// ValidateSearchMode validates a search mode, returning "" when none is given
+func ValidateSearchMode(mode string, allowedModes []string) (string, error) {
+	if mode == "" {
+		return "", nil
+	}
+	
+	mode = strings.ToLower(mode)
+	for _, allowed := range allowedModes {
+		if mode == allowed {
+			return mode, nil
+		}
+	}
+	
+	return "", fmt.Errorf("search mode must be one of: %s", strings.Join(allowedModes, ", "))
+}
@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+// searchModes mirrors searchModes, which this package cannot import
+var searchModes = []string{"plain", "phrase", "prefix"}
+
+func TestValidateSearchModeAcceptsPrefix(t *testing.T) {
+	// Act
+	mode, err := ValidateSearchMode("Prefix", searchModes)
+
+	// Assert
+	if err != nil || mode != "prefix" {
+		t.Errorf("ValidateSearchMode = %q, %v, want prefix", mode, err)
+	}
+}
+
+func TestValidateSearchModeDefaultsToEmpty(t *testing.T) {
+	// Act
+	mode, err := ValidateSearchMode("", searchModes)
+
+	// Assert
+	if err != nil || mode != "" {
+		t.Errorf("ValidateSearchMode = %q, %v, want empty", mode, err)
+	}
+}
+
+func TestValidateSearchModeRejectsUnknownMode(t *testing.T) {
+	// Act
+	_, err := ValidateSearchMode("regex", searchModes)
+
+	// Assert
+	if err == nil {
+		t.Error("ValidateSearchMode accepted regex")
+	}
+}
+
+// withNameCasePolicy sets NameCasePolicy for the rest of the test
+func withNameCasePolicy(t *testing.T, policy string) {
+	t.Helper()
+	previous := NameCasePolicy
+	NameCasePolicy = policy
+	t.Cleanup(func() { NameCasePolicy = previous })
+}
+
+func TestNameCasePoliciesAcceptAndRejectPropertyNames(t *testing.T) {
+	cases := []struct {
+		policy string
+		name   string
+		valid  bool
+	}{
+		{NameCaseAny, "firstName", true},
+		{NameCaseAny, "first_name", true},
+		{NameCaseAny, "first__Name_", true},
+		{NameCaseAny, "FirstName", false},
+		{NameCaseCamel, "firstName", true},
+		{NameCaseCamel, "address2", true},
+		{NameCaseCamel, "first_name", false},
+		{NameCaseCamel, "FirstName", false},
+		{NameCaseSnake, "first_name", true},
+		{NameCaseSnake, "address_line_2", true},
+		{NameCaseSnake, "email", true},
+		{NameCaseSnake, "firstName", false},
+		{NameCaseSnake, "first__name", false},
+		{NameCaseSnake, "first_name_", false},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		withNameCasePolicy(t, tc.policy)
+
+		// Act
+		err := ValidatePropertyName(tc.name)
+
+		// Assert
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: ValidatePropertyName(%q) = %v, want valid %t", tc.policy, tc.name, err, tc.valid)
+		}
+	}
+}
+
+func TestNameCasePoliciesAcceptAndRejectObjectTypeNames(t *testing.T) {
+	cases := []struct {
+		policy string
+		name   string
+		valid  bool
+	}{
+		{NameCaseAny, "Customer", true},
+		{NameCaseAny, "SalesOrder", true},
+		{NameCaseAny, "Sales_Order", true},
+		{NameCaseCamel, "SalesOrder", true},
+		{NameCaseCamel, "salesOrder", true},
+		{NameCaseCamel, "Sales_Order", false},
+		{NameCaseSnake, "sales_order", true},
+		{NameCaseSnake, "customer", true},
+		{NameCaseSnake, "SalesOrder", false},
+		{NameCaseSnake, "Sales_Order", false},
+	}
+
+	for _, tc := range cases {
+		// Arrange
+		withNameCasePolicy(t, tc.policy)
+
+		// Act
+		err := ValidateObjectTypeName(tc.name)
+
+		// Assert
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: ValidateObjectTypeName(%q) = %v, want valid %t", tc.policy, tc.name, err, tc.valid)
+		}
+	}
+}
+
+func TestNameCasePolicyErrorNamesTheConvention(t *testing.T) {
+	// Arrange
+	withNameCasePolicy(t, NameCaseSnake)
+
+	// Act
+	err := ValidatePropertyName("firstName")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "snake_case") {
+		t.Errorf("ValidatePropertyName = %v, want an error naming snake_case", err)
+	}
+}
+
+func TestDefaultNameCasePolicyIsAny(t *testing.T) {
+	// Assert
+	if NameCasePolicy != NameCaseAny {
+		t.Errorf("NameCasePolicy = %q, want %q by default", NameCasePolicy, NameCaseAny)
+	}
+}